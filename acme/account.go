@@ -14,7 +14,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/containous/traefik/log"
 	acmeprovider "github.com/containous/traefik/provider/acme"
 	"github.com/containous/traefik/types"
 	"github.com/go-acme/lego/certcrypto"
@@ -48,7 +47,7 @@ func (a *Account) Init() error {
 
 	err = a.RemoveAccountV1Values()
 	if err != nil {
-		log.Errorf("Unable to remove ACME Account V1 values during account initialization: %v", err)
+		logger.Errorf("Unable to remove ACME Account V1 values during account initialization: %v", err)
 	}
 
 	for _, cert := range a.ChallengeCerts {
@@ -111,7 +110,7 @@ func (a *Account) GetPrivateKey() crypto.PrivateKey {
 		return privateKey
 	}
 
-	log.Errorf("Cannot unmarshall private key %+v", a.PrivateKey)
+	logger.Errorf("Cannot unmarshall private key %+v", a.PrivateKey)
 	return nil
 }
 
@@ -132,7 +131,7 @@ func (a *Account) RemoveAccountV1Values() error {
 }
 
 func (a *Account) reset() {
-	log.Debug("Reset ACME account object.")
+	logger.Debug("Reset ACME account object.")
 	a.Email = ""
 	a.Registration = nil
 	a.PrivateKey = nil
@@ -179,7 +178,7 @@ func (dc *DomainsCertificates) removeDuplicates() {
 		for i2 := i + 1; i2 < len(dc.Certs); i2++ {
 			if reflect.DeepEqual(dc.Certs[i].Domains, dc.Certs[i2].Domains) {
 				// delete
-				log.Warnf("Remove duplicate cert: %+v, expiration :%s", dc.Certs[i2].Domains, dc.Certs[i2].tlsCert.Leaf.NotAfter.String())
+				logger.Warnf("Remove duplicate cert: %+v, expiration :%s", dc.Certs[i2].Domains, dc.Certs[i2].tlsCert.Leaf.NotAfter.String())
 				dc.Certs = append(dc.Certs[:i2], dc.Certs[i2+1:]...)
 				i2--
 			}
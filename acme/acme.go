@@ -38,6 +38,8 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var logger = log.WithModule("acme")
+
 var (
 	// OSCPMustStaple enables OSCP stapling as from https://github.com/go-acme/lego/issues/270
 	OSCPMustStaple = false
@@ -103,7 +105,7 @@ func (a *ACME) AddRoutes(router *mux.Router) {
 			if token, ok := vars["token"]; ok {
 				domain, _, err := net.SplitHostPort(req.Host)
 				if err != nil {
-					log.Debugf("Unable to split host and port: %v. Fallback to request host.", err)
+					logger.Debugf("Unable to split host and port: %v. Fallback to request host.", err)
 					domain = req.Host
 				}
 				tokenValue := a.challengeHTTPProvider.getTokenValue(token, domain)
@@ -140,7 +142,7 @@ func (a *ACME) CreateClusterConfig(leadership *cluster.Leadership, tlsConfig *tl
 		if !leadership.IsLeader() {
 			a.client, err = a.buildACMEClient(account)
 			if err != nil {
-				log.Errorf("Error building ACME client %+v: %s", object, err.Error())
+				logger.Errorf("Error building ACME client %+v: %s", object, err.Error())
 			}
 		}
 		return nil
@@ -163,8 +165,8 @@ func (a *ACME) CreateClusterConfig(leadership *cluster.Leadership, tlsConfig *tl
 
 	ticker := time.NewTicker(24 * time.Hour)
 	leadership.Pool.AddGoCtx(func(ctx context.Context) {
-		log.Info("Starting ACME renew job...")
-		defer log.Info("Stopped ACME renew job...")
+		logger.Info("Starting ACME renew job...")
+		defer logger.Info("Stopped ACME renew job...")
 		for {
 			select {
 			case <-ctx.Done():
@@ -195,7 +197,7 @@ func (a *ACME) leadershipListener(elected bool) error {
 		account.Init()
 		// Reset Account values if caServer changed, thus registration URI can be updated
 		if account != nil && account.Registration != nil && !isAccountMatchingCaServer(account.Registration.URI, a.CAServer) {
-			log.Info("Account URI does not match the current CAServer. The account will be reset")
+			logger.Info("Account URI does not match the current CAServer. The account will be reset")
 			account.reset()
 		}
 
@@ -223,7 +225,7 @@ func (a *ACME) leadershipListener(elected bool) error {
 		}
 		if needRegister {
 			// New users will need to register; be sure to save it
-			log.Debug("Register...")
+			logger.Debug("Register...")
 
 			reg, err := a.client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
 			if err != nil {
@@ -248,12 +250,12 @@ func (a *ACME) leadershipListener(elected bool) error {
 func isAccountMatchingCaServer(accountURI string, serverURI string) bool {
 	aru, err := url.Parse(accountURI)
 	if err != nil {
-		log.Infof("Unable to parse account.Registration URL : %v", err)
+		logger.Infof("Unable to parse account.Registration URL : %v", err)
 		return false
 	}
 	cau, err := url.Parse(serverURI)
 	if err != nil {
-		log.Infof("Unable to parse CAServer URL : %v", err)
+		logger.Infof("Unable to parse CAServer URL : %v", err)
 		return false
 	}
 	return cau.Hostname() == aru.Hostname()
@@ -264,7 +266,7 @@ func (a *ACME) getCertificate(clientHello *tls.ClientHelloInfo) (*tls.Certificat
 	account := a.store.Get().(*Account)
 
 	if challengeCert, ok := a.challengeTLSProvider.getCertificate(domain); ok {
-		log.Debugf("ACME got challenge %s", domain)
+		logger.Debugf("ACME got challenge %s", domain)
 		return challengeCert, nil
 	}
 
@@ -273,7 +275,7 @@ func (a *ACME) getCertificate(clientHello *tls.ClientHelloInfo) (*tls.Certificat
 	}
 
 	if domainCert, ok := account.DomainsCertificate.getCertificateForDomain(domain); ok {
-		log.Debugf("ACME got domain cert %s", domain)
+		logger.Debugf("ACME got domain cert %s", domain)
 		return domainCert.tlsCert, nil
 	}
 
@@ -284,13 +286,13 @@ func (a *ACME) getCertificate(clientHello *tls.ClientHelloInfo) (*tls.Certificat
 		return a.loadCertificateOnDemand(clientHello)
 	}
 
-	log.Debugf("No certificate found or generated for %s", domain)
+	logger.Debugf("No certificate found or generated for %s", domain)
 	return nil, nil
 }
 
 func (a *ACME) retrieveCertificates() {
 	a.jobs.In() <- func() {
-		log.Info("Retrieving ACME certificates...")
+		logger.Info("Retrieving ACME certificates...")
 
 		a.deleteUnnecessaryDomains()
 
@@ -305,63 +307,63 @@ func (a *ACME) retrieveCertificates() {
 				domains = append(domains, domain.SANs...)
 				domains, err := a.getValidDomains(domains, true)
 				if err != nil {
-					log.Errorf("Error validating ACME certificate for domain %q: %s", domains, err)
+					logger.Errorf("Error validating ACME certificate for domain %q: %s", domains, err)
 					continue
 				}
 
 				certificateResource, err := a.getDomainsCertificates(domains)
 				if err != nil {
-					log.Errorf("Error getting ACME certificate for domain %q: %s", domains, err)
+					logger.Errorf("Error getting ACME certificate for domain %q: %s", domains, err)
 					continue
 				}
 
 				transaction, object, err := a.store.Begin()
 				if err != nil {
-					log.Errorf("Error creating ACME store transaction from domain %q: %s", domain, err)
+					logger.Errorf("Error creating ACME store transaction from domain %q: %s", domain, err)
 					continue
 				}
 
 				account = object.(*Account)
 				_, err = account.DomainsCertificate.addCertificateForDomains(certificateResource, domain)
 				if err != nil {
-					log.Errorf("Error adding ACME certificate for domain %q: %s", domains, err)
+					logger.Errorf("Error adding ACME certificate for domain %q: %s", domains, err)
 					continue
 				}
 
 				if err = transaction.Commit(account); err != nil {
-					log.Errorf("Error Saving ACME account %+v: %s", account, err)
+					logger.Errorf("Error Saving ACME account %+v: %s", account, err)
 					continue
 				}
 			}
 		}
 
-		log.Info("Retrieved ACME certificates")
+		logger.Info("Retrieved ACME certificates")
 	}
 }
 
 func (a *ACME) renewCertificates() {
 	a.jobs.In() <- func() {
-		log.Info("Testing certificate renew...")
+		logger.Info("Testing certificate renew...")
 		account := a.store.Get().(*Account)
 		for _, certificateResource := range account.DomainsCertificate.Certs {
 			if certificateResource.needRenew() {
-				log.Infof("Renewing certificate from LE : %+v", certificateResource.Domains)
+				logger.Infof("Renewing certificate from LE : %+v", certificateResource.Domains)
 				renewedACMECert, err := a.renewACMECertificate(certificateResource)
 				if err != nil {
-					log.Errorf("Error renewing certificate from LE: %v", err)
+					logger.Errorf("Error renewing certificate from LE: %v", err)
 					continue
 				}
 				operation := func() error {
 					return a.storeRenewedCertificate(certificateResource, renewedACMECert)
 				}
 				notify := func(err error, time time.Duration) {
-					log.Warnf("Renewed certificate storage error: %v, retrying in %s", err, time)
+					logger.Warnf("Renewed certificate storage error: %v, retrying in %s", err, time)
 				}
 				ebo := backoff.NewExponentialBackOff()
 				ebo.MaxElapsedTime = 60 * time.Second
 				err = backoff.RetryNotify(safe.OperationWithRecover(operation), ebo, notify)
 				if err != nil {
-					log.Errorf("Datastore cannot sync: %v", err)
+					logger.Errorf("Datastore cannot sync: %v", err)
 					continue
 				}
 			}
@@ -380,7 +382,7 @@ func (a *ACME) renewACMECertificate(certificateResource *DomainsCertificate) (*C
 	if err != nil {
 		return nil, err
 	}
-	log.Infof("Renewed certificate from  LE: %+v", certificateResource.Domains)
+	logger.Infof("Renewed certificate from  LE: %+v", certificateResource.Domains)
 	return &Certificate{
 		Domain:        renewedCert.Domain,
 		CertURL:       renewedCert.CertURL,
@@ -396,14 +398,14 @@ func (a *ACME) storeRenewedCertificate(certificateResource *DomainsCertificate,
 		return fmt.Errorf("error during transaction initialization for renewing certificate: %v", err)
 	}
 
-	log.Infof("Renewing certificate in data store : %+v ", certificateResource.Domains)
+	logger.Infof("Renewing certificate in data store : %+v ", certificateResource.Domains)
 	account := object.(*Account)
 	err = account.DomainsCertificate.renewCertificates(renewedACMECert, certificateResource.Domains)
 	if err != nil {
 		return fmt.Errorf("error renewing certificate in datastore: %v ", err)
 	}
 
-	log.Infof("Commit certificate renewed in data store : %+v", certificateResource.Domains)
+	logger.Infof("Commit certificate renewed in data store : %+v", certificateResource.Domains)
 	if err = transaction.Commit(account); err != nil {
 		return fmt.Errorf("error saving ACME account %+v: %v", account, err)
 	}
@@ -415,12 +417,12 @@ func (a *ACME) storeRenewedCertificate(certificateResource *DomainsCertificate,
 		}
 	}
 
-	log.Infof("Certificate successfully renewed in data store: %+v", certificateResource.Domains)
+	logger.Infof("Certificate successfully renewed in data store: %+v", certificateResource.Domains)
 	return nil
 }
 
 func (a *ACME) buildACMEClient(account *Account) (*lego.Client, error) {
-	log.Debug("Building ACME client...")
+	logger.Debug("Building ACME client...")
 	caServer := "https://acme-v02.api.letsencrypt.org/directory"
 	if len(a.CAServer) > 0 {
 		caServer = a.CAServer
@@ -438,7 +440,7 @@ func (a *ACME) buildACMEClient(account *Account) (*lego.Client, error) {
 
 	// DNS challenge
 	if a.DNSChallenge != nil && len(a.DNSChallenge.Provider) > 0 {
-		log.Debugf("Using DNS Challenge provider: %s", a.DNSChallenge.Provider)
+		logger.Debugf("Using DNS Challenge provider: %s", a.DNSChallenge.Provider)
 
 		var provider challenge.Provider
 		provider, err = dns.NewDNSChallengeProviderByName(a.DNSChallenge.Provider)
@@ -451,7 +453,7 @@ func (a *ACME) buildACMEClient(account *Account) (*lego.Client, error) {
 			dns01.CondOption(a.DNSChallenge.DisablePropagationCheck || a.DNSChallenge.DelayBeforeCheck > 0,
 				dns01.AddPreCheck(func(_, _ string) (bool, error) {
 					if a.DNSChallenge.DelayBeforeCheck > 0 {
-						log.Debugf("Delaying %d rather than validating DNS propagation now.", a.DNSChallenge.DelayBeforeCheck)
+						logger.Debugf("Delaying %d rather than validating DNS propagation now.", a.DNSChallenge.DelayBeforeCheck)
 						time.Sleep(time.Duration(a.DNSChallenge.DelayBeforeCheck))
 					}
 					return true, nil
@@ -462,7 +464,7 @@ func (a *ACME) buildACMEClient(account *Account) (*lego.Client, error) {
 
 	// HTTP challenge
 	if a.HTTPChallenge != nil && len(a.HTTPChallenge.EntryPoint) > 0 {
-		log.Debug("Using HTTP Challenge provider.")
+		logger.Debug("Using HTTP Challenge provider.")
 
 		a.challengeHTTPProvider = &challengeHTTPProvider{store: a.store}
 		err = client.Challenge.SetHTTP01Provider(a.challengeHTTPProvider)
@@ -471,7 +473,7 @@ func (a *ACME) buildACMEClient(account *Account) (*lego.Client, error) {
 
 	// TLS Challenge
 	if a.TLSChallenge != nil {
-		log.Debug("Using TLS Challenge provider.")
+		logger.Debug("Using TLS Challenge provider.")
 
 		err = client.Challenge.SetTLSALPN01Provider(a.challengeTLSProvider)
 		return client, err
@@ -490,7 +492,7 @@ func (a *ACME) loadCertificateOnDemand(clientHello *tls.ClientHelloInfo) (*tls.C
 	if err != nil {
 		return nil, err
 	}
-	log.Debugf("Got certificate on demand for domain %s", domain)
+	logger.Debugf("Got certificate on demand for domain %s", domain)
 
 	transaction, object, err := a.store.Begin()
 	if err != nil {
@@ -510,11 +512,11 @@ func (a *ACME) loadCertificateOnDemand(clientHello *tls.ClientHelloInfo) (*tls.C
 // LoadCertificateForDomains loads certificates from ACME for given domains
 func (a *ACME) LoadCertificateForDomains(domains []string) {
 	a.jobs.In() <- func() {
-		log.Debugf("LoadCertificateForDomains %v...", domains)
+		logger.Debugf("LoadCertificateForDomains %v...", domains)
 
 		domains, err := a.getValidDomains(domains, false)
 		if err != nil {
-			log.Errorf("Error getting valid domain: %v", err)
+			logger.Errorf("Error getting valid domain: %v", err)
 			return
 		}
 
@@ -525,13 +527,13 @@ func (a *ACME) LoadCertificateForDomains(domains []string) {
 			return nil
 		}
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Error getting ACME client: %v, retrying in %s", err, time)
+			logger.Errorf("Error getting ACME client: %v, retrying in %s", err, time)
 		}
 		ebo := backoff.NewExponentialBackOff()
 		ebo.MaxElapsedTime = 30 * time.Second
 		err = backoff.RetryNotify(safe.OperationWithRecover(operation), ebo, notify)
 		if err != nil {
-			log.Errorf("Error getting ACME client: %v", err)
+			logger.Errorf("Error getting ACME client: %v", err)
 			return
 		}
 		account := a.store.Get().(*Account)
@@ -547,14 +549,14 @@ func (a *ACME) LoadCertificateForDomains(domains []string) {
 
 		cert, err := a.getDomainsCertificates(uncheckedDomains)
 		if err != nil {
-			log.Errorf("Error getting ACME certificates %+v : %v", uncheckedDomains, err)
+			logger.Errorf("Error getting ACME certificates %+v : %v", uncheckedDomains, err)
 			return
 		}
-		log.Debugf("Got certificate for domains %+v", uncheckedDomains)
+		logger.Debugf("Got certificate for domains %+v", uncheckedDomains)
 		transaction, object, err := a.store.Begin()
 
 		if err != nil {
-			log.Errorf("Error creating transaction %+v : %v", uncheckedDomains, err)
+			logger.Errorf("Error creating transaction %+v : %v", uncheckedDomains, err)
 			return
 		}
 		var domain types.Domain
@@ -566,11 +568,11 @@ func (a *ACME) LoadCertificateForDomains(domains []string) {
 		account = object.(*Account)
 		_, err = account.DomainsCertificate.addCertificateForDomains(cert, domain)
 		if err != nil {
-			log.Errorf("Error adding ACME certificates %+v : %v", uncheckedDomains, err)
+			logger.Errorf("Error adding ACME certificates %+v : %v", uncheckedDomains, err)
 			return
 		}
 		if err = transaction.Commit(account); err != nil {
-			log.Errorf("Error Saving ACME account %+v: %v", account, err)
+			logger.Errorf("Error Saving ACME account %+v: %v", account, err)
 			return
 		}
 	}
@@ -597,13 +599,13 @@ func (a *ACME) removeResolvingDomains(resolvingDomains []string) {
 // Get provided certificate which check a domains list (Main and SANs)
 // from static and dynamic provided certificates
 func (a *ACME) getProvidedCertificate(domains string) *tls.Certificate {
-	log.Debugf("Looking for provided certificate to validate %s...", domains)
+	logger.Debugf("Looking for provided certificate to validate %s...", domains)
 	cert := searchProvidedCertificateForDomains(domains, a.TLSConfig.NameToCertificate)
 	if cert == nil && a.dynamicCerts != nil && a.dynamicCerts.Get() != nil {
 		cert = searchProvidedCertificateForDomains(domains, a.dynamicCerts.Get().(map[string]*tls.Certificate))
 	}
 	if cert == nil {
-		log.Debugf("No provided certificate found for domains %s, get ACME certificate.", domains)
+		logger.Debugf("No provided certificate found for domains %s, get ACME certificate.", domains)
 	}
 	return cert
 }
@@ -619,7 +621,7 @@ func searchProvidedCertificateForDomains(domain string, certs map[string]*tls.Ce
 			}
 		}
 		if domainChecked {
-			log.Debugf("Domain %q checked by provided certificate %q", domain, certDomains)
+			logger.Debugf("Domain %q checked by provided certificate %q", domain, certDomains)
 			return certs[certDomains]
 		}
 	}
@@ -632,7 +634,7 @@ func (a *ACME) getUncheckedDomains(domains []string, account *Account) []string
 	a.resolvingDomainsMutex.RLock()
 	defer a.resolvingDomainsMutex.RUnlock()
 
-	log.Debugf("Looking for provided certificate to validate %s...", domains)
+	logger.Debugf("Looking for provided certificate to validate %s...", domains)
 	allCerts := make(map[string]*tls.Certificate)
 
 	// Get static certificates
@@ -681,9 +683,9 @@ func searchUncheckedDomains(domains []string, certs map[string]*tls.Certificate)
 	}
 
 	if len(uncheckedDomains) == 0 {
-		log.Debugf("No ACME certificate to generate for domains %q.", domains)
+		logger.Debugf("No ACME certificate to generate for domains %q.", domains)
 	} else {
-		log.Debugf("Domains %q need ACME certificates generation for domains %q.", domains, strings.Join(uncheckedDomains, ","))
+		logger.Debugf("Domains %q need ACME certificates generation for domains %q.", domains, strings.Join(uncheckedDomains, ","))
 	}
 	return uncheckedDomains
 }
@@ -694,12 +696,12 @@ func (a *ACME) getDomainsCertificates(domains []string) (*Certificate, error) {
 		canonicalDomain := types.CanonicalDomain(domain)
 		cleanDomain := dns01.UnFqdn(canonicalDomain)
 		if canonicalDomain != cleanDomain {
-			log.Warnf("FQDN detected, please remove the trailing dot: %s", canonicalDomain)
+			logger.Warnf("FQDN detected, please remove the trailing dot: %s", canonicalDomain)
 		}
 		cleanDomains = append(cleanDomains, cleanDomain)
 	}
 
-	log.Debugf("Loading ACME certificates %s...", cleanDomains)
+	logger.Debugf("Loading ACME certificates %s...", cleanDomains)
 	bundle := true
 
 	request := certificate.ObtainRequest{
@@ -713,7 +715,7 @@ func (a *ACME) getDomainsCertificates(domains []string) (*Certificate, error) {
 		return nil, fmt.Errorf("cannot obtain certificates: %+v", err)
 	}
 
-	log.Debugf("Loaded ACME certificates %s", cleanDomains)
+	logger.Debugf("Loaded ACME certificates %s", cleanDomains)
 	return &Certificate{
 		Domain:        cert.Domain,
 		CertURL:       cert.CertURL,
@@ -783,7 +785,7 @@ func (a *ACME) deleteUnnecessaryDomains() {
 
 			if reflect.DeepEqual(domain, domainToCheck) {
 				if idxDomainToCheck > idxDomain {
-					log.Warnf("The domain %v is duplicated in the configuration but will be process by ACME only once.", domainToCheck)
+					logger.Warnf("The domain %v is duplicated in the configuration but will be process by ACME only once.", domainToCheck)
 					keepDomain = false
 				}
 				break
@@ -801,11 +803,11 @@ func (a *ACME) deleteUnnecessaryDomains() {
 			for _, domainProcessed := range domainToCheck.ToStrArray() {
 				if idxDomain < idxDomainToCheck && isDomainAlreadyChecked(domainProcessed, domainsMap) {
 					// The domain is duplicated in a CN
-					log.Warnf("Domain %q is duplicated in the configuration or validated by the domain %v. It will be processed once.", domainProcessed, domain)
+					logger.Warnf("Domain %q is duplicated in the configuration or validated by the domain %v. It will be processed once.", domainProcessed, domain)
 					continue
 				} else if domain.Main != domainProcessed && strings.HasPrefix(domain.Main, "*") && types.MatchDomain(domainProcessed, domain.Main) {
 					// Check if a wildcard can validate the domain
-					log.Warnf("Domain %q will not be processed by ACME provider because it is validated by the wildcard %q", domainProcessed, domain.Main)
+					logger.Warnf("Domain %q will not be processed by ACME provider because it is validated by the wildcard %q", domainProcessed, domain.Main)
 					continue
 				}
 				newDomainsToCheck = append(newDomainsToCheck, domainProcessed)
@@ -7,7 +7,6 @@ import (
 
 	"github.com/cenk/backoff"
 	"github.com/containous/traefik/cluster"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/go-acme/lego/challenge"
 )
@@ -20,7 +19,7 @@ type challengeHTTPProvider struct {
 }
 
 func (c *challengeHTTPProvider) getTokenValue(token, domain string) []byte {
-	log.Debugf("Looking for an existing ACME challenge for token %v...", token)
+	logger.Debugf("Looking for an existing ACME challenge for token %v...", token)
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
@@ -39,21 +38,21 @@ func (c *challengeHTTPProvider) getTokenValue(token, domain string) []byte {
 	}
 
 	notify := func(err error, time time.Duration) {
-		log.Errorf("Error getting challenge for token retrying in %s", time)
+		logger.Errorf("Error getting challenge for token retrying in %s", time)
 	}
 
 	ebo := backoff.NewExponentialBackOff()
 	ebo.MaxElapsedTime = 60 * time.Second
 	err := backoff.RetryNotify(safe.OperationWithRecover(operation), ebo, notify)
 	if err != nil {
-		log.Errorf("Error getting challenge for token: %v", err)
+		logger.Errorf("Error getting challenge for token: %v", err)
 		return []byte{}
 	}
 	return result
 }
 
 func (c *challengeHTTPProvider) Present(domain, token, keyAuth string) error {
-	log.Debugf("Challenge Present %s", domain)
+	logger.Debugf("Challenge Present %s", domain)
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -77,7 +76,7 @@ func (c *challengeHTTPProvider) Present(domain, token, keyAuth string) error {
 }
 
 func (c *challengeHTTPProvider) CleanUp(domain, token, keyAuth string) error {
-	log.Debugf("Challenge CleanUp %s", domain)
+	logger.Debugf("Challenge CleanUp %s", domain)
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
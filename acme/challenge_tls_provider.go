@@ -9,7 +9,6 @@ import (
 
 	"github.com/cenk/backoff"
 	"github.com/containous/traefik/cluster"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/go-acme/lego/challenge"
 	"github.com/go-acme/lego/challenge/tlsalpn01"
@@ -23,7 +22,7 @@ type challengeTLSProvider struct {
 }
 
 func (c *challengeTLSProvider) getCertificate(domain string) (cert *tls.Certificate, exists bool) {
-	log.Debugf("Looking for an existing ACME challenge for %s...", domain)
+	logger.Debugf("Looking for an existing ACME challenge for %s...", domain)
 
 	if !strings.HasSuffix(domain, ".acme.invalid") {
 		return nil, false
@@ -53,14 +52,14 @@ func (c *challengeTLSProvider) getCertificate(domain string) (cert *tls.Certific
 	}
 
 	notify := func(err error, time time.Duration) {
-		log.Errorf("Error getting cert: %v, retrying in %s", err, time)
+		logger.Errorf("Error getting cert: %v, retrying in %s", err, time)
 	}
 	ebo := backoff.NewExponentialBackOff()
 	ebo.MaxElapsedTime = 60 * time.Second
 
 	err := backoff.RetryNotify(safe.OperationWithRecover(operation), ebo, notify)
 	if err != nil {
-		log.Errorf("Error getting cert: %v", err)
+		logger.Errorf("Error getting cert: %v", err)
 		return nil, false
 
 	}
@@ -68,7 +67,7 @@ func (c *challengeTLSProvider) getCertificate(domain string) (cert *tls.Certific
 }
 
 func (c *challengeTLSProvider) Present(domain, token, keyAuth string) error {
-	log.Debugf("Challenge Present %s", domain)
+	logger.Debugf("Challenge Present %s", domain)
 
 	cert, err := tlsALPN01ChallengeCert(domain, keyAuth)
 	if err != nil {
@@ -93,7 +92,7 @@ func (c *challengeTLSProvider) Present(domain, token, keyAuth string) error {
 }
 
 func (c *challengeTLSProvider) CleanUp(domain, token, keyAuth string) error {
-	log.Debugf("Challenge CleanUp %s", domain)
+	logger.Debugf("Challenge CleanUp %s", domain)
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -5,7 +5,6 @@ import (
 	"io/ioutil"
 	"os"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider/acme"
 )
 
@@ -56,13 +55,13 @@ func ConvertToNewFormat(fileName string) {
 
 	storeAccount, err := localStore.GetAccount()
 	if err != nil {
-		log.Errorf("Failed to read new account, ACME data conversion is not available : %v", err)
+		logger.Errorf("Failed to read new account, ACME data conversion is not available : %v", err)
 		return
 	}
 
 	storeCertificates, err := localStore.GetCertificates()
 	if err != nil {
-		log.Errorf("Failed to read new certificates, ACME data conversion is not available : %v", err)
+		logger.Errorf("Failed to read new certificates, ACME data conversion is not available : %v", err)
 		return
 	}
 
@@ -71,7 +70,7 @@ func ConvertToNewFormat(fileName string) {
 
 		account, err := localStore.Get()
 		if err != nil {
-			log.Errorf("Failed to read old account, ACME data conversion is not available : %v", err)
+			logger.Errorf("Failed to read old account, ACME data conversion is not available : %v", err)
 			return
 		}
 
@@ -80,13 +79,13 @@ func ConvertToNewFormat(fileName string) {
 		if account != nil && len(account.Email) > 0 {
 			err = backupACMEFile(fileName, account)
 			if err != nil {
-				log.Errorf("Unable to create a backup for the V1 formatted ACME file: %v", err)
+				logger.Errorf("Unable to create a backup for the V1 formatted ACME file: %v", err)
 				return
 			}
 
 			err = account.RemoveAccountV1Values()
 			if err != nil {
-				log.Errorf("Unable to remove ACME Account V1 values during format conversion: %v", err)
+				logger.Errorf("Unable to remove ACME Account V1 values during format conversion: %v", err)
 				return
 			}
 
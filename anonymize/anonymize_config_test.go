@@ -22,11 +22,13 @@ import (
 	"github.com/containous/traefik/provider/etcd"
 	"github.com/containous/traefik/provider/eureka"
 	"github.com/containous/traefik/provider/file"
+	httpprovider "github.com/containous/traefik/provider/http"
 	"github.com/containous/traefik/provider/kubernetes"
 	"github.com/containous/traefik/provider/kv"
 	"github.com/containous/traefik/provider/marathon"
 	"github.com/containous/traefik/provider/mesos"
 	"github.com/containous/traefik/provider/rancher"
+	"github.com/containous/traefik/provider/redis"
 	"github.com/containous/traefik/provider/zk"
 	"github.com/containous/traefik/safe"
 	traefiktls "github.com/containous/traefik/tls"
@@ -471,6 +473,32 @@ func TestDo_globalConfiguration(t *testing.T) {
 		Delay:          flaeg.Duration(30 * time.Second),
 		RefreshSeconds: flaeg.Duration(30 * time.Second),
 	}
+	config.HTTP = &httpprovider.Provider{
+		BaseProvider: provider.BaseProvider{
+			Watch:    true,
+			Filename: "http Filename",
+			Constraints: types.Constraints{
+				{
+					Key:       "http Constraints Key 1",
+					Regex:     "http Constraints Regex 2",
+					MustMatch: true,
+				},
+			},
+			Trace:                     true,
+			DebugLogGeneratedTemplate: true,
+		},
+		Endpoint:        "http Endpoint",
+		PollInterval:    flaeg.Duration(5 * time.Second),
+		PollTimeout:     flaeg.Duration(5 * time.Second),
+		LongPollTimeout: flaeg.Duration(30 * time.Second),
+		TLS: &types.ClientTLS{
+			CA:                 "http CA",
+			Cert:               "http Cert",
+			Key:                "http Key",
+			InsecureSkipVerify: true,
+		},
+		Headers: types.KeyValues{"Authorization": "http Authorization"},
+	}
 	config.ECS = &ecs.Provider{
 		BaseProvider: provider.BaseProvider{
 			Watch:    true,
@@ -660,6 +688,38 @@ func TestDo_globalConfiguration(t *testing.T) {
 			Password: "boltdb Password",
 		},
 	}
+	config.Redis = &redis.Provider{
+		Provider: kv.Provider{
+			BaseProvider: provider.BaseProvider{
+				Watch:    true,
+				Filename: "redis Filename",
+				Constraints: types.Constraints{
+					{
+						Key:       "redis Constraints Key 1",
+						Regex:     "redis Constraints Regex 2",
+						MustMatch: true,
+					},
+					{
+						Key:       "redis Constraints Key 1",
+						Regex:     "redis Constraints Regex 2",
+						MustMatch: true,
+					},
+				},
+				Trace:                     true,
+				DebugLogGeneratedTemplate: true,
+			},
+			Endpoint: "redis Endpoint",
+			Prefix:   "redis Prefix",
+			TLS: &types.ClientTLS{
+				CA:                 "redis CA",
+				Cert:               "redis Cert",
+				Key:                "redis Key",
+				InsecureSkipVerify: true,
+			},
+			Username: "redis Username",
+			Password: "redis Password",
+		},
+	}
 	config.Consul = &consul.Provider{
 		Provider: kv.Provider{
 			BaseProvider: provider.BaseProvider{
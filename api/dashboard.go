@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/containous/mux"
-	"github.com/containous/traefik/log"
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 )
 
@@ -16,7 +15,7 @@ type DashboardHandler struct {
 // AddRoutes add dashboard routes on a router
 func (g DashboardHandler) AddRoutes(router *mux.Router) {
 	if g.Assets == nil {
-		log.Error("No assets for dashboard")
+		logger.Error("No assets for dashboard")
 		return
 	}
 
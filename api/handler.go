@@ -14,6 +14,8 @@ import (
 	"github.com/unrolled/render"
 )
 
+var logger = log.WithModule("api")
+
 // Handler expose api routes
 type Handler struct {
 	EntryPoint            string `description:"EntryPoint" export:"true"`
@@ -53,6 +55,16 @@ func (p Handler) AddRoutes(router *mux.Router) {
 	// health route
 	router.Methods(http.MethodGet).Path("/health").HandlerFunc(p.getHealthHandler)
 
+	// log levels routes
+	router.Methods(http.MethodGet).Path("/api/log/levels").HandlerFunc(p.getLogLevelsHandler)
+	router.Methods(http.MethodPut).Path("/api/log/levels").HandlerFunc(p.putLogLevelsHandler)
+
+	// requests inspection routes
+	router.Methods(http.MethodGet).Path("/api/requests/recent").HandlerFunc(p.getRecentRequestsHandler)
+	router.Methods(http.MethodGet).Path("/api/requests/recent/{frontend}").HandlerFunc(p.getFrontendRecentRequestsHandler)
+	router.Methods(http.MethodGet).Path("/api/requests/inflight").HandlerFunc(p.getInFlightRequestsHandler)
+	router.Methods(http.MethodGet).Path("/api/requests/stream").HandlerFunc(p.getRequestsStreamHandler)
+
 	version.Handler{}.AddRoutes(router)
 
 	if p.Dashboard {
@@ -73,7 +85,7 @@ func (p Handler) getConfigHandler(response http.ResponseWriter, request *http.Re
 	currentConfigurations := p.CurrentConfigurations.Get().(types.Configurations)
 	err := templatesRenderer.JSON(response, http.StatusOK, currentConfigurations)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 }
 
@@ -84,7 +96,7 @@ func (p Handler) getProviderHandler(response http.ResponseWriter, request *http.
 	if provider, ok := currentConfigurations[providerID]; ok {
 		err := templatesRenderer.JSON(response, http.StatusOK, provider)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 		}
 	} else {
 		http.NotFound(response, request)
@@ -98,7 +110,7 @@ func (p Handler) getBackendsHandler(response http.ResponseWriter, request *http.
 	if provider, ok := currentConfigurations[providerID]; ok {
 		err := templatesRenderer.JSON(response, http.StatusOK, provider.Backends)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 		}
 	} else {
 		http.NotFound(response, request)
@@ -115,7 +127,7 @@ func (p Handler) getBackendHandler(response http.ResponseWriter, request *http.R
 		if backend, ok := provider.Backends[backendID]; ok {
 			err := templatesRenderer.JSON(response, http.StatusOK, backend)
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 			}
 			return
 		}
@@ -133,7 +145,7 @@ func (p Handler) getServersHandler(response http.ResponseWriter, request *http.R
 		if backend, ok := provider.Backends[backendID]; ok {
 			err := templatesRenderer.JSON(response, http.StatusOK, backend.Servers)
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 			}
 			return
 		}
@@ -153,7 +165,7 @@ func (p Handler) getServerHandler(response http.ResponseWriter, request *http.Re
 			if server, ok := backend.Servers[serverID]; ok {
 				err := templatesRenderer.JSON(response, http.StatusOK, server)
 				if err != nil {
-					log.Error(err)
+					logger.Error(err)
 				}
 				return
 			}
@@ -169,7 +181,7 @@ func (p Handler) getFrontendsHandler(response http.ResponseWriter, request *http
 	if provider, ok := currentConfigurations[providerID]; ok {
 		err := templatesRenderer.JSON(response, http.StatusOK, provider.Frontends)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 		}
 	} else {
 		http.NotFound(response, request)
@@ -186,7 +198,7 @@ func (p Handler) getFrontendHandler(response http.ResponseWriter, request *http.
 		if frontend, ok := provider.Frontends[frontendID]; ok {
 			err := templatesRenderer.JSON(response, http.StatusOK, frontend)
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 			}
 			return
 		}
@@ -204,7 +216,7 @@ func (p Handler) getRoutesHandler(response http.ResponseWriter, request *http.Re
 		if frontend, ok := provider.Frontends[frontendID]; ok {
 			err := templatesRenderer.JSON(response, http.StatusOK, frontend.Routes)
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 			}
 			return
 		}
@@ -224,7 +236,7 @@ func (p Handler) getRouteHandler(response http.ResponseWriter, request *http.Req
 			if route, ok := frontend.Routes[routeID]; ok {
 				err := templatesRenderer.JSON(response, http.StatusOK, route)
 				if err != nil {
-					log.Error(err)
+					logger.Error(err)
 				}
 				return
 			}
@@ -247,6 +259,6 @@ func (p *Handler) getHealthHandler(response http.ResponseWriter, request *http.R
 	}
 	err := templatesRenderer.JSON(response, http.StatusOK, health)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 }
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/containous/traefik/log"
+	"github.com/sirupsen/logrus"
+)
+
+// logLevels is the representation of the log levels in the API.
+type logLevels struct {
+	Level   string            `json:"level,omitempty"`
+	Modules map[string]string `json:"modules"`
+}
+
+func currentLogLevels() logLevels {
+	levels := logLevels{
+		Level:   strings.ToUpper(log.GetLevel().String()),
+		Modules: make(map[string]string),
+	}
+	for module, level := range log.GetModuleLevels() {
+		levels.Modules[module] = strings.ToUpper(level.String())
+	}
+	return levels
+}
+
+func (p Handler) getLogLevelsHandler(response http.ResponseWriter, request *http.Request) {
+	err := templatesRenderer.JSON(response, http.StatusOK, currentLogLevels())
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// putLogLevelsHandler changes the global log level, when given, and replaces the log levels of the modules, when given.
+func (p Handler) putLogLevelsHandler(response http.ResponseWriter, request *http.Request) {
+	levels := logLevels{}
+	if err := json.NewDecoder(request.Body).Decode(&levels); err != nil {
+		http.Error(response, fmt.Sprintf("invalid log levels: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var level logrus.Level
+	if levels.Level != "" {
+		var err error
+		level, err = logrus.ParseLevel(strings.ToLower(levels.Level))
+		if err != nil {
+			http.Error(response, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	moduleLevels := make(map[string]logrus.Level, len(levels.Modules))
+	for module, moduleLevel := range levels.Modules {
+		parsed, err := logrus.ParseLevel(strings.ToLower(moduleLevel))
+		if err != nil {
+			http.Error(response, fmt.Sprintf("invalid level of module %s: %v", module, err), http.StatusBadRequest)
+			return
+		}
+		moduleLevels[module] = parsed
+	}
+
+	if levels.Level != "" {
+		log.SetLevel(level)
+		logger.Infof("Log level changed to %s", levels.Level)
+	}
+	if levels.Modules != nil {
+		log.SetModuleLevels(moduleLevels)
+		logger.Infof("Log levels of the modules changed to %v", levels.Modules)
+	}
+
+	err := templatesRenderer.JSON(response, http.StatusOK, currentLogLevels())
+	if err != nil {
+		logger.Error(err)
+	}
+}
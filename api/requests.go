@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/containous/mux"
+)
+
+func (p Handler) getRecentRequestsHandler(response http.ResponseWriter, request *http.Request) {
+	if !p.StatsRecorder.InspectionEnabled() {
+		http.NotFound(response, request)
+		return
+	}
+
+	err := templatesRenderer.JSON(response, http.StatusOK, p.StatsRecorder.RecentRequests())
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+func (p Handler) getFrontendRecentRequestsHandler(response http.ResponseWriter, request *http.Request) {
+	if !p.StatsRecorder.InspectionEnabled() {
+		http.NotFound(response, request)
+		return
+	}
+
+	frontendID := mux.Vars(request)["frontend"]
+
+	if requests, ok := p.StatsRecorder.RecentRequests()[frontendID]; ok {
+		err := templatesRenderer.JSON(response, http.StatusOK, requests)
+		if err != nil {
+			logger.Error(err)
+		}
+		return
+	}
+	http.NotFound(response, request)
+}
+
+func (p Handler) getInFlightRequestsHandler(response http.ResponseWriter, request *http.Request) {
+	if !p.StatsRecorder.InspectionEnabled() {
+		http.NotFound(response, request)
+		return
+	}
+
+	err := templatesRenderer.JSON(response, http.StatusOK, p.StatsRecorder.InFlightRequests())
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// getRequestsStreamHandler streams the served requests as server-sent events,
+// optionally only the requests of the frontend given by the frontend query parameter.
+func (p Handler) getRequestsStreamHandler(response http.ResponseWriter, request *http.Request) {
+	if !p.StatsRecorder.InspectionEnabled() {
+		http.NotFound(response, request)
+		return
+	}
+
+	flusher, ok := response.(http.Flusher)
+	if !ok {
+		http.Error(response, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	frontendID := request.URL.Query().Get("frontend")
+
+	requests, unsubscribe := p.StatsRecorder.SubscribeRequests()
+	defer unsubscribe()
+
+	response.Header().Set("Content-Type", "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case inspected := <-requests:
+			if frontendID != "" && inspected.Frontend != frontendID {
+				continue
+			}
+
+			data, err := json.Marshal(inspected)
+			if err != nil {
+				logger.Error(err)
+				continue
+			}
+
+			if _, err := fmt.Fprintf(response, "event: request\nid: %d\ndata: %s\n\n", inspected.ID, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
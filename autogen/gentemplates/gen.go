@@ -195,6 +195,16 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{if $service.TLS }}
+  [backends."backend-{{ $backendName }}".tls]
+    ca = """{{ $service.TLS.CA }}"""
+    cert = """{{ $service.TLS.Cert }}"""
+    key = """{{ $service.TLS.Key }}"""
+    trustedURIs = [{{range $service.TLS.TrustedURIs }}
+      "{{.}}",
+      {{end}}]
+  {{end}}
+
 {{end}}
 {{range $index, $node := .Nodes}}
   {{ $server := getServer $node }}
@@ -318,6 +328,15 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
       permanent = {{ $redirect.Permanent }}
     {{end}}
 
+    {{ $accessLog := getAccessLog $service.TraefikLabels }}
+    {{if $accessLog }}
+    [frontends."frontend-{{ $service.ServiceName }}".accessLog]
+      disabled = {{ $accessLog.Disabled }}
+      verbose = {{ $accessLog.Verbose }}
+      sampleRate = {{ $accessLog.SampleRate }}
+      filePath = "{{ $accessLog.FilePath }}"
+    {{end}}
+
     {{ $errorPages := getErrorPages $service.TraefikLabels }}
     {{if $errorPages }}
     [frontends."frontend-{{ $service.ServiceName }}".errors]
@@ -813,6 +832,15 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
       permanent = {{ $redirect.Permanent }}
     {{end}}
 
+    {{ $accessLog := getAccessLog $container.SegmentLabels }}
+    {{if $accessLog }}
+    [frontends."frontend-{{ $frontendName }}".accessLog]
+      disabled = {{ $accessLog.Disabled }}
+      verbose = {{ $accessLog.Verbose }}
+      sampleRate = {{ $accessLog.SampleRate }}
+      filePath = "{{ $accessLog.FilePath }}"
+    {{end}}
+
     {{ $errorPages := getErrorPages $container.SegmentLabels }}
     {{if $errorPages }}
     [frontends."frontend-{{ $frontendName }}".errors]
@@ -1161,6 +1189,15 @@ var _templatesEcsTmpl = []byte(`[backends]
       permanent = {{ $redirect.Permanent }}
     {{end}}
 
+    {{ $accessLog := getAccessLog $instance.SegmentLabels }}
+    {{if $accessLog }}
+    [frontends."frontend-{{ $frontendName }}".accessLog]
+      disabled = {{ $accessLog.Disabled }}
+      verbose = {{ $accessLog.Verbose }}
+      sampleRate = {{ $accessLog.SampleRate }}
+      filePath = "{{ $accessLog.FilePath }}"
+    {{end}}
+
     {{ $errorPages := getErrorPages $instance.SegmentLabels }}
     {{if $errorPages }}
     [frontends."frontend-{{ $frontendName }}".errors]
@@ -1511,6 +1548,12 @@ var _templatesKubernetesTmpl = []byte(`[backends]
       {{ $k }} = "{{ $v }}"
       {{end}}
     {{end}}
+    {{if $frontend.Headers.AppendRequestHeaders }}
+    [frontends."{{ $frontendName }}".headers.appendRequestHeaders]
+      {{range $k, $v := $frontend.Headers.AppendRequestHeaders }}
+      {{ $k }} = "{{ $v }}"
+      {{end}}
+    {{end}}
     {{if $frontend.Headers.CustomResponseHeaders }}
     [frontends."{{ $frontendName }}".headers.customResponseHeaders]
       {{range $k, $v := $frontend.Headers.CustomResponseHeaders }}
@@ -1537,10 +1580,41 @@ var _templatesKubernetesTmpl = []byte(`[backends]
   entryPoints = [{{range $tls.EntryPoints }}
     "{{.}}",
     {{end}}]
+  default = {{ $tls.Default }}
   [tls.certificate]
     certFile = """{{ $tls.Certificate.CertFile }}"""
     keyFile = """{{ $tls.Certificate.KeyFile }}"""
 {{end}}
+
+{{range $clientCA := .ClientCAs }}
+[[clientCAs]]
+  entryPoints = [{{range $clientCA.EntryPoints }}
+    "{{.}}",
+    {{end}}]
+  files = [{{range $clientCA.Files }}
+    """{{.}}""",
+    {{end}}]
+{{end}}
+
+{{range $options := .TLSOptions }}
+[[tlsOptions]]
+  entryPoints = [{{range $options.EntryPoints }}
+    "{{.}}",
+    {{end}}]
+  domains = [{{range $options.Domains }}
+    "{{.}}",
+    {{end}}]
+  minVersion = "{{ $options.MinVersion }}"
+  maxVersion = "{{ $options.MaxVersion }}"
+  cipherSuites = [{{range $options.CipherSuites }}
+    "{{.}}",
+    {{end}}]
+  [tlsOptions.clientCA]
+    optional = {{ $options.ClientCA.Optional }}
+    files = [{{range $options.ClientCA.Files }}
+      """{{.}}""",
+      {{end}}]
+{{end}}
 `)
 
 func templatesKubernetesTmplBytes() ([]byte, error) {
@@ -1835,11 +1909,24 @@ var _templatesKvTmpl = []byte(`[backends]
   entryPoints = [{{range $tls.EntryPoints }}
     "{{.}}",
     {{end}}]
+  default = {{ $tls.Default }}
 
   [tls.certificate]
     certFile = """{{ $tls.Certificate.CertFile }}"""
     keyFile = """{{ $tls.Certificate.KeyFile }}"""
 
+{{end}}
+
+{{range $clientCA := getClientCAsSection .Prefix }}
+[[clientCAs]]
+
+  entryPoints = [{{range $clientCA.EntryPoints }}
+    "{{.}}",
+    {{end}}]
+  files = [{{range $clientCA.Files }}
+    """{{.}}""",
+    {{end}}]
+
 {{end}}
 `)
 
@@ -2129,6 +2216,15 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
       permanent = {{ $redirect.Permanent }}
     {{end}}
 
+    {{ $accessLog := getAccessLog $app.SegmentLabels }}
+    {{if $accessLog }}
+    [frontends."{{ $frontendName }}".accessLog]
+      disabled = {{ $accessLog.Disabled }}
+      verbose = {{ $accessLog.Verbose }}
+      sampleRate = {{ $accessLog.SampleRate }}
+      filePath = "{{ $accessLog.FilePath }}"
+    {{end}}
+
     {{ $errorPages := getErrorPages $app.SegmentLabels }}
     {{if $errorPages }}
     [frontends."{{ $frontendName }}".errors]
@@ -2461,6 +2557,15 @@ var _templatesMesosTmpl = []byte(`[backends]
       permanent = {{ $redirect.Permanent }}
     {{end}}
 
+    {{ $accessLog := getAccessLog $app.TraefikLabels }}
+    {{if $accessLog }}
+    [frontends."frontend-{{ $frontendName }}".accessLog]
+      disabled = {{ $accessLog.Disabled }}
+      verbose = {{ $accessLog.Verbose }}
+      sampleRate = {{ $accessLog.SampleRate }}
+      filePath = "{{ $accessLog.FilePath }}"
+    {{end}}
+
     {{ $errorPages := getErrorPages $app.TraefikLabels }}
     {{if $errorPages }}
     [frontends."frontend-{{ $frontendName }}".errors]
@@ -2846,6 +2951,15 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
       permanent = {{ $redirect.Permanent }}
     {{end}}
 
+    {{ $accessLog := getAccessLog $service.SegmentLabels }}
+    {{if $accessLog }}
+    [frontends."frontend-{{ $frontendName }}".accessLog]
+      disabled = {{ $accessLog.Disabled }}
+      verbose = {{ $accessLog.Verbose }}
+      sampleRate = {{ $accessLog.SampleRate }}
+      filePath = "{{ $accessLog.FilePath }}"
+    {{end}}
+
     {{ $errorPages := getErrorPages $service.SegmentLabels }}
     {{if $errorPages }}
     [frontends."frontend-{{ $frontendName }}".errors]
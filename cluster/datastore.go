@@ -11,7 +11,6 @@ import (
 	"github.com/cenk/backoff"
 	"github.com/containous/staert"
 	"github.com/containous/traefik/job"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/google/uuid"
 )
@@ -100,25 +99,25 @@ func (d *Datastore) watchChanges() error {
 					if d.listener != nil {
 						err := d.listener(d.meta.object)
 						if err != nil {
-							log.Errorf("Error calling datastore listener: %s", err)
+							logger.Errorf("Error calling datastore listener: %s", err)
 						}
 					}
 				}
 			}
 		}
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Error in watch datastore: %+v, retrying in %s", err, time)
+			logger.Errorf("Error in watch datastore: %+v, retrying in %s", err, time)
 		}
 		err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 		if err != nil {
-			log.Errorf("Error in watch datastore: %v", err)
+			logger.Errorf("Error in watch datastore: %v", err)
 		}
 	})
 	return nil
 }
 
 func (d *Datastore) reload() error {
-	log.Debug("Datastore reload")
+	logger.Debug("Datastore reload")
 	_, err := d.Load()
 	return err
 }
@@ -126,7 +125,7 @@ func (d *Datastore) reload() error {
 // Begin creates a transaction with the KV store.
 func (d *Datastore) Begin() (Transaction, Object, error) {
 	id := uuid.New().String()
-	log.Debugf("Transaction %s begins", id)
+	logger.Debugf("Transaction %s begins", id)
 	remoteLock, err := d.kv.NewLock(d.lockKey, &store.LockOptions{TTL: 20 * time.Second, Value: []byte(id)})
 	if err != nil {
 		return nil, nil, err
@@ -157,10 +156,10 @@ func (d *Datastore) Begin() (Transaction, Object, error) {
 		return nil
 	}
 	notify := func(err error, time time.Duration) {
-		log.Errorf("Datastore sync error: %v, retrying in %s", err, time)
+		logger.Errorf("Datastore sync error: %v, retrying in %s", err, time)
 		err = d.reload()
 		if err != nil {
-			log.Errorf("Error reloading: %+v", err)
+			logger.Errorf("Error reloading: %+v", err)
 		}
 	}
 	ebo := backoff.NewExponentialBackOff()
@@ -242,6 +241,6 @@ func (s *datastoreTransaction) Commit(object Object) error {
 	}
 
 	s.dirty = true
-	log.Debugf("Transaction committed %s", s.id)
+	logger.Debugf("Transaction committed %s", s.id)
 	return nil
 }
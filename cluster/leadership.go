@@ -14,6 +14,8 @@ import (
 	"github.com/unrolled/render"
 )
 
+var logger = log.WithModule("cluster")
+
 const clusterLeaderKeySuffix = "/leader"
 
 var templatesRenderer = render.New(render.Options{
@@ -46,19 +48,19 @@ type LeaderListener func(elected bool) error
 // Participate tries to be a leader
 func (l *Leadership) Participate(pool *safe.Pool) {
 	pool.GoCtx(func(ctx context.Context) {
-		log.Debugf("Node %s running for election", l.Cluster.Node)
-		defer log.Debugf("Node %s no more running for election", l.Cluster.Node)
+		logger.Debugf("Node %s running for election", l.Cluster.Node)
+		defer logger.Debugf("Node %s no more running for election", l.Cluster.Node)
 		backOff := backoff.NewExponentialBackOff()
 		operation := func() error {
 			return l.run(ctx, l.candidate)
 		}
 
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Leadership election error %+v, retrying in %s", err, time)
+			logger.Errorf("Leadership election error %+v, retrying in %s", err, time)
 		}
 		err := backoff.RetryNotify(safe.OperationWithRecover(operation), backOff, notify)
 		if err != nil {
-			log.Errorf("Cannot elect leadership %+v", err)
+			logger.Errorf("Cannot elect leadership %+v", err)
 		}
 	})
 }
@@ -71,7 +73,7 @@ func (l *Leadership) AddListener(listener LeaderListener) {
 // Resign resigns from being a leader
 func (l *Leadership) Resign() {
 	l.candidate.Resign()
-	log.Infof("Node %s resigned", l.Cluster.Node)
+	logger.Infof("Node %s resigned", l.Cluster.Node)
 }
 
 func (l *Leadership) run(ctx context.Context, candidate *leadership.Candidate) error {
@@ -91,18 +93,18 @@ func (l *Leadership) run(ctx context.Context, candidate *leadership.Candidate) e
 
 func (l *Leadership) onElection(elected bool) {
 	if elected {
-		log.Infof("Node %s elected leader ♚", l.Cluster.Node)
+		logger.Infof("Node %s elected leader ♚", l.Cluster.Node)
 		l.leader.Set(true)
 		l.Start()
 	} else {
-		log.Infof("Node %s elected worker ♝", l.Cluster.Node)
+		logger.Infof("Node %s elected worker ♝", l.Cluster.Node)
 		l.leader.Set(false)
 		l.Stop()
 	}
 	for _, listener := range l.listeners {
 		err := listener(elected)
 		if err != nil {
-			log.Errorf("Error calling Leadership listener: %s", err)
+			logger.Errorf("Error calling Leadership listener: %s", err)
 		}
 	}
 }
@@ -116,7 +118,7 @@ func (l *Leadership) getLeaderHandler(response http.ResponseWriter, request *htt
 	leaderNode := ""
 	leaderKv, err := l.Cluster.Store.Get(l.Cluster.Store.Prefix+clusterLeaderKeySuffix, nil)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	} else {
 		leaderNode = string(leaderKv.Value)
 	}
@@ -130,7 +132,7 @@ func (l *Leadership) getLeaderHandler(response http.ResponseWriter, request *htt
 
 	err = templatesRenderer.JSON(response, status, leader)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 }
 
@@ -7,11 +7,14 @@ import (
 	servicefabric "github.com/containous/traefik-extra-service-fabric"
 	"github.com/containous/traefik/api"
 	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/metrics"
 	"github.com/containous/traefik/middlewares/accesslog"
 	"github.com/containous/traefik/middlewares/tracing"
 	"github.com/containous/traefik/middlewares/tracing/datadog"
 	"github.com/containous/traefik/middlewares/tracing/jaeger"
+	tracingotlp "github.com/containous/traefik/middlewares/tracing/otlp"
 	"github.com/containous/traefik/middlewares/tracing/zipkin"
+	"github.com/containous/traefik/otlp"
 	"github.com/containous/traefik/ping"
 	"github.com/containous/traefik/provider/boltdb"
 	"github.com/containous/traefik/provider/consul"
@@ -22,12 +25,15 @@ import (
 	"github.com/containous/traefik/provider/etcd"
 	"github.com/containous/traefik/provider/eureka"
 	"github.com/containous/traefik/provider/file"
+	httpprovider "github.com/containous/traefik/provider/http"
 	"github.com/containous/traefik/provider/kubernetes"
 	"github.com/containous/traefik/provider/marathon"
 	"github.com/containous/traefik/provider/mesos"
 	"github.com/containous/traefik/provider/rancher"
+	"github.com/containous/traefik/provider/redis"
 	"github.com/containous/traefik/provider/rest"
 	"github.com/containous/traefik/provider/zk"
+	"github.com/containous/traefik/tls/watchdog"
 	"github.com/containous/traefik/types"
 	sf "github.com/jjcollinge/servicefabric"
 )
@@ -112,6 +118,7 @@ func NewTraefikDefaultPointersConfiguration() *TraefikConfiguration {
 	defaultConsulCatalog.FrontEndRule = "Host:{{.ServiceName}}.{{.Domain}}"
 	defaultConsulCatalog.Stale = false
 	defaultConsulCatalog.StrictChecks = true
+	defaultConsulCatalog.ServiceName = "traefik"
 
 	// default Etcd
 	var defaultEtcd etcd.Provider
@@ -134,6 +141,13 @@ func NewTraefikDefaultPointersConfiguration() *TraefikConfiguration {
 	defaultBoltDb.Prefix = "/traefik"
 	defaultBoltDb.Constraints = types.Constraints{}
 
+	// default Redis
+	var defaultRedis redis.Provider
+	defaultRedis.Watch = true
+	defaultRedis.Endpoint = "127.0.0.1:6379"
+	defaultRedis.Prefix = "traefik"
+	defaultRedis.Constraints = types.Constraints{}
+
 	// default Kubernetes
 	var defaultKubernetes kubernetes.Provider
 	defaultKubernetes.Watch = true
@@ -175,6 +189,12 @@ func NewTraefikDefaultPointersConfiguration() *TraefikConfiguration {
 	var defaultEureka eureka.Provider
 	defaultEureka.RefreshSeconds = flaeg.Duration(30 * time.Second)
 
+	// default HTTP
+	var defaultHTTP httpprovider.Provider
+	defaultHTTP.Watch = true
+	defaultHTTP.PollInterval = flaeg.Duration(5 * time.Second)
+	defaultHTTP.PollTimeout = flaeg.Duration(5 * time.Second)
+
 	// default ServiceFabric
 	var defaultServiceFabric servicefabric.Provider
 	defaultServiceFabric.APIVersion = sf.DefaultAPIVersion
@@ -243,6 +263,14 @@ func NewTraefikDefaultPointersConfiguration() *TraefikConfiguration {
 			Debug:              false,
 			PrioritySampling:   false,
 		},
+		OTLP: &tracingotlp.Config{
+			OTLP: types.OTLP{
+				Protocol: otlp.ProtocolGRPC,
+				Endpoint: otlp.DefaultGRPCEndpoint,
+			},
+			BatchSize:     tracingotlp.DefaultBatchSize,
+			FlushInterval: flaeg.Duration(tracingotlp.DefaultFlushInterval),
+		},
 	}
 
 	// default LifeCycle
@@ -278,6 +306,17 @@ func NewTraefikDefaultPointersConfiguration() *TraefikConfiguration {
 			Protocol:     "udp",
 			PushInterval: "10s",
 		},
+		OTLP: &types.OTLPMetrics{
+			OTLP: types.OTLP{
+				Protocol: otlp.ProtocolGRPC,
+				Endpoint: otlp.DefaultGRPCEndpoint,
+			},
+			PushInterval: "10s",
+			Buckets:      types.Buckets{0.1, 0.3, 1.2, 5},
+		},
+		Servers: &types.ServerMetrics{
+			MaxSeries: metrics.DefaultServerMaxSeries,
+		},
 	}
 
 	defaultResolver := configuration.HostResolverConfig{
@@ -286,35 +325,44 @@ func NewTraefikDefaultPointersConfiguration() *TraefikConfiguration {
 		ResolvDepth:     5,
 	}
 
+	// default CertificatesWatchdog
+	defaultCertificatesWatchdog := watchdog.Config{
+		CheckInterval: watchdog.DefaultCheckInterval,
+		Thresholds:    append(watchdog.Days{}, watchdog.DefaultThresholds...),
+	}
+
 	defaultConfiguration := configuration.GlobalConfiguration{
-		Docker:             &defaultDocker,
-		File:               &defaultFile,
-		Web:                &defaultWeb,
-		Rest:               &defaultRest,
-		Marathon:           &defaultMarathon,
-		Consul:             &defaultConsul,
-		ConsulCatalog:      &defaultConsulCatalog,
-		Etcd:               &defaultEtcd,
-		Zookeeper:          &defaultZookeeper,
-		Boltdb:             &defaultBoltDb,
-		Kubernetes:         &defaultKubernetes,
-		Mesos:              &defaultMesos,
-		ECS:                &defaultECS,
-		Rancher:            &defaultRancher,
-		Eureka:             &defaultEureka,
-		DynamoDB:           &defaultDynamoDB,
-		Retry:              &configuration.Retry{},
-		HealthCheck:        &healthCheck,
-		RespondingTimeouts: &respondingTimeouts,
-		ForwardingTimeouts: &forwardingTimeouts,
-		TraefikLog:         &defaultTraefikLog,
-		AccessLog:          &defaultAccessLog,
-		LifeCycle:          &defaultLifeCycle,
-		Ping:               &defaultPing,
-		API:                &defaultAPI,
-		Metrics:            &defaultMetrics,
-		Tracing:            &defaultTracing,
-		HostResolver:       &defaultResolver,
+		Docker:               &defaultDocker,
+		File:                 &defaultFile,
+		Web:                  &defaultWeb,
+		Rest:                 &defaultRest,
+		Marathon:             &defaultMarathon,
+		Consul:               &defaultConsul,
+		ConsulCatalog:        &defaultConsulCatalog,
+		Etcd:                 &defaultEtcd,
+		Zookeeper:            &defaultZookeeper,
+		Boltdb:               &defaultBoltDb,
+		Redis:                &defaultRedis,
+		Kubernetes:           &defaultKubernetes,
+		Mesos:                &defaultMesos,
+		ECS:                  &defaultECS,
+		Rancher:              &defaultRancher,
+		Eureka:               &defaultEureka,
+		DynamoDB:             &defaultDynamoDB,
+		HTTP:                 &defaultHTTP,
+		Retry:                &configuration.Retry{},
+		HealthCheck:          &healthCheck,
+		RespondingTimeouts:   &respondingTimeouts,
+		ForwardingTimeouts:   &forwardingTimeouts,
+		TraefikLog:           &defaultTraefikLog,
+		AccessLog:            &defaultAccessLog,
+		LifeCycle:            &defaultLifeCycle,
+		Ping:                 &defaultPing,
+		API:                  &defaultAPI,
+		Metrics:              &defaultMetrics,
+		Tracing:              &defaultTracing,
+		HostResolver:         &defaultResolver,
+		CertificatesWatchdog: &defaultCertificatesWatchdog,
 	}
 
 	return &TraefikConfiguration{
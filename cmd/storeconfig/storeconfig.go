@@ -16,6 +16,8 @@ import (
 	"github.com/containous/traefik/log"
 )
 
+var logger = log.WithModule("cmd/storeconfig")
+
 // NewCmd builds a new StoreConfig command
 func NewCmd(traefikConfiguration *cmd.TraefikConfiguration, traefikPointersConfiguration *cmd.TraefikConfiguration) *flaeg.Command {
 	return &flaeg.Command{
@@ -167,7 +169,7 @@ func migrateACMEData(fileName string) (*acme.Account, error) {
 			account = accountFromNewFormat
 		}
 	} else {
-		log.Warnf("No data will be imported from the storageFile %q because it is empty.", fileName)
+		logger.Warnf("No data will be imported from the storageFile %q because it is empty.", fileName)
 	}
 
 	err = account.Init()
@@ -206,6 +208,12 @@ func CreateKvSource(traefikConfiguration *cmd.TraefikConfiguration) (*staert.KvS
 			Store:  kvStore,
 			Prefix: traefikConfiguration.Boltdb.Prefix,
 		}
+	case traefikConfiguration.Redis != nil:
+		kvStore, err = traefikConfiguration.Redis.CreateStore()
+		kv = &staert.KvSource{
+			Store:  kvStore,
+			Prefix: traefikConfiguration.Redis.Prefix,
+		}
 	}
 	return kv, err
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/containous/traefik/server"
 	"github.com/containous/traefik/server/uuid"
 	traefiktls "github.com/containous/traefik/tls"
+	"github.com/containous/traefik/tls/watchdog"
 	"github.com/containous/traefik/types"
 	"github.com/containous/traefik/version"
 	"github.com/coreos/go-systemd/daemon"
@@ -40,6 +41,8 @@ import (
 	"github.com/vulcand/oxy/roundrobin"
 )
 
+var logger = log.WithModule("cmd/traefik")
+
 func main() {
 	// traefik config inits
 	traefikConfiguration := cmd.NewTraefikConfiguration()
@@ -76,6 +79,8 @@ Complete documentation is available at https://traefik.io`,
 	f.AddParser(reflect.TypeOf(types.StatusCodes{}), &types.StatusCodes{})
 	f.AddParser(reflect.TypeOf(types.FieldNames{}), &types.FieldNames{})
 	f.AddParser(reflect.TypeOf(types.FieldHeaderNames{}), &types.FieldHeaderNames{})
+	f.AddParser(reflect.TypeOf(types.KeyValues{}), &types.KeyValues{})
+	f.AddParser(reflect.TypeOf(watchdog.Days{}), &watchdog.Days{})
 
 	// add commands
 	f.AddCommand(cmdVersion.NewCmd())
@@ -133,7 +138,7 @@ Complete documentation is available at https://traefik.io`,
 			return err
 		}
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Load config error: %+v, retrying in %s", err, time)
+			logger.Errorf("Load config error: %+v, retrying in %s", err, time)
 		}
 		err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 		if err != nil {
@@ -154,7 +159,7 @@ func runCmd(globalConfiguration *configuration.GlobalConfiguration, configFile s
 	configureLogging(globalConfiguration)
 
 	if len(configFile) > 0 {
-		log.Infof("Using TOML configuration file %s", configFile)
+		logger.Infof("Using TOML configuration file %s", configFile)
 	}
 
 	http.DefaultTransport.(*http.Transport).Proxy = http.ProxyFromEnvironment
@@ -166,14 +171,14 @@ func runCmd(globalConfiguration *configuration.GlobalConfiguration, configFile s
 	globalConfiguration.SetEffectiveConfiguration(configFile)
 	globalConfiguration.ValidateConfiguration()
 
-	log.Infof("Traefik version %s built on %s", version.Version, version.BuildDate)
+	logger.Infof("Traefik version %s built on %s", version.Version, version.BuildDate)
 
 	jsonConf, err := json.Marshal(globalConfiguration)
 	if err != nil {
-		log.Error(err)
-		log.Debugf("Global configuration loaded [struct] %#v", globalConfiguration)
+		logger.Error(err)
+		logger.Debugf("Global configuration loaded [struct] %#v", globalConfiguration)
 	} else {
-		log.Debugf("Global configuration loaded %s", string(jsonConf))
+		logger.Debugf("Global configuration loaded %s", string(jsonConf))
 	}
 
 	if globalConfiguration.API != nil && globalConfiguration.API.Dashboard {
@@ -190,11 +195,11 @@ func runCmd(globalConfiguration *configuration.GlobalConfiguration, configFile s
 
 	acmeprovider, err := globalConfiguration.InitACMEProvider()
 	if err != nil {
-		log.Errorf("Unable to initialize ACME provider: %v", err)
+		logger.Errorf("Unable to initialize ACME provider: %v", err)
 	} else if acmeprovider != nil {
 		err = providerAggregator.AddProvider(acmeprovider)
 		if err != nil {
-			log.Errorf("Unable to add ACME provider to the providers list: %v", err)
+			logger.Errorf("Unable to add ACME provider to the providers list: %v", err)
 			acmeprovider = nil
 		}
 	}
@@ -210,6 +215,7 @@ func runCmd(globalConfiguration *configuration.GlobalConfiguration, configFile s
 		if acmeprovider != nil {
 			if acmeprovider.HTTPChallenge != nil && entryPointName == acmeprovider.HTTPChallenge.EntryPoint {
 				internalRouter.AddRouter(acmeprovider)
+				entryPoint.ACMEHTTPChallenge = true
 			}
 
 			// TLS ALPN 01
@@ -224,7 +230,7 @@ func runCmd(globalConfiguration *configuration.GlobalConfiguration, configFile s
 			if entryPointName == acmeprovider.EntryPoint {
 				entryPoint.CertificateStore = traefiktls.NewCertificateStore()
 				acmeprovider.SetCertificateStore(entryPoint.CertificateStore)
-				log.Debugf("Setting Acme Certificate store from Entrypoint: %s", entryPointName)
+				logger.Debugf("Setting Acme Certificate store from Entrypoint: %s", entryPointName)
 			}
 		}
 
@@ -248,33 +254,33 @@ func runCmd(globalConfiguration *configuration.GlobalConfiguration, configFile s
 
 	sent, err := daemon.SdNotify(false, "READY=1")
 	if !sent && err != nil {
-		log.Error("Fail to notify", err)
+		logger.Error("Fail to notify", err)
 	}
 
 	t, err := daemon.SdWatchdogEnabled(false)
 	if err != nil {
-		log.Error("Problem with watchdog", err)
+		logger.Error("Problem with watchdog", err)
 	} else if t != 0 {
 		// Send a ping each half time given
 		t = t / 2
-		log.Info("Watchdog activated with timer each ", t)
+		logger.Info("Watchdog activated with timer each ", t)
 		safe.Go(func() {
 			tick := time.Tick(t)
 			for range tick {
 				_, errHealthCheck := healthcheck.Do(*globalConfiguration)
 				if globalConfiguration.Ping == nil || errHealthCheck == nil {
 					if ok, _ := daemon.SdNotify(false, "WATCHDOG=1"); !ok {
-						log.Error("Fail to tick watchdog")
+						logger.Error("Fail to tick watchdog")
 					}
 				} else {
-					log.Error(errHealthCheck)
+					logger.Error(errHealthCheck)
 				}
 			}
 		})
 	}
 
 	svr.Wait()
-	log.Info("Shutting down")
+	logger.Info("Shutting down")
 	logrus.Exit(0)
 }
 
@@ -295,14 +301,28 @@ func configureLogging(globalConfiguration *configuration.GlobalConfiguration) {
 	}
 	level, err := logrus.ParseLevel(levelStr)
 	if err != nil {
-		log.Error("Error getting level", err)
+		logger.Error("Error getting level", err)
 	}
 	log.SetLevel(level)
 
+	// configure the log levels of the modules
+	if globalConfiguration.TraefikLog != nil && len(globalConfiguration.TraefikLog.ModuleLevels) > 0 {
+		moduleLevels := make(map[string]logrus.Level)
+		for module, moduleLevelStr := range globalConfiguration.TraefikLog.ModuleLevels {
+			moduleLevel, err := logrus.ParseLevel(strings.ToLower(moduleLevelStr))
+			if err != nil {
+				logger.Errorf("Error getting level of module %s: %v", module, err)
+				continue
+			}
+			moduleLevels[module] = moduleLevel
+		}
+		log.SetModuleLevels(moduleLevels)
+	}
+
 	// configure log output file
 	logFile := globalConfiguration.TraefikLogsFile
 	if len(logFile) > 0 {
-		log.Warn("top-level traefikLogsFile has been deprecated -- please use traefiklog.filepath")
+		logger.Warn("top-level traefikLogsFile has been deprecated -- please use traefiklog.filepath")
 	}
 	if globalConfiguration.TraefikLog != nil && len(globalConfiguration.TraefikLog.FilePath) > 0 {
 		logFile = globalConfiguration.TraefikLog.FilePath
@@ -322,17 +342,17 @@ func configureLogging(globalConfiguration *configuration.GlobalConfiguration) {
 		dir := filepath.Dir(logFile)
 
 		if err := os.MkdirAll(dir, 0755); err != nil {
-			log.Errorf("Failed to create log path %s: %s", dir, err)
+			logger.Errorf("Failed to create log path %s: %s", dir, err)
 		}
 
 		err = log.OpenFile(logFile)
 		logrus.RegisterExitHandler(func() {
 			if err := log.CloseFile(); err != nil {
-				log.Error("Error closing log", err)
+				logger.Error("Error closing log", err)
 			}
 		})
 		if err != nil {
-			log.Error("Error opening file", err)
+			logger.Error("Error opening file", err)
 		}
 	}
 }
@@ -348,7 +368,7 @@ func checkNewVersion() {
 
 func stats(globalConfiguration *configuration.GlobalConfiguration) {
 	if globalConfiguration.SendAnonymousUsage {
-		log.Info(`
+		logger.Info(`
 Stats collection is enabled.
 Many thanks for contributing to Traefik's improvement by allowing us to receive anonymous information from your configuration.
 Help us improve Traefik by leaving this feature on :)
@@ -356,7 +376,7 @@ More details on: https://docs.traefik.io/v1.7/basics/#collected-data
 `)
 		collect(globalConfiguration)
 	} else {
-		log.Info(`
+		logger.Info(`
 Stats collection is disabled.
 Help us improve Traefik by turning this feature on :)
 More details on: https://docs.traefik.io/v1.7/basics/#collected-data
@@ -369,7 +389,7 @@ func collect(globalConfiguration *configuration.GlobalConfiguration) {
 	safe.Go(func() {
 		for time.Sleep(10 * time.Minute); ; <-ticker {
 			if err := collector.Collect(globalConfiguration); err != nil {
-				log.Debug(err)
+				logger.Debug(err)
 			}
 		}
 	})
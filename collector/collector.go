@@ -16,6 +16,8 @@ import (
 	"github.com/mitchellh/hashstructure"
 )
 
+var logger = log.WithModule("collector")
+
 // collectorURL URL where the stats are send
 const collectorURL = "https://collect.traefik.io/619df80498b60f985d766ce62f912b7c"
 
@@ -35,7 +37,7 @@ func Collect(globalConfiguration *configuration.GlobalConfiguration) error {
 		return err
 	}
 
-	log.Infof("Anonymous stats sent to %s: %s", collectorURL, anonConfig)
+	logger.Infof("Anonymous stats sent to %s: %s", collectorURL, anonConfig)
 
 	hashConf, err := hashstructure.Hash(globalConfiguration, nil)
 	if err != nil {
@@ -2,9 +2,11 @@ package configuration
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/abronan/valkeyrie/store"
 	"github.com/containous/flaeg"
 	servicefabric "github.com/containous/traefik-extra-service-fabric"
 	"github.com/containous/traefik/acme"
@@ -13,7 +15,9 @@ import (
 	"github.com/containous/traefik/middlewares/tracing"
 	"github.com/containous/traefik/middlewares/tracing/datadog"
 	"github.com/containous/traefik/middlewares/tracing/jaeger"
+	tracingotlp "github.com/containous/traefik/middlewares/tracing/otlp"
 	"github.com/containous/traefik/middlewares/tracing/zipkin"
+	"github.com/containous/traefik/otlp"
 	"github.com/containous/traefik/ping"
 	acmeprovider "github.com/containous/traefik/provider/acme"
 	"github.com/containous/traefik/provider/boltdb"
@@ -25,19 +29,25 @@ import (
 	"github.com/containous/traefik/provider/etcd"
 	"github.com/containous/traefik/provider/eureka"
 	"github.com/containous/traefik/provider/file"
+	httpprovider "github.com/containous/traefik/provider/http"
 	"github.com/containous/traefik/provider/kubernetes"
 	"github.com/containous/traefik/provider/marathon"
 	"github.com/containous/traefik/provider/mesos"
 	"github.com/containous/traefik/provider/rancher"
+	"github.com/containous/traefik/provider/redis"
 	"github.com/containous/traefik/provider/rest"
 	"github.com/containous/traefik/provider/zk"
+	"github.com/containous/traefik/server/uuid"
 	"github.com/containous/traefik/tls"
+	"github.com/containous/traefik/tls/watchdog"
 	"github.com/containous/traefik/types"
 	"github.com/go-acme/lego/challenge/dns01"
 	"github.com/pkg/errors"
 	jaegercli "github.com/uber/jaeger-client-go"
 )
 
+var logger = log.WithModule("configuration")
+
 const (
 	// DefaultInternalEntryPointName the name of the default internal entry point
 	DefaultInternalEntryPointName = "traefik"
@@ -98,6 +108,7 @@ type GlobalConfiguration struct {
 	Etcd                      *etcd.Provider          `description:"Enable Etcd backend with default settings" export:"true"`
 	Zookeeper                 *zk.Provider            `description:"Enable Zookeeper backend with default settings" export:"true"`
 	Boltdb                    *boltdb.Provider        `description:"Enable Boltdb backend with default settings" export:"true"`
+	Redis                     *redis.Provider         `description:"Enable Redis backend with default settings" export:"true"`
 	Kubernetes                *kubernetes.Provider    `description:"Enable Kubernetes backend with default settings" export:"true"`
 	Mesos                     *mesos.Provider         `description:"Enable Mesos backend with default settings" export:"true"`
 	Eureka                    *eureka.Provider        `description:"Enable Eureka backend with default settings" export:"true"`
@@ -106,10 +117,12 @@ type GlobalConfiguration struct {
 	DynamoDB                  *dynamodb.Provider      `description:"Enable DynamoDB backend with default settings" export:"true"`
 	ServiceFabric             *servicefabric.Provider `description:"Enable Service Fabric backend with default settings" export:"true"`
 	Rest                      *rest.Provider          `description:"Enable Rest backend with default settings" export:"true"`
+	HTTP                      *httpprovider.Provider  `description:"Enable HTTP backend with default settings" export:"true"`
 	API                       *api.Handler            `description:"Enable api/dashboard" export:"true"`
 	Metrics                   *types.Metrics          `description:"Enable a metrics exporter" export:"true"`
 	Ping                      *ping.Handler           `description:"Enable ping" export:"true"`
 	HostResolver              *HostResolverConfig     `description:"Enable CNAME Flattening" export:"true"`
+	CertificatesWatchdog      *watchdog.Config        `description:"Enable the certificates expiration watchdog" export:"true"`
 }
 
 // WebCompatibility is a configuration to handle compatibility with deprecated web provider options
@@ -127,10 +140,10 @@ type WebCompatibility struct {
 
 func (gc *GlobalConfiguration) handleWebDeprecation() {
 	if gc.Web != nil {
-		log.Warn("web provider configuration is deprecated, you should use these options : api, rest provider, ping and metrics")
+		logger.Warn("web provider configuration is deprecated, you should use these options : api, rest provider, ping and metrics")
 
 		if gc.API != nil || gc.Metrics != nil || gc.Ping != nil || gc.Rest != nil {
-			log.Warn("web option is ignored if you use it with one of these options : api, rest provider, ping or metrics")
+			logger.Warn("web option is ignored if you use it with one of these options : api, rest provider, ping or metrics")
 			return
 		}
 		gc.EntryPoints[DefaultInternalEntryPointName] = &EntryPoint{
@@ -202,7 +215,7 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 		}
 
 		if len(entryPoint.WhitelistSourceRange) > 0 {
-			log.Warnf("Deprecated configuration found: %s. Please use %s.", "whiteListSourceRange", "whiteList.sourceRange")
+			logger.Warnf("Deprecated configuration found: %s. Please use %s.", "whiteListSourceRange", "whiteList.sourceRange")
 
 			if entryPoint.WhiteList == nil {
 				entryPoint.WhiteList = &types.WhiteList{
@@ -219,9 +232,15 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 		}
 
 		if entryPoint.TLS != nil && entryPoint.TLS.DefaultCertificate == nil && len(entryPoint.TLS.Certificates) > 0 {
-			log.Infof("No tls.defaultCertificate given for %s: using the first item in tls.certificates as a fallback.", entryPointName)
+			logger.Infof("No tls.defaultCertificate given for %s: using the first item in tls.certificates as a fallback.", entryPointName)
 			entryPoint.TLS.DefaultCertificate = &entryPoint.TLS.Certificates[0]
 		}
+
+		if len(entryPoint.InternalRoutesPriority) > 0 &&
+			entryPoint.InternalRoutesPriority != InternalRoutesPriorityHigh && entryPoint.InternalRoutesPriority != InternalRoutesPriorityLow {
+			logger.Errorf("Invalid internal routes priority %q for entry point %s: using %q.", entryPoint.InternalRoutesPriority, entryPointName, InternalRoutesPriorityHigh)
+			entryPoint.InternalRoutesPriority = InternalRoutesPriorityHigh
+		}
 	}
 
 	// Make sure LifeCycle isn't nil to spare nil checks elsewhere.
@@ -231,13 +250,13 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 
 	// Prefer legacy grace timeout parameter for backwards compatibility reasons.
 	if gc.GraceTimeOut > 0 {
-		log.Warn("top-level grace period configuration has been deprecated -- please use lifecycle grace period")
+		logger.Warn("top-level grace period configuration has been deprecated -- please use lifecycle grace period")
 		gc.LifeCycle.GraceTimeOut = gc.GraceTimeOut
 	}
 
 	if gc.Docker != nil {
 		if len(gc.Docker.Filename) != 0 && gc.Docker.TemplateVersion != 2 {
-			log.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
+			logger.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
 			gc.Docker.TemplateVersion = 1
 		} else {
 			gc.Docker.TemplateVersion = 2
@@ -250,7 +269,7 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 
 	if gc.Marathon != nil {
 		if len(gc.Marathon.Filename) != 0 && gc.Marathon.TemplateVersion != 2 {
-			log.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
+			logger.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
 			gc.Marathon.TemplateVersion = 1
 		} else {
 			gc.Marathon.TemplateVersion = 2
@@ -259,7 +278,7 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 
 	if gc.Mesos != nil {
 		if len(gc.Mesos.Filename) != 0 && gc.Mesos.TemplateVersion != 2 {
-			log.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
+			logger.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
 			gc.Mesos.TemplateVersion = 1
 		} else {
 			gc.Mesos.TemplateVersion = 2
@@ -268,14 +287,14 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 
 	if gc.Eureka != nil {
 		if gc.Eureka.Delay != 0 {
-			log.Warn("Delay has been deprecated -- please use RefreshSeconds")
+			logger.Warn("Delay has been deprecated -- please use RefreshSeconds")
 			gc.Eureka.RefreshSeconds = gc.Eureka.Delay
 		}
 	}
 
 	if gc.ECS != nil {
 		if len(gc.ECS.Filename) != 0 && gc.ECS.TemplateVersion != 2 {
-			log.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
+			logger.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
 			gc.ECS.TemplateVersion = 1
 		} else {
 			gc.ECS.TemplateVersion = 2
@@ -284,7 +303,7 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 
 	if gc.ConsulCatalog != nil {
 		if len(gc.ConsulCatalog.Filename) != 0 && gc.ConsulCatalog.TemplateVersion != 2 {
-			log.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
+			logger.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
 			gc.ConsulCatalog.TemplateVersion = 1
 		} else {
 			gc.ConsulCatalog.TemplateVersion = 2
@@ -293,7 +312,7 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 
 	if gc.Rancher != nil {
 		if len(gc.Rancher.Filename) != 0 && gc.Rancher.TemplateVersion != 2 {
-			log.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
+			logger.Warn("Template version 1 is deprecated, please use version 2, see TemplateVersion.")
 			gc.Rancher.TemplateVersion = 1
 		} else {
 			gc.Rancher.TemplateVersion = 2
@@ -311,7 +330,7 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 					Endpoint:  gc.Rancher.Endpoint,
 				}
 			}
-			log.Warn("Deprecated configuration found: rancher.[accesskey|secretkey|endpoint]. " +
+			logger.Warn("Deprecated configuration found: rancher.[accesskey|secretkey|endpoint]. " +
 				"Please use rancher.api.[accesskey|secretkey|endpoint] instead.")
 		}
 
@@ -320,6 +339,14 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 		}
 	}
 
+	if gc.Kubernetes != nil {
+		addresses := make(map[string]string)
+		for entryPointName, entryPoint := range gc.EntryPoints {
+			addresses[entryPointName] = entryPoint.Address
+		}
+		gc.Kubernetes.SetEntryPoints(addresses)
+	}
+
 	if gc.API != nil {
 		gc.API.Debug = gc.Debug
 	}
@@ -338,6 +365,11 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 
 func (gc *GlobalConfiguration) initTracing() {
 	if gc.Tracing != nil {
+		if err := tracing.ValidatePropagation(gc.Tracing.Propagation); err != nil {
+			logger.Errorf("Invalid tracing propagation, the trace context is propagated in the format of the tracer: %v", err)
+			gc.Tracing.Propagation = nil
+		}
+
 		switch gc.Tracing.Backend {
 		case jaeger.Name:
 			if gc.Tracing.Jaeger == nil {
@@ -350,13 +382,17 @@ func (gc *GlobalConfiguration) initTracing() {
 				}
 			}
 			if gc.Tracing.Zipkin != nil {
-				log.Warn("Zipkin configuration will be ignored")
+				logger.Warn("Zipkin configuration will be ignored")
 				gc.Tracing.Zipkin = nil
 			}
 			if gc.Tracing.DataDog != nil {
-				log.Warn("DataDog configuration will be ignored")
+				logger.Warn("DataDog configuration will be ignored")
 				gc.Tracing.DataDog = nil
 			}
+			if gc.Tracing.OTLP != nil {
+				logger.Warn("OTLP configuration will be ignored")
+				gc.Tracing.OTLP = nil
+			}
 		case zipkin.Name:
 			if gc.Tracing.Zipkin == nil {
 				gc.Tracing.Zipkin = &zipkin.Config{
@@ -367,13 +403,17 @@ func (gc *GlobalConfiguration) initTracing() {
 				}
 			}
 			if gc.Tracing.Jaeger != nil {
-				log.Warn("Jaeger configuration will be ignored")
+				logger.Warn("Jaeger configuration will be ignored")
 				gc.Tracing.Jaeger = nil
 			}
 			if gc.Tracing.DataDog != nil {
-				log.Warn("DataDog configuration will be ignored")
+				logger.Warn("DataDog configuration will be ignored")
 				gc.Tracing.DataDog = nil
 			}
+			if gc.Tracing.OTLP != nil {
+				logger.Warn("OTLP configuration will be ignored")
+				gc.Tracing.OTLP = nil
+			}
 		case datadog.Name:
 			if gc.Tracing.DataDog == nil {
 				gc.Tracing.DataDog = &datadog.Config{
@@ -384,15 +424,42 @@ func (gc *GlobalConfiguration) initTracing() {
 				}
 			}
 			if gc.Tracing.Zipkin != nil {
-				log.Warn("Zipkin configuration will be ignored")
+				logger.Warn("Zipkin configuration will be ignored")
 				gc.Tracing.Zipkin = nil
 			}
 			if gc.Tracing.Jaeger != nil {
-				log.Warn("Jaeger configuration will be ignored")
+				logger.Warn("Jaeger configuration will be ignored")
 				gc.Tracing.Jaeger = nil
 			}
+			if gc.Tracing.OTLP != nil {
+				logger.Warn("OTLP configuration will be ignored")
+				gc.Tracing.OTLP = nil
+			}
+		case tracingotlp.Name:
+			if gc.Tracing.OTLP == nil {
+				gc.Tracing.OTLP = &tracingotlp.Config{
+					OTLP: types.OTLP{
+						Protocol: otlp.ProtocolGRPC,
+						Endpoint: otlp.DefaultGRPCEndpoint,
+					},
+					BatchSize:     tracingotlp.DefaultBatchSize,
+					FlushInterval: flaeg.Duration(tracingotlp.DefaultFlushInterval),
+				}
+			}
+			if gc.Tracing.Jaeger != nil {
+				logger.Warn("Jaeger configuration will be ignored")
+				gc.Tracing.Jaeger = nil
+			}
+			if gc.Tracing.Zipkin != nil {
+				logger.Warn("Zipkin configuration will be ignored")
+				gc.Tracing.Zipkin = nil
+			}
+			if gc.Tracing.DataDog != nil {
+				logger.Warn("DataDog configuration will be ignored")
+				gc.Tracing.DataDog = nil
+			}
 		default:
-			log.Warnf("Unknown tracer %q", gc.Tracing.Backend)
+			logger.Warnf("Unknown tracer %q", gc.Tracing.Backend)
 			return
 		}
 	}
@@ -403,44 +470,44 @@ func (gc *GlobalConfiguration) initACMEProvider() {
 		gc.ACME.CAServer = getSafeACMECAServer(gc.ACME.CAServer)
 
 		if gc.ACME.DNSChallenge != nil && gc.ACME.HTTPChallenge != nil {
-			log.Warn("Unable to use DNS challenge and HTTP challenge at the same time. Fallback to DNS challenge.")
+			logger.Warn("Unable to use DNS challenge and HTTP challenge at the same time. Fallback to DNS challenge.")
 			gc.ACME.HTTPChallenge = nil
 		}
 
 		if gc.ACME.DNSChallenge != nil && gc.ACME.TLSChallenge != nil {
-			log.Warn("Unable to use DNS challenge and TLS challenge at the same time. Fallback to DNS challenge.")
+			logger.Warn("Unable to use DNS challenge and TLS challenge at the same time. Fallback to DNS challenge.")
 			gc.ACME.TLSChallenge = nil
 		}
 
 		if gc.ACME.HTTPChallenge != nil && gc.ACME.TLSChallenge != nil {
-			log.Warn("Unable to use HTTP challenge and TLS challenge at the same time. Fallback to TLS challenge.")
+			logger.Warn("Unable to use HTTP challenge and TLS challenge at the same time. Fallback to TLS challenge.")
 			gc.ACME.HTTPChallenge = nil
 		}
 
 		for _, domain := range gc.ACME.Domains {
 			if domain.Main != dns01.UnFqdn(domain.Main) {
-				log.Warnf("FQDN detected, please remove the trailing dot: %s", domain.Main)
+				logger.Warnf("FQDN detected, please remove the trailing dot: %s", domain.Main)
 			}
 			for _, san := range domain.SANs {
 				if san != dns01.UnFqdn(san) {
-					log.Warnf("FQDN detected, please remove the trailing dot: %s", san)
+					logger.Warnf("FQDN detected, please remove the trailing dot: %s", san)
 				}
 			}
 		}
 
 		// TODO: to remove in the future
 		if len(gc.ACME.StorageFile) > 0 && len(gc.ACME.Storage) == 0 {
-			log.Warn("ACME.StorageFile is deprecated, use ACME.Storage instead")
+			logger.Warn("ACME.StorageFile is deprecated, use ACME.Storage instead")
 			gc.ACME.Storage = gc.ACME.StorageFile
 		}
 
 		if len(gc.ACME.DNSProvider) > 0 {
-			log.Warn("ACME.DNSProvider is deprecated, use ACME.DNSChallenge instead")
+			logger.Warn("ACME.DNSProvider is deprecated, use ACME.DNSChallenge instead")
 			gc.ACME.DNSChallenge = &acmeprovider.DNSChallenge{Provider: gc.ACME.DNSProvider, DelayBeforeCheck: gc.ACME.DelayDontCheckDNS}
 		}
 
 		if gc.ACME.OnDemand {
-			log.Warn("ACME.OnDemand is deprecated")
+			logger.Warn("ACME.OnDemand is deprecated")
 		}
 	}
 }
@@ -472,9 +539,12 @@ func (gc *GlobalConfiguration) InitACMEProvider() (*acmeprovider.Provider, error
 				EntryPoint:    gc.ACME.EntryPoint,
 			}
 
-			store := acmeprovider.NewLocalStore(provider.Storage)
+			store, err := gc.initACMEStore(provider.Storage)
+			if err != nil {
+				gc.ACME = nil
+				return nil, err
+			}
 			provider.Store = store
-			acme.ConvertToNewFormat(provider.Storage)
 			gc.ACME = nil
 			return provider, nil
 		}
@@ -482,6 +552,79 @@ func (gc *GlobalConfiguration) InitACMEProvider() (*acmeprovider.Provider, error
 	return nil, nil
 }
 
+// initACMEStore creates the store of the ACME provider from the storage location:
+// a Kubernetes secret (kubernetes:<namespace>/<name>), a key of the KV provider store (kv:<key>), or a local file.
+func (gc *GlobalConfiguration) initACMEStore(storage string) (acmeprovider.Store, error) {
+	identity := getInstanceIdentity()
+
+	switch {
+	case strings.HasPrefix(storage, acmeprovider.KubernetesStoragePrefix):
+		namespace, name, err := acmeprovider.ParseKubernetesStorage(storage)
+		if err != nil {
+			return nil, err
+		}
+
+		kubernetesProvider := gc.Kubernetes
+		if kubernetesProvider == nil {
+			kubernetesProvider = &kubernetes.Provider{}
+		}
+
+		clientset, err := kubernetesProvider.NewClientset()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the Kubernetes client for the ACME storage: %v", err)
+		}
+
+		logger.Infof("Using the Kubernetes secret %s/%s as ACME storage", namespace, name)
+		return acmeprovider.NewKubernetesStore(clientset.CoreV1().Secrets(namespace), name, identity), nil
+
+	case strings.HasPrefix(storage, acmeprovider.KVStoragePrefix):
+		prefix := strings.TrimPrefix(storage, acmeprovider.KVStoragePrefix)
+		if len(prefix) == 0 {
+			return nil, fmt.Errorf("invalid KV ACME storage %q, expected %s<key>", storage, acmeprovider.KVStoragePrefix)
+		}
+
+		kvStore, err := gc.createKVStore()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the KV store for the ACME storage: %v", err)
+		}
+
+		logger.Infof("Using the KV store key %s as ACME storage", prefix)
+		return acmeprovider.NewKVStore(kvStore, prefix, identity), nil
+
+	default:
+		store := acmeprovider.NewLocalStore(storage)
+		acme.ConvertToNewFormat(storage)
+		return store, nil
+	}
+}
+
+// createKVStore creates the store of the enabled KV provider
+func (gc *GlobalConfiguration) createKVStore() (store.Store, error) {
+	switch {
+	case gc.Consul != nil:
+		return gc.Consul.CreateStore()
+	case gc.Etcd != nil:
+		return gc.Etcd.CreateStore()
+	case gc.Zookeeper != nil:
+		return gc.Zookeeper.CreateStore()
+	case gc.Boltdb != nil:
+		return gc.Boltdb.CreateStore()
+	case gc.Redis != nil:
+		return gc.Redis.CreateStore()
+	default:
+		return nil, errors.New("no KV provider enabled")
+	}
+}
+
+// getInstanceIdentity returns an identifier unique to the Traefik instance
+func getInstanceIdentity() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return uuid.Get()
+	}
+	return hostname + "_" + uuid.Get()
+}
+
 func getSafeACMECAServer(caServerSrc string) string {
 	if len(caServerSrc) == 0 {
 		return DefaultAcmeCAServer
@@ -489,13 +632,13 @@ func getSafeACMECAServer(caServerSrc string) string {
 
 	if strings.HasPrefix(caServerSrc, "https://acme-v01.api.letsencrypt.org") {
 		caServer := strings.Replace(caServerSrc, "v01", "v02", 1)
-		log.Warnf("The CA server %[1]q refers to a v01 endpoint of the ACME API, please change to %[2]q. Fallback to %[2]q.", caServerSrc, caServer)
+		logger.Warnf("The CA server %[1]q refers to a v01 endpoint of the ACME API, please change to %[2]q. Fallback to %[2]q.", caServerSrc, caServer)
 		return caServer
 	}
 
 	if strings.HasPrefix(caServerSrc, "https://acme-staging.api.letsencrypt.org") {
 		caServer := strings.Replace(caServerSrc, "https://acme-staging.api.letsencrypt.org", "https://acme-staging-v02.api.letsencrypt.org", 1)
-		log.Warnf("The CA server %[1]q refers to a v01 endpoint of the ACME API, please change to %[2]q. Fallback to %[2]q.", caServerSrc, caServer)
+		logger.Warnf("The CA server %[1]q refers to a v01 endpoint of the ACME API, please change to %[2]q. Fallback to %[2]q.", caServerSrc, caServer)
 		return caServer
 	}
 
@@ -506,10 +649,10 @@ func getSafeACMECAServer(caServerSrc string) string {
 func (gc *GlobalConfiguration) ValidateConfiguration() {
 	if gc.ACME != nil {
 		if _, ok := gc.EntryPoints[gc.ACME.EntryPoint]; !ok {
-			log.Fatalf("Unknown entrypoint %q for ACME configuration", gc.ACME.EntryPoint)
+			logger.Fatalf("Unknown entrypoint %q for ACME configuration", gc.ACME.EntryPoint)
 		} else {
 			if gc.EntryPoints[gc.ACME.EntryPoint].TLS == nil {
-				log.Fatalf("Entrypoint %q has no TLS configuration for ACME configuration", gc.ACME.EntryPoint)
+				logger.Fatalf("Entrypoint %q has no TLS configuration for ACME configuration", gc.ACME.EntryPoint)
 			}
 		}
 	}
@@ -4,22 +4,29 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
 )
 
+const (
+	// InternalRoutesPriorityHigh serves the internal routes (API, ping, metrics...) before the entry point middlewares and the frontends
+	InternalRoutesPriorityHigh = "high"
+	// InternalRoutesPriorityLow serves the internal routes after the entry point middlewares, only when no frontend matches the request
+	InternalRoutesPriorityLow = "low"
+)
+
 // EntryPoint holds an entry point configuration of the reverse proxy (ip, port, TLS...)
 type EntryPoint struct {
-	Address              string
-	TLS                  *tls.TLS          `export:"true"`
-	Redirect             *types.Redirect   `export:"true"`
-	Auth                 *types.Auth       `export:"true"`
-	WhitelistSourceRange []string          // Deprecated
-	WhiteList            *types.WhiteList  `export:"true"`
-	Compress             bool              `export:"true"`
-	ProxyProtocol        *ProxyProtocol    `export:"true"`
-	ForwardedHeaders     *ForwardedHeaders `export:"true"`
+	Address                string
+	TLS                    *tls.TLS          `export:"true"`
+	Redirect               *types.Redirect   `export:"true"`
+	Auth                   *types.Auth       `export:"true"`
+	WhitelistSourceRange   []string          // Deprecated
+	WhiteList              *types.WhiteList  `export:"true"`
+	Compress               bool              `export:"true"`
+	ProxyProtocol          *ProxyProtocol    `export:"true"`
+	ForwardedHeaders       *ForwardedHeaders `export:"true"`
+	InternalRoutesPriority string            `export:"true"`
 }
 
 // ProxyProtocol contains Proxy-Protocol configuration
@@ -76,16 +83,22 @@ func (ep *EntryPoints) Set(value string) error {
 		return err
 	}
 
+	internalRoutesPriority := result["internalroutespriority"]
+	if len(internalRoutesPriority) > 0 && internalRoutesPriority != InternalRoutesPriorityHigh && internalRoutesPriority != InternalRoutesPriorityLow {
+		return fmt.Errorf("invalid internal routes priority %q, expected %q or %q", internalRoutesPriority, InternalRoutesPriorityHigh, InternalRoutesPriorityLow)
+	}
+
 	(*ep)[result["name"]] = &EntryPoint{
-		Address:              result["address"],
-		TLS:                  configTLS,
-		Auth:                 makeEntryPointAuth(result),
-		Redirect:             makeEntryPointRedirect(result),
-		Compress:             compress,
-		WhitelistSourceRange: whiteListSourceRange,
-		WhiteList:            makeWhiteList(result),
-		ProxyProtocol:        makeEntryPointProxyProtocol(result),
-		ForwardedHeaders:     makeEntryPointForwardedHeaders(result),
+		Address:                result["address"],
+		TLS:                    configTLS,
+		Auth:                   makeEntryPointAuth(result),
+		Redirect:               makeEntryPointRedirect(result),
+		Compress:               compress,
+		WhitelistSourceRange:   whiteListSourceRange,
+		WhiteList:              makeWhiteList(result),
+		ProxyProtocol:          makeEntryPointProxyProtocol(result),
+		ForwardedHeaders:       makeEntryPointForwardedHeaders(result),
+		InternalRoutesPriority: internalRoutesPriority,
 	}
 
 	return nil
@@ -177,7 +190,7 @@ func makeEntryPointProxyProtocol(result map[string]string) *ProxyProtocol {
 	}
 
 	if proxyProtocol != nil && proxyProtocol.Insecure {
-		log.Warn("ProxyProtocol.Insecure:true is dangerous. Please use 'ProxyProtocol.TrustedIPs:IPs' and remove 'ProxyProtocol.Insecure:true'")
+		logger.Warn("ProxyProtocol.Insecure:true is dangerous. Please use 'ProxyProtocol.TrustedIPs:IPs' and remove 'ProxyProtocol.Insecure:true'")
 	}
 
 	return proxyProtocol
@@ -210,6 +223,10 @@ func makeEntryPointRedirect(result map[string]string) *types.Redirect {
 			Replacement: result["redirect_replacement"],
 			Permanent:   toBool(result, "redirect_permanent"),
 		}
+
+		if len(result["redirect_exemptions"]) > 0 {
+			redirect.Exemptions = strings.Split(result["redirect_exemptions"], ",")
+		}
 	}
 
 	return redirect
@@ -247,10 +264,34 @@ func makeEntryPointTLS(result map[string]string) (*tls.TLS, error) {
 			configTLS.MinVersion = result["tls_minversion"]
 		}
 
+		if len(result["tls_maxversion"]) > 0 {
+			configTLS.MaxVersion = result["tls_maxversion"]
+		}
+
 		if len(result["tls_ciphersuites"]) > 0 {
 			configTLS.CipherSuites = strings.Split(result["tls_ciphersuites"], ",")
 		}
 
+		if len(result["tls_curvepreferences"]) > 0 {
+			configTLS.CurvePreferences = strings.Split(result["tls_curvepreferences"], ",")
+		}
+
+		if len(result["tls_preferserverciphersuites"]) > 0 {
+			configTLS.PreferServerCipherSuites = toBool(result, "tls_preferserverciphersuites")
+		}
+
+		if len(result["tls_sessiontickets_disabled"]) > 0 || len(result["tls_sessiontickets_keyfiles"]) > 0 || len(result["tls_sessiontickets_storage"]) > 0 {
+			configTLS.SessionTickets = &tls.SessionTickets{
+				Disabled: toBool(result, "tls_sessiontickets_disabled"),
+				Storage:  result["tls_sessiontickets_storage"],
+			}
+			if len(result["tls_sessiontickets_keyfiles"]) > 0 {
+				files := tls.FilesOrContents{}
+				files.Set(result["tls_sessiontickets_keyfiles"])
+				configTLS.SessionTickets.KeyFiles = files
+			}
+		}
+
 		if len(result["tls_snistrict"]) > 0 {
 			configTLS.SniStrict = toBool(result, "tls_snistrict")
 		}
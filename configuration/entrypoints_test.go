@@ -457,6 +457,59 @@ func TestEntryPoints_Set(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TLS versions, curves and session tickets",
+			expression: "Name:foo TLS " +
+				"TLS.MinVersion:VersionTLS12 " +
+				"TLS.MaxVersion:VersionTLS13 " +
+				"TLS.CurvePreferences:X25519,secp256r1 " +
+				"TLS.PreferServerCipherSuites:true " +
+				"TLS.SessionTickets.KeyFiles:path/to/keys1,path/to/keys2 " +
+				"TLS.SessionTickets.Storage:traefik/tickets",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				TLS: &tls.TLS{
+					MinVersion:               "VersionTLS12",
+					MaxVersion:               "VersionTLS13",
+					CurvePreferences:         []string{"X25519", "secp256r1"},
+					PreferServerCipherSuites: true,
+					Certificates:             tls.Certificates{},
+					SessionTickets: &tls.SessionTickets{
+						KeyFiles: tls.FilesOrContents{"path/to/keys1", "path/to/keys2"},
+						Storage:  "traefik/tickets",
+					},
+				},
+				ForwardedHeaders: &ForwardedHeaders{Insecure: true},
+			},
+		},
+		{
+			name:                   "TLS session tickets disabled",
+			expression:             "Name:foo TLS TLS.SessionTickets.Disabled:true",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				TLS: &tls.TLS{
+					Certificates:   tls.Certificates{},
+					SessionTickets: &tls.SessionTickets{Disabled: true},
+				},
+				ForwardedHeaders: &ForwardedHeaders{Insecure: true},
+			},
+		},
+		{
+			name: "redirect exemptions and internal routes priority",
+			expression: "Name:foo " +
+				"Redirect.EntryPoint:https " +
+				"Redirect.Exemptions:/ping,/healthz " +
+				"InternalRoutesPriority:low",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				Redirect: &types.Redirect{
+					EntryPoint: "https",
+					Exemptions: []string{"/ping", "/healthz"},
+				},
+				ForwardedHeaders:       &ForwardedHeaders{Insecure: true},
+				InternalRoutesPriority: InternalRoutesPriorityLow,
+			},
+		},
 		{
 			name:                   "compress on",
 			expression:             "Name:foo Compress:on",
@@ -491,3 +544,9 @@ func TestEntryPoints_Set(t *testing.T) {
 		})
 	}
 }
+
+func TestEntryPoints_SetInvalidInternalRoutesPriority(t *testing.T) {
+	eps := EntryPoints{}
+	err := eps.Set("Name:foo InternalRoutesPriority:first")
+	assert.Error(t, err)
+}
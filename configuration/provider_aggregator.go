@@ -3,7 +3,6 @@ package configuration
 import (
 	"encoding/json"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
@@ -47,6 +46,9 @@ func NewProviderAggregator(gc *GlobalConfiguration) ProviderAggregator {
 	if gc.Boltdb != nil {
 		provider.quietAddProvider(gc.Boltdb)
 	}
+	if gc.Redis != nil {
+		provider.quietAddProvider(gc.Redis)
+	}
 	if gc.Kubernetes != nil {
 		provider.quietAddProvider(gc.Kubernetes)
 	}
@@ -68,13 +70,16 @@ func NewProviderAggregator(gc *GlobalConfiguration) ProviderAggregator {
 	if gc.ServiceFabric != nil {
 		provider.quietAddProvider(gc.ServiceFabric)
 	}
+	if gc.HTTP != nil {
+		provider.quietAddProvider(gc.HTTP)
+	}
 	return provider
 }
 
 func (p *ProviderAggregator) quietAddProvider(provider provider.Provider) {
 	err := p.AddProvider(provider)
 	if err != nil {
-		log.Errorf("Error initializing provider %T: %v", provider, err)
+		logger.Errorf("Error initializing provider %T: %v", provider, err)
 	}
 }
 
@@ -98,14 +103,14 @@ func (p ProviderAggregator) Provide(configurationChan chan<- types.ConfigMessage
 	for _, p := range p.providers {
 		jsonConf, err := json.Marshal(p)
 		if err != nil {
-			log.Debugf("Unable to marshal provider conf %T with error: %v", p, err)
+			logger.Debugf("Unable to marshal provider conf %T with error: %v", p, err)
 		}
-		log.Infof("Starting provider %T %s", p, jsonConf)
+		logger.Infof("Starting provider %T %s", p, jsonConf)
 		currentProvider := p
 		safe.Go(func() {
 			err := currentProvider.Provide(configurationChan, pool)
 			if err != nil {
-				log.Errorf("Error starting provider %T: %v", p, err)
+				logger.Errorf("Error starting provider %T: %v", p, err)
 			}
 		})
 	}
@@ -11,6 +11,8 @@ import (
 	"github.com/urfave/negroni"
 )
 
+var logger = log.WithModule("configuration/router")
+
 // NewInternalRouterAggregator Create a new internalRouterAggregator
 func NewInternalRouterAggregator(globalConfiguration configuration.GlobalConfiguration, entryPointName string) *InternalRouterAggregator {
 	var serverMiddlewares []negroni.Handler
@@ -20,7 +22,7 @@ func NewInternalRouterAggregator(globalConfiguration configuration.GlobalConfigu
 			globalConfiguration.EntryPoints[entryPointName].WhiteList.SourceRange,
 			globalConfiguration.EntryPoints[entryPointName].WhiteList.UseXForwardedFor)
 		if err != nil {
-			log.Fatalf("Error creating whitelist middleware: %s", err)
+			logger.Fatalf("Error creating whitelist middleware: %s", err)
 		}
 		if ipWhitelistMiddleware != nil {
 			serverMiddlewares = append(serverMiddlewares, ipWhitelistMiddleware)
@@ -30,7 +32,7 @@ func NewInternalRouterAggregator(globalConfiguration configuration.GlobalConfigu
 	if globalConfiguration.EntryPoints[entryPointName].Auth != nil {
 		authMiddleware, err := mauth.NewAuthenticator(globalConfiguration.EntryPoints[entryPointName].Auth, nil)
 		if err != nil {
-			log.Fatalf("Error creating authenticator middleware: %s", err)
+			logger.Fatalf("Error creating authenticator middleware: %s", err)
 		}
 		serverMiddlewares = append(serverMiddlewares, authMiddleware)
 	}
@@ -23,12 +23,13 @@ import (
 	"strings"
 
 	"github.com/containous/traefik/log"
-
 	"golang.org/x/net/http/httpguts"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/hpack"
 )
 
+var logger = log.WithModule("h2c")
+
 var (
 	http2VerboseLogs bool
 )
@@ -55,12 +56,12 @@ func (s Server) Serve(l net.Listener) error {
 	s.Server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "PRI" && r.URL.Path == "*" && r.Proto == "HTTP/2.0" {
 			if http2VerboseLogs {
-				log.Debugf("Attempting h2c with prior knowledge.")
+				logger.Debugf("Attempting h2c with prior knowledge.")
 			}
 			conn, err := initH2CWithPriorKnowledge(w)
 			if err != nil {
 				if http2VerboseLogs {
-					log.Debugf("Error h2c with prior knowledge: %v", err)
+					logger.Debugf("Error h2c with prior knowledge: %v", err)
 				}
 				return
 			}
@@ -114,7 +115,7 @@ func initH2CWithPriorKnowledge(w http.ResponseWriter) (net.Conn, error) {
 
 	conn.Close()
 	if http2VerboseLogs {
-		log.Printf(
+		logger.Printf(
 			"Missing the request body portion of the client preface. Wanted: %v Got: %v",
 			[]byte(expectedBody),
 			buf[0:n],
@@ -16,6 +16,8 @@ import (
 	"github.com/vulcand/oxy/roundrobin"
 )
 
+var logger = log.WithModule("healthcheck")
+
 var singleton *HealthCheck
 var once sync.Once
 
@@ -116,17 +118,17 @@ func (hc *HealthCheck) SetBackendsConfiguration(parentCtx context.Context, backe
 }
 
 func (hc *HealthCheck) execute(ctx context.Context, backend *BackendConfig) {
-	log.Debugf("Initial health check for backend: %q", backend.name)
+	logger.Debugf("Initial health check for backend: %q", backend.name)
 	hc.checkBackend(backend)
 	ticker := time.NewTicker(backend.Interval)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
-			log.Debugf("Stopping current health check goroutines of backend: %s", backend.name)
+			logger.Debugf("Stopping current health check goroutines of backend: %s", backend.name)
 			return
 		case <-ticker.C:
-			log.Debugf("Refreshing health check for backend: %s", backend.name)
+			logger.Debugf("Refreshing health check for backend: %s", backend.name)
 			hc.checkBackend(backend)
 		}
 	}
@@ -138,11 +140,11 @@ func (hc *HealthCheck) checkBackend(backend *BackendConfig) {
 	for _, backendurl := range backend.disabledURLs {
 		serverUpMetricValue := float64(0)
 		if err := checkHealth(backendurl.url, backend); err == nil {
-			log.Warnf("Health check up: Returning to server list. Backend: %q URL: %q Weight: %d", backend.name, backendurl.url.String(), backendurl.weight)
+			logger.Warnf("Health check up: Returning to server list. Backend: %q URL: %q Weight: %d", backend.name, backendurl.url.String(), backendurl.weight)
 			backend.LB.UpsertServer(backendurl.url, roundrobin.Weight(backendurl.weight))
 			serverUpMetricValue = 1
 		} else {
-			log.Warnf("Health check still failing. Backend: %q URL: %q Reason: %s", backend.name, backendurl.url.String(), err)
+			logger.Warnf("Health check still failing. Backend: %q URL: %q Reason: %s", backend.name, backendurl.url.String(), err)
 			newDisabledURLs = append(newDisabledURLs, backendurl)
 		}
 		labelValues := []string{"backend", backend.name, "url", backendurl.url.String()}
@@ -162,7 +164,7 @@ func (hc *HealthCheck) checkBackend(backend *BackendConfig) {
 					weight = 1
 				}
 			}
-			log.Warnf("Health check failed: Remove from server list. Backend: %q URL: %q Weight: %d Reason: %s", backend.name, url.String(), weight, err)
+			logger.Warnf("Health check failed: Remove from server list. Backend: %q URL: %q Weight: %d Reason: %s", backend.name, url.String(), weight, err)
 			backend.LB.RemoveServer(url)
 			backend.disabledURLs = append(backend.disabledURLs, backendURL{url, weight})
 			serverUpMetricValue = 0
@@ -12,6 +12,8 @@ import (
 	"github.com/patrickmn/go-cache"
 )
 
+var logger = log.WithModule("hostresolver")
+
 type cnameResolv struct {
 	TTL    time.Duration
 	Record string
@@ -49,7 +51,7 @@ func (hr *Resolver) CNAMEFlatten(host string) (string, string) {
 		for depth := 0; depth < hr.ResolvDepth; depth++ {
 			resolv, err := cnameResolve(request, hr.ResolvConfig)
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 				break
 			}
 			if resolv == nil {
@@ -85,7 +87,7 @@ func cnameResolve(host string, resolvPath string) (*cnameResolv, error) {
 	for _, server := range config.Servers {
 		tempRecord, err := getRecord(client, m, server, config.Port)
 		if err != nil {
-			log.Errorf("Failed to resolve host %s: %v", host, err)
+			logger.Errorf("Failed to resolve host %s: %v", host, err)
 			continue
 		}
 		result = append(result, tempRecord)
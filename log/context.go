@@ -0,0 +1,47 @@
+package log
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Names of the context fields of the logs.
+const (
+	ModuleName     = "module"
+	ProviderName   = "providerName"
+	EntryPointName = "entryPointName"
+	FrontendName   = "frontendName"
+	BackendName    = "backendName"
+	RequestID      = "requestID"
+)
+
+type contextKey struct{}
+
+// With returns a copy of the context in which the logger has the given fields,
+// in addition to the fields already set in the context.
+func With(ctx context.Context, fields logrus.Fields) context.Context {
+	merged := logrus.Fields{}
+	for key, value := range fieldsFromContext(ctx) {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// FromContext creates an entry from the standard logger with the fields of the context.
+func FromContext(ctx context.Context) *logrus.Entry {
+	return logger.WithFields(fieldsFromContext(ctx))
+}
+
+func fieldsFromContext(ctx context.Context) logrus.Fields {
+	if ctx == nil {
+		return nil
+	}
+
+	fields, _ := ctx.Value(contextKey{}).(logrus.Fields)
+	return fields
+}
@@ -35,11 +35,13 @@ func Context(context interface{}) *logrus.Entry {
 // SetOutput sets the standard logger output.
 func SetOutput(out io.Writer) {
 	logrus.SetOutput(out)
+	setModulesOutput(out)
 }
 
 // SetFormatter sets the standard logger formatter.
 func SetFormatter(formatter logrus.Formatter) {
 	logrus.SetFormatter(formatter)
+	setModulesFormatter(formatter)
 }
 
 // SetLevel sets the standard logger level.
@@ -221,7 +223,7 @@ func OpenFile(path string) error {
 
 // CloseFile closes the log and sets the Output to stdout
 func CloseFile() error {
-	logrus.SetOutput(os.Stdout)
+	SetOutput(os.Stdout)
 
 	if logFile != nil {
 		return logFile.Close()
@@ -0,0 +1,314 @@
+package log
+
+import (
+	"context"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	modulesMu        sync.RWMutex
+	moduleLoggers              = make(map[string]*logrus.Logger)
+	modulesOutput    io.Writer = os.Stdout
+	modulesFormatter logrus.Formatter
+	// modulesEnabled is 1 when at least one module has its own level, to skip the lookup of the module otherwise.
+	modulesEnabled int32
+	// modulesGeneration changes with the module levels, for the module loggers to resolve their logger again.
+	modulesGeneration uint64
+)
+
+// SetModuleLevels replaces the log levels of the modules.
+// A module is a package path relative to the Traefik repository, e.g. "provider/kubernetes",
+// and its level also applies to the packages below it.
+func SetModuleLevels(levels map[string]logrus.Level) {
+	modulesMu.Lock()
+	defer modulesMu.Unlock()
+
+	loggers := make(map[string]*logrus.Logger, len(levels))
+	for module, level := range levels {
+		module = strings.Trim(module, "/")
+		if module == "" {
+			continue
+		}
+
+		moduleLogger := logrus.New()
+		moduleLogger.Out = modulesOutput
+		if modulesFormatter != nil {
+			moduleLogger.Formatter = modulesFormatter
+		}
+		// The hooks are shared with the standard logger.
+		moduleLogger.Hooks = logrus.StandardLogger().Hooks
+		moduleLogger.SetLevel(level)
+
+		loggers[module] = moduleLogger
+	}
+	moduleLoggers = loggers
+	atomic.AddUint64(&modulesGeneration, 1)
+
+	if len(moduleLoggers) > 0 {
+		atomic.StoreInt32(&modulesEnabled, 1)
+	} else {
+		atomic.StoreInt32(&modulesEnabled, 0)
+	}
+}
+
+// GetModuleLevels returns the log levels of the modules.
+func GetModuleLevels() map[string]logrus.Level {
+	modulesMu.RLock()
+	defer modulesMu.RUnlock()
+
+	levels := make(map[string]logrus.Level, len(moduleLoggers))
+	for module, moduleLogger := range moduleLoggers {
+		levels[module] = moduleLogger.GetLevel()
+	}
+	return levels
+}
+
+func setModulesOutput(out io.Writer) {
+	modulesMu.Lock()
+	defer modulesMu.Unlock()
+
+	modulesOutput = out
+	for _, moduleLogger := range moduleLoggers {
+		moduleLogger.SetOutput(out)
+	}
+}
+
+func setModulesFormatter(formatter logrus.Formatter) {
+	modulesMu.Lock()
+	defer modulesMu.Unlock()
+
+	modulesFormatter = formatter
+	for _, moduleLogger := range moduleLoggers {
+		moduleLogger.SetFormatter(formatter)
+	}
+}
+
+// findModuleLogger returns the logger of the most specific module containing the given package.
+// It must be called with the lock held.
+func findModuleLogger(pkg string) (string, *logrus.Logger) {
+	var names []string
+	for name := range moduleLoggers {
+		if pkg == name || strings.HasPrefix(pkg, name+"/") {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+	return names[0], moduleLoggers[names[0]]
+}
+
+// ModuleLogger is the logger of a module, which uses the level of the module when it has its own level,
+// and the standard logger otherwise.
+type ModuleLogger struct {
+	name  string
+	state atomic.Value
+}
+
+// moduleState is the logger resolved for a module by a generation of module levels.
+type moduleState struct {
+	generation uint64
+	entry      *logrus.Entry
+}
+
+// WithModule returns the logger of a module, which is a package path relative to the Traefik repository,
+// e.g. "provider/kubernetes". It is meant to be created once per package.
+func WithModule(name string) *ModuleLogger {
+	return &ModuleLogger{name: strings.Trim(name, "/")}
+}
+
+// moduleEntry returns the entry of the module logger, or nil when this module has no level of its own.
+// The module logger is resolved once per change of the module levels.
+func (m *ModuleLogger) moduleEntry() *logrus.Entry {
+	if atomic.LoadInt32(&modulesEnabled) == 0 {
+		return nil
+	}
+
+	generation := atomic.LoadUint64(&modulesGeneration)
+	if state, ok := m.state.Load().(*moduleState); ok && state.generation == generation {
+		return state.entry
+	}
+
+	modulesMu.RLock()
+	name, moduleLogger := findModuleLogger(m.name)
+	modulesMu.RUnlock()
+
+	state := &moduleState{generation: generation}
+	if moduleLogger != nil {
+		state.entry = moduleLogger.WithField(ModuleName, name)
+	}
+	m.state.Store(state)
+
+	return state.entry
+}
+
+func (m *ModuleLogger) current() Logger {
+	if entry := m.moduleEntry(); entry != nil {
+		return entry
+	}
+	return logger
+}
+
+// GetLevel returns the level of the module, or the standard logger level when this module has no level of its own.
+func (m *ModuleLogger) GetLevel() logrus.Level {
+	if entry := m.moduleEntry(); entry != nil {
+		return entry.Logger.GetLevel()
+	}
+	return GetLevel()
+}
+
+// Context sets the Context of the logger.
+func (m *ModuleLogger) Context(context interface{}) *logrus.Entry {
+	return m.current().WithField("context", context)
+}
+
+// FromContext creates an entry from the logger of the module with the fields of the context.
+func (m *ModuleLogger) FromContext(ctx context.Context) *logrus.Entry {
+	return m.current().WithFields(fieldsFromContext(ctx))
+}
+
+// WithError creates an entry from the logger of the module and adds an error to it.
+func (m *ModuleLogger) WithError(err error) *logrus.Entry {
+	return m.current().WithError(err)
+}
+
+// WithField creates an entry from the logger of the module and adds a field to it.
+func (m *ModuleLogger) WithField(key string, value interface{}) *logrus.Entry {
+	return m.current().WithField(key, value)
+}
+
+// WithFields creates an entry from the logger of the module and adds multiple fields to it.
+func (m *ModuleLogger) WithFields(fields logrus.Fields) *logrus.Entry {
+	return m.current().WithFields(fields)
+}
+
+// Debug logs a message at level Debug on the logger of the module.
+func (m *ModuleLogger) Debug(args ...interface{}) {
+	m.current().Debug(args...)
+}
+
+// Print logs a message at level Info on the logger of the module.
+func (m *ModuleLogger) Print(args ...interface{}) {
+	m.current().Print(args...)
+}
+
+// Info logs a message at level Info on the logger of the module.
+func (m *ModuleLogger) Info(args ...interface{}) {
+	m.current().Info(args...)
+}
+
+// Warn logs a message at level Warn on the logger of the module.
+func (m *ModuleLogger) Warn(args ...interface{}) {
+	m.current().Warn(args...)
+}
+
+// Warning logs a message at level Warn on the logger of the module.
+func (m *ModuleLogger) Warning(args ...interface{}) {
+	m.current().Warning(args...)
+}
+
+// Error logs a message at level Error on the logger of the module.
+func (m *ModuleLogger) Error(args ...interface{}) {
+	m.current().Error(args...)
+}
+
+// Panic logs a message at level Panic on the logger of the module.
+func (m *ModuleLogger) Panic(args ...interface{}) {
+	m.current().Panic(args...)
+}
+
+// Fatal logs a message at level Fatal on the logger of the module.
+func (m *ModuleLogger) Fatal(args ...interface{}) {
+	m.current().Fatal(args...)
+}
+
+// Debugf logs a message at level Debug on the logger of the module.
+func (m *ModuleLogger) Debugf(format string, args ...interface{}) {
+	m.current().Debugf(format, args...)
+}
+
+// Printf logs a message at level Info on the logger of the module.
+func (m *ModuleLogger) Printf(format string, args ...interface{}) {
+	m.current().Printf(format, args...)
+}
+
+// Infof logs a message at level Info on the logger of the module.
+func (m *ModuleLogger) Infof(format string, args ...interface{}) {
+	m.current().Infof(format, args...)
+}
+
+// Warnf logs a message at level Warn on the logger of the module.
+func (m *ModuleLogger) Warnf(format string, args ...interface{}) {
+	m.current().Warnf(format, args...)
+}
+
+// Warningf logs a message at level Warn on the logger of the module.
+func (m *ModuleLogger) Warningf(format string, args ...interface{}) {
+	m.current().Warningf(format, args...)
+}
+
+// Errorf logs a message at level Error on the logger of the module.
+func (m *ModuleLogger) Errorf(format string, args ...interface{}) {
+	m.current().Errorf(format, args...)
+}
+
+// Panicf logs a message at level Panic on the logger of the module.
+func (m *ModuleLogger) Panicf(format string, args ...interface{}) {
+	m.current().Panicf(format, args...)
+}
+
+// Fatalf logs a message at level Fatal on the logger of the module.
+func (m *ModuleLogger) Fatalf(format string, args ...interface{}) {
+	m.current().Fatalf(format, args...)
+}
+
+// Debugln logs a message at level Debug on the logger of the module.
+func (m *ModuleLogger) Debugln(args ...interface{}) {
+	m.current().Debugln(args...)
+}
+
+// Println logs a message at level Info on the logger of the module.
+func (m *ModuleLogger) Println(args ...interface{}) {
+	m.current().Println(args...)
+}
+
+// Infoln logs a message at level Info on the logger of the module.
+func (m *ModuleLogger) Infoln(args ...interface{}) {
+	m.current().Infoln(args...)
+}
+
+// Warnln logs a message at level Warn on the logger of the module.
+func (m *ModuleLogger) Warnln(args ...interface{}) {
+	m.current().Warnln(args...)
+}
+
+// Warningln logs a message at level Warn on the logger of the module.
+func (m *ModuleLogger) Warningln(args ...interface{}) {
+	m.current().Warningln(args...)
+}
+
+// Errorln logs a message at level Error on the logger of the module.
+func (m *ModuleLogger) Errorln(args ...interface{}) {
+	m.current().Errorln(args...)
+}
+
+// Panicln logs a message at level Panic on the logger of the module.
+func (m *ModuleLogger) Panicln(args ...interface{}) {
+	m.current().Panicln(args...)
+}
+
+// Fatalln logs a message at level Fatal on the logger of the module.
+func (m *ModuleLogger) Fatalln(args ...interface{}) {
+	m.current().Fatalln(args...)
+}
@@ -0,0 +1,170 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleLevels(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		moduleLevels map[string]logrus.Level
+		expected     []string
+		notExpected  []string
+	}{
+		{
+			desc:        "no module levels",
+			expected:    []string{"error message"},
+			notExpected: []string{"debug message", "module="},
+		},
+		{
+			desc:         "more verbose module level",
+			moduleLevels: map[string]logrus.Level{"provider/kubernetes": logrus.DebugLevel},
+			expected:     []string{"debug message", "error message", "module=provider/kubernetes"},
+		},
+		{
+			desc:         "more verbose parent module level",
+			moduleLevels: map[string]logrus.Level{"provider": logrus.DebugLevel},
+			expected:     []string{"debug message", "error message", "module=provider"},
+		},
+		{
+			desc:         "less verbose module level",
+			moduleLevels: map[string]logrus.Level{"provider/kubernetes": logrus.PanicLevel},
+			notExpected:  []string{"debug message", "error message"},
+		},
+		{
+			desc:         "other module level",
+			moduleLevels: map[string]logrus.Level{"server": logrus.DebugLevel},
+			expected:     []string{"error message"},
+			notExpected:  []string{"debug message", "module="},
+		},
+	}
+
+	defer SetOutput(os.Stdout)
+	defer SetLevel(GetLevel())
+	defer SetModuleLevels(nil)
+
+	// The same module logger is used for all the cases, to check that it follows the changes of the module levels.
+	moduleLogger := WithModule("provider/kubernetes")
+
+	for _, test := range testCases {
+		buf := &bytes.Buffer{}
+		SetOutput(buf)
+		SetLevel(logrus.ErrorLevel)
+		SetModuleLevels(test.moduleLevels)
+
+		moduleLogger.Debug("debug message")
+		moduleLogger.Errorf("error %s", "message")
+
+		for _, expected := range test.expected {
+			assert.Contains(t, buf.String(), expected, test.desc)
+		}
+		for _, notExpected := range test.notExpected {
+			assert.NotContains(t, buf.String(), notExpected, test.desc)
+		}
+	}
+}
+
+func TestModuleLoggerGetLevel(t *testing.T) {
+	defer SetLevel(GetLevel())
+	defer SetModuleLevels(nil)
+
+	SetLevel(logrus.ErrorLevel)
+	moduleLogger := WithModule("server")
+
+	assert.Equal(t, logrus.ErrorLevel, moduleLogger.GetLevel())
+
+	SetModuleLevels(map[string]logrus.Level{"server": logrus.DebugLevel})
+	assert.Equal(t, logrus.DebugLevel, moduleLogger.GetLevel())
+
+	SetModuleLevels(map[string]logrus.Level{"provider": logrus.DebugLevel})
+	assert.Equal(t, logrus.ErrorLevel, moduleLogger.GetLevel())
+}
+
+func TestStandardLoggerIgnoresModuleLevels(t *testing.T) {
+	defer SetOutput(os.Stdout)
+	defer SetLevel(GetLevel())
+	defer SetModuleLevels(nil)
+
+	buf := &bytes.Buffer{}
+	SetOutput(buf)
+	SetLevel(logrus.ErrorLevel)
+	SetModuleLevels(map[string]logrus.Level{"log": logrus.DebugLevel})
+
+	Debug("debug message")
+
+	assert.Empty(t, buf.String())
+}
+
+func TestGetModuleLevels(t *testing.T) {
+	defer SetModuleLevels(nil)
+
+	SetModuleLevels(map[string]logrus.Level{
+		"/provider/kubernetes/": logrus.DebugLevel,
+		"":                      logrus.InfoLevel,
+	})
+
+	assert.Equal(t, map[string]logrus.Level{"provider/kubernetes": logrus.DebugLevel}, GetModuleLevels())
+}
+
+func TestFindModuleLogger(t *testing.T) {
+	defer SetModuleLevels(nil)
+
+	SetModuleLevels(map[string]logrus.Level{
+		"provider":            logrus.InfoLevel,
+		"provider/kubernetes": logrus.DebugLevel,
+	})
+
+	testCases := []struct {
+		pkg      string
+		expected string
+	}{
+		{pkg: "provider", expected: "provider"},
+		{pkg: "provider/docker", expected: "provider"},
+		{pkg: "provider/kubernetes", expected: "provider/kubernetes"},
+		{pkg: "provider/kubernetes/crd", expected: "provider/kubernetes"},
+		{pkg: "providers", expected: ""},
+		{pkg: "server", expected: ""},
+	}
+
+	modulesMu.RLock()
+	defer modulesMu.RUnlock()
+
+	for _, test := range testCases {
+		name, moduleLogger := findModuleLogger(test.pkg)
+		assert.Equal(t, test.expected, name, test.pkg)
+		assert.Equal(t, test.expected != "", moduleLogger != nil, test.pkg)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := With(context.Background(), logrus.Fields{EntryPointName: "http", FrontendName: "frontend1"})
+	ctx = With(ctx, logrus.Fields{FrontendName: "frontend2", BackendName: "backend2"})
+
+	expected := logrus.Fields{
+		EntryPointName: "http",
+		FrontendName:   "frontend2",
+		BackendName:    "backend2",
+	}
+	assert.Equal(t, expected, FromContext(ctx).Data)
+	assert.Empty(t, FromContext(context.Background()).Data)
+}
+
+func TestModuleLoggerFromContext(t *testing.T) {
+	defer SetModuleLevels(nil)
+
+	SetModuleLevels(map[string]logrus.Level{"server": logrus.DebugLevel})
+
+	ctx := With(context.Background(), logrus.Fields{EntryPointName: "http"})
+
+	expected := logrus.Fields{
+		ModuleName:     "server",
+		EntryPointName: "http",
+	}
+	assert.Equal(t, expected, WithModule("server").FromContext(ctx).Data)
+}
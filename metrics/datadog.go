@@ -3,7 +3,6 @@ package metrics
 import (
 	"time"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
 	kitlog "github.com/go-kit/kit/log"
@@ -11,7 +10,7 @@ import (
 )
 
 var datadogClient = dogstatsd.New("traefik.", kitlog.LoggerFunc(func(keyvals ...interface{}) error {
-	log.Info(keyvals)
+	logger.Info(keyvals)
 	return nil
 }))
 
@@ -19,6 +18,8 @@ var datadogTicker *time.Ticker
 
 // Metric names consistent with https://github.com/DataDog/integrations-extras/pull/64
 const (
+	ddMetricsFrontendReqsName     = "frontend.request.total"
+	ddMetricsFrontendLatencyName  = "frontend.request.duration"
 	ddMetricsBackendReqsName      = "backend.request.total"
 	ddMetricsBackendLatencyName   = "backend.request.duration"
 	ddRetriesTotalName            = "backend.retries.total"
@@ -29,8 +30,18 @@ const (
 	ddEntrypointReqsName          = "entrypoint.request.total"
 	ddEntrypointReqDurationName   = "entrypoint.request.duration"
 	ddEntrypointOpenConnsName     = "entrypoint.connections.open"
+	ddEntrypointReqSizeName       = "entrypoint.request.size"
+	ddEntrypointRespSizeName      = "entrypoint.response.size"
 	ddOpenConnsName               = "backend.connections.open"
 	ddServerUpName                = "backend.server.up"
+	ddMetricsBackendReqSizeName   = "backend.request.size"
+	ddMetricsBackendRespSizeName  = "backend.response.size"
+	ddMetricsServerReqsName       = "backend.server.request.total"
+	ddMetricsServerLatencyName    = "backend.server.request.duration"
+	ddTLSHandshakesName           = "tls.handshake.total"
+	ddTLSHandshakeDurationName    = "tls.handshake.duration"
+	ddTLSHandshakeErrorsName      = "tls.handshake.errors"
+	ddCertificateDaysToExpiryName = "tls.certificate.daysToExpiry"
 )
 
 // RegisterDatadog registers the metrics pusher if this didn't happen yet and creates a datadog Registry instance.
@@ -48,11 +59,23 @@ func RegisterDatadog(config *types.Datadog) Registry {
 		entrypointReqsCounter:          datadogClient.NewCounter(ddEntrypointReqsName, 1.0),
 		entrypointReqDurationHistogram: datadogClient.NewHistogram(ddEntrypointReqDurationName, 1.0),
 		entrypointOpenConnsGauge:       datadogClient.NewGauge(ddEntrypointOpenConnsName),
+		entrypointReqSizeHistogram:     datadogClient.NewHistogram(ddEntrypointReqSizeName, 1.0),
+		entrypointRespSizeHistogram:    datadogClient.NewHistogram(ddEntrypointRespSizeName, 1.0),
+		frontendReqsCounter:            datadogClient.NewCounter(ddMetricsFrontendReqsName, 1.0),
+		frontendReqDurationHistogram:   datadogClient.NewHistogram(ddMetricsFrontendLatencyName, 1.0),
 		backendReqsCounter:             datadogClient.NewCounter(ddMetricsBackendReqsName, 1.0),
 		backendReqDurationHistogram:    datadogClient.NewHistogram(ddMetricsBackendLatencyName, 1.0),
 		backendRetriesCounter:          datadogClient.NewCounter(ddRetriesTotalName, 1.0),
 		backendOpenConnsGauge:          datadogClient.NewGauge(ddOpenConnsName),
 		backendServerUpGauge:           datadogClient.NewGauge(ddServerUpName),
+		backendReqSizeHistogram:        datadogClient.NewHistogram(ddMetricsBackendReqSizeName, 1.0),
+		backendRespSizeHistogram:       datadogClient.NewHistogram(ddMetricsBackendRespSizeName, 1.0),
+		serverReqsCounter:              datadogClient.NewCounter(ddMetricsServerReqsName, 1.0),
+		serverReqDurationHistogram:     datadogClient.NewHistogram(ddMetricsServerLatencyName, 1.0),
+		tlsHandshakesCounter:           datadogClient.NewCounter(ddTLSHandshakesName, 1.0),
+		tlsHandshakeDurationHistogram:  datadogClient.NewHistogram(ddTLSHandshakeDurationName, 1.0),
+		tlsHandshakeErrorsCounter:      datadogClient.NewCounter(ddTLSHandshakeErrorsName, 1.0),
+		certificateDaysToExpiryGauge:   datadogClient.NewGauge(ddCertificateDaysToExpiryName),
 	}
 
 	return registry
@@ -65,7 +88,7 @@ func initDatadogClient(config *types.Datadog) *time.Ticker {
 	}
 	pushInterval, err := time.ParseDuration(config.PushInterval)
 	if err != nil {
-		log.Warnf("Unable to parse %s into pushInterval, using 10s as default value", config.PushInterval)
+		logger.Warnf("Unable to parse %s into pushInterval, using 10s as default value", config.PushInterval)
 		pushInterval = 10 * time.Second
 	}
 
@@ -7,7 +7,6 @@ import (
 	"regexp"
 	"time"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
 	kitlog "github.com/go-kit/kit/log"
@@ -25,6 +24,8 @@ type influxDBWriter struct {
 var influxDBTicker *time.Ticker
 
 const (
+	influxDBMetricsFrontendReqsName     = "traefik.frontend.requests.total"
+	influxDBMetricsFrontendLatencyName  = "traefik.frontend.request.duration"
 	influxDBMetricsBackendReqsName      = "traefik.backend.requests.total"
 	influxDBMetricsBackendLatencyName   = "traefik.backend.request.duration"
 	influxDBRetriesTotalName            = "traefik.backend.retries.total"
@@ -35,8 +36,18 @@ const (
 	influxDBEntrypointReqsName          = "traefik.entrypoint.requests.total"
 	influxDBEntrypointReqDurationName   = "traefik.entrypoint.request.duration"
 	influxDBEntrypointOpenConnsName     = "traefik.entrypoint.connections.open"
+	influxDBEntrypointReqSizeName       = "traefik.entrypoint.request.size"
+	influxDBEntrypointRespSizeName      = "traefik.entrypoint.response.size"
 	influxDBOpenConnsName               = "traefik.backend.connections.open"
 	influxDBServerUpName                = "traefik.backend.server.up"
+	influxDBMetricsBackendReqSizeName   = "traefik.backend.request.size"
+	influxDBMetricsBackendRespSizeName  = "traefik.backend.response.size"
+	influxDBMetricsServerReqsName       = "traefik.backend.server.requests.total"
+	influxDBMetricsServerLatencyName    = "traefik.backend.server.request.duration"
+	influxDBTLSHandshakesName           = "traefik.tls.handshake.total"
+	influxDBTLSHandshakeDurationName    = "traefik.tls.handshake.duration"
+	influxDBTLSHandshakeErrorsName      = "traefik.tls.handshake.errors"
+	influxDBCertificateDaysToExpiryName = "traefik.tls.certificate.daysToExpiry"
 )
 
 // RegisterInfluxDB registers the metrics pusher if this didn't happen yet and creates a InfluxDB Registry instance.
@@ -57,11 +68,23 @@ func RegisterInfluxDB(config *types.InfluxDB) Registry {
 		entrypointReqsCounter:          influxDBClient.NewCounter(influxDBEntrypointReqsName),
 		entrypointReqDurationHistogram: influxDBClient.NewHistogram(influxDBEntrypointReqDurationName),
 		entrypointOpenConnsGauge:       influxDBClient.NewGauge(influxDBEntrypointOpenConnsName),
+		entrypointReqSizeHistogram:     influxDBClient.NewHistogram(influxDBEntrypointReqSizeName),
+		entrypointRespSizeHistogram:    influxDBClient.NewHistogram(influxDBEntrypointRespSizeName),
+		frontendReqsCounter:            influxDBClient.NewCounter(influxDBMetricsFrontendReqsName),
+		frontendReqDurationHistogram:   influxDBClient.NewHistogram(influxDBMetricsFrontendLatencyName),
 		backendReqsCounter:             influxDBClient.NewCounter(influxDBMetricsBackendReqsName),
 		backendReqDurationHistogram:    influxDBClient.NewHistogram(influxDBMetricsBackendLatencyName),
 		backendRetriesCounter:          influxDBClient.NewCounter(influxDBRetriesTotalName),
 		backendOpenConnsGauge:          influxDBClient.NewGauge(influxDBOpenConnsName),
 		backendServerUpGauge:           influxDBClient.NewGauge(influxDBServerUpName),
+		backendReqSizeHistogram:        influxDBClient.NewHistogram(influxDBMetricsBackendReqSizeName),
+		backendRespSizeHistogram:       influxDBClient.NewHistogram(influxDBMetricsBackendRespSizeName),
+		serverReqsCounter:              influxDBClient.NewCounter(influxDBMetricsServerReqsName),
+		serverReqDurationHistogram:     influxDBClient.NewHistogram(influxDBMetricsServerLatencyName),
+		tlsHandshakesCounter:           influxDBClient.NewCounter(influxDBTLSHandshakesName),
+		tlsHandshakeDurationHistogram:  influxDBClient.NewHistogram(influxDBTLSHandshakeDurationName),
+		tlsHandshakeErrorsCounter:      influxDBClient.NewCounter(influxDBTLSHandshakeErrorsName),
+		certificateDaysToExpiryGauge:   influxDBClient.NewGauge(influxDBCertificateDaysToExpiryName),
 	}
 }
 
@@ -71,24 +94,24 @@ func initInfluxDBClient(config *types.InfluxDB) *influx.Influx {
 	switch config.Protocol {
 	case "udp":
 		if len(config.Database) > 0 || len(config.RetentionPolicy) > 0 {
-			log.Warn("Database and RetentionPolicy are only used when protocol is http.")
+			logger.Warn("Database and RetentionPolicy are only used when protocol is http.")
 			config.Database = ""
 			config.RetentionPolicy = ""
 		}
 	case "http":
 		if u, err := url.Parse(config.Address); err == nil {
 			if u.Scheme != "http" && u.Scheme != "https" {
-				log.Warnf("InfluxDB address %s should specify a scheme of http or https, defaulting to http.", config.Address)
+				logger.Warnf("InfluxDB address %s should specify a scheme of http or https, defaulting to http.", config.Address)
 				config.Address = "http://" + config.Address
 			}
 		} else {
-			log.Errorf("Unable to parse influxdb address: %v, defaulting to udp.", err)
+			logger.Errorf("Unable to parse influxdb address: %v, defaulting to udp.", err)
 			config.Protocol = "udp"
 			config.Database = ""
 			config.RetentionPolicy = ""
 		}
 	default:
-		log.Warnf("Unsupported protocol: %s, defaulting to udp.", config.Protocol)
+		logger.Warnf("Unsupported protocol: %s, defaulting to udp.", config.Protocol)
 		config.Protocol = "udp"
 		config.Database = ""
 		config.RetentionPolicy = ""
@@ -101,7 +124,7 @@ func initInfluxDBClient(config *types.InfluxDB) *influx.Influx {
 			RetentionPolicy: config.RetentionPolicy,
 		},
 		kitlog.LoggerFunc(func(keyvals ...interface{}) error {
-			log.Info(keyvals)
+			logger.Info(keyvals)
 			return nil
 		}))
 }
@@ -110,7 +133,7 @@ func initInfluxDBClient(config *types.InfluxDB) *influx.Influx {
 func initInfluxDBTicker(config *types.InfluxDB) *time.Ticker {
 	pushInterval, err := time.ParseDuration(config.PushInterval)
 	if err != nil {
-		log.Warnf("Unable to parse %s into pushInterval, using 10s as default value", config.PushInterval)
+		logger.Warnf("Unable to parse %s into pushInterval, using 10s as default value", config.PushInterval)
 		pushInterval = 10 * time.Second
 	}
 
@@ -144,7 +167,7 @@ func (w *influxDBWriter) Write(bp influxdb.BatchPoints) error {
 	defer c.Close()
 
 	if writeErr := c.Write(bp); writeErr != nil {
-		log.Errorf("Error writing to influx: %s", writeErr.Error())
+		logger.Errorf("Error writing to influx: %s", writeErr.Error())
 		if handleErr := w.handleWriteError(c, writeErr); handleErr != nil {
 			return handleErr
 		}
@@ -182,7 +205,7 @@ func (w *influxDBWriter) handleWriteError(c influxdb.Client, writeErr error) err
 		qStr = fmt.Sprintf("%s WITH NAME \"%s\"", qStr, w.config.RetentionPolicy)
 	}
 
-	log.Debugf("Influx database does not exist, attempting to create with query: %s", qStr)
+	logger.Debugf("Influx database does not exist, attempting to create with query: %s", qStr)
 
 	q := influxdb.NewQuery(qStr, "", "")
 	response, queryErr := c.Query(q)
@@ -190,10 +213,10 @@ func (w *influxDBWriter) handleWriteError(c influxdb.Client, writeErr error) err
 		queryErr = response.Error()
 	}
 	if queryErr != nil {
-		log.Errorf("Error creating InfluxDB database: %s", queryErr)
+		logger.Errorf("Error creating InfluxDB database: %s", queryErr)
 		return queryErr
 	}
 
-	log.Debugf("Successfully created influx database: %s", w.config.Database)
+	logger.Debugf("Successfully created influx database: %s", w.config.Database)
 	return nil
 }
@@ -1,10 +1,13 @@
 package metrics
 
 import (
+	"github.com/containous/traefik/log"
 	"github.com/go-kit/kit/metrics"
 	"github.com/go-kit/kit/metrics/multi"
 )
 
+var logger = log.WithModule("metrics")
+
 // Registry has to implemented by any system that wants to monitor and expose metrics.
 type Registry interface {
 	// IsEnabled shows whether metrics instrumentation is enabled.
@@ -15,11 +18,18 @@ type Registry interface {
 	ConfigReloadsFailureCounter() metrics.Counter
 	LastConfigReloadSuccessGauge() metrics.Gauge
 	LastConfigReloadFailureGauge() metrics.Gauge
+	ConfigBuildDurationHistogram() metrics.Histogram
 
 	// entry point metrics
 	EntrypointReqsCounter() metrics.Counter
 	EntrypointReqDurationHistogram() metrics.Histogram
 	EntrypointOpenConnsGauge() metrics.Gauge
+	EntrypointReqSizeHistogram() metrics.Histogram
+	EntrypointRespSizeHistogram() metrics.Histogram
+
+	// frontend metrics
+	FrontendReqsCounter() metrics.Counter
+	FrontendReqDurationHistogram() metrics.Histogram
 
 	// backend metrics
 	BackendReqsCounter() metrics.Counter
@@ -27,6 +37,29 @@ type Registry interface {
 	BackendOpenConnsGauge() metrics.Gauge
 	BackendRetriesCounter() metrics.Counter
 	BackendServerUpGauge() metrics.Gauge
+	BackendReqSizeHistogram() metrics.Histogram
+	BackendRespSizeHistogram() metrics.Histogram
+
+	// server metrics, partitioned by backend and server URL
+	ServerReqsCounter() metrics.Counter
+	ServerReqDurationHistogram() metrics.Histogram
+
+	// TLS metrics
+	TLSHandshakesCounter() metrics.Counter
+	TLSHandshakeDurationHistogram() metrics.Histogram
+	TLSHandshakeErrorsCounter() metrics.Counter
+
+	// certificate metrics
+	CertificateDaysToExpiryGauge() metrics.Gauge
+
+	// provider metrics
+	ProviderEventsCounter() metrics.Counter
+	ProviderThrottledConfigsCounter() metrics.Counter
+	ProviderDroppedConfigsCounter() metrics.Counter
+	ProviderLastSyncGauge() metrics.Gauge
+	ProviderFrontendsGauge() metrics.Gauge
+	ProviderBackendsGauge() metrics.Gauge
+	ProviderServersGauge() metrics.Gauge
 }
 
 // NewVoidRegistry is a noop implementation of metrics.Registry.
@@ -43,14 +76,34 @@ func NewMultiRegistry(registries []Registry) Registry {
 	var configReloadsFailureCounter []metrics.Counter
 	var lastConfigReloadSuccessGauge []metrics.Gauge
 	var lastConfigReloadFailureGauge []metrics.Gauge
+	var configBuildDurationHistogram []metrics.Histogram
 	var entrypointReqsCounter []metrics.Counter
 	var entrypointReqDurationHistogram []metrics.Histogram
 	var entrypointOpenConnsGauge []metrics.Gauge
+	var entrypointReqSizeHistogram []metrics.Histogram
+	var entrypointRespSizeHistogram []metrics.Histogram
+	var frontendReqsCounter []metrics.Counter
+	var frontendReqDurationHistogram []metrics.Histogram
 	var backendReqsCounter []metrics.Counter
 	var backendReqDurationHistogram []metrics.Histogram
 	var backendOpenConnsGauge []metrics.Gauge
 	var backendRetriesCounter []metrics.Counter
 	var backendServerUpGauge []metrics.Gauge
+	var backendReqSizeHistogram []metrics.Histogram
+	var backendRespSizeHistogram []metrics.Histogram
+	var serverReqsCounter []metrics.Counter
+	var serverReqDurationHistogram []metrics.Histogram
+	var tlsHandshakesCounter []metrics.Counter
+	var tlsHandshakeDurationHistogram []metrics.Histogram
+	var tlsHandshakeErrorsCounter []metrics.Counter
+	var certificateDaysToExpiryGauge []metrics.Gauge
+	var providerEventsCounter []metrics.Counter
+	var providerThrottledConfigsCounter []metrics.Counter
+	var providerDroppedConfigsCounter []metrics.Counter
+	var providerLastSyncGauge []metrics.Gauge
+	var providerFrontendsGauge []metrics.Gauge
+	var providerBackendsGauge []metrics.Gauge
+	var providerServersGauge []metrics.Gauge
 
 	for _, r := range registries {
 		if r.ConfigReloadsCounter() != nil {
@@ -65,6 +118,9 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.LastConfigReloadFailureGauge() != nil {
 			lastConfigReloadFailureGauge = append(lastConfigReloadFailureGauge, r.LastConfigReloadFailureGauge())
 		}
+		if r.ConfigBuildDurationHistogram() != nil {
+			configBuildDurationHistogram = append(configBuildDurationHistogram, r.ConfigBuildDurationHistogram())
+		}
 		if r.EntrypointReqsCounter() != nil {
 			entrypointReqsCounter = append(entrypointReqsCounter, r.EntrypointReqsCounter())
 		}
@@ -74,6 +130,18 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.EntrypointOpenConnsGauge() != nil {
 			entrypointOpenConnsGauge = append(entrypointOpenConnsGauge, r.EntrypointOpenConnsGauge())
 		}
+		if r.EntrypointReqSizeHistogram() != nil {
+			entrypointReqSizeHistogram = append(entrypointReqSizeHistogram, r.EntrypointReqSizeHistogram())
+		}
+		if r.EntrypointRespSizeHistogram() != nil {
+			entrypointRespSizeHistogram = append(entrypointRespSizeHistogram, r.EntrypointRespSizeHistogram())
+		}
+		if r.FrontendReqsCounter() != nil {
+			frontendReqsCounter = append(frontendReqsCounter, r.FrontendReqsCounter())
+		}
+		if r.FrontendReqDurationHistogram() != nil {
+			frontendReqDurationHistogram = append(frontendReqDurationHistogram, r.FrontendReqDurationHistogram())
+		}
 		if r.BackendReqsCounter() != nil {
 			backendReqsCounter = append(backendReqsCounter, r.BackendReqsCounter())
 		}
@@ -89,39 +157,124 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.BackendServerUpGauge() != nil {
 			backendServerUpGauge = append(backendServerUpGauge, r.BackendServerUpGauge())
 		}
+		if r.BackendReqSizeHistogram() != nil {
+			backendReqSizeHistogram = append(backendReqSizeHistogram, r.BackendReqSizeHistogram())
+		}
+		if r.BackendRespSizeHistogram() != nil {
+			backendRespSizeHistogram = append(backendRespSizeHistogram, r.BackendRespSizeHistogram())
+		}
+		if r.ServerReqsCounter() != nil {
+			serverReqsCounter = append(serverReqsCounter, r.ServerReqsCounter())
+		}
+		if r.ServerReqDurationHistogram() != nil {
+			serverReqDurationHistogram = append(serverReqDurationHistogram, r.ServerReqDurationHistogram())
+		}
+		if r.TLSHandshakesCounter() != nil {
+			tlsHandshakesCounter = append(tlsHandshakesCounter, r.TLSHandshakesCounter())
+		}
+		if r.TLSHandshakeDurationHistogram() != nil {
+			tlsHandshakeDurationHistogram = append(tlsHandshakeDurationHistogram, r.TLSHandshakeDurationHistogram())
+		}
+		if r.TLSHandshakeErrorsCounter() != nil {
+			tlsHandshakeErrorsCounter = append(tlsHandshakeErrorsCounter, r.TLSHandshakeErrorsCounter())
+		}
+		if r.CertificateDaysToExpiryGauge() != nil {
+			certificateDaysToExpiryGauge = append(certificateDaysToExpiryGauge, r.CertificateDaysToExpiryGauge())
+		}
+		if r.ProviderEventsCounter() != nil {
+			providerEventsCounter = append(providerEventsCounter, r.ProviderEventsCounter())
+		}
+		if r.ProviderThrottledConfigsCounter() != nil {
+			providerThrottledConfigsCounter = append(providerThrottledConfigsCounter, r.ProviderThrottledConfigsCounter())
+		}
+		if r.ProviderDroppedConfigsCounter() != nil {
+			providerDroppedConfigsCounter = append(providerDroppedConfigsCounter, r.ProviderDroppedConfigsCounter())
+		}
+		if r.ProviderLastSyncGauge() != nil {
+			providerLastSyncGauge = append(providerLastSyncGauge, r.ProviderLastSyncGauge())
+		}
+		if r.ProviderFrontendsGauge() != nil {
+			providerFrontendsGauge = append(providerFrontendsGauge, r.ProviderFrontendsGauge())
+		}
+		if r.ProviderBackendsGauge() != nil {
+			providerBackendsGauge = append(providerBackendsGauge, r.ProviderBackendsGauge())
+		}
+		if r.ProviderServersGauge() != nil {
+			providerServersGauge = append(providerServersGauge, r.ProviderServersGauge())
+		}
 	}
 
 	return &standardRegistry{
-		enabled:                        len(registries) > 0,
-		configReloadsCounter:           multi.NewCounter(configReloadsCounter...),
-		configReloadsFailureCounter:    multi.NewCounter(configReloadsFailureCounter...),
-		lastConfigReloadSuccessGauge:   multi.NewGauge(lastConfigReloadSuccessGauge...),
-		lastConfigReloadFailureGauge:   multi.NewGauge(lastConfigReloadFailureGauge...),
-		entrypointReqsCounter:          multi.NewCounter(entrypointReqsCounter...),
-		entrypointReqDurationHistogram: multi.NewHistogram(entrypointReqDurationHistogram...),
-		entrypointOpenConnsGauge:       multi.NewGauge(entrypointOpenConnsGauge...),
-		backendReqsCounter:             multi.NewCounter(backendReqsCounter...),
-		backendReqDurationHistogram:    multi.NewHistogram(backendReqDurationHistogram...),
-		backendOpenConnsGauge:          multi.NewGauge(backendOpenConnsGauge...),
-		backendRetriesCounter:          multi.NewCounter(backendRetriesCounter...),
-		backendServerUpGauge:           multi.NewGauge(backendServerUpGauge...),
+		enabled:                         len(registries) > 0,
+		configReloadsCounter:            multi.NewCounter(configReloadsCounter...),
+		configReloadsFailureCounter:     multi.NewCounter(configReloadsFailureCounter...),
+		lastConfigReloadSuccessGauge:    multi.NewGauge(lastConfigReloadSuccessGauge...),
+		lastConfigReloadFailureGauge:    multi.NewGauge(lastConfigReloadFailureGauge...),
+		configBuildDurationHistogram:    multi.NewHistogram(configBuildDurationHistogram...),
+		entrypointReqsCounter:           multi.NewCounter(entrypointReqsCounter...),
+		entrypointReqDurationHistogram:  multi.NewHistogram(entrypointReqDurationHistogram...),
+		entrypointOpenConnsGauge:        multi.NewGauge(entrypointOpenConnsGauge...),
+		entrypointReqSizeHistogram:      multi.NewHistogram(entrypointReqSizeHistogram...),
+		entrypointRespSizeHistogram:     multi.NewHistogram(entrypointRespSizeHistogram...),
+		frontendReqsCounter:             multi.NewCounter(frontendReqsCounter...),
+		frontendReqDurationHistogram:    multi.NewHistogram(frontendReqDurationHistogram...),
+		backendReqsCounter:              multi.NewCounter(backendReqsCounter...),
+		backendReqDurationHistogram:     multi.NewHistogram(backendReqDurationHistogram...),
+		backendOpenConnsGauge:           multi.NewGauge(backendOpenConnsGauge...),
+		backendRetriesCounter:           multi.NewCounter(backendRetriesCounter...),
+		backendServerUpGauge:            multi.NewGauge(backendServerUpGauge...),
+		backendReqSizeHistogram:         multi.NewHistogram(backendReqSizeHistogram...),
+		backendRespSizeHistogram:        multi.NewHistogram(backendRespSizeHistogram...),
+		serverReqsCounter:               multi.NewCounter(serverReqsCounter...),
+		serverReqDurationHistogram:      multi.NewHistogram(serverReqDurationHistogram...),
+		tlsHandshakesCounter:            multi.NewCounter(tlsHandshakesCounter...),
+		tlsHandshakeDurationHistogram:   multi.NewHistogram(tlsHandshakeDurationHistogram...),
+		tlsHandshakeErrorsCounter:       multi.NewCounter(tlsHandshakeErrorsCounter...),
+		certificateDaysToExpiryGauge:    multi.NewGauge(certificateDaysToExpiryGauge...),
+		providerEventsCounter:           multi.NewCounter(providerEventsCounter...),
+		providerThrottledConfigsCounter: multi.NewCounter(providerThrottledConfigsCounter...),
+		providerDroppedConfigsCounter:   multi.NewCounter(providerDroppedConfigsCounter...),
+		providerLastSyncGauge:           multi.NewGauge(providerLastSyncGauge...),
+		providerFrontendsGauge:          multi.NewGauge(providerFrontendsGauge...),
+		providerBackendsGauge:           multi.NewGauge(providerBackendsGauge...),
+		providerServersGauge:            multi.NewGauge(providerServersGauge...),
 	}
 }
 
 type standardRegistry struct {
-	enabled                        bool
-	configReloadsCounter           metrics.Counter
-	configReloadsFailureCounter    metrics.Counter
-	lastConfigReloadSuccessGauge   metrics.Gauge
-	lastConfigReloadFailureGauge   metrics.Gauge
-	entrypointReqsCounter          metrics.Counter
-	entrypointReqDurationHistogram metrics.Histogram
-	entrypointOpenConnsGauge       metrics.Gauge
-	backendReqsCounter             metrics.Counter
-	backendReqDurationHistogram    metrics.Histogram
-	backendOpenConnsGauge          metrics.Gauge
-	backendRetriesCounter          metrics.Counter
-	backendServerUpGauge           metrics.Gauge
+	enabled                         bool
+	configReloadsCounter            metrics.Counter
+	configReloadsFailureCounter     metrics.Counter
+	lastConfigReloadSuccessGauge    metrics.Gauge
+	lastConfigReloadFailureGauge    metrics.Gauge
+	configBuildDurationHistogram    metrics.Histogram
+	entrypointReqsCounter           metrics.Counter
+	entrypointReqDurationHistogram  metrics.Histogram
+	entrypointOpenConnsGauge        metrics.Gauge
+	entrypointReqSizeHistogram      metrics.Histogram
+	entrypointRespSizeHistogram     metrics.Histogram
+	frontendReqsCounter             metrics.Counter
+	frontendReqDurationHistogram    metrics.Histogram
+	backendReqsCounter              metrics.Counter
+	backendReqDurationHistogram     metrics.Histogram
+	backendOpenConnsGauge           metrics.Gauge
+	backendRetriesCounter           metrics.Counter
+	backendServerUpGauge            metrics.Gauge
+	backendReqSizeHistogram         metrics.Histogram
+	backendRespSizeHistogram        metrics.Histogram
+	serverReqsCounter               metrics.Counter
+	serverReqDurationHistogram      metrics.Histogram
+	tlsHandshakesCounter            metrics.Counter
+	tlsHandshakeDurationHistogram   metrics.Histogram
+	tlsHandshakeErrorsCounter       metrics.Counter
+	certificateDaysToExpiryGauge    metrics.Gauge
+	providerEventsCounter           metrics.Counter
+	providerThrottledConfigsCounter metrics.Counter
+	providerDroppedConfigsCounter   metrics.Counter
+	providerLastSyncGauge           metrics.Gauge
+	providerFrontendsGauge          metrics.Gauge
+	providerBackendsGauge           metrics.Gauge
+	providerServersGauge            metrics.Gauge
 }
 
 func (r *standardRegistry) IsEnabled() bool {
@@ -144,6 +297,10 @@ func (r *standardRegistry) LastConfigReloadFailureGauge() metrics.Gauge {
 	return r.lastConfigReloadFailureGauge
 }
 
+func (r *standardRegistry) ConfigBuildDurationHistogram() metrics.Histogram {
+	return r.configBuildDurationHistogram
+}
+
 func (r *standardRegistry) EntrypointReqsCounter() metrics.Counter {
 	return r.entrypointReqsCounter
 }
@@ -156,6 +313,22 @@ func (r *standardRegistry) EntrypointOpenConnsGauge() metrics.Gauge {
 	return r.entrypointOpenConnsGauge
 }
 
+func (r *standardRegistry) EntrypointReqSizeHistogram() metrics.Histogram {
+	return r.entrypointReqSizeHistogram
+}
+
+func (r *standardRegistry) EntrypointRespSizeHistogram() metrics.Histogram {
+	return r.entrypointRespSizeHistogram
+}
+
+func (r *standardRegistry) FrontendReqsCounter() metrics.Counter {
+	return r.frontendReqsCounter
+}
+
+func (r *standardRegistry) FrontendReqDurationHistogram() metrics.Histogram {
+	return r.frontendReqDurationHistogram
+}
+
 func (r *standardRegistry) BackendReqsCounter() metrics.Counter {
 	return r.backendReqsCounter
 }
@@ -175,3 +348,63 @@ func (r *standardRegistry) BackendRetriesCounter() metrics.Counter {
 func (r *standardRegistry) BackendServerUpGauge() metrics.Gauge {
 	return r.backendServerUpGauge
 }
+
+func (r *standardRegistry) BackendReqSizeHistogram() metrics.Histogram {
+	return r.backendReqSizeHistogram
+}
+
+func (r *standardRegistry) BackendRespSizeHistogram() metrics.Histogram {
+	return r.backendRespSizeHistogram
+}
+
+func (r *standardRegistry) ServerReqsCounter() metrics.Counter {
+	return r.serverReqsCounter
+}
+
+func (r *standardRegistry) ServerReqDurationHistogram() metrics.Histogram {
+	return r.serverReqDurationHistogram
+}
+
+func (r *standardRegistry) TLSHandshakesCounter() metrics.Counter {
+	return r.tlsHandshakesCounter
+}
+
+func (r *standardRegistry) TLSHandshakeDurationHistogram() metrics.Histogram {
+	return r.tlsHandshakeDurationHistogram
+}
+
+func (r *standardRegistry) TLSHandshakeErrorsCounter() metrics.Counter {
+	return r.tlsHandshakeErrorsCounter
+}
+
+func (r *standardRegistry) CertificateDaysToExpiryGauge() metrics.Gauge {
+	return r.certificateDaysToExpiryGauge
+}
+
+func (r *standardRegistry) ProviderEventsCounter() metrics.Counter {
+	return r.providerEventsCounter
+}
+
+func (r *standardRegistry) ProviderThrottledConfigsCounter() metrics.Counter {
+	return r.providerThrottledConfigsCounter
+}
+
+func (r *standardRegistry) ProviderDroppedConfigsCounter() metrics.Counter {
+	return r.providerDroppedConfigsCounter
+}
+
+func (r *standardRegistry) ProviderLastSyncGauge() metrics.Gauge {
+	return r.providerLastSyncGauge
+}
+
+func (r *standardRegistry) ProviderFrontendsGauge() metrics.Gauge {
+	return r.providerFrontendsGauge
+}
+
+func (r *standardRegistry) ProviderBackendsGauge() metrics.Gauge {
+	return r.providerBackendsGauge
+}
+
+func (r *standardRegistry) ProviderServersGauge() metrics.Gauge {
+	return r.providerServersGauge
+}
@@ -0,0 +1,395 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/otlp"
+	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/types"
+	"github.com/go-kit/kit/metrics"
+)
+
+var otlpClient *otlpMetrics
+
+var otlpTicker *time.Ticker
+
+const (
+	otlpServiceName = "traefik"
+
+	otlpMetricsFrontendReqsName     = "traefik.frontend.request.total"
+	otlpMetricsFrontendLatencyName  = "traefik.frontend.request.duration"
+	otlpMetricsBackendReqsName      = "traefik.backend.request.total"
+	otlpMetricsBackendLatencyName   = "traefik.backend.request.duration"
+	otlpRetriesTotalName            = "traefik.backend.retries.total"
+	otlpConfigReloadsName           = "traefik.config.reload.total"
+	otlpConfigReloadsFailureTagName = "failure"
+	otlpLastConfigReloadSuccessName = "traefik.config.reload.lastSuccessTimestamp"
+	otlpLastConfigReloadFailureName = "traefik.config.reload.lastFailureTimestamp"
+	otlpEntrypointReqsName          = "traefik.entrypoint.request.total"
+	otlpEntrypointReqDurationName   = "traefik.entrypoint.request.duration"
+	otlpEntrypointOpenConnsName     = "traefik.entrypoint.connections.open"
+	otlpEntrypointReqSizeName       = "traefik.entrypoint.request.size"
+	otlpEntrypointRespSizeName      = "traefik.entrypoint.response.size"
+	otlpOpenConnsName               = "traefik.backend.connections.open"
+	otlpServerUpName                = "traefik.backend.server.up"
+	otlpMetricsBackendReqSizeName   = "traefik.backend.request.size"
+	otlpMetricsBackendRespSizeName  = "traefik.backend.response.size"
+	otlpMetricsServerReqsName       = "traefik.backend.server.request.total"
+	otlpMetricsServerLatencyName    = "traefik.backend.server.request.duration"
+	otlpTLSHandshakesName           = "traefik.tls.handshake.total"
+	otlpTLSHandshakeDurationName    = "traefik.tls.handshake.duration"
+	otlpTLSHandshakeErrorsName      = "traefik.tls.handshake.errors"
+	otlpCertificateDaysToExpiryName = "traefik.tls.certificate.daysToExpiry"
+)
+
+// RegisterOTLP registers the metrics pusher if this didn't happen yet and creates an OTLP Registry instance.
+func RegisterOTLP(config *types.OTLPMetrics) Registry {
+	if otlpClient == nil {
+		exporter, err := otlp.NewExporter(&config.OTLP)
+		if err != nil {
+			logger.Errorf("Unable to create the OTLP metrics exporter: %v", err)
+			return nil
+		}
+		otlpClient = newOTLPMetrics(exporter, otlp.NewResource(otlpServiceName, config.ResourceAttributes), config.Buckets)
+	}
+	if otlpTicker == nil {
+		otlpTicker = initOTLPTicker(config)
+	}
+
+	return &standardRegistry{
+		enabled:                        true,
+		configReloadsCounter:           otlpClient.NewCounter(otlpConfigReloadsName),
+		configReloadsFailureCounter:    otlpClient.NewCounter(otlpConfigReloadsName).With(otlpConfigReloadsFailureTagName, "true"),
+		lastConfigReloadSuccessGauge:   otlpClient.NewGauge(otlpLastConfigReloadSuccessName),
+		lastConfigReloadFailureGauge:   otlpClient.NewGauge(otlpLastConfigReloadFailureName),
+		entrypointReqsCounter:          otlpClient.NewCounter(otlpEntrypointReqsName),
+		entrypointReqDurationHistogram: otlpClient.NewHistogram(otlpEntrypointReqDurationName),
+		entrypointOpenConnsGauge:       otlpClient.NewGauge(otlpEntrypointOpenConnsName),
+		entrypointReqSizeHistogram:     otlpClient.NewHistogram(otlpEntrypointReqSizeName),
+		entrypointRespSizeHistogram:    otlpClient.NewHistogram(otlpEntrypointRespSizeName),
+		frontendReqsCounter:            otlpClient.NewCounter(otlpMetricsFrontendReqsName),
+		frontendReqDurationHistogram:   otlpClient.NewHistogram(otlpMetricsFrontendLatencyName),
+		backendReqsCounter:             otlpClient.NewCounter(otlpMetricsBackendReqsName),
+		backendReqDurationHistogram:    otlpClient.NewHistogram(otlpMetricsBackendLatencyName),
+		backendRetriesCounter:          otlpClient.NewCounter(otlpRetriesTotalName),
+		backendOpenConnsGauge:          otlpClient.NewGauge(otlpOpenConnsName),
+		backendServerUpGauge:           otlpClient.NewGauge(otlpServerUpName),
+		backendReqSizeHistogram:        otlpClient.NewHistogram(otlpMetricsBackendReqSizeName),
+		backendRespSizeHistogram:       otlpClient.NewHistogram(otlpMetricsBackendRespSizeName),
+		serverReqsCounter:              otlpClient.NewCounter(otlpMetricsServerReqsName),
+		serverReqDurationHistogram:     otlpClient.NewHistogram(otlpMetricsServerLatencyName),
+		tlsHandshakesCounter:           otlpClient.NewCounter(otlpTLSHandshakesName),
+		tlsHandshakeDurationHistogram:  otlpClient.NewHistogram(otlpTLSHandshakeDurationName),
+		tlsHandshakeErrorsCounter:      otlpClient.NewCounter(otlpTLSHandshakeErrorsName),
+		certificateDaysToExpiryGauge:   otlpClient.NewGauge(otlpCertificateDaysToExpiryName),
+	}
+}
+
+// initOTLPTicker initializes metrics pusher
+func initOTLPTicker(config *types.OTLPMetrics) *time.Ticker {
+	pushInterval, err := time.ParseDuration(config.PushInterval)
+	if err != nil {
+		logger.Warnf("Unable to parse %s into pushInterval, using 10s as default value", config.PushInterval)
+		pushInterval = 10 * time.Second
+	}
+
+	report := time.NewTicker(pushInterval)
+
+	client := otlpClient
+	safe.Go(func() {
+		for range report.C {
+			client.push()
+		}
+	})
+
+	return report
+}
+
+// StopOTLP stops internal otlpTicker which controls the pushing of metrics to the OpenTelemetry collector and resets it to `nil`
+func StopOTLP() {
+	if otlpTicker != nil {
+		otlpTicker.Stop()
+	}
+	otlpTicker = nil
+
+	if otlpClient != nil {
+		otlpClient.push()
+		if err := otlpClient.exporter.Close(); err != nil {
+			logger.Errorf("Unable to close the OTLP metrics exporter: %v", err)
+		}
+	}
+	otlpClient = nil
+}
+
+type otlpInstrumentKind int
+
+const (
+	otlpCounter otlpInstrumentKind = iota
+	otlpGauge
+	otlpHistogram
+)
+
+// otlpMetrics aggregates the cumulative values of the metrics pushed to the OpenTelemetry collector
+type otlpMetrics struct {
+	exporter *otlp.Exporter
+	resource *otlp.Resource
+	buckets  []float64
+	start    time.Time
+
+	lock        sync.Mutex
+	instruments map[string]*otlpInstrument
+	names       []string
+}
+
+type otlpInstrument struct {
+	kind   otlpInstrumentKind
+	series map[string]*otlpSeries
+	keys   []string
+}
+
+type otlpSeries struct {
+	attributes   []*otlp.KeyValue
+	value        float64
+	count        uint64
+	bucketCounts []uint64
+}
+
+func newOTLPMetrics(exporter *otlp.Exporter, resource *otlp.Resource, buckets types.Buckets) *otlpMetrics {
+	if len(buckets) == 0 {
+		buckets = types.Buckets{0.1, 0.3, 1.2, 5}
+	}
+
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+
+	return &otlpMetrics{
+		exporter:    exporter,
+		resource:    resource,
+		buckets:     bounds,
+		start:       time.Now(),
+		instruments: make(map[string]*otlpInstrument),
+	}
+}
+
+// NewCounter returns a counter exported as a cumulative monotonic sum
+func (m *otlpMetrics) NewCounter(name string) metrics.Counter {
+	m.register(name, otlpCounter)
+	return &otlpCounterMetric{otlpMetric{metrics: m, name: name}}
+}
+
+// NewGauge returns a gauge exported as is
+func (m *otlpMetrics) NewGauge(name string) metrics.Gauge {
+	m.register(name, otlpGauge)
+	return &otlpGaugeMetric{otlpMetric{metrics: m, name: name}}
+}
+
+// NewHistogram returns a histogram exported with cumulative bucket counts
+func (m *otlpMetrics) NewHistogram(name string) metrics.Histogram {
+	m.register(name, otlpHistogram)
+	return &otlpHistogramMetric{otlpMetric{metrics: m, name: name}}
+}
+
+func (m *otlpMetrics) register(name string, kind otlpInstrumentKind) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.instruments[name]; ok {
+		return
+	}
+	m.instruments[name] = &otlpInstrument{kind: kind, series: make(map[string]*otlpSeries)}
+	m.names = append(m.names, name)
+	sort.Strings(m.names)
+}
+
+// update applies fn to the series of the metric identified by the label values, the series is created if needed
+func (m *otlpMetrics) update(name string, labelValues []string, fn func(*otlpSeries)) {
+	pairs := make([][2]string, 0, len(labelValues)/2)
+	for i := 0; i+1 < len(labelValues); i += 2 {
+		pairs = append(pairs, [2]string{labelValues[i], labelValues[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+
+	key := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		key = append(key, pair[0]+"="+pair[1])
+	}
+	seriesKey := strings.Join(key, ",")
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	instrument := m.instruments[name]
+	series, ok := instrument.series[seriesKey]
+	if !ok {
+		series = &otlpSeries{}
+		for _, pair := range pairs {
+			series.attributes = append(series.attributes, otlp.StringAttribute(pair[0], pair[1]))
+		}
+		if instrument.kind == otlpHistogram {
+			series.bucketCounts = make([]uint64, len(m.buckets)+1)
+		}
+		instrument.series[seriesKey] = series
+		instrument.keys = append(instrument.keys, seriesKey)
+		sort.Strings(instrument.keys)
+	}
+
+	fn(series)
+}
+
+func (m *otlpMetrics) observe(name string, labelValues []string, value float64) {
+	m.update(name, labelValues, func(series *otlpSeries) {
+		series.count++
+		series.value += value
+		series.bucketCounts[sort.SearchFloat64s(m.buckets, value)]++
+	})
+}
+
+// collect returns the current values of all the series
+func (m *otlpMetrics) collect() *otlp.ExportMetricsServiceRequest {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	start := uint64(m.start.UnixNano())
+	now := uint64(time.Now().UnixNano())
+
+	var result []*otlp.Metric
+	for _, name := range m.names {
+		instrument := m.instruments[name]
+		if len(instrument.series) == 0 {
+			continue
+		}
+
+		metric := &otlp.Metric{Name: name}
+		switch instrument.kind {
+		case otlpCounter:
+			metric.Sum = &otlp.Sum{AggregationTemporality: otlp.AggregationTemporalityCumulative, IsMonotonic: true}
+		case otlpGauge:
+			metric.Gauge = &otlp.Gauge{}
+		case otlpHistogram:
+			metric.Histogram = &otlp.Histogram{AggregationTemporality: otlp.AggregationTemporalityCumulative}
+		}
+
+		for _, key := range instrument.keys {
+			series := instrument.series[key]
+			value := series.value
+
+			switch instrument.kind {
+			case otlpCounter:
+				metric.Sum.DataPoints = append(metric.Sum.DataPoints, &otlp.NumberDataPoint{
+					StartTimeUnixNano: start,
+					TimeUnixNano:      now,
+					AsDouble:          &value,
+					Attributes:        series.attributes,
+				})
+			case otlpGauge:
+				metric.Gauge.DataPoints = append(metric.Gauge.DataPoints, &otlp.NumberDataPoint{
+					TimeUnixNano: now,
+					AsDouble:     &value,
+					Attributes:   series.attributes,
+				})
+			case otlpHistogram:
+				metric.Histogram.DataPoints = append(metric.Histogram.DataPoints, &otlp.HistogramDataPoint{
+					StartTimeUnixNano: start,
+					TimeUnixNano:      now,
+					Count:             series.count,
+					Sum:               &value,
+					BucketCounts:      append([]uint64(nil), series.bucketCounts...),
+					ExplicitBounds:    m.buckets,
+					Attributes:        series.attributes,
+				})
+			}
+		}
+
+		result = append(result, metric)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return &otlp.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlp.ResourceMetrics{{
+			Resource: m.resource,
+			ScopeMetrics: []*otlp.ScopeMetrics{{
+				Scope:   otlp.NewInstrumentationScope(),
+				Metrics: result,
+			}},
+		}},
+	}
+}
+
+func (m *otlpMetrics) push() {
+	request := m.collect()
+	if request == nil {
+		return
+	}
+
+	if err := m.exporter.ExportMetrics(context.Background(), request); err != nil {
+		logger.Errorf("Unable to push the metrics to the OpenTelemetry collector: %v", err)
+	}
+}
+
+type otlpMetric struct {
+	metrics     *otlpMetrics
+	name        string
+	labelValues []string
+}
+
+func (o otlpMetric) with(labelValues []string) otlpMetric {
+	return otlpMetric{
+		metrics:     o.metrics,
+		name:        o.name,
+		labelValues: append(append([]string(nil), o.labelValues...), labelValues...),
+	}
+}
+
+type otlpCounterMetric struct {
+	otlpMetric
+}
+
+// With returns a new counter with the label values applied
+func (c *otlpCounterMetric) With(labelValues ...string) metrics.Counter {
+	return &otlpCounterMetric{c.with(labelValues)}
+}
+
+// Add increments the counter by delta
+func (c *otlpCounterMetric) Add(delta float64) {
+	c.metrics.update(c.name, c.labelValues, func(series *otlpSeries) { series.value += delta })
+}
+
+type otlpGaugeMetric struct {
+	otlpMetric
+}
+
+// With returns a new gauge with the label values applied
+func (g *otlpGaugeMetric) With(labelValues ...string) metrics.Gauge {
+	return &otlpGaugeMetric{g.with(labelValues)}
+}
+
+// Set sets the value of the gauge
+func (g *otlpGaugeMetric) Set(value float64) {
+	g.metrics.update(g.name, g.labelValues, func(series *otlpSeries) { series.value = value })
+}
+
+// Add increments the gauge by delta
+func (g *otlpGaugeMetric) Add(delta float64) {
+	g.metrics.update(g.name, g.labelValues, func(series *otlpSeries) { series.value += delta })
+}
+
+type otlpHistogramMetric struct {
+	otlpMetric
+}
+
+// With returns a new histogram with the label values applied
+func (h *otlpHistogramMetric) With(labelValues ...string) metrics.Histogram {
+	return &otlpHistogramMetric{h.with(labelValues)}
+}
+
+// Observe records a value in the histogram
+func (h *otlpHistogramMetric) Observe(value float64) {
+	h.metrics.observe(h.name, h.labelValues, value)
+}
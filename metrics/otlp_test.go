@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/containous/traefik/otlp"
+	"github.com/containous/traefik/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTLP(t *testing.T) {
+	requests := make(chan *otlp.ExportMetricsServiceRequest, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		request := &otlp.ExportMetricsServiceRequest{}
+		if err := proto.Unmarshal(body, request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests <- request
+	}))
+	defer ts.Close()
+
+	otlpRegistry := RegisterOTLP(&types.OTLPMetrics{
+		OTLP:         types.OTLP{Protocol: otlp.ProtocolHTTP, Endpoint: ts.URL},
+		PushInterval: "1h",
+		Buckets:      types.Buckets{0.1, 1},
+	})
+	require.NotNil(t, otlpRegistry)
+
+	if !otlpRegistry.IsEnabled() {
+		t.Fatalf("OTLP registry must be enabled")
+	}
+
+	otlpRegistry.BackendReqsCounter().With("backend", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+	otlpRegistry.BackendReqsCounter().With("method", http.MethodGet, "code", strconv.Itoa(http.StatusOK), "backend", "test").Add(1)
+	otlpRegistry.ConfigReloadsFailureCounter().Add(1)
+	otlpRegistry.BackendServerUpGauge().With("backend", "test", "url", "http://127.0.0.1").Set(1)
+	otlpRegistry.EntrypointReqDurationHistogram().With("entrypoint", "test").Observe(0.05)
+	otlpRegistry.EntrypointReqDurationHistogram().With("entrypoint", "test").Observe(0.5)
+	otlpRegistry.EntrypointReqDurationHistogram().With("entrypoint", "test").Observe(10)
+
+	// pushes the collected metrics
+	StopOTLP()
+
+	request := <-requests
+	require.Len(t, request.ResourceMetrics, 1)
+	assert.Equal(t, otlp.NewResource("traefik", nil), request.ResourceMetrics[0].Resource)
+	require.Len(t, request.ResourceMetrics[0].ScopeMetrics, 1)
+
+	exported := make(map[string]*otlp.Metric)
+	for _, metric := range request.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		exported[metric.Name] = metric
+	}
+
+	backendReqs := exported[otlpMetricsBackendReqsName]
+	require.NotNil(t, backendReqs)
+	require.NotNil(t, backendReqs.Sum)
+	assert.True(t, backendReqs.Sum.IsMonotonic)
+	assert.Equal(t, otlp.AggregationTemporalityCumulative, backendReqs.Sum.AggregationTemporality)
+	require.Len(t, backendReqs.Sum.DataPoints, 1)
+	assert.Equal(t, 2.0, *backendReqs.Sum.DataPoints[0].AsDouble)
+	assert.Equal(t, []*otlp.KeyValue{
+		otlp.StringAttribute("backend", "test"),
+		otlp.StringAttribute("code", "200"),
+		otlp.StringAttribute("method", http.MethodGet),
+	}, backendReqs.Sum.DataPoints[0].Attributes)
+
+	configReloads := exported[otlpConfigReloadsName]
+	require.NotNil(t, configReloads)
+	require.Len(t, configReloads.Sum.DataPoints, 1)
+	assert.Equal(t, []*otlp.KeyValue{otlp.StringAttribute("failure", "true")}, configReloads.Sum.DataPoints[0].Attributes)
+
+	serverUp := exported[otlpServerUpName]
+	require.NotNil(t, serverUp)
+	require.NotNil(t, serverUp.Gauge)
+	require.Len(t, serverUp.Gauge.DataPoints, 1)
+	assert.Equal(t, 1.0, *serverUp.Gauge.DataPoints[0].AsDouble)
+
+	duration := exported[otlpEntrypointReqDurationName]
+	require.NotNil(t, duration)
+	require.NotNil(t, duration.Histogram)
+	require.Len(t, duration.Histogram.DataPoints, 1)
+	dataPoint := duration.Histogram.DataPoints[0]
+	assert.Equal(t, uint64(3), dataPoint.Count)
+	assert.Equal(t, 10.55, *dataPoint.Sum)
+	assert.Equal(t, []float64{0.1, 1}, dataPoint.ExplicitBounds)
+	assert.Equal(t, []uint64{1, 1, 1}, dataPoint.BucketCounts)
+
+	// metrics without series are not exported
+	assert.NotContains(t, exported, otlpEntrypointOpenConnsName)
+}
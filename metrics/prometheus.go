@@ -7,7 +7,6 @@ import (
 	"sync"
 
 	"github.com/containous/mux"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
 	"github.com/go-kit/kit/metrics"
@@ -25,12 +24,30 @@ const (
 	configReloadsFailuresTotalName = metricConfigPrefix + "reloads_failure_total"
 	configLastReloadSuccessName    = metricConfigPrefix + "last_reload_success"
 	configLastReloadFailureName    = metricConfigPrefix + "last_reload_failure"
+	configBuildDurationName        = metricConfigPrefix + "build_duration_seconds"
+
+	// providers
+	metricProviderPrefix         = MetricNamePrefix + "provider_"
+	providerEventsTotalName      = metricProviderPrefix + "events_total"
+	providerThrottledConfigsName = metricProviderPrefix + "throttled_configs_total"
+	providerDroppedConfigsName   = metricProviderPrefix + "dropped_configs_total"
+	providerLastSyncName         = metricProviderPrefix + "last_sync"
+	providerFrontendsName        = metricProviderPrefix + "frontends"
+	providerBackendsName         = metricProviderPrefix + "backends"
+	providerServersName          = metricProviderPrefix + "servers"
 
 	// entrypoint
 	metricEntryPointPrefix    = MetricNamePrefix + "entrypoint_"
 	entrypointReqsTotalName   = metricEntryPointPrefix + "requests_total"
 	entrypointReqDurationName = metricEntryPointPrefix + "request_duration_seconds"
 	entrypointOpenConnsName   = metricEntryPointPrefix + "open_connections"
+	entrypointReqSizeName     = metricEntryPointPrefix + "request_size_bytes"
+	entrypointRespSizeName    = metricEntryPointPrefix + "response_size_bytes"
+
+	// frontend level
+	metricFrontendPrefix    = MetricNamePrefix + "frontend_"
+	frontendReqsTotalName   = metricFrontendPrefix + "requests_total"
+	frontendReqDurationName = metricFrontendPrefix + "request_duration_seconds"
 
 	// backend level.
 
@@ -41,6 +58,29 @@ const (
 	backendOpenConnsName    = MetricBackendPrefix + "open_connections"
 	backendRetriesTotalName = MetricBackendPrefix + "retries_total"
 	backendServerUpName     = MetricBackendPrefix + "server_up"
+	backendReqSizeName      = MetricBackendPrefix + "request_size_bytes"
+	backendRespSizeName     = MetricBackendPrefix + "response_size_bytes"
+
+	// server level
+	backendServerReqsTotalName   = MetricBackendPrefix + "server_requests_total"
+	backendServerReqDurationName = MetricBackendPrefix + "server_request_duration_seconds"
+
+	// TLS handshakes
+	metricTLSPrefix          = MetricNamePrefix + "tls_"
+	tlsHandshakesTotalName   = metricTLSPrefix + "handshakes_total"
+	tlsHandshakeDurationName = metricTLSPrefix + "handshake_duration_seconds"
+	tlsHandshakeErrorsName   = metricTLSPrefix + "handshake_errors_total"
+
+	// certificates
+	metricCertificatePrefix     = MetricNamePrefix + "tls_certificate_"
+	certificateDaysToExpiryName = metricCertificatePrefix + "days_to_expiry"
+)
+
+var (
+	// sizeBuckets are the buckets of the request and response size histograms, from 100B to 10MB
+	sizeBuckets = []float64{100, 1000, 10000, 100000, 1000000, 10000000}
+	// tlsHandshakeBuckets are the buckets of the TLS handshake duration histogram
+	tlsHandshakeBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
 )
 
 // promState holds all metric state internally and acts as the only Collector we register for Prometheus.
@@ -103,6 +143,40 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		Name: configLastReloadFailureName,
 		Help: "Last config reload failure",
 	}, []string{})
+	configBuildDurations := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+		Name:    configBuildDurationName,
+		Help:    "How long it took to build the configuration, partitioned by the provider which triggered the reload.",
+		Buckets: buckets,
+	}, []string{"provider"})
+
+	providerEvents := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: providerEventsTotalName,
+		Help: "How many configuration messages were received from a provider.",
+	}, []string{"provider"})
+	providerThrottledConfigs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: providerThrottledConfigsName,
+		Help: "How many configurations of a provider were delayed by the providers throttle duration.",
+	}, []string{"provider"})
+	providerDroppedConfigs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: providerDroppedConfigsName,
+		Help: "How many configurations of a provider were superseded by a newer one before being applied.",
+	}, []string{"provider"})
+	providerLastSync := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+		Name: providerLastSyncName,
+		Help: "Last time a configuration was successfully received from a provider.",
+	}, []string{"provider"})
+	providerFrontends := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+		Name: providerFrontendsName,
+		Help: "How many frontends are defined by a provider.",
+	}, []string{"provider"})
+	providerBackends := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+		Name: providerBackendsName,
+		Help: "How many backends are defined by a provider.",
+	}, []string{"provider"})
+	providerServers := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+		Name: providerServersName,
+		Help: "How many backend servers are defined by a provider.",
+	}, []string{"provider"})
 
 	entrypointReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
 		Name: entrypointReqsTotalName,
@@ -117,6 +191,26 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		Name: entrypointOpenConnsName,
 		Help: "How many open connections exist on an entrypoint, partitioned by method and protocol.",
 	}, []string{"method", "protocol", "entrypoint"})
+	entrypointReqSizes := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+		Name:    entrypointReqSizeName,
+		Help:    "Size of the requests processed on an entrypoint in bytes, partitioned by status code, protocol, and method.",
+		Buckets: sizeBuckets,
+	}, []string{"code", "method", "protocol", "entrypoint"})
+	entrypointRespSizes := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+		Name:    entrypointRespSizeName,
+		Help:    "Size of the responses sent on an entrypoint in bytes, partitioned by status code, protocol, and method.",
+		Buckets: sizeBuckets,
+	}, []string{"code", "method", "protocol", "entrypoint"})
+
+	frontendReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: frontendReqsTotalName,
+		Help: "How many HTTP requests processed on a frontend, partitioned by status code, protocol, and method.",
+	}, []string{"code", "method", "protocol", "frontend"})
+	frontendReqDurations := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+		Name:    frontendReqDurationName,
+		Help:    "How long it took to process the request on a frontend, partitioned by status code, protocol, and method.",
+		Buckets: buckets,
+	}, []string{"code", "method", "protocol", "frontend"})
 
 	backendReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
 		Name: backendReqsTotalName,
@@ -139,46 +233,125 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		Name: backendServerUpName,
 		Help: "Backend server is up, described by gauge value of 0 or 1.",
 	}, []string{"backend", "url"})
+	backendReqSizes := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+		Name:    backendReqSizeName,
+		Help:    "Size of the requests processed on a backend in bytes, partitioned by status code, protocol, and method.",
+		Buckets: sizeBuckets,
+	}, []string{"code", "method", "protocol", "backend"})
+	backendRespSizes := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+		Name:    backendRespSizeName,
+		Help:    "Size of the responses sent by a backend in bytes, partitioned by status code, protocol, and method.",
+		Buckets: sizeBuckets,
+	}, []string{"code", "method", "protocol", "backend"})
+
+	serverReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: backendServerReqsTotalName,
+		Help: "How many HTTP requests processed by a backend server, partitioned by status code, protocol, and method.",
+	}, []string{"code", "method", "protocol", "backend", "url"})
+	serverReqDurations := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+		Name:    backendServerReqDurationName,
+		Help:    "How long it took to process the request by a backend server, partitioned by status code, protocol, and method.",
+		Buckets: buckets,
+	}, []string{"code", "method", "protocol", "backend", "url"})
+
+	tlsHandshakes := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: tlsHandshakesTotalName,
+		Help: "How many TLS handshakes succeeded on an entrypoint, partitioned by TLS version and cipher suite.",
+	}, []string{"entrypoint", "tls_version", "tls_cipher"})
+	tlsHandshakeDurations := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+		Name:    tlsHandshakeDurationName,
+		Help:    "How long it took to complete a TLS handshake on an entrypoint, partitioned by TLS version.",
+		Buckets: tlsHandshakeBuckets,
+	}, []string{"entrypoint", "tls_version"})
+	tlsHandshakeErrors := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: tlsHandshakeErrorsName,
+		Help: "How many TLS handshakes failed on an entrypoint, partitioned by reason.",
+	}, []string{"entrypoint", "reason"})
+
+	certificateDaysToExpiry := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+		Name: certificateDaysToExpiryName,
+		Help: "Number of days before a certificate served by the entrypoints expires, partitioned by common name and serial number.",
+	}, []string{"cn", "serial"})
 
 	promState.describers = []func(chan<- *stdprometheus.Desc){
 		configReloads.cv.Describe,
 		configReloadsFailures.cv.Describe,
 		lastConfigReloadSuccess.gv.Describe,
 		lastConfigReloadFailure.gv.Describe,
+		configBuildDurations.hv.Describe,
 		entrypointReqs.cv.Describe,
 		entrypointReqDurations.hv.Describe,
 		entrypointOpenConns.gv.Describe,
+		entrypointReqSizes.hv.Describe,
+		entrypointRespSizes.hv.Describe,
+		frontendReqs.cv.Describe,
+		frontendReqDurations.hv.Describe,
 		backendReqs.cv.Describe,
 		backendReqDurations.hv.Describe,
 		backendOpenConns.gv.Describe,
 		backendRetries.cv.Describe,
 		backendServerUp.gv.Describe,
+		backendReqSizes.hv.Describe,
+		backendRespSizes.hv.Describe,
+		serverReqs.cv.Describe,
+		serverReqDurations.hv.Describe,
+		tlsHandshakes.cv.Describe,
+		tlsHandshakeDurations.hv.Describe,
+		tlsHandshakeErrors.cv.Describe,
+		certificateDaysToExpiry.gv.Describe,
+		providerEvents.cv.Describe,
+		providerThrottledConfigs.cv.Describe,
+		providerDroppedConfigs.cv.Describe,
+		providerLastSync.gv.Describe,
+		providerFrontends.gv.Describe,
+		providerBackends.gv.Describe,
+		providerServers.gv.Describe,
 	}
 
 	return &standardRegistry{
-		enabled:                        true,
-		configReloadsCounter:           configReloads,
-		configReloadsFailureCounter:    configReloadsFailures,
-		lastConfigReloadSuccessGauge:   lastConfigReloadSuccess,
-		lastConfigReloadFailureGauge:   lastConfigReloadFailure,
-		entrypointReqsCounter:          entrypointReqs,
-		entrypointReqDurationHistogram: entrypointReqDurations,
-		entrypointOpenConnsGauge:       entrypointOpenConns,
-		backendReqsCounter:             backendReqs,
-		backendReqDurationHistogram:    backendReqDurations,
-		backendOpenConnsGauge:          backendOpenConns,
-		backendRetriesCounter:          backendRetries,
-		backendServerUpGauge:           backendServerUp,
+		enabled:                         true,
+		configReloadsCounter:            configReloads,
+		configReloadsFailureCounter:     configReloadsFailures,
+		lastConfigReloadSuccessGauge:    lastConfigReloadSuccess,
+		lastConfigReloadFailureGauge:    lastConfigReloadFailure,
+		configBuildDurationHistogram:    configBuildDurations,
+		entrypointReqsCounter:           entrypointReqs,
+		entrypointReqDurationHistogram:  entrypointReqDurations,
+		entrypointOpenConnsGauge:        entrypointOpenConns,
+		entrypointReqSizeHistogram:      entrypointReqSizes,
+		entrypointRespSizeHistogram:     entrypointRespSizes,
+		frontendReqsCounter:             frontendReqs,
+		frontendReqDurationHistogram:    frontendReqDurations,
+		backendReqsCounter:              backendReqs,
+		backendReqDurationHistogram:     backendReqDurations,
+		backendOpenConnsGauge:           backendOpenConns,
+		backendRetriesCounter:           backendRetries,
+		backendServerUpGauge:            backendServerUp,
+		backendReqSizeHistogram:         backendReqSizes,
+		backendRespSizeHistogram:        backendRespSizes,
+		serverReqsCounter:               serverReqs,
+		serverReqDurationHistogram:      serverReqDurations,
+		tlsHandshakesCounter:            tlsHandshakes,
+		tlsHandshakeDurationHistogram:   tlsHandshakeDurations,
+		tlsHandshakeErrorsCounter:       tlsHandshakeErrors,
+		certificateDaysToExpiryGauge:    certificateDaysToExpiry,
+		providerEventsCounter:           providerEvents,
+		providerThrottledConfigsCounter: providerThrottledConfigs,
+		providerDroppedConfigsCounter:   providerDroppedConfigs,
+		providerLastSyncGauge:           providerLastSync,
+		providerFrontendsGauge:          providerFrontends,
+		providerBackendsGauge:           providerBackends,
+		providerServersGauge:            providerServers,
 	}
 }
 
 func registerPromState() bool {
 	if err := stdprometheus.Register(promState); err != nil {
 		if _, ok := err.(stdprometheus.AlreadyRegisteredError); !ok {
-			log.Errorf("Unable to register Traefik to Prometheus: %v", err)
+			logger.Errorf("Unable to register Traefik to Prometheus: %v", err)
 			return false
 		}
-		log.Debug("Prometheus collector already registered.")
+		logger.Debug("Prometheus collector already registered.")
 	}
 	return true
 }
@@ -190,7 +363,8 @@ func OnConfigurationUpdate(configurations types.Configurations) {
 	dynamicConfig := newDynamicConfig()
 
 	for _, config := range configurations {
-		for _, frontend := range config.Frontends {
+		for frontendName, frontend := range config.Frontends {
+			dynamicConfig.frontends[frontendName] = true
 			for _, entrypointName := range frontend.EntryPoints {
 				dynamicConfig.entrypoints[entrypointName] = true
 			}
@@ -207,10 +381,17 @@ func OnConfigurationUpdate(configurations types.Configurations) {
 	promState.SetDynamicConfig(dynamicConfig)
 }
 
+// OnCertificatesUpdate receives the certificates checked by the certificates expiration watchdog,
+// as serial numbers by common name, so that the series of the certificates no longer served are removed.
+func OnCertificatesUpdate(certificates map[string]map[string]bool) {
+	promState.SetCertificates(certificates)
+}
+
 func newPrometheusState() *prometheusState {
 	return &prometheusState{
 		collectors:    make(chan *collector),
 		dynamicConfig: newDynamicConfig(),
+		certificates:  make(map[string]map[string]bool),
 		state:         make(map[string]*collector),
 	}
 }
@@ -221,6 +402,7 @@ type prometheusState struct {
 
 	mtx           sync.Mutex
 	dynamicConfig *dynamicConfig
+	certificates  map[string]map[string]bool
 	state         map[string]*collector
 }
 
@@ -231,6 +413,7 @@ func (ps *prometheusState) reset() {
 	ps.collectors = make(chan *collector)
 	ps.describers = []func(ch chan<- *stdprometheus.Desc){}
 	ps.dynamicConfig = newDynamicConfig()
+	ps.certificates = make(map[string]map[string]bool)
 	ps.state = make(map[string]*collector)
 }
 
@@ -240,6 +423,12 @@ func (ps *prometheusState) SetDynamicConfig(dynamicConfig *dynamicConfig) {
 	ps.dynamicConfig = dynamicConfig
 }
 
+func (ps *prometheusState) SetCertificates(certificates map[string]map[string]bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	ps.certificates = certificates
+}
+
 func (ps *prometheusState) ListenValueUpdates() {
 	for collector := range ps.collectors {
 		ps.mtx.Lock()
@@ -289,6 +478,10 @@ func (ps *prometheusState) isOutdated(collector *collector) bool {
 		return true
 	}
 
+	if frontendName, ok := labels["frontend"]; ok && !ps.dynamicConfig.hasFrontend(frontendName) {
+		return true
+	}
+
 	if backendName, ok := labels["backend"]; ok {
 		if !ps.dynamicConfig.hasBackend(backendName) {
 			return true
@@ -298,22 +491,28 @@ func (ps *prometheusState) isOutdated(collector *collector) bool {
 		}
 	}
 
+	if serial, ok := labels["serial"]; ok && !ps.certificates[labels["cn"]][serial] {
+		return true
+	}
+
 	return false
 }
 
 func newDynamicConfig() *dynamicConfig {
 	return &dynamicConfig{
 		entrypoints: make(map[string]bool),
+		frontends:   make(map[string]bool),
 		backends:    make(map[string]map[string]bool),
 	}
 }
 
-// dynamicConfig holds the current configuration for entrypoints, backends,
+// dynamicConfig holds the current configuration for entrypoints, frontends, backends,
 // and server URLs in an optimized way to check for existence. This provides
 // a performant way to check whether the collected metrics belong to the
 // current configuration or to an outdated one.
 type dynamicConfig struct {
 	entrypoints map[string]bool
+	frontends   map[string]bool
 	backends    map[string]map[string]bool
 }
 
@@ -322,6 +521,11 @@ func (d *dynamicConfig) hasEntrypoint(entrypointName string) bool {
 	return ok
 }
 
+func (d *dynamicConfig) hasFrontend(frontendName string) bool {
+	_, ok := d.frontends[frontendName]
+	return ok
+}
+
 func (d *dynamicConfig) hasBackend(backendName string) bool {
 	_, ok := d.backends[backendName]
 	return ok
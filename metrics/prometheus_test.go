@@ -116,6 +116,15 @@ func TestPrometheus(t *testing.T) {
 		With("method", http.MethodGet, "protocol", "http", "entrypoint", "http").
 		Set(1)
 
+	prometheusRegistry.
+		FrontendReqsCounter().
+		With("frontend", "frontend1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Add(1)
+	prometheusRegistry.
+		FrontendReqDurationHistogram().
+		With("frontend", "frontend1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Observe(1)
+
 	prometheusRegistry.
 		BackendReqsCounter().
 		With("backend", "backend1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
@@ -136,6 +145,54 @@ func TestPrometheus(t *testing.T) {
 		BackendServerUpGauge().
 		With("backend", "backend1", "url", "http://127.0.0.10:80").
 		Set(1)
+	prometheusRegistry.
+		ServerReqsCounter().
+		With("backend", "backend1", "url", "http://127.0.0.10:80", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Add(1)
+	prometheusRegistry.
+		ServerReqDurationHistogram().
+		With("backend", "backend1", "url", "http://127.0.0.10:80", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Observe(1)
+	prometheusRegistry.
+		EntrypointReqSizeHistogram().
+		With("code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http", "entrypoint", "http").
+		Observe(512)
+	prometheusRegistry.
+		EntrypointRespSizeHistogram().
+		With("code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http", "entrypoint", "http").
+		Observe(2048)
+	prometheusRegistry.
+		BackendReqSizeHistogram().
+		With("backend", "backend1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Observe(512)
+	prometheusRegistry.
+		BackendRespSizeHistogram().
+		With("backend", "backend1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Observe(2048)
+	prometheusRegistry.
+		TLSHandshakesCounter().
+		With("entrypoint", "https", "tls_version", "1.2", "tls_cipher", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256").
+		Add(1)
+	prometheusRegistry.
+		TLSHandshakeDurationHistogram().
+		With("entrypoint", "https", "tls_version", "1.2").
+		Observe(0.02)
+	prometheusRegistry.
+		TLSHandshakeErrorsCounter().
+		With("entrypoint", "https", "reason", "sni_strict").
+		Add(1)
+	prometheusRegistry.ConfigBuildDurationHistogram().With("provider", "file").Observe(0.01)
+	prometheusRegistry.ProviderEventsCounter().With("provider", "file").Add(1)
+	prometheusRegistry.ProviderThrottledConfigsCounter().With("provider", "file").Add(1)
+	prometheusRegistry.ProviderDroppedConfigsCounter().With("provider", "file").Add(1)
+	prometheusRegistry.ProviderLastSyncGauge().With("provider", "file").Set(float64(time.Now().Unix()))
+	prometheusRegistry.ProviderFrontendsGauge().With("provider", "file").Set(2)
+	prometheusRegistry.ProviderBackendsGauge().With("provider", "file").Set(1)
+	prometheusRegistry.ProviderServersGauge().With("provider", "file").Set(3)
+	prometheusRegistry.
+		CertificateDaysToExpiryGauge().
+		With("cn", "traefik.wtf", "serial", "1").
+		Set(30)
 
 	delayForTrackingCompletion()
 
@@ -162,6 +219,46 @@ func TestPrometheus(t *testing.T) {
 			name:   configLastReloadFailureName,
 			assert: buildTimestampAssert(t, configLastReloadFailureName),
 		},
+		{
+			name:   configBuildDurationName,
+			labels: map[string]string{"provider": "file"},
+			assert: buildHistogramAssert(t, configBuildDurationName, 1),
+		},
+		{
+			name:   providerEventsTotalName,
+			labels: map[string]string{"provider": "file"},
+			assert: buildCounterAssert(t, providerEventsTotalName, 1),
+		},
+		{
+			name:   providerThrottledConfigsName,
+			labels: map[string]string{"provider": "file"},
+			assert: buildCounterAssert(t, providerThrottledConfigsName, 1),
+		},
+		{
+			name:   providerDroppedConfigsName,
+			labels: map[string]string{"provider": "file"},
+			assert: buildCounterAssert(t, providerDroppedConfigsName, 1),
+		},
+		{
+			name:   providerLastSyncName,
+			labels: map[string]string{"provider": "file"},
+			assert: buildTimestampAssert(t, providerLastSyncName),
+		},
+		{
+			name:   providerFrontendsName,
+			labels: map[string]string{"provider": "file"},
+			assert: buildGaugeAssert(t, providerFrontendsName, 2),
+		},
+		{
+			name:   providerBackendsName,
+			labels: map[string]string{"provider": "file"},
+			assert: buildGaugeAssert(t, providerBackendsName, 1),
+		},
+		{
+			name:   providerServersName,
+			labels: map[string]string{"provider": "file"},
+			assert: buildGaugeAssert(t, providerServersName, 3),
+		},
 		{
 			name: entrypointReqsTotalName,
 			labels: map[string]string{
@@ -191,6 +288,113 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildGaugeAssert(t, entrypointOpenConnsName, 1),
 		},
+		{
+			name: entrypointReqSizeName,
+			labels: map[string]string{
+				"code":       "200",
+				"method":     http.MethodGet,
+				"protocol":   "http",
+				"entrypoint": "http",
+			},
+			assert: buildHistogramAssert(t, entrypointReqSizeName, 1),
+		},
+		{
+			name: entrypointRespSizeName,
+			labels: map[string]string{
+				"code":       "200",
+				"method":     http.MethodGet,
+				"protocol":   "http",
+				"entrypoint": "http",
+			},
+			assert: buildHistogramAssert(t, entrypointRespSizeName, 1),
+		},
+		{
+			name: backendReqSizeName,
+			labels: map[string]string{
+				"code":     "200",
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"backend":  "backend1",
+			},
+			assert: buildHistogramAssert(t, backendReqSizeName, 1),
+		},
+		{
+			name: backendRespSizeName,
+			labels: map[string]string{
+				"code":     "200",
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"backend":  "backend1",
+			},
+			assert: buildHistogramAssert(t, backendRespSizeName, 1),
+		},
+		{
+			name: tlsHandshakesTotalName,
+			labels: map[string]string{
+				"entrypoint":  "https",
+				"tls_version": "1.2",
+				"tls_cipher":  "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			},
+			assert: buildCounterAssert(t, tlsHandshakesTotalName, 1),
+		},
+		{
+			name: tlsHandshakeDurationName,
+			labels: map[string]string{
+				"entrypoint":  "https",
+				"tls_version": "1.2",
+			},
+			assert: buildHistogramAssert(t, tlsHandshakeDurationName, 1),
+		},
+		{
+			name: tlsHandshakeErrorsName,
+			labels: map[string]string{
+				"entrypoint": "https",
+				"reason":     "sni_strict",
+			},
+			assert: buildCounterAssert(t, tlsHandshakeErrorsName, 1),
+		},
+		{
+			name: frontendReqsTotalName,
+			labels: map[string]string{
+				"code":     "200",
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"frontend": "frontend1",
+			},
+			assert: buildCounterAssert(t, frontendReqsTotalName, 1),
+		},
+		{
+			name: frontendReqDurationName,
+			labels: map[string]string{
+				"code":     "200",
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"frontend": "frontend1",
+			},
+			assert: buildHistogramAssert(t, frontendReqDurationName, 1),
+		},
+		{
+			name: backendServerReqsTotalName,
+			labels: map[string]string{
+				"code":     "200",
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"backend":  "backend1",
+				"url":      "http://127.0.0.10:80",
+			},
+			assert: buildCounterAssert(t, backendServerReqsTotalName, 1),
+		},
+		{
+			name: backendServerReqDurationName,
+			labels: map[string]string{
+				"code":     "200",
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"backend":  "backend1",
+				"url":      "http://127.0.0.10:80",
+			},
+			assert: buildHistogramAssert(t, backendServerReqDurationName, 1),
+		},
 		{
 			name: backendReqsTotalName,
 			labels: map[string]string{
@@ -235,6 +439,14 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildGaugeAssert(t, backendServerUpName, 1),
 		},
+		{
+			name: certificateDaysToExpiryName,
+			labels: map[string]string{
+				"cn":     "traefik.wtf",
+				"serial": "1",
+			},
+			assert: buildGaugeAssert(t, certificateDaysToExpiryName, 30),
+		},
 	}
 
 	for _, test := range tests {
@@ -288,11 +500,15 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 		BackendServerUpGauge().
 		With("backend", "backend1", "url", "http://localhost:9999").
 		Set(1)
+	prometheusRegistry.
+		FrontendReqsCounter().
+		With("frontend", "frontend2", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Add(1)
 
 	delayForTrackingCompletion()
 
-	assertMetricsExist(t, mustScrape(), entrypointReqsTotalName, backendReqsTotalName, backendServerUpName)
-	assertMetricsAbsent(t, mustScrape(), entrypointReqsTotalName, backendReqsTotalName, backendServerUpName)
+	assertMetricsExist(t, mustScrape(), entrypointReqsTotalName, backendReqsTotalName, backendServerUpName, frontendReqsTotalName)
+	assertMetricsAbsent(t, mustScrape(), entrypointReqsTotalName, backendReqsTotalName, backendServerUpName, frontendReqsTotalName)
 
 	// To verify that metrics belonging to active configurations are not removed
 	// here the counter examples.
@@ -307,6 +523,38 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 	assertMetricsExist(t, mustScrape(), entrypointReqsTotalName)
 }
 
+func TestPrometheusCertificateMetricRemoval(t *testing.T) {
+	// Reset state of global promState.
+	defer promState.reset()
+
+	prometheusRegistry := RegisterPrometheus(&types.Prometheus{})
+	defer prometheus.Unregister(promState)
+
+	OnCertificatesUpdate(map[string]map[string]bool{
+		"traefik.wtf": {"1": true},
+	})
+
+	prometheusRegistry.
+		CertificateDaysToExpiryGauge().
+		With("cn", "traefik.wtf", "serial", "1").
+		Set(30)
+	// The certificate with the serial number 2 is no longer served.
+	prometheusRegistry.
+		CertificateDaysToExpiryGauge().
+		With("cn", "traefik.wtf", "serial", "2").
+		Set(10)
+
+	delayForTrackingCompletion()
+
+	family := findMetricFamily(certificateDaysToExpiryName, mustScrape())
+	assert.NotNil(t, findMetricByLabelNamesValues(family, "cn", "traefik.wtf", "serial", "1"))
+	assert.NotNil(t, findMetricByLabelNamesValues(family, "cn", "traefik.wtf", "serial", "2"))
+
+	family = findMetricFamily(certificateDaysToExpiryName, mustScrape())
+	assert.NotNil(t, findMetricByLabelNamesValues(family, "cn", "traefik.wtf", "serial", "1"))
+	assert.Nil(t, findMetricByLabelNamesValues(family, "cn", "traefik.wtf", "serial", "2"))
+}
+
 func TestPrometheusRemovedMetricsReset(t *testing.T) {
 	// Reset state of global promState.
 	defer promState.reset()
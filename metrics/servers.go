@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"net/url"
+	"sync"
+
+	"github.com/containous/traefik/types"
+)
+
+// DefaultServerMaxSeries is the default number of backend/server pairs for which per server metrics are recorded.
+const DefaultServerMaxSeries = 100
+
+// ServerSeriesLimiter guards the cardinality of the per server metrics:
+// once the limit is reached, requests to servers not already tracked are not recorded.
+type ServerSeriesLimiter struct {
+	maxSeries int
+
+	lock    sync.Mutex
+	series  map[string]map[string]bool
+	count   int
+	limited bool
+}
+
+// NewServerSeriesLimiter creates a ServerSeriesLimiter allowing at most maxSeries backend/server pairs.
+func NewServerSeriesLimiter(maxSeries int) *ServerSeriesLimiter {
+	if maxSeries <= 0 {
+		maxSeries = DefaultServerMaxSeries
+	}
+
+	return &ServerSeriesLimiter{
+		maxSeries: maxSeries,
+		series:    make(map[string]map[string]bool),
+	}
+}
+
+// Allow reports whether the metrics of the given backend server can be recorded.
+func (l *ServerSeriesLimiter) Allow(backendName, serverURL string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.series[backendName][serverURL] {
+		return true
+	}
+
+	if l.count >= l.maxSeries {
+		if !l.limited {
+			logger.Warnf("Per server metrics limit of %d series reached, the server %s of the backend %s is not tracked", l.maxSeries, serverURL, backendName)
+			l.limited = true
+		}
+		return false
+	}
+
+	if l.series[backendName] == nil {
+		l.series[backendName] = make(map[string]bool)
+	}
+	l.series[backendName][serverURL] = true
+	l.count++
+
+	return true
+}
+
+// OnConfigurationUpdate releases the series of the servers no longer present in the configuration.
+func (l *ServerSeriesLimiter) OnConfigurationUpdate(configurations types.Configurations) {
+	servers := make(map[string]map[string]bool)
+	for _, config := range configurations {
+		for backendName, backend := range config.Backends {
+			if servers[backendName] == nil {
+				servers[backendName] = make(map[string]bool)
+			}
+			for _, server := range backend.Servers {
+				servers[backendName][ServerLabel(server.URL)] = true
+			}
+		}
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for backendName, urls := range l.series {
+		for serverURL := range urls {
+			if !servers[backendName][serverURL] {
+				delete(urls, serverURL)
+				l.count--
+			}
+		}
+		if len(urls) == 0 {
+			delete(l.series, backendName)
+		}
+	}
+
+	l.limited = false
+}
+
+// ServerLabel returns the value of the url label of a server: its scheme and host.
+func ServerLabel(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"testing"
+
+	th "github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerSeriesLimiter(t *testing.T) {
+	limiter := NewServerSeriesLimiter(2)
+
+	assert.True(t, limiter.Allow("backend1", "http://10.0.0.1:80"))
+	assert.True(t, limiter.Allow("backend1", "http://10.0.0.2:80"))
+	assert.False(t, limiter.Allow("backend2", "http://10.0.0.3:80"))
+
+	// already tracked servers are still recorded once the limit is reached
+	assert.True(t, limiter.Allow("backend1", "http://10.0.0.1:80"))
+
+	configurations := types.Configurations{
+		"providerName": th.BuildConfiguration(
+			th.WithBackends(
+				th.WithBackendNew("backend1", th.WithServersNew(th.WithServerNew("http://10.0.0.1:80/path"))),
+			),
+		),
+	}
+	limiter.OnConfigurationUpdate(configurations)
+
+	assert.True(t, limiter.Allow("backend2", "http://10.0.0.3:80"))
+	assert.False(t, limiter.Allow("backend2", "http://10.0.0.4:80"))
+}
+
+func TestServerLabel(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		url      string
+		expected string
+	}{
+		{
+			desc:     "scheme and host",
+			url:      "http://10.0.0.1:80",
+			expected: "http://10.0.0.1:80",
+		},
+		{
+			desc:     "path and query are dropped",
+			url:      "https://10.0.0.1:443/foo?bar=baz",
+			expected: "https://10.0.0.1:443",
+		},
+		{
+			desc:     "not an absolute URL",
+			url:      "/foo",
+			expected: "/foo",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, ServerLabel(test.url))
+		})
+	}
+}
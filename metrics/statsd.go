@@ -3,7 +3,6 @@ package metrics
 import (
 	"time"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
 	kitlog "github.com/go-kit/kit/log"
@@ -11,13 +10,15 @@ import (
 )
 
 var statsdClient = statsd.New("traefik.", kitlog.LoggerFunc(func(keyvals ...interface{}) error {
-	log.Info(keyvals)
+	logger.Info(keyvals)
 	return nil
 }))
 
 var statsdTicker *time.Ticker
 
 const (
+	statsdMetricsFrontendReqsName     = "frontend.request.total"
+	statsdMetricsFrontendLatencyName  = "frontend.request.duration"
 	statsdMetricsBackendReqsName      = "backend.request.total"
 	statsdMetricsBackendLatencyName   = "backend.request.duration"
 	statsdRetriesTotalName            = "backend.retries.total"
@@ -28,8 +29,18 @@ const (
 	statsdEntrypointReqsName          = "entrypoint.request.total"
 	statsdEntrypointReqDurationName   = "entrypoint.request.duration"
 	statsdEntrypointOpenConnsName     = "entrypoint.connections.open"
+	statsdEntrypointReqSizeName       = "entrypoint.request.size"
+	statsdEntrypointRespSizeName      = "entrypoint.response.size"
 	statsdOpenConnsName               = "backend.connections.open"
 	statsdServerUpName                = "backend.server.up"
+	statsdMetricsBackendReqSizeName   = "backend.request.size"
+	statsdMetricsBackendRespSizeName  = "backend.response.size"
+	statsdMetricsServerReqsName       = "backend.server.request.total"
+	statsdMetricsServerLatencyName    = "backend.server.request.duration"
+	statsdTLSHandshakesName           = "tls.handshake.total"
+	statsdTLSHandshakeDurationName    = "tls.handshake.duration"
+	statsdTLSHandshakeErrorsName      = "tls.handshake.errors"
+	statsdCertificateDaysToExpiryName = "tls.certificate.daysToExpiry"
 )
 
 // RegisterStatsd registers the metrics pusher if this didn't happen yet and creates a statsd Registry instance.
@@ -47,11 +58,23 @@ func RegisterStatsd(config *types.Statsd) Registry {
 		entrypointReqsCounter:          statsdClient.NewCounter(statsdEntrypointReqsName, 1.0),
 		entrypointReqDurationHistogram: statsdClient.NewTiming(statsdEntrypointReqDurationName, 1.0),
 		entrypointOpenConnsGauge:       statsdClient.NewGauge(statsdEntrypointOpenConnsName),
+		entrypointReqSizeHistogram:     statsdClient.NewTiming(statsdEntrypointReqSizeName, 1.0),
+		entrypointRespSizeHistogram:    statsdClient.NewTiming(statsdEntrypointRespSizeName, 1.0),
+		frontendReqsCounter:            statsdClient.NewCounter(statsdMetricsFrontendReqsName, 1.0),
+		frontendReqDurationHistogram:   statsdClient.NewTiming(statsdMetricsFrontendLatencyName, 1.0),
 		backendReqsCounter:             statsdClient.NewCounter(statsdMetricsBackendReqsName, 1.0),
 		backendReqDurationHistogram:    statsdClient.NewTiming(statsdMetricsBackendLatencyName, 1.0),
 		backendRetriesCounter:          statsdClient.NewCounter(statsdRetriesTotalName, 1.0),
 		backendOpenConnsGauge:          statsdClient.NewGauge(statsdOpenConnsName),
 		backendServerUpGauge:           statsdClient.NewGauge(statsdServerUpName),
+		backendReqSizeHistogram:        statsdClient.NewTiming(statsdMetricsBackendReqSizeName, 1.0),
+		backendRespSizeHistogram:       statsdClient.NewTiming(statsdMetricsBackendRespSizeName, 1.0),
+		serverReqsCounter:              statsdClient.NewCounter(statsdMetricsServerReqsName, 1.0),
+		serverReqDurationHistogram:     statsdClient.NewTiming(statsdMetricsServerLatencyName, 1.0),
+		tlsHandshakesCounter:           statsdClient.NewCounter(statsdTLSHandshakesName, 1.0),
+		tlsHandshakeDurationHistogram:  statsdClient.NewTiming(statsdTLSHandshakeDurationName, 1.0),
+		tlsHandshakeErrorsCounter:      statsdClient.NewCounter(statsdTLSHandshakeErrorsName, 1.0),
+		certificateDaysToExpiryGauge:   statsdClient.NewGauge(statsdCertificateDaysToExpiryName),
 	}
 }
 
@@ -63,7 +86,7 @@ func initStatsdTicker(config *types.Statsd) *time.Ticker {
 	}
 	pushInterval, err := time.ParseDuration(config.PushInterval)
 	if err != nil {
-		log.Warnf("Unable to parse %s into pushInterval, using 10s as default value", config.PushInterval)
+		logger.Warnf("Unable to parse %s into pushInterval, using 10s as default value", config.PushInterval)
 		pushInterval = 10 * time.Second
 	}
 
@@ -0,0 +1,127 @@
+package accesslog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containous/traefik/types"
+	"github.com/sirupsen/logrus"
+)
+
+// frontendFile is the output of the frontends with their own access log file.
+type frontendFile struct {
+	file   *os.File
+	logger *logrus.Logger
+}
+
+// frontendFilePath returns the path of the access log file of a frontend, within the frontend files directory.
+func (l *LogHandler) frontendFilePath(name string) (string, error) {
+	if l.config.FrontendFilesDirectory == "" {
+		return "", fmt.Errorf("the frontend files directory is not configured")
+	}
+	if err := types.CheckFrontendAccessLogFilePath(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.config.FrontendFilesDirectory, name), nil
+}
+
+// frontendLogger returns the logger writing to the access log file of a frontend, which is opened on first use.
+// The main logger is returned when the file cannot be opened.
+// It must be called with the lock held.
+func (l *LogHandler) frontendLogger(name string) *logrus.Logger {
+	if output, ok := l.frontendFiles[name]; ok {
+		if output.logger == nil {
+			return l.logger
+		}
+		return output.logger
+	}
+
+	filePath, err := l.frontendFilePath(name)
+	var file *os.File
+	if err == nil {
+		file, err = openAccessLogFile(filePath)
+	}
+	if err != nil {
+		logger.Errorf("Error opening frontend access log file, using the main access log instead: %v", err)
+		// keep the failure to not try again on each request, until the next rotation
+		l.frontendFiles[name] = &frontendFile{}
+		return l.logger
+	}
+
+	// The lines of the frontend are also sent to the sinks, hence the shared hooks.
+	fileLogger := &logrus.Logger{
+		Out:       file,
+		Formatter: l.logger.Formatter,
+		Hooks:     l.logger.Hooks,
+		Level:     logrus.InfoLevel,
+	}
+	l.frontendFiles[name] = &frontendFile{file: file, logger: fileLogger}
+
+	return fileLogger
+}
+
+// OnConfigurationUpdate closes the access log files of the frontends which no longer exist.
+func (l *LogHandler) OnConfigurationUpdate(configurations types.Configurations) {
+	used := make(map[string]bool)
+	for _, config := range configurations {
+		for _, frontend := range config.Frontends {
+			if frontend.AccessLog != nil && frontend.AccessLog.FilePath != "" {
+				used[frontend.AccessLog.FilePath] = true
+			}
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for name, output := range l.frontendFiles {
+		if used[name] {
+			continue
+		}
+
+		if output.file != nil {
+			if err := output.file.Close(); err != nil {
+				logger.Errorf("Error closing frontend access log file %s: %v", name, err)
+			}
+		}
+		delete(l.frontendFiles, name)
+	}
+}
+
+// rotateFrontendFiles closes and reopens the access log files of the frontends.
+// It must be called with the lock held.
+func (l *LogHandler) rotateFrontendFiles() error {
+	var lastErr error
+	for name, output := range l.frontendFiles {
+		if output.file == nil {
+			delete(l.frontendFiles, name)
+			continue
+		}
+
+		file, err := os.OpenFile(output.file.Name(), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0664)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		_ = output.file.Close()
+		output.file = file
+		output.logger.Out = file
+	}
+	return lastErr
+}
+
+func (l *LogHandler) closeFrontendFiles() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for name, output := range l.frontendFiles {
+		if output.file != nil {
+			if err := output.file.Close(); err != nil {
+				logger.Errorf("Error closing frontend access log file %s: %v", name, err)
+			}
+		}
+		delete(l.frontendFiles, name)
+	}
+}
@@ -2,6 +2,9 @@ package accesslog
 
 import (
 	"net/http"
+
+	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/types"
 )
 
 const (
@@ -74,8 +77,30 @@ const (
 	Overhead = "Overhead"
 	// RetryAttempts is the map key used for the amount of attempts the request was retried.
 	RetryAttempts = "RetryAttempts"
+	// EntryPointName is the map key used for the name of the entry point which received the request.
+	EntryPointName = "EntryPointName"
+	// MatchedRule is the map key used for the rule of the frontend which matched the request.
+	MatchedRule = "MatchedRule"
+	// RequestID is the map key used for the request ID, read from the X-Request-Id header.
+	RequestID = "RequestID"
+	// TraceID is the map key used for the ID of the trace of the request, when tracing is enabled.
+	TraceID = "TraceID"
+	// TLSVersion is the map key used for the TLS version of the connection of the client, if any.
+	TLSVersion = "TLSVersion"
+	// TLSCipher is the map key used for the TLS cipher suite of the connection of the client, if any.
+	TLSCipher = "TLSCipher"
+	// TLSServerName is the map key used for the server name (SNI) requested by the client, if any.
+	TLSServerName = "TLSServerName"
+	// OriginConnectDuration is the map key used for the time taken to open the connection to the origin server,
+	// including the TLS handshake. It is 0 when an idle connection was reused.
+	OriginConnectDuration = "OriginConnectDuration"
+	// OriginTimeToFirstByte is the map key used for the time taken by the origin server to send the first byte of its response.
+	OriginTimeToFirstByte = "OriginTimeToFirstByte"
 )
 
+// RequestIDHeader is the request header holding the request ID.
+const RequestIDHeader = middlewares.RequestIDHeader
+
 // These are written out in the default case when no config is provided to specify keys of interest.
 var defaultCoreKeys = [...]string{
 	StartUTC,
@@ -117,6 +142,15 @@ func init() {
 	allCoreKeys[StartLocal] = struct{}{}
 	allCoreKeys[Overhead] = struct{}{}
 	allCoreKeys[RetryAttempts] = struct{}{}
+	allCoreKeys[EntryPointName] = struct{}{}
+	allCoreKeys[MatchedRule] = struct{}{}
+	allCoreKeys[RequestID] = struct{}{}
+	allCoreKeys[TraceID] = struct{}{}
+	allCoreKeys[TLSVersion] = struct{}{}
+	allCoreKeys[TLSCipher] = struct{}{}
+	allCoreKeys[TLSServerName] = struct{}{}
+	allCoreKeys[OriginConnectDuration] = struct{}{}
+	allCoreKeys[OriginTimeToFirstByte] = struct{}{}
 }
 
 // CoreLogData holds the fields computed from the request/response.
@@ -128,4 +162,6 @@ type LogData struct {
 	Request            http.Header
 	OriginResponse     http.Header
 	DownstreamResponse http.Header
+	// FrontendAccessLog holds the access log settings of the frontend which matched the request, if any.
+	FrontendAccessLog *types.FrontendAccessLog
 }
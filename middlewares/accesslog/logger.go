@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
@@ -15,10 +16,15 @@ import (
 
 	"github.com/containous/flaeg/parse"
 	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/middlewares/tracing"
+	traefiktls "github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
 	"github.com/sirupsen/logrus"
+	"github.com/urfave/negroni"
 )
 
+var logger = log.WithModule("middlewares/accesslog")
+
 type key string
 
 const (
@@ -31,6 +37,12 @@ const (
 
 	// JSONFormat is the JSON logging format
 	JSONFormat = "json"
+
+	// LogfmtFormat is the logfmt logging format (key=value pairs)
+	LogfmtFormat = "logfmt"
+
+	// TemplateFormat is the logging format defined by a Go template
+	TemplateFormat = "template"
 )
 
 type noopCloser struct {
@@ -61,6 +73,8 @@ type LogHandler struct {
 	httpCodeRanges types.HTTPCodeRanges
 	logHandlerChan chan logHandlerParams
 	wg             sync.WaitGroup
+	sinks          []sink
+	frontendFiles  map[string]*frontendFile
 }
 
 // NewLogHandler creates a new LogHandler
@@ -82,27 +96,47 @@ func NewLogHandler(config *types.AccessLog) (*LogHandler, error) {
 		formatter = new(CommonLogFormatter)
 	case JSONFormat:
 		formatter = new(logrus.JSONFormatter)
+	case LogfmtFormat:
+		formatter = new(LogfmtFormatter)
+	case TemplateFormat:
+		templateFormatter, err := NewTemplateFormatter(config.Template)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("error parsing access log template: %v", err)
+		}
+		formatter = templateFormatter
 	default:
 		return nil, fmt.Errorf("unsupported access log format: %s", config.Format)
 	}
 
-	logger := &logrus.Logger{
+	sinks, err := newSinks(config.Sinks, formatter)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	accessLogger := &logrus.Logger{
 		Out:       file,
 		Formatter: formatter,
 		Hooks:     make(logrus.LevelHooks),
 		Level:     logrus.InfoLevel,
 	}
+	for _, s := range sinks {
+		accessLogger.Hooks.Add(s)
+	}
 
 	logHandler := &LogHandler{
 		config:         config,
-		logger:         logger,
+		logger:         accessLogger,
 		file:           file,
 		logHandlerChan: logHandlerChan,
+		sinks:          sinks,
+		frontendFiles:  make(map[string]*frontendFile),
 	}
 
 	if config.Filters != nil {
 		if httpCodeRanges, err := types.NewHTTPCodeRanges(config.Filters.StatusCodes); err != nil {
-			log.Errorf("Failed to create new HTTP code ranges: %s", err)
+			logger.Errorf("Failed to create new HTTP code ranges: %s", err)
 		} else {
 			logHandler.httpCodeRanges = httpCodeRanges
 		}
@@ -142,11 +176,23 @@ func GetLogDataTable(req *http.Request) *LogData {
 	if ld, ok := req.Context().Value(DataTableKey).(*LogData); ok {
 		return ld
 	}
-	log.Errorf("%s is nil", DataTableKey)
+	logger.Errorf("%s is nil", DataTableKey)
 	return &LogData{Core: make(CoreLogData)}
 }
 
+// EntryPointHandler returns a handler writing the requests received by an entry point to the access log,
+// along with the name of the entry point.
+func (l *LogHandler) EntryPointHandler(entryPointName string) negroni.Handler {
+	return negroni.HandlerFunc(func(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		l.serveHTTP(rw, req, next, entryPointName)
+	})
+}
+
 func (l *LogHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	l.serveHTTP(rw, req, next, "")
+}
+
+func (l *LogHandler) serveHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc, entryPointName string) {
 	now := time.Now().UTC()
 
 	core := CoreLogData{
@@ -190,6 +236,23 @@ func (l *LogHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next h
 		core[ClientHost] = forwardedFor
 	}
 
+	if entryPointName != "" {
+		core[EntryPointName] = entryPointName
+	}
+	if requestID := req.Header.Get(RequestIDHeader); requestID != "" {
+		core[RequestID] = requestID
+	}
+	if traceID := tracing.GetTraceID(req); traceID != "" {
+		core[TraceID] = traceID
+	}
+	if req.TLS != nil {
+		core[TLSVersion] = traefiktls.VersionName(req.TLS.Version)
+		core[TLSCipher] = traefiktls.CipherSuiteName(req.TLS.CipherSuite)
+		if req.TLS.ServerName != "" {
+			core[TLSServerName] = req.TLS.ServerName
+		}
+	}
+
 	crw := &captureResponseWriter{rw: rw}
 
 	next.ServeHTTP(crw, reqWithDataTable)
@@ -209,10 +272,16 @@ func (l *LogHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next h
 	}
 }
 
-// Close closes the Logger (i.e. the file, drain logHandlerChan, etc).
+// Close closes the Logger (i.e. the file, drain logHandlerChan, flush the sinks, etc).
 func (l *LogHandler) Close() error {
 	close(l.logHandlerChan)
 	l.wg.Wait()
+	for _, s := range l.sinks {
+		if err := s.Close(); err != nil {
+			logger.Errorf("Error closing access log sink: %v", err)
+		}
+	}
+	l.closeFrontendFiles()
 	return l.file.Close()
 }
 
@@ -220,7 +289,9 @@ func (l *LogHandler) Close() error {
 // by an external source.
 func (l *LogHandler) Rotate() error {
 	if l.config.FilePath == "" {
-		return nil
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.rotateFrontendFiles()
 	}
 
 	if l.file != nil {
@@ -235,7 +306,7 @@ func (l *LogHandler) Rotate() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.logger.Out = l.file
-	return nil
+	return l.rotateFrontendFiles()
 }
 
 func silentSplitHostPort(value string) (host string, port string) {
@@ -258,6 +329,11 @@ func formatUsernameForLog(usernameField interface{}) string {
 func (l *LogHandler) logTheRoundTrip(logDataTable *LogData, crr *captureRequestReader, crw *captureResponseWriter) {
 	core := logDataTable.Core
 
+	frontendConfig := logDataTable.FrontendAccessLog
+	if frontendConfig != nil && frontendConfig.Disabled {
+		return
+	}
+
 	retryAttempts, ok := core[RetryAttempts].(int)
 	if !ok {
 		retryAttempts = 0
@@ -274,7 +350,10 @@ func (l *LogHandler) logTheRoundTrip(logDataTable *LogData, crr *captureRequestR
 	totalDuration := time.Now().UTC().Sub(core[StartUTC].(time.Time))
 	core[Duration] = totalDuration
 
-	if l.keepAccessLog(crw.Status(), retryAttempts, totalDuration) {
+	// The verbose frontends bypass the filters, not the fields and headers modes.
+	verbose := frontendConfig != nil && frontendConfig.Verbose
+
+	if verbose || l.keepAccessLog(crw.Status(), retryAttempts, totalDuration, l.sampleRate(frontendConfig)) {
 		core[DownstreamStatusLine] = fmt.Sprintf("%03d %s", crw.Status(), http.StatusText(crw.Status()))
 		core[DownstreamContentSize] = crw.Size()
 		if original, ok := core[OriginContentSize]; ok {
@@ -292,8 +371,11 @@ func (l *LogHandler) logTheRoundTrip(logDataTable *LogData, crr *captureRequestR
 		fields := logrus.Fields{}
 
 		for k, v := range logDataTable.Core {
-			if l.config.Fields.Keep(k) {
+			switch l.config.Fields.KeepField(k) {
+			case types.AccessLogKeep:
 				fields[k] = v
+			case types.AccessLogRedact:
+				fields[k] = "REDACTED"
 			}
 		}
 
@@ -303,7 +385,12 @@ func (l *LogHandler) logTheRoundTrip(logDataTable *LogData, crr *captureRequestR
 
 		l.mu.Lock()
 		defer l.mu.Unlock()
-		l.logger.WithFields(fields).Println()
+
+		accessLogger := l.logger
+		if frontendConfig != nil && frontendConfig.FilePath != "" {
+			accessLogger = l.frontendLogger(frontendConfig.FilePath)
+		}
+		accessLogger.WithFields(fields).Println()
 	}
 }
 
@@ -318,13 +405,25 @@ func (l *LogHandler) redactHeaders(headers http.Header, fields logrus.Fields, pr
 	}
 }
 
-func (l *LogHandler) keepAccessLog(statusCode, retryAttempts int, duration time.Duration) bool {
-	if l.config.Filters == nil {
-		// no filters were specified
-		return true
+// sampleRate returns the sample rate of the access logs, which can be overridden by the frontend
+func (l *LogHandler) sampleRate(frontendConfig *types.FrontendAccessLog) float64 {
+	if frontendConfig != nil && frontendConfig.SampleRate > 0 {
+		return frontendConfig.SampleRate
+	}
+	if l.config.Filters != nil {
+		return l.config.Filters.SampleRate
 	}
+	return 0
+}
 
-	if len(l.httpCodeRanges) == 0 && !l.config.Filters.RetryAttempts && l.config.Filters.MinDuration == 0 {
+func (l *LogHandler) keepAccessLog(statusCode, retryAttempts int, duration time.Duration, sampleRate float64) bool {
+	filters := l.config.Filters
+	if filters == nil {
+		// no filters were specified, except maybe the sample rate of the frontend
+		filters = &types.AccessLogFilters{}
+	}
+
+	if len(l.httpCodeRanges) == 0 && !filters.RetryAttempts && filters.MinDuration == 0 && sampleRate <= 0 {
 		// empty filters were specified, e.g. by passing --accessLog.filters only (without other filter options)
 		return true
 	}
@@ -333,11 +432,15 @@ func (l *LogHandler) keepAccessLog(statusCode, retryAttempts int, duration time.
 		return true
 	}
 
-	if l.config.Filters.RetryAttempts && retryAttempts > 0 {
+	if filters.RetryAttempts && retryAttempts > 0 {
+		return true
+	}
+
+	if filters.MinDuration > 0 && (parse.Duration(duration) > filters.MinDuration) {
 		return true
 	}
 
-	if l.config.Filters.MinDuration > 0 && (parse.Duration(duration) > l.config.Filters.MinDuration) {
+	if sampleRate > 0 && rand.Float64() < sampleRate {
 		return true
 	}
 
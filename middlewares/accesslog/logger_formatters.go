@@ -3,6 +3,11 @@ package accesslog
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -21,16 +26,17 @@ type CommonLogFormatter struct{}
 func (f *CommonLogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	b := &bytes.Buffer{}
 
+	// The fields can be redacted, hence the checked type assertions.
 	var timestamp = defaultValue
-	if v, ok := entry.Data[StartUTC]; ok {
-		timestamp = v.(time.Time).Format(commonLogTimeFormat)
-	} else if v, ok := entry.Data[StartLocal]; ok {
-		timestamp = v.(time.Time).Local().Format(commonLogTimeFormat)
+	if v, ok := entry.Data[StartUTC].(time.Time); ok {
+		timestamp = v.Format(commonLogTimeFormat)
+	} else if v, ok := entry.Data[StartLocal].(time.Time); ok {
+		timestamp = v.Local().Format(commonLogTimeFormat)
 	}
 
 	var elapsedMillis int64
-	if v, ok := entry.Data[Duration]; ok {
-		elapsedMillis = v.(time.Duration).Nanoseconds() / 1000000
+	if v, ok := entry.Data[Duration].(time.Duration); ok {
+		elapsedMillis = v.Nanoseconds() / 1000000
 	}
 
 	_, err := fmt.Fprintf(b, "%s - %s [%s] \"%s %s %s\" %v %v %s %s %v %s %s %dms\n",
@@ -82,3 +88,125 @@ func toLogEntry(s string, defaultValue string, quote bool) string {
 	}
 	return s
 }
+
+// LogfmtFormatter provides formatting in the logfmt format: key=value pairs sorted by key
+type LogfmtFormatter struct{}
+
+// Format formats the log entry in the logfmt format
+func (f *LogfmtFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	keys := make([]string, 0, len(entry.Data))
+	for k := range entry.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b := &bytes.Buffer{}
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(entry.Data[k]))
+	}
+	b.WriteByte('\n')
+
+	return b.Bytes(), nil
+}
+
+func logfmtValue(v interface{}) string {
+	var s string
+	switch value := v.(type) {
+	case nil:
+		s = ""
+	case string:
+		s = value
+	case time.Time:
+		s = value.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		s = value.String()
+	default:
+		s = fmt.Sprint(value)
+	}
+
+	needsQuotes := s == "" || strings.IndexFunc(s, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == '\\' || r == 0x7f
+	}) >= 0
+	if needsQuotes {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// TemplateFormatter provides formatting with a user defined Go template.
+// The fields are available by name ({{.RequestPath}}), the ones which are absent or dropped have the "-" value.
+// The headers are available with the field function ({{field "request_User-Agent"}}).
+type TemplateFormatter struct {
+	mu       sync.Mutex
+	template *template.Template
+	data     map[string]interface{}
+}
+
+// NewTemplateFormatter creates a TemplateFormatter from the text of a template
+func NewTemplateFormatter(text string) (*TemplateFormatter, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("empty template")
+	}
+
+	f := &TemplateFormatter{}
+
+	tmpl, err := template.New("accesslog").Funcs(template.FuncMap{
+		"field": f.field,
+		"quote": func(v interface{}) string { return strconv.Quote(fmt.Sprint(v)) },
+		"ms":    durationMillis,
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	f.template = tmpl
+
+	return f, nil
+}
+
+// Format formats the log entry with the template
+func (f *TemplateFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(map[string]interface{}, len(allCoreKeys)+len(entry.Data))
+	for k := range allCoreKeys {
+		data[k] = defaultValue
+	}
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// data is read by the field function during the execution
+	f.data = data
+	defer func() { f.data = nil }()
+
+	b := &bytes.Buffer{}
+	if err := f.template.Execute(b, data); err != nil {
+		return nil, err
+	}
+	if !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+		b.WriteByte('\n')
+	}
+
+	return b.Bytes(), nil
+}
+
+func (f *TemplateFormatter) field(name string) interface{} {
+	if v, ok := f.data[name]; ok && v != nil {
+		return v
+	}
+	return defaultValue
+}
+
+// durationMillis returns the number of milliseconds of a duration, or the value itself when it is not a duration
+func durationMillis(v interface{}) interface{} {
+	if d, ok := v.(time.Duration); ok {
+		return d.Nanoseconds() / int64(time.Millisecond)
+	}
+	return v
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommonLogFormatter_Format(t *testing.T) {
@@ -79,6 +80,19 @@ func TestCommonLogFormatter_Format(t *testing.T) {
 				BackendURL:             "http://10.0.0.2/toto",
 			},
 			expectedLog: `10.0.0.1 - Client [10/Nov/2009:14:00:00 -0900] "GET /foo http" 123 132 "referer" "agent" - "foo" "http://10.0.0.2/toto" 123000ms
+`,
+		},
+		{
+			name: "StartUTC & Duration are redacted",
+			data: map[string]interface{}{
+				StartUTC:        "REDACTED",
+				Duration:        "REDACTED",
+				ClientHost:      "10.0.0.1",
+				RequestMethod:   http.MethodGet,
+				RequestPath:     "/foo",
+				RequestProtocol: "http",
+			},
+			expectedLog: `10.0.0.1 - - [-] "GET /foo http" - - "-" "-" - - - 0ms
 `,
 		},
 	}
@@ -163,3 +177,127 @@ func Test_toLog(t *testing.T) {
 		})
 	}
 }
+
+func TestLogfmtFormatter_Format(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		data        map[string]interface{}
+		expectedLog string
+	}{
+		{
+			desc:        "no fields",
+			data:        map[string]interface{}{},
+			expectedLog: "\n",
+		},
+		{
+			desc: "fields sorted by key",
+			data: map[string]interface{}{
+				StartUTC:         time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+				Duration:         123 * time.Millisecond,
+				RequestMethod:    http.MethodGet,
+				RequestPath:      "/foo",
+				DownstreamStatus: 200,
+				OriginStatus:     nil,
+			},
+			expectedLog: `DownstreamStatus=200 Duration=123ms OriginStatus="" RequestMethod=GET RequestPath=/foo StartUTC=2009-11-10T23:00:00Z
+`,
+		},
+		{
+			desc: "quoted values",
+			data: map[string]interface{}{
+				RequestLine:            "GET /foo HTTP/1.1",
+				RequestUserAgentHeader: `agent "quoted"`,
+				RequestPath:            "/foo?bar=baz",
+				FrontendName:           "",
+			},
+			expectedLog: `FrontendName="" RequestLine="GET /foo HTTP/1.1" RequestPath="/foo?bar=baz" request_User-Agent="agent \"quoted\""
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := new(LogfmtFormatter).Format(&logrus.Entry{Data: test.data})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedLog, string(raw))
+		})
+	}
+}
+
+func TestTemplateFormatter_Format(t *testing.T) {
+	data := map[string]interface{}{
+		ClientHost:             "10.0.0.1",
+		RequestMethod:          http.MethodGet,
+		RequestPath:            "/foo",
+		DownstreamStatus:       200,
+		Duration:               1234 * time.Millisecond,
+		TLSVersion:             "1.3",
+		RequestUserAgentHeader: "agent",
+	}
+
+	testCases := []struct {
+		desc          string
+		template      string
+		expectedLog   string
+		expectedError bool
+	}{
+		{
+			desc:        "fields",
+			template:    `{{.ClientHost}} {{.RequestMethod}} {{.RequestPath}} {{.DownstreamStatus}} {{.TLSVersion}}`,
+			expectedLog: "10.0.0.1 GET /foo 200 1.3\n",
+		},
+		{
+			desc:        "absent fields",
+			template:    `{{.ClientUsername}} {{.TraceID}} {{field "request_Referer"}}`,
+			expectedLog: "- - -\n",
+		},
+		{
+			desc:        "functions",
+			template:    `{{ms .Duration}}ms {{quote (field "request_User-Agent")}}`,
+			expectedLog: "1234ms \"agent\"\n",
+		},
+		{
+			desc:        "trailing new line",
+			template:    "{{.RequestPath}}\n",
+			expectedLog: "/foo\n",
+		},
+		{
+			desc:          "empty template",
+			template:      "  ",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid template",
+			template:      "{{.RequestPath",
+			expectedError: true,
+		},
+		{
+			desc:          "unknown function",
+			template:      "{{unknown .RequestPath}}",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			formatter, err := NewTemplateFormatter(test.template)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			raw, err := formatter.Format(&logrus.Entry{Data: data})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedLog, string(raw))
+		})
+	}
+}
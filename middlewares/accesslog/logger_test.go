@@ -1,6 +1,7 @@
 package accesslog
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -308,6 +309,30 @@ func TestLoggerJSON(t *testing.T) {
 				RequestRefererHeader: assertString(testReferer),
 			},
 		},
+		{
+			desc: "default config drop all fields and headers but redact someone",
+			config: &types.AccessLog{
+				FilePath: "",
+				Format:   JSONFormat,
+				Fields: &types.AccessLogFields{
+					DefaultMode: "drop",
+					Names: types.FieldNames{
+						ClientHost:     "redact",
+						ClientUsername: "keep",
+					},
+					Headers: &types.FieldHeaders{
+						DefaultMode: "drop",
+					},
+				},
+			},
+			expected: map[string]func(t *testing.T, value interface{}){
+				ClientHost:     assertString("REDACTED"),
+				ClientUsername: assertString(testUsername),
+				"level":        assertString("info"),
+				"msg":          assertString(""),
+				"time":         assertNotEqual(""),
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -339,6 +364,243 @@ func TestLoggerJSON(t *testing.T) {
 	}
 }
 
+func TestLoggerAdditionalFields(t *testing.T) {
+	tmpDir := createTempDir(t, JSONFormat)
+	defer os.RemoveAll(tmpDir)
+
+	logFilePath := filepath.Join(tmpDir, logFileNameSuffix)
+	logHandler, err := NewLogHandler(&types.AccessLog{FilePath: logFilePath, Format: JSONFormat})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "https://foo.bar/baz", nil)
+	req.Header.Set(RequestIDHeader, "1234")
+	req.TLS = &tls.ConnectionState{
+		Version:     tls.VersionTLS12,
+		CipherSuite: tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		ServerName:  "foo.bar",
+	}
+
+	next := func(rw http.ResponseWriter, r *http.Request) {
+		NewSaveRule(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusOK)
+		}), "Host:foo.bar").ServeHTTP(rw, r)
+	}
+	logHandler.EntryPointHandler("https").ServeHTTP(httptest.NewRecorder(), req, next)
+	require.NoError(t, logHandler.Close())
+
+	logData, err := ioutil.ReadFile(logFilePath)
+	require.NoError(t, err)
+
+	jsonData := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(logData, &jsonData))
+
+	assert.Equal(t, "https", jsonData[EntryPointName])
+	assert.Equal(t, "Host:foo.bar", jsonData[MatchedRule])
+	assert.Equal(t, "1234", jsonData[RequestID])
+	assert.Equal(t, "1.2", jsonData[TLSVersion])
+	assert.Equal(t, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", jsonData[TLSCipher])
+	assert.Equal(t, "foo.bar", jsonData[TLSServerName])
+	assert.NotContains(t, jsonData, TraceID)
+}
+
+func TestLoggerFrontendAccessLog(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		config           *types.AccessLog
+		frontendConfig   *types.FrontendAccessLog
+		expectedLines    int
+		expectedFrontend int
+		expectedFields   map[string]interface{}
+	}{
+		{
+			desc:           "disabled",
+			config:         &types.AccessLog{Format: JSONFormat},
+			frontendConfig: &types.FrontendAccessLog{Disabled: true},
+		},
+		{
+			desc: "sample rate overriding the filters",
+			config: &types.AccessLog{
+				Format: JSONFormat,
+				Filters: &types.AccessLogFilters{
+					StatusCodes: []string{"500"},
+				},
+			},
+			frontendConfig: &types.FrontendAccessLog{SampleRate: 1},
+			expectedLines:  1,
+		},
+		{
+			desc: "verbose bypassing the filters",
+			config: &types.AccessLog{
+				Format: JSONFormat,
+				Filters: &types.AccessLogFilters{
+					StatusCodes: []string{"500"},
+				},
+			},
+			frontendConfig: &types.FrontendAccessLog{Verbose: true},
+			expectedLines:  1,
+			expectedFields: map[string]interface{}{
+				FrontendName:    testFrontendName,
+				"request_X-Foo": "bar",
+			},
+		},
+		{
+			desc: "verbose keeping the fields and headers modes",
+			config: &types.AccessLog{
+				Format: JSONFormat,
+				Filters: &types.AccessLogFilters{
+					StatusCodes: []string{"500"},
+				},
+				Fields: &types.AccessLogFields{
+					DefaultMode: types.AccessLogDrop,
+					Names: types.FieldNames{
+						FrontendName: types.AccessLogKeep,
+					},
+					Headers: &types.FieldHeaders{
+						DefaultMode: types.AccessLogRedact,
+					},
+				},
+			},
+			frontendConfig: &types.FrontendAccessLog{Verbose: true},
+			expectedLines:  1,
+			expectedFields: map[string]interface{}{
+				FrontendName:            testFrontendName,
+				RequestHost:             nil,
+				"request_X-Foo":         "REDACTED",
+				"request_Authorization": "REDACTED",
+			},
+		},
+		{
+			desc:             "own file",
+			config:           &types.AccessLog{Format: JSONFormat, FrontendFilesDirectory: "frontends"},
+			frontendConfig:   &types.FrontendAccessLog{FilePath: "frontend.log"},
+			expectedFrontend: 1,
+		},
+		{
+			desc:           "own file without frontend files directory",
+			config:         &types.AccessLog{Format: JSONFormat},
+			frontendConfig: &types.FrontendAccessLog{FilePath: "frontend.log"},
+			expectedLines:  1,
+		},
+		{
+			desc:           "own file outside of the frontend files directory",
+			config:         &types.AccessLog{Format: JSONFormat, FrontendFilesDirectory: "frontends"},
+			frontendConfig: &types.FrontendAccessLog{FilePath: "../frontend.log"},
+			expectedLines:  1,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			tmpDir := createTempDir(t, JSONFormat)
+			defer os.RemoveAll(tmpDir)
+
+			test.config.FilePath = filepath.Join(tmpDir, logFileNameSuffix)
+			frontendsDir := filepath.Join(tmpDir, "frontends")
+			if test.config.FrontendFilesDirectory != "" {
+				test.config.FrontendFilesDirectory = frontendsDir
+			}
+
+			logHandler, err := NewLogHandler(test.config)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+			req.Header.Set("X-Foo", "bar")
+			req.Header.Set("Authorization", "Basic dGVzdDp0ZXN0")
+			next := NewSaveFrontendAccessLog(http.HandlerFunc(logWriterTestHandlerFunc), test.frontendConfig)
+			logHandler.ServeHTTP(httptest.NewRecorder(), req, next.ServeHTTP)
+
+			require.NoError(t, logHandler.Close())
+
+			assert.Equal(t, test.expectedLines, lineCount(t, test.config.FilePath))
+			if test.frontendConfig.FilePath != "" {
+				frontendFilePath := filepath.Join(frontendsDir, test.frontendConfig.FilePath)
+				if test.expectedFrontend > 0 {
+					assert.Equal(t, test.expectedFrontend, lineCount(t, frontendFilePath))
+				} else {
+					_, err = os.Stat(frontendFilePath)
+					assert.True(t, os.IsNotExist(err), "the frontend file %s must not be created", frontendFilePath)
+				}
+			}
+
+			if len(test.expectedFields) > 0 {
+				logData, err := ioutil.ReadFile(test.config.FilePath)
+				require.NoError(t, err)
+
+				jsonData := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal(logData, &jsonData))
+				for field, expected := range test.expectedFields {
+					if expected == nil {
+						assert.NotContains(t, jsonData, field)
+					} else {
+						assert.Equal(t, expected, jsonData[field], field)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLoggerFrontendAccessLogRotation(t *testing.T) {
+	tmpDir := createTempDir(t, CommonFormat)
+	defer os.RemoveAll(tmpDir)
+
+	fileName := filepath.Join(tmpDir, logFileNameSuffix)
+	frontendFileName := filepath.Join(tmpDir, "frontend.log")
+	rotatedFileName := frontendFileName + ".rotated"
+
+	logHandler, err := NewLogHandler(&types.AccessLog{FilePath: fileName, Format: CommonFormat, FrontendFilesDirectory: tmpDir})
+	require.NoError(t, err)
+	defer logHandler.Close()
+
+	frontendConfig := &types.FrontendAccessLog{FilePath: "frontend.log"}
+	serve := func() {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+		next := NewSaveFrontendAccessLog(http.HandlerFunc(logWriterTestHandlerFunc), frontendConfig)
+		logHandler.ServeHTTP(httptest.NewRecorder(), req, next.ServeHTTP)
+	}
+
+	serve()
+	require.NoError(t, os.Rename(frontendFileName, rotatedFileName))
+	require.NoError(t, logHandler.Rotate())
+	serve()
+
+	assert.Equal(t, 1, lineCount(t, rotatedFileName))
+	assert.Equal(t, 1, lineCount(t, frontendFileName))
+	assert.Equal(t, 0, lineCount(t, fileName))
+}
+
+func TestLoggerFrontendAccessLogRemovedFrontend(t *testing.T) {
+	tmpDir := createTempDir(t, CommonFormat)
+	defer os.RemoveAll(tmpDir)
+
+	logHandler, err := NewLogHandler(&types.AccessLog{FilePath: filepath.Join(tmpDir, logFileNameSuffix), Format: CommonFormat, FrontendFilesDirectory: tmpDir})
+	require.NoError(t, err)
+	defer logHandler.Close()
+
+	for _, name := range []string{"frontend1.log", "frontend2.log"} {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+		next := NewSaveFrontendAccessLog(http.HandlerFunc(logWriterTestHandlerFunc), &types.FrontendAccessLog{FilePath: name})
+		logHandler.ServeHTTP(httptest.NewRecorder(), req, next.ServeHTTP)
+	}
+	require.Len(t, logHandler.frontendFiles, 2)
+	removed := logHandler.frontendFiles["frontend2.log"].file
+
+	logHandler.OnConfigurationUpdate(types.Configurations{
+		"file": &types.Configuration{
+			Frontends: map[string]*types.Frontend{
+				"frontend1": {AccessLog: &types.FrontendAccessLog{FilePath: "frontend1.log"}},
+				"frontend3": {},
+			},
+		},
+	})
+
+	assert.Len(t, logHandler.frontendFiles, 1)
+	assert.Contains(t, logHandler.frontendFiles, "frontend1.log")
+	// the file of the removed frontend is closed
+	assert.Error(t, removed.Close())
+}
+
 func TestNewLogHandlerOutputStdout(t *testing.T) {
 	testCases := []struct {
 		desc        string
@@ -406,6 +668,30 @@ func TestNewLogHandlerOutputStdout(t *testing.T) {
 			},
 			expectedLog: `TestHost - TestUser [13/Apr/2016:07:14:19 -0700] "POST testpath HTTP/0.0" 123 12 "testReferer" "testUserAgent" 23 "testFrontend" "http://127.0.0.1/testBackend" 1ms`,
 		},
+		{
+			desc: "Sample rate filter keeping every log",
+			config: &types.AccessLog{
+				FilePath: "",
+				Format:   CommonFormat,
+				Filters: &types.AccessLogFilters{
+					StatusCodes: []string{"200"},
+					SampleRate:  1,
+				},
+			},
+			expectedLog: `TestHost - TestUser [13/Apr/2016:07:14:19 -0700] "POST testpath HTTP/0.0" 123 12 "testReferer" "testUserAgent" 23 "testFrontend" "http://127.0.0.1/testBackend" 1ms`,
+		},
+		{
+			desc: "Sample rate filter without status code filter matching",
+			config: &types.AccessLog{
+				FilePath: "",
+				Format:   CommonFormat,
+				Filters: &types.AccessLogFilters{
+					StatusCodes: []string{"200"},
+					SampleRate:  0,
+				},
+			},
+			expectedLog: ``,
+		},
 		{
 			desc: "Retry attempts filter matching",
 			config: &types.AccessLog{
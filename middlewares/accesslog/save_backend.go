@@ -3,6 +3,8 @@ package accesslog
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptrace"
+	"sync"
 	"time"
 
 	"github.com/urfave/negroni"
@@ -22,8 +24,8 @@ func NewSaveBackend(next http.Handler, backendName string) http.Handler {
 }
 
 func (sb *SaveBackend) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	serveSaveBackend(rw, r, sb.backendName, func(crw *captureResponseWriter) {
-		sb.next.ServeHTTP(crw, r)
+	serveSaveBackend(rw, r, sb.backendName, func(crw *captureResponseWriter, req *http.Request) {
+		sb.next.ServeHTTP(crw, req)
 	})
 }
 
@@ -39,12 +41,12 @@ func NewSaveNegroniBackend(next negroni.Handler, backendName string) negroni.Han
 }
 
 func (sb *SaveNegroniBackend) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-	serveSaveBackend(rw, r, sb.backendName, func(crw *captureResponseWriter) {
-		sb.next.ServeHTTP(crw, r, next)
+	serveSaveBackend(rw, r, sb.backendName, func(crw *captureResponseWriter, req *http.Request) {
+		sb.next.ServeHTTP(crw, req, next)
 	})
 }
 
-func serveSaveBackend(rw http.ResponseWriter, r *http.Request, backendName string, apply func(*captureResponseWriter)) {
+func serveSaveBackend(rw http.ResponseWriter, r *http.Request, backendName string, apply func(*captureResponseWriter, *http.Request)) {
 	table := GetLogDataTable(r)
 	table.Core[BackendName] = backendName
 	table.Core[BackendURL] = r.URL // note that this is *not* the original incoming URL
@@ -53,10 +55,12 @@ func serveSaveBackend(rw http.ResponseWriter, r *http.Request, backendName strin
 	crw := &captureResponseWriter{rw: rw}
 	start := time.Now().UTC()
 
-	apply(crw)
+	timings := &originTimings{start: start}
+	apply(crw, r.WithContext(httptrace.WithClientTrace(r.Context(), timings.clientTrace())))
 
 	// use UTC to handle switchover of daylight saving correctly
 	table.Core[OriginDuration] = time.Now().UTC().Sub(start)
+	timings.save(table)
 	table.Core[OriginStatus] = crw.Status()
 	table.Core[OriginStatusLine] = fmt.Sprintf("%03d %s", crw.Status(), http.StatusText(crw.Status()))
 	// make copy of headers so we can ensure there is no subsequent mutation during response processing
@@ -64,3 +68,51 @@ func serveSaveBackend(rw http.ResponseWriter, r *http.Request, backendName strin
 	utils.CopyHeaders(table.OriginResponse, crw.Header())
 	table.Core[OriginContentSize] = crw.Size()
 }
+
+// originTimings records the connection and first byte timings of the request to the origin server.
+// The hooks of the trace can be called from the goroutines of the transport, hence the lock.
+type originTimings struct {
+	mu          sync.Mutex
+	start       time.Time
+	getConn     time.Time
+	connect     time.Duration
+	connected   bool
+	firstByte   time.Duration
+	gotResponse bool
+}
+
+func (o *originTimings) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			o.mu.Lock()
+			defer o.mu.Unlock()
+			o.getConn = time.Now().UTC()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			o.mu.Lock()
+			defer o.mu.Unlock()
+			o.connected = true
+			if !info.Reused && !o.getConn.IsZero() {
+				o.connect = time.Now().UTC().Sub(o.getConn)
+			}
+		},
+		GotFirstResponseByte: func() {
+			o.mu.Lock()
+			defer o.mu.Unlock()
+			o.gotResponse = true
+			o.firstByte = time.Now().UTC().Sub(o.start)
+		},
+	}
+}
+
+func (o *originTimings) save(table *LogData) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.connected {
+		table.Core[OriginConnectDuration] = o.connect
+	}
+	if o.gotResponse {
+		table.Core[OriginTimeToFirstByte] = o.firstByte
+	}
+}
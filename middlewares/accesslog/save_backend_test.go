@@ -0,0 +1,54 @@
+package accesslog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveBackendOriginTimings(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	client := &http.Client{Transport: &http.Transport{}}
+
+	forward := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		outReq, err := http.NewRequest(http.MethodGet, backend.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := client.Do(outReq.WithContext(req.Context()))
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		rw.WriteHeader(resp.StatusCode)
+	})
+	handler := NewSaveBackend(forward, "backend")
+
+	// The first request opens a connection, the second one reuses it.
+	var tables []*LogData
+	for i := 0; i < 2; i++ {
+		table := &LogData{Core: CoreLogData{}}
+		req := httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(context.WithValue(req.Context(), DataTableKey, table)))
+		tables = append(tables, table)
+	}
+
+	require.Contains(t, tables[0].Core, OriginConnectDuration)
+	assert.True(t, tables[0].Core[OriginConnectDuration].(time.Duration) > 0)
+	assert.Equal(t, time.Duration(0), tables[1].Core[OriginConnectDuration])
+
+	for _, table := range tables {
+		require.Contains(t, table.Core, OriginTimeToFirstByte)
+		ttfb := table.Core[OriginTimeToFirstByte].(time.Duration)
+		assert.True(t, ttfb >= 10*time.Millisecond)
+		assert.True(t, ttfb <= table.Core[OriginDuration].(time.Duration))
+	}
+}
@@ -0,0 +1,25 @@
+package accesslog
+
+import (
+	"net/http"
+
+	"github.com/containous/traefik/types"
+)
+
+// SaveFrontendAccessLog sends the access log settings of the frontend which matched the request to the logger.
+type SaveFrontendAccessLog struct {
+	next   http.Handler
+	config *types.FrontendAccessLog
+}
+
+// NewSaveFrontendAccessLog creates a SaveFrontendAccessLog handler.
+func NewSaveFrontendAccessLog(next http.Handler, config *types.FrontendAccessLog) http.Handler {
+	return &SaveFrontendAccessLog{next, config}
+}
+
+func (sf *SaveFrontendAccessLog) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	table := GetLogDataTable(r)
+	table.FrontendAccessLog = sf.config
+
+	sf.next.ServeHTTP(rw, r)
+}
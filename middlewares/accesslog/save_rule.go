@@ -0,0 +1,23 @@
+package accesslog
+
+import (
+	"net/http"
+)
+
+// SaveRule sends the rule of the frontend which matched the request to the logger.
+type SaveRule struct {
+	next http.Handler
+	rule string
+}
+
+// NewSaveRule creates a SaveRule handler.
+func NewSaveRule(next http.Handler, rule string) http.Handler {
+	return &SaveRule{next, rule}
+}
+
+func (sr *SaveRule) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	table := GetLogDataTable(r)
+	table.Core[MatchedRule] = sr.rule
+
+	sr.next.ServeHTTP(rw, r)
+}
@@ -0,0 +1,547 @@
+package accesslog
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/containous/traefik/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SyslogProtocolUDP sends each access log line in a datagram
+	SyslogProtocolUDP = "udp"
+	// SyslogProtocolTCP sends the access log lines in a TCP stream, using the octet counting framing (RFC6587)
+	SyslogProtocolTCP = "tcp"
+	// SyslogProtocolTLS sends the access log lines in a TLS stream, using the octet counting framing (RFC5425)
+	SyslogProtocolTLS = "tls"
+
+	// DefaultSyslogFacility is the default facility of the syslog sink
+	DefaultSyslogFacility = "local0"
+	// DefaultSyslogAppName is the default application name of the syslog sink
+	DefaultSyslogAppName = "traefik"
+
+	// DefaultSinkQueueSize is the default number of access log lines waiting to be sent by a sink
+	DefaultSinkQueueSize = 1000
+	// DefaultHTTPSinkBatchSize is the default maximum number of access log lines sent in a request
+	DefaultHTTPSinkBatchSize = 100
+	// DefaultHTTPSinkFlushInterval is the default maximum time an access log line waits before being sent
+	DefaultHTTPSinkFlushInterval = time.Second
+	// DefaultHTTPSinkMaxRetries is the default number of retries of a failed request
+	DefaultHTTPSinkMaxRetries = 3
+	// DefaultHTTPSinkTimeout is the default timeout of the requests
+	DefaultHTTPSinkTimeout = 5 * time.Second
+
+	sinkDialTimeout   = 5 * time.Second
+	sinkWriteTimeout  = 5 * time.Second
+	sinkRedialBackoff = time.Second
+
+	httpSinkMinBackoff = 100 * time.Millisecond
+	httpSinkMaxBackoff = 5 * time.Second
+
+	// syslogSeverity is the severity of the access log lines: informational.
+	syslogSeverity = 6
+)
+
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// sink is an additional output of the access logs.
+// The lines are formatted when the hook is fired and sent asynchronously.
+type sink interface {
+	logrus.Hook
+	io.Closer
+}
+
+// newSinks creates the sinks of the configuration.
+// The lines of the syslog and TCP sinks use the given formatter, the HTTP sink always sends JSON lines.
+func newSinks(config *types.AccessLogSinks, formatter logrus.Formatter) ([]sink, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	var sinks []sink
+	closeAll := func() {
+		for _, s := range sinks {
+			_ = s.Close()
+		}
+	}
+
+	if config.Syslog != nil {
+		s, err := newSyslogSink(config.Syslog, formatter)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("error creating syslog sink: %v", err)
+		}
+		sinks = append(sinks, s)
+	}
+
+	if config.HTTP != nil {
+		s, err := newHTTPSink(config.HTTP)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("error creating HTTP sink: %v", err)
+		}
+		sinks = append(sinks, s)
+	}
+
+	if config.TCP != nil {
+		s, err := newTCPSink(config.TCP, formatter)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("error creating TCP sink: %v", err)
+		}
+		sinks = append(sinks, s)
+	}
+
+	return sinks, nil
+}
+
+// sinkQueue holds the formatted lines waiting to be sent by a sink.
+// Firing the hook never blocks the requests: the lines are dropped, and counted, when the queue is full.
+type sinkQueue struct {
+	formatter logrus.Formatter
+	queue     chan []byte
+	done      chan struct{}
+	closing   chan struct{}
+	dropped   uint64
+	mu        sync.RWMutex
+	closed    bool
+}
+
+func newSinkQueue(formatter logrus.Formatter, size int) *sinkQueue {
+	if size <= 0 {
+		size = DefaultSinkQueueSize
+	}
+	return &sinkQueue{
+		formatter: formatter,
+		queue:     make(chan []byte, size),
+		done:      make(chan struct{}),
+		closing:   make(chan struct{}),
+	}
+}
+
+// Levels returns the levels of the lines sent by the sink.
+func (q *sinkQueue) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire formats the entry and queues it.
+func (q *sinkQueue) Fire(entry *logrus.Entry) error {
+	line, err := q.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return nil
+	}
+
+	select {
+	case q.queue <- line:
+	default:
+		atomic.AddUint64(&q.dropped, 1)
+	}
+	return nil
+}
+
+// reportDropped logs the number of lines dropped since the last report because the queue was full.
+func (q *sinkQueue) reportDropped(name string) {
+	if dropped := atomic.SwapUint64(&q.dropped, 0); dropped > 0 {
+		logger.Warnf("Access log %s sink queue full, %d lines dropped", name, dropped)
+	}
+}
+
+// Close stops accepting lines, and waits for the queued ones to be sent.
+func (q *sinkQueue) Close() error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.closing)
+		close(q.queue)
+	}
+	q.mu.Unlock()
+
+	<-q.done
+	return nil
+}
+
+// connSink writes the lines to a connection, which is dialed again when a write fails.
+// The lines sent while the endpoint cannot be reached are dropped.
+type connSink struct {
+	*sinkQueue
+	name    string
+	dial    func() (net.Conn, error)
+	frame   func(line []byte) []byte
+	conn    net.Conn
+	retryAt time.Time
+	dropped int
+}
+
+func newConnSink(name string, queue *sinkQueue, dial func() (net.Conn, error), frame func([]byte) []byte) *connSink {
+	s := &connSink{
+		sinkQueue: queue,
+		name:      name,
+		dial:      dial,
+		frame:     frame,
+	}
+	go s.run()
+	return s
+}
+
+func (s *connSink) run() {
+	defer close(s.done)
+
+	for line := range s.queue {
+		s.write(s.frame(line))
+		s.reportDropped(s.name)
+	}
+
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	if s.dropped > 0 {
+		logger.Warnf("Access log %s sink closed, %d lines dropped", s.name, s.dropped)
+	}
+}
+
+func (s *connSink) write(msg []byte) {
+	// A broken connection is only noticed by the write following the failure, hence the second attempt.
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if time.Now().Before(s.retryAt) {
+				break
+			}
+
+			conn, err := s.dial()
+			if err != nil {
+				logger.Warnf("Unable to connect the access log %s sink, dropping lines for %s: %v", s.name, sinkRedialBackoff, err)
+				s.retryAt = time.Now().Add(sinkRedialBackoff)
+				break
+			}
+			if s.dropped > 0 {
+				logger.Warnf("Access log %s sink connected, %d lines dropped", s.name, s.dropped)
+				s.dropped = 0
+			}
+			s.conn = conn
+		}
+
+		if err := s.conn.SetWriteDeadline(time.Now().Add(sinkWriteTimeout)); err == nil {
+			if _, err = s.conn.Write(msg); err == nil {
+				return
+			}
+		}
+
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+
+	s.dropped++
+}
+
+func dialer(network, address string, tlsConfig *tls.Config) func() (net.Conn, error) {
+	d := &net.Dialer{Timeout: sinkDialTimeout}
+	if tlsConfig != nil {
+		return func() (net.Conn, error) {
+			return tls.DialWithDialer(d, network, address, tlsConfig)
+		}
+	}
+	return func() (net.Conn, error) {
+		return d.Dial(network, address)
+	}
+}
+
+// newSyslogSink creates a sink sending the lines to a syslog server, in the RFC5424 format.
+func newSyslogSink(config *types.AccessLogSyslog, formatter logrus.Formatter) (sink, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+
+	facilityName := config.Facility
+	if facilityName == "" {
+		facilityName = DefaultSyslogFacility
+	}
+	facility, ok := syslogFacilities[facilityName]
+	if !ok {
+		return nil, fmt.Errorf("unknown facility: %s", facilityName)
+	}
+
+	appName := config.AppName
+	if appName == "" {
+		appName = DefaultSyslogAppName
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	syslogFormatter := &syslogFormatter{
+		formatter: formatter,
+		priority:  facility*8 + syslogSeverity,
+		hostname:  hostname,
+		appName:   appName,
+		procID:    strconv.Itoa(os.Getpid()),
+	}
+	queue := newSinkQueue(syslogFormatter, config.QueueSize)
+
+	switch config.Protocol {
+	case "", SyslogProtocolUDP:
+		return newConnSink("syslog", queue, dialer("udp", config.Address, nil), unframed), nil
+	case SyslogProtocolTCP:
+		return newConnSink("syslog", queue, dialer("tcp", config.Address, nil), octetCounting), nil
+	case SyslogProtocolTLS:
+		tlsConfig := &tls.Config{}
+		if config.TLS != nil {
+			tlsConfig, err = config.TLS.CreateTLSConfig()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return newConnSink("syslog", queue, dialer("tcp", config.Address, tlsConfig), octetCounting), nil
+	default:
+		return nil, fmt.Errorf("unsupported protocol: %s", config.Protocol)
+	}
+}
+
+// syslogFormatter wraps the lines of a formatter in RFC5424 messages.
+type syslogFormatter struct {
+	formatter logrus.Formatter
+	priority  int
+	hostname  string
+	appName   string
+	procID    string
+}
+
+// Format formats the entry as a syslog message, without framing.
+func (f *syslogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	line, err := f.formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bytes.Buffer{}
+	// No message ID nor structured data: <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID - - MSG
+	fmt.Fprintf(b, "<%d>1 %s %s %s %s - - ", f.priority, entry.Time.Format("2006-01-02T15:04:05.000000Z07:00"), f.hostname, f.appName, f.procID)
+	b.Write(bytes.TrimRight(line, "\n"))
+	return b.Bytes(), nil
+}
+
+func unframed(msg []byte) []byte {
+	return msg
+}
+
+func octetCounting(msg []byte) []byte {
+	return append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+}
+
+func newLine(line []byte) []byte {
+	if bytes.HasSuffix(line, []byte("\n")) {
+		return line
+	}
+	return append(line, '\n')
+}
+
+// newTCPSink creates a sink sending the lines, separated by new lines, to a TCP endpoint.
+func newTCPSink(config *types.AccessLogTCP, formatter logrus.Formatter) (sink, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+
+	var tlsConfig *tls.Config
+	if config.TLS != nil {
+		var err error
+		tlsConfig, err = config.TLS.CreateTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newConnSink("TCP", newSinkQueue(formatter, config.QueueSize), dialer("tcp", config.Address, tlsConfig), newLine), nil
+}
+
+// httpSink posts batches of JSON lines (NDJSON) to an HTTP endpoint.
+// A failed request is retried with an exponential backoff, during which the queue fills up,
+// the retries are abandoned when the sink is closed.
+type httpSink struct {
+	*sinkQueue
+	client        *http.Client
+	url           string
+	headers       map[string]string
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+}
+
+func newHTTPSink(config *types.AccessLogHTTP) (sink, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   sinkDialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if config.TLS != nil {
+		tlsConfig, err := config.TLS.CreateTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	timeout := time.Duration(config.Timeout)
+	if timeout <= 0 {
+		timeout = DefaultHTTPSinkTimeout
+	}
+
+	s := &httpSink{
+		sinkQueue:     newSinkQueue(new(logrus.JSONFormatter), config.QueueSize),
+		client:        &http.Client{Transport: transport, Timeout: timeout},
+		url:           config.URL,
+		headers:       config.Headers,
+		batchSize:     config.BatchSize,
+		flushInterval: time.Duration(config.FlushInterval),
+		maxRetries:    config.MaxRetries,
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = DefaultHTTPSinkBatchSize
+	}
+	if s.flushInterval <= 0 {
+		s.flushInterval = DefaultHTTPSinkFlushInterval
+	}
+	if s.maxRetries < 0 {
+		s.maxRetries = 0
+	} else if s.maxRetries == 0 {
+		s.maxRetries = DefaultHTTPSinkMaxRetries
+	}
+
+	go s.run()
+	return s, nil
+}
+
+func (s *httpSink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := &bytes.Buffer{}
+	count := 0
+	flush := func() {
+		if count > 0 {
+			s.send(batch.Bytes(), count)
+			batch = &bytes.Buffer{}
+			count = 0
+		}
+		s.reportDropped("HTTP")
+	}
+
+	for {
+		select {
+		case line, ok := <-s.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch.Write(newLine(line))
+			count++
+			if count >= s.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (s *httpSink) send(body []byte, count int) {
+	backoff := httpSinkMinBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= s.maxRetries {
+			logger.Warnf("Unable to send access logs to %s, dropping %d lines: %v", s.url, count, err)
+			return
+		}
+
+		logger.Debugf("Unable to send access logs to %s, retrying in %s: %v", s.url, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-s.closing:
+			logger.Warnf("Unable to send access logs to %s, dropping %d lines on close: %v", s.url, count, err)
+			return
+		}
+		backoff *= 2
+		if backoff > httpSinkMaxBackoff {
+			backoff = httpSinkMaxBackoff
+		}
+	}
+}
+
+// post sends the batch, and tells whether a failed request can be retried.
+func (s *httpSink) post(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	for name, value := range s.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+}
@@ -0,0 +1,436 @@
+package accesslog
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg/parse"
+	"github.com/containous/traefik/types"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const syslogCLFPattern = `^<134>1 \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}\S+ \S+ traefik \d+ - - TestHost - TestUser \[[^]]+\] "POST testpath HTTP/0.0" 123 12 "testReferer" "testUserAgent" \d+ "testFrontend" "http://127.0.0.1/testBackend" \d+ms$`
+
+func TestSyslogSink(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		protocol string
+		listen   func(t *testing.T) (string, func() string)
+	}{
+		{
+			desc:     "udp",
+			protocol: SyslogProtocolUDP,
+			listen: func(t *testing.T) (string, func() string) {
+				conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+				require.NoError(t, err)
+
+				return conn.LocalAddr().String(), func() string {
+					defer conn.Close()
+
+					require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+					buf := make([]byte, 64*1024)
+					n, _, err := conn.ReadFrom(buf)
+					require.NoError(t, err)
+					return string(buf[:n])
+				}
+			},
+		},
+		{
+			desc:     "tcp",
+			protocol: SyslogProtocolTCP,
+			listen: func(t *testing.T) (string, func() string) {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				require.NoError(t, err)
+
+				return listener.Addr().String(), func() string {
+					defer listener.Close()
+
+					conn, err := listener.Accept()
+					require.NoError(t, err)
+					defer conn.Close()
+
+					require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+					data, err := ioutil.ReadAll(conn)
+					require.NoError(t, err)
+
+					// octet counting framing
+					frame := strings.SplitN(string(data), " ", 2)
+					require.Len(t, frame, 2)
+					assert.Equal(t, strconv.Itoa(len(frame[1])), frame[0])
+					return frame[1]
+				}
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			tmpDir := createTempDir(t, CommonFormat)
+			defer os.RemoveAll(tmpDir)
+
+			address, receive := test.listen(t)
+
+			config := &types.AccessLog{
+				FilePath: filepath.Join(tmpDir, logFileNameSuffix),
+				Format:   CommonFormat,
+				Sinks: &types.AccessLogSinks{
+					Syslog: &types.AccessLogSyslog{
+						Address:  address,
+						Protocol: test.protocol,
+					},
+				},
+			}
+			doLogging(t, config)
+
+			assert.Regexp(t, regexp.MustCompile(syslogCLFPattern), receive())
+		})
+	}
+}
+
+func TestTCPSink(t *testing.T) {
+	tmpDir := createTempDir(t, JSONFormat)
+	defer os.RemoveAll(tmpDir)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	config := &types.AccessLog{
+		FilePath: filepath.Join(tmpDir, logFileNameSuffix),
+		Format:   JSONFormat,
+		Sinks: &types.AccessLogSinks{
+			TCP: &types.AccessLogTCP{Address: listener.Addr().String()},
+		},
+	}
+	doLogging(t, config)
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	require.NoError(t, err)
+
+	jsonLine := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(line, &jsonLine))
+	assert.Equal(t, testFrontendName, jsonLine[FrontendName])
+	assert.Equal(t, testBackendName, jsonLine[BackendURL])
+}
+
+func TestHTTPSink(t *testing.T) {
+	tmpDir := createTempDir(t, CommonFormat)
+	defer os.RemoveAll(tmpDir)
+
+	var mu sync.Mutex
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/x-ndjson", req.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", req.Header.Get("Authorization"))
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		batches = append(batches, strings.Split(strings.TrimSuffix(string(body), "\n"), "\n"))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	config := &types.AccessLog{
+		FilePath: filepath.Join(tmpDir, logFileNameSuffix),
+		// The HTTP sink always sends JSON lines.
+		Format: CommonFormat,
+		Sinks: &types.AccessLogSinks{
+			HTTP: &types.AccessLogHTTP{
+				URL:           server.URL,
+				Headers:       types.KeyValues{"Authorization": "secret"},
+				BatchSize:     2,
+				FlushInterval: parse.Duration(time.Hour),
+			},
+		},
+	}
+
+	logHandler, err := NewLogHandler(config)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+		logHandler.ServeHTTP(httptest.NewRecorder(), req, func(rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(http.StatusOK)
+		})
+	}
+
+	// Closing the handler sends the last, incomplete, batch.
+	require.NoError(t, logHandler.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], 2)
+	assert.Len(t, batches[1], 1)
+
+	for _, batch := range batches {
+		for _, line := range batch {
+			jsonLine := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(line), &jsonLine))
+			assert.Equal(t, "/foo", jsonLine[RequestPath])
+		}
+	}
+}
+
+func TestHTTPSinkRetry(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		statusCodes      []int
+		maxRetries       int
+		expectedRequests int
+	}{
+		{
+			desc:             "retry on server error",
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:       1,
+			expectedRequests: 2,
+		},
+		{
+			desc:             "retry on too many requests",
+			statusCodes:      []int{http.StatusTooManyRequests, http.StatusOK},
+			maxRetries:       1,
+			expectedRequests: 2,
+		},
+		{
+			desc:             "give up after the max retries",
+			statusCodes:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			maxRetries:       1,
+			expectedRequests: 2,
+		},
+		{
+			desc:             "no retry on client error",
+			statusCodes:      []int{http.StatusBadRequest, http.StatusOK},
+			maxRetries:       3,
+			expectedRequests: 1,
+		},
+		{
+			desc:             "no retry when disabled",
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:       -1,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var mu sync.Mutex
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				rw.WriteHeader(test.statusCodes[requests])
+				requests++
+			}))
+			defer server.Close()
+
+			s, err := newHTTPSink(&types.AccessLogHTTP{
+				URL:        server.URL,
+				MaxRetries: test.maxRetries,
+			})
+			require.NoError(t, err)
+
+			s.(*httpSink).send([]byte("{}\n"), 1)
+			require.NoError(t, s.Close())
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, test.expectedRequests, requests)
+		})
+	}
+}
+
+func TestHTTPSinkCloseAbandonsRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	s, err := newHTTPSink(&types.AccessLogHTTP{
+		URL:           server.URL,
+		MaxRetries:    100,
+		FlushInterval: parse.Duration(time.Hour),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Fire(logrus.NewEntry(logrus.New())))
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		assert.NoError(t, s.Close())
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the sink is still retrying after being closed")
+	}
+}
+
+func TestSinkQueueFull(t *testing.T) {
+	// without a consumer, the queue fills up
+	q := newSinkQueue(new(logrus.JSONFormatter), 2)
+
+	fired := make(chan struct{})
+	go func() {
+		defer close(fired)
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, q.Fire(logrus.NewEntry(logrus.New())))
+		}
+	}()
+
+	select {
+	case <-fired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("firing the hook blocks on a full queue")
+	}
+
+	assert.Len(t, q.queue, 2)
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&q.dropped))
+
+	q.reportDropped("test")
+	assert.Equal(t, uint64(0), atomic.LoadUint64(&q.dropped))
+}
+
+func TestConnSinkReconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	s, err := newTCPSink(&types.AccessLogTCP{Address: listener.Addr().String()}, new(CommonLogFormatter))
+	require.NoError(t, err)
+	connSink := s.(*connSink)
+
+	// The first connection is closed by the endpoint right after the first line.
+	connSink.write([]byte("first\n"))
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", line)
+	require.NoError(t, conn.Close())
+
+	// Writing on a connection closed by the peer only fails once the peer has reset it.
+	var received string
+	for i := 0; i < 10 && received == ""; i++ {
+		connSink.write([]byte("next\n"))
+
+		require.NoError(t, listener.(*net.TCPListener).SetDeadline(time.Now().Add(100*time.Millisecond)))
+		conn, err = listener.Accept()
+		if err != nil {
+			continue
+		}
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		received, err = bufio.NewReader(conn).ReadString('\n')
+		require.NoError(t, err)
+		conn.Close()
+	}
+
+	assert.Equal(t, "next\n", received)
+	require.NoError(t, s.Close())
+}
+
+func TestNewSinks(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        *types.AccessLogSinks
+		expectedSinks int
+		expectedError string
+	}{
+		{
+			desc: "no sinks",
+		},
+		{
+			desc: "all sinks",
+			config: &types.AccessLogSinks{
+				Syslog: &types.AccessLogSyslog{Address: "127.0.0.1:514"},
+				HTTP:   &types.AccessLogHTTP{URL: "http://127.0.0.1:8080"},
+				TCP:    &types.AccessLogTCP{Address: "127.0.0.1:5000"},
+			},
+			expectedSinks: 3,
+		},
+		{
+			desc: "syslog without address",
+			config: &types.AccessLogSinks{
+				Syslog: &types.AccessLogSyslog{},
+			},
+			expectedError: "error creating syslog sink: address is required",
+		},
+		{
+			desc: "syslog with unknown facility",
+			config: &types.AccessLogSinks{
+				Syslog: &types.AccessLogSyslog{Address: "127.0.0.1:514", Facility: "local8"},
+			},
+			expectedError: "error creating syslog sink: unknown facility: local8",
+		},
+		{
+			desc: "syslog with unsupported protocol",
+			config: &types.AccessLogSinks{
+				Syslog: &types.AccessLogSyslog{Address: "127.0.0.1:514", Protocol: "unix"},
+			},
+			expectedError: "error creating syslog sink: unsupported protocol: unix",
+		},
+		{
+			desc: "http without url",
+			config: &types.AccessLogSinks{
+				HTTP: &types.AccessLogHTTP{},
+			},
+			expectedError: "error creating HTTP sink: url is required",
+		},
+		{
+			desc: "tcp without address",
+			config: &types.AccessLogSinks{
+				Syslog: &types.AccessLogSyslog{Address: "127.0.0.1:514"},
+				TCP:    &types.AccessLogTCP{},
+			},
+			expectedError: "error creating TCP sink: address is required",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			sinks, err := newSinks(test.config, new(CommonLogFormatter))
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Len(t, sinks, test.expectedSinks)
+			for _, s := range sinks {
+				require.NoError(t, s.Close())
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/urfave/negroni"
 )
 
+var logger = log.WithModule("middlewares/auth")
+
 // Authenticator is a middleware that provides HTTP basic and digest authentication
 type Authenticator struct {
 	handler negroni.Handler
@@ -82,10 +84,10 @@ func createAuthForwardHandler(authConfig *types.Auth) negroni.HandlerFunc {
 func createAuthDigestHandler(digestAuth *goauth.DigestAuth, authConfig *types.Auth) negroni.HandlerFunc {
 	return negroni.HandlerFunc(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 		if username, _ := digestAuth.CheckAuth(r); username == "" {
-			log.Debugf("Digest auth failed")
+			logger.FromContext(r.Context()).Debugf("Digest auth failed")
 			digestAuth.RequireAuth(w, r)
 		} else {
-			log.Debugf("Digest auth succeeded")
+			logger.FromContext(r.Context()).Debugf("Digest auth succeeded")
 
 			// set username in request context
 			r = accesslog.WithUserName(r, username)
@@ -94,7 +96,7 @@ func createAuthDigestHandler(digestAuth *goauth.DigestAuth, authConfig *types.Au
 				r.Header[authConfig.HeaderField] = []string{username}
 			}
 			if authConfig.Digest.RemoveHeader {
-				log.Debugf("Remove the Authorization header from the Digest auth")
+				logger.FromContext(r.Context()).Debugf("Remove the Authorization header from the Digest auth")
 				r.Header.Del(authorizationHeader)
 			}
 			next.ServeHTTP(w, r)
@@ -104,10 +106,10 @@ func createAuthDigestHandler(digestAuth *goauth.DigestAuth, authConfig *types.Au
 func createAuthBasicHandler(basicAuth *goauth.BasicAuth, authConfig *types.Auth) negroni.HandlerFunc {
 	return negroni.HandlerFunc(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 		if username := basicAuth.CheckAuth(r); username == "" {
-			log.Debugf("Basic auth failed")
+			logger.FromContext(r.Context()).Debugf("Basic auth failed")
 			basicAuth.RequireAuth(w, r)
 		} else {
-			log.Debugf("Basic auth succeeded")
+			logger.FromContext(r.Context()).Debugf("Basic auth succeeded")
 
 			// set username in request context
 			r = accesslog.WithUserName(r, username)
@@ -116,7 +118,7 @@ func createAuthBasicHandler(basicAuth *goauth.BasicAuth, authConfig *types.Auth)
 				r.Header[authConfig.HeaderField] = []string{username}
 			}
 			if authConfig.Basic.RemoveHeader {
-				log.Debugf("Remove the Authorization header from the Basic auth")
+				logger.FromContext(r.Context()).Debugf("Remove the Authorization header from the Basic auth")
 				r.Header.Del(authorizationHeader)
 			}
 			next.ServeHTTP(w, r)
@@ -145,7 +147,7 @@ func (a *Authenticator) secretBasic(user, realm string) string {
 	if secret, ok := a.users[user]; ok {
 		return secret
 	}
-	log.Debugf("User not found: %s", user)
+	logger.Debugf("User not found: %s", user)
 	return ""
 }
 
@@ -153,7 +155,7 @@ func (a *Authenticator) secretDigest(user, realm string) string {
 	if secret, ok := a.users[user+":"+realm]; ok {
 		return secret
 	}
-	log.Debugf("User not found: %s:%s", user, realm)
+	logger.Debugf("User not found: %s:%s", user, realm)
 	return ""
 }
 
@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares/tracing"
 	"github.com/containous/traefik/types"
 	"github.com/vulcand/oxy/forward"
@@ -70,7 +69,7 @@ func Forward(config *types.Forward, w http.ResponseWriter, r *http.Request, next
 	// Pass the forward response's body and selected headers if it
 	// didn't return a response within the range of [200, 300).
 	if forwardResponse.StatusCode < http.StatusOK || forwardResponse.StatusCode >= http.StatusMultipleChoices {
-		log.Debugf("Remote error %s. StatusCode: %d", config.Address, forwardResponse.StatusCode)
+		logger.Debugf("Remote error %s. StatusCode: %d", config.Address, forwardResponse.StatusCode)
 
 		utils.CopyHeaders(w.Header(), forwardResponse.Header)
 		utils.RemoveHeaders(w.Header(), forward.HopHeaders...)
@@ -9,6 +9,8 @@ import (
 	"github.com/containous/traefik/log"
 )
 
+var logger = log.WithModule("middlewares")
+
 // Compress is a middleware that allows redirection
 type Compress struct{}
 
@@ -27,7 +29,7 @@ func gzipHandler(h http.Handler) http.Handler {
 		gziphandler.CompressionLevel(gzip.DefaultCompression),
 		gziphandler.MinSize(gziphandler.DefaultMinSize))
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 	return wrapper(h)
 }
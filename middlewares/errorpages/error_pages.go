@@ -18,6 +18,8 @@ import (
 	"github.com/vulcand/oxy/utils"
 )
 
+var logger = log.WithModule("middlewares/errorpages")
+
 // Compile time validation that the response recorder implements http interfaces correctly.
 var (
 	_ middlewares.Stateful = &responseRecorderWithCloseNotify{}
@@ -72,7 +74,7 @@ func (h *Handler) PostLoad(backendHandler http.Handler) error {
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
 	if h.backendHandler == nil {
-		log.Error("Error pages: no backend handler.")
+		logger.Error("Error pages: no backend handler.")
 		next.ServeHTTP(w, req)
 		return
 	}
@@ -87,7 +89,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.
 	code := catcher.getCode()
 	for _, block := range h.httpCodeRanges {
 		if code >= block[0] && code <= block[1] {
-			log.Errorf("Caught HTTP Status Code %d, returning error page", code)
+			logger.FromContext(req.Context()).Errorf("Caught HTTP Status Code %d, returning error page", code)
 
 			var query string
 			if len(h.backendQuery) > 0 {
@@ -97,7 +99,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.
 
 			pageReq, err := newRequest(h.backendURL + query)
 			if err != nil {
-				log.Error(err)
+				logger.FromContext(req.Context()).Error(err)
 				w.WriteHeader(code)
 				fmt.Fprint(w, http.StatusText(code))
 				return
@@ -351,7 +353,7 @@ func (r *responseRecorderWithoutCloseNotify) Flush() {
 
 	_, err := r.responseWriter.Write(r.Body.Bytes())
 	if err != nil {
-		log.Errorf("Error writing response in responseRecorder: %v", err)
+		logger.Errorf("Error writing response in responseRecorder: %v", err)
 		r.err = err
 	}
 	r.Body.Reset()
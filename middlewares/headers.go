@@ -12,6 +12,8 @@ import (
 type HeaderOptions struct {
 	// If Custom request headers are set, these will be added to the request
 	CustomRequestHeaders map[string]string
+	// If Append request headers are set, their values will be appended to the values of the request
+	AppendRequestHeaders map[string]string
 	// If Custom response headers are set, these will be added to the ResponseWriter
 	CustomResponseHeaders map[string]string
 }
@@ -32,6 +34,7 @@ func NewHeaderFromStruct(headers *types.Headers) *HeaderStruct {
 	return &HeaderStruct{
 		opt: HeaderOptions{
 			CustomRequestHeaders:  headers.CustomRequestHeaders,
+			AppendRequestHeaders:  headers.AppendRequestHeaders,
 			CustomResponseHeaders: headers.CustomResponseHeaders,
 		},
 	}
@@ -45,7 +48,7 @@ func (s *HeaderStruct) ServeHTTP(w http.ResponseWriter, r *http.Request, next ht
 	}
 }
 
-// ModifyRequestHeaders set or delete request headers
+// ModifyRequestHeaders set, append or delete request headers
 func (s *HeaderStruct) ModifyRequestHeaders(r *http.Request) {
 	// Loop through Custom request headers
 	for header, value := range s.opt.CustomRequestHeaders {
@@ -55,6 +58,10 @@ func (s *HeaderStruct) ModifyRequestHeaders(r *http.Request) {
 			r.Header.Set(header, value)
 		}
 	}
+
+	for header, value := range s.opt.AppendRequestHeaders {
+		r.Header.Add(header, value)
+	}
 }
 
 // ModifyResponseHeaders set or delete response headers
@@ -87,6 +87,23 @@ func TestCustomRequestHeader(t *testing.T) {
 	assert.Equal(t, "test_request", req.Header.Get("X-Custom-Request-Header"), "Did not get expected header")
 }
 
+func TestAppendRequestHeader(t *testing.T) {
+	header := newHeader(HeaderOptions{
+		AppendRequestHeaders: map[string]string{
+			"X-Custom-Request-Header": "test_append",
+		},
+	})
+
+	res := httptest.NewRecorder()
+	req := testhelpers.MustNewRequest(http.MethodGet, "/foo", nil)
+	req.Header.Set("X-Custom-Request-Header", "test_request")
+
+	header.ServeHTTP(res, req, nil)
+
+	assert.Equal(t, http.StatusOK, res.Code, "Status not OK")
+	assert.Equal(t, []string{"test_request", "test_append"}, req.Header["X-Custom-Request-Header"], "Did not get expected header")
+}
+
 func TestCustomRequestHeaderEmptyValue(t *testing.T) {
 	header := newHeader(HeaderOptions{
 		CustomRequestHeaders: map[string]string{
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares/tracing"
 	"github.com/containous/traefik/whitelist"
 	"github.com/pkg/errors"
@@ -32,7 +31,7 @@ func NewIPWhiteLister(whiteList []string, useXForwardedFor bool) (*IPWhiteLister
 	whiteLister.whiteLister = ip
 
 	whiteLister.handler = negroni.HandlerFunc(whiteLister.handle)
-	log.Debugf("configured IP white list: %s", whiteList)
+	logger.Debugf("configured IP white list: %s", whiteList)
 
 	return &whiteLister, nil
 }
@@ -59,6 +58,6 @@ func reject(w http.ResponseWriter) {
 	w.WriteHeader(statusCode)
 	_, err := w.Write([]byte(http.StatusText(statusCode)))
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 }
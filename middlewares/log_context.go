@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/containous/traefik/log"
+	"github.com/sirupsen/logrus"
+)
+
+// RequestIDHeader is the header holding the ID of the request, when set by the client or a proxy in front of Traefik.
+const RequestIDHeader = "X-Request-Id"
+
+// EntryPointLogContext is a middleware adding the entry point and the request ID to the logger of the request context.
+type EntryPointLogContext struct {
+	entryPointName string
+}
+
+// NewEntryPointLogContext creates an EntryPointLogContext middleware.
+func NewEntryPointLogContext(entryPointName string) *EntryPointLogContext {
+	return &EntryPointLogContext{entryPointName: entryPointName}
+}
+
+func (e *EntryPointLogContext) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	fields := logrus.Fields{log.EntryPointName: e.entryPointName}
+	if requestID := r.Header.Get(RequestIDHeader); requestID != "" {
+		fields[log.RequestID] = requestID
+	}
+
+	next(rw, r.WithContext(log.With(r.Context(), fields)))
+}
+
+// NewFrontendLogContext returns a handler adding the frontend and the backend to the logger of the request context.
+func NewFrontendLogContext(next http.Handler, frontendName, backendName string) http.Handler {
+	fields := logrus.Fields{
+		log.FrontendName: frontendName,
+		log.BackendName:  backendName,
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(rw, r.WithContext(log.With(r.Context(), fields)))
+	})
+}
@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containous/traefik/log"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogContext(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		requestID string
+		expected  logrus.Fields
+	}{
+		{
+			desc: "without request ID",
+			expected: logrus.Fields{
+				log.EntryPointName: "http",
+				log.FrontendName:   "frontend1",
+				log.BackendName:    "backend1",
+			},
+		},
+		{
+			desc:      "with request ID",
+			requestID: "42",
+			expected: logrus.Fields{
+				log.EntryPointName: "http",
+				log.FrontendName:   "frontend1",
+				log.BackendName:    "backend1",
+				log.RequestID:      "42",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var fields logrus.Fields
+			handler := NewFrontendLogContext(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				fields = log.FromContext(req.Context()).Data
+			}), "frontend1", "backend1")
+
+			req := httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil)
+			if test.requestID != "" {
+				req.Header.Set("X-Request-Id", test.requestID)
+			}
+
+			NewEntryPointLogContext("http").ServeHTTP(httptest.NewRecorder(), req, handler.ServeHTTP)
+
+			assert.Equal(t, test.expected, fields)
+		})
+	}
+}
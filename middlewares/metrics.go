@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -8,7 +9,6 @@ import (
 	"time"
 	"unicode/utf8"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/metrics"
 	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/urfave/negroni"
@@ -26,6 +26,8 @@ func NewEntryPointMetricsMiddleware(registry metrics.Registry, entryPointName st
 		reqsCounter:          registry.EntrypointReqsCounter(),
 		reqDurationHistogram: registry.EntrypointReqDurationHistogram(),
 		openConnsGauge:       registry.EntrypointOpenConnsGauge(),
+		reqSizeHistogram:     registry.EntrypointReqSizeHistogram(),
+		respSizeHistogram:    registry.EntrypointRespSizeHistogram(),
 		baseLabels:           []string{"entrypoint", entryPointName},
 	}
 }
@@ -36,10 +38,21 @@ func NewBackendMetricsMiddleware(registry metrics.Registry, backendName string)
 		reqsCounter:          registry.BackendReqsCounter(),
 		reqDurationHistogram: registry.BackendReqDurationHistogram(),
 		openConnsGauge:       registry.BackendOpenConnsGauge(),
+		reqSizeHistogram:     registry.BackendReqSizeHistogram(),
+		respSizeHistogram:    registry.BackendRespSizeHistogram(),
 		baseLabels:           []string{"backend", backendName},
 	}
 }
 
+// NewFrontendMetricsMiddleware creates a new metrics middleware for a Frontend.
+func NewFrontendMetricsMiddleware(registry metrics.Registry, frontendName string) negroni.Handler {
+	return &metricsMiddleware{
+		reqsCounter:          registry.FrontendReqsCounter(),
+		reqDurationHistogram: registry.FrontendReqDurationHistogram(),
+		baseLabels:           []string{"frontend", frontendName},
+	}
+}
+
 type metricsMiddleware struct {
 	// Important: Since this int64 field is using sync/atomic, it has to be at the top of the struct due to a bug on 32-bit platform
 	// See: https://golang.org/pkg/sync/atomic/ for more information
@@ -47,6 +60,8 @@ type metricsMiddleware struct {
 	reqsCounter          gokitmetrics.Counter
 	reqDurationHistogram gokitmetrics.Histogram
 	openConnsGauge       gokitmetrics.Gauge
+	reqSizeHistogram     gokitmetrics.Histogram
+	respSizeHistogram    gokitmetrics.Histogram
 	baseLabels           []string
 }
 
@@ -54,15 +69,91 @@ func (m *metricsMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, n
 	labels := []string{"method", getMethod(r), "protocol", getRequestProtocol(r)}
 	labels = append(labels, m.baseLabels...)
 
-	openConns := atomic.AddInt64(&m.openConns, 1)
-	m.openConnsGauge.With(labels...).Set(float64(openConns))
-	defer func(labelValues []string) {
-		openConns := atomic.AddInt64(&m.openConns, -1)
-		m.openConnsGauge.With(labelValues...).Set(float64(openConns))
-	}(labels)
+	if m.openConnsGauge != nil {
+		openConns := atomic.AddInt64(&m.openConns, 1)
+		m.openConnsGauge.With(labels...).Set(float64(openConns))
+		defer func(labelValues []string) {
+			openConns := atomic.AddInt64(&m.openConns, -1)
+			m.openConnsGauge.With(labelValues...).Set(float64(openConns))
+		}(labels)
+	}
+
+	var body *requestSizeReader
+	if m.reqSizeHistogram != nil && r.Body != nil && r.Body != http.NoBody {
+		body = &requestSizeReader{ReadCloser: r.Body}
+		r.Body = body
+	}
+
+	start := time.Now()
+	recorder := &responseRecorder{ResponseWriter: rw, statusCode: http.StatusOK}
+	next(recorder, r)
+
+	labels = append(labels, "code", strconv.Itoa(recorder.statusCode))
+	m.reqsCounter.With(labels...).Add(1)
+	m.reqDurationHistogram.With(labels...).Observe(time.Since(start).Seconds())
+
+	if m.reqSizeHistogram != nil {
+		m.reqSizeHistogram.With(labels...).Observe(float64(body.Size()))
+	}
+	if m.respSizeHistogram != nil {
+		m.respSizeHistogram.With(labels...).Observe(float64(recorder.size))
+	}
+}
+
+// requestSizeReader counts the bytes read from the request body.
+type requestSizeReader struct {
+	io.ReadCloser
+	size int64
+}
+
+func (r *requestSizeReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	atomic.AddInt64(&r.size, int64(n))
+	return n, err
+}
+
+// Size returns the number of bytes read so far, a nil reader has read nothing.
+func (r *requestSizeReader) Size() int64 {
+	if r == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&r.size)
+}
+
+// NewServerMetricsMiddleware creates a new metrics middleware for the servers of a Backend.
+// It must wrap the forwarder, once the load-balancer has set the server URL on the request.
+func NewServerMetricsMiddleware(registry serverMetrics, backendName string, limiter *metrics.ServerSeriesLimiter) negroni.Handler {
+	return &serverMetricsMiddleware{
+		reqsCounter:          registry.ServerReqsCounter(),
+		reqDurationHistogram: registry.ServerReqDurationHistogram(),
+		backendName:          backendName,
+		limiter:              limiter,
+	}
+}
+
+type serverMetrics interface {
+	ServerReqsCounter() gokitmetrics.Counter
+	ServerReqDurationHistogram() gokitmetrics.Histogram
+}
+
+type serverMetricsMiddleware struct {
+	reqsCounter          gokitmetrics.Counter
+	reqDurationHistogram gokitmetrics.Histogram
+	backendName          string
+	limiter              *metrics.ServerSeriesLimiter
+}
+
+func (m *serverMetricsMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	serverURL := metrics.ServerLabel(r.URL.String())
+	if m.limiter != nil && !m.limiter.Allow(m.backendName, serverURL) {
+		next(rw, r)
+		return
+	}
+
+	labels := []string{"method", getMethod(r), "protocol", getRequestProtocol(r), "backend", m.backendName, "url", serverURL}
 
 	start := time.Now()
-	recorder := &responseRecorder{rw, http.StatusOK}
+	recorder := &responseRecorder{ResponseWriter: rw, statusCode: http.StatusOK}
 	next(recorder, r)
 
 	labels = append(labels, "code", strconv.Itoa(recorder.statusCode))
@@ -103,7 +194,7 @@ func containsHeader(req *http.Request, name, value string) bool {
 
 func getMethod(r *http.Request) string {
 	if !utf8.ValidString(r.Method) {
-		log.Warnf("Invalid HTTP method encoding: %s", r.Method)
+		logger.Warnf("Invalid HTTP method encoding: %s", r.Method)
 		return "NON_UTF8_HTTP_METHOD"
 	}
 	return r.Method
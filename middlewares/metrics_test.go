@@ -1,13 +1,18 @@
 package middlewares
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/containous/traefik/metrics"
 	"github.com/containous/traefik/testhelpers"
-	"github.com/go-kit/kit/metrics"
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMetricsRetryListener(t *testing.T) {
@@ -37,6 +42,87 @@ func newCollectingRetryMetrics() *collectingRetryMetrics {
 	return &collectingRetryMetrics{retriesCounter: &testhelpers.CollectingCounter{}}
 }
 
-func (metrics *collectingRetryMetrics) BackendRetriesCounter() metrics.Counter {
+func (metrics *collectingRetryMetrics) BackendRetriesCounter() gokitmetrics.Counter {
 	return metrics.retriesCounter
 }
+
+func TestMetricsMiddlewareSizes(t *testing.T) {
+	reqSizes := &testhelpers.CollectingHistogram{}
+	respSizes := &testhelpers.CollectingHistogram{}
+	middleware := &metricsMiddleware{
+		reqsCounter:          &testhelpers.CollectingCounter{},
+		reqDurationHistogram: &testhelpers.CollectingHistogram{},
+		reqSizeHistogram:     reqSizes,
+		respSizeHistogram:    respSizes,
+		baseLabels:           []string{"entrypoint", "http"},
+	}
+
+	next := func(rw http.ResponseWriter, req *http.Request) {
+		_, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		rw.WriteHeader(http.StatusCreated)
+		_, err = rw.Write([]byte("created"))
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "http://foo/", strings.NewReader("request body"))
+	middleware.ServeHTTP(httptest.NewRecorder(), req, next)
+
+	expectedLabels := []string{"method", http.MethodPost, "protocol", "http", "entrypoint", "http", "code", "201"}
+	assert.Equal(t, []float64{12}, reqSizes.Observations)
+	assert.Equal(t, expectedLabels, reqSizes.LastLabelValues)
+	assert.Equal(t, []float64{7}, respSizes.Observations)
+	assert.Equal(t, expectedLabels, respSizes.LastLabelValues)
+
+	// a request without body
+	req = httptest.NewRequest(http.MethodGet, "http://foo/", nil)
+	middleware.ServeHTTP(httptest.NewRecorder(), req, next)
+
+	assert.Equal(t, []float64{12, 0}, reqSizes.Observations)
+	assert.Equal(t, []float64{7, 7}, respSizes.Observations)
+}
+
+func TestServerMetricsMiddleware(t *testing.T) {
+	serverMetrics := &collectingServerMetrics{
+		reqsCounter:          &testhelpers.CollectingCounter{},
+		reqDurationHistogram: &testhelpers.CollectingHistogram{},
+	}
+	middleware := NewServerMetricsMiddleware(serverMetrics, "backend1", metrics.NewServerSeriesLimiter(1))
+
+	next := func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}
+
+	// the load-balancer has rewritten the request URL with the server one
+	req := httptest.NewRequest(http.MethodGet, "http://10.0.0.1:80/foo", nil)
+	middleware.ServeHTTP(httptest.NewRecorder(), req, next)
+
+	assert.Equal(t, float64(1), serverMetrics.reqsCounter.CounterValue)
+	assert.Len(t, serverMetrics.reqDurationHistogram.Observations, 1)
+	expectedLabels := []string{"method", http.MethodGet, "protocol", "http", "backend", "backend1", "url", "http://10.0.0.1:80", "code", "404"}
+	assert.Equal(t, expectedLabels, serverMetrics.reqsCounter.LastLabelValues)
+	assert.Equal(t, expectedLabels, serverMetrics.reqDurationHistogram.LastLabelValues)
+
+	// the series limit is reached
+	req = httptest.NewRequest(http.MethodGet, "http://10.0.0.2:80/foo", nil)
+	recorder := httptest.NewRecorder()
+	middleware.ServeHTTP(recorder, req, next)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, float64(1), serverMetrics.reqsCounter.CounterValue)
+	assert.Len(t, serverMetrics.reqDurationHistogram.Observations, 1)
+}
+
+type collectingServerMetrics struct {
+	reqsCounter          *testhelpers.CollectingCounter
+	reqDurationHistogram *testhelpers.CollectingHistogram
+}
+
+func (m *collectingServerMetrics) ServerReqsCounter() gokitmetrics.Counter {
+	return m.reqsCounter
+}
+
+func (m *collectingServerMetrics) ServerReqDurationHistogram() gokitmetrics.Histogram {
+	return m.reqDurationHistogram
+}
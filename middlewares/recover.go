@@ -4,7 +4,6 @@ import (
 	"net/http"
 	"runtime"
 
-	"github.com/containous/traefik/log"
 	"github.com/urfave/negroni"
 )
 
@@ -29,16 +28,16 @@ func NegroniRecoverHandler() negroni.Handler {
 func recoverFunc(w http.ResponseWriter, r *http.Request) {
 	if err := recover(); err != nil {
 		if !shouldLogPanic(err) {
-			log.Debugf("Request has been aborted [%s - %s]: %v", r.RemoteAddr, r.URL, err)
+			logger.FromContext(r.Context()).Debugf("Request has been aborted [%s - %s]: %v", r.RemoteAddr, r.URL, err)
 			return
 		}
 
-		log.Errorf("Recovered from panic in HTTP handler [%s - %s]: %+v", r.RemoteAddr, r.URL, err)
+		logger.FromContext(r.Context()).Errorf("Recovered from panic in HTTP handler [%s - %s]: %+v", r.RemoteAddr, r.URL, err)
 
 		const size = 64 << 10
 		buf := make([]byte, size)
 		buf = buf[:runtime.Stack(buf, false)]
-		log.Errorf("Stack: %s", buf)
+		logger.FromContext(r.Context()).Errorf("Stack: %s", buf)
 
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 	}
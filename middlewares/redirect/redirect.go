@@ -10,8 +10,11 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/containous/mux"
 	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/rules"
+	"github.com/containous/traefik/types"
 	"github.com/urfave/negroni"
 	"github.com/vulcand/oxy/utils"
 )
@@ -53,6 +56,45 @@ func NewRegexHandler(exp string, replacement string, permanent bool) (negroni.Ha
 	}, nil
 }
 
+// NewExemptedHandler wraps a redirection handler to skip the redirection of the requests matching one of the exemptions.
+// An exemption is a path prefix when it starts with a slash (e.g. /ping), a frontend rule otherwise (e.g. Host:lb.local;Path:/health).
+func NewExemptedHandler(redirection negroni.Handler, exemptions []string) (negroni.Handler, error) {
+	if len(exemptions) == 0 {
+		return redirection, nil
+	}
+
+	router := mux.NewRouter()
+	router.SkipClean(true)
+
+	for _, exemption := range exemptions {
+		if strings.HasPrefix(exemption, "/") {
+			router.NewRoute().PathPrefix(exemption)
+			continue
+		}
+
+		rls := &rules.Rules{Route: &types.ServerRoute{Route: router.NewRoute()}}
+		if _, err := rls.Parse(exemption); err != nil {
+			return nil, fmt.Errorf("invalid redirect exemption %q: %v", exemption, err)
+		}
+	}
+
+	return &exemptedHandler{redirection: redirection, exemptions: router}, nil
+}
+
+type exemptedHandler struct {
+	redirection negroni.Handler
+	exemptions  *mux.Router
+}
+
+func (h *exemptedHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	if h.exemptions.Match(req, &mux.RouteMatch{}) {
+		next.ServeHTTP(rw, req)
+		return
+	}
+
+	h.redirection.ServeHTTP(rw, req, next)
+}
+
 type handler struct {
 	regexp      *regexp.Regexp
 	replacement string
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/testhelpers"
 	"github.com/containous/traefik/tls"
 	"github.com/stretchr/testify/assert"
@@ -203,3 +204,79 @@ func TestNewRegexHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestNewExemptedHandler(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		exemptions     []string
+		url            string
+		expectedStatus int
+		errorExpected  bool
+	}{
+		{
+			desc:           "no exemption",
+			url:            "http://foo:80/ping",
+			expectedStatus: http.StatusFound,
+		},
+		{
+			desc:           "path prefix exemption",
+			exemptions:     []string{"/ping"},
+			url:            "http://foo:80/ping",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "path prefix exemption not matching",
+			exemptions:     []string{"/ping"},
+			url:            "http://foo:80/api",
+			expectedStatus: http.StatusFound,
+		},
+		{
+			desc:           "rule exemption",
+			exemptions:     []string{"/ping", "Host:lb.local;Path:/healthz"},
+			url:            "http://lb.local:80/healthz",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "rule exemption not matching the host",
+			exemptions:     []string{"Host:lb.local;Path:/healthz"},
+			url:            "http://foo:80/healthz",
+			expectedStatus: http.StatusFound,
+		},
+		{
+			desc:          "invalid rule exemption",
+			exemptions:    []string{"Foo:bar"},
+			errorExpected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			redirection, err := NewEntryPointHandler(&configuration.EntryPoint{Address: ":443", TLS: &tls.TLS{}}, false)
+			require.NoError(t, err)
+
+			handler, err := NewExemptedHandler(redirection, test.exemptions)
+			if test.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			r := testhelpers.MustNewRequest(http.MethodGet, test.url, nil)
+			next := func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			}
+
+			// the Host rules rely on the canonized host
+			requestHost := &middlewares.RequestHost{}
+			requestHost.ServeHTTP(recorder, r, func(rw http.ResponseWriter, req *http.Request) {
+				handler.ServeHTTP(rw, req, next)
+			})
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+		})
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
-
-	"github.com/containous/traefik/log"
 )
 
 // ReplacePathRegex is a middleware used to replace the path of a URL request with a regular expression
@@ -20,7 +18,7 @@ type ReplacePathRegex struct {
 func NewReplacePathRegexHandler(regex string, replacement string, handler http.Handler) http.Handler {
 	exp, err := regexp.Compile(strings.TrimSpace(regex))
 	if err != nil {
-		log.Errorf("Error compiling regular expression %s: %s", regex, err)
+		logger.Errorf("Error compiling regular expression %s: %s", regex, err)
 	}
 	return &ReplacePathRegex{
 		Regexp:      exp,
@@ -7,8 +7,6 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptrace"
-
-	"github.com/containous/traefik/log"
 )
 
 // Compile time validation that the response writer implements http interfaces correctly.
@@ -64,7 +62,7 @@ func (retry *Retry) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		}
 
 		attempts++
-		log.Debugf("New attempt %d for request: %v", attempts, r.URL)
+		logger.FromContext(r.Context()).Debugf("New attempt %d for request: %v", attempts, r.URL)
 		retry.listener.Retried(r, attempts)
 	}
 }
@@ -2,10 +2,13 @@ package middlewares
 
 import (
 	"bufio"
+	"context"
 	"net"
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/containous/traefik/types"
 )
 
 var (
@@ -15,18 +18,25 @@ var (
 // StatsRecorder is an optional middleware that records more details statistics
 // about requests and how they are processed. This currently consists of recent
 // requests that have caused errors (4xx and 5xx status codes), making it easy
-// to pinpoint problems.
+// to pinpoint problems, and, when enabled, the recent requests of each frontend
+// and the requests in flight.
 type StatsRecorder struct {
 	mutex           sync.RWMutex
 	numRecentErrors int
 	recentErrors    []*statsError
+	requests        *requestsInspector
 }
 
-// NewStatsRecorder returns a new StatsRecorder
-func NewStatsRecorder(numRecentErrors int) *StatsRecorder {
-	return &StatsRecorder{
-		numRecentErrors: numRecentErrors,
+// NewStatsRecorder returns a new StatsRecorder.
+// The headers of the inspected requests are kept, dropped or redacted following the access log fields.
+func NewStatsRecorder(config *types.Statistics, fields *types.AccessLogFields) *StatsRecorder {
+	recorder := &StatsRecorder{
+		numRecentErrors: config.RecentErrors,
+	}
+	if config.RecentRequests > 0 {
+		recorder.requests = newRequestsInspector(config.RecentRequests, fields)
 	}
+	return recorder
 }
 
 // Stats includes all of the stats gathered by the recorder.
@@ -49,6 +59,14 @@ type statsError struct {
 type responseRecorder struct {
 	http.ResponseWriter
 	statusCode int
+	size       int64
+}
+
+// Write counts the bytes of the response body.
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.size += int64(n)
+	return n, err
 }
 
 // WriteHeader captures the status code for later retrieval.
@@ -78,7 +96,12 @@ func (r *responseRecorder) Flush() {
 // is processed. If the response is 4xx or 5xx, add it to the list of 10 most
 // recent errors.
 func (s *StatsRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-	recorder := &responseRecorder{w, http.StatusOK}
+	recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+	if s.requests != nil {
+		inspected := s.requests.start(r)
+		defer func() { s.requests.end(inspected, recorder.statusCode, recorder.size) }()
+		r = r.WithContext(context.WithValue(r.Context(), inspectedRequestKey, inspected))
+	}
 	next(recorder, r)
 	if recorder.statusCode >= http.StatusBadRequest {
 		s.mutex.Lock()
@@ -0,0 +1,248 @@
+package middlewares
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/types"
+)
+
+const (
+	// inspectedRequestKey is the key within the request context used to
+	// store the inspected request
+	inspectedRequestKey key = "InspectedRequest"
+
+	// requestsSubscriberBufferSize is the number of requests buffered for a subscriber,
+	// the requests are dropped for the slow subscribers.
+	requestsSubscriberBufferSize = 100
+)
+
+// credentialHeaders are always redacted in the inspected requests, whatever the access logs headers rules say,
+// as the inspection API exposes them to anyone who can reach the API.
+var credentialHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+}
+
+// InspectedRequest is a request in flight, or recently served, recorded for the inspection API.
+type InspectedRequest struct {
+	ID         uint64            `json:"id"`
+	Frontend   string            `json:"frontend,omitempty"`
+	Backend    string            `json:"backend,omitempty"`
+	Method     string            `json:"method"`
+	Host       string            `json:"host"`
+	Path       string            `json:"path"`
+	RemoteAddr string            `json:"remote_addr"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Start      time.Time         `json:"start"`
+	Age        string            `json:"age,omitempty"`
+	StatusCode int               `json:"status_code,omitempty"`
+	Size       int64             `json:"size,omitempty"`
+	Duration   string            `json:"duration,omitempty"`
+}
+
+// requestsInspector keeps the requests in flight and a ring buffer of the recent requests of each frontend.
+type requestsInspector struct {
+	mu                sync.Mutex
+	fields            *types.AccessLogFields
+	numRecentRequests int
+	lastID            uint64
+	inFlight          map[uint64]*InspectedRequest
+	recent            map[string]*requestRing
+	subscribers       map[chan InspectedRequest]struct{}
+}
+
+func newRequestsInspector(numRecentRequests int, fields *types.AccessLogFields) *requestsInspector {
+	return &requestsInspector{
+		fields:            fields,
+		numRecentRequests: numRecentRequests,
+		inFlight:          make(map[uint64]*InspectedRequest),
+		recent:            make(map[string]*requestRing),
+		subscribers:       make(map[chan InspectedRequest]struct{}),
+	}
+}
+
+func (i *requestsInspector) start(r *http.Request) *InspectedRequest {
+	// Without access log fields, the headers are not recorded.
+	headers := make(map[string]string)
+	if i.fields != nil {
+		for name := range r.Header {
+			mode := i.fields.KeepHeader(name)
+			if _, ok := credentialHeaders[http.CanonicalHeaderKey(name)]; ok && mode == types.AccessLogKeep {
+				mode = types.AccessLogRedact
+			}
+
+			switch mode {
+			case types.AccessLogKeep:
+				headers[name] = r.Header.Get(name)
+			case types.AccessLogRedact:
+				headers[name] = "REDACTED"
+			}
+		}
+	}
+
+	inspected := &InspectedRequest{
+		Method:     r.Method,
+		Host:       r.Host,
+		Path:       r.URL.Path,
+		RemoteAddr: r.RemoteAddr,
+		Headers:    headers,
+		Start:      time.Now(),
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.lastID++
+	inspected.ID = i.lastID
+	i.inFlight[inspected.ID] = inspected
+
+	return inspected
+}
+
+func (i *requestsInspector) setFrontend(inspected *InspectedRequest, frontendName, backendName string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	inspected.Frontend = frontendName
+	inspected.Backend = backendName
+}
+
+func (i *requestsInspector) end(inspected *InspectedRequest, statusCode int, size int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.inFlight, inspected.ID)
+
+	inspected.StatusCode = statusCode
+	inspected.Size = size
+	inspected.Duration = time.Since(inspected.Start).String()
+
+	// The requests which did not match any frontend are only streamed.
+	if inspected.Frontend != "" {
+		ring, ok := i.recent[inspected.Frontend]
+		if !ok {
+			ring = &requestRing{requests: make([]InspectedRequest, 0, i.numRecentRequests)}
+			i.recent[inspected.Frontend] = ring
+		}
+		ring.add(*inspected)
+	}
+
+	for subscriber := range i.subscribers {
+		select {
+		case subscriber <- *inspected:
+		default:
+		}
+	}
+}
+
+// requestRing is a fixed size ring buffer of requests.
+type requestRing struct {
+	requests []InspectedRequest
+	next     int
+}
+
+func (r *requestRing) add(request InspectedRequest) {
+	if len(r.requests) < cap(r.requests) {
+		r.requests = append(r.requests, request)
+		return
+	}
+
+	r.requests[r.next] = request
+	r.next = (r.next + 1) % len(r.requests)
+}
+
+// list returns a copy of the requests, the most recent first.
+func (r *requestRing) list() []InspectedRequest {
+	size := len(r.requests)
+	requests := make([]InspectedRequest, 0, size)
+	for k := 1; k <= size; k++ {
+		requests = append(requests, r.requests[(r.next-k+size)%size])
+	}
+	return requests
+}
+
+// InspectionEnabled returns true if the recent requests and the requests in flight are recorded.
+func (s *StatsRecorder) InspectionEnabled() bool {
+	return s != nil && s.requests != nil
+}
+
+// RecentRequests returns the recent requests of each frontend, the most recent first.
+func (s *StatsRecorder) RecentRequests() map[string][]InspectedRequest {
+	recent := make(map[string][]InspectedRequest)
+	if !s.InspectionEnabled() {
+		return recent
+	}
+
+	s.requests.mu.Lock()
+	defer s.requests.mu.Unlock()
+
+	for frontendName, ring := range s.requests.recent {
+		recent[frontendName] = ring.list()
+	}
+	return recent
+}
+
+// InFlightRequests returns the requests in flight, the oldest first.
+func (s *StatsRecorder) InFlightRequests() []InspectedRequest {
+	inFlight := []InspectedRequest{}
+	if !s.InspectionEnabled() {
+		return inFlight
+	}
+
+	s.requests.mu.Lock()
+	defer s.requests.mu.Unlock()
+
+	now := time.Now()
+	for _, inspected := range s.requests.inFlight {
+		request := *inspected
+		request.Age = now.Sub(request.Start).String()
+		inFlight = append(inFlight, request)
+	}
+
+	sort.Slice(inFlight, func(a, b int) bool {
+		return inFlight[a].ID < inFlight[b].ID
+	})
+	return inFlight
+}
+
+// SubscribeRequests returns a channel receiving the requests once served, and the function to unsubscribe.
+// The requests are dropped when the subscriber is too slow to receive them.
+func (s *StatsRecorder) SubscribeRequests() (<-chan InspectedRequest, func()) {
+	subscriber := make(chan InspectedRequest, requestsSubscriberBufferSize)
+	if !s.InspectionEnabled() {
+		return subscriber, func() {}
+	}
+
+	s.requests.mu.Lock()
+	s.requests.subscribers[subscriber] = struct{}{}
+	s.requests.mu.Unlock()
+
+	var once sync.Once
+	return subscriber, func() {
+		once.Do(func() {
+			s.requests.mu.Lock()
+			defer s.requests.mu.Unlock()
+
+			delete(s.requests.subscribers, subscriber)
+			close(subscriber)
+		})
+	}
+}
+
+// SaveFrontend returns a handler recording the frontend and the backend which serve the inspected request.
+func (s *StatsRecorder) SaveFrontend(next http.Handler, frontendName, backendName string) http.Handler {
+	if !s.InspectionEnabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if inspected, ok := r.Context().Value(inspectedRequestKey).(*InspectedRequest); ok {
+			s.requests.setFrontend(inspected, frontendName, backendName)
+		}
+		next.ServeHTTP(rw, r)
+	})
+}
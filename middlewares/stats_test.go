@@ -0,0 +1,202 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsRecorderRecentRequests(t *testing.T) {
+	fields := &types.AccessLogFields{
+		Headers: &types.FieldHeaders{
+			DefaultMode: types.AccessLogKeep,
+			Names: types.FieldHeaderNames{
+				"Authorization": types.AccessLogRedact,
+				"X-Dropped":     types.AccessLogDrop,
+			},
+		},
+	}
+	recorder := NewStatsRecorder(&types.Statistics{RecentErrors: 10, RecentRequests: 2}, fields)
+	require.True(t, recorder.InspectionEnabled())
+
+	handler := recorder.SaveFrontend(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+	}), "frontend1", "backend1")
+
+	requests, unsubscribe := recorder.SubscribeRequests()
+	defer unsubscribe()
+
+	for i := 1; i <= 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://foo.bar/%d", i), nil)
+		req.Header.Set("Authorization", "secret")
+		req.Header.Set("X-Dropped", "dropped")
+		req.Header.Set("X-Kept", "kept")
+		recorder.ServeHTTP(httptest.NewRecorder(), req, handler.ServeHTTP)
+	}
+
+	// A request which does not match any frontend is not kept.
+	recorder.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://foo.bar/404", nil), http.NotFound)
+
+	recent := recorder.RecentRequests()
+	require.Len(t, recent, 1)
+	require.Len(t, recent["frontend1"], 2)
+
+	assert.Equal(t, "/3", recent["frontend1"][0].Path)
+	assert.Equal(t, "/2", recent["frontend1"][1].Path)
+
+	inspected := recent["frontend1"][0]
+	assert.Equal(t, uint64(3), inspected.ID)
+	assert.Equal(t, "backend1", inspected.Backend)
+	assert.Equal(t, http.StatusAccepted, inspected.StatusCode)
+	assert.NotEmpty(t, inspected.Duration)
+	assert.Equal(t, map[string]string{"Authorization": "REDACTED", "X-Kept": "kept"}, inspected.Headers)
+
+	for _, expectedPath := range []string{"/1", "/2", "/3", "/404"} {
+		select {
+		case inspected := <-requests:
+			assert.Equal(t, expectedPath, inspected.Path)
+		case <-time.After(time.Second):
+			t.Fatalf("request %s not received", expectedPath)
+		}
+	}
+
+	assert.Len(t, recorder.Data().RecentErrors, 1)
+	assert.Empty(t, recorder.InFlightRequests())
+}
+
+func TestStatsRecorderRedactsCredentialHeaders(t *testing.T) {
+	fields := &types.AccessLogFields{
+		Headers: &types.FieldHeaders{
+			DefaultMode: types.AccessLogKeep,
+			Names: types.FieldHeaderNames{
+				"Cookie": types.AccessLogDrop,
+			},
+		},
+	}
+	recorder := NewStatsRecorder(&types.Statistics{RecentRequests: 10}, fields)
+
+	handler := recorder.SaveFrontend(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), "frontend1", "backend1")
+
+	req := httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil)
+	req.Header.Set("Authorization", "Basic dGVzdDp0ZXN0")
+	req.Header.Set("Proxy-Authorization", "Basic dGVzdDp0ZXN0")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("User-Agent", "curl")
+	recorder.ServeHTTP(httptest.NewRecorder(), req, handler.ServeHTTP)
+
+	recent := recorder.RecentRequests()["frontend1"]
+	require.Len(t, recent, 1)
+
+	expected := map[string]string{
+		"Authorization":       "REDACTED",
+		"Proxy-Authorization": "REDACTED",
+		"User-Agent":          "curl",
+	}
+	assert.Equal(t, expected, recent[0].Headers)
+}
+
+func TestStatsRecorderInFlightRequests(t *testing.T) {
+	recorder := NewStatsRecorder(&types.Statistics{RecentRequests: 10}, nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := recorder.SaveFrontend(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-release
+	}), "frontend1", "backend1")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		req := httptest.NewRequest(http.MethodGet, "http://foo.bar/slow", nil)
+		req.Header.Set("Authorization", "secret")
+		recorder.ServeHTTP(httptest.NewRecorder(), req, handler.ServeHTTP)
+	}()
+
+	<-started
+	inFlight := recorder.InFlightRequests()
+	require.Len(t, inFlight, 1)
+	assert.Equal(t, "/slow", inFlight[0].Path)
+	assert.Equal(t, "frontend1", inFlight[0].Frontend)
+	assert.Equal(t, "backend1", inFlight[0].Backend)
+	assert.NotEmpty(t, inFlight[0].Age)
+	// Without access log fields, the headers are not recorded.
+	assert.Empty(t, inFlight[0].Headers)
+
+	close(release)
+	<-done
+
+	assert.Empty(t, recorder.InFlightRequests())
+	assert.Len(t, recorder.RecentRequests()["frontend1"], 1)
+}
+
+func TestStatsRecorderInspectionDisabled(t *testing.T) {
+	recorder := NewStatsRecorder(&types.Statistics{RecentErrors: 10}, nil)
+	assert.False(t, recorder.InspectionEnabled())
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+	handler := recorder.SaveFrontend(next, "frontend1", "backend1")
+
+	recorder.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil), handler.ServeHTTP)
+
+	assert.Empty(t, recorder.RecentRequests())
+	assert.Empty(t, recorder.InFlightRequests())
+}
+
+func TestRequestRing(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		size     int
+		added    int
+		expected []uint64
+	}{
+		{
+			desc:     "empty",
+			size:     3,
+			expected: []uint64{},
+		},
+		{
+			desc:     "not full",
+			size:     3,
+			added:    2,
+			expected: []uint64{2, 1},
+		},
+		{
+			desc:     "full",
+			size:     3,
+			added:    3,
+			expected: []uint64{3, 2, 1},
+		},
+		{
+			desc:     "overwritten",
+			size:     3,
+			added:    7,
+			expected: []uint64{7, 6, 5},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ring := &requestRing{requests: make([]InspectedRequest, 0, test.size)}
+			for i := 1; i <= test.added; i++ {
+				ring.add(InspectedRequest{ID: uint64(i)})
+			}
+
+			ids := []uint64{}
+			for _, request := range ring.list() {
+				ids = append(ids, request.ID)
+			}
+			assert.Equal(t, test.expected, ids)
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/containous/mux"
-	"github.com/containous/traefik/log"
 )
 
 // StripPrefixRegex is a middleware used to strip prefix from an URL request
@@ -36,7 +35,7 @@ func (s *StripPrefixRegex) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 		prefix, err := match.Route.URL(params...)
 		if err != nil || len(prefix.Path) > len(r.URL.Path) {
-			log.Error("Error in stripPrefix middleware", err)
+			logger.Error("Error in stripPrefix middleware", err)
 			return
 		}
 		rawReqPath := r.URL.Path
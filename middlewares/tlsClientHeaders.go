@@ -9,7 +9,6 @@ import (
 	"net/url"
 	"strings"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/types"
 )
 
@@ -114,7 +113,7 @@ func extractCertificate(cert *x509.Certificate) string {
 	b := pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}
 	certPEM := pem.EncodeToMemory(&b)
 	if certPEM == nil {
-		log.Error("Cannot extract the certificate content")
+		logger.Error("Cannot extract the certificate content")
 		return ""
 	}
 	return sanitize(certPEM)
@@ -266,7 +265,7 @@ func (s *TLSClientHeaders) ModifyRequestHeaders(r *http.Request) {
 		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
 			r.Header.Set(xForwardedTLSClientCert, getXForwardedTLSClientCert(r.TLS.PeerCertificates))
 		} else {
-			log.Warn("Try to extract certificate on a request without TLS")
+			logger.Warn("Try to extract certificate on a request without TLS")
 		}
 	}
 
@@ -276,7 +275,7 @@ func (s *TLSClientHeaders) ModifyRequestHeaders(r *http.Request) {
 			headerContent := s.getXForwardedTLSClientCertInfo(r.TLS.PeerCertificates)
 			r.Header.Set(xForwardedTLSClientCertInfos, url.QueryEscape(headerContent))
 		} else {
-			log.Warn("Try to extract certificate on a request without TLS")
+			logger.Warn("Try to extract certificate on a request without TLS")
 		}
 	}
 }
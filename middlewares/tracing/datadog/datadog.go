@@ -10,6 +10,8 @@ import (
 	datadog "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 )
 
+var logger = log.WithModule("middlewares/tracing/datadog")
+
 // Name sets the name of this tracer
 const Name = "datadog"
 
@@ -54,7 +56,7 @@ func (c *Config) Setup(serviceName string) (opentracing.Tracer, io.Closer, error
 	// Without this, child spans are getting the NOOP tracer
 	opentracing.SetGlobalTracer(tracer)
 
-	log.Debug("DataDog tracer configured")
+	logger.Debug("DataDog tracer configured")
 
 	return tracer, nil, nil
 }
@@ -1,10 +1,10 @@
 package tracing
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
-	"github.com/containous/traefik/log"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/urfave/negroni"
@@ -17,20 +17,23 @@ type entryPointMiddleware struct {
 
 // NewEntryPoint creates a new middleware that the incoming request
 func (t *Tracing) NewEntryPoint(name string) negroni.Handler {
-	log.Debug("Added entrypoint tracing middleware")
+	logger.Debug("Added entrypoint tracing middleware")
 	return &entryPointMiddleware{Tracing: t, entryPoint: name}
 }
 
 func (e *entryPointMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 	opNameFunc := generateEntryPointSpanName
 
-	ctx, _ := e.Extract(opentracing.HTTPHeaders, HTTPHeadersCarrier(r.Header))
+	ctx, _ := e.extractRequest(r.Header)
 	span := e.StartSpan(opNameFunc(r, e.entryPoint, e.SpanNameLimit), ext.RPCServerOption(ctx))
 	ext.Component.Set(span, e.ServiceName)
 	LogRequest(span, r)
 	ext.SpanKindRPCServer.Set(span)
 
-	r = r.WithContext(opentracing.ContextWithSpan(r.Context(), span))
+	r = r.WithContext(context.WithValue(opentracing.ContextWithSpan(r.Context(), span), tracingKey, e.Tracing))
+	if traceID := e.traceID(span.Context()); traceID != "" {
+		r = r.WithContext(context.WithValue(r.Context(), traceIDKey, traceID))
+	}
 
 	recorder := newStatusCodeRecoder(w, 200)
 	next(recorder, r)
@@ -45,7 +48,7 @@ func generateEntryPointSpanName(r *http.Request, entryPoint string, spanLimit in
 
 	if spanLimit > 0 && len(name) > spanLimit {
 		if spanLimit < EntryPointMaxLengthNumber {
-			log.Warnf("SpanNameLimit is set to be less than required static number of characters, defaulting to %d + 3", EntryPointMaxLengthNumber)
+			logger.Warnf("SpanNameLimit is set to be less than required static number of characters, defaulting to %d + 3", EntryPointMaxLengthNumber)
 			spanLimit = EntryPointMaxLengthNumber + 3
 		}
 		hash := computeHash(name)
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/containous/traefik/log"
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/urfave/negroni"
 )
@@ -18,7 +17,7 @@ type forwarderMiddleware struct {
 
 // NewForwarderMiddleware creates a new forwarder middleware that traces the outgoing request
 func (t *Tracing) NewForwarderMiddleware(frontend, backend string) negroni.Handler {
-	log.Debugf("Added outgoing tracing middleware %s", frontend)
+	logger.Debugf("Added outgoing tracing middleware %s", frontend)
 	return &forwarderMiddleware{
 		Tracing:  t,
 		frontend: frontend,
@@ -51,7 +50,7 @@ func generateForwardSpanName(frontend, backend string, spanLimit int) string {
 
 	if spanLimit > 0 && len(name) > spanLimit {
 		if spanLimit < ForwardMaxLengthNumber {
-			log.Warnf("SpanNameLimit is set to be less than required static number of characters, defaulting to %d + 3", ForwardMaxLengthNumber)
+			logger.Warnf("SpanNameLimit is set to be less than required static number of characters, defaulting to %d + 3", ForwardMaxLengthNumber)
 			spanLimit = ForwardMaxLengthNumber + 3
 		}
 		hash := computeHash(name)
@@ -10,6 +10,8 @@ import (
 	jaegermet "github.com/uber/jaeger-lib/metrics"
 )
 
+var logger = log.WithModule("middlewares/tracing/jaeger")
+
 // Name sets the name of this tracer
 const Name = "jaeger"
 
@@ -48,10 +50,10 @@ func (c *Config) Setup(componentName string) (opentracing.Tracer, io.Closer, err
 		jaegercfg.Metrics(jMetricsFactory),
 	)
 	if err != nil {
-		log.Warnf("Could not initialize jaeger tracer: %s", err.Error())
+		logger.Warnf("Could not initialize jaeger tracer: %s", err.Error())
 		return nil, nil, err
 	}
-	log.Debug("Jaeger tracer configured")
+	logger.Debug("Jaeger tracer configured")
 
 	return opentracing.GlobalTracer(), closer, nil
 }
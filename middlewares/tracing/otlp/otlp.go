@@ -0,0 +1,58 @@
+package otlp
+
+import (
+	"io"
+	"time"
+
+	"github.com/containous/flaeg"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/otlp"
+	"github.com/containous/traefik/types"
+	"github.com/opentracing/opentracing-go"
+)
+
+var logger = log.WithModule("middlewares/tracing/otlp")
+
+// Name sets the name of this tracer
+const Name = "otlp"
+
+const (
+	// DefaultBatchSize is the default maximum number of spans sent in a single export request
+	DefaultBatchSize = 512
+	// DefaultFlushInterval is the default maximum delay between two export requests
+	DefaultFlushInterval = 5 * time.Second
+)
+
+// Config provides configuration settings for an OpenTelemetry (OTLP) tracer
+type Config struct {
+	types.OTLP    `mapstructure:",squash" export:"true"`
+	BatchSize     int            `description:"Maximum number of spans sent in a single export request." export:"true"`
+	FlushInterval flaeg.Duration `description:"Maximum delay between two export requests." export:"true"`
+}
+
+// Setup sets up the tracer
+func (c *Config) Setup(serviceName string) (opentracing.Tracer, io.Closer, error) {
+	exporter, err := otlp.NewExporter(&c.OTLP)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	batchSize := c.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	flushInterval := time.Duration(c.FlushInterval)
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+
+	tracer := newTracer(exporter, otlp.NewResource(serviceName, c.ResourceAttributes), batchSize, flushInterval)
+
+	// Without this, child spans are getting the NOOP tracer
+	opentracing.SetGlobalTracer(tracer)
+
+	logger.Debug("OTLP tracer configured")
+
+	return tracer, tracer, nil
+}
@@ -0,0 +1,77 @@
+package otlp
+
+import (
+	"strings"
+
+	"github.com/containous/traefik/middlewares/tracing/tracecontext"
+	"github.com/opentracing/opentracing-go"
+)
+
+func (t *tracer) Inject(sc opentracing.SpanContext, format interface{}, carrier interface{}) error {
+	ctx, ok := sc.(spanContext)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+
+	switch format {
+	case opentracing.HTTPHeaders, opentracing.TextMap:
+	default:
+		return opentracing.ErrUnsupportedFormat
+	}
+
+	writer, ok := carrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+
+	parent := tracecontext.TraceParent{TraceID: ctx.traceID, SpanID: ctx.spanID, Sampled: ctx.sampled}
+	writer.Set(tracecontext.TraceParentHeader, parent.String())
+	if len(ctx.traceState) > 0 {
+		writer.Set(tracecontext.TraceStateHeader, ctx.traceState)
+	}
+
+	return nil
+}
+
+func (t *tracer) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
+	switch format {
+	case opentracing.HTTPHeaders, opentracing.TextMap:
+	default:
+		return nil, opentracing.ErrUnsupportedFormat
+	}
+
+	reader, ok := carrier.(opentracing.TextMapReader)
+	if !ok {
+		return nil, opentracing.ErrInvalidCarrier
+	}
+
+	var traceParent, traceState string
+	err := reader.ForeachKey(func(key, value string) error {
+		switch strings.ToLower(key) {
+		case tracecontext.TraceParentHeader:
+			traceParent = value
+		case tracecontext.TraceStateHeader:
+			traceState = value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(traceParent) == 0 {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+
+	parent, err := tracecontext.Parse(traceParent)
+	if err != nil {
+		return nil, err
+	}
+
+	return spanContext{
+		traceID:    parent.TraceID,
+		spanID:     parent.SpanID,
+		sampled:    parent.Sampled,
+		traceState: traceState,
+	}, nil
+}
@@ -0,0 +1,372 @@
+package otlp
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/otlp"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+)
+
+// tracer is an opentracing.Tracer exporting the sampled spans by batches to an OpenTelemetry collector
+type tracer struct {
+	exporter      *otlp.Exporter
+	resource      *otlp.Resource
+	batchSize     int
+	flushInterval time.Duration
+
+	spans     chan *otlp.Span
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newTracer(exporter *otlp.Exporter, resource *otlp.Resource, batchSize int, flushInterval time.Duration) *tracer {
+	t := &tracer{
+		exporter:      exporter,
+		resource:      resource,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		spans:         make(chan *otlp.Span, 2*batchSize),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	go t.run()
+
+	return t
+}
+
+func (t *tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	options := opentracing.StartSpanOptions{}
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+
+	s := &span{
+		tracer:        t,
+		operationName: operationName,
+		start:         options.StartTime,
+		tags:          make(map[string]interface{}, len(options.Tags)),
+	}
+
+	if s.start.IsZero() {
+		s.start = time.Now()
+	}
+
+	for key, value := range options.Tags {
+		s.tags[key] = value
+	}
+
+	for _, ref := range options.References {
+		parent, ok := ref.ReferencedContext.(spanContext)
+		if !ok {
+			continue
+		}
+
+		s.context = spanContext{
+			traceID:    parent.traceID,
+			sampled:    parent.sampled,
+			traceState: parent.traceState,
+			baggage:    parent.baggage,
+		}
+		s.parentSpanID = parent.spanID
+		s.hasParent = true
+
+		if ref.Type == opentracing.ChildOfRef {
+			break
+		}
+	}
+
+	if !s.hasParent {
+		randomID(s.context.traceID[:])
+		s.context.sampled = true
+	}
+	randomID(s.context.spanID[:])
+
+	return s
+}
+
+// Close flushes the pending spans and closes the exporter
+func (t *tracer) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.stop)
+		<-t.done
+	})
+	return t.exporter.Close()
+}
+
+func (t *tracer) enqueue(s *otlp.Span) {
+	select {
+	case t.spans <- s:
+	default:
+		logger.Debugf("OTLP tracer queue is full, dropping the span %q", s.Name)
+	}
+}
+
+func (t *tracer) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.flushInterval)
+	defer ticker.Stop()
+
+	var batch []*otlp.Span
+	for {
+		select {
+		case s := <-t.spans:
+			batch = append(batch, s)
+			if len(batch) >= t.batchSize {
+				t.export(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			t.export(batch)
+			batch = nil
+		case <-t.stop:
+			for {
+				select {
+				case s := <-t.spans:
+					batch = append(batch, s)
+				default:
+					t.export(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (t *tracer) export(spans []*otlp.Span) {
+	if len(spans) == 0 {
+		return
+	}
+
+	request := &otlp.ExportTraceServiceRequest{
+		ResourceSpans: []*otlp.ResourceSpans{{
+			Resource: t.resource,
+			ScopeSpans: []*otlp.ScopeSpans{{
+				Scope: otlp.NewInstrumentationScope(),
+				Spans: spans,
+			}},
+		}},
+	}
+
+	if err := t.exporter.ExportTraces(context.Background(), request); err != nil {
+		logger.Errorf("Unable to export %d spans to the OpenTelemetry collector: %v", len(spans), err)
+	}
+}
+
+// spanContext holds the W3C trace context of a span
+type spanContext struct {
+	traceID    [16]byte
+	spanID     [8]byte
+	sampled    bool
+	traceState string
+	baggage    map[string]string
+}
+
+func (c spanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	for k, v := range c.baggage {
+		if !handler(k, v) {
+			return
+		}
+	}
+}
+
+type span struct {
+	tracer *tracer
+
+	lock          sync.Mutex
+	context       spanContext
+	parentSpanID  [8]byte
+	hasParent     bool
+	operationName string
+	start         time.Time
+	tags          map[string]interface{}
+	logs          []opentracing.LogRecord
+	finished      bool
+}
+
+func (s *span) Finish() {
+	s.FinishWithOptions(opentracing.FinishOptions{})
+}
+
+func (s *span) FinishWithOptions(opts opentracing.FinishOptions) {
+	finish := opts.FinishTime
+	if finish.IsZero() {
+		finish = time.Now()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.finished {
+		return
+	}
+	s.finished = true
+
+	s.logs = append(s.logs, opts.LogRecords...)
+	for _, data := range opts.BulkLogData {
+		s.logs = append(s.logs, data.ToLogRecord())
+	}
+
+	if s.context.sampled {
+		s.tracer.enqueue(s.toOTLP(finish))
+	}
+}
+
+func (s *span) Context() opentracing.SpanContext {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.context
+}
+
+func (s *span) SetOperationName(operationName string) opentracing.Span {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.operationName = operationName
+	return s
+}
+
+func (s *span) SetTag(key string, value interface{}) opentracing.Span {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tags[key] = value
+	return s
+}
+
+func (s *span) LogFields(fields ...otlog.Field) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.logs = append(s.logs, opentracing.LogRecord{Timestamp: time.Now(), Fields: fields})
+}
+
+func (s *span) LogKV(alternatingKeyValues ...interface{}) {
+	fields, err := otlog.InterleavedKVToFields(alternatingKeyValues...)
+	if err != nil {
+		s.LogFields(otlog.Error(err), otlog.String("function", "LogKV"))
+		return
+	}
+	s.LogFields(fields...)
+}
+
+func (s *span) SetBaggageItem(restrictedKey, value string) opentracing.Span {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	baggage := make(map[string]string, len(s.context.baggage)+1)
+	for k, v := range s.context.baggage {
+		baggage[k] = v
+	}
+	baggage[restrictedKey] = value
+	s.context.baggage = baggage
+
+	return s
+}
+
+func (s *span) BaggageItem(restrictedKey string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.context.baggage[restrictedKey]
+}
+
+func (s *span) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+func (s *span) LogEvent(event string) {
+	s.Log(opentracing.LogData{Event: event})
+}
+
+func (s *span) LogEventWithPayload(event string, payload interface{}) {
+	s.Log(opentracing.LogData{Event: event, Payload: payload})
+}
+
+func (s *span) Log(data opentracing.LogData) {
+	record := data.ToLogRecord()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.logs = append(s.logs, record)
+}
+
+// toOTLP converts the span, the lock must be held by the caller
+func (s *span) toOTLP(finish time.Time) *otlp.Span {
+	result := &otlp.Span{
+		TraceID:           append([]byte(nil), s.context.traceID[:]...),
+		SpanID:            append([]byte(nil), s.context.spanID[:]...),
+		TraceState:        s.context.traceState,
+		Name:              s.operationName,
+		Kind:              otlp.SpanKindInternal,
+		StartTimeUnixNano: uint64(s.start.UnixNano()),
+		EndTimeUnixNano:   uint64(finish.UnixNano()),
+		Status:            &otlp.Status{Code: otlp.StatusCodeUnset},
+	}
+
+	if s.hasParent {
+		result.ParentSpanID = append([]byte(nil), s.parentSpanID[:]...)
+	}
+
+	keys := make([]string, 0, len(s.tags))
+	for key := range s.tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := s.tags[key]
+
+		switch key {
+		case string(ext.SpanKind):
+			result.Kind = spanKind(value)
+			continue
+		case string(ext.Error):
+			if isError, ok := value.(bool); ok && isError {
+				result.Status.Code = otlp.StatusCodeError
+			}
+		}
+
+		result.Attributes = append(result.Attributes, otlp.Attribute(key, value))
+	}
+
+	for _, record := range s.logs {
+		event := &otlp.Event{TimeUnixNano: uint64(record.Timestamp.UnixNano()), Name: "log"}
+		for _, field := range record.Fields {
+			if field.Key() == "event" {
+				event.Name = fmt.Sprint(field.Value())
+				continue
+			}
+			event.Attributes = append(event.Attributes, otlp.Attribute(field.Key(), field.Value()))
+		}
+		result.Events = append(result.Events, event)
+	}
+
+	return result
+}
+
+func spanKind(value interface{}) otlp.SpanKind {
+	switch ext.SpanKindEnum(fmt.Sprint(value)) {
+	case ext.SpanKindRPCServerEnum:
+		return otlp.SpanKindServer
+	case ext.SpanKindRPCClientEnum:
+		return otlp.SpanKindClient
+	case ext.SpanKindProducerEnum:
+		return otlp.SpanKindProducer
+	case ext.SpanKindConsumerEnum:
+		return otlp.SpanKindConsumer
+	default:
+		return otlp.SpanKindInternal
+	}
+}
+
+func randomID(id []byte) {
+	if _, err := rand.Read(id); err != nil {
+		logger.Errorf("Unable to generate a random span identifier: %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package otlp
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/otlp"
+	"github.com/containous/traefik/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracerExport(t *testing.T) {
+	requests := make(chan *otlp.ExportTraceServiceRequest, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		request := &otlp.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, request); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests <- request
+	}))
+	defer ts.Close()
+
+	config := &Config{
+		OTLP: types.OTLP{
+			Protocol:           otlp.ProtocolHTTP,
+			Endpoint:           ts.URL,
+			ResourceAttributes: types.KeyValues{"deployment.environment": "test"},
+		},
+	}
+	tracer, closer, err := config.Setup("traefik")
+	require.NoError(t, err)
+
+	incoming := http.Header{}
+	incoming.Set("Traceparent", "00-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-01")
+	incoming.Set("Tracestate", "congo=t61rcWkgMzE")
+
+	parent, err := tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(incoming))
+	require.NoError(t, err)
+
+	server := tracer.StartSpan("Entrypoint http", ext.RPCServerOption(parent))
+	ext.HTTPMethod.Set(server, http.MethodGet)
+	client := tracer.StartSpan("forward backend", opentracing.ChildOf(server.Context()))
+	ext.SpanKindRPCClient.Set(client)
+	ext.Error.Set(client, true)
+	client.LogKV("event", "retry", "attempt", 1)
+
+	outgoing := http.Header{}
+	require.NoError(t, tracer.Inject(client.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(outgoing)))
+	clientID := client.Context().(spanContext).spanID
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c00f7b7-"+hex.EncodeToString(clientID[:])+"-01", outgoing.Get("traceparent"))
+	assert.Equal(t, "congo=t61rcWkgMzE", outgoing.Get("tracestate"))
+
+	client.Finish()
+	server.Finish()
+
+	// flushes the pending spans
+	require.NoError(t, closer.Close())
+
+	var request *otlp.ExportTraceServiceRequest
+	select {
+	case request = <-requests:
+	case <-time.After(time.Second):
+		t.Fatal("the spans have not been exported")
+	}
+
+	require.Len(t, request.ResourceSpans, 1)
+	assert.Equal(t, otlp.NewResource("traefik", types.KeyValues{"deployment.environment": "test"}), request.ResourceSpans[0].Resource)
+	require.Len(t, request.ResourceSpans[0].ScopeSpans, 1)
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	traceID, _ := hex.DecodeString("0af7651916cd43dd8448eb211c00f7b7")
+	parentID, _ := hex.DecodeString("b7ad6b7169203331")
+
+	exportedClient := spans[0]
+	assert.Equal(t, "forward backend", exportedClient.Name)
+	assert.Equal(t, traceID, exportedClient.TraceID)
+	assert.Equal(t, otlp.SpanKindClient, exportedClient.Kind)
+	assert.Equal(t, otlp.StatusCodeError, exportedClient.Status.Code)
+	require.Len(t, exportedClient.Events, 1)
+	assert.Equal(t, "retry", exportedClient.Events[0].Name)
+	assert.Equal(t, []*otlp.KeyValue{otlp.Attribute("attempt", 1)}, exportedClient.Events[0].Attributes)
+
+	exportedServer := spans[1]
+	assert.Equal(t, "Entrypoint http", exportedServer.Name)
+	assert.Equal(t, traceID, exportedServer.TraceID)
+	assert.Equal(t, parentID, exportedServer.ParentSpanID)
+	assert.Equal(t, exportedServer.SpanID, exportedClient.ParentSpanID)
+	assert.Equal(t, "congo=t61rcWkgMzE", exportedServer.TraceState)
+	assert.Equal(t, otlp.SpanKindServer, exportedServer.Kind)
+	assert.Equal(t, otlp.StatusCodeUnset, exportedServer.Status.Code)
+	assert.Equal(t, []*otlp.KeyValue{otlp.Attribute("http.method", http.MethodGet)}, exportedServer.Attributes)
+}
+
+func TestTracerExtract(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		traceParent     string
+		expectedErr     error
+		expectedSampled bool
+	}{
+		{
+			desc:            "sampled",
+			traceParent:     "00-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-01",
+			expectedSampled: true,
+		},
+		{
+			desc:        "not sampled",
+			traceParent: "00-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-00",
+		},
+		{
+			desc:            "future version with additional fields",
+			traceParent:     "01-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-01-extra",
+			expectedSampled: true,
+		},
+		{
+			desc:        "missing",
+			expectedErr: opentracing.ErrSpanContextNotFound,
+		},
+		{
+			desc:        "invalid version",
+			traceParent: "ff-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-01",
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc:        "additional fields with version 00",
+			traceParent: "00-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-01-extra",
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc:        "zero trace id",
+			traceParent: "00-00000000000000000000000000000000-b7ad6b7169203331-01",
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc:        "upper case span id",
+			traceParent: "00-0af7651916cd43dd8448eb211c00f7b7-B7AD6B7169203331-01",
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc:        "short trace id",
+			traceParent: "00-0af7651916cd43dd-b7ad6b7169203331-01",
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+	}
+
+	tracer := &tracer{}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{}
+			if len(test.traceParent) > 0 {
+				header.Set("traceparent", test.traceParent)
+			}
+
+			ctx, err := tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header))
+			if test.expectedErr != nil {
+				assert.Equal(t, test.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSampled, ctx.(spanContext).sampled)
+		})
+	}
+}
+
+func TestTracerUnsampledSpansAreNotExported(t *testing.T) {
+	tracer := &tracer{spans: make(chan *otlp.Span, 1)}
+
+	header := http.Header{}
+	header.Set("traceparent", "00-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-00")
+	parent, err := tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header))
+	require.NoError(t, err)
+
+	span := tracer.StartSpan("Entrypoint http", ext.RPCServerOption(parent))
+	span.Finish()
+
+	assert.Len(t, tracer.spans, 0)
+
+	// the sampling decision is propagated
+	outgoing := http.Header{}
+	require.NoError(t, tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(outgoing)))
+	assert.Regexp(t, `^00-0af7651916cd43dd8448eb211c00f7b7-[0-9a-f]{16}-00$`, outgoing.Get("traceparent"))
+}
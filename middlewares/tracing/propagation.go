@@ -0,0 +1,469 @@
+package tracing
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/containous/traefik/middlewares/tracing/datadog"
+	"github.com/containous/traefik/middlewares/tracing/jaeger"
+	"github.com/containous/traefik/middlewares/tracing/otlp"
+	"github.com/containous/traefik/middlewares/tracing/tracecontext"
+	"github.com/containous/traefik/middlewares/tracing/zipkin"
+	"github.com/opentracing/opentracing-go"
+	jaegercli "github.com/uber/jaeger-client-go"
+	ddtracer "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// Propagation formats of the trace context
+const (
+	// PropagationTracer is the native format of the tracing backend
+	PropagationTracer = "tracer"
+	// PropagationTraceContext is the W3C Trace Context format (traceparent and tracestate headers)
+	PropagationTraceContext = "tracecontext"
+	// PropagationB3 is the B3 format with multiple headers (X-B3-TraceId, X-B3-SpanId and X-B3-Sampled)
+	PropagationB3 = "b3"
+	// PropagationB3Single is the B3 format with a single header (b3)
+	PropagationB3Single = "b3single"
+)
+
+const (
+	b3TraceIDHeader      = "X-B3-TraceId"
+	b3SpanIDHeader       = "X-B3-SpanId"
+	b3ParentSpanIDHeader = "X-B3-ParentSpanId"
+	b3SampledHeader      = "X-B3-Sampled"
+	b3FlagsHeader        = "X-B3-Flags"
+	b3SingleHeader       = "b3"
+)
+
+// TraceContext is the trace context of a request, independent of the propagation format.
+// 64 bits trace IDs are stored in the lower half of TraceID.
+// Deferred is set when the format carried no sampling decision, the sampler of the tracer decides.
+type TraceContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	Sampled    bool
+	Deferred   bool
+	TraceState string
+}
+
+// propagator reads and writes a trace context from and to the headers in a given format
+type propagator interface {
+	Extract(header http.Header) (*TraceContext, error)
+	Inject(ctx *TraceContext, header http.Header)
+}
+
+// propagation translates the trace context between the configured formats and the native format of the backend
+type propagation struct {
+	formats []string
+	native  propagator
+}
+
+// ValidatePropagation checks the propagation formats
+func ValidatePropagation(formats []string) error {
+	for _, format := range formats {
+		if format != PropagationTracer && propagatorFor(format) == nil {
+			return fmt.Errorf("unknown propagation format %q, expected %q, %q, %q or %q",
+				format, PropagationTracer, PropagationTraceContext, PropagationB3, PropagationB3Single)
+		}
+	}
+	return nil
+}
+
+func newPropagation(formats []string, native propagator) *propagation {
+	if len(formats) == 0 || native == nil {
+		return nil
+	}
+	return &propagation{formats: formats, native: native}
+}
+
+// extract returns the span context of the first configured format found in the headers
+func (p *propagation) extract(tracer opentracing.Tracer, header http.Header) (opentracing.SpanContext, error) {
+	for _, format := range p.formats {
+		if format == PropagationTracer {
+			ctx, err := tracer.Extract(opentracing.HTTPHeaders, HTTPHeadersCarrier(header))
+			if err == nil {
+				return ctx, nil
+			}
+			continue
+		}
+
+		prop := propagatorFor(format)
+		if prop == nil {
+			continue
+		}
+
+		traceContext, err := prop.Extract(header)
+		if err != nil {
+			if err != opentracing.ErrSpanContextNotFound {
+				logger.Debugf("Unable to extract the %s trace context: %v", format, err)
+			}
+			continue
+		}
+
+		if traceContext.Deferred && !defersSampling(p.native) {
+			traceContext.Sampled = sample(tracer, p.native)
+			traceContext.Deferred = false
+		}
+
+		nativeHeader := http.Header{}
+		p.native.Inject(traceContext, nativeHeader)
+		return tracer.Extract(opentracing.HTTPHeaders, HTTPHeadersCarrier(nativeHeader))
+	}
+
+	return nil, opentracing.ErrSpanContextNotFound
+}
+
+// inject writes the span context in all the configured formats
+func (p *propagation) inject(tracer opentracing.Tracer, sc opentracing.SpanContext, header http.Header) error {
+	nativeHeader := http.Header{}
+	if err := tracer.Inject(sc, opentracing.HTTPHeaders, HTTPHeadersCarrier(nativeHeader)); err != nil {
+		return err
+	}
+
+	traceContext, err := p.native.Extract(nativeHeader)
+	if err != nil {
+		traceContext = nil
+	}
+
+	for _, format := range p.formats {
+		if format == PropagationTracer {
+			for key, values := range nativeHeader {
+				header[key] = values
+			}
+			continue
+		}
+
+		if prop := propagatorFor(format); prop != nil && traceContext != nil {
+			prop.Inject(traceContext, header)
+		}
+	}
+
+	return nil
+}
+
+// defersSampling returns whether the native format carries the contexts without sampling decision to the tracer
+func defersSampling(native propagator) bool {
+	_, ok := native.(datadogPropagator)
+	return ok
+}
+
+// sample asks the sampler of the tracer for a decision, with a root span which is never finished nor reported
+func sample(tracer opentracing.Tracer, native propagator) bool {
+	span := tracer.StartSpan("sampling")
+
+	nativeHeader := http.Header{}
+	if err := tracer.Inject(span.Context(), opentracing.HTTPHeaders, HTTPHeadersCarrier(nativeHeader)); err != nil {
+		return true
+	}
+
+	traceContext, err := native.Extract(nativeHeader)
+	if err != nil {
+		return true
+	}
+	return traceContext.Sampled
+}
+
+// traceID returns the hexadecimal ID of the trace of a span context, read from its native format
+func (t *Tracing) traceID(sc opentracing.SpanContext) string {
+	if t.native == nil {
+		return ""
+	}
+
+	nativeHeader := http.Header{}
+	if err := t.tracer.Inject(sc, opentracing.HTTPHeaders, HTTPHeadersCarrier(nativeHeader)); err != nil {
+		return ""
+	}
+
+	traceContext, err := t.native.Extract(nativeHeader)
+	if err != nil {
+		return ""
+	}
+	return encodeTraceID(traceContext)
+}
+
+func propagatorFor(format string) propagator {
+	switch format {
+	case PropagationTraceContext:
+		return traceContextPropagator{}
+	case PropagationB3:
+		return b3Propagator{}
+	case PropagationB3Single:
+		return b3SinglePropagator{}
+	default:
+		return nil
+	}
+}
+
+// nativePropagator returns the propagator of the native format of the backend
+func (t *Tracing) nativePropagator() propagator {
+	switch t.Backend {
+	case jaeger.Name:
+		headerName := jaegercli.TraceContextHeaderName
+		if t.Jaeger != nil && len(t.Jaeger.TraceContextHeaderName) > 0 {
+			headerName = t.Jaeger.TraceContextHeaderName
+		}
+		return jaegerPropagator{headerName: headerName}
+	case zipkin.Name:
+		return b3Propagator{}
+	case datadog.Name:
+		prop := datadogPropagator{
+			traceIDHeader:  ddtracer.DefaultTraceIDHeader,
+			parentIDHeader: ddtracer.DefaultParentIDHeader,
+			priorityHeader: ddtracer.DefaultPriorityHeader,
+		}
+		if t.DataDog != nil {
+			if len(t.DataDog.TraceIDHeaderName) > 0 {
+				prop.traceIDHeader = t.DataDog.TraceIDHeaderName
+			}
+			if len(t.DataDog.ParentIDHeaderName) > 0 {
+				prop.parentIDHeader = t.DataDog.ParentIDHeaderName
+			}
+			if len(t.DataDog.SamplingPriorityHeaderName) > 0 {
+				prop.priorityHeader = t.DataDog.SamplingPriorityHeaderName
+			}
+		}
+		return prop
+	case otlp.Name:
+		return traceContextPropagator{}
+	default:
+		return nil
+	}
+}
+
+// traceContextPropagator implements the W3C Trace Context format, see https://www.w3.org/TR/trace-context/
+type traceContextPropagator struct{}
+
+func (traceContextPropagator) Extract(header http.Header) (*TraceContext, error) {
+	value := header.Get(tracecontext.TraceParentHeader)
+	if len(value) == 0 {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+
+	parent, err := tracecontext.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TraceContext{
+		TraceID:    parent.TraceID,
+		SpanID:     parent.SpanID,
+		Sampled:    parent.Sampled,
+		TraceState: header.Get(tracecontext.TraceStateHeader),
+	}, nil
+}
+
+// Inject writes the contexts without sampling decision as sampled, the format always carries a decision
+func (traceContextPropagator) Inject(ctx *TraceContext, header http.Header) {
+	parent := tracecontext.TraceParent{TraceID: ctx.TraceID, SpanID: ctx.SpanID, Sampled: ctx.Sampled || ctx.Deferred}
+	header.Set(tracecontext.TraceParentHeader, parent.String())
+	if len(ctx.TraceState) > 0 {
+		header.Set(tracecontext.TraceStateHeader, ctx.TraceState)
+	}
+}
+
+// b3Propagator implements the B3 format with multiple headers, see https://github.com/openzipkin/b3-propagation
+type b3Propagator struct{}
+
+func (b3Propagator) Extract(header http.Header) (*TraceContext, error) {
+	traceID := header.Get(b3TraceIDHeader)
+	spanID := header.Get(b3SpanIDHeader)
+	if len(traceID) == 0 && len(spanID) == 0 {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+
+	ctx := &TraceContext{}
+	if !decodeTraceID(ctx, traceID) || !tracecontext.DecodeID(ctx.SpanID[:], spanID) {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+
+	sampled := header.Get(b3SampledHeader)
+	if header.Get(b3FlagsHeader) == "1" {
+		sampled = "d"
+	}
+	parseB3Sampled(ctx, sampled)
+
+	return ctx, nil
+}
+
+func (b3Propagator) Inject(ctx *TraceContext, header http.Header) {
+	header.Set(b3TraceIDHeader, encodeTraceID(ctx))
+	header.Set(b3SpanIDHeader, hex.EncodeToString(ctx.SpanID[:]))
+	header.Del(b3ParentSpanIDHeader)
+	switch {
+	case ctx.Deferred:
+		header.Del(b3SampledHeader)
+	case ctx.Sampled:
+		header.Set(b3SampledHeader, "1")
+	default:
+		header.Set(b3SampledHeader, "0")
+	}
+}
+
+// b3SinglePropagator implements the B3 format with a single header: {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}
+type b3SinglePropagator struct{}
+
+func (b3SinglePropagator) Extract(header http.Header) (*TraceContext, error) {
+	value := header.Get(b3SingleHeader)
+	if len(value) == 0 {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+
+	parts := strings.Split(value, "-")
+	// a single sampling state is a sampling decision without trace context
+	if len(parts) < 2 {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+	if len(parts) > 4 {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+
+	ctx := &TraceContext{}
+	if !decodeTraceID(ctx, parts[0]) || !tracecontext.DecodeID(ctx.SpanID[:], parts[1]) {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+
+	var sampled string
+	if len(parts) > 2 {
+		sampled = parts[2]
+	}
+	parseB3Sampled(ctx, sampled)
+
+	return ctx, nil
+}
+
+func (b3SinglePropagator) Inject(ctx *TraceContext, header http.Header) {
+	value := fmt.Sprintf("%s-%x", encodeTraceID(ctx), ctx.SpanID[:])
+	switch {
+	case ctx.Deferred:
+	case ctx.Sampled:
+		value += "-1"
+	default:
+		value += "-0"
+	}
+	header.Set(b3SingleHeader, value)
+}
+
+// jaegerPropagator implements the Jaeger format: {trace-id}:{span-id}:{parent-span-id}:{flags}
+type jaegerPropagator struct {
+	headerName string
+}
+
+func (p jaegerPropagator) Extract(header http.Header) (*TraceContext, error) {
+	value := header.Get(p.headerName)
+	if len(value) == 0 {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		value = unescaped
+	}
+
+	sc, err := jaegercli.ContextFromString(value)
+	if err != nil {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+
+	ctx := &TraceContext{Sampled: sc.IsSampled()}
+	binary.BigEndian.PutUint64(ctx.TraceID[:8], sc.TraceID().High)
+	binary.BigEndian.PutUint64(ctx.TraceID[8:], sc.TraceID().Low)
+	binary.BigEndian.PutUint64(ctx.SpanID[:], uint64(sc.SpanID()))
+
+	return ctx, nil
+}
+
+func (p jaegerPropagator) Inject(ctx *TraceContext, header http.Header) {
+	flags := 0
+	if ctx.Sampled {
+		flags = 1
+	}
+
+	traceID := jaegercli.TraceID{
+		High: binary.BigEndian.Uint64(ctx.TraceID[:8]),
+		Low:  binary.BigEndian.Uint64(ctx.TraceID[8:]),
+	}
+	header.Set(p.headerName, fmt.Sprintf("%s:%x:0:%d", traceID, binary.BigEndian.Uint64(ctx.SpanID[:]), flags))
+}
+
+// datadogPropagator implements the DataDog format, the identifiers are 64 bits decimal numbers.
+// The higher half of the 128 bits trace IDs is lost.
+type datadogPropagator struct {
+	traceIDHeader  string
+	parentIDHeader string
+	priorityHeader string
+}
+
+func (p datadogPropagator) Extract(header http.Header) (*TraceContext, error) {
+	traceID := header.Get(p.traceIDHeader)
+	parentID := header.Get(p.parentIDHeader)
+	if len(traceID) == 0 && len(parentID) == 0 {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+
+	tid, err := strconv.ParseUint(traceID, 10, 64)
+	if err != nil || tid == 0 {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	pid, err := strconv.ParseUint(parentID, 10, 64)
+	if err != nil || pid == 0 {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+
+	ctx := &TraceContext{Sampled: true, Deferred: true}
+	binary.BigEndian.PutUint64(ctx.TraceID[8:], tid)
+	binary.BigEndian.PutUint64(ctx.SpanID[:], pid)
+
+	if priority := header.Get(p.priorityHeader); len(priority) > 0 {
+		value, err := strconv.Atoi(priority)
+		if err != nil {
+			return nil, opentracing.ErrSpanContextCorrupted
+		}
+		ctx.Sampled = value > 0
+		ctx.Deferred = false
+	}
+
+	return ctx, nil
+}
+
+func (p datadogPropagator) Inject(ctx *TraceContext, header http.Header) {
+	header.Set(p.traceIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(ctx.TraceID[8:]), 10))
+	header.Set(p.parentIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(ctx.SpanID[:]), 10))
+	switch {
+	case ctx.Deferred:
+		header.Del(p.priorityHeader)
+	case ctx.Sampled:
+		header.Set(p.priorityHeader, "1")
+	default:
+		header.Set(p.priorityHeader, "0")
+	}
+}
+
+// parseB3Sampled reads the B3 sampling state, the sampling decision is deferred to the sampler without state
+func parseB3Sampled(ctx *TraceContext, value string) {
+	switch value {
+	case "":
+		ctx.Deferred = true
+	case "1", "true", "d":
+		ctx.Sampled = true
+	}
+}
+
+// decodeTraceID decodes a 64 or 128 bits hexadecimal trace ID
+func decodeTraceID(ctx *TraceContext, value string) bool {
+	if len(value) == 16 {
+		return tracecontext.DecodeID(ctx.TraceID[8:], value)
+	}
+	return tracecontext.DecodeID(ctx.TraceID[:], value)
+}
+
+// encodeTraceID encodes the trace ID on 64 bits when the higher half is empty
+func encodeTraceID(ctx *TraceContext) string {
+	if binary.BigEndian.Uint64(ctx.TraceID[:8]) == 0 {
+		return hex.EncodeToString(ctx.TraceID[8:])
+	}
+	return hex.EncodeToString(ctx.TraceID[:])
+}
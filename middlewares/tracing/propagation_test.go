@@ -0,0 +1,366 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jaegercli "github.com/uber/jaeger-client-go"
+)
+
+func TestPropagatorExtract(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		propagator  propagator
+		header      map[string]string
+		expected    *TraceContext
+		expectedErr error
+	}{
+		{
+			desc:       "tracecontext",
+			propagator: traceContextPropagator{},
+			header: map[string]string{
+				"traceparent": "00-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-01",
+				"tracestate":  "congo=t61rcWkgMzE",
+			},
+			expected: &TraceContext{
+				TraceID:    [16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x00, 0xf7, 0xb7},
+				SpanID:     [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+				Sampled:    true,
+				TraceState: "congo=t61rcWkgMzE",
+			},
+		},
+		{
+			desc:        "tracecontext with zero span ID",
+			propagator:  traceContextPropagator{},
+			header:      map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c00f7b7-0000000000000000-01"},
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc:        "tracecontext missing",
+			propagator:  traceContextPropagator{},
+			expectedErr: opentracing.ErrSpanContextNotFound,
+		},
+		{
+			desc:       "b3 with 64 bits trace ID",
+			propagator: b3Propagator{},
+			header: map[string]string{
+				"X-B3-TraceId": "8448eb211c00f7b7",
+				"X-B3-SpanId":  "b7ad6b7169203331",
+				"X-B3-Sampled": "1",
+			},
+			expected: &TraceContext{
+				TraceID: [16]byte{8: 0x84, 9: 0x48, 10: 0xeb, 11: 0x21, 12: 0x1c, 13: 0x00, 14: 0xf7, 15: 0xb7},
+				SpanID:  [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+				Sampled: true,
+			},
+		},
+		{
+			desc:       "b3 debug flag",
+			propagator: b3Propagator{},
+			header: map[string]string{
+				"X-B3-TraceId": "0af7651916cd43dd8448eb211c00f7b7",
+				"X-B3-SpanId":  "b7ad6b7169203331",
+				"X-B3-Flags":   "1",
+			},
+			expected: &TraceContext{
+				TraceID: [16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x00, 0xf7, 0xb7},
+				SpanID:  [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+				Sampled: true,
+			},
+		},
+		{
+			desc:       "b3 without sampling state",
+			propagator: b3Propagator{},
+			header: map[string]string{
+				"X-B3-TraceId": "0af7651916cd43dd8448eb211c00f7b7",
+				"X-B3-SpanId":  "b7ad6b7169203331",
+			},
+			expected: &TraceContext{
+				TraceID:  [16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x00, 0xf7, 0xb7},
+				SpanID:   [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+				Deferred: true,
+			},
+		},
+		{
+			desc:       "b3 missing span ID",
+			propagator: b3Propagator{},
+			header: map[string]string{
+				"X-B3-TraceId": "0af7651916cd43dd8448eb211c00f7b7",
+			},
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc:       "b3single not sampled",
+			propagator: b3SinglePropagator{},
+			header:     map[string]string{"b3": "0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-0-05e3ac9a4f6e3b90"},
+			expected: &TraceContext{
+				TraceID: [16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x00, 0xf7, 0xb7},
+				SpanID:  [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+			},
+		},
+		{
+			desc:       "b3single debug",
+			propagator: b3SinglePropagator{},
+			header:     map[string]string{"b3": "8448eb211c00f7b7-b7ad6b7169203331-d"},
+			expected: &TraceContext{
+				TraceID: [16]byte{8: 0x84, 9: 0x48, 10: 0xeb, 11: 0x21, 12: 0x1c, 13: 0x00, 14: 0xf7, 15: 0xb7},
+				SpanID:  [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+				Sampled: true,
+			},
+		},
+		{
+			desc:       "b3single without sampling state",
+			propagator: b3SinglePropagator{},
+			header:     map[string]string{"b3": "0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331"},
+			expected: &TraceContext{
+				TraceID:  [16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x00, 0xf7, 0xb7},
+				SpanID:   [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+				Deferred: true,
+			},
+		},
+		{
+			desc:        "b3single sampling decision only",
+			propagator:  b3SinglePropagator{},
+			header:      map[string]string{"b3": "0"},
+			expectedErr: opentracing.ErrSpanContextNotFound,
+		},
+		{
+			desc:       "jaeger",
+			propagator: jaegerPropagator{headerName: "uber-trace-id"},
+			header:     map[string]string{"uber-trace-id": "8448eb211c00f7b7%3Ab7ad6b7169203331%3A0%3A1"},
+			expected: &TraceContext{
+				TraceID: [16]byte{8: 0x84, 9: 0x48, 10: 0xeb, 11: 0x21, 12: 0x1c, 13: 0x00, 14: 0xf7, 15: 0xb7},
+				SpanID:  [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+				Sampled: true,
+			},
+		},
+		{
+			desc:       "datadog",
+			propagator: datadogPropagator{traceIDHeader: "x-datadog-trace-id", parentIDHeader: "x-datadog-parent-id", priorityHeader: "x-datadog-sampling-priority"},
+			header: map[string]string{
+				"x-datadog-trace-id":          "9532127138765928375",
+				"x-datadog-parent-id":         "13235353014750950193",
+				"x-datadog-sampling-priority": "0",
+			},
+			expected: &TraceContext{
+				TraceID: [16]byte{8: 0x84, 9: 0x48, 10: 0xeb, 11: 0x21, 12: 0x1c, 13: 0x00, 14: 0xf7, 15: 0xb7},
+				SpanID:  [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{}
+			for key, value := range test.header {
+				header.Set(key, value)
+			}
+
+			ctx, err := test.propagator.Extract(header)
+			if test.expectedErr != nil {
+				assert.Equal(t, test.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ctx)
+		})
+	}
+}
+
+func TestPropagatorInjectExtract(t *testing.T) {
+	contexts := map[string]*TraceContext{
+		"128 bits trace ID": {
+			TraceID: [16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x00, 0xf7, 0xb7},
+			SpanID:  [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+			Sampled: true,
+		},
+		"64 bits trace ID not sampled": {
+			TraceID: [16]byte{8: 0x84, 9: 0x48, 10: 0xeb, 11: 0x21, 12: 0x1c, 13: 0x00, 14: 0xf7, 15: 0xb7},
+			SpanID:  [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+		},
+	}
+
+	deferred := &TraceContext{
+		TraceID:  [16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x00, 0xf7, 0xb7},
+		SpanID:   [8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+		Deferred: true,
+	}
+
+	propagators := map[string]propagator{
+		PropagationTraceContext: traceContextPropagator{},
+		PropagationB3:           b3Propagator{},
+		PropagationB3Single:     b3SinglePropagator{},
+		"jaeger":                jaegerPropagator{headerName: "uber-trace-id"},
+	}
+
+	for name, prop := range propagators {
+		for desc, ctx := range contexts {
+			header := http.Header{}
+			prop.Inject(ctx, header)
+
+			extracted, err := prop.Extract(header)
+			require.NoError(t, err, "%s: %s", name, desc)
+			assert.Equal(t, ctx, extracted, "%s: %s", name, desc)
+		}
+	}
+
+	// the B3 formats carry the contexts without sampling decision
+	for name, prop := range map[string]propagator{PropagationB3: b3Propagator{}, PropagationB3Single: b3SinglePropagator{}} {
+		header := http.Header{}
+		prop.Inject(deferred, header)
+
+		extracted, err := prop.Extract(header)
+		require.NoError(t, err, name)
+		assert.Equal(t, deferred, extracted, name)
+	}
+}
+
+func TestValidatePropagation(t *testing.T) {
+	assert.NoError(t, ValidatePropagation(nil))
+	assert.NoError(t, ValidatePropagation([]string{PropagationTracer, PropagationTraceContext, PropagationB3, PropagationB3Single}))
+	assert.Error(t, ValidatePropagation([]string{"xray"}))
+}
+
+func TestPropagationIndependentOfBackend(t *testing.T) {
+	tracer, closer := jaegercli.NewTracer("traefik", jaegercli.NewConstSampler(true), jaegercli.NewNullReporter())
+	defer closer.Close()
+
+	tracing := &Tracing{
+		Backend:     "jaeger",
+		ServiceName: "traefik",
+		tracer:      tracer,
+	}
+	tracing.propagation = newPropagation([]string{PropagationB3Single, PropagationTraceContext, PropagationTracer}, tracing.nativePropagator())
+
+	var forwarded http.Header
+	next := func(rw http.ResponseWriter, req *http.Request) {
+		InjectRequestHeaders(req)
+		forwarded = req.Header
+	}
+
+	// the browser uses the W3C trace context, the backend is Jaeger
+	req := httptest.NewRequest(http.MethodGet, "http://www.test.com/", nil)
+	req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-01")
+
+	tracing.NewEntryPoint("http").ServeHTTP(httptest.NewRecorder(), req, next)
+
+	require.NotNil(t, forwarded)
+	assert.Regexp(t, `^00-0af7651916cd43dd8448eb211c00f7b7-[0-9a-f]{16}-01$`, forwarded.Get("traceparent"))
+	assert.Regexp(t, `^0af7651916cd43dd8448eb211c00f7b7-[0-9a-f]{16}-1$`, forwarded.Get("b3"))
+	assert.Regexp(t, `^af7651916cd43dd8448eb211c00f7b7(%3A|:)[0-9a-f]+(%3A|:)b7ad6b7169203331(%3A|:)1$`, forwarded.Get("uber-trace-id"))
+
+	// the mesh sidecar uses B3 single
+	forwarded = nil
+	req = httptest.NewRequest(http.MethodGet, "http://www.test.com/", nil)
+	req.Header.Set("b3", "8448eb211c00f7b7-b7ad6b7169203331-1")
+
+	tracing.NewEntryPoint("http").ServeHTTP(httptest.NewRecorder(), req, next)
+
+	require.NotNil(t, forwarded)
+	assert.Regexp(t, `^00-00000000000000008448eb211c00f7b7-[0-9a-f]{16}-01$`, forwarded.Get("traceparent"))
+}
+
+func TestPropagationDefersSamplingToTracer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		header   map[string]string
+		sampled  bool
+		expected string
+	}{
+		{
+			desc:     "b3 sampled by the sampler",
+			header:   map[string]string{"X-B3-TraceId": "8448eb211c00f7b7", "X-B3-SpanId": "b7ad6b7169203331"},
+			sampled:  true,
+			expected: "1",
+		},
+		{
+			desc:     "b3 not sampled by the sampler",
+			header:   map[string]string{"X-B3-TraceId": "8448eb211c00f7b7", "X-B3-SpanId": "b7ad6b7169203331"},
+			expected: "0",
+		},
+		{
+			desc:     "b3single sampled by the sampler",
+			header:   map[string]string{"b3": "8448eb211c00f7b7-b7ad6b7169203331"},
+			sampled:  true,
+			expected: "1",
+		},
+		{
+			desc:     "b3single not sampled by the sampler",
+			header:   map[string]string{"b3": "8448eb211c00f7b7-b7ad6b7169203331"},
+			expected: "0",
+		},
+		{
+			desc:     "b3single sampling decision wins over the sampler",
+			header:   map[string]string{"b3": "8448eb211c00f7b7-b7ad6b7169203331-1"},
+			expected: "1",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tracer, closer := jaegercli.NewTracer("traefik", jaegercli.NewConstSampler(test.sampled), jaegercli.NewNullReporter())
+			defer closer.Close()
+
+			tracing := &Tracing{
+				Backend:     "jaeger",
+				ServiceName: "traefik",
+				tracer:      tracer,
+			}
+			tracing.propagation = newPropagation([]string{PropagationB3, PropagationB3Single}, tracing.nativePropagator())
+
+			header := http.Header{}
+			for key, value := range test.header {
+				header.Set(key, value)
+			}
+
+			sc, err := tracing.extractRequest(header)
+			require.NoError(t, err)
+
+			injected := http.Header{}
+			require.NoError(t, tracing.propagation.inject(tracer, sc, injected))
+			assert.Equal(t, test.expected, injected.Get("X-B3-Sampled"))
+		})
+	}
+}
+
+func TestGetTraceID(t *testing.T) {
+	tracer, closer := jaegercli.NewTracer("traefik", jaegercli.NewConstSampler(true), jaegercli.NewNullReporter())
+	defer closer.Close()
+
+	tracing := &Tracing{
+		Backend:     "jaeger",
+		ServiceName: "traefik",
+		tracer:      tracer,
+	}
+	tracing.native = tracing.nativePropagator()
+	tracing.propagation = newPropagation([]string{PropagationTraceContext}, tracing.native)
+
+	var traceID string
+	next := func(rw http.ResponseWriter, req *http.Request) {
+		traceID = GetTraceID(req)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://www.test.com/", nil)
+	req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c00f7b7-b7ad6b7169203331-01")
+	tracing.NewEntryPoint("http").ServeHTTP(httptest.NewRecorder(), req, next)
+
+	assert.Equal(t, "0af7651916cd43dd8448eb211c00f7b7", traceID)
+
+	// a new trace is started without an incoming trace context
+	req = httptest.NewRequest(http.MethodGet, "http://www.test.com/", nil)
+	tracing.NewEntryPoint("http").ServeHTTP(httptest.NewRecorder(), req, next)
+
+	assert.Regexp(t, `^[0-9a-f]{16,32}$`, traceID)
+	assert.NotEqual(t, "0af7651916cd43dd8448eb211c00f7b7", traceID)
+}
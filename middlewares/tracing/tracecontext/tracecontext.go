@@ -0,0 +1,81 @@
+// Package tracecontext parses and formats the W3C Trace Context headers, see https://www.w3.org/TR/trace-context/
+package tracecontext
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// W3C trace context headers
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+const (
+	version     = "00"
+	sampledFlag = 0x01
+)
+
+// TraceParent is the value of a traceparent header
+type TraceParent struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// Parse parses a traceparent header value: version-traceid-spanid-flags
+func Parse(value string) (TraceParent, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return TraceParent{}, opentracing.ErrSpanContextCorrupted
+	}
+
+	// future versions may append fields, the version 00 has exactly 4 fields
+	if parts[0] == version && len(parts) != 4 {
+		return TraceParent{}, opentracing.ErrSpanContextCorrupted
+	}
+
+	parent := TraceParent{}
+	if !DecodeID(parent.TraceID[:], parts[1]) || !DecodeID(parent.SpanID[:], parts[2]) {
+		return TraceParent{}, opentracing.ErrSpanContextCorrupted
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return TraceParent{}, opentracing.ErrSpanContextCorrupted
+	}
+	parent.Sampled = flags[0]&sampledFlag == sampledFlag
+
+	return parent, nil
+}
+
+// String formats the traceparent header value in the version 00
+func (p TraceParent) String() string {
+	var flags byte
+	if p.Sampled {
+		flags |= sampledFlag
+	}
+	return fmt.Sprintf("%s-%x-%x-%02x", version, p.TraceID[:], p.SpanID[:], flags)
+}
+
+// DecodeID decodes a lower case hexadecimal identifier which must not be all zeros
+func DecodeID(dst []byte, value string) bool {
+	if len(value) != hex.EncodedLen(len(dst)) || strings.ToLower(value) != value {
+		return false
+	}
+
+	if _, err := hex.Decode(dst, []byte(value)); err != nil {
+		return false
+	}
+
+	for _, b := range dst {
+		if b != 0 {
+			return true
+		}
+	}
+	return false
+}
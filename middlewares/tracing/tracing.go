@@ -9,11 +9,14 @@ import (
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares/tracing/datadog"
 	"github.com/containous/traefik/middlewares/tracing/jaeger"
+	"github.com/containous/traefik/middlewares/tracing/otlp"
 	"github.com/containous/traefik/middlewares/tracing/zipkin"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 )
 
+var logger = log.WithModule("middlewares/tracing")
+
 // ForwardMaxLengthNumber defines the number of static characters in the Forwarding Span Trace name : 8 chars for 'forward ' + 8 chars for hash + 2 chars for '_'.
 const ForwardMaxLengthNumber = 18
 
@@ -23,17 +26,30 @@ const EntryPointMaxLengthNumber = 21
 // TraceNameHashLength defines the number of characters to use from the head of the generated hash.
 const TraceNameHashLength = 8
 
+type contextKey string
+
+const (
+	// traceIDKey is the key of the trace ID in the context of the requests
+	traceIDKey contextKey = "TraceID"
+	// tracingKey is the key of the tracing of the entry point in the context of the requests
+	tracingKey contextKey = "Tracing"
+)
+
 // Tracing middleware
 type Tracing struct {
-	Backend       string          `description:"Selects the tracking backend ('jaeger','zipkin', 'datadog')." export:"true"`
+	Backend       string          `description:"Selects the tracking backend ('jaeger','zipkin', 'datadog', 'otlp')." export:"true"`
 	ServiceName   string          `description:"Set the name for this service" export:"true"`
 	SpanNameLimit int             `description:"Set the maximum character limit for Span names (default 0 = no limit)" export:"true"`
 	Jaeger        *jaeger.Config  `description:"Settings for jaeger"`
 	Zipkin        *zipkin.Config  `description:"Settings for zipkin"`
 	DataDog       *datadog.Config `description:"Settings for DataDog"`
+	OTLP          *otlp.Config    `description:"Settings for OpenTelemetry (OTLP)"`
+	Propagation   []string        `description:"Propagation formats of the trace context, extracted in order and all injected ('tracer', 'tracecontext', 'b3', 'b3single')" export:"true"`
 
-	tracer opentracing.Tracer
-	closer io.Closer
+	tracer      opentracing.Tracer
+	closer      io.Closer
+	propagation *propagation
+	native      propagator
 }
 
 // StartSpan delegates to opentracing.Tracer
@@ -51,6 +67,14 @@ func (t *Tracing) Extract(format interface{}, carrier interface{}) (opentracing.
 	return t.tracer.Extract(format, carrier)
 }
 
+// extractRequest extracts the span context from the request headers in the configured propagation formats
+func (t *Tracing) extractRequest(header http.Header) (opentracing.SpanContext, error) {
+	if t.propagation != nil {
+		return t.propagation.extract(t.tracer, header)
+	}
+	return t.Extract(opentracing.HTTPHeaders, HTTPHeadersCarrier(header))
+}
+
 // Backend describes things we can use to setup tracing
 type Backend interface {
 	Setup(serviceName string) (opentracing.Tracer, io.Closer, error)
@@ -67,14 +91,20 @@ func (t *Tracing) Setup() {
 		t.tracer, t.closer, err = t.Zipkin.Setup(t.ServiceName)
 	case datadog.Name:
 		t.tracer, t.closer, err = t.DataDog.Setup(t.ServiceName)
+	case otlp.Name:
+		t.tracer, t.closer, err = t.OTLP.Setup(t.ServiceName)
 	default:
-		log.Warnf("Unknown tracer %q", t.Backend)
+		logger.Warnf("Unknown tracer %q", t.Backend)
 		return
 	}
 
 	if err != nil {
-		log.Warnf("Could not initialize %s tracing: %v", t.Backend, err)
+		logger.Warnf("Could not initialize %s tracing: %v", t.Backend, err)
+		return
 	}
+
+	t.native = t.nativePropagator()
+	t.propagation = newPropagation(t.Propagation, t.native)
 }
 
 // IsEnabled determines if tracing was successfully activated
@@ -90,7 +120,7 @@ func (t *Tracing) Close() {
 	if t.closer != nil {
 		err := t.closer.Close()
 		if err != nil {
-			log.Warn(err)
+			logger.Warn(err)
 		}
 	}
 }
@@ -119,15 +149,27 @@ func GetSpan(r *http.Request) opentracing.Span {
 	return opentracing.SpanFromContext(r.Context())
 }
 
-// InjectRequestHeaders used to inject OpenTracing headers into the request
+// GetTraceID used to retrieve the hexadecimal ID of the trace of the request, set by the entry point middleware
+func GetTraceID(r *http.Request) string {
+	traceID, _ := r.Context().Value(traceIDKey).(string)
+	return traceID
+}
+
+// InjectRequestHeaders used to inject OpenTracing headers into the request,
+// in the propagation formats of the tracing of the entry point which started the trace
 func InjectRequestHeaders(r *http.Request) {
 	if span := GetSpan(r); span != nil {
-		err := opentracing.GlobalTracer().Inject(
-			span.Context(),
-			opentracing.HTTPHeaders,
-			HTTPHeadersCarrier(r.Header))
+		var err error
+		if t, ok := r.Context().Value(tracingKey).(*Tracing); ok && t.propagation != nil {
+			err = t.propagation.inject(t.tracer, span.Context(), r.Header)
+		} else {
+			err = opentracing.GlobalTracer().Inject(
+				span.Context(),
+				opentracing.HTTPHeaders,
+				HTTPHeadersCarrier(r.Header))
+		}
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 		}
 	}
 }
@@ -161,14 +203,14 @@ func SetError(r *http.Request) {
 // SetErrorAndDebugLog flags the span associated with this request as in error and create a debug log.
 func SetErrorAndDebugLog(r *http.Request, format string, args ...interface{}) {
 	SetError(r)
-	log.Debugf(format, args...)
+	logger.Debugf(format, args...)
 	LogEventf(r, format, args...)
 }
 
 // SetErrorAndWarnLog flags the span associated with this request as in error and create a debug log.
 func SetErrorAndWarnLog(r *http.Request, format string, args ...interface{}) {
 	SetError(r)
-	log.Warnf(format, args...)
+	logger.Warnf(format, args...)
 	LogEventf(r, format, args...)
 }
 
@@ -190,7 +232,7 @@ func computeHash(name string) string {
 	hash := sha256.New()
 	if _, err := hash.Write(data); err != nil {
 		// Impossible case
-		log.Errorf("Fail to create Span name hash for %s: %v", name, err)
+		logger.Errorf("Fail to create Span name hash for %s: %v", name, err)
 	}
 
 	return fmt.Sprintf("%x", hash.Sum(nil))[:TraceNameHashLength]
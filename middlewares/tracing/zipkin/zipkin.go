@@ -8,6 +8,8 @@ import (
 	zipkin "github.com/openzipkin-contrib/zipkin-go-opentracing"
 )
 
+var logger = log.WithModule("middlewares/tracing/zipkin")
+
 // Name sets the name of this tracer
 const Name = "zipkin"
 
@@ -40,7 +42,7 @@ func (c *Config) Setup(serviceName string) (opentracing.Tracer, io.Closer, error
 	// Without this, child spans are getting the NOOP tracer
 	opentracing.SetGlobalTracer(tracer)
 
-	log.Debug("Zipkin tracer configured")
+	logger.Debug("Zipkin tracer configured")
 
 	return tracer, collector, nil
 }
@@ -0,0 +1,279 @@
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/types"
+	"github.com/containous/traefik/version"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+var logger = log.WithModule("otlp")
+
+// Protocols of the OTLP exporter
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+)
+
+const (
+	// DefaultGRPCEndpoint is the default endpoint of an OpenTelemetry collector for the grpc protocol
+	DefaultGRPCEndpoint = "localhost:4317"
+	// DefaultHTTPEndpoint is the default endpoint of an OpenTelemetry collector for the http protocol
+	DefaultHTTPEndpoint = "http://localhost:4318"
+	// DefaultTimeout is the default timeout of the export requests
+	DefaultTimeout = 10 * time.Second
+
+	// TraceServiceExportMethod is the grpc method of the trace service
+	TraceServiceExportMethod = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+	// MetricsServiceExportMethod is the grpc method of the metrics service
+	MetricsServiceExportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+	// TracesPath is the path of the traces endpoint for the http protocol
+	TracesPath = "/v1/traces"
+	// MetricsPath is the path of the metrics endpoint for the http protocol
+	MetricsPath = "/v1/metrics"
+
+	scopeName                = "traefik"
+	serviceNameAttributeKey  = "service.name"
+	protobufContentType      = "application/x-protobuf"
+	maxErrorResponseBodySize = 1024
+)
+
+// Exporter sends the telemetry data to an OpenTelemetry collector
+type Exporter struct {
+	protocol string
+	endpoint string
+	headers  types.KeyValues
+	timeout  time.Duration
+
+	conn       *grpc.ClientConn
+	httpClient *http.Client
+}
+
+// NewExporter creates an Exporter from its configuration
+func NewExporter(config *types.OTLP) (*Exporter, error) {
+	exporter := &Exporter{
+		protocol: config.Protocol,
+		endpoint: config.Endpoint,
+		headers:  config.Headers,
+		timeout:  time.Duration(config.Timeout),
+	}
+
+	if exporter.timeout <= 0 {
+		exporter.timeout = DefaultTimeout
+	}
+
+	if len(exporter.protocol) == 0 {
+		exporter.protocol = ProtocolGRPC
+	}
+
+	var tlsConfig *tls.Config
+	if config.TLS != nil {
+		var err error
+		tlsConfig, err = config.TLS.CreateTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the TLS configuration: %v", err)
+		}
+	}
+
+	switch exporter.protocol {
+	case ProtocolGRPC:
+		if len(exporter.endpoint) == 0 {
+			exporter.endpoint = DefaultGRPCEndpoint
+		}
+
+		var dialOption grpc.DialOption
+		switch {
+		case config.Insecure:
+			dialOption = grpc.WithInsecure()
+		case tlsConfig != nil:
+			dialOption = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+		default:
+			dialOption = grpc.WithTransportCredentials(credentials.NewTLS(nil))
+		}
+
+		conn, err := grpc.Dial(exporter.endpoint, dialOption)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to %s: %v", exporter.endpoint, err)
+		}
+		exporter.conn = conn
+
+	case ProtocolHTTP:
+		if len(exporter.endpoint) == 0 {
+			exporter.endpoint = DefaultHTTPEndpoint
+		}
+		exporter.endpoint = strings.TrimSuffix(exporter.endpoint, "/")
+
+		transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+		if tlsConfig != nil {
+			transport.TLSClientConfig = tlsConfig
+		}
+		exporter.httpClient = &http.Client{Transport: transport}
+
+	default:
+		return nil, fmt.Errorf("unknown OTLP protocol %q, expected %q or %q", exporter.protocol, ProtocolGRPC, ProtocolHTTP)
+	}
+
+	logger.Debugf("OTLP exporter configured to send data to %s using %s", exporter.endpoint, exporter.protocol)
+
+	return exporter, nil
+}
+
+// ExportTraces sends the spans to the collector
+func (e *Exporter) ExportTraces(ctx context.Context, request *ExportTraceServiceRequest) error {
+	if e.protocol == ProtocolGRPC {
+		return e.invoke(ctx, TraceServiceExportMethod, request, &ExportTraceServiceResponse{})
+	}
+	return e.post(ctx, TracesPath, request)
+}
+
+// ExportMetrics sends the metrics to the collector
+func (e *Exporter) ExportMetrics(ctx context.Context, request *ExportMetricsServiceRequest) error {
+	if e.protocol == ProtocolGRPC {
+		return e.invoke(ctx, MetricsServiceExportMethod, request, &ExportMetricsServiceResponse{})
+	}
+	return e.post(ctx, MetricsPath, request)
+}
+
+// Close closes the connection to the collector
+func (e *Exporter) Close() error {
+	if e.conn != nil {
+		return e.conn.Close()
+	}
+	return nil
+}
+
+func (e *Exporter) invoke(ctx context.Context, method string, request, response proto.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	if len(e.headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(e.headers))
+	}
+
+	return e.conn.Invoke(ctx, method, request, response)
+}
+
+func (e *Exporter) post(ctx context.Context, path string, request proto.Message) error {
+	body, err := proto.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("unable to encode the request: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", protobufContentType)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorResponseBodySize))
+		return fmt.Errorf("unexpected response from %s: %s: %s", req.URL, resp.Status, message)
+	}
+
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+// NewResource creates the resource describing the Traefik instance
+func NewResource(serviceName string, attributes types.KeyValues) *Resource {
+	resource := &Resource{}
+
+	if _, ok := attributes[serviceNameAttributeKey]; !ok && len(serviceName) > 0 {
+		resource.Attributes = append(resource.Attributes, StringAttribute(serviceNameAttributeKey, serviceName))
+	}
+
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		resource.Attributes = append(resource.Attributes, StringAttribute(key, attributes[key]))
+	}
+
+	return resource
+}
+
+// NewInstrumentationScope returns the instrumentation scope of the data produced by Traefik
+func NewInstrumentationScope() *InstrumentationScope {
+	return &InstrumentationScope{Name: scopeName, Version: version.Version}
+}
+
+// StringAttribute creates a string attribute
+func StringAttribute(key, value string) *KeyValue {
+	return &KeyValue{Key: key, Value: &AnyValue{StringValue: &value}}
+}
+
+// Attribute creates an attribute from a value of any type
+func Attribute(key string, value interface{}) *KeyValue {
+	anyValue := &AnyValue{}
+
+	switch v := value.(type) {
+	case string:
+		anyValue.StringValue = &v
+	case bool:
+		anyValue.BoolValue = &v
+	case int:
+		i := int64(v)
+		anyValue.IntValue = &i
+	case int8:
+		i := int64(v)
+		anyValue.IntValue = &i
+	case int16:
+		i := int64(v)
+		anyValue.IntValue = &i
+	case int32:
+		i := int64(v)
+		anyValue.IntValue = &i
+	case int64:
+		anyValue.IntValue = &v
+	case uint8:
+		i := int64(v)
+		anyValue.IntValue = &i
+	case uint16:
+		i := int64(v)
+		anyValue.IntValue = &i
+	case uint32:
+		i := int64(v)
+		anyValue.IntValue = &i
+	case float32:
+		f := float64(v)
+		anyValue.DoubleValue = &f
+	case float64:
+		anyValue.DoubleValue = &v
+	default:
+		s := fmt.Sprint(v)
+		anyValue.StringValue = &s
+	}
+
+	return &KeyValue{Key: key, Value: anyValue}
+}
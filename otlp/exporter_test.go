@@ -0,0 +1,250 @@
+package otlp
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containous/traefik/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// receiver is an in-process OTLP receiver for the grpc protocol
+type receiver struct {
+	traces  chan *ExportTraceServiceRequest
+	metrics chan *ExportMetricsServiceRequest
+	headers chan metadata.MD
+}
+
+func startReceiver(t *testing.T) (*receiver, *grpc.Server, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	r := &receiver{
+		traces:  make(chan *ExportTraceServiceRequest, 1),
+		metrics: make(chan *ExportMetricsServiceRequest, 1),
+		headers: make(chan metadata.MD, 2),
+	}
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "opentelemetry.proto.collector.trace.v1.TraceService",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Export",
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				request := &ExportTraceServiceRequest{}
+				if err := dec(request); err != nil {
+					return nil, err
+				}
+				md, _ := metadata.FromIncomingContext(ctx)
+				r.headers <- md
+				r.traces <- request
+				return &ExportTraceServiceResponse{}, nil
+			},
+		}},
+	}, r)
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "opentelemetry.proto.collector.metrics.v1.MetricsService",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Export",
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				request := &ExportMetricsServiceRequest{}
+				if err := dec(request); err != nil {
+					return nil, err
+				}
+				md, _ := metadata.FromIncomingContext(ctx)
+				r.headers <- md
+				r.metrics <- request
+				return &ExportMetricsServiceResponse{}, nil
+			},
+		}},
+	}, r)
+
+	go server.Serve(listener)
+
+	return r, server, listener.Addr().String()
+}
+
+func newTraceRequest() *ExportTraceServiceRequest {
+	return &ExportTraceServiceRequest{
+		ResourceSpans: []*ResourceSpans{{
+			Resource: NewResource("traefik", nil),
+			ScopeSpans: []*ScopeSpans{{
+				Scope: NewInstrumentationScope(),
+				Spans: []*Span{{
+					TraceID:           []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+					SpanID:            []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					Name:              "Entrypoint http",
+					Kind:              SpanKindServer,
+					StartTimeUnixNano: 1,
+					EndTimeUnixNano:   2,
+					Attributes: []*KeyValue{
+						Attribute("http.method", "GET"),
+						Attribute("http.status_code", uint16(200)),
+						Attribute("error", false),
+					},
+					Status: &Status{Code: StatusCodeOk},
+				}},
+			}},
+		}},
+	}
+}
+
+func newMetricsRequest() *ExportMetricsServiceRequest {
+	sum := 1.5
+	return &ExportMetricsServiceRequest{
+		ResourceMetrics: []*ResourceMetrics{{
+			Resource: NewResource("traefik", nil),
+			ScopeMetrics: []*ScopeMetrics{{
+				Scope: NewInstrumentationScope(),
+				Metrics: []*Metric{{
+					Name: "traefik.entrypoint.request.duration",
+					Histogram: &Histogram{
+						AggregationTemporality: AggregationTemporalityCumulative,
+						DataPoints: []*HistogramDataPoint{{
+							Count:          2,
+							Sum:            &sum,
+							BucketCounts:   []uint64{1, 1, 0},
+							ExplicitBounds: []float64{0.1, 1},
+							Attributes:     []*KeyValue{StringAttribute("entrypoint", "http")},
+						}},
+					},
+				}},
+			}},
+		}},
+	}
+}
+
+func TestExporterGRPC(t *testing.T) {
+	r, server, address := startReceiver(t)
+	defer server.Stop()
+
+	exporter, err := NewExporter(&types.OTLP{
+		Protocol: ProtocolGRPC,
+		Endpoint: address,
+		Insecure: true,
+		Headers:  types.KeyValues{"authorization": "Bearer token"},
+	})
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	traces := newTraceRequest()
+	require.NoError(t, exporter.ExportTraces(context.Background(), traces))
+	assert.Equal(t, traces, <-r.traces)
+	assert.Equal(t, []string{"Bearer token"}, (<-r.headers).Get("authorization"))
+
+	metrics := newMetricsRequest()
+	require.NoError(t, exporter.ExportMetrics(context.Background(), metrics))
+	assert.Equal(t, metrics, <-r.metrics)
+}
+
+func TestExporterHTTP(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests <- req
+		bodies <- body
+	}))
+	defer server.Close()
+
+	exporter, err := NewExporter(&types.OTLP{
+		Protocol: ProtocolHTTP,
+		Endpoint: server.URL + "/",
+		Headers:  types.KeyValues{"Authorization": "Bearer token"},
+	})
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	traces := newTraceRequest()
+	require.NoError(t, exporter.ExportTraces(context.Background(), traces))
+
+	req := <-requests
+	assert.Equal(t, TracesPath, req.URL.Path)
+	assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+
+	received := &ExportTraceServiceRequest{}
+	require.NoError(t, proto.Unmarshal(<-bodies, received))
+	assert.Equal(t, traces, received)
+
+	metrics := newMetricsRequest()
+	require.NoError(t, exporter.ExportMetrics(context.Background(), metrics))
+	assert.Equal(t, MetricsPath, (<-requests).URL.Path)
+
+	receivedMetrics := &ExportMetricsServiceRequest{}
+	require.NoError(t, proto.Unmarshal(<-bodies, receivedMetrics))
+	assert.Equal(t, metrics, receivedMetrics)
+}
+
+func TestExporterHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "invalid request", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter, err := NewExporter(&types.OTLP{Protocol: ProtocolHTTP, Endpoint: server.URL})
+	require.NoError(t, err)
+
+	err = exporter.ExportTraces(context.Background(), newTraceRequest())
+	assert.EqualError(t, err, "unexpected response from "+server.URL+TracesPath+": 400 Bad Request: invalid request\n")
+}
+
+func TestNewExporterUnknownProtocol(t *testing.T) {
+	_, err := NewExporter(&types.OTLP{Protocol: "udp"})
+	assert.Error(t, err)
+}
+
+func TestNewResource(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		serviceName        string
+		attributes         types.KeyValues
+		expectedAttributes []*KeyValue
+	}{
+		{
+			desc:               "service name",
+			serviceName:        "traefik",
+			expectedAttributes: []*KeyValue{StringAttribute("service.name", "traefik")},
+		},
+		{
+			desc:        "sorted attributes",
+			serviceName: "traefik",
+			attributes:  types.KeyValues{"deployment.environment": "production", "cloud.region": "eu-west-1"},
+			expectedAttributes: []*KeyValue{
+				StringAttribute("service.name", "traefik"),
+				StringAttribute("cloud.region", "eu-west-1"),
+				StringAttribute("deployment.environment", "production"),
+			},
+		},
+		{
+			desc:               "service name overridden by the attributes",
+			serviceName:        "traefik",
+			attributes:         types.KeyValues{"service.name": "edge"},
+			expectedAttributes: []*KeyValue{StringAttribute("service.name", "edge")},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			resource := NewResource(test.serviceName, test.attributes)
+			assert.Equal(t, test.expectedAttributes, resource.Attributes)
+		})
+	}
+}
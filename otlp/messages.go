@@ -0,0 +1,216 @@
+package otlp
+
+import "github.com/golang/protobuf/proto"
+
+// This file holds the subset of the OpenTelemetry protocol messages (opentelemetry-proto v1.0.0) used by Traefik.
+// The messages are encoded by the reflection based golang/protobuf marshaler, the oneof fields are represented
+// as optional fields which are encoded the same way on the wire.
+
+// SpanKind is the type of a span
+type SpanKind int32
+
+// Span kinds
+const (
+	SpanKindUnspecified SpanKind = 0
+	SpanKindInternal    SpanKind = 1
+	SpanKindServer      SpanKind = 2
+	SpanKindClient      SpanKind = 3
+	SpanKindProducer    SpanKind = 4
+	SpanKindConsumer    SpanKind = 5
+)
+
+// StatusCode is the status of a span
+type StatusCode int32
+
+// Status codes
+const (
+	StatusCodeUnset StatusCode = 0
+	StatusCodeOk    StatusCode = 1
+	StatusCodeError StatusCode = 2
+)
+
+// AggregationTemporality defines how a metric aggregator reports aggregated values
+type AggregationTemporality int32
+
+// Aggregation temporalities
+const (
+	AggregationTemporalityUnspecified AggregationTemporality = 0
+	AggregationTemporalityDelta       AggregationTemporality = 1
+	AggregationTemporalityCumulative  AggregationTemporality = 2
+)
+
+// AnyValue is used to represent any type of attribute value, only one of the fields is set
+type AnyValue struct {
+	StringValue *string  `protobuf:"bytes,1,opt,name=string_value"`
+	BoolValue   *bool    `protobuf:"varint,2,opt,name=bool_value"`
+	IntValue    *int64   `protobuf:"varint,3,opt,name=int_value"`
+	DoubleValue *float64 `protobuf:"fixed64,4,opt,name=double_value"`
+}
+
+// KeyValue is a key-value pair that is used to store Span attributes, Resource attributes, etc.
+type KeyValue struct {
+	Key   string    `protobuf:"bytes,1,opt,name=key,proto3"`
+	Value *AnyValue `protobuf:"bytes,2,opt,name=value,proto3"`
+}
+
+// InstrumentationScope is a message representing the instrumentation scope information
+type InstrumentationScope struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3"`
+}
+
+// Resource information
+type Resource struct {
+	Attributes []*KeyValue `protobuf:"bytes,1,rep,name=attributes,proto3"`
+}
+
+// ExportTraceServiceRequest is the request of the trace service
+type ExportTraceServiceRequest struct {
+	ResourceSpans []*ResourceSpans `protobuf:"bytes,1,rep,name=resource_spans,proto3"`
+}
+
+// ExportTraceServiceResponse is the response of the trace service
+type ExportTraceServiceResponse struct{}
+
+// ResourceSpans is a collection of ScopeSpans from a Resource
+type ResourceSpans struct {
+	Resource   *Resource     `protobuf:"bytes,1,opt,name=resource,proto3"`
+	ScopeSpans []*ScopeSpans `protobuf:"bytes,2,rep,name=scope_spans,proto3"`
+}
+
+// ScopeSpans is a collection of Spans produced by an InstrumentationScope
+type ScopeSpans struct {
+	Scope *InstrumentationScope `protobuf:"bytes,1,opt,name=scope,proto3"`
+	Spans []*Span               `protobuf:"bytes,2,rep,name=spans,proto3"`
+}
+
+// Span represents a single operation within a trace
+type Span struct {
+	TraceID           []byte      `protobuf:"bytes,1,opt,name=trace_id,proto3"`
+	SpanID            []byte      `protobuf:"bytes,2,opt,name=span_id,proto3"`
+	TraceState        string      `protobuf:"bytes,3,opt,name=trace_state,proto3"`
+	ParentSpanID      []byte      `protobuf:"bytes,4,opt,name=parent_span_id,proto3"`
+	Name              string      `protobuf:"bytes,5,opt,name=name,proto3"`
+	Kind              SpanKind    `protobuf:"varint,6,opt,name=kind,proto3"`
+	StartTimeUnixNano uint64      `protobuf:"fixed64,7,opt,name=start_time_unix_nano,proto3"`
+	EndTimeUnixNano   uint64      `protobuf:"fixed64,8,opt,name=end_time_unix_nano,proto3"`
+	Attributes        []*KeyValue `protobuf:"bytes,9,rep,name=attributes,proto3"`
+	Events            []*Event    `protobuf:"bytes,11,rep,name=events,proto3"`
+	Status            *Status     `protobuf:"bytes,15,opt,name=status,proto3"`
+}
+
+// Event is a time-stamped annotation of the span
+type Event struct {
+	TimeUnixNano uint64      `protobuf:"fixed64,1,opt,name=time_unix_nano,proto3"`
+	Name         string      `protobuf:"bytes,2,opt,name=name,proto3"`
+	Attributes   []*KeyValue `protobuf:"bytes,3,rep,name=attributes,proto3"`
+}
+
+// Status represents the status of a finished span
+type Status struct {
+	Message string     `protobuf:"bytes,2,opt,name=message,proto3"`
+	Code    StatusCode `protobuf:"varint,3,opt,name=code,proto3"`
+}
+
+// ExportMetricsServiceRequest is the request of the metrics service
+type ExportMetricsServiceRequest struct {
+	ResourceMetrics []*ResourceMetrics `protobuf:"bytes,1,rep,name=resource_metrics,proto3"`
+}
+
+// ExportMetricsServiceResponse is the response of the metrics service
+type ExportMetricsServiceResponse struct{}
+
+// ResourceMetrics is a collection of ScopeMetrics from a Resource
+type ResourceMetrics struct {
+	Resource     *Resource       `protobuf:"bytes,1,opt,name=resource,proto3"`
+	ScopeMetrics []*ScopeMetrics `protobuf:"bytes,2,rep,name=scope_metrics,proto3"`
+}
+
+// ScopeMetrics is a collection of Metrics produced by an InstrumentationScope
+type ScopeMetrics struct {
+	Scope   *InstrumentationScope `protobuf:"bytes,1,opt,name=scope,proto3"`
+	Metrics []*Metric             `protobuf:"bytes,2,rep,name=metrics,proto3"`
+}
+
+// Metric represents a metric, only one of Gauge, Sum and Histogram is set
+type Metric struct {
+	Name        string     `protobuf:"bytes,1,opt,name=name,proto3"`
+	Description string     `protobuf:"bytes,2,opt,name=description,proto3"`
+	Unit        string     `protobuf:"bytes,3,opt,name=unit,proto3"`
+	Gauge       *Gauge     `protobuf:"bytes,5,opt,name=gauge"`
+	Sum         *Sum       `protobuf:"bytes,7,opt,name=sum"`
+	Histogram   *Histogram `protobuf:"bytes,9,opt,name=histogram"`
+}
+
+// Gauge represents the type of a scalar metric that always exports the "current value" for every data point
+type Gauge struct {
+	DataPoints []*NumberDataPoint `protobuf:"bytes,1,rep,name=data_points,proto3"`
+}
+
+// Sum represents the type of a scalar metric that is calculated as a sum of all reported measurements over a time interval
+type Sum struct {
+	DataPoints             []*NumberDataPoint     `protobuf:"bytes,1,rep,name=data_points,proto3"`
+	AggregationTemporality AggregationTemporality `protobuf:"varint,2,opt,name=aggregation_temporality,proto3"`
+	IsMonotonic            bool                   `protobuf:"varint,3,opt,name=is_monotonic,proto3"`
+}
+
+// Histogram represents the type of a metric that is calculated by aggregating as a Histogram of all reported measurements
+type Histogram struct {
+	DataPoints             []*HistogramDataPoint  `protobuf:"bytes,1,rep,name=data_points,proto3"`
+	AggregationTemporality AggregationTemporality `protobuf:"varint,2,opt,name=aggregation_temporality,proto3"`
+}
+
+// NumberDataPoint is a single data point in a timeseries that describes the time-varying scalar value of a metric
+type NumberDataPoint struct {
+	StartTimeUnixNano uint64      `protobuf:"fixed64,2,opt,name=start_time_unix_nano,proto3"`
+	TimeUnixNano      uint64      `protobuf:"fixed64,3,opt,name=time_unix_nano,proto3"`
+	AsDouble          *float64    `protobuf:"fixed64,4,opt,name=as_double"`
+	Attributes        []*KeyValue `protobuf:"bytes,7,rep,name=attributes,proto3"`
+}
+
+// HistogramDataPoint is a single data point in a timeseries that describes the time-varying values of a Histogram
+type HistogramDataPoint struct {
+	StartTimeUnixNano uint64      `protobuf:"fixed64,2,opt,name=start_time_unix_nano,proto3"`
+	TimeUnixNano      uint64      `protobuf:"fixed64,3,opt,name=time_unix_nano,proto3"`
+	Count             uint64      `protobuf:"fixed64,4,opt,name=count,proto3"`
+	Sum               *float64    `protobuf:"fixed64,5,opt,name=sum"`
+	BucketCounts      []uint64    `protobuf:"fixed64,6,rep,packed,name=bucket_counts,proto3"`
+	ExplicitBounds    []float64   `protobuf:"fixed64,7,rep,packed,name=explicit_bounds,proto3"`
+	Attributes        []*KeyValue `protobuf:"bytes,9,rep,name=attributes,proto3"`
+}
+
+// Reset implements proto.Message
+func (m *ExportTraceServiceRequest) Reset() { *m = ExportTraceServiceRequest{} }
+
+// String implements proto.Message
+func (m *ExportTraceServiceRequest) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage implements proto.Message
+func (*ExportTraceServiceRequest) ProtoMessage() {}
+
+// Reset implements proto.Message
+func (m *ExportTraceServiceResponse) Reset() { *m = ExportTraceServiceResponse{} }
+
+// String implements proto.Message
+func (m *ExportTraceServiceResponse) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage implements proto.Message
+func (*ExportTraceServiceResponse) ProtoMessage() {}
+
+// Reset implements proto.Message
+func (m *ExportMetricsServiceRequest) Reset() { *m = ExportMetricsServiceRequest{} }
+
+// String implements proto.Message
+func (m *ExportMetricsServiceRequest) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage implements proto.Message
+func (*ExportMetricsServiceRequest) ProtoMessage() {}
+
+// Reset implements proto.Message
+func (m *ExportMetricsServiceResponse) Reset() { *m = ExportMetricsServiceResponse{} }
+
+// String implements proto.Message
+func (m *ExportMetricsServiceResponse) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage implements proto.Message
+func (*ExportMetricsServiceResponse) ProtoMessage() {}
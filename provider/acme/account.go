@@ -6,7 +6,6 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 
-	"github.com/containous/traefik/log"
 	"github.com/go-acme/lego/certcrypto"
 	"github.com/go-acme/lego/registration"
 )
@@ -57,7 +56,7 @@ func (a *Account) GetPrivateKey() crypto.PrivateKey {
 		return privateKey
 	}
 
-	log.Errorf("Cannot unmarshal private key %+v", a.PrivateKey)
+	logger.Errorf("Cannot unmarshal private key %+v", a.PrivateKey)
 	return nil
 }
 
@@ -75,10 +74,10 @@ func GetKeyType(value string) certcrypto.KeyType {
 	case "RSA8192":
 		return certcrypto.RSA8192
 	case "":
-		log.Infof("The key type is empty. Use default key type %v.", certcrypto.RSA4096)
+		logger.Infof("The key type is empty. Use default key type %v.", certcrypto.RSA4096)
 		return certcrypto.RSA4096
 	default:
-		log.Infof("Unable to determine key type value %q. Use default key type %v.", value, certcrypto.RSA4096)
+		logger.Infof("Unable to determine key type value %q. Use default key type %v.", value, certcrypto.RSA4096)
 		return certcrypto.RSA4096
 	}
 }
@@ -7,7 +7,6 @@ import (
 
 	"github.com/cenk/backoff"
 	"github.com/containous/mux"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/go-acme/lego/challenge"
 	"github.com/go-acme/lego/challenge/http01"
@@ -35,7 +34,7 @@ func (c *challengeHTTP) Timeout() (timeout, interval time.Duration) {
 }
 
 func getTokenValue(token, domain string, store Store) []byte {
-	log.Debugf("Looking for an existing ACME challenge for token %v...", token)
+	logger.Debugf("Looking for an existing ACME challenge for token %v...", token)
 	var result []byte
 
 	operation := func() error {
@@ -45,14 +44,14 @@ func getTokenValue(token, domain string, store Store) []byte {
 	}
 
 	notify := func(err error, time time.Duration) {
-		log.Errorf("Error getting challenge for token retrying in %s", time)
+		logger.Errorf("Error getting challenge for token retrying in %s", time)
 	}
 
 	ebo := backoff.NewExponentialBackOff()
 	ebo.MaxElapsedTime = 60 * time.Second
 	err := backoff.RetryNotify(safe.OperationWithRecover(operation), ebo, notify)
 	if err != nil {
-		log.Errorf("Error getting challenge for token: %v", err)
+		logger.Errorf("Error getting challenge for token: %v", err)
 		return []byte{}
 	}
 
@@ -68,7 +67,7 @@ func (p *Provider) AddRoutes(router *mux.Router) {
 			if token, ok := vars["token"]; ok {
 				domain, _, err := net.SplitHostPort(req.Host)
 				if err != nil {
-					log.Debugf("Unable to split host and port: %v. Fallback to request host.", err)
+					logger.Debugf("Unable to split host and port: %v. Fallback to request host.", err)
 					domain = req.Host
 				}
 
@@ -77,7 +76,7 @@ func (p *Provider) AddRoutes(router *mux.Router) {
 					rw.WriteHeader(http.StatusOK)
 					_, err = rw.Write(tokenValue)
 					if err != nil {
-						log.Errorf("Unable to write token : %v", err)
+						logger.Errorf("Unable to write token : %v", err)
 					}
 					return
 				}
@@ -3,7 +3,6 @@ package acme
 import (
 	"crypto/tls"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/types"
 	"github.com/go-acme/lego/challenge"
 	"github.com/go-acme/lego/challenge/tlsalpn01"
@@ -16,7 +15,7 @@ type challengeTLSALPN struct {
 }
 
 func (c *challengeTLSALPN) Present(domain, token, keyAuth string) error {
-	log.Debugf("TLS Challenge Present temp certificate for %s", domain)
+	logger.Debugf("TLS Challenge Present temp certificate for %s", domain)
 
 	certPEMBlock, keyPEMBlock, err := tlsalpn01.ChallengeBlocks(domain, keyAuth)
 	if err != nil {
@@ -28,7 +27,7 @@ func (c *challengeTLSALPN) Present(domain, token, keyAuth string) error {
 }
 
 func (c *challengeTLSALPN) CleanUp(domain, token, keyAuth string) error {
-	log.Debugf("TLS Challenge CleanUp temp certificate for %s", domain)
+	logger.Debugf("TLS Challenge CleanUp temp certificate for %s", domain)
 
 	return c.Store.RemoveTLSChallenge(domain)
 }
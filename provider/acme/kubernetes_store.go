@@ -0,0 +1,175 @@
+package acme
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// KubernetesStoragePrefix is the prefix of the ACME storage backed by a Kubernetes secret: kubernetes:<namespace>/<name>
+	KubernetesStoragePrefix = "kubernetes:"
+
+	annotationKubernetesStoreLeader      = "traefik.ingress.kubernetes.io/acme-leader"
+	annotationKubernetesStoreLeaderRenew = "traefik.ingress.kubernetes.io/acme-leader-renew"
+
+	defaultKubernetesStoreLeaseDuration = 30 * time.Second
+	kubernetesStoreUpdateRetries        = 5
+)
+
+// secretsClient is the subset of the Kubernetes secrets client used by the store
+type secretsClient interface {
+	Create(*corev1.Secret) (*corev1.Secret, error)
+	Update(*corev1.Secret) (*corev1.Secret, error)
+	Get(name string, options metav1.GetOptions) (*corev1.Secret, error)
+}
+
+// NewKubernetesStore creates a SharedStore backed by a Kubernetes secret.
+// The leader is elected through annotations of the secret, identity must be unique among the Traefik instances.
+func NewKubernetesStore(secrets secretsClient, name, identity string) SharedStore {
+	return newSharedStore(&kubernetesStoreBackend{
+		secrets:       secrets,
+		name:          name,
+		identity:      identity,
+		leaseDuration: defaultKubernetesStoreLeaseDuration,
+	})
+}
+
+// ParseKubernetesStorage returns the namespace and the name of the secret from a kubernetes:<namespace>/<name> storage
+func ParseKubernetesStorage(storage string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(storage, KubernetesStoragePrefix), "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("invalid Kubernetes ACME storage %q, expected %s<namespace>/<name>", storage, KubernetesStoragePrefix)
+	}
+	return parts[0], parts[1], nil
+}
+
+type kubernetesStoreBackend struct {
+	secrets       secretsClient
+	name          string
+	identity      string
+	leaseDuration time.Duration
+}
+
+func (b *kubernetesStoreBackend) Get(key string) ([]byte, error) {
+	secret, err := b.secrets.Get(b.name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data[key], nil
+}
+
+func (b *kubernetesStoreBackend) Put(key string, value []byte) error {
+	return b.update(func(secret *corev1.Secret) bool {
+		secret.Data[key] = value
+		return true
+	})
+}
+
+func (b *kubernetesStoreBackend) Delete(key string) error {
+	return b.update(func(secret *corev1.Secret) bool {
+		if _, ok := secret.Data[key]; !ok {
+			return false
+		}
+		delete(secret.Data, key)
+		return true
+	})
+}
+
+// update applies the mutation to the secret, creating it if needed.
+// The update is retried when the secret has been modified concurrently.
+func (b *kubernetesStoreBackend) update(mutate func(secret *corev1.Secret) bool) error {
+	var err error
+	for i := 0; i < kubernetesStoreUpdateRetries; i++ {
+		var secret *corev1.Secret
+		secret, err = b.secrets.Get(b.name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: b.name}}
+			if !mutate(initSecret(secret)) {
+				return nil
+			}
+			_, err = b.secrets.Create(secret)
+			if kerrors.IsAlreadyExists(err) {
+				continue
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		secret = secret.DeepCopy()
+		if !mutate(initSecret(secret)) {
+			return nil
+		}
+
+		_, err = b.secrets.Update(secret)
+		if !kerrors.IsConflict(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("unable to update secret %s: %v", b.name, err)
+}
+
+func initSecret(secret *corev1.Secret) *corev1.Secret {
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	return secret
+}
+
+// Campaign takes or renews the leadership by writing the identity of the leader and the renew time in the secret annotations.
+// The leadership is taken over when the leader did not renew it during the lease duration.
+func (b *kubernetesStoreBackend) Campaign(stop chan bool, onLeadership func(leader bool)) {
+	ticker := time.NewTicker(b.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		leader, err := b.tryAcquire(time.Now())
+		if err != nil {
+			logger.Errorf("Unable to acquire the ACME leadership on secret %s: %v", b.name, err)
+		}
+		onLeadership(leader)
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *kubernetesStoreBackend) tryAcquire(now time.Time) (bool, error) {
+	acquired := false
+	err := b.update(func(secret *corev1.Secret) bool {
+		acquired = false
+
+		holder := secret.Annotations[annotationKubernetesStoreLeader]
+		if len(holder) > 0 && holder != b.identity {
+			renew, err := time.Parse(time.RFC3339Nano, secret.Annotations[annotationKubernetesStoreLeaderRenew])
+			if err == nil && now.Sub(renew) < b.leaseDuration {
+				return false
+			}
+			logger.Infof("ACME leader %s did not renew its lease, taking over.", holder)
+		}
+
+		secret.Annotations[annotationKubernetesStoreLeader] = b.identity
+		secret.Annotations[annotationKubernetesStoreLeaderRenew] = now.UTC().Format(time.RFC3339Nano)
+		acquired = true
+		return true
+	})
+	if err != nil {
+		return false, err
+	}
+	return acquired, nil
+}
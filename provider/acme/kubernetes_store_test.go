@@ -0,0 +1,184 @@
+package acme
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var secretsResource = schema.GroupResource{Resource: "secrets"}
+
+// fakeSecrets is an in-memory secrets client checking the resource versions as the API server does
+type fakeSecrets struct {
+	lock    sync.Mutex
+	secrets map[string]*corev1.Secret
+	version int
+}
+
+func newFakeSecrets() *fakeSecrets {
+	return &fakeSecrets{secrets: make(map[string]*corev1.Secret)}
+}
+
+func (f *fakeSecrets) Create(secret *corev1.Secret) (*corev1.Secret, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.secrets[secret.Name]; ok {
+		return nil, kerrors.NewAlreadyExists(secretsResource, secret.Name)
+	}
+	return f.store(secret), nil
+}
+
+func (f *fakeSecrets) Update(secret *corev1.Secret) (*corev1.Secret, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	current, ok := f.secrets[secret.Name]
+	if !ok {
+		return nil, kerrors.NewNotFound(secretsResource, secret.Name)
+	}
+	if current.ResourceVersion != secret.ResourceVersion {
+		return nil, kerrors.NewConflict(secretsResource, secret.Name, nil)
+	}
+	return f.store(secret), nil
+}
+
+func (f *fakeSecrets) Get(name string, _ metav1.GetOptions) (*corev1.Secret, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	secret, ok := f.secrets[name]
+	if !ok {
+		return nil, kerrors.NewNotFound(secretsResource, name)
+	}
+	return secret.DeepCopy(), nil
+}
+
+func (f *fakeSecrets) store(secret *corev1.Secret) *corev1.Secret {
+	f.version++
+	stored := secret.DeepCopy()
+	stored.ResourceVersion = strconv.Itoa(f.version)
+	f.secrets[secret.Name] = stored
+	return stored.DeepCopy()
+}
+
+func TestParseKubernetesStorage(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		storage           string
+		expectedNamespace string
+		expectedName      string
+		expectedErr       bool
+	}{
+		{
+			desc:              "valid",
+			storage:           "kubernetes:traefik/acme",
+			expectedNamespace: "traefik",
+			expectedName:      "acme",
+		},
+		{
+			desc:        "missing namespace",
+			storage:     "kubernetes:acme",
+			expectedErr: true,
+		},
+		{
+			desc:        "empty name",
+			storage:     "kubernetes:traefik/",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			namespace, name, err := ParseKubernetesStorage(test.storage)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedNamespace, namespace)
+			assert.Equal(t, test.expectedName, name)
+		})
+	}
+}
+
+func TestKubernetesStoreLeaderOnlyWrites(t *testing.T) {
+	secrets := newFakeSecrets()
+	leader := NewKubernetesStore(secrets, "acme", "traefik-0").(*sharedStore)
+	follower := NewKubernetesStore(secrets, "acme", "traefik-1").(*sharedStore)
+
+	now := time.Now()
+	elected, err := leader.backend.(*kubernetesStoreBackend).tryAcquire(now)
+	require.NoError(t, err)
+	require.True(t, elected)
+	leader.setLeader(elected)
+
+	elected, err = follower.backend.(*kubernetesStoreBackend).tryAcquire(now.Add(time.Second))
+	require.NoError(t, err)
+	require.False(t, elected)
+	follower.setLeader(elected)
+
+	certificates := []*Certificate{{Certificate: []byte("cert"), Key: []byte("key")}}
+	require.NoError(t, leader.SaveCertificates(certificates))
+	require.NoError(t, follower.SaveCertificates([]*Certificate{{Certificate: []byte("other")}}))
+
+	stored, err := follower.GetCertificates()
+	require.NoError(t, err)
+	assert.Equal(t, certificates, stored)
+
+	// challenges are written by the leader, and read by any instance
+	require.NoError(t, leader.SetHTTPChallengeToken("token", "traefik.wtf", []byte("keyAuth")))
+	keyAuth, err := follower.GetHTTPChallengeToken("token", "traefik.wtf")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("keyAuth"), keyAuth)
+
+	require.NoError(t, leader.RemoveHTTPChallengeToken("token", "traefik.wtf"))
+	_, err = follower.GetHTTPChallengeToken("token", "traefik.wtf")
+	assert.Error(t, err)
+
+	secret, err := secrets.Get("acme", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "traefik-0", secret.Annotations[annotationKubernetesStoreLeader])
+}
+
+func TestKubernetesStoreLeaseExpiration(t *testing.T) {
+	secrets := newFakeSecrets()
+	first := &kubernetesStoreBackend{secrets: secrets, name: "acme", identity: "traefik-0", leaseDuration: time.Minute}
+	second := &kubernetesStoreBackend{secrets: secrets, name: "acme", identity: "traefik-1", leaseDuration: time.Minute}
+
+	now := time.Now()
+
+	elected, err := first.tryAcquire(now)
+	require.NoError(t, err)
+	assert.True(t, elected)
+
+	// the leader renews its lease
+	elected, err = first.tryAcquire(now.Add(30 * time.Second))
+	require.NoError(t, err)
+	assert.True(t, elected)
+
+	elected, err = second.tryAcquire(now.Add(80 * time.Second))
+	require.NoError(t, err)
+	assert.False(t, elected)
+
+	// the leader did not renew its lease
+	elected, err = second.tryAcquire(now.Add(2 * time.Minute))
+	require.NoError(t, err)
+	assert.True(t, elected)
+
+	elected, err = first.tryAcquire(now.Add(2*time.Minute + time.Second))
+	require.NoError(t, err)
+	assert.False(t, elected)
+}
@@ -0,0 +1,108 @@
+package acme
+
+import (
+	"path"
+	"time"
+
+	"github.com/abronan/valkeyrie/store"
+)
+
+const (
+	// KVStoragePrefix is the prefix of the ACME storage backed by the KV store of the KV provider: kv:<key>
+	KVStoragePrefix = "kv:"
+
+	kvStoreLeaderKey       = "leader"
+	defaultKVStoreLockTTL  = 30 * time.Second
+	kvStoreCampaignBackoff = 5 * time.Second
+)
+
+// NewKVStore creates a SharedStore backed by a KV store under the given prefix.
+// The leader holds a lock on the <prefix>/leader key, identity must be unique among the Traefik instances.
+func NewKVStore(kvStore store.Store, prefix, identity string) SharedStore {
+	return newSharedStore(&kvStoreBackend{
+		store:    kvStore,
+		prefix:   prefix,
+		identity: identity,
+		lockTTL:  defaultKVStoreLockTTL,
+	})
+}
+
+type kvStoreBackend struct {
+	store    store.Store
+	prefix   string
+	identity string
+	lockTTL  time.Duration
+}
+
+func (b *kvStoreBackend) Get(key string) ([]byte, error) {
+	pair, err := b.store.Get(path.Join(b.prefix, key), nil)
+	if err == store.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pair.Value, nil
+}
+
+func (b *kvStoreBackend) Put(key string, value []byte) error {
+	return b.store.Put(path.Join(b.prefix, key), value, nil)
+}
+
+func (b *kvStoreBackend) Delete(key string) error {
+	err := b.store.Delete(path.Join(b.prefix, key))
+	if err == store.ErrKeyNotFound {
+		return nil
+	}
+	return err
+}
+
+// Campaign holds a lock on the leader key while the current instance is the leader.
+// Stores without lock support (BoltDB) are not shared, the current instance is always the leader.
+func (b *kvStoreBackend) Campaign(stop chan bool, onLeadership func(leader bool)) {
+	locker, err := b.store.NewLock(path.Join(b.prefix, kvStoreLeaderKey), &store.LockOptions{
+		Value: []byte(b.identity),
+		TTL:   b.lockTTL,
+	})
+	if err == store.ErrCallNotSupported {
+		logger.Debug("The KV store does not support locks, the ACME leadership is not shared.")
+		onLeadership(true)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Unable to create the ACME leader lock: %v", err)
+		return
+	}
+
+	stopCh := make(chan struct{})
+	go func() {
+		<-stop
+		close(stopCh)
+	}()
+
+	for {
+		lost, err := locker.Lock(stopCh)
+		if err != nil {
+			logger.Errorf("Unable to acquire the ACME leader lock: %v", err)
+		} else if lost != nil {
+			onLeadership(true)
+
+			select {
+			case <-stop:
+				onLeadership(false)
+				if err := locker.Unlock(); err != nil {
+					logger.Errorf("Unable to release the ACME leader lock: %v", err)
+				}
+				return
+			case <-lost:
+				onLeadership(false)
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(kvStoreCampaignBackoff):
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package acme
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abronan/valkeyrie/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKVStore is an in-memory store.Store without lock support, as BoltDB
+type memoryKVStore struct {
+	store.Store
+	lock  sync.Mutex
+	pairs map[string][]byte
+}
+
+func newMemoryKVStore() *memoryKVStore {
+	return &memoryKVStore{pairs: make(map[string][]byte)}
+}
+
+func (m *memoryKVStore) Put(key string, value []byte, _ *store.WriteOptions) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.pairs[key] = value
+	return nil
+}
+
+func (m *memoryKVStore) Get(key string, _ *store.ReadOptions) (*store.KVPair, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	value, ok := m.pairs[key]
+	if !ok {
+		return nil, store.ErrKeyNotFound
+	}
+	return &store.KVPair{Key: key, Value: value}, nil
+}
+
+func (m *memoryKVStore) Delete(key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.pairs[key]; !ok {
+		return store.ErrKeyNotFound
+	}
+	delete(m.pairs, key)
+	return nil
+}
+
+func (m *memoryKVStore) NewLock(_ string, _ *store.LockOptions) (store.Locker, error) {
+	return nil, store.ErrCallNotSupported
+}
+
+func TestKVStore(t *testing.T) {
+	kvStore := newMemoryKVStore()
+	leader := NewKVStore(kvStore, "traefik/acme", "traefik-0").(*sharedStore)
+	leader.pollInterval = 10 * time.Millisecond
+	follower := NewKVStore(kvStore, "traefik/acme", "traefik-1").(*sharedStore)
+	follower.pollInterval = 10 * time.Millisecond
+
+	stop := make(chan bool)
+	defer close(stop)
+
+	// without lock support, the store is not shared and the instance is always the leader
+	elected := make(chan struct{})
+	go leader.Run(stop, func() { close(elected) }, func([]*Certificate) {})
+
+	select {
+	case <-elected:
+	case <-time.After(time.Second):
+		t.Fatal("the instance has not been elected")
+	}
+	assert.True(t, leader.IsLeader())
+	assert.False(t, follower.IsLeader())
+
+	account := &Account{Email: "test@traefik.wtf"}
+	require.NoError(t, leader.SaveAccount(account))
+	require.NoError(t, follower.SaveAccount(&Account{Email: "other@traefik.wtf"}))
+
+	storedAccount, err := follower.GetAccount()
+	require.NoError(t, err)
+	assert.Equal(t, account, storedAccount)
+	assert.Contains(t, kvStore.pairs, "traefik/acme/account")
+
+	require.NoError(t, leader.AddTLSChallenge("traefik.wtf", &Certificate{Certificate: []byte("cert")}))
+	challenge, err := follower.GetTLSChallenge("traefik.wtf")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("cert"), challenge.Certificate)
+
+	require.NoError(t, leader.RemoveTLSChallenge("traefik.wtf"))
+	challenge, err = follower.GetTLSChallenge("traefik.wtf")
+	require.NoError(t, err)
+	assert.Nil(t, challenge)
+}
+
+func TestSharedStoreFollowsLeaderCertificates(t *testing.T) {
+	kvStore := newMemoryKVStore()
+	follower := newSharedStore(&kvStoreBackend{store: kvStore, prefix: "traefik/acme", identity: "traefik-1"})
+	follower.pollInterval = 10 * time.Millisecond
+
+	// the follower never gets the leadership
+	backend := &followerBackend{sharedStoreBackend: follower.backend, started: make(chan struct{})}
+	follower.backend = backend
+
+	updates := make(chan []*Certificate, 1)
+	stop := make(chan bool)
+	defer close(stop)
+	go follower.Run(stop, func() {}, func(certificates []*Certificate) { updates <- certificates })
+
+	select {
+	case <-backend.started:
+	case <-time.After(time.Second):
+		t.Fatal("the follower has not started")
+	}
+
+	// written by the leader
+	leader := newSharedStore(&kvStoreBackend{store: kvStore, prefix: "traefik/acme", identity: "traefik-0"})
+	leader.setLeader(true)
+	certificates := []*Certificate{{Certificate: []byte("cert"), Key: []byte("key")}}
+	require.NoError(t, leader.SaveCertificates(certificates))
+
+	select {
+	case updated := <-updates:
+		assert.Equal(t, certificates, updated)
+	case <-time.After(time.Second):
+		t.Fatal("the certificates saved by the leader have not been received")
+	}
+}
+
+// followerBackend never gets the leadership, started is closed on the first read of the backend
+type followerBackend struct {
+	sharedStoreBackend
+	started chan struct{}
+	once    sync.Once
+}
+
+func (b *followerBackend) Get(key string) ([]byte, error) {
+	data, err := b.sharedStoreBackend.Get(key)
+	b.once.Do(func() { close(b.started) })
+	return data, err
+}
+
+func (b *followerBackend) Campaign(stop chan bool, onLeadership func(leader bool)) {
+	onLeadership(false)
+}
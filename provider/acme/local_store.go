@@ -8,7 +8,6 @@ import (
 	"regexp"
 	"sync"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 )
 
@@ -66,7 +65,7 @@ func (s *LocalStore) get() (*StoredData, error) {
 					return nil, err
 				}
 				if isOldRegistration {
-					log.Debug("Reset ACME account.")
+					logger.Debug("Reset ACME account.")
 					s.storedData.Account = nil
 					s.SaveDataChan <- s.storedData
 				}
@@ -76,7 +75,7 @@ func (s *LocalStore) get() (*StoredData, error) {
 			var certificates []*Certificate
 			for _, certificate := range s.storedData.Certificates {
 				if len(certificate.Certificate) == 0 || len(certificate.Key) == 0 {
-					log.Debugf("Delete certificate %v for domains %v which have no value.", certificate, certificate.Domain.ToStrArray())
+					logger.Debugf("Delete certificate %v for domains %v which have no value.", certificate, certificate.Domain.ToStrArray())
 					continue
 				}
 				certificates = append(certificates, certificate)
@@ -98,12 +97,12 @@ func (s *LocalStore) listenSaveAction() {
 		for object := range s.SaveDataChan {
 			data, err := json.MarshalIndent(object, "", "  ")
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 			}
 
 			err = ioutil.WriteFile(s.filename, data, 0600)
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 			}
 		}
 	})
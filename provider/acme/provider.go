@@ -31,6 +31,8 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var logger = log.WithModule("provider/acme")
+
 var (
 	// OSCPMustStaple enables OSCP stapling as from https://github.com/go-acme/lego/issues/270
 	OSCPMustStaple = false
@@ -67,6 +69,9 @@ type Provider struct {
 	pool                   *safe.Pool
 	resolvingDomains       map[string]struct{}
 	resolvingDomainsMutex  sync.RWMutex
+	sharedCertsChan        chan []*Certificate
+	lastConfiguration      *types.Configuration
+	lastConfigurationMutex sync.Mutex
 }
 
 // Certificate is a struct which contains all data needed from an ACME certificate
@@ -141,7 +146,7 @@ func (p *Provider) Init(_ types.Constraints) error {
 
 	// Reset Account if caServer changed, thus registration URI can be updated
 	if p.account != nil && p.account.Registration != nil && !isAccountMatchingCaServer(p.account.Registration.URI, p.CAServer) {
-		log.Info("Account URI does not match the current CAServer. The account will be reset")
+		logger.Info("Account URI does not match the current CAServer. The account will be reset")
 		p.account = nil
 	}
 
@@ -159,12 +164,12 @@ func (p *Provider) Init(_ types.Constraints) error {
 func isAccountMatchingCaServer(accountURI string, serverURI string) bool {
 	aru, err := url.Parse(accountURI)
 	if err != nil {
-		log.Infof("Unable to parse account.Registration URL : %v", err)
+		logger.Infof("Unable to parse account.Registration URL : %v", err)
 		return false
 	}
 	cau, err := url.Parse(serverURI)
 	if err != nil {
-		log.Infof("Unable to parse CAServer URL : %v", err)
+		logger.Infof("Unable to parse CAServer URL : %v", err)
 		return false
 	}
 	return cau.Hostname() == aru.Hostname()
@@ -182,17 +187,18 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 	p.refreshCertificates()
 
 	p.deleteUnnecessaryDomains()
-	for i := 0; i < len(p.Domains); i++ {
-		domain := p.Domains[i]
-		safe.Go(func() {
-			if _, err := p.resolveCertificate(domain, true); err != nil {
-				log.Errorf("Unable to obtain ACME certificate for domains %q : %v", strings.Join(domain.ToStrArray(), ","), err)
-			}
-		})
-	}
+	p.resolveDomains()
 
 	p.renewCertificates()
 
+	if sharedStore, ok := p.Store.(SharedStore); ok {
+		pool.Go(func(stop chan bool) {
+			sharedStore.Run(stop, p.onElected, func(certificates []*Certificate) {
+				p.sharedCertsChan <- certificates
+			})
+		})
+	}
+
 	ticker := time.NewTicker(24 * time.Hour)
 	pool.Go(func(stop chan bool) {
 		for {
@@ -222,13 +228,13 @@ func (p *Provider) getClient() (*lego.Client, error) {
 		return nil, err
 	}
 
-	log.Debug("Building ACME client...")
+	logger.Debug("Building ACME client...")
 
 	caServer := "https://acme-v02.api.letsencrypt.org/directory"
 	if len(p.CAServer) > 0 {
 		caServer = p.CAServer
 	}
-	log.Debug(caServer)
+	logger.Debug(caServer)
 
 	config := lego.NewConfig(account)
 	config.CADirURL = caServer
@@ -242,7 +248,7 @@ func (p *Provider) getClient() (*lego.Client, error) {
 
 	// New users will need to register; be sure to save it
 	if account.GetRegistration() == nil {
-		log.Info("Register...")
+		logger.Info("Register...")
 
 		reg, err := client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
 		if err != nil {
@@ -260,7 +266,7 @@ func (p *Provider) getClient() (*lego.Client, error) {
 	}
 
 	if p.DNSChallenge != nil && len(p.DNSChallenge.Provider) > 0 {
-		log.Debugf("Using DNS Challenge provider: %s", p.DNSChallenge.Provider)
+		logger.Debugf("Using DNS Challenge provider: %s", p.DNSChallenge.Provider)
 
 		var provider challenge.Provider
 		provider, err = dns.NewDNSChallengeProviderByName(p.DNSChallenge.Provider)
@@ -273,7 +279,7 @@ func (p *Provider) getClient() (*lego.Client, error) {
 			dns01.CondOption(p.DNSChallenge.DisablePropagationCheck || p.DNSChallenge.DelayBeforeCheck > 0,
 				dns01.AddPreCheck(func(_, _ string) (bool, error) {
 					if p.DNSChallenge.DelayBeforeCheck > 0 {
-						log.Debugf("Delaying %d rather than validating DNS propagation now.", p.DNSChallenge.DelayBeforeCheck)
+						logger.Debugf("Delaying %d rather than validating DNS propagation now.", p.DNSChallenge.DelayBeforeCheck)
 						time.Sleep(time.Duration(p.DNSChallenge.DelayBeforeCheck))
 					}
 					return true, nil
@@ -293,14 +299,14 @@ func (p *Provider) getClient() (*lego.Client, error) {
 		}
 
 	} else if p.HTTPChallenge != nil && len(p.HTTPChallenge.EntryPoint) > 0 {
-		log.Debug("Using HTTP Challenge provider.")
+		logger.Debug("Using HTTP Challenge provider.")
 
 		err = client.Challenge.SetHTTP01Provider(&challengeHTTP{Store: p.Store})
 		if err != nil {
 			return nil, err
 		}
 	} else if p.TLSChallenge != nil {
-		log.Debug("Using TLS Challenge provider.")
+		logger.Debug("Using TLS Challenge provider.")
 
 		err = client.Challenge.SetTLSALPN01Provider(&challengeTLSALPN{Store: p.Store})
 		if err != nil {
@@ -345,45 +351,105 @@ func (p *Provider) watchNewDomains() {
 		for {
 			select {
 			case config := <-p.configFromListenerChan:
-				for _, frontend := range config.Frontends {
-					if !contains(frontend.EntryPoints, p.EntryPoint) {
-						continue
-					}
-					for _, route := range frontend.Routes {
-						domainRules := rules.Rules{}
-						domains, err := domainRules.ParseDomains(route.Rule)
-						if err != nil {
-							log.Errorf("Error parsing domains in provider ACME: %v", err)
-							continue
-						}
+				p.lastConfigurationMutex.Lock()
+				p.lastConfiguration = &config
+				p.lastConfigurationMutex.Unlock()
 
-						if len(domains) == 0 {
-							log.Debugf("No domain parsed in rule %q in provider ACME", route.Rule)
-							continue
-						}
+				p.resolveDomainsFromConfiguration(config)
+			case <-stop:
+				return
+			}
+		}
+	})
+}
+
+func (p *Provider) resolveDomainsFromConfiguration(config types.Configuration) {
+	for _, frontend := range config.Frontends {
+		if !contains(frontend.EntryPoints, p.EntryPoint) {
+			continue
+		}
+		for _, route := range frontend.Routes {
+			domainRules := rules.Rules{}
+			domains, err := domainRules.ParseDomains(route.Rule)
+			if err != nil {
+				logger.Errorf("Error parsing domains in provider ACME: %v", err)
+				continue
+			}
 
-						log.Debugf("Try to challenge certificate for domain %v founded in Host rule", domains)
+			if len(domains) == 0 {
+				logger.Debugf("No domain parsed in rule %q in provider ACME", route.Rule)
+				continue
+			}
 
-						var domain types.Domain
-						if len(domains) > 0 {
-							domain = types.Domain{Main: domains[0]}
-							if len(domains) > 1 {
-								domain.SANs = domains[1:]
-							}
+			logger.Debugf("Try to challenge certificate for domain %v founded in Host rule", domains)
 
-							safe.Go(func() {
-								if _, err := p.resolveCertificate(domain, false); err != nil {
-									log.Errorf("Unable to obtain ACME certificate for domains %q detected thanks to rule %q : %v", strings.Join(domains, ","), route.Rule, err)
-								}
-							})
-						}
-					}
+			var domain types.Domain
+			if len(domains) > 0 {
+				domain = types.Domain{Main: domains[0]}
+				if len(domains) > 1 {
+					domain.SANs = domains[1:]
 				}
-			case <-stop:
-				return
+
+				rule := route.Rule
+				safe.Go(func() {
+					if _, err := p.resolveCertificate(domain, false); err != nil {
+						logger.Errorf("Unable to obtain ACME certificate for domains %q detected thanks to rule %q : %v", strings.Join(domains, ","), rule, err)
+					}
+				})
 			}
 		}
-	})
+	}
+}
+
+// resolveDomains resolves the certificates of the domains from the configuration file
+func (p *Provider) resolveDomains() {
+	for i := 0; i < len(p.Domains); i++ {
+		domain := p.Domains[i]
+		safe.Go(func() {
+			if _, err := p.resolveCertificate(domain, true); err != nil {
+				logger.Errorf("Unable to obtain ACME certificate for domains %q : %v", strings.Join(domain.ToStrArray(), ","), err)
+			}
+		})
+	}
+}
+
+// isLeader returns false if the store is shared with other Traefik instances and another instance is the leader
+func (p *Provider) isLeader() bool {
+	if sharedStore, ok := p.Store.(SharedStore); ok {
+		return sharedStore.IsLeader()
+	}
+	return true
+}
+
+// onElected takes over the certificates management when the current instance becomes the leader of a shared store
+func (p *Provider) onElected() {
+	// the account may have been registered by the previous leader
+	account, err := p.Store.GetAccount()
+	if err != nil {
+		logger.Errorf("Unable to get ACME account: %v", err)
+	} else if account != nil {
+		p.clientMutex.Lock()
+		p.account = account
+		p.client = nil
+		p.clientMutex.Unlock()
+	}
+
+	certificates, err := p.Store.GetCertificates()
+	if err != nil {
+		logger.Errorf("Unable to get ACME certificates: %v", err)
+	} else {
+		p.sharedCertsChan <- certificates
+	}
+
+	p.resolveDomains()
+	p.renewCertificates()
+
+	p.lastConfigurationMutex.Lock()
+	config := p.lastConfiguration
+	p.lastConfigurationMutex.Unlock()
+	if config != nil {
+		p.resolveDomainsFromConfiguration(*config)
+	}
 }
 
 func (p *Provider) resolveCertificate(domain types.Domain, domainFromConfigurationFile bool) (*certificate.Resource, error) {
@@ -398,10 +464,15 @@ func (p *Provider) resolveCertificate(domain types.Domain, domainFromConfigurati
 		return nil, nil
 	}
 
+	if !p.isLeader() {
+		logger.Debugf("Not the ACME leader, the certificates for the domains %q are obtained by the leader.", uncheckedDomains)
+		return nil, nil
+	}
+
 	p.addResolvingDomains(uncheckedDomains)
 	defer p.removeResolvingDomains(uncheckedDomains)
 
-	log.Debugf("Loading ACME certificates %+v...", uncheckedDomains)
+	logger.Debugf("Loading ACME certificates %+v...", uncheckedDomains)
 
 	client, err := p.getClient()
 	if err != nil {
@@ -431,7 +502,7 @@ func (p *Provider) resolveCertificate(domain types.Domain, domainFromConfigurati
 		return nil, fmt.Errorf("domains %v generate certificate with no value: %v", uncheckedDomains, cert)
 	}
 
-	log.Debugf("Certificates obtained for domains %+v", uncheckedDomains)
+	logger.Debugf("Certificates obtained for domains %+v", uncheckedDomains)
 
 	if len(uncheckedDomains) > 1 {
 		domain = types.Domain{Main: uncheckedDomains[0], SANs: uncheckedDomains[1:]}
@@ -502,7 +573,7 @@ func obtainCertificateWithRetry(domains []string, client *lego.Client, timeout,
 	}
 
 	notify := func(err error, time time.Duration) {
-		log.Errorf("Error obtaining certificate retrying in %s", time)
+		logger.Errorf("Error obtaining certificate retrying in %s", time)
 	}
 
 	// Define a retry backOff to let LEGO tries twice to obtain a certificate for both wildcard and root domain
@@ -513,7 +584,7 @@ func obtainCertificateWithRetry(domains []string, client *lego.Client, timeout,
 
 	err = backoff.RetryNotify(safe.OperationWithRecover(operation), rbo, notify)
 	if err != nil {
-		log.Errorf("Error obtaining certificate: %v", err)
+		logger.Errorf("Error obtaining certificate: %v", err)
 		return nil, err
 	}
 
@@ -540,7 +611,7 @@ func (p *Provider) deleteUnnecessaryDomains() {
 
 			if reflect.DeepEqual(domain, domainToCheck) {
 				if idxDomainToCheck > idxDomain {
-					log.Warnf("The domain %v is duplicated in the configuration but will be process by ACME provider only once.", domainToCheck)
+					logger.Warnf("The domain %v is duplicated in the configuration but will be process by ACME provider only once.", domainToCheck)
 					keepDomain = false
 				}
 				break
@@ -552,11 +623,11 @@ func (p *Provider) deleteUnnecessaryDomains() {
 			for _, domainProcessed := range domainToCheck.ToStrArray() {
 				if idxDomain < idxDomainToCheck && isDomainAlreadyChecked(domainProcessed, domain.ToStrArray()) {
 					// The domain is duplicated in a CN
-					log.Warnf("Domain %q is duplicated in the configuration or validated by the domain %v. It will be processed once.", domainProcessed, domain)
+					logger.Warnf("Domain %q is duplicated in the configuration or validated by the domain %v. It will be processed once.", domainProcessed, domain)
 					continue
 				} else if domain.Main != domainProcessed && strings.HasPrefix(domain.Main, "*") && isDomainAlreadyChecked(domainProcessed, []string{domain.Main}) {
 					// Check if a wildcard can validate the domain
-					log.Warnf("Domain %q will not be processed by ACME provider because it is validated by the wildcard %q", domainProcessed, domain.Main)
+					logger.Warnf("Domain %q will not be processed by ACME provider because it is validated by the wildcard %q", domainProcessed, domain.Main)
 					continue
 				}
 				newDomainsToCheck = append(newDomainsToCheck, domainProcessed)
@@ -581,9 +652,14 @@ func (p *Provider) deleteUnnecessaryDomains() {
 
 func (p *Provider) watchCertificate() {
 	p.certsChan = make(chan *Certificate)
+	p.sharedCertsChan = make(chan []*Certificate)
 	p.pool.Go(func(stop chan bool) {
 		for {
 			select {
+			case certificates := <-p.sharedCertsChan:
+				p.certificates = certificates
+				p.refreshCertificates()
+
 			case cert := <-p.certsChan:
 				certUpdated := false
 				for _, domainsCertificate := range p.certificates {
@@ -600,7 +676,7 @@ func (p *Provider) watchCertificate() {
 
 				err := p.saveCertificates()
 				if err != nil {
-					log.Error(err)
+					logger.Error(err)
 				}
 
 			case <-stop:
@@ -636,7 +712,12 @@ func (p *Provider) refreshCertificates() {
 }
 
 func (p *Provider) renewCertificates() {
-	log.Info("Testing certificate renew...")
+	if !p.isLeader() {
+		logger.Debug("Not the ACME leader, the certificates are renewed by the leader.")
+		return
+	}
+
+	logger.Info("Testing certificate renew...")
 	for _, cert := range p.certificates {
 		crt, err := getX509Certificate(cert)
 		// If there's an error, we assume the cert is broken, and needs update
@@ -644,11 +725,11 @@ func (p *Provider) renewCertificates() {
 		if err != nil || crt == nil || crt.NotAfter.Before(time.Now().Add(24*30*time.Hour)) {
 			client, err := p.getClient()
 			if err != nil {
-				log.Infof("Error renewing certificate from LE : %+v, %v", cert.Domain, err)
+				logger.Infof("Error renewing certificate from LE : %+v, %v", cert.Domain, err)
 				continue
 			}
 
-			log.Infof("Renewing certificate from LE : %+v", cert.Domain)
+			logger.Infof("Renewing certificate from LE : %+v", cert.Domain)
 
 			renewedCert, err := client.Certificate.Renew(certificate.Resource{
 				Domain:      cert.Domain.Main,
@@ -657,12 +738,12 @@ func (p *Provider) renewCertificates() {
 			}, true, OSCPMustStaple)
 
 			if err != nil {
-				log.Errorf("Error renewing certificate from LE: %v, %v", cert.Domain, err)
+				logger.Errorf("Error renewing certificate from LE: %v, %v", cert.Domain, err)
 				continue
 			}
 
 			if len(renewedCert.Certificate) == 0 || len(renewedCert.PrivateKey) == 0 {
-				log.Errorf("domains %v renew certificate with no value: %v", cert.Domain.ToStrArray(), cert)
+				logger.Errorf("domains %v renew certificate with no value: %v", cert.Domain.ToStrArray(), cert)
 				continue
 			}
 
@@ -677,7 +758,7 @@ func (p *Provider) getUncheckedDomains(domainsToCheck []string, checkConfigurati
 	p.resolvingDomainsMutex.RLock()
 	defer p.resolvingDomainsMutex.RUnlock()
 
-	log.Debugf("Looking for provided certificate(s) to validate %q...", domainsToCheck)
+	logger.Debugf("Looking for provided certificate(s) to validate %q...", domainsToCheck)
 
 	allDomains := p.certificateStore.GetAllDomains()
 
@@ -710,9 +791,9 @@ func searchUncheckedDomains(domainsToCheck []string, existentDomains []string) [
 	}
 
 	if len(uncheckedDomains) == 0 {
-		log.Debugf("No ACME certificate generation required for domains %q.", domainsToCheck)
+		logger.Debugf("No ACME certificate generation required for domains %q.", domainsToCheck)
 	} else {
-		log.Debugf("Domains %q need ACME certificates generation for domains %q.", domainsToCheck, strings.Join(uncheckedDomains, ","))
+		logger.Debugf("Domains %q need ACME certificates generation for domains %q.", domainsToCheck, strings.Join(uncheckedDomains, ","))
 	}
 	return uncheckedDomains
 }
@@ -720,7 +801,7 @@ func searchUncheckedDomains(domainsToCheck []string, existentDomains []string) [
 func getX509Certificate(cert *Certificate) (*x509.Certificate, error) {
 	tlsCert, err := tls.X509KeyPair(cert.Certificate, cert.Key)
 	if err != nil {
-		log.Errorf("Failed to load TLS keypair from ACME certificate for domain %q (SAN : %q), certificate will be renewed : %v", cert.Domain.Main, strings.Join(cert.Domain.SANs, ","), err)
+		logger.Errorf("Failed to load TLS keypair from ACME certificate for domain %q (SAN : %q), certificate will be renewed : %v", cert.Domain.Main, strings.Join(cert.Domain.SANs, ","), err)
 		return nil, err
 	}
 
@@ -728,7 +809,7 @@ func getX509Certificate(cert *Certificate) (*x509.Certificate, error) {
 	if crt == nil {
 		crt, err = x509.ParseCertificate(tlsCert.Certificate[0])
 		if err != nil {
-			log.Errorf("Failed to parse TLS keypair from ACME certificate for domain %q (SAN : %q), certificate will be renewed : %v", cert.Domain.Main, strings.Join(cert.Domain.SANs, ","), err)
+			logger.Errorf("Failed to parse TLS keypair from ACME certificate for domain %q (SAN : %q), certificate will be renewed : %v", cert.Domain.Main, strings.Join(cert.Domain.SANs, ","), err)
 		}
 	}
 
@@ -761,7 +842,7 @@ func (p *Provider) getValidDomains(domain types.Domain, wildcardAllowed bool) ([
 		canonicalDomain := types.CanonicalDomain(domain)
 		cleanDomain := dns01.UnFqdn(canonicalDomain)
 		if canonicalDomain != cleanDomain {
-			log.Warnf("FQDN detected, please remove the trailing dot: %s", canonicalDomain)
+			logger.Warnf("FQDN detected, please remove the trailing dot: %s", canonicalDomain)
 		}
 		cleanDomains = append(cleanDomains, cleanDomain)
 	}
@@ -0,0 +1,237 @@
+package acme
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	sharedStoreAccountKey          = "account"
+	sharedStoreCertificatesKey     = "certificates"
+	sharedStoreHTTPChallengePrefix = "http-challenge."
+	sharedStoreTLSChallengePrefix  = "tls-challenge."
+
+	// defaultSharedStorePollInterval is the interval between two checks of the certificates saved by the leader
+	defaultSharedStorePollInterval = 30 * time.Second
+)
+
+// SharedStore is a Store shared by several Traefik instances.
+// Only the elected leader saves the account and the certificates,
+// the other instances use the certificates saved by the leader.
+// The challenges are shared so that any instance can answer them.
+type SharedStore interface {
+	Store
+
+	// IsLeader returns true if the current instance is allowed to save the account and the certificates
+	IsLeader() bool
+	// Run runs the leader election until stop is closed.
+	// onElected is called each time the current instance becomes the leader,
+	// onCertificates is called with the certificates saved by the leader while the current instance is not the leader.
+	Run(stop chan bool, onElected func(), onCertificates func([]*Certificate))
+}
+
+// sharedStoreBackend is the storage backing a sharedStore
+type sharedStoreBackend interface {
+	// Get returns nil without error if the key does not exist
+	Get(key string) ([]byte, error)
+	Put(key string, value []byte) error
+	Delete(key string) error
+	// Campaign runs the leader election until stop is closed, onLeadership is called each time the leadership changes
+	Campaign(stop chan bool, onLeadership func(leader bool))
+}
+
+var _ SharedStore = (*sharedStore)(nil)
+
+type sharedStore struct {
+	backend      sharedStoreBackend
+	pollInterval time.Duration
+
+	lock   sync.RWMutex
+	leader bool
+}
+
+func newSharedStore(backend sharedStoreBackend) *sharedStore {
+	return &sharedStore{
+		backend:      backend,
+		pollInterval: defaultSharedStorePollInterval,
+	}
+}
+
+func (s *sharedStore) getJSON(key string, object interface{}) (bool, error) {
+	data, err := s.backend.Get(key)
+	if err != nil {
+		return false, err
+	}
+
+	if len(data) == 0 {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(data, object); err != nil {
+		return false, fmt.Errorf("unable to decode %s: %v", key, err)
+	}
+	return true, nil
+}
+
+func (s *sharedStore) putJSON(key string, object interface{}) error {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	return s.backend.Put(key, data)
+}
+
+// GetAccount returns ACME Account
+func (s *sharedStore) GetAccount() (*Account, error) {
+	account := &Account{}
+	found, err := s.getJSON(sharedStoreAccountKey, account)
+	if err != nil || !found {
+		return nil, err
+	}
+	return account, nil
+}
+
+// SaveAccount stores ACME Account, only if the current instance is the leader
+func (s *sharedStore) SaveAccount(account *Account) error {
+	if !s.IsLeader() {
+		logger.Debug("Not the ACME leader, the account is not saved.")
+		return nil
+	}
+	return s.putJSON(sharedStoreAccountKey, account)
+}
+
+// GetCertificates returns ACME Certificates list
+func (s *sharedStore) GetCertificates() ([]*Certificate, error) {
+	var certificates []*Certificate
+	if _, err := s.getJSON(sharedStoreCertificatesKey, &certificates); err != nil {
+		return nil, err
+	}
+	return certificates, nil
+}
+
+// SaveCertificates stores ACME Certificates list, only if the current instance is the leader
+func (s *sharedStore) SaveCertificates(certificates []*Certificate) error {
+	if !s.IsLeader() {
+		logger.Debug("Not the ACME leader, the certificates are not saved.")
+		return nil
+	}
+	return s.putJSON(sharedStoreCertificatesKey, certificates)
+}
+
+// GetHTTPChallengeToken Get the http challenge token from the store
+func (s *sharedStore) GetHTTPChallengeToken(token, domain string) ([]byte, error) {
+	keyAuth, err := s.backend.Get(httpChallengeKey(token, domain))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyAuth) == 0 {
+		return nil, fmt.Errorf("cannot find challenge for token %v", token)
+	}
+	return keyAuth, nil
+}
+
+// SetHTTPChallengeToken Set the http challenge token in the store
+func (s *sharedStore) SetHTTPChallengeToken(token, domain string, keyAuth []byte) error {
+	return s.backend.Put(httpChallengeKey(token, domain), keyAuth)
+}
+
+// RemoveHTTPChallengeToken Remove the http challenge token in the store
+func (s *sharedStore) RemoveHTTPChallengeToken(token, domain string) error {
+	return s.backend.Delete(httpChallengeKey(token, domain))
+}
+
+// AddTLSChallenge Add a certificate to the ACME TLS-ALPN-01 certificates storage
+func (s *sharedStore) AddTLSChallenge(domain string, cert *Certificate) error {
+	return s.putJSON(sharedStoreTLSChallengePrefix+domain, cert)
+}
+
+// GetTLSChallenge Get a certificate from the ACME TLS-ALPN-01 certificates storage
+func (s *sharedStore) GetTLSChallenge(domain string) (*Certificate, error) {
+	cert := &Certificate{}
+	found, err := s.getJSON(sharedStoreTLSChallengePrefix+domain, cert)
+	if err != nil || !found {
+		return nil, err
+	}
+	return cert, nil
+}
+
+// RemoveTLSChallenge Remove a certificate from the ACME TLS-ALPN-01 certificates storage
+func (s *sharedStore) RemoveTLSChallenge(domain string) error {
+	return s.backend.Delete(sharedStoreTLSChallengePrefix + domain)
+}
+
+// IsLeader returns true if the current instance is the leader
+func (s *sharedStore) IsLeader() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.leader
+}
+
+func (s *sharedStore) setLeader(leader bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.leader = leader
+}
+
+// Run runs the leader election and follows the certificates saved by the leader until stop is closed
+func (s *sharedStore) Run(stop chan bool, onElected func(), onCertificates func([]*Certificate)) {
+	go s.backend.Campaign(stop, func(leader bool) {
+		wasLeader := s.IsLeader()
+		s.setLeader(leader)
+
+		if leader && !wasLeader {
+			logger.Info("Elected as the ACME leader.")
+			onElected()
+		} else if !leader && wasLeader {
+			logger.Info("No longer the ACME leader.")
+		}
+	})
+
+	// the certificates read at start are already known by the provider
+	last, err := s.backend.Get(sharedStoreCertificatesKey)
+	if err != nil {
+		logger.Errorf("Unable to get ACME certificates: %v", err)
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			data, err := s.backend.Get(sharedStoreCertificatesKey)
+			if err != nil {
+				logger.Errorf("Unable to get ACME certificates: %v", err)
+				continue
+			}
+
+			if bytes.Equal(data, last) {
+				continue
+			}
+			last = data
+
+			if s.IsLeader() {
+				continue
+			}
+
+			var certificates []*Certificate
+			if len(data) > 0 {
+				if err := json.Unmarshal(data, &certificates); err != nil {
+					logger.Errorf("Unable to decode ACME certificates: %v", err)
+					continue
+				}
+			}
+			logger.Debug("ACME certificates updated by the leader.")
+			onCertificates(certificates)
+		}
+	}
+}
+
+func httpChallengeKey(token, domain string) string {
+	return sharedStoreHTTPChallengePrefix + token + "." + domain
+}
@@ -11,7 +11,6 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -48,6 +47,7 @@ func (p *Provider) buildConfigurationV2(catalog []catalogUpdate) *types.Configur
 		"getPassTLSClientCert":   label.GetTLSClientCert,
 		"getWhiteList":           label.GetWhiteList,
 		"getRedirect":            label.GetRedirect,
+		"getAccessLog":           label.GetAccessLog,
 		"getErrorPages":          label.GetErrorPages,
 		"getRateLimit":           label.GetRateLimit,
 		"getHeaders":             label.GetHeaders,
@@ -74,7 +74,7 @@ func (p *Provider) buildConfigurationV2(catalog []catalogUpdate) *types.Configur
 
 	configuration, err := p.GetConfiguration("templates/consul_catalog.tmpl", funcMap, templateObjects)
 	if err != nil {
-		log.WithError(err).Error("Failed to create config")
+		logger.WithError(err).Error("Failed to create config")
 	}
 
 	return configuration
@@ -91,7 +91,7 @@ func (p *Provider) getFrontendRule(service serviceUpdate) string {
 	tmpl := p.frontEndRuleTemplate
 	tmpl, err := tmpl.Parse(customFrontendRule)
 	if err != nil {
-		log.Errorf("Failed to parse Consul Catalog custom frontend rule: %v", err)
+		logger.Errorf("Failed to parse Consul Catalog custom frontend rule: %v", err)
 		return ""
 	}
 
@@ -108,7 +108,7 @@ func (p *Provider) getFrontendRule(service serviceUpdate) string {
 	var buffer bytes.Buffer
 	err = tmpl.Execute(&buffer, templateObjects)
 	if err != nil {
-		log.Errorf("Failed to execute Consul Catalog custom frontend rule template: %v", err)
+		logger.Errorf("Failed to execute Consul Catalog custom frontend rule template: %v", err)
 		return ""
 	}
 
@@ -117,6 +117,10 @@ func (p *Provider) getFrontendRule(service serviceUpdate) string {
 
 func (p *Provider) getServer(node *api.ServiceEntry) types.Server {
 	scheme := p.getAttribute(label.SuffixProtocol, node.Service.Tags, label.DefaultProtocol)
+	if p.isConnectEnabled(node.Service.Tags) {
+		// The proxies of the Connect service mesh only accept mutual TLS connections.
+		scheme = "https"
+	}
 	address := getBackendAddress(node)
 
 	return types.Server{
@@ -140,7 +144,7 @@ func (p *Provider) setupFrontEndRuleTemplate() {
 // Deprecated
 func getLoadBalancer(labels map[string]string) *types.LoadBalancer {
 	if v, ok := labels[label.TraefikBackendLoadBalancer]; ok {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancer, label.TraefikBackendLoadBalancerMethod)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancer, label.TraefikBackendLoadBalancerMethod)
 		if !label.Has(labels, label.TraefikBackendLoadBalancerMethod) {
 			labels[label.TraefikBackendLoadBalancerMethod] = v
 		}
@@ -153,7 +157,7 @@ func getLoadBalancer(labels map[string]string) *types.LoadBalancer {
 // Deprecated
 func getCircuitBreaker(labels map[string]string) *types.CircuitBreaker {
 	if v, ok := labels[label.TraefikBackendCircuitBreaker]; ok {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendCircuitBreaker, label.TraefikBackendCircuitBreakerExpression)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendCircuitBreaker, label.TraefikBackendCircuitBreakerExpression)
 		if !label.Has(labels, label.TraefikBackendCircuitBreakerExpression) {
 			labels[label.TraefikBackendCircuitBreakerExpression] = v
 		}
@@ -189,7 +193,7 @@ func getServerName(node *api.ServiceEntry, index int) string {
 	_, err := hash.Write([]byte(serviceName))
 	if err != nil {
 		// Impossible case
-		log.Error(err)
+		logger.Error(err)
 	} else {
 		serviceName = base64.URLEncoding.EncodeToString(hash.Sum(nil))
 	}
@@ -204,7 +208,7 @@ func (p *Provider) getWeight(tags []string) int {
 	// Deprecated
 	deprecatedWeightTag := "backend." + label.SuffixWeight
 	if p.hasAttribute(deprecatedWeightTag, tags) {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.",
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.",
 			p.getPrefixedName(deprecatedWeightTag), p.getPrefixedName(label.SuffixWeight))
 
 		weight = p.getIntAttribute(deprecatedWeightTag, tags, label.DefaultWeight)
@@ -823,6 +823,67 @@ func TestProviderBuildConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "Should build config with a backend of the Connect service mesh",
+			nodes: []catalogUpdate{
+				{
+					Service: &serviceUpdate{
+						ServiceName: "test",
+						Attributes:  []string{},
+						TLS: &types.BackendTLS{
+							CA:          "ca",
+							Cert:        "cert",
+							Key:         "key",
+							TrustedURIs: []string{"spiffe://11111111-2222-3333-4444-555555555555.consul/ns/default/dc/dc1/svc/test"},
+						},
+					},
+					Nodes: []*api.ServiceEntry{
+						{
+							Service: &api.AgentService{
+								Service: "test",
+								Address: "127.0.0.1",
+								Port:    21000,
+								Tags: []string{
+									label.TraefikProtocol + "=https",
+								},
+							},
+							Node: &api.Node{
+								Node:    "localhost",
+								Address: "127.0.0.1",
+							},
+						},
+					},
+				},
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-test": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					Routes: map[string]types.Route{
+						"route-host-test": {
+							Rule: "Host:test.localhost",
+						},
+					},
+					EntryPoints: []string{},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"test-0-Fmb-WTBDS-WIEFYYhO-i9Cju1mk": {
+							URL:    "https://127.0.0.1:21000",
+							Weight: label.DefaultWeight,
+						},
+					},
+					TLS: &types.BackendTLS{
+						CA:          "ca",
+						Cert:        "cert",
+						Key:         "key",
+						TrustedURIs: []string{"spiffe://11111111-2222-3333-4444-555555555555.consul/ns/default/dc/dc1/svc/test"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
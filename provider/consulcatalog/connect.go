@@ -0,0 +1,314 @@
+package consulcatalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/types"
+	"github.com/hashicorp/consul/api"
+)
+
+const (
+	connectRootsEndpoint      = "/v1/agent/connect/ca/roots"
+	connectLeafEndpoint       = "/v1/agent/connect/ca/leaf/"
+	connectAuthorizeEndpoint  = "/v1/agent/connect/authorize"
+	connectIntentionsEndpoint = "/v1/connect/intentions"
+	connectHealthEndpoint     = "/v1/health/connect/"
+
+	// connectNamespace is the namespace of the services in the SPIFFE IDs, namespaces are an enterprise feature of Consul
+	connectNamespace = "default"
+)
+
+// connectCARoots is the response of the Consul agent to a CA roots request
+type connectCARoots struct {
+	TrustDomain string
+	Roots       []connectCARoot
+}
+
+// connectCARoot is a CA root certificate of the Connect service mesh
+type connectCARoot struct {
+	ID          string
+	RootCertPEM string `json:"RootCert"`
+	Active      bool
+}
+
+// connectLeafCert is the leaf certificate identifying Traefik in the Connect service mesh
+type connectLeafCert struct {
+	SerialNumber  string
+	CertPEM       string
+	PrivateKeyPEM string
+	Service       string
+	ServiceURI    string
+}
+
+// connectAuthorizeRequest is the request asking the Consul agent whether the intentions allow a connection
+type connectAuthorizeRequest struct {
+	Target           string
+	ClientCertURI    string
+	ClientCertSerial string
+}
+
+// connectAuthorizeResponse is the response of the Consul agent to an authorize request
+type connectAuthorizeResponse struct {
+	Authorized bool
+	Reason     string
+}
+
+// isConnectEnabled tells whether the servers of a service are reached through the Connect service mesh
+func (p *Provider) isConnectEnabled(tags []string) bool {
+	if !p.ConnectAware {
+		return false
+	}
+
+	rawValue := p.getAttribute(suffixConnect, tags, "")
+	if len(rawValue) == 0 {
+		return p.ConnectByDefault
+	}
+
+	value, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		logger.Errorf("Invalid value for %s: %s", suffixConnect, rawValue)
+		return p.ConnectByDefault
+	}
+	return value
+}
+
+// watchConnect watches the CA roots, the leaf certificate of Traefik and the intentions of the Connect service mesh.
+// The services are listed to watchCh on each change, so that the TLS configuration of their backends is updated
+// and the connections to them are authorized again.
+func (p *Provider) watchConnect(stopCh <-chan struct{}, watchCh chan<- map[string][]string, notifyError func(error)) {
+	p.watchConnectEndpoint(stopCh, watchCh, notifyError, "CA roots", p.loadConnectRoots)
+	p.watchConnectEndpoint(stopCh, watchCh, notifyError, "leaf certificate", p.loadConnectLeaf)
+	p.watchConnectEndpoint(stopCh, watchCh, notifyError, "intentions", p.loadConnectIntentions)
+}
+
+func (p *Provider) watchConnectEndpoint(stopCh <-chan struct{}, watchCh chan<- map[string][]string, notifyError func(error),
+	name string, load func(options *api.QueryOptions) (*api.QueryMeta, error)) {
+	catalog := p.client.Catalog()
+
+	safe.Go(func() {
+		options := &api.QueryOptions{WaitTime: DefaultWatchWaitTime}
+
+		for {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+
+			meta, err := load(options)
+			if err != nil {
+				logger.Errorf("Failed to fetch the Connect %s: %v", name, err)
+				notifyError(err)
+				return
+			}
+
+			// If LastIndex didn't change then it means `Get` returned
+			// because of the WaitTime and the watched endpoint didn't change.
+			if options.WaitIndex == meta.LastIndex {
+				continue
+			}
+
+			options.WaitIndex = meta.LastIndex
+
+			if p.getConnectTLS() == nil {
+				continue
+			}
+
+			data, _, err := catalog.Services(&api.QueryOptions{AllowStale: p.Stale})
+			if err != nil {
+				logger.Errorf("Failed to list services: %v", err)
+				notifyError(err)
+				return
+			}
+
+			logger.Debugf("Connect %s changed", name)
+			watchCh <- data
+		}
+	})
+}
+
+func (p *Provider) loadConnectRoots(options *api.QueryOptions) (*api.QueryMeta, error) {
+	var roots connectCARoots
+	meta, err := p.client.Raw().Query(connectRootsEndpoint, &roots, options)
+	if err != nil {
+		return nil, err
+	}
+
+	p.connectRoots.Set(&roots)
+	return meta, nil
+}
+
+func (p *Provider) loadConnectLeaf(options *api.QueryOptions) (*api.QueryMeta, error) {
+	var leaf connectLeafCert
+	meta, err := p.client.Raw().Query(connectLeafEndpoint+url.PathEscape(p.ServiceName), &leaf, options)
+	if err != nil {
+		return nil, err
+	}
+
+	p.connectLeaf.Set(&leaf)
+	return meta, nil
+}
+
+// loadConnectIntentions only tracks the index of the intentions, the agent is asked to authorize each connection.
+func (p *Provider) loadConnectIntentions(options *api.QueryOptions) (*api.QueryMeta, error) {
+	var intentions []json.RawMessage
+	return p.client.Raw().Query(connectIntentionsEndpoint, &intentions, options)
+}
+
+// getConnectTLS returns the TLS configuration of the backend of a Connect service, trusting the given SPIFFE IDs.
+// It returns nil until both the CA roots and the leaf certificate are known.
+func (p *Provider) getConnectTLS(trustedURIs ...string) *types.BackendTLS {
+	roots, _ := p.connectRoots.Get().(*connectCARoots)
+	leaf, _ := p.connectLeaf.Get().(*connectLeafCert)
+	if roots == nil || leaf == nil {
+		return nil
+	}
+
+	// All the roots are trusted, the servers may still use certificates signed by a previous root during a rotation.
+	var rootCerts []string
+	for _, root := range roots.Roots {
+		rootCerts = append(rootCerts, strings.TrimSpace(root.RootCertPEM))
+	}
+
+	return &types.BackendTLS{
+		CA:          strings.Join(rootCerts, "\n"),
+		Cert:        leaf.CertPEM,
+		Key:         leaf.PrivateKeyPEM,
+		TrustedURIs: trustedURIs,
+	}
+}
+
+// getConnectServiceURI returns the SPIFFE ID of a service of the Connect service mesh
+func (p *Provider) getConnectServiceURI(datacenter, service string) string {
+	roots, _ := p.connectRoots.Get().(*connectCARoots)
+	if roots == nil {
+		return ""
+	}
+
+	return (&url.URL{
+		Scheme: "spiffe",
+		Host:   roots.TrustDomain,
+		Path:   fmt.Sprintf("/ns/%s/dc/%s/svc/%s", connectNamespace, datacenter, service),
+	}).String()
+}
+
+// connectAuthorize tells whether the intentions allow Traefik to connect to a service.
+// The request is sent as a POST, which is not supported by the raw queries of the vendored Consul client.
+func (p *Provider) connectAuthorize(target string) (bool, error) {
+	leaf, _ := p.connectLeaf.Get().(*connectLeafCert)
+	if leaf == nil {
+		return false, nil
+	}
+
+	body, err := json.Marshal(connectAuthorizeRequest{
+		Target:           target,
+		ClientCertURI:    leaf.ServiceURI,
+		ClientCertSerial: leaf.SerialNumber,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, connectAuthorizeEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	// The request is addressed as the Consul client does: for a unix:// endpoint,
+	// the address is the path of the socket and the HTTP client dials it.
+	req.URL.Scheme = p.clientConfig.Scheme
+	req.URL.Host = p.clientConfig.Address
+	req.Host = p.clientConfig.Address
+	if p.clientConfig.HttpAuth != nil {
+		req.SetBasicAuth(p.clientConfig.HttpAuth.Username, p.clientConfig.HttpAuth.Password)
+	}
+	if p.clientConfig.Token != "" {
+		req.Header.Set("X-Consul-Token", p.clientConfig.Token)
+	}
+
+	resp, err := p.clientConfig.HttpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected response code %d while authorizing the connection to %s", resp.StatusCode, target)
+	}
+
+	var authorization connectAuthorizeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&authorization); err != nil {
+		return false, err
+	}
+
+	if !authorization.Authorized {
+		logger.Debugf("Connection to the Connect service %s denied: %s", target, authorization.Reason)
+	}
+	return authorization.Authorized, nil
+}
+
+// connectNodes replaces the nodes of a Connect service by its proxies, or by its instances when they are Connect native,
+// and sets the TLS configuration of its backend.
+// The service is left aside until the certificates of Traefik are known, or when the intentions deny the connections to it.
+func (p *Provider) connectNodes(update catalogUpdate) (catalogUpdate, error) {
+	serviceName := update.Nodes[0].Service.Service
+
+	if p.getConnectTLS() == nil {
+		logger.Debugf("Connect certificates not available yet, skipping the Connect service %s", serviceName)
+		return catalogUpdate{}, nil
+	}
+
+	authorized, err := p.connectAuthorize(serviceName)
+	if err != nil {
+		logger.WithError(err).Errorf("Failed to authorize the connection to the Connect service %s", serviceName)
+		return catalogUpdate{}, err
+	}
+	if !authorized {
+		return catalogUpdate{}, nil
+	}
+
+	var entries []*api.ServiceEntry
+	_, err = p.client.Raw().Query(connectHealthEndpoint+url.PathEscape(update.Service.ServiceName), &entries, &api.QueryOptions{AllowStale: p.Stale})
+	if err != nil {
+		logger.WithError(err).Errorf("Failed to fetch the Connect proxies of %s", serviceName)
+		return catalogUpdate{}, err
+	}
+
+	// The merged tags of the service are sorted, to keep stable server names across the updates.
+	tags := make([]string, len(update.Service.Attributes))
+	copy(tags, update.Service.Attributes)
+	sort.Strings(tags)
+
+	var nodes []*api.ServiceEntry
+	trustedURIs := make(map[string]struct{})
+	for _, entry := range entries {
+		if !p.hasPassingChecks(entry) {
+			continue
+		}
+
+		// The proxies are registered under their own name and tags, they serve the backend of the service.
+		entry.Service.Service = serviceName
+		entry.Service.Tags = tags
+		nodes = append(nodes, entry)
+
+		trustedURIs[p.getConnectServiceURI(entry.Node.Datacenter, serviceName)] = struct{}{}
+	}
+
+	var uris []string
+	for uri := range trustedURIs {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	update.Service.TLS = p.getConnectTLS(uris...)
+	update.Nodes = nodes
+	return update, nil
+}
@@ -0,0 +1,389 @@
+package consulcatalog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTrustDomain = "11111111-2222-3333-4444-555555555555.consul"
+
+// fakeConnectAgent is a stand-in of the HTTP API of a Consul agent serving the Connect endpoints
+type fakeConnectAgent struct {
+	roots      *connectCARoots
+	leaf       *connectLeafCert
+	authorized map[string]bool
+	services   map[string][]*api.ServiceEntry
+	proxies    map[string][]*api.ServiceEntry
+	catalog    map[string][]string
+}
+
+func (a *fakeConnectAgent) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	var response interface{}
+
+	switch {
+	case req.URL.Path == connectRootsEndpoint && a.roots != nil:
+		response = a.roots
+	case strings.HasPrefix(req.URL.Path, connectLeafEndpoint) && a.leaf != nil:
+		response = a.leaf
+	case req.URL.Path == connectAuthorizeEndpoint && req.Method == http.MethodPost:
+		var authorizeReq connectAuthorizeRequest
+		if err := json.NewDecoder(req.Body).Decode(&authorizeReq); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response = connectAuthorizeResponse{Authorized: a.authorized[authorizeReq.Target], Reason: "intention"}
+	case req.URL.Path == connectIntentionsEndpoint:
+		response = []struct{}{}
+	case req.URL.Path == "/v1/catalog/services":
+		response = a.catalog
+	case strings.HasPrefix(req.URL.Path, "/v1/health/service/"):
+		response = a.services[strings.TrimPrefix(req.URL.Path, "/v1/health/service/")]
+	case strings.HasPrefix(req.URL.Path, connectHealthEndpoint):
+		response = a.proxies[strings.TrimPrefix(req.URL.Path, connectHealthEndpoint)]
+	default:
+		http.NotFound(rw, req)
+		return
+	}
+
+	rw.Header().Set("X-Consul-Index", "1")
+	if err := json.NewEncoder(rw).Encode(response); err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func newConnectTestProvider(t *testing.T, agent *fakeConnectAgent) (*Provider, func()) {
+	server := httptest.NewServer(agent)
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	return newConnectTestProviderWithEndpoint(t, serverURL.Host), server.Close
+}
+
+func newConnectTestProviderWithEndpoint(t *testing.T, endpoint string) *Provider {
+	p := &Provider{
+		Endpoint:         endpoint,
+		Prefix:           "traefik",
+		ExposedByDefault: true,
+		StrictChecks:     true,
+		ConnectAware:     true,
+		ServiceName:      "traefik",
+	}
+
+	var err error
+	p.client, p.clientConfig, err = p.createClient()
+	require.NoError(t, err)
+
+	return p
+}
+
+func newConnectTestEntry(service string, address string, port int, tags ...string) *api.ServiceEntry {
+	return &api.ServiceEntry{
+		Node: &api.Node{
+			Node:       "node1",
+			Address:    address,
+			Datacenter: "dc1",
+		},
+		Service: &api.AgentService{
+			Service: service,
+			Address: address,
+			Port:    port,
+			Tags:    tags,
+		},
+		Checks: api.HealthChecks{
+			{Status: api.HealthPassing},
+		},
+	}
+}
+
+func TestProviderIsConnectEnabled(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		connectAware     bool
+		connectByDefault bool
+		tags             []string
+		expected         bool
+	}{
+		{
+			desc:     "Should be disabled when the provider is not Connect aware",
+			tags:     []string{"traefik.connect=true"},
+			expected: false,
+		},
+		{
+			desc:         "Should be disabled without tag",
+			connectAware: true,
+			tags:         []string{},
+			expected:     false,
+		},
+		{
+			desc:             "Should be enabled by default",
+			connectAware:     true,
+			connectByDefault: true,
+			tags:             []string{},
+			expected:         true,
+		},
+		{
+			desc:         "Should be enabled by tag",
+			connectAware: true,
+			tags:         []string{"traefik.connect=true"},
+			expected:     true,
+		},
+		{
+			desc:             "Should be disabled by tag",
+			connectAware:     true,
+			connectByDefault: true,
+			tags:             []string{"traefik.connect=false"},
+			expected:         false,
+		},
+		{
+			desc:             "Should fall back to the default with an invalid tag",
+			connectAware:     true,
+			connectByDefault: true,
+			tags:             []string{"traefik.connect=foo"},
+			expected:         true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Provider{
+				Prefix:           "traefik",
+				ConnectAware:     test.connectAware,
+				ConnectByDefault: test.connectByDefault,
+			}
+
+			assert.Equal(t, test.expected, p.isConnectEnabled(test.tags))
+		})
+	}
+}
+
+func TestProviderGetConnectTLS(t *testing.T) {
+	p := &Provider{}
+	assert.Nil(t, p.getConnectTLS())
+
+	p.connectRoots.Set(&connectCARoots{
+		TrustDomain: testTrustDomain,
+		Roots: []connectCARoot{
+			{ID: "1", RootCertPEM: "root1\n", Active: false},
+			{ID: "2", RootCertPEM: "root2\n", Active: true},
+		},
+	})
+	assert.Nil(t, p.getConnectTLS())
+
+	p.connectLeaf.Set(&connectLeafCert{CertPEM: "cert", PrivateKeyPEM: "key"})
+
+	backendTLS := p.getConnectTLS("spiffe://" + testTrustDomain + "/ns/default/dc/dc1/svc/web")
+	require.NotNil(t, backendTLS)
+	assert.Equal(t, "root1\nroot2", backendTLS.CA)
+	assert.Equal(t, "cert", backendTLS.Cert)
+	assert.Equal(t, "key", backendTLS.Key)
+	assert.Equal(t, []string{"spiffe://" + testTrustDomain + "/ns/default/dc/dc1/svc/web"}, backendTLS.TrustedURIs)
+
+	assert.Equal(t, "spiffe://"+testTrustDomain+"/ns/default/dc/dc2/svc/api", p.getConnectServiceURI("dc2", "api"))
+}
+
+func TestProviderHealthyNodesConnect(t *testing.T) {
+	roots := &connectCARoots{
+		TrustDomain: testTrustDomain,
+		Roots:       []connectCARoot{{ID: "1", RootCertPEM: "root", Active: true}},
+	}
+	leaf := &connectLeafCert{
+		SerialNumber:  "01",
+		CertPEM:       "cert",
+		PrivateKeyPEM: "key",
+		Service:       "traefik",
+		ServiceURI:    "spiffe://" + testTrustDomain + "/ns/default/dc/dc1/svc/traefik",
+	}
+
+	services := map[string][]*api.ServiceEntry{
+		"web": {
+			newConnectTestEntry("web", "10.0.0.1", 80, "traefik.connect=true", "traefik.frontend.rule=Host:web.localhost"),
+		},
+		"api": {
+			newConnectTestEntry("api", "10.0.0.2", 80, "traefik.connect=true"),
+		},
+		"legacy": {
+			newConnectTestEntry("legacy", "10.0.0.3", 80),
+		},
+	}
+
+	proxies := map[string][]*api.ServiceEntry{
+		"web": {
+			newConnectTestEntry("web-sidecar-proxy", "10.0.0.1", 21000),
+			{
+				Node:    &api.Node{Node: "node2", Address: "10.0.0.4", Datacenter: "dc1"},
+				Service: &api.AgentService{Service: "web-sidecar-proxy", Address: "10.0.0.4", Port: 21000},
+				Checks:  api.HealthChecks{{Status: api.HealthCritical}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc          string
+		service       string
+		roots         *connectCARoots
+		leaf          *connectLeafCert
+		expectedNodes []*api.ServiceEntry
+		expectedTLS   bool
+	}{
+		{
+			desc:    "Should replace the nodes by the healthy proxies",
+			service: "web",
+			roots:   roots,
+			leaf:    leaf,
+			expectedNodes: []*api.ServiceEntry{
+				newConnectTestEntry("web", "10.0.0.1", 21000, "traefik.connect=true", "traefik.frontend.rule=Host:web.localhost"),
+			},
+			expectedTLS: true,
+		},
+		{
+			desc:    "Should skip a service denied by the intentions",
+			service: "api",
+			roots:   roots,
+			leaf:    leaf,
+		},
+		{
+			desc:    "Should skip a service until the certificates are known",
+			service: "web",
+			roots:   roots,
+		},
+		{
+			desc:    "Should keep the nodes of a service outside of the Connect service mesh",
+			service: "legacy",
+			roots:   roots,
+			leaf:    leaf,
+			expectedNodes: []*api.ServiceEntry{
+				newConnectTestEntry("legacy", "10.0.0.3", 80),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			agent := &fakeConnectAgent{
+				roots:      test.roots,
+				leaf:       test.leaf,
+				authorized: map[string]bool{"web": true},
+				services:   services,
+				proxies:    proxies,
+			}
+
+			p, closeServer := newConnectTestProvider(t, agent)
+			defer closeServer()
+
+			if test.roots != nil {
+				_, err := p.loadConnectRoots(&api.QueryOptions{})
+				require.NoError(t, err)
+			}
+			if test.leaf != nil {
+				_, err := p.loadConnectLeaf(&api.QueryOptions{})
+				require.NoError(t, err)
+			}
+
+			update, err := p.healthyNodes(test.service)
+			require.NoError(t, err)
+
+			if test.expectedNodes == nil {
+				assert.Empty(t, update.Nodes)
+				return
+			}
+
+			require.NotNil(t, update.Service)
+			assert.Equal(t, test.expectedNodes, update.Nodes)
+
+			if !test.expectedTLS {
+				assert.Nil(t, update.Service.TLS)
+				return
+			}
+
+			require.NotNil(t, update.Service.TLS)
+			assert.Equal(t, "root", update.Service.TLS.CA)
+			assert.Equal(t, "cert", update.Service.TLS.Cert)
+			assert.Equal(t, "key", update.Service.TLS.Key)
+			assert.Equal(t, []string{"spiffe://" + testTrustDomain + "/ns/default/dc/dc1/svc/" + test.service}, update.Service.TLS.TrustedURIs)
+		})
+	}
+}
+
+func TestProviderConnectAuthorizeUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "consul")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "consul.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	agent := &fakeConnectAgent{
+		leaf:       &connectLeafCert{SerialNumber: "01", ServiceURI: "spiffe://" + testTrustDomain + "/ns/default/dc/dc1/svc/traefik"},
+		authorized: map[string]bool{"web": true},
+	}
+
+	server := &httptest.Server{Listener: listener, Config: &http.Server{Handler: agent}}
+	server.Start()
+	defer server.Close()
+
+	p := newConnectTestProviderWithEndpoint(t, "unix://"+socket)
+
+	_, err = p.loadConnectLeaf(&api.QueryOptions{})
+	require.NoError(t, err)
+
+	authorized, err := p.connectAuthorize("web")
+	require.NoError(t, err)
+	assert.True(t, authorized)
+
+	authorized, err = p.connectAuthorize("api")
+	require.NoError(t, err)
+	assert.False(t, authorized)
+}
+
+func TestProviderWatchConnectIntentions(t *testing.T) {
+	agent := &fakeConnectAgent{
+		roots: &connectCARoots{
+			TrustDomain: testTrustDomain,
+			Roots:       []connectCARoot{{ID: "1", RootCertPEM: "root", Active: true}},
+		},
+		leaf:    &connectLeafCert{SerialNumber: "01", CertPEM: "cert", PrivateKeyPEM: "key"},
+		catalog: map[string][]string{"web": {"traefik.connect=true"}},
+	}
+
+	p, closeServer := newConnectTestProvider(t, agent)
+	defer closeServer()
+
+	_, err := p.loadConnectRoots(&api.QueryOptions{})
+	require.NoError(t, err)
+	_, err = p.loadConnectLeaf(&api.QueryOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	watchCh := make(chan map[string][]string)
+
+	p.watchConnectEndpoint(stopCh, watchCh, func(err error) { t.Error(err) }, "intentions", p.loadConnectIntentions)
+
+	select {
+	case services := <-watchCh:
+		assert.Equal(t, agent.catalog, services)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the services were not listed on the change of the intentions")
+	}
+}
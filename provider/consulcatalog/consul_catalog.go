@@ -19,9 +19,14 @@ import (
 	"github.com/hashicorp/consul/api"
 )
 
+var logger = log.WithModule("provider/consulcatalog")
+
 const (
 	// DefaultWatchWaitTime is the duration to wait when polling consul
 	DefaultWatchWaitTime = 15 * time.Second
+
+	// suffixConnect is the suffix of the tag enabling the Connect service mesh for a service
+	suffixConnect = "connect"
 )
 
 var _ provider.Provider = (*Provider)(nil)
@@ -37,8 +42,14 @@ type Provider struct {
 	StrictChecks          bool             `description:"Keep a Consul node only if all checks status are passing" export:"true"`
 	FrontEndRule          string           `description:"Frontend rule used for Consul services" export:"true"`
 	TLS                   *types.ClientTLS `description:"Enable TLS support" export:"true"`
+	ConnectAware          bool             `description:"Enable Consul Connect support" export:"true"`
+	ConnectByDefault      bool             `description:"Consider every service as Connect capable by default" export:"true"`
+	ServiceName           string           `description:"Name of the Traefik service in Consul Catalog, identifying Traefik in the Connect service mesh" export:"true"`
 	client                *api.Client
+	clientConfig          *api.Config
 	frontEndRuleTemplate  *template.Template
+	connectRoots          safe.Safe
+	connectLeaf           safe.Safe
 }
 
 // Service represent a Consul service.
@@ -55,6 +66,7 @@ type serviceUpdate struct {
 	ParentServiceName string
 	Attributes        []string
 	TraefikLabels     map[string]string
+	TLS               *types.BackendTLS
 }
 
 type frontendSegment struct {
@@ -103,12 +115,13 @@ func (p *Provider) Init(constraints types.Constraints) error {
 		return err
 	}
 
-	client, err := p.createClient()
+	client, config, err := p.createClient()
 	if err != nil {
 		return err
 	}
 
 	p.client = client
+	p.clientConfig = config
 	p.setupFrontEndRuleTemplate()
 
 	return nil
@@ -119,26 +132,27 @@ func (p *Provider) Init(constraints types.Constraints) error {
 func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *safe.Pool) error {
 	pool.Go(func(stop chan bool) {
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Consul connection error %+v, retrying in %s", err, time)
+			logger.Errorf("Consul connection error %+v, retrying in %s", err, time)
 		}
 		operation := func() error {
 			return p.watch(configurationChan, stop)
 		}
 		errRetry := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 		if errRetry != nil {
-			log.Errorf("Cannot connect to consul server %+v", errRetry)
+			logger.Errorf("Cannot connect to consul server %+v", errRetry)
 		}
 	})
 	return nil
 }
 
-func (p *Provider) createClient() (*api.Client, error) {
+// createClient returns the Consul client, along with its configuration completed by the client.
+func (p *Provider) createClient() (*api.Client, *api.Config, error) {
 	config := api.DefaultConfig()
 	config.Address = p.Endpoint
 	if p.TLS != nil {
 		tlsConfig, err := p.TLS.CreateTLSConfig()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		config.Scheme = "https"
@@ -147,10 +161,10 @@ func (p *Provider) createClient() (*api.Client, error) {
 
 	client, err := api.NewClient(config)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return client, nil
+	return client, config, nil
 }
 
 func (p *Provider) watch(configurationChan chan<- types.ConfigMessage, stop chan bool) error {
@@ -167,13 +181,16 @@ func (p *Provider) watch(configurationChan chan<- types.ConfigMessage, stop chan
 
 	p.watchHealthState(stopCh, watchCh, notifyError)
 	p.watchCatalogServices(stopCh, watchCh, notifyError)
+	if p.ConnectAware {
+		p.watchConnect(stopCh, watchCh, notifyError)
+	}
 
 	defer close(stopCh)
 	defer close(watchCh)
 
 	safe.Go(func() {
 		for index := range watchCh {
-			log.Debug("List of services changed")
+			logger.Debug("List of services changed")
 			nodes, err := p.getNodes(index)
 			if err != nil {
 				notifyError(err)
@@ -214,7 +231,7 @@ func (p *Provider) watchCatalogServices(stopCh <-chan struct{}, watchCh chan<- m
 
 			data, meta, err := catalog.Services(options)
 			if err != nil {
-				log.Errorf("Failed to list services: %v", err)
+				logger.Errorf("Failed to list services: %v", err)
 				notifyError(err)
 				return
 			}
@@ -230,7 +247,7 @@ func (p *Provider) watchCatalogServices(stopCh <-chan struct{}, watchCh chan<- m
 				for key, value := range data {
 					nodes, _, err := catalog.Service(key, "", &api.QueryOptions{AllowStale: p.Stale})
 					if err != nil {
-						log.Errorf("Failed to get detail of service %s: %v", key, err)
+						logger.Errorf("Failed to get detail of service %s: %v", key, err)
 						notifyError(err)
 						return
 					}
@@ -288,7 +305,7 @@ func (p *Provider) watchHealthState(stopCh <-chan struct{}, watchCh chan<- map[s
 			// Listening to changes that leads to `passing` state or degrades from it.
 			healthyState, meta, err := health.State("any", options)
 			if err != nil {
-				log.WithError(err).Error("Failed to retrieve health checks")
+				logger.WithError(err).Error("Failed to retrieve health checks")
 				notifyError(err)
 				return
 			}
@@ -326,7 +343,7 @@ func (p *Provider) watchHealthState(stopCh <-chan struct{}, watchCh chan<- map[s
 			// The response should be unified with watchCatalogServices
 			data, _, err := catalog.Services(&api.QueryOptions{AllowStale: p.Stale})
 			if err != nil {
-				log.Errorf("Failed to list services: %v", err)
+				logger.Errorf("Failed to list services: %v", err)
 				notifyError(err)
 				return
 			}
@@ -338,7 +355,7 @@ func (p *Provider) watchHealthState(stopCh <-chan struct{}, watchCh chan<- map[s
 				addedKeys, removedKeys, changedKeys := getChangedHealth(current, flashback)
 
 				if len(addedKeys) > 0 || len(removedKeys) > 0 || len(changedKeys) > 0 {
-					log.WithField("DiscoveredServices", addedKeys).
+					logger.WithField("DiscoveredServices", addedKeys).
 						WithField("MissingServices", removedKeys).
 						WithField("ChangedServices", changedKeys).
 						Debug("Health State change detected.")
@@ -350,7 +367,7 @@ func (p *Provider) watchHealthState(stopCh <-chan struct{}, watchCh chan<- map[s
 					addedKeysMaintenance, removedMaintenance := getChangedStringKeys(maintenance, flashbackMaintenance)
 
 					if len(addedKeysMaintenance) > 0 || len(removedMaintenance) > 0 {
-						log.WithField("MaintenanceMode", maintenance).Debug("Maintenance change detected.")
+						logger.WithField("MaintenanceMode", maintenance).Debug("Maintenance change detected.")
 						watchCh <- data
 						flashback = current
 						flashbackMaintenance = maintenance
@@ -369,7 +386,7 @@ func (p *Provider) getNodes(index map[string][]string) ([]catalogUpdate, error)
 		name := strings.ToLower(service)
 		if !strings.Contains(name, " ") && !visited[name] {
 			visited[name] = true
-			log.WithField("service", name).Debug("Fetching service")
+			logger.WithField("service", name).Debug("Fetching service")
 			healthy, err := p.healthyNodes(name)
 			if err != nil {
 				return nil, err
@@ -495,7 +512,7 @@ func (p *Provider) healthyNodes(service string) (catalogUpdate, error) {
 	// You can't filter with assigning passingOnly here, nodeFilter will do this later
 	data, _, err := health.Service(service, "", false, &api.QueryOptions{AllowStale: p.Stale})
 	if err != nil {
-		log.WithError(err).Errorf("Failed to fetch details of %s", service)
+		logger.WithError(err).Errorf("Failed to fetch details of %s", service)
 		return catalogUpdate{}, err
 	}
 
@@ -513,20 +530,25 @@ func (p *Provider) healthyNodes(service string) (catalogUpdate, error) {
 
 	labels := tagsToNeutralLabels(tags, p.Prefix)
 
-	return catalogUpdate{
+	update := catalogUpdate{
 		Service: &serviceUpdate{
 			ServiceName:   service,
 			Attributes:    tags,
 			TraefikLabels: labels,
 		},
 		Nodes: nodes,
-	}, nil
+	}
+
+	if len(nodes) > 0 && p.isConnectEnabled(tags) {
+		return p.connectNodes(update)
+	}
+	return update, nil
 }
 
 func (p *Provider) nodeFilter(service string, node *api.ServiceEntry) bool {
 	// Filter disabled application.
 	if !p.isServiceEnabled(node) {
-		log.Debugf("Filtering disabled Consul service %s", service)
+		logger.Debugf("Filtering disabled Consul service %s", service)
 		return false
 	}
 
@@ -534,7 +556,7 @@ func (p *Provider) nodeFilter(service string, node *api.ServiceEntry) bool {
 	constraintTags := p.getConstraintTags(node.Service.Tags)
 	ok, failingConstraint := p.MatchConstraints(constraintTags)
 	if !ok && failingConstraint != nil {
-		log.Debugf("Service %v pruned by '%v' constraint", service, failingConstraint.String())
+		logger.Debugf("Service %v pruned by '%v' constraint", service, failingConstraint.String())
 		return false
 	}
 
@@ -550,7 +572,7 @@ func (p *Provider) isServiceEnabled(node *api.ServiceEntry) bool {
 
 	value, err := strconv.ParseBool(rawValue)
 	if err != nil {
-		log.Errorf("Invalid value for %s: %s", label.SuffixEnable, rawValue)
+		logger.Errorf("Invalid value for %s: %s", label.SuffixEnable, rawValue)
 		return p.ExposedByDefault
 	}
 	return value
@@ -585,6 +607,7 @@ func (p *Provider) generateFrontends(service *serviceUpdate) []*serviceUpdate {
 		ParentServiceName: service.ServiceName,
 		Attributes:        service.Attributes,
 		TraefikLabels:     service.TraefikLabels,
+		TLS:               service.TLS,
 	})
 
 	// loop over children of <prefix>.frontends.*
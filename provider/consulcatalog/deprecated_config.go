@@ -7,7 +7,6 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
 	"github.com/hashicorp/consul/api"
@@ -62,7 +61,7 @@ func (p *Provider) buildConfigurationV1(catalog []catalogUpdate) *types.Configur
 
 	configuration, err := p.GetConfiguration("templates/consul_catalog-v1.tmpl", FuncMap, templateObjects)
 	if err != nil {
-		log.WithError(err).Error("Failed to create config")
+		logger.WithError(err).Error("Failed to create config")
 	}
 
 	return configuration
@@ -80,7 +79,7 @@ func (p *Provider) getFrontendRuleV1(service serviceUpdate) string {
 	tmpl := p.frontEndRuleTemplate
 	tmpl, err := tmpl.Parse(customFrontendRule)
 	if err != nil {
-		log.Errorf("Failed to parse Consul Catalog custom frontend rule: %v", err)
+		logger.Errorf("Failed to parse Consul Catalog custom frontend rule: %v", err)
 		return ""
 	}
 
@@ -97,7 +96,7 @@ func (p *Provider) getFrontendRuleV1(service serviceUpdate) string {
 	var buffer bytes.Buffer
 	err = tmpl.Execute(&buffer, templateObjects)
 	if err != nil {
-		log.Errorf("Failed to execute Consul Catalog custom frontend rule template: %v", err)
+		logger.Errorf("Failed to execute Consul Catalog custom frontend rule template: %v", err)
 		return ""
 	}
 
@@ -122,7 +121,7 @@ func getEntryPointsV1(list string) []string {
 func (p *Provider) getStickyV1(tags []string) string {
 	stickyTag := p.getAttribute(label.SuffixBackendLoadBalancerSticky, tags, "")
 	if len(stickyTag) > 0 {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
 	} else {
 		stickyTag = "false"
 	}
@@ -179,7 +178,7 @@ func (p *Provider) getInt64Attribute(name string, tags []string, defaultValue in
 
 	value, err := strconv.ParseInt(rawValue, 10, 64)
 	if err != nil {
-		log.Errorf("Invalid value for %s: %s", name, rawValue)
+		logger.Errorf("Invalid value for %s: %s", name, rawValue)
 		return defaultValue
 	}
 	return value
@@ -195,7 +194,7 @@ func (p *Provider) getIntAttribute(name string, tags []string, defaultValue int)
 
 	value, err := strconv.Atoi(rawValue)
 	if err != nil {
-		log.Errorf("Invalid value for %s: %s", name, rawValue)
+		logger.Errorf("Invalid value for %s: %s", name, rawValue)
 		return defaultValue
 	}
 	return value
@@ -221,7 +220,7 @@ func (p *Provider) getBoolAttribute(name string, tags []string, defaultValue boo
 
 	value, err := strconv.ParseBool(rawValue)
 	if err != nil {
-		log.Errorf("Invalid value for %s: %s", name, rawValue)
+		logger.Errorf("Invalid value for %s: %s", name, rawValue)
 		return defaultValue
 	}
 	return value
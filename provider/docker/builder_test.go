@@ -79,7 +79,8 @@ func ipv4(ip string) func(*network.EndpointSettings) {
 
 func swarmTask(id string, ops ...func(*swarm.Task)) swarm.Task {
 	task := &swarm.Task{
-		ID: id,
+		ID:           id,
+		DesiredState: swarm.TaskStateRunning,
 	}
 
 	for _, op := range ops {
@@ -95,6 +96,12 @@ func taskSlot(slot int) func(*swarm.Task) {
 	}
 }
 
+func taskDesiredState(state swarm.TaskState) func(*swarm.Task) {
+	return func(task *swarm.Task) {
+		task.DesiredState = state
+	}
+}
+
 func taskNetworkAttachment(id string, name string, driver string, addresses []string) func(*swarm.Task) {
 	return func(task *swarm.Task) {
 		task.NetworksAttachments = append(task.NetworksAttachments, swarm.NetworkAttachment{
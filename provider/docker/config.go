@@ -11,7 +11,6 @@ import (
 	"text/template"
 
 	"github.com/BurntSushi/ty/fun"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -23,6 +22,7 @@ const (
 	labelBackendLoadBalancerSwarm = "traefik.backend.loadbalancer.swarm"
 	labelDockerComposeProject     = "com.docker.compose.project"
 	labelDockerComposeService     = "com.docker.compose.service"
+	labelDockerSwarmTaskID        = "com.docker.swarm.task.id"
 )
 
 func (p *Provider) buildConfigurationV2(containersInspected []dockerData) *types.Configuration {
@@ -53,6 +53,7 @@ func (p *Provider) buildConfigurationV2(containersInspected []dockerData) *types
 		"getAuth":              label.GetAuth,
 		"getFrontendRule":      p.getFrontendRule,
 		"getRedirect":          label.GetRedirect,
+		"getAccessLog":         label.GetAccessLog,
 		"getErrorPages":        label.GetErrorPages,
 		"getRateLimit":         label.GetRateLimit,
 		"getHeaders":           label.GetHeaders,
@@ -105,7 +106,7 @@ func (p *Provider) buildConfigurationV2(containersInspected []dockerData) *types
 
 	configuration, err := p.GetConfiguration("templates/docker.tmpl", dockerFuncMap, templateObjects)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 
 	return configuration
@@ -121,7 +122,7 @@ func getServiceNameKey(container dockerData, swarmMode bool, segmentName string)
 
 func (p *Provider) containerFilter(container dockerData) bool {
 	if !label.IsEnabled(container.Labels, p.ExposedByDefault) {
-		log.Debugf("Filtering disabled container %s", container.Name)
+		logger.Debugf("Filtering disabled container %s", container.Name)
 		return false
 	}
 
@@ -132,26 +133,26 @@ func (p *Provider) containerFilter(container dockerData) bool {
 		errPort = checkSegmentPort(labels, segmentName)
 
 		if len(p.getFrontendRule(container, labels)) == 0 {
-			log.Debugf("Filtering container with empty frontend rule %s %s", container.Name, segmentName)
+			logger.Debugf("Filtering container with empty frontend rule %s %s", container.Name, segmentName)
 			return false
 		}
 	}
 
 	if len(container.NetworkSettings.Ports) == 0 && errPort != nil {
-		log.Debugf("Filtering container without port, %s: %v", container.Name, errPort)
+		logger.Debugf("Filtering container without port, %s: %v", container.Name, errPort)
 		return false
 	}
 
 	constraintTags := label.SplitAndTrimString(container.Labels[label.TraefikTags], ",")
 	if ok, failingConstraint := p.MatchConstraints(constraintTags); !ok {
 		if failingConstraint != nil {
-			log.Debugf("Container %s pruned by %q constraint", container.Name, failingConstraint.String())
+			logger.Debugf("Container %s pruned by %q constraint", container.Name, failingConstraint.String())
 		}
 		return false
 	}
 
 	if container.Health != "" && container.Health != "healthy" {
-		log.Debugf("Filtering unhealthy or starting container %s", container.Name)
+		logger.Debugf("Filtering unhealthy or starting container %s", container.Name)
 		return false
 	}
 
@@ -211,7 +212,7 @@ func (p Provider) getIPAddress(container dockerData) string {
 				return network.Addr
 			}
 
-			log.Warnf("Could not find network named '%s' for container '%s'! Maybe you're missing the project's prefix in the label? Defaulting to first available network.", value, container.Name)
+			logger.Warnf("Could not find network named '%s' for container '%s'! Maybe you're missing the project's prefix in the label? Defaulting to first available network.", value, container.Name)
 		}
 	}
 
@@ -227,14 +228,14 @@ func (p Provider) getIPAddress(container dockerData) string {
 	if container.NetworkSettings.NetworkMode.IsContainer() {
 		dockerClient, err := p.createClient()
 		if err != nil {
-			log.Warnf("Unable to get IP address for container %s, error: %s", container.Name, err)
+			logger.Warnf("Unable to get IP address for container %s, error: %s", container.Name, err)
 			return ""
 		}
 
 		connectedContainer := container.NetworkSettings.NetworkMode.ConnectedContainer()
 		containerInspected, err := dockerClient.ContainerInspect(context.Background(), connectedContainer)
 		if err != nil {
-			log.Warnf("Unable to get IP address for container %s : Failed to inspect container ID %s, error: %s", container.Name, connectedContainer, err)
+			logger.Warnf("Unable to get IP address for container %s : Failed to inspect container ID %s, error: %s", container.Name, connectedContainer, err)
 			return ""
 		}
 		return p.getIPAddress(parseContainer(containerInspected))
@@ -244,7 +245,7 @@ func (p Provider) getIPAddress(container dockerData) string {
 		return network.Addr
 	}
 
-	log.Warnf("Unable to find the IP address for the container %q.", container.Name)
+	logger.Warnf("Unable to find the IP address for the container %q.", container.Name)
 	return ""
 }
 
@@ -252,7 +253,7 @@ func (p Provider) getIPAddress(container dockerData) string {
 func (p *Provider) getDeprecatedIPAddress(container dockerData) string {
 	ip, _, err := p.getIPPort(container)
 	if err != nil {
-		log.Warn(err)
+		logger.Warn(err)
 		return ""
 	}
 	return ip
@@ -346,9 +347,9 @@ func (p *Provider) getIPPort(container dockerData) (string, string, error) {
 	if p.UseBindPortIP {
 		portBinding, err := p.getPortBinding(container)
 		if err != nil {
-			log.Infof("Unable to find a binding for container %q, falling back on its internal IP/Port.", container.Name)
+			logger.Infof("Unable to find a binding for container %q, falling back on its internal IP/Port.", container.Name)
 		} else if (portBinding.HostIP == "0.0.0.0") || (len(portBinding.HostIP) == 0) {
-			log.Infof("Cannot determine the IP address (got %q) for %q's binding, falling back on its internal IP/Port.", portBinding.HostIP, container.Name)
+			logger.Infof("Cannot determine the IP address (got %q) for %q's binding, falling back on its internal IP/Port.", portBinding.HostIP, container.Name)
 		} else {
 			ip = portBinding.HostIP
 			port = portBinding.HostPort
@@ -374,7 +375,7 @@ func (p *Provider) getServers(containers []dockerData) map[string]types.Server {
 	for _, container := range containers {
 		ip, port, err := p.getIPPort(container)
 		if err != nil {
-			log.Warn(err)
+			logger.Warn(err)
 			continue
 		}
 
@@ -388,7 +389,7 @@ func (p *Provider) getServers(containers []dockerData) map[string]types.Server {
 
 		serverName := getServerName(container.Name, serverURL)
 		if _, exist := servers[serverName]; exist {
-			log.Debugf("Skipping server %q with the same URL.", serverName)
+			logger.Debugf("Skipping server %q with the same URL.", serverName)
 			continue
 		}
 
@@ -406,7 +407,7 @@ func getServerName(containerName, url string) string {
 	_, err := hash.Write([]byte(url))
 	if err != nil {
 		// Impossible case
-		log.Errorf("Fail to hash server URL %q", url)
+		logger.Errorf("Fail to hash server URL %q", url)
 	}
 
 	return provider.Normalize("server-" + containerName + "-" + hex.EncodeToString(hash.Sum(nil)))
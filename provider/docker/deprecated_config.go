@@ -8,7 +8,6 @@ import (
 	"text/template"
 
 	"github.com/BurntSushi/ty/fun"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
 )
@@ -158,7 +157,7 @@ func (p *Provider) buildConfigurationV1(containersInspected []dockerData) *types
 
 	configuration, err := p.GetConfiguration("templates/docker-v1.tmpl", DockerFuncMap, templateObjects)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 
 	return configuration
@@ -167,7 +166,7 @@ func (p *Provider) buildConfigurationV1(containersInspected []dockerData) *types
 // Deprecated
 func (p Provider) containerFilterV1(container dockerData) bool {
 	if !label.IsEnabled(container.Labels, p.ExposedByDefault) {
-		log.Debugf("Filtering disabled container %s", container.Name)
+		logger.Debugf("Filtering disabled container %s", container.Name)
 		return false
 	}
 
@@ -180,25 +179,25 @@ func (p Provider) containerFilterV1(container dockerData) bool {
 		_, err = strconv.Atoi(container.Labels[label.TraefikPort])
 	}
 	if len(container.NetworkSettings.Ports) == 0 && err != nil {
-		log.Debugf("Filtering container without port and no %s %s : %s", portLabel, container.Name, err.Error())
+		logger.Debugf("Filtering container without port and no %s %s : %s", portLabel, container.Name, err.Error())
 		return false
 	}
 
 	constraintTags := label.SplitAndTrimString(container.Labels[label.TraefikTags], ",")
 	if ok, failingConstraint := p.MatchConstraints(constraintTags); !ok {
 		if failingConstraint != nil {
-			log.Debugf("Container %v pruned by '%v' constraint", container.Name, failingConstraint.String())
+			logger.Debugf("Container %v pruned by '%v' constraint", container.Name, failingConstraint.String())
 		}
 		return false
 	}
 
 	if container.Health != "" && container.Health != "healthy" {
-		log.Debugf("Filtering unhealthy or starting container %s", container.Name)
+		logger.Debugf("Filtering unhealthy or starting container %s", container.Name)
 		return false
 	}
 
 	if len(p.getFrontendRuleV1(container)) == 0 {
-		log.Debugf("Filtering container with empty frontend rule %s", container.Name)
+		logger.Debugf("Filtering container with empty frontend rule %s", container.Name)
 		return false
 	}
 
@@ -214,7 +213,7 @@ func (p Provider) getIPAddressV1(container dockerData) string {
 				return network.Addr
 			}
 
-			log.Warnf("Could not find network named '%s' for container '%s'! Maybe you're missing the project's prefix in the label? Defaulting to first available network.", value, container.Name)
+			logger.Warnf("Could not find network named '%s' for container '%s'! Maybe you're missing the project's prefix in the label? Defaulting to first available network.", value, container.Name)
 		}
 	}
 
@@ -230,14 +229,14 @@ func (p Provider) getIPAddressV1(container dockerData) string {
 	if container.NetworkSettings.NetworkMode.IsContainer() {
 		dockerClient, err := p.createClient()
 		if err != nil {
-			log.Warnf("Unable to get IP address for container %s, error: %s", container.Name, err)
+			logger.Warnf("Unable to get IP address for container %s, error: %s", container.Name, err)
 			return ""
 		}
 
 		connectedContainer := container.NetworkSettings.NetworkMode.ConnectedContainer()
 		containerInspected, err := dockerClient.ContainerInspect(context.Background(), connectedContainer)
 		if err != nil {
-			log.Warnf("Unable to get IP address for container %s : Failed to inspect container ID %s, error: %s", container.Name, connectedContainer, err)
+			logger.Warnf("Unable to get IP address for container %s : Failed to inspect container ID %s, error: %s", container.Name, connectedContainer, err)
 			return ""
 		}
 		return p.getIPAddress(parseContainer(containerInspected))
@@ -258,6 +257,6 @@ func (p Provider) getIPAddressV1(container dockerData) string {
 		return network.Addr
 	}
 
-	log.Warnf("Unable to find the IP address for the container %q.", container.Name)
+	logger.Warnf("Unable to find the IP address for the container %q.", container.Name)
 	return ""
 }
@@ -6,7 +6,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/docker/go-connections/nat"
@@ -82,7 +81,7 @@ func getPortV1(container dockerData) string {
 // Deprecated
 func getStickyV1(container dockerData) bool {
 	if label.Has(container.Labels, label.TraefikBackendLoadBalancerSticky) {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
 	}
 
 	return label.GetBoolValue(container.Labels, label.TraefikBackendLoadBalancerSticky, false)
@@ -191,7 +190,7 @@ func getFuncMapLabelV1(labelName string) func(container dockerData) map[string]s
 func parseMapLabelV1(container dockerData, labelName string) map[string]string {
 	if parts, err := getLabelV1(container, labelName); err == nil {
 		if len(parts) == 0 {
-			log.Errorf("Could not load %q", labelName)
+			logger.Errorf("Could not load %q", labelName)
 			return nil
 		}
 
@@ -199,14 +198,14 @@ func parseMapLabelV1(container dockerData, labelName string) map[string]string {
 		for _, headers := range strings.Split(parts, "||") {
 			pair := strings.SplitN(headers, ":", 2)
 			if len(pair) != 2 {
-				log.Warnf("Could not load %q: %v, skipping...", labelName, pair)
+				logger.Warnf("Could not load %q: %v, skipping...", labelName, pair)
 			} else {
 				values[http.CanonicalHeaderKey(strings.TrimSpace(pair[0]))] = strings.TrimSpace(pair[1])
 			}
 		}
 
 		if len(values) == 0 {
-			log.Errorf("Could not load %q", labelName)
+			logger.Errorf("Could not load %q", labelName)
 			return nil
 		}
 		return values
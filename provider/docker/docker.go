@@ -27,6 +27,8 @@ import (
 	"github.com/docker/go-connections/sockets"
 )
 
+var logger = log.WithModule("provider/docker")
+
 const (
 	// DockerAPIVersion is a constant holding the version of the Provider API traefik will use
 	DockerAPIVersion = "1.24"
@@ -46,7 +48,7 @@ type Provider struct {
 	UseBindPortIP           bool             `description:"Use the ip address from the bound port, rather than from the inner network" export:"true"`
 	SwarmMode               bool             `description:"Use Docker on Swarm Mode" export:"true"`
 	Network                 string           `description:"Default Docker network used" export:"true"`
-	SwarmModeRefreshSeconds int              `description:"Polling interval for swarm mode (in seconds)" export:"true"`
+	SwarmModeRefreshSeconds int              `description:"Interval of the full resync of the services in swarm mode (in seconds)" export:"true"`
 }
 
 // Init the provider
@@ -131,27 +133,27 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 			defer cancel()
 			dockerClient, err := p.createClient()
 			if err != nil {
-				log.Errorf("Failed to create a client for docker, error: %s", err)
+				logger.Errorf("Failed to create a client for docker, error: %s", err)
 				return err
 			}
 
 			serverVersion, err := dockerClient.ServerVersion(ctx)
 			if err != nil {
-				log.Errorf("Failed to retrieve information of the docker client and server host: %s", err)
+				logger.Errorf("Failed to retrieve information of the docker client and server host: %s", err)
 				return err
 			}
-			log.Debugf("Provider connection established with docker %s (API %s)", serverVersion.Version, serverVersion.APIVersion)
+			logger.Debugf("Provider connection established with docker %s (API %s)", serverVersion.Version, serverVersion.APIVersion)
 			var dockerDataList []dockerData
 			if p.SwarmMode {
 				dockerDataList, err = listServices(ctx, dockerClient)
 				if err != nil {
-					log.Errorf("Failed to list services for docker swarm mode, error %s", err)
+					logger.Errorf("Failed to list services for docker swarm mode, error %s", err)
 					return err
 				}
 			} else {
 				dockerDataList, err = listContainers(ctx, dockerClient)
 				if err != nil {
-					log.Errorf("Failed to list containers for docker, error %s", err)
+					logger.Errorf("Failed to list containers for docker, error %s", err)
 					return err
 				}
 			}
@@ -163,102 +165,163 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 			}
 			if p.Watch {
 				if p.SwarmMode {
-					errChan := make(chan error)
-					// TODO: This need to be change. Linked to Swarm events docker/docker#23827
-					ticker := time.NewTicker(time.Second * time.Duration(p.SwarmModeRefreshSeconds))
-					pool.GoCtx(func(ctx context.Context) {
-						defer close(errChan)
-						for {
-							select {
-							case <-ticker.C:
-								services, err := listServices(ctx, dockerClient)
-								if err != nil {
-									log.Errorf("Failed to list services for docker, error %s", err)
-									errChan <- err
-									return
-								}
-								configuration := p.buildConfiguration(services)
-								if configuration != nil {
-									configurationChan <- types.ConfigMessage{
-										ProviderName:  "docker",
-										Configuration: configuration,
-									}
-								}
-
-							case <-ctx.Done():
-								ticker.Stop()
-								return
-							}
-						}
-					})
-					if err, ok := <-errChan; ok {
-						return err
-					}
-					// channel closed
+					return p.watchSwarm(ctx, dockerClient, configurationChan)
+				}
 
-				} else {
-					f := filters.NewArgs()
-					f.Add("type", "container")
-					options := dockertypes.EventsOptions{
-						Filters: f,
-					}
+				f := filters.NewArgs()
+				f.Add("type", "container")
+				options := dockertypes.EventsOptions{
+					Filters: f,
+				}
 
-					startStopHandle := func(m eventtypes.Message) {
-						log.Debugf("Provider event received %+v", m)
-						containers, err := listContainers(ctx, dockerClient)
-						if err != nil {
-							log.Errorf("Failed to list containers for docker, error %s", err)
-							// Call cancel to get out of the monitor
-							return
+				startStopHandle := func(m eventtypes.Message) {
+					logger.Debugf("Provider event received %+v", m)
+					containers, err := listContainers(ctx, dockerClient)
+					if err != nil {
+						logger.Errorf("Failed to list containers for docker, error %s", err)
+						// Call cancel to get out of the monitor
+						return
+					}
+					configuration := p.buildConfiguration(containers)
+					if configuration != nil {
+						message := types.ConfigMessage{
+							ProviderName:  "docker",
+							Configuration: configuration,
 						}
-						configuration := p.buildConfiguration(containers)
-						if configuration != nil {
-							message := types.ConfigMessage{
-								ProviderName:  "docker",
-								Configuration: configuration,
-							}
-							select {
-							case configurationChan <- message:
-							case <-ctx.Done():
-							}
-
+						select {
+						case configurationChan <- message:
+						case <-ctx.Done():
 						}
+
 					}
+				}
 
-					eventsc, errc := dockerClient.Events(ctx, options)
-					for {
-						select {
-						case event := <-eventsc:
-							if event.Action == "start" ||
-								event.Action == "die" ||
-								strings.HasPrefix(event.Action, "health_status") {
-								startStopHandle(event)
-							}
-						case err := <-errc:
-							if err == io.EOF {
-								log.Debug("Provider event stream closed")
-							}
-							return err
-						case <-ctx.Done():
-							return nil
+				eventsc, errc := dockerClient.Events(ctx, options)
+				for {
+					select {
+					case event := <-eventsc:
+						if event.Action == "start" ||
+							event.Action == "die" ||
+							strings.HasPrefix(event.Action, "health_status") {
+							startStopHandle(event)
+						}
+					case err := <-errc:
+						if err == io.EOF {
+							logger.Debug("Provider event stream closed")
 						}
+						return err
+					case <-ctx.Done():
+						return nil
 					}
 				}
 			}
 			return nil
 		}
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Provider connection error %+v, retrying in %s", err, time)
+			logger.Errorf("Provider connection error %+v, retrying in %s", err, time)
 		}
 		err := backoff.RetryNotify(safe.OperationWithRecover(operation), backoff.WithContext(job.NewBackOff(backoff.NewExponentialBackOff()), routineCtx), notify)
 		if err != nil {
-			log.Errorf("Cannot connect to docker server %+v", err)
+			logger.Errorf("Cannot connect to docker server %+v", err)
 		}
 	})
 
 	return nil
 }
 
+// watchSwarm lists the services again when a service or a task container changes,
+// and every SwarmModeRefreshSeconds as a safety net, as the tasks running on the other nodes send no event.
+func (p *Provider) watchSwarm(ctx context.Context, dockerClient client.APIClient, configurationChan chan<- types.ConfigMessage) error {
+	f := filters.NewArgs()
+	f.Add("type", eventtypes.ServiceEventType)
+	f.Add("type", eventtypes.ContainerEventType)
+	options := dockertypes.EventsOptions{
+		Filters: f,
+	}
+
+	// The refreshes requested while the services are listed are coalesced into a single one.
+	refresh := make(chan struct{}, 1)
+	requestRefresh := func() {
+		select {
+		case refresh <- struct{}{}:
+		default:
+		}
+	}
+
+	errChan := make(chan error, 1)
+	safe.Go(func() {
+		for {
+			select {
+			case <-refresh:
+				services, err := listServices(ctx, dockerClient)
+				if err != nil {
+					logger.Errorf("Failed to list services for docker, error %s", err)
+					errChan <- err
+					return
+				}
+				configuration := p.buildConfiguration(services)
+				if configuration != nil {
+					message := types.ConfigMessage{
+						ProviderName:  "docker",
+						Configuration: configuration,
+					}
+					select {
+					case configurationChan <- message:
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	ticker := time.NewTicker(time.Second * time.Duration(p.SwarmModeRefreshSeconds))
+	defer ticker.Stop()
+
+	eventsc, errc := dockerClient.Events(ctx, options)
+	for {
+		select {
+		case event := <-eventsc:
+			if isSwarmEvent(event) {
+				logger.Debugf("Provider event received %+v", event)
+				requestRefresh()
+			}
+		case <-ticker.C:
+			requestRefresh()
+		case err := <-errChan:
+			return err
+		case err := <-errc:
+			if err == io.EOF {
+				logger.Debug("Provider event stream closed")
+			}
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// isSwarmEvent returns true if the event changes the servers of a service:
+// a service is created, updated (e.g. scaled or rolled out) or removed,
+// or a task container of the node starts, stops or changes of health.
+func isSwarmEvent(event eventtypes.Message) bool {
+	switch event.Type {
+	case eventtypes.ServiceEventType:
+		return event.Action == "create" || event.Action == "update" || event.Action == "remove"
+	case eventtypes.ContainerEventType:
+		if _, ok := event.Actor.Attributes[labelDockerSwarmTaskID]; !ok {
+			return false
+		}
+		return event.Action == "start" ||
+			event.Action == "die" ||
+			strings.HasPrefix(event.Action, "health_status")
+	default:
+		return false
+	}
+}
+
 func listContainers(ctx context.Context, dockerClient client.ContainerAPIClient) ([]dockerData, error) {
 	containerList, err := dockerClient.ContainerList(ctx, dockertypes.ContainerListOptions{})
 	if err != nil {
@@ -280,7 +343,7 @@ func inspectContainers(ctx context.Context, dockerClient client.ContainerAPIClie
 	dData := dockerData{}
 	containerInspected, err := dockerClient.ContainerInspect(ctx, containerID)
 	if err != nil {
-		log.Warnf("Failed to inspect container %s, error: %s", containerID, err)
+		logger.Warnf("Failed to inspect container %s, error: %s", containerID, err)
 	} else {
 		// This condition is here to avoid to have empty IP https://github.com/containous/traefik/issues/2459
 		// We register only container which are running
@@ -353,7 +416,7 @@ func listServices(ctx context.Context, dockerClient client.APIClient) ([]dockerD
 
 	networkList, err := dockerClient.NetworkList(ctx, dockertypes.NetworkListOptions{Filters: networkListArgs})
 	if err != nil {
-		log.Debugf("Failed to network inspect on client for docker, error: %s", err)
+		logger.Debugf("Failed to network inspect on client for docker, error: %s", err)
 		return nil, err
 	}
 
@@ -377,7 +440,7 @@ func listServices(ctx context.Context, dockerClient client.APIClient) ([]dockerD
 			isGlobalSvc := service.Spec.Mode.Global != nil
 			dockerDataListTasks, err = listTasks(ctx, dockerClient, service.ID, dData, networkMap, isGlobalSvc)
 			if err != nil {
-				log.Warn(err)
+				logger.Warn(err)
 			} else {
 				dockerDataList = append(dockerDataList, dockerDataListTasks...)
 			}
@@ -397,7 +460,7 @@ func parseService(service swarmtypes.Service, networkMap map[string]*dockertypes
 	if service.Spec.EndpointSpec != nil {
 		if service.Spec.EndpointSpec.Mode == swarmtypes.ResolutionModeDNSRR {
 			if isBackendLBSwarm(dData) {
-				log.Warnf("Ignored %s endpoint-mode not supported, service name: %s. Fallback to Traefik load balancing", swarmtypes.ResolutionModeDNSRR, service.Spec.Annotations.Name)
+				logger.Warnf("Ignored %s endpoint-mode not supported, service name: %s. Fallback to Traefik load balancing", swarmtypes.ResolutionModeDNSRR, service.Spec.Annotations.Name)
 			}
 		} else if service.Spec.EndpointSpec.Mode == swarmtypes.ResolutionModeVIP {
 			dData.NetworkSettings.Networks = make(map[string]*networkData)
@@ -413,10 +476,10 @@ func parseService(service swarmtypes.Service, networkMap map[string]*dockertypes
 						}
 						dData.NetworkSettings.Networks[network.Name] = network
 					} else {
-						log.Debugf("No virtual IPs found in network %s", virtualIP.NetworkID)
+						logger.Debugf("No virtual IPs found in network %s", virtualIP.NetworkID)
 					}
 				} else {
-					log.Debugf("Network not found, id: %s", virtualIP.NetworkID)
+					logger.Debugf("Network not found, id: %s", virtualIP.NetworkID)
 				}
 			}
 		}
@@ -437,7 +500,7 @@ func listTasks(ctx context.Context, dockerClient client.APIClient, serviceID str
 
 	var dockerDataList []dockerData
 	for _, task := range taskList {
-		if task.Status.State != swarmtypes.TaskStateRunning {
+		if !isTaskServing(task) {
 			continue
 		}
 		dData := parseTasks(task, serviceDockerData, networkMap, isGlobalSvc)
@@ -448,6 +511,13 @@ func listTasks(ctx context.Context, dockerClient client.APIClient, serviceID str
 	return dockerDataList, err
 }
 
+// isTaskServing returns true if a task can receive requests.
+// Swarm reports a task running once its container passed its health check, if any,
+// and a task being shut down, e.g. replaced during a rolling update, is drained as soon as its desired state changes.
+func isTaskServing(task swarmtypes.Task) bool {
+	return task.Status.State == swarmtypes.TaskStateRunning && task.DesiredState == swarmtypes.TaskStateRunning
+}
+
 func parseTasks(task swarmtypes.Task, serviceDockerData dockerData,
 	networkMap map[string]*dockertypes.NetworkResource, isGlobalSvc bool) dockerData {
 	dData := dockerData{
@@ -477,7 +547,7 @@ func parseTasks(task swarmtypes.Task, serviceDockerData dockerData,
 						dData.NetworkSettings.Networks[network.Name] = network
 					}
 				} else {
-					log.Debugf("No IP addresses found for network %s", virtualIP.Network.ID)
+					logger.Debugf("No IP addresses found for network %s", virtualIP.Network.ID)
 				}
 			}
 		}
@@ -2,16 +2,21 @@ package docker
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 	"time"
 
+	"github.com/containous/traefik/provider/label"
+	"github.com/containous/traefik/types"
 	"github.com/davecgh/go-spew/spew"
 	docker "github.com/docker/docker/api/types"
 	dockertypes "github.com/docker/docker/api/types"
+	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/swarm"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type fakeTasksClient struct {
@@ -64,6 +69,12 @@ func TestListTasks(t *testing.T) {
 					taskNetworkAttachment("1", "network1", "overlay", []string{"127.0.0.5"}),
 					taskStatus(taskState(swarm.TaskStateFailed)),
 				),
+				swarmTask("id6",
+					taskSlot(6),
+					taskNetworkAttachment("1", "network1", "overlay", []string{"127.0.0.6"}),
+					taskStatus(taskState(swarm.TaskStateRunning)),
+					taskDesiredState(swarm.TaskStateShutdown),
+				),
 			},
 			isGlobalSVC: false,
 			expectedTasks: []string{
@@ -105,9 +116,15 @@ type fakeServicesClient struct {
 	networks      []dockertypes.NetworkResource
 	services      []swarm.Service
 	tasks         []swarm.Task
+	events        chan eventtypes.Message
+	eventsErr     chan error
 	err           error
 }
 
+func (c *fakeServicesClient) Events(ctx context.Context, options dockertypes.EventsOptions) (<-chan eventtypes.Message, <-chan error) {
+	return c.events, c.eventsErr
+}
+
 func (c *fakeServicesClient) ServiceList(ctx context.Context, options dockertypes.ServiceListOptions) ([]swarm.Service, error) {
 	return c.services, c.err
 }
@@ -395,3 +412,142 @@ func TestSwarmTaskParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSwarmEvent(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		event    eventtypes.Message
+		expected bool
+	}{
+		{
+			desc:     "service created",
+			event:    eventtypes.Message{Type: eventtypes.ServiceEventType, Action: "create"},
+			expected: true,
+		},
+		{
+			desc:     "service updated",
+			event:    eventtypes.Message{Type: eventtypes.ServiceEventType, Action: "update"},
+			expected: true,
+		},
+		{
+			desc:     "service removed",
+			event:    eventtypes.Message{Type: eventtypes.ServiceEventType, Action: "remove"},
+			expected: true,
+		},
+		{
+			desc: "task container started",
+			event: eventtypes.Message{
+				Type:   eventtypes.ContainerEventType,
+				Action: "start",
+				Actor:  eventtypes.Actor{Attributes: map[string]string{labelDockerSwarmTaskID: "id1"}},
+			},
+			expected: true,
+		},
+		{
+			desc: "task container died",
+			event: eventtypes.Message{
+				Type:   eventtypes.ContainerEventType,
+				Action: "die",
+				Actor:  eventtypes.Actor{Attributes: map[string]string{labelDockerSwarmTaskID: "id1"}},
+			},
+			expected: true,
+		},
+		{
+			desc: "task container unhealthy",
+			event: eventtypes.Message{
+				Type:   eventtypes.ContainerEventType,
+				Action: "health_status: unhealthy",
+				Actor:  eventtypes.Actor{Attributes: map[string]string{labelDockerSwarmTaskID: "id1"}},
+			},
+			expected: true,
+		},
+		{
+			desc: "task container paused",
+			event: eventtypes.Message{
+				Type:   eventtypes.ContainerEventType,
+				Action: "pause",
+				Actor:  eventtypes.Actor{Attributes: map[string]string{labelDockerSwarmTaskID: "id1"}},
+			},
+			expected: false,
+		},
+		{
+			desc:     "container outside of the swarm started",
+			event:    eventtypes.Message{Type: eventtypes.ContainerEventType, Action: "start"},
+			expected: false,
+		},
+		{
+			desc:     "node updated",
+			event:    eventtypes.Message{Type: eventtypes.NodeEventType, Action: "update"},
+			expected: false,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, isSwarmEvent(test.event))
+		})
+	}
+}
+
+func TestWatchSwarm(t *testing.T) {
+	dockerClient := &fakeServicesClient{
+		dockerVersion: "1.30",
+		services: []swarm.Service{
+			swarmService(
+				serviceName("service1"),
+				serviceLabels(map[string]string{
+					label.TraefikPort:             "80",
+					labelBackendLoadBalancerSwarm: "true",
+				}),
+				withEndpointSpec(modeVIP),
+				withEndpoint(virtualIP("1", "10.11.12.13/24")),
+			),
+		},
+		networks:  []dockertypes.NetworkResource{{Name: "network1", ID: "1"}},
+		events:    make(chan eventtypes.Message),
+		eventsErr: make(chan error, 1),
+	}
+
+	p := &Provider{ExposedByDefault: true, Domain: "docker.localhost", SwarmModeRefreshSeconds: 3600}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configurationChan := make(chan types.ConfigMessage)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- p.watchSwarm(ctx, dockerClient, configurationChan)
+	}()
+
+	// The events not related to the services do not trigger a refresh.
+	dockerClient.events <- eventtypes.Message{Type: eventtypes.ContainerEventType, Action: "start"}
+
+	select {
+	case message := <-configurationChan:
+		t.Fatalf("unexpected configuration %+v", message)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	dockerClient.events <- eventtypes.Message{Type: eventtypes.ServiceEventType, Action: "update"}
+
+	select {
+	case message := <-configurationChan:
+		assert.Equal(t, "docker", message.ProviderName)
+		require.NotNil(t, message.Configuration)
+		assert.Contains(t, message.Configuration.Backends, "backend-service1")
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the configuration")
+	}
+
+	dockerClient.eventsErr <- errors.New("events stream error")
+
+	select {
+	case err := <-errChan:
+		assert.EqualError(t, err, "events stream error")
+	case <-time.After(time.Second):
+		t.Fatal("the watch did not stop on the events stream error")
+	}
+}
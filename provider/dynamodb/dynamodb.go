@@ -20,6 +20,8 @@ import (
 	"github.com/containous/traefik/types"
 )
 
+var logger = log.WithModule("provider/dynamodb")
+
 var _ provider.Provider = (*Provider)(nil)
 
 // Provider holds configuration for provider.
@@ -44,7 +46,7 @@ func (p *Provider) Init(constraints types.Constraints) error {
 
 // createClient configures aws credentials and creates a dynamoClient
 func (p *Provider) createClient() (*dynamoClient, error) {
-	log.Info("Creating Provider client...")
+	logger.Info("Creating Provider client...")
 	sess, err := session.NewSession()
 	if err != nil {
 		return nil, err
@@ -70,7 +72,7 @@ func (p *Provider) createClient() (*dynamoClient, error) {
 
 	if p.Trace {
 		cfg.WithLogger(aws.LoggerFunc(func(args ...interface{}) {
-			log.Debug(args...)
+			logger.Debug(args...)
 		}))
 	}
 
@@ -85,7 +87,7 @@ func (p *Provider) createClient() (*dynamoClient, error) {
 
 // scanTable scans the given table and returns slice of all items in the table
 func (p *Provider) scanTable(client *dynamoClient) ([]map[string]*dynamodb.AttributeValue, error) {
-	log.Debugf("Scanning Provider table: %s ...", p.TableName)
+	logger.Debugf("Scanning Provider table: %s ...", p.TableName)
 	params := &dynamodb.ScanInput{
 		TableName: aws.String(p.TableName),
 	}
@@ -96,10 +98,10 @@ func (p *Provider) scanTable(client *dynamoClient) ([]map[string]*dynamodb.Attri
 			return !lastPage
 		})
 	if err != nil {
-		log.Errorf("Failed to scan Provider table %s", p.TableName)
+		logger.Errorf("Failed to scan Provider table %s", p.TableName)
 		return nil, err
 	}
-	log.Debugf("Successfully scanned Provider table %s", p.TableName)
+	logger.Debugf("Successfully scanned Provider table %s", p.TableName)
 	return items, nil
 }
 
@@ -109,36 +111,36 @@ func (p *Provider) buildConfiguration(client *dynamoClient) (*types.Configuratio
 	if err != nil {
 		return nil, err
 	}
-	log.Debugf("Number of Items retrieved from Provider: %d", len(items))
+	logger.Debugf("Number of Items retrieved from Provider: %d", len(items))
 	backends := make(map[string]*types.Backend)
 	frontends := make(map[string]*types.Frontend)
 	// unmarshal dynamoAttributes into Backends and Frontends
 	for i, item := range items {
-		log.Debugf("Provider Item: %d\n%v", i, item)
+		logger.Debugf("Provider Item: %d\n%v", i, item)
 		// verify the type of each item by checking to see if it has
 		// the corresponding type, backend or frontend map
 		if backend, exists := item["backend"]; exists {
-			log.Debug("Unmarshaling backend from Provider...")
+			logger.Debug("Unmarshaling backend from Provider...")
 			tmpBackend := &types.Backend{}
 			err = dynamodbattribute.Unmarshal(backend, tmpBackend)
 			if err != nil {
-				log.Errorf(err.Error())
+				logger.Errorf(err.Error())
 			} else {
 				backends[*item["name"].S] = tmpBackend
-				log.Debug("Backend from Provider unmarshalled successfully")
+				logger.Debug("Backend from Provider unmarshalled successfully")
 			}
 		} else if frontend, exists := item["frontend"]; exists {
-			log.Debugf("Unmarshaling frontend from Provider...")
+			logger.Debugf("Unmarshaling frontend from Provider...")
 			tmpFrontend := &types.Frontend{}
 			err = dynamodbattribute.Unmarshal(frontend, tmpFrontend)
 			if err != nil {
-				log.Errorf(err.Error())
+				logger.Errorf(err.Error())
 			} else {
 				frontends[*item["name"].S] = tmpFrontend
-				log.Debug("Frontend from Provider unmarshalled successfully")
+				logger.Debug("Frontend from Provider unmarshalled successfully")
 			}
 		} else {
-			log.Warnf("Error in format of Provider Item: %v", item)
+			logger.Warnf("Error in format of Provider Item: %v", item)
 		}
 	}
 
@@ -185,7 +187,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 				reload := time.NewTicker(time.Second * time.Duration(p.RefreshSeconds))
 				defer reload.Stop()
 				for {
-					log.Debug("Watching Provider...")
+					logger.Debug("Watching Provider...")
 					select {
 					case <-reload.C:
 						configuration, err := p.buildConfiguration(awsClient)
@@ -205,12 +207,12 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 			return nil
 		}
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Provider error: %s time: %v", err.Error(), time)
+			logger.Errorf("Provider error: %s time: %v", err.Error(), time)
 		}
 
 		err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 		if err != nil {
-			log.Errorf("Failed to connect to Provider. %s", err.Error())
+			logger.Errorf("Failed to connect to Provider. %s", err.Error())
 		}
 	})
 	return nil
@@ -11,7 +11,6 @@ import (
 
 	"github.com/BurntSushi/ty/fun"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -44,6 +43,7 @@ func (p *Provider) buildConfigurationV2(instances []ecsInstance) (*types.Configu
 		"getAuth":              label.GetAuth,
 		"getEntryPoints":       label.GetFuncSliceString(label.TraefikFrontendEntryPoints),
 		"getRedirect":          label.GetRedirect,
+		"getAccessLog":         label.GetAccessLog,
 		"getErrorPages":        label.GetErrorPages,
 		"getRateLimit":         label.GetRateLimit,
 		"getHeaders":           label.GetHeaders,
@@ -78,34 +78,34 @@ func (p *Provider) buildConfigurationV2(instances []ecsInstance) (*types.Configu
 
 func (p *Provider) filterInstance(i ecsInstance) bool {
 	if i.machine == nil {
-		log.Debug("Filtering ecs instance with nil machine")
+		logger.Debug("Filtering ecs instance with nil machine")
 		return false
 	}
 
 	if labelPort := label.GetStringValue(i.TraefikLabels, label.TraefikPort, ""); len(i.machine.ports) == 0 && labelPort == "" {
-		log.Debugf("Filtering ecs instance without port %s (%s)", i.Name, i.ID)
+		logger.Debugf("Filtering ecs instance without port %s (%s)", i.Name, i.ID)
 		return false
 	}
 
 	if strings.ToLower(i.machine.state) != ec2.InstanceStateNameRunning {
-		log.Debugf("Filtering ecs instance with an incorrect state %s (%s) (state = %s)", i.Name, i.ID, i.machine.state)
+		logger.Debugf("Filtering ecs instance with an incorrect state %s (%s) (state = %s)", i.Name, i.ID, i.machine.state)
 		return false
 	}
 
 	if len(i.machine.privateIP) == 0 {
-		log.Debugf("Filtering ecs instance without an ip address %s (%s)", i.Name, i.ID)
+		logger.Debugf("Filtering ecs instance without an ip address %s (%s)", i.Name, i.ID)
 		return false
 	}
 
 	if !isEnabled(i, p.ExposedByDefault) {
-		log.Debugf("Filtering disabled ecs instance %s (%s)", i.Name, i.ID)
+		logger.Debugf("Filtering disabled ecs instance %s (%s)", i.Name, i.ID)
 		return false
 	}
 
 	constraintTags := label.GetSliceStringValue(i.TraefikLabels, label.TraefikTags)
 	if ok, failingConstraint := p.MatchConstraints(constraintTags); !ok {
 		if failingConstraint != nil {
-			log.Debugf("Filtering ecs instance pruned by constraint %s (%s) (constraint = %q)", i.Name, i.ID, failingConstraint.String())
+			logger.Debugf("Filtering ecs instance pruned by constraint %s (%s) (constraint = %q)", i.Name, i.ID, failingConstraint.String())
 		}
 		return false
 	}
@@ -220,7 +220,7 @@ func getServers(instances []ecsInstance) map[string]types.Server {
 		serverName := getServerName(instance, serverURL)
 
 		if _, exist := servers[serverName]; exist {
-			log.Debugf("Skipping server %q with the same URL.", serverName)
+			logger.Debugf("Skipping server %q with the same URL.", serverName)
 			continue
 		}
 
@@ -242,7 +242,7 @@ func getServerName(instance ecsInstance, url string) string {
 	_, err := hash.Write([]byte(url))
 	if err != nil {
 		// Impossible case
-		log.Errorf("Fail to hash server URL %q", url)
+		logger.Errorf("Fail to hash server URL %q", url)
 	}
 
 	if len(instance.SegmentName) > 0 {
@@ -8,7 +8,6 @@ import (
 	"github.com/BurntSushi/ty/fun"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
 )
@@ -90,27 +89,27 @@ func (p *Provider) getFrontendRuleV1(i ecsInstance) string {
 // Deprecated
 func (p *Provider) filterInstanceV1(i ecsInstance) bool {
 	if i.machine == nil {
-		log.Debug("Filtering ecs instance with nil machine")
+		logger.Debug("Filtering ecs instance with nil machine")
 		return false
 	}
 
 	if labelPort := getStringValueV1(i, label.TraefikPort, ""); len(i.machine.ports) == 0 && labelPort == "" {
-		log.Debugf("Filtering ecs instance without port %s (%s)", i.Name, i.ID)
+		logger.Debugf("Filtering ecs instance without port %s (%s)", i.Name, i.ID)
 		return false
 	}
 
 	if strings.ToLower(i.machine.state) != ec2.InstanceStateNameRunning {
-		log.Debugf("Filtering ecs instance in an incorrect state %s (%s) (state = %s)", i.Name, i.ID, i.machine.state)
+		logger.Debugf("Filtering ecs instance in an incorrect state %s (%s) (state = %s)", i.Name, i.ID, i.machine.state)
 		return false
 	}
 
 	if len(i.machine.privateIP) == 0 {
-		log.Debugf("Filtering ecs instance without an ip address %s (%s)", i.Name, i.ID)
+		logger.Debugf("Filtering ecs instance without an ip address %s (%s)", i.Name, i.ID)
 		return false
 	}
 
 	if !isEnabled(i, p.ExposedByDefault) {
-		log.Debugf("Filtering disabled ecs instance %s (%s)", i.Name, i.ID)
+		logger.Debugf("Filtering disabled ecs instance %s (%s)", i.Name, i.ID)
 		return false
 	}
 
@@ -122,7 +121,7 @@ func (p *Provider) filterInstanceV1(i ecsInstance) bool {
 // Deprecated
 func getStickyV1(instances []ecsInstance) bool {
 	if hasFirstV1(instances, label.TraefikBackendLoadBalancerSticky) {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
 	}
 	return getFirstBoolValueV1(instances, label.TraefikBackendLoadBalancerSticky, false)
 }
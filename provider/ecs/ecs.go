@@ -22,6 +22,8 @@ import (
 	"github.com/patrickmn/go-cache"
 )
 
+var logger = log.WithModule("provider/ecs")
+
 var _ provider.Provider = (*Provider)(nil)
 var existingTaskDefCache = cache.New(30*time.Minute, 5*time.Minute)
 
@@ -82,7 +84,7 @@ func (p *Provider) createClient() (*awsClient, error) {
 
 	ec2meta := ec2metadata.New(sess)
 	if p.Region == "" {
-		log.Infoln("No EC2 region provided, querying instance metadata endpoint...")
+		logger.Infoln("No EC2 region provided, querying instance metadata endpoint...")
 		identity, err := ec2meta.GetInstanceIdentityDocument()
 		if err != nil {
 			return nil, err
@@ -108,7 +110,7 @@ func (p *Provider) createClient() (*awsClient, error) {
 
 	if p.Trace {
 		cfg.WithLogger(aws.LoggerFunc(func(args ...interface{}) {
-			log.Debug(args...)
+			logger.Debug(args...)
 		}))
 	}
 
@@ -176,11 +178,11 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 		}
 
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Provider connection error %+v, retrying in %s", err, time)
+			logger.Errorf("Provider connection error %+v, retrying in %s", err, time)
 		}
 		err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 		if err != nil {
-			log.Errorf("Cannot connect to Provider api %+v", err)
+			logger.Errorf("Cannot connect to Provider api %+v", err)
 		}
 	})
 
@@ -216,14 +218,14 @@ func (p *Provider) listInstances(ctx context.Context, client *awsClient) ([]ecsI
 	} else if p.Cluster != "" {
 		// TODO: Deprecated configuration - Need to be removed in the future
 		clusters = Clusters{p.Cluster}
-		log.Warn("Deprecated configuration found: ecs.cluster. Please use ecs.clusters instead.")
+		logger.Warn("Deprecated configuration found: ecs.cluster. Please use ecs.clusters instead.")
 	} else {
 		clusters = p.Clusters
 	}
 
 	var instances []ecsInstance
 
-	log.Debugf("ECS Clusters: %s", clusters)
+	logger.Debugf("ECS Clusters: %s", clusters)
 
 	for _, c := range clusters {
 
@@ -239,7 +241,7 @@ func (p *Provider) listInstances(ctx context.Context, client *awsClient) ([]ecsI
 					Cluster: &c,
 				})
 				if err != nil {
-					log.Errorf("Unable to describe tasks for %v", page.TaskArns)
+					logger.Errorf("Unable to describe tasks for %v", page.TaskArns)
 				} else {
 					for _, t := range resp.Tasks {
 						if aws.StringValue(t.LastStatus) == ecs.DesiredStatusRunning {
@@ -252,7 +254,7 @@ func (p *Provider) listInstances(ctx context.Context, client *awsClient) ([]ecsI
 		})
 
 		if err != nil {
-			log.Error("Unable to list tasks")
+			logger.Error("Unable to list tasks")
 			return nil, err
 		}
 
@@ -288,7 +290,7 @@ func (p *Provider) listInstances(ctx context.Context, client *awsClient) ([]ecsI
 				}
 
 				if containerDefinition == nil {
-					log.Debugf("Unable to find container definition for %s", aws.StringValue(container.Name))
+					logger.Debugf("Unable to find container definition for %s", aws.StringValue(container.Name))
 					continue
 				}
 
@@ -360,7 +362,7 @@ func (p *Provider) lookupEc2Instances(ctx context.Context, client *awsClient, cl
 		})
 
 		if err != nil {
-			log.Errorf("Unable to describe container instances: %v", err)
+			logger.Errorf("Unable to describe container instances: %v", err)
 			return nil, err
 		}
 
@@ -390,7 +392,7 @@ func (p *Provider) lookupEc2Instances(ctx context.Context, client *awsClient, cl
 			})
 
 			if err != nil {
-				log.Errorf("Unable to describe instances: %v", err)
+				logger.Errorf("Unable to describe instances: %v", err)
 				return nil, err
 			}
 		}
@@ -404,14 +406,14 @@ func (p *Provider) lookupTaskDefinitions(ctx context.Context, client *awsClient,
 	for arn, task := range taskDefArns {
 		if definition, ok := existingTaskDefCache.Get(arn); ok {
 			taskDef[arn] = definition.(*ecs.TaskDefinition)
-			log.Debugf("Found cached task definition for %s. Skipping the call", arn)
+			logger.Debugf("Found cached task definition for %s. Skipping the call", arn)
 		} else {
 			resp, err := client.ecs.DescribeTaskDefinitionWithContext(ctx, &ecs.DescribeTaskDefinitionInput{
 				TaskDefinition: task.TaskDefinitionArn,
 			})
 
 			if err != nil {
-				log.Errorf("Unable to describe task definition: %s", err)
+				logger.Errorf("Unable to describe task definition: %s", err)
 				return nil, err
 			}
 
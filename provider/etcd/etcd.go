@@ -1,11 +1,14 @@
 package etcd
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/abronan/valkeyrie/store"
 	"github.com/abronan/valkeyrie/store/etcd/v2"
-	etcdv3 "github.com/abronan/valkeyrie/store/etcd/v3"
+	"github.com/cenk/backoff"
+	"github.com/containous/traefik/job"
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/kv"
@@ -13,12 +16,15 @@ import (
 	"github.com/containous/traefik/types"
 )
 
+var logger = log.WithModule("provider/etcd")
+
 var _ provider.Provider = (*Provider)(nil)
 
 // Provider holds configurations of the provider.
 type Provider struct {
 	kv.Provider `mapstructure:",squash" export:"true"`
 	UseAPIV3    bool `description:"Use ETCD API V3" export:"true"`
+	client      *Store
 }
 
 // Init the provider
@@ -28,29 +34,95 @@ func (p *Provider) Init(constraints types.Constraints) error {
 		return err
 	}
 
-	store, err := p.CreateStore()
+	kvStore, err := p.CreateStore()
 	if err != nil {
 		return fmt.Errorf("failed to Connect to KV store: %v", err)
 	}
 
-	p.SetKVClient(store)
+	p.SetKVClient(kvStore)
+	if client, ok := kvStore.(*Store); ok {
+		p.client = client
+	}
 	return nil
 }
 
 // Provide allows the etcd provider to Provide configurations to traefik
 // using the given configuration channel.
 func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *safe.Pool) error {
-	return p.Provider.Provide(configurationChan, pool)
+	if p.client == nil {
+		return p.Provider.Provide(configurationChan, pool)
+	}
+
+	pool.GoCtx(func(routineCtx context.Context) {
+		operation := func() error {
+			return p.provide(routineCtx, configurationChan)
+		}
+
+		notify := func(err error, time time.Duration) {
+			logger.Errorf("KV connection error: %+v, retrying in %s", err, time)
+		}
+		err := backoff.RetryNotify(safe.OperationWithRecover(operation), backoff.WithContext(job.NewBackOff(backoff.NewExponentialBackOff()), routineCtx), notify)
+		if err != nil {
+			logger.Errorf("Cannot connect to KV server: %v", err)
+		}
+	})
+
+	return nil
+}
+
+// provide lists the keys under the prefix, and watches their changes from the revision of the listing.
+// The configuration is built from a local copy of the keys, which the changes are applied to.
+func (p *Provider) provide(ctx context.Context, configurationChan chan<- types.ConfigMessage) error {
+	prefix := p.client.normalize(p.Prefix)
+
+	listCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	pairs, revision, err := p.client.list(listCtx, prefix, nil)
+	cancel()
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+
+	if err := p.sendConfiguration(ctx, configurationChan, pairs); err != nil {
+		return err
+	}
+
+	if !p.Watch {
+		return nil
+	}
+
+	err = p.client.watchTree(ctx, prefix, revision, pairs, nil, func(pairs []*store.KVPair) error {
+		return p.sendConfiguration(ctx, configurationChan, pairs)
+	})
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (p *Provider) sendConfiguration(ctx context.Context, configurationChan chan<- types.ConfigMessage, pairs []*store.KVPair) error {
+	configuration, err := p.BuildConfiguration(newSnapshot(p.client, p.Prefix, pairs))
+	if err != nil {
+		return err
+	}
+
+	select {
+	case configurationChan <- types.ConfigMessage{
+		ProviderName:  string(store.ETCDV3),
+		Configuration: configuration,
+	}:
+	case <-ctx.Done():
+	}
+	return nil
 }
 
 // CreateStore creates the KV store
 func (p *Provider) CreateStore() (store.Store, error) {
 	if p.UseAPIV3 {
-		etcdv3.Register()
+		Register()
 		p.SetStoreType(store.ETCDV3)
 	} else {
 		// TODO: Deprecated
-		log.Warn("The ETCD API V2 is deprecated. Please use API V3 instead")
+		logger.Warn("The ETCD API V2 is deprecated. Please use API V3 instead")
 		etcd.Register()
 		p.SetStoreType(store.ETCD)
 	}
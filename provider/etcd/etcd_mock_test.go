@@ -0,0 +1,642 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+const fakeEtcdToken = "fake-token"
+
+// fakeEtcd is an in-memory stand-in of an etcd v3 server, serving the gRPC API used by the store:
+// the revisions of the keys, the watches from a revision, the compaction, the leases and the authentication.
+type fakeEtcd struct {
+	listener net.Listener
+	server   *grpc.Server
+	username string
+	password string
+
+	mu        sync.Mutex
+	revision  int64
+	compacted int64
+	kvs       map[string]*mvccpb.KeyValue
+	history   []*mvccpb.Event
+	leases    map[int64]*fakeLease
+	lastLease int64
+	watchers  map[*fakeWatcher]struct{}
+	done      chan struct{}
+}
+
+type fakeLease struct {
+	ttl      int64
+	expireAt time.Time
+	keys     map[string]struct{}
+}
+
+type fakeWatcher struct {
+	id       int64
+	key      []byte
+	rangeEnd []byte
+	filters  []pb.WatchCreateRequest_FilterType
+	outbox   chan *pb.WatchResponse
+}
+
+func newFakeEtcd(t *testing.T, username, password string) *fakeEtcd {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	f := &fakeEtcd{
+		listener: listener,
+		username: username,
+		password: password,
+		revision: 1,
+		kvs:      make(map[string]*mvccpb.KeyValue),
+		leases:   make(map[int64]*fakeLease),
+		watchers: make(map[*fakeWatcher]struct{}),
+		done:     make(chan struct{}),
+	}
+
+	f.server = grpc.NewServer(grpc.UnaryInterceptor(f.authorizeUnary), grpc.StreamInterceptor(f.authorizeStream))
+	pb.RegisterKVServer(f.server, f)
+	pb.RegisterWatchServer(f.server, f)
+	pb.RegisterLeaseServer(f.server, f)
+	pb.RegisterAuthServer(f.server, &fakeEtcdAuth{fakeEtcd: f})
+	healthpb.RegisterHealthServer(f.server, f)
+
+	go f.server.Serve(listener)
+	go f.expireLeases()
+
+	return f
+}
+
+func (f *fakeEtcd) endpoint() string {
+	return f.listener.Addr().String()
+}
+
+func (f *fakeEtcd) close() {
+	close(f.done)
+	f.server.Stop()
+}
+
+func (f *fakeEtcd) authorize(ctx context.Context, method string) error {
+	if len(f.password) == 0 || method == "/etcdserverpb.Auth/Authenticate" || method == "/grpc.health.v1.Health/Check" {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if tokens := md["token"]; len(tokens) == 0 || tokens[0] != fakeEtcdToken {
+		return rpctypes.ErrGRPCInvalidAuthToken
+	}
+	return nil
+}
+
+func (f *fakeEtcd) authorizeUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := f.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (f *fakeEtcd) authorizeStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := f.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// fakeEtcdAuth only implements the authentication of the Auth service.
+type fakeEtcdAuth struct {
+	pb.AuthServer
+	*fakeEtcd
+}
+
+func (a *fakeEtcdAuth) Authenticate(ctx context.Context, req *pb.AuthenticateRequest) (*pb.AuthenticateResponse, error) {
+	if len(a.password) == 0 {
+		return nil, rpctypes.ErrGRPCAuthNotEnabled
+	}
+	if req.Name != a.username || req.Password != a.password {
+		return nil, rpctypes.ErrGRPCAuthFailed
+	}
+	return &pb.AuthenticateResponse{Header: a.header(), Token: fakeEtcdToken}, nil
+}
+
+func (f *fakeEtcd) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// header returns the header of a response, the lock is held.
+func (f *fakeEtcd) header() *pb.ResponseHeader {
+	return &pb.ResponseHeader{ClusterId: 1, MemberId: 1, Revision: f.revision, RaftTerm: 1}
+}
+
+// keys returns the sorted keys in a range, the lock is held.
+func (f *fakeEtcd) keys(key, rangeEnd []byte) []string {
+	var keys []string
+	for k := range f.kvs {
+		if inRange([]byte(k), key, rangeEnd) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func inRange(k, key, rangeEnd []byte) bool {
+	switch {
+	case len(rangeEnd) == 0:
+		return bytes.Equal(k, key)
+	case len(rangeEnd) == 1 && rangeEnd[0] == 0:
+		return bytes.Compare(k, key) >= 0
+	default:
+		return bytes.Compare(k, key) >= 0 && bytes.Compare(k, rangeEnd) < 0
+	}
+}
+
+func (f *fakeEtcd) Range(ctx context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rangeKeys(req), nil
+}
+
+func (f *fakeEtcd) rangeKeys(req *pb.RangeRequest) *pb.RangeResponse {
+	resp := &pb.RangeResponse{Header: f.header()}
+
+	keys := f.keys(req.Key, req.RangeEnd)
+	if req.SortOrder == pb.RangeRequest_DESCEND {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	}
+
+	resp.Count = int64(len(keys))
+	if req.CountOnly {
+		return resp
+	}
+
+	for _, k := range keys {
+		kv := *f.kvs[k]
+		resp.Kvs = append(resp.Kvs, &kv)
+	}
+	return resp
+}
+
+func (f *fakeEtcd) Put(ctx context.Context, req *pb.PutRequest) (*pb.PutResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp, err := f.txn(&pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: req}}}})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Responses[0].GetResponsePut(), nil
+}
+
+func (f *fakeEtcd) DeleteRange(ctx context.Context, req *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp, err := f.txn(&pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: req}}}})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Responses[0].GetResponseDeleteRange(), nil
+}
+
+func (f *fakeEtcd) Txn(ctx context.Context, req *pb.TxnRequest) (*pb.TxnResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.txn(req)
+}
+
+// txn runs a transaction, the changes are made at a single new revision. The lock is held.
+func (f *fakeEtcd) txn(req *pb.TxnRequest) (*pb.TxnResponse, error) {
+	succeeded := true
+	for _, cmp := range req.Compare {
+		if !f.compare(cmp) {
+			succeeded = false
+			break
+		}
+	}
+
+	ops := req.Success
+	if !succeeded {
+		ops = req.Failure
+	}
+
+	// The leases are checked first, a failed transaction does not change the store.
+	for _, op := range ops {
+		if put := op.GetRequestPut(); put != nil && put.Lease != 0 {
+			if _, ok := f.leases[put.Lease]; !ok {
+				return nil, rpctypes.ErrGRPCLeaseNotFound
+			}
+		}
+	}
+
+	revision := f.revision + 1
+	var events []*mvccpb.Event
+	resp := &pb.TxnResponse{Succeeded: succeeded}
+
+	for _, op := range ops {
+		switch {
+		case op.GetRequestRange() != nil:
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{Response: &pb.ResponseOp_ResponseRange{ResponseRange: f.rangeKeys(op.GetRequestRange())}})
+
+		case op.GetRequestPut() != nil:
+			put := op.GetRequestPut()
+			kv := &mvccpb.KeyValue{Key: put.Key, Value: put.Value, CreateRevision: revision, ModRevision: revision, Version: 1, Lease: put.Lease}
+			if previous, ok := f.kvs[string(put.Key)]; ok {
+				kv.CreateRevision = previous.CreateRevision
+				kv.Version = previous.Version + 1
+				f.detach(previous)
+			}
+			f.kvs[string(put.Key)] = kv
+			if lease, ok := f.leases[put.Lease]; ok {
+				lease.keys[string(put.Key)] = struct{}{}
+			}
+
+			events = append(events, &mvccpb.Event{Type: mvccpb.PUT, Kv: kv})
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: &pb.PutResponse{}}})
+
+		case op.GetRequestDeleteRange() != nil:
+			deleteRange := op.GetRequestDeleteRange()
+			keys := f.keys(deleteRange.Key, deleteRange.RangeEnd)
+			for _, k := range keys {
+				events = append(events, f.delete(k, revision))
+			}
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &pb.DeleteRangeResponse{Deleted: int64(len(keys))}}})
+		}
+	}
+
+	f.publish(events)
+
+	resp.Header = f.header()
+	for _, r := range resp.Responses {
+		switch {
+		case r.GetResponsePut() != nil:
+			r.GetResponsePut().Header = resp.Header
+		case r.GetResponseDeleteRange() != nil:
+			r.GetResponseDeleteRange().Header = resp.Header
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeEtcd) compare(cmp *pb.Compare) bool {
+	kv := f.kvs[string(cmp.Key)]
+	if kv == nil {
+		kv = &mvccpb.KeyValue{}
+	}
+
+	var result int
+	switch cmp.Target {
+	case pb.Compare_VERSION:
+		result = compareInt(kv.Version, cmp.GetVersion())
+	case pb.Compare_CREATE:
+		result = compareInt(kv.CreateRevision, cmp.GetCreateRevision())
+	case pb.Compare_MOD:
+		result = compareInt(kv.ModRevision, cmp.GetModRevision())
+	case pb.Compare_VALUE:
+		result = bytes.Compare(kv.Value, cmp.GetValue())
+	case pb.Compare_LEASE:
+		result = compareInt(kv.Lease, cmp.GetLease())
+	}
+
+	switch cmp.Result {
+	case pb.Compare_EQUAL:
+		return result == 0
+	case pb.Compare_NOT_EQUAL:
+		return result != 0
+	case pb.Compare_GREATER:
+		return result > 0
+	default:
+		return result < 0
+	}
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// delete deletes a key at a revision, and returns its event. The lock is held.
+func (f *fakeEtcd) delete(key string, revision int64) *mvccpb.Event {
+	f.detach(f.kvs[key])
+	delete(f.kvs, key)
+	return &mvccpb.Event{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte(key), ModRevision: revision}}
+}
+
+func (f *fakeEtcd) detach(kv *mvccpb.KeyValue) {
+	if lease, ok := f.leases[kv.Lease]; ok {
+		delete(lease.keys, string(kv.Key))
+	}
+}
+
+// publish records the events of a new revision, and sends them to the watchers. The lock is held.
+func (f *fakeEtcd) publish(events []*mvccpb.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	f.revision++
+	f.history = append(f.history, events...)
+
+	for w := range f.watchers {
+		w.send(f.header(), events)
+	}
+}
+
+func (w *fakeWatcher) send(header *pb.ResponseHeader, events []*mvccpb.Event) {
+	var matching []*mvccpb.Event
+	for _, event := range events {
+		if !inRange(event.Kv.Key, w.key, w.rangeEnd) || w.filtered(event) {
+			continue
+		}
+		matching = append(matching, event)
+	}
+
+	if len(matching) > 0 {
+		w.outbox <- &pb.WatchResponse{Header: header, WatchId: w.id, Events: matching}
+	}
+}
+
+func (w *fakeWatcher) filtered(event *mvccpb.Event) bool {
+	for _, filter := range w.filters {
+		if (filter == pb.WatchCreateRequest_NOPUT && event.Type == mvccpb.PUT) || (filter == pb.WatchCreateRequest_NODELETE && event.Type == mvccpb.DELETE) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeEtcd) Compact(ctx context.Context, req *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	f.compact(req.Revision)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &pb.CompactionResponse{Header: f.header()}, nil
+}
+
+// compact drops the history before a revision, the watches from an older revision are canceled.
+func (f *fakeEtcd) compact(revision int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.compacted = revision
+
+	var history []*mvccpb.Event
+	for _, event := range f.history {
+		if event.Kv.ModRevision >= revision {
+			history = append(history, event)
+		}
+	}
+	f.history = history
+}
+
+func (f *fakeEtcd) Watch(stream pb.Watch_WatchServer) error {
+	outbox := make(chan *pb.WatchResponse, 1000)
+	var watchers []*fakeWatcher
+	var lastID int64
+
+	defer func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, w := range watchers {
+			delete(f.watchers, w)
+		}
+	}()
+
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			if create := req.GetCreateRequest(); create != nil {
+				lastID++
+				w := &fakeWatcher{id: lastID, key: create.Key, rangeEnd: create.RangeEnd, filters: create.Filters, outbox: outbox}
+
+				f.mu.Lock()
+				outbox <- &pb.WatchResponse{Header: f.header(), WatchId: w.id, Created: true}
+
+				if create.StartRevision > 0 && create.StartRevision < f.compacted {
+					outbox <- &pb.WatchResponse{Header: f.header(), WatchId: w.id, Canceled: true, CompactRevision: f.compacted}
+					f.mu.Unlock()
+					continue
+				}
+
+				// The events since the start revision are sent first.
+				for _, event := range f.history {
+					if create.StartRevision > 0 && event.Kv.ModRevision >= create.StartRevision {
+						w.send(&pb.ResponseHeader{Revision: event.Kv.ModRevision}, []*mvccpb.Event{event})
+					}
+				}
+
+				f.watchers[w] = struct{}{}
+				watchers = append(watchers, w)
+				f.mu.Unlock()
+			}
+
+			if cancel := req.GetCancelRequest(); cancel != nil {
+				f.mu.Lock()
+				for _, w := range watchers {
+					if w.id == cancel.WatchId {
+						delete(f.watchers, w)
+					}
+				}
+				outbox <- &pb.WatchResponse{Header: f.header(), WatchId: cancel.WatchId, Canceled: true}
+				f.mu.Unlock()
+			}
+		}
+	}()
+
+	for {
+		select {
+		case resp := <-outbox:
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case err := <-errCh:
+			return err
+		case <-f.done:
+			return nil
+		}
+	}
+}
+
+func (f *fakeEtcd) LeaseGrant(ctx context.Context, req *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastLease++
+	f.leases[f.lastLease] = &fakeLease{
+		ttl:      req.TTL,
+		expireAt: time.Now().Add(time.Duration(req.TTL) * time.Second),
+		keys:     make(map[string]struct{}),
+	}
+	return &pb.LeaseGrantResponse{Header: f.header(), ID: f.lastLease, TTL: req.TTL}, nil
+}
+
+func (f *fakeEtcd) LeaseRevoke(ctx context.Context, req *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.revoke(req.ID) {
+		return nil, rpctypes.ErrGRPCLeaseNotFound
+	}
+	return &pb.LeaseRevokeResponse{Header: f.header()}, nil
+}
+
+// revoke deletes a lease and its keys, the lock is held.
+func (f *fakeEtcd) revoke(id int64) bool {
+	lease, ok := f.leases[id]
+	if !ok {
+		return false
+	}
+
+	var keys []string
+	for key := range lease.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	revision := f.revision + 1
+	var events []*mvccpb.Event
+	for _, key := range keys {
+		events = append(events, f.delete(key, revision))
+	}
+	delete(f.leases, id)
+
+	f.publish(events)
+	return true
+}
+
+func (f *fakeEtcd) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		f.mu.Lock()
+		resp := &pb.LeaseKeepAliveResponse{Header: f.header(), ID: req.ID}
+		if lease, ok := f.leases[req.ID]; ok {
+			lease.expireAt = time.Now().Add(time.Duration(lease.ttl) * time.Second)
+			resp.TTL = lease.ttl
+		}
+		f.mu.Unlock()
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (f *fakeEtcd) LeaseTimeToLive(ctx context.Context, req *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lease, ok := f.leases[req.ID]
+	if !ok {
+		return &pb.LeaseTimeToLiveResponse{Header: f.header(), ID: req.ID, TTL: -1}, nil
+	}
+	return &pb.LeaseTimeToLiveResponse{Header: f.header(), ID: req.ID, TTL: int64(time.Until(lease.expireAt).Seconds()), GrantedTTL: lease.ttl}, nil
+}
+
+func (f *fakeEtcd) LeaseLeases(ctx context.Context, req *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &pb.LeaseLeasesResponse{Header: f.header()}
+	for id := range f.leases {
+		resp.Leases = append(resp.Leases, &pb.LeaseStatus{ID: id})
+	}
+	return resp, nil
+}
+
+func (f *fakeEtcd) expireLeases() {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.mu.Lock()
+			for id, lease := range f.leases {
+				if time.Now().After(lease.expireAt) {
+					f.revoke(id)
+				}
+			}
+			f.mu.Unlock()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// expire expires a lease right away, like a lease not kept alive.
+func (f *fakeEtcd) expire(id int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.revoke(id)
+}
+
+// put sets a key outside of the client.
+func (f *fakeEtcd) put(key, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err := f.txn(&pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte(key), Value: []byte(value)}}}}})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// remove deletes a key outside of the client.
+func (f *fakeEtcd) remove(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.publish([]*mvccpb.Event{f.delete(key, f.revision+1)})
+}
+
+// value returns the key-value of a key, or nil.
+func (f *fakeEtcd) value(key string) *mvccpb.KeyValue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	kv, ok := f.kvs[key]
+	if !ok {
+		return nil
+	}
+	copied := *kv
+	return &copied
+}
+
+func (f *fakeEtcd) currentRevision() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.revision
+}
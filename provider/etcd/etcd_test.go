@@ -0,0 +1,74 @@
+package etcd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderProvide(t *testing.T) {
+	server := newFakeEtcd(t, "traefik", "secret")
+	defer server.close()
+
+	server.put("/traefik/backends/backend1/servers/server1/url", "http://127.0.0.1:80")
+	server.put("/traefik/backends/backend1/servers/server1/weight", "10")
+	server.put("/traefik/frontends/frontend1/backend", "backend1")
+	server.put("/traefik/frontends/frontend1/routes/route1/rule", "Host:test.localhost")
+
+	p := &Provider{UseAPIV3: true}
+	p.Endpoint = server.endpoint()
+	p.Prefix = "/traefik"
+	p.Username = "traefik"
+	p.Password = "secret"
+	p.Watch = true
+	require.NoError(t, p.Init(nil))
+
+	configurationChan := make(chan types.ConfigMessage)
+	pool := safe.NewPool(context.Background())
+	defer pool.Stop()
+
+	require.NoError(t, p.Provide(configurationChan, pool))
+
+	configuration := receiveConfiguration(t, configurationChan)
+	require.Contains(t, configuration.Backends, "backend1")
+	assert.Equal(t, map[string]types.Server{
+		"server1": {URL: "http://127.0.0.1:80", Weight: 10},
+	}, configuration.Backends["backend1"].Servers)
+	require.Contains(t, configuration.Frontends, "frontend1")
+	assert.Equal(t, "backend1", configuration.Frontends["frontend1"].Backend)
+
+	// The configuration is sent again on each change under the prefix.
+	server.put("/traefik/backends/backend1/servers/server2/url", "http://127.0.0.1:81")
+
+	configuration = receiveConfiguration(t, configurationChan)
+	assert.Equal(t, map[string]types.Server{
+		"server1": {URL: "http://127.0.0.1:80", Weight: 10},
+		"server2": {URL: "http://127.0.0.1:81", Weight: 1},
+	}, configuration.Backends["backend1"].Servers)
+
+	server.remove("/traefik/frontends/frontend1/routes/route1/rule")
+	receiveConfiguration(t, configurationChan)
+
+	server.remove("/traefik/frontends/frontend1/backend")
+
+	configuration = receiveConfiguration(t, configurationChan)
+	assert.NotContains(t, configuration.Frontends, "frontend1")
+}
+
+func receiveConfiguration(t *testing.T, configurationChan <-chan types.ConfigMessage) *types.Configuration {
+	t.Helper()
+
+	select {
+	case message := <-configurationChan:
+		assert.Equal(t, "etcdv3", message.ProviderName)
+		return message.Configuration
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the configuration")
+		return nil
+	}
+}
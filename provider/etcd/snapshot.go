@@ -0,0 +1,129 @@
+package etcd
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/abronan/valkeyrie/store"
+)
+
+// errReadOnly is returned by the methods of a snapshot writing to the store
+var errReadOnly = errors.New("read-only etcd snapshot")
+
+var _ store.Store = (*snapshot)(nil)
+
+// snapshot is a read-only store serving a local copy of the pairs under a directory, sorted by key,
+// so that the configuration is built without a request to etcd for each key.
+// The keys outside of the directory, like the ones of an alias, are read from etcd.
+type snapshot struct {
+	store     *Store
+	directory string
+	pairs     []*store.KVPair
+}
+
+func newSnapshot(s *Store, directory string, pairs []*store.KVPair) *snapshot {
+	return &snapshot{
+		store:     s,
+		directory: s.normalize(directory),
+		pairs:     pairs,
+	}
+}
+
+// contains returns true if the pairs under the given key are in the snapshot.
+func (s *snapshot) contains(key string) bool {
+	return key != s.directory && strings.HasPrefix(key, s.directory)
+}
+
+// search returns the index of the first pair with a key greater or equal to the given one.
+func (s *snapshot) search(key string) int {
+	return sort.Search(len(s.pairs), func(i int) bool { return s.pairs[i].Key >= key })
+}
+
+// Get a value given its key
+func (s *snapshot) Get(key string, options *store.ReadOptions) (*store.KVPair, error) {
+	key = s.store.normalize(key)
+	if !s.contains(key) {
+		return s.store.Get(key, options)
+	}
+
+	if i := s.search(key); i < len(s.pairs) && s.pairs[i].Key == key {
+		return s.pairs[i], nil
+	}
+	return nil, store.ErrKeyNotFound
+}
+
+// Exists verifies if a key exists in the snapshot
+func (s *snapshot) Exists(key string, options *store.ReadOptions) (bool, error) {
+	_, err := s.Get(key, options)
+	if err == store.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// List the content of a given directory
+func (s *snapshot) List(directory string, options *store.ReadOptions) ([]*store.KVPair, error) {
+	directory = s.store.normalize(directory)
+	if !s.contains(directory) && directory != s.directory {
+		return s.store.List(directory, options)
+	}
+
+	var pairs []*store.KVPair
+	for i := s.search(directory); i < len(s.pairs) && strings.HasPrefix(s.pairs[i].Key, directory); i++ {
+		if s.pairs[i].Key != directory {
+			pairs = append(pairs, s.pairs[i])
+		}
+	}
+
+	if len(pairs) == 0 {
+		return nil, store.ErrKeyNotFound
+	}
+	return pairs, nil
+}
+
+// Put is not supported by a snapshot
+func (s *snapshot) Put(key string, value []byte, options *store.WriteOptions) error {
+	return errReadOnly
+}
+
+// Delete is not supported by a snapshot
+func (s *snapshot) Delete(key string) error {
+	return errReadOnly
+}
+
+// Watch is not supported by a snapshot
+func (s *snapshot) Watch(key string, stopCh <-chan struct{}, options *store.ReadOptions) (<-chan *store.KVPair, error) {
+	return nil, errReadOnly
+}
+
+// WatchTree is not supported by a snapshot
+func (s *snapshot) WatchTree(directory string, stopCh <-chan struct{}, options *store.ReadOptions) (<-chan []*store.KVPair, error) {
+	return nil, errReadOnly
+}
+
+// NewLock is not supported by a snapshot
+func (s *snapshot) NewLock(key string, options *store.LockOptions) (store.Locker, error) {
+	return nil, errReadOnly
+}
+
+// DeleteTree is not supported by a snapshot
+func (s *snapshot) DeleteTree(directory string) error {
+	return errReadOnly
+}
+
+// AtomicPut is not supported by a snapshot
+func (s *snapshot) AtomicPut(key string, value []byte, previous *store.KVPair, options *store.WriteOptions) (bool, *store.KVPair, error) {
+	return false, nil, errReadOnly
+}
+
+// AtomicDelete is not supported by a snapshot
+func (s *snapshot) AtomicDelete(key string, previous *store.KVPair) (bool, error) {
+	return false, errReadOnly
+}
+
+// Close does nothing, the store of the snapshot is not closed
+func (s *snapshot) Close() {}
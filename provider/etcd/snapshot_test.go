@@ -0,0 +1,101 @@
+package etcd
+
+import (
+	"testing"
+
+	"github.com/abronan/valkeyrie/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotGet(t *testing.T) {
+	server := newFakeEtcd(t, "", "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	// The keys outside of the snapshot are read from etcd, the ones inside are not.
+	server.put("/alias/key", "etcd")
+	server.put("/traefik/b", "etcd")
+
+	snap := newSnapshot(s, "/traefik", []*store.KVPair{
+		{Key: "/traefik/a", Value: []byte("1")},
+		{Key: "/traefik/a/c", Value: []byte("3")},
+		{Key: "/traefik/c", Value: []byte("2")},
+	})
+
+	testCases := []struct {
+		desc     string
+		key      string
+		expected string
+		err      error
+	}{
+		{desc: "key in the snapshot", key: "/traefik/a", expected: "1"},
+		{desc: "nested key in the snapshot", key: "/traefik/a/c", expected: "3"},
+		{desc: "key missing from the snapshot", key: "/traefik/b", err: store.ErrKeyNotFound},
+		{desc: "key outside of the snapshot", key: "/alias/key", expected: "etcd"},
+		{desc: "key missing from etcd", key: "/alias/missing", err: store.ErrKeyNotFound},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			pair, err := snap.Get(test.key, nil)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(pair.Value))
+		})
+	}
+}
+
+func TestSnapshotList(t *testing.T) {
+	server := newFakeEtcd(t, "", "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	server.put("/alias/a", "etcd")
+
+	snap := newSnapshot(s, "/traefik", []*store.KVPair{
+		{Key: "/traefik/backends/a", Value: []byte("1")},
+		{Key: "/traefik/backends/a/url", Value: []byte("2")},
+		{Key: "/traefik/frontends/b", Value: []byte("3")},
+	})
+
+	testCases := []struct {
+		desc      string
+		directory string
+		expected  []string
+		err       error
+	}{
+		{desc: "snapshot directory", directory: "/traefik", expected: []string{"/traefik/backends/a", "/traefik/backends/a/url", "/traefik/frontends/b"}},
+		{desc: "sub directory", directory: "/traefik/backends", expected: []string{"/traefik/backends/a", "/traefik/backends/a/url"}},
+		{desc: "missing directory", directory: "/traefik/missing", err: store.ErrKeyNotFound},
+		{desc: "directory outside of the snapshot", directory: "/alias", expected: []string{"/alias/a"}},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			pairs, err := snap.List(test.directory, nil)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var keys []string
+			for _, pair := range pairs {
+				keys = append(keys, pair.Key)
+			}
+			assert.Equal(t, test.expected, keys)
+		})
+	}
+}
@@ -0,0 +1,667 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abronan/valkeyrie"
+	"github.com/abronan/valkeyrie/store"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+const (
+	defaultLockTTL = 20 * time.Second
+	requestTimeout = 5 * time.Second
+)
+
+// errWatchClosed is returned when a watch channel is closed by the etcd client, which happens when the watch fails
+var errWatchClosed = errors.New("etcd watch channel closed")
+
+var _ store.Store = (*Store)(nil)
+
+// Store is a valkeyrie store using the etcd v3 API natively.
+// The watches start from the revision of the read they follow, so that no change is missed in between,
+// and read the keys again when this revision has been compacted.
+// The locks are keys attached to a lease, which is kept alive while the lock is held.
+type Store struct {
+	client *clientv3.Client
+}
+
+// Register registers the etcd v3 store to valkeyrie, in place of the valkeyrie one
+func Register() {
+	valkeyrie.AddStore(store.ETCDV3, New)
+}
+
+// New creates a new etcd v3 client given a list of endpoints and the options of the store.
+func New(endpoints []string, options *store.Config) (store.Store, error) {
+	config := clientv3.Config{
+		Endpoints: store.CreateEndpoints(endpoints, "http"),
+	}
+
+	if options != nil {
+		if options.TLS != nil {
+			config.Endpoints = store.CreateEndpoints(endpoints, "https")
+			config.TLS = options.TLS
+		}
+		config.DialTimeout = options.ConnectionTimeout
+		config.Username = options.Username
+		config.Password = options.Password
+		config.AutoSyncInterval = options.SyncPeriod
+	}
+
+	client, err := clientv3.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{client: client}, nil
+}
+
+// normalize removes the leading slash of a key, as the valkeyrie etcd v3 store does.
+func (s *Store) normalize(key string) string {
+	return strings.TrimPrefix(store.Normalize(key), "/")
+}
+
+// Get a value given its key
+func (s *Store) Get(key string, options *store.ReadOptions) (*store.KVPair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	pair, _, err := s.get(ctx, s.normalize(key), options)
+	return pair, err
+}
+
+// get returns the pair of a key, and the revision of the store it was read at.
+func (s *Store) get(ctx context.Context, key string, options *store.ReadOptions) (*store.KVPair, int64, error) {
+	resp, err := s.client.Get(ctx, key, readOptions(options)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, resp.Header.Revision, store.ErrKeyNotFound
+	}
+	return toPair(resp.Kvs[0]), resp.Header.Revision, nil
+}
+
+// Put a value at the specified key
+func (s *Store) Put(key string, value []byte, options *store.WriteOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	opts, err := s.writeOptions(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Put(ctx, s.normalize(key), string(value), opts...)
+	return err
+}
+
+// writeOptions attaches the key to a new lease when a TTL is given.
+func (s *Store) writeOptions(ctx context.Context, options *store.WriteOptions) ([]clientv3.OpOption, error) {
+	if options == nil || options.TTL <= 0 {
+		return nil, nil
+	}
+
+	lease, err := s.client.Grant(ctx, ttlSeconds(options.TTL))
+	if err != nil {
+		return nil, err
+	}
+	return []clientv3.OpOption{clientv3.WithLease(lease.ID)}, nil
+}
+
+// Delete the value at the specified key
+func (s *Store) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.Delete(ctx, s.normalize(key))
+	if err != nil {
+		return err
+	}
+	if resp.Deleted == 0 {
+		return store.ErrKeyNotFound
+	}
+	return nil
+}
+
+// Exists verifies if a key exists in the store
+func (s *Store) Exists(key string, options *store.ReadOptions) (bool, error) {
+	_, err := s.Get(key, options)
+	if err == store.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// List the content of a given directory
+func (s *Store) List(directory string, options *store.ReadOptions) ([]*store.KVPair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	pairs, _, err := s.list(ctx, s.normalize(directory), options)
+	return pairs, err
+}
+
+// list returns the pairs under a directory sorted by key, and the revision of the store they were read at.
+func (s *Store) list(ctx context.Context, directory string, options *store.ReadOptions) ([]*store.KVPair, int64, error) {
+	opts := append(readOptions(options), clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+
+	resp, err := s.client.Get(ctx, directory, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var pairs []*store.KVPair
+	for _, kv := range resp.Kvs {
+		if string(kv.Key) == directory {
+			continue
+		}
+		pairs = append(pairs, toPair(kv))
+	}
+
+	if len(pairs) == 0 {
+		return nil, resp.Header.Revision, store.ErrKeyNotFound
+	}
+	return pairs, resp.Header.Revision, nil
+}
+
+// DeleteTree deletes a range of keys under a given directory
+func (s *Store) DeleteTree(directory string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.Delete(ctx, s.normalize(directory), clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+	if resp.Deleted == 0 {
+		return store.ErrKeyNotFound
+	}
+	return nil
+}
+
+// Watch for changes on a key.
+// The current value is sent first if the key exists, then the value is sent on each change of the key.
+// A deleted key is sent with an empty value.
+func (s *Store) Watch(key string, stopCh <-chan struct{}, options *store.ReadOptions) (<-chan *store.KVPair, error) {
+	key = s.normalize(key)
+
+	ctx, cancel := contextWithStop(stopCh)
+
+	readCtx, readCancel := context.WithTimeout(ctx, requestTimeout)
+	pair, revision, err := s.get(readCtx, key, options)
+	readCancel()
+	if err != nil && err != store.ErrKeyNotFound {
+		cancel()
+		return nil, err
+	}
+
+	watchCh := make(chan *store.KVPair)
+	go func() {
+		defer cancel()
+		defer close(watchCh)
+
+		for {
+			if pair != nil {
+				select {
+				case watchCh <- pair:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			changes := s.client.Watch(ctx, key, clientv3.WithRev(revision+1))
+			err := forEachChange(changes, func(event *clientv3.Event) error {
+				revision = event.Kv.ModRevision
+
+				changed := toPair(event.Kv)
+				if event.Type == mvccpb.DELETE {
+					changed = &store.KVPair{Key: key, LastIndex: uint64(event.Kv.ModRevision)}
+				}
+
+				select {
+				case watchCh <- changed:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			if err != errCompacted || ctx.Err() != nil {
+				logWatchError(ctx, key, err)
+				return
+			}
+
+			logger.Warnf("The etcd revision %d of the key %s has been compacted, reading it again", revision+1, key)
+
+			readCtx, readCancel := context.WithTimeout(ctx, requestTimeout)
+			pair, revision, err = s.get(readCtx, key, options)
+			readCancel()
+			if err != nil && err != store.ErrKeyNotFound {
+				logWatchError(ctx, key, err)
+				return
+			}
+		}
+	}()
+
+	return watchCh, nil
+}
+
+// WatchTree watches for changes on child nodes under a given directory.
+// The current pairs are sent first, then all the pairs under the directory are sent on each change.
+func (s *Store) WatchTree(directory string, stopCh <-chan struct{}, options *store.ReadOptions) (<-chan []*store.KVPair, error) {
+	directory = s.normalize(directory)
+
+	ctx, cancel := contextWithStop(stopCh)
+
+	readCtx, readCancel := context.WithTimeout(ctx, requestTimeout)
+	pairs, revision, err := s.list(readCtx, directory, options)
+	readCancel()
+	if err != nil && err != store.ErrKeyNotFound {
+		cancel()
+		return nil, err
+	}
+
+	watchCh := make(chan []*store.KVPair)
+	send := func(pairs []*store.KVPair) error {
+		select {
+		case watchCh <- pairs:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	go func() {
+		defer cancel()
+		defer close(watchCh)
+
+		if send(pairs) != nil {
+			return
+		}
+
+		err := s.watchTree(ctx, directory, revision, pairs, options, send)
+		logWatchError(ctx, directory, err)
+	}()
+
+	return watchCh, nil
+}
+
+// watchTree keeps up to date a copy of the pairs under a directory read at the given revision,
+// and calls onChange with all the pairs after each change, until the context is done or onChange fails.
+// When the revision has been compacted, the pairs are read again.
+func (s *Store) watchTree(ctx context.Context, directory string, revision int64, pairs []*store.KVPair, options *store.ReadOptions, onChange func([]*store.KVPair) error) error {
+	tree := make(map[string]*store.KVPair, len(pairs))
+	for _, pair := range pairs {
+		tree[pair.Key] = pair
+	}
+
+	for {
+		changes := s.client.Watch(ctx, directory, clientv3.WithPrefix(), clientv3.WithRev(revision+1))
+
+		// The events of a same revision, like the ones of a transaction, are applied together.
+		var pending bool
+		err := forEachResponse(changes, func(resp clientv3.WatchResponse) error {
+			for _, event := range resp.Events {
+				revision = event.Kv.ModRevision
+
+				key := string(event.Kv.Key)
+				if key == directory {
+					continue
+				}
+
+				pending = true
+				if event.Type == mvccpb.DELETE {
+					delete(tree, key)
+				} else {
+					tree[key] = toPair(event.Kv)
+				}
+			}
+
+			if !pending {
+				return nil
+			}
+			pending = false
+			return onChange(sortedPairs(tree))
+		})
+		if err != errCompacted || ctx.Err() != nil {
+			return err
+		}
+
+		logger.Warnf("The etcd revision %d of the keys under %s has been compacted, listing them again", revision+1, directory)
+
+		readCtx, readCancel := context.WithTimeout(ctx, requestTimeout)
+		pairs, revision, err = s.list(readCtx, directory, options)
+		readCancel()
+		if err != nil && err != store.ErrKeyNotFound {
+			return err
+		}
+
+		tree = make(map[string]*store.KVPair, len(pairs))
+		for _, pair := range pairs {
+			tree[pair.Key] = pair
+		}
+
+		if err := onChange(pairs); err != nil {
+			return err
+		}
+	}
+}
+
+// errCompacted is returned by forEachResponse when the watched revision has been compacted
+var errCompacted = errors.New("etcd revision compacted")
+
+// forEachResponse calls fn for each response of a watch, until the watch channel is closed or fn fails.
+func forEachResponse(changes clientv3.WatchChan, fn func(clientv3.WatchResponse) error) error {
+	for resp := range changes {
+		if resp.CompactRevision != 0 {
+			return errCompacted
+		}
+		if err := resp.Err(); err != nil {
+			return err
+		}
+
+		if err := fn(resp); err != nil {
+			return err
+		}
+	}
+	return errWatchClosed
+}
+
+// forEachChange calls fn for each event of a watch, until the watch channel is closed or fn fails.
+func forEachChange(changes clientv3.WatchChan, fn func(*clientv3.Event) error) error {
+	return forEachResponse(changes, func(resp clientv3.WatchResponse) error {
+		for _, event := range resp.Events {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func logWatchError(ctx context.Context, key string, err error) {
+	if ctx.Err() == nil && err != nil {
+		logger.Errorf("Unable to watch the etcd key %s: %v", key, err)
+	}
+}
+
+// AtomicPut puts a value at the specified key, if it has not been modified since the previous pair.
+// Pass previous = nil to create a new key.
+func (s *Store) AtomicPut(key string, value []byte, previous *store.KVPair, options *store.WriteOptions) (bool, *store.KVPair, error) {
+	key = s.normalize(key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	cmp := clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	if previous != nil {
+		cmp = clientv3.Compare(clientv3.ModRevision(key), "=", int64(previous.LastIndex))
+	}
+
+	opts, err := s.writeOptions(ctx, options)
+	if err != nil {
+		return false, nil, err
+	}
+
+	resp, err := s.client.Txn(ctx).If(cmp).Then(clientv3.OpPut(key, string(value), opts...)).Commit()
+	if err != nil {
+		return false, nil, err
+	}
+
+	if !resp.Succeeded {
+		if previous == nil {
+			return false, nil, store.ErrKeyExists
+		}
+		return false, nil, store.ErrKeyModified
+	}
+
+	return true, &store.KVPair{Key: key, Value: value, LastIndex: uint64(resp.Header.Revision)}, nil
+}
+
+// AtomicDelete deletes a value at the specified key, if it has not been modified since the previous pair.
+func (s *Store) AtomicDelete(key string, previous *store.KVPair) (bool, error) {
+	if previous == nil {
+		return false, store.ErrPreviousNotSpecified
+	}
+
+	key = s.normalize(key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", int64(previous.LastIndex))).
+		Then(clientv3.OpDelete(key)).
+		Else(clientv3.OpGet(key, clientv3.WithCountOnly())).
+		Commit()
+	if err != nil {
+		return false, err
+	}
+
+	if !resp.Succeeded {
+		if resp.Responses[0].GetResponseRange().Count == 0 {
+			return false, store.ErrKeyNotFound
+		}
+		return false, store.ErrKeyModified
+	}
+	return true, nil
+}
+
+// NewLock creates a lock for a given key.
+// The lock holds the key, set to the value of the lock and attached to a lease, which is kept alive until the lock is released.
+func (s *Store) NewLock(key string, options *store.LockOptions) (store.Locker, error) {
+	l := &lock{
+		store: s,
+		key:   s.normalize(key),
+		ttl:   defaultLockTTL,
+	}
+
+	if options != nil {
+		l.value = string(options.Value)
+		l.renewCh = options.RenewLock
+		if options.TTL > 0 {
+			l.ttl = options.TTL
+		}
+	}
+
+	return l, nil
+}
+
+// Close the store connection
+func (s *Store) Close() {
+	s.client.Close()
+}
+
+// lock is a store.Locker on an etcd key attached to a lease
+type lock struct {
+	store   *Store
+	key     string
+	value   string
+	ttl     time.Duration
+	renewCh chan struct{}
+
+	mu      sync.Mutex
+	leaseID clientv3.LeaseID
+	release context.CancelFunc
+}
+
+// Lock attempts to acquire the lock and blocks while doing so.
+// It returns a channel that is closed if the lock is lost, or nil if stopChan receives or is closed before the lock is acquired.
+func (l *lock) Lock(stopChan chan struct{}) (<-chan struct{}, error) {
+	ctx, cancel := contextWithStop(stopChan)
+	defer cancel()
+
+	client := l.store.client
+
+	var lease *clientv3.LeaseGrantResponse
+	var revision int64
+	for {
+		// A lease is granted for each attempt, so that it does not expire while the lock is held by another client.
+		var err error
+		lease, err = client.Grant(ctx, ttlSeconds(l.ttl))
+		if err != nil {
+			return stopped(ctx, err)
+		}
+
+		resp, err := client.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(l.key), "=", 0)).
+			Then(clientv3.OpPut(l.key, l.value, clientv3.WithLease(lease.ID))).
+			Commit()
+		if err != nil {
+			l.revoke(lease.ID)
+			return stopped(ctx, err)
+		}
+
+		revision = resp.Header.Revision
+		if resp.Succeeded {
+			break
+		}
+		l.revoke(lease.ID)
+
+		// The lock is held by another client, it is acquired again once the key is deleted.
+		changes := client.Watch(ctx, l.key, clientv3.WithRev(revision+1), clientv3.WithFilterPut())
+		err = forEachChange(changes, func(*clientv3.Event) error { return errDeleted })
+		if err != errDeleted && err != errCompacted {
+			return stopped(ctx, err)
+		}
+	}
+
+	// The lease is kept alive, and the lock is held, until the lock is released.
+	holdCtx, release := context.WithCancel(context.Background())
+	keepAlive, err := client.KeepAlive(holdCtx, lease.ID)
+	if err != nil {
+		release()
+		l.revoke(lease.ID)
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.leaseID = lease.ID
+	l.release = release
+	l.mu.Unlock()
+
+	lostCh := make(chan struct{})
+	go l.hold(holdCtx, lease.ID, revision, keepAlive, lostCh)
+
+	return lostCh, nil
+}
+
+// errDeleted stops watching a lock held by another client, when its key has been deleted
+var errDeleted = errors.New("etcd lock key deleted")
+
+// hold closes lostCh when the lock is lost: when the lease expires, or when the key is deleted or taken over.
+// Closing the RenewLock channel of the options releases the lock.
+func (l *lock) hold(ctx context.Context, leaseID clientv3.LeaseID, revision int64, keepAlive <-chan *clientv3.LeaseKeepAliveResponse, lostCh chan struct{}) {
+	defer close(lostCh)
+
+	changes := l.store.client.Watch(ctx, l.key, clientv3.WithRev(revision+1))
+	for {
+		select {
+		case _, ok := <-keepAlive:
+			if !ok {
+				return
+			}
+		case resp, ok := <-changes:
+			if !ok || resp.Err() != nil {
+				return
+			}
+			for _, event := range resp.Events {
+				if event.Type == mvccpb.DELETE || event.Kv.Lease != int64(leaseID) {
+					return
+				}
+			}
+		case <-l.renewCh:
+			if err := l.Unlock(); err != nil {
+				logger.Errorf("Unable to release the etcd lock %s: %v", l.key, err)
+			}
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Unlock releases the lock, if it is still held.
+// The lease is revoked, which deletes the key.
+func (l *lock) Unlock() error {
+	l.mu.Lock()
+	leaseID, release := l.leaseID, l.release
+	l.leaseID, l.release = 0, nil
+	l.mu.Unlock()
+
+	if release == nil {
+		return nil
+	}
+
+	release()
+	return l.revoke(leaseID)
+}
+
+func (l *lock) revoke(leaseID clientv3.LeaseID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	_, err := l.store.client.Revoke(ctx, leaseID)
+	return err
+}
+
+// stopped returns nil when the context of a lock attempt has been canceled by its stop channel, and the error otherwise.
+func stopped(ctx context.Context, err error) (<-chan struct{}, error) {
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// contextWithStop returns a context canceled when stopCh receives or is closed, or when the returned cancel function is called.
+func contextWithStop(stopCh <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+func readOptions(options *store.ReadOptions) []clientv3.OpOption {
+	if options != nil && !options.Consistent {
+		return []clientv3.OpOption{clientv3.WithSerializable()}
+	}
+	return nil
+}
+
+// ttlSeconds rounds up a TTL to the seconds of the etcd leases.
+func ttlSeconds(ttl time.Duration) int64 {
+	return int64(math.Max(1, math.Ceil(ttl.Seconds())))
+}
+
+func toPair(kv *mvccpb.KeyValue) *store.KVPair {
+	return &store.KVPair{
+		Key:       string(kv.Key),
+		Value:     kv.Value,
+		LastIndex: uint64(kv.ModRevision),
+	}
+}
+
+func sortedPairs(tree map[string]*store.KVPair) []*store.KVPair {
+	pairs := make([]*store.KVPair, 0, len(tree))
+	for _, pair := range tree {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	return pairs
+}
@@ -0,0 +1,221 @@
+package etcd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abronan/valkeyrie/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, server *fakeEtcd) *Store {
+	kv, err := New([]string{server.endpoint()}, &store.Config{ConnectionTimeout: time.Second})
+	require.NoError(t, err)
+	return kv.(*Store)
+}
+
+func TestStoreAuthentication(t *testing.T) {
+	server := newFakeEtcd(t, "traefik", "secret")
+	defer server.close()
+
+	_, err := New([]string{server.endpoint()}, &store.Config{ConnectionTimeout: time.Second, Username: "traefik", Password: "wrong"})
+	assert.Error(t, err)
+
+	kv, err := New([]string{server.endpoint()}, &store.Config{ConnectionTimeout: time.Second, Username: "traefik", Password: "secret"})
+	require.NoError(t, err)
+	defer kv.Close()
+
+	require.NoError(t, kv.Put("traefik/key", []byte("value"), nil))
+
+	pair, err := kv.Get("traefik/key", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "value", string(pair.Value))
+}
+
+func TestStorePutTTLLeaseExpired(t *testing.T) {
+	server := newFakeEtcd(t, "", "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	require.NoError(t, s.Put("traefik/ttl", []byte("value"), &store.WriteOptions{TTL: 10 * time.Millisecond}))
+
+	kv := server.value("traefik/ttl")
+	require.NotNil(t, kv)
+	require.NotZero(t, kv.Lease)
+
+	server.expire(kv.Lease)
+
+	_, err := s.Get("traefik/ttl", nil)
+	assert.Equal(t, store.ErrKeyNotFound, err)
+}
+
+func TestStoreWatchCompacted(t *testing.T) {
+	server := newFakeEtcd(t, "", "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	server.put("traefik/key", "value1")
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	watchCh, err := s.Watch("traefik/key", stopCh, nil)
+	require.NoError(t, err)
+
+	// The watch starts once the current value is received: the changes made in between are compacted, so the key is read again.
+	server.put("traefik/key", "value2")
+	server.put("traefik/other", "other")
+	server.compact(server.currentRevision())
+
+	assert.Equal(t, "value1", string(receivePair(t, watchCh).Value))
+
+	pair := receivePair(t, watchCh)
+	assert.Equal(t, "value2", string(pair.Value))
+	assert.Equal(t, uint64(server.value("traefik/key").ModRevision), pair.LastIndex)
+
+	// The watch goes on from the revision of the new read.
+	server.put("traefik/key", "value3")
+	assert.Equal(t, "value3", string(receivePair(t, watchCh).Value))
+}
+
+func TestStoreWatchTreeCompacted(t *testing.T) {
+	server := newFakeEtcd(t, "", "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	server.put("traefik/a", "1")
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	watchCh, err := s.WatchTree("traefik", stopCh, nil)
+	require.NoError(t, err)
+
+	// The watch starts once the current pairs are received: the changes made in between are compacted, so the keys are listed again.
+	server.put("traefik/b", "2")
+	server.remove("traefik/a")
+	server.compact(server.currentRevision())
+
+	assert.Equal(t, map[string]string{"traefik/a": "1"}, values(receivePairs(t, watchCh)))
+	assert.Equal(t, map[string]string{"traefik/b": "2"}, values(receivePairs(t, watchCh)))
+
+	// The watch goes on from the revision of the new listing.
+	server.put("traefik/c", "3")
+	assert.Equal(t, map[string]string{"traefik/b": "2", "traefik/c": "3"}, values(receivePairs(t, watchCh)))
+}
+
+func TestStoreLockLeaseKeptAlive(t *testing.T) {
+	server := newFakeEtcd(t, "", "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	lock, err := s.NewLock("traefik/lock", &store.LockOptions{Value: []byte("node1"), TTL: time.Second})
+	require.NoError(t, err)
+
+	lostCh, err := lock.Lock(nil)
+	require.NoError(t, err)
+
+	// The lease would expire after its TTL of one second if it were not kept alive.
+	select {
+	case <-lostCh:
+		t.Fatal("the lock is lost while its lease is kept alive")
+	case <-time.After(1500 * time.Millisecond):
+	}
+
+	kv := server.value("traefik/lock")
+	require.NotNil(t, kv)
+	assert.Equal(t, "node1", string(kv.Value))
+
+	require.NoError(t, lock.Unlock())
+	assertClosed(t, lostCh)
+	assert.Nil(t, server.value("traefik/lock"))
+}
+
+func TestStoreLockLeaseExpired(t *testing.T) {
+	server := newFakeEtcd(t, "", "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	lock1, err := s.NewLock("traefik/lock", &store.LockOptions{Value: []byte("node1")})
+	require.NoError(t, err)
+
+	lostCh1, err := lock1.Lock(nil)
+	require.NoError(t, err)
+
+	lock2, err := s.NewLock("traefik/lock", &store.LockOptions{Value: []byte("node2")})
+	require.NoError(t, err)
+
+	acquired := make(chan (<-chan struct{}))
+	go func() {
+		lostCh2, errLock := lock2.Lock(nil)
+		assert.NoError(t, errLock)
+		acquired <- lostCh2
+	}()
+
+	// The expiry of the lease deletes the key: the lock is lost, and taken by the waiting node.
+	server.expire(server.value("traefik/lock").Lease)
+	assertClosed(t, lostCh1)
+
+	select {
+	case lostCh2 := <-acquired:
+		require.NotNil(t, lostCh2)
+	case <-time.After(time.Second):
+		t.Fatal("the lock is not acquired after the expiry of the lease")
+	}
+	assert.Equal(t, "node2", string(server.value("traefik/lock").Value))
+}
+
+func receivePair(t *testing.T, watchCh <-chan *store.KVPair) *store.KVPair {
+	t.Helper()
+
+	select {
+	case pair, ok := <-watchCh:
+		require.True(t, ok, "the watch channel is closed")
+		return pair
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the pair")
+		return nil
+	}
+}
+
+func receivePairs(t *testing.T, watchCh <-chan []*store.KVPair) []*store.KVPair {
+	t.Helper()
+
+	select {
+	case pairs, ok := <-watchCh:
+		require.True(t, ok, "the watch channel is closed")
+		return pairs
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the pairs")
+		return nil
+	}
+}
+
+func values(pairs []*store.KVPair) map[string]string {
+	result := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		result[pair.Key] = string(pair.Value)
+	}
+	return result
+}
+
+func assertClosed(t *testing.T, ch <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("the channel is not closed")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"text/template"
 
 	"github.com/ArthurHlt/go-eureka-client/eureka"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -28,7 +27,7 @@ func (p *Provider) buildConfiguration(apps *eureka.Applications) (*types.Configu
 
 	configuration, err := p.GetConfiguration("templates/eureka.tmpl", eurekaFuncMap, templateObjects)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 	return configuration, nil
 }
@@ -14,6 +14,8 @@ import (
 	"github.com/containous/traefik/types"
 )
 
+var logger = log.WithModule("provider/eureka")
+
 // Provider holds configuration of the Provider provider.
 type Provider struct {
 	provider.BaseProvider `mapstructure:",squash" export:"true"`
@@ -37,13 +39,13 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 
 		applications, err := client.GetApplications()
 		if err != nil {
-			log.Errorf("Failed to retrieve applications, error: %s", err)
+			logger.Errorf("Failed to retrieve applications, error: %s", err)
 			return err
 		}
 
 		configuration, err := p.buildConfiguration(applications)
 		if err != nil {
-			log.Errorf("Failed to build configuration for Provider, error: %s", err)
+			logger.Errorf("Failed to build configuration for Provider, error: %s", err)
 			return err
 		}
 
@@ -57,15 +59,15 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 			for {
 				select {
 				case t := <-ticker.C:
-					log.Debugf("Refreshing Provider %s", t.String())
+					logger.Debugf("Refreshing Provider %s", t.String())
 					applications, err := client.GetApplications()
 					if err != nil {
-						log.Errorf("Failed to retrieve applications, error: %s", err)
+						logger.Errorf("Failed to retrieve applications, error: %s", err)
 						continue
 					}
 					configuration, err := p.buildConfiguration(applications)
 					if err != nil {
-						log.Errorf("Failed to refresh Provider configuration, error: %s", err)
+						logger.Errorf("Failed to refresh Provider configuration, error: %s", err)
 						continue
 					}
 					configurationChan <- types.ConfigMessage{
@@ -82,12 +84,12 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 
 	err := backoff.RetryNotify(operation, job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 	if err != nil {
-		log.Errorf("Cannot connect to Provider server %+v", err)
+		logger.Errorf("Cannot connect to Provider server %+v", err)
 		return err
 	}
 	return nil
 }
 
 func notify(err error, time time.Duration) {
-	log.Errorf("Provider connection error %+v, retrying in %s", err, time)
+	logger.Errorf("Provider connection error %+v, retrying in %s", err, time)
 }
@@ -18,6 +18,8 @@ import (
 	"gopkg.in/fsnotify.v1"
 )
 
+var logger = log.WithModule("provider/file")
+
 var _ provider.Provider = (*Provider)(nil)
 
 // Provider holds configurations of the provider.
@@ -115,7 +117,7 @@ func (p *Provider) addWatcher(pool *safe.Pool, directory string, configurationCh
 					callback(configurationChan, evt)
 				}
 			case err := <-watcher.Errors:
-				log.Errorf("Watcher event error: %s", err)
+				logger.Errorf("Watcher event error: %s", err)
 			}
 		}
 	})
@@ -131,14 +133,14 @@ func (p *Provider) watcherCallback(configurationChan chan<- types.ConfigMessage,
 	}
 
 	if _, err := os.Stat(watchItem); err != nil {
-		log.Debugf("Unable to watch %s : %v", watchItem, err)
+		logger.Debugf("Unable to watch %s : %v", watchItem, err)
 		return
 	}
 
 	configuration, err := p.BuildConfiguration()
 
 	if err != nil {
-		log.Errorf("Error occurred during watcher callback: %s", err)
+		logger.Errorf("Error occurred during watcher callback: %s", err)
 		return
 	}
 
@@ -183,14 +185,14 @@ func (p *Provider) loadFileConfig(filename string, parseTemplate bool) (*types.C
 	for _, conf := range configuration.TLS {
 		bytes, err := conf.Certificate.CertFile.Read()
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 			continue
 		}
 		conf.Certificate.CertFile = tls.FileOrContent(string(bytes))
 
 		bytes, err = conf.Certificate.KeyFile.Read()
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 			continue
 		}
 		conf.Certificate.KeyFile = tls.FileOrContent(string(bytes))
@@ -243,7 +245,7 @@ func (p *Provider) loadFileConfigFromDirectory(directory string, configuration *
 
 		for backendName, backend := range c.Backends {
 			if _, exists := configuration.Backends[backendName]; exists {
-				log.Warnf("Backend %s already configured, skipping", backendName)
+				logger.Warnf("Backend %s already configured, skipping", backendName)
 			} else {
 				configuration.Backends[backendName] = backend
 			}
@@ -251,7 +253,7 @@ func (p *Provider) loadFileConfigFromDirectory(directory string, configuration *
 
 		for frontendName, frontend := range c.Frontends {
 			if _, exists := configuration.Frontends[frontendName]; exists {
-				log.Warnf("Frontend %s already configured, skipping", frontendName)
+				logger.Warnf("Frontend %s already configured, skipping", frontendName)
 			} else {
 				configuration.Frontends[frontendName] = frontend
 			}
@@ -259,7 +261,7 @@ func (p *Provider) loadFileConfigFromDirectory(directory string, configuration *
 
 		for _, conf := range c.TLS {
 			if _, exists := configTLSMaps[conf]; exists {
-				log.Warnf("TLS Configuration %v already configured, skipping", conf)
+				logger.Warnf("TLS Configuration %v already configured, skipping", conf)
 			} else {
 				configTLSMaps[conf] = struct{}{}
 			}
@@ -0,0 +1,247 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cenk/backoff"
+	"github.com/containous/flaeg"
+	"github.com/containous/traefik/job"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/provider"
+	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/types"
+)
+
+var logger = log.WithModule("provider/http")
+
+var _ provider.Provider = (*Provider)(nil)
+
+// errNotModified is returned when the configuration served by the endpoint did not change since the last request
+var errNotModified = errors.New("configuration not modified")
+
+// Provider holds configurations of the provider.
+type Provider struct {
+	provider.BaseProvider `mapstructure:",squash" export:"true"`
+	Endpoint              string           `description:"URL of the endpoint serving the configuration"`
+	PollInterval          flaeg.Duration   `description:"Polling interval of the endpoint" export:"true"`
+	PollTimeout           flaeg.Duration   `description:"Timeout of the requests to the endpoint" export:"true"`
+	LongPollTimeout       flaeg.Duration   `description:"Maximum duration the endpoint may hold a request until the configuration changes (long-poll), disabled if 0" export:"true"`
+	TLS                   *types.ClientTLS `description:"Enable TLS support" export:"true"`
+	Headers               types.KeyValues  `description:"Headers sent with the requests to the endpoint (key=value)"`
+	client                *http.Client
+	etag                  string
+	lastContent           []byte
+}
+
+// Init the provider
+func (p *Provider) Init(constraints types.Constraints) error {
+	if len(p.Endpoint) == 0 {
+		return errors.New("an endpoint is required")
+	}
+
+	client, err := p.createClient()
+	if err != nil {
+		return err
+	}
+	p.client = client
+
+	return p.BaseProvider.Init(constraints)
+}
+
+func (p *Provider) createClient() (*http.Client, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+
+	if p.TLS != nil {
+		tlsConfig, err := p.TLS.CreateTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(p.PollTimeout) + time.Duration(p.LongPollTimeout),
+	}, nil
+}
+
+// Provide allows the http provider to provide configurations to traefik
+// using the given configuration channel.
+func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *safe.Pool) error {
+	pool.GoCtx(func(routineCtx context.Context) {
+		operation := func() error {
+			configuration, err := p.fetchConfiguration(routineCtx)
+			if err != nil && err != errNotModified {
+				logger.Errorf("Failed to fetch the configuration from %s: %v", p.Endpoint, err)
+				return err
+			}
+
+			if configuration != nil {
+				sendConfiguration(routineCtx, configurationChan, configuration)
+			}
+
+			if p.Watch {
+				p.watch(routineCtx, configurationChan)
+			}
+			return nil
+		}
+
+		notify := func(err error, time time.Duration) {
+			logger.Errorf("Provider connection error %+v, retrying in %s", err, time)
+		}
+		err := backoff.RetryNotify(safe.OperationWithRecover(operation), backoff.WithContext(job.NewBackOff(backoff.NewExponentialBackOff()), routineCtx), notify)
+		if err != nil {
+			logger.Errorf("Cannot connect to HTTP endpoint %s: %v", p.Endpoint, err)
+		}
+	})
+
+	return nil
+}
+
+// watch polls the endpoint until the context is done.
+// The last good configuration is kept when the endpoint cannot be reached, or serves an invalid configuration.
+func (p *Provider) watch(ctx context.Context, configurationChan chan<- types.ConfigMessage) {
+	start := time.Now()
+
+	for {
+		// With long-poll, the time the endpoint held the last request counts in the polling interval,
+		// so that a change is fetched right away, while an endpoint ignoring long-poll is not flooded.
+		delay := time.Duration(p.PollInterval)
+		if p.LongPollTimeout > 0 {
+			delay -= time.Since(start)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		start = time.Now()
+		configuration, err := p.fetchConfiguration(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch {
+		case err == errNotModified:
+			logger.Debugf("Configuration not modified on %s", p.Endpoint)
+		case err != nil:
+			logger.Errorf("Failed to fetch the configuration from %s, keeping the last one: %v", p.Endpoint, err)
+		default:
+			sendConfiguration(ctx, configurationChan, configuration)
+		}
+	}
+}
+
+func sendConfiguration(ctx context.Context, configurationChan chan<- types.ConfigMessage, configuration *types.Configuration) {
+	message := types.ConfigMessage{
+		ProviderName:  "http",
+		Configuration: configuration,
+	}
+
+	select {
+	case configurationChan <- message:
+	case <-ctx.Done():
+	}
+}
+
+// fetchConfiguration requests the configuration to the endpoint.
+// It returns errNotModified when the endpoint answers with a 304, or serves the same content as the last time.
+func (p *Provider) fetchConfiguration(ctx context.Context) (*types.Configuration, error) {
+	req, err := http.NewRequest(http.MethodGet, p.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	for name, value := range p.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Accept", "application/json, application/toml")
+	if len(p.etag) > 0 {
+		req.Header.Set("If-None-Match", p.etag)
+	}
+	if p.LongPollTimeout > 0 {
+		// https://tools.ietf.org/html/rfc7240#section-4.3
+		req.Header.Set("Prefer", fmt.Sprintf("wait=%d", int64(time.Duration(p.LongPollTimeout).Seconds())))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, errNotModified
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, errors.New("empty configuration")
+	}
+
+	if p.lastContent != nil && bytes.Equal(content, p.lastContent) {
+		p.etag = resp.Header.Get("ETag")
+		return nil, errNotModified
+	}
+
+	configuration, err := p.decodeConfiguration(resp.Header.Get("Content-Type"), content)
+	if err != nil {
+		return nil, err
+	}
+
+	// The validators are only kept once the configuration is decoded, so that an invalid configuration is not skipped when fixed.
+	p.etag = resp.Header.Get("ETag")
+	p.lastContent = content
+
+	return configuration, nil
+}
+
+// decodeConfiguration decodes the content served by the endpoint according to its media type.
+// Without a known media type, the content is decoded as JSON when it is a JSON object, and as TOML otherwise.
+func (p *Provider) decodeConfiguration(contentType string, content []byte) (*types.Configuration, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = ""
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return decodeJSONConfiguration(content)
+	case mediaType == "application/toml" || mediaType == "text/x-toml":
+		return p.DecodeConfiguration(string(content))
+	case bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")):
+		return decodeJSONConfiguration(content)
+	default:
+		return p.DecodeConfiguration(string(content))
+	}
+}
+
+func decodeJSONConfiguration(content []byte) (*types.Configuration, error) {
+	configuration := new(types.Configuration)
+	if err := json.Unmarshal(content, configuration); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
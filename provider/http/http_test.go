@@ -0,0 +1,222 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg"
+	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	jsonConfiguration = `{"backends":{"backend1":{"servers":{"server1":{"url":"http://127.0.0.1:8080","weight":1}}}}}`
+	tomlConfiguration = `
+[backends]
+  [backends.backend1]
+    [backends.backend1.servers.server1]
+    url = "http://127.0.0.1:8080"
+    weight = 1
+`
+)
+
+var expectedBackends = map[string]*types.Backend{
+	"backend1": {
+		Servers: map[string]types.Server{
+			"server1": {URL: "http://127.0.0.1:8080", Weight: 1},
+		},
+	},
+}
+
+func TestProviderFetchConfiguration(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		contentType   string
+		content       string
+		status        int
+		expectedError bool
+	}{
+		{
+			desc:        "JSON configuration",
+			contentType: "application/json; charset=utf-8",
+			content:     jsonConfiguration,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "TOML configuration",
+			contentType: "application/toml",
+			content:     tomlConfiguration,
+			status:      http.StatusOK,
+		},
+		{
+			desc:    "JSON configuration without content type",
+			content: jsonConfiguration,
+			status:  http.StatusOK,
+		},
+		{
+			desc:        "TOML configuration with an unknown content type",
+			contentType: "text/plain",
+			content:     tomlConfiguration,
+			status:      http.StatusOK,
+		},
+		{
+			desc:          "Invalid configuration",
+			contentType:   "application/json",
+			content:       `{"backends":`,
+			status:        http.StatusOK,
+			expectedError: true,
+		},
+		{
+			desc:          "Empty configuration",
+			contentType:   "application/json",
+			status:        http.StatusOK,
+			expectedError: true,
+		},
+		{
+			desc:          "Error status",
+			content:       jsonConfiguration,
+			status:        http.StatusInternalServerError,
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if len(test.contentType) > 0 {
+					rw.Header().Set("Content-Type", test.contentType)
+				}
+				rw.WriteHeader(test.status)
+				fmt.Fprint(rw, test.content)
+			}))
+			defer server.Close()
+
+			p := &Provider{Endpoint: server.URL}
+			require.NoError(t, p.Init(nil))
+
+			configuration, err := p.fetchConfiguration(context.Background())
+			if test.expectedError {
+				require.Error(t, err)
+				assert.Nil(t, configuration)
+				assert.Nil(t, p.lastContent)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, configuration)
+			assert.Equal(t, expectedBackends, configuration.Backends)
+		})
+	}
+}
+
+func TestProviderFetchConfigurationNotModified(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req)
+
+		switch req.Header.Get("If-None-Match") {
+		case `"v1"`:
+			rw.WriteHeader(http.StatusNotModified)
+		case `"v2"`:
+			// Same content with another ETag, like an endpoint serving a configuration generated again.
+			rw.Header().Set("ETag", `"v3"`)
+			fmt.Fprint(rw, jsonConfiguration)
+		default:
+			rw.Header().Set("ETag", `"v1"`)
+			fmt.Fprint(rw, jsonConfiguration)
+		}
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		Endpoint:        server.URL,
+		LongPollTimeout: flaeg.Duration(30 * time.Second),
+		Headers:         types.KeyValues{"Authorization": "Bearer token"},
+	}
+	require.NoError(t, p.Init(nil))
+
+	configuration, err := p.fetchConfiguration(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expectedBackends, configuration.Backends)
+
+	configuration, err = p.fetchConfiguration(context.Background())
+	assert.Equal(t, errNotModified, err)
+	assert.Nil(t, configuration)
+
+	p.etag = `"v2"`
+	configuration, err = p.fetchConfiguration(context.Background())
+	assert.Equal(t, errNotModified, err)
+	assert.Nil(t, configuration)
+	assert.Equal(t, `"v3"`, p.etag)
+
+	require.Len(t, requests, 3)
+	assert.Empty(t, requests[0].Header.Get("If-None-Match"))
+	assert.Equal(t, `"v1"`, requests[1].Header.Get("If-None-Match"))
+	for _, req := range requests {
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		assert.Equal(t, "wait=30", req.Header.Get("Prefer"))
+	}
+}
+
+func TestProviderProvide(t *testing.T) {
+	var lock sync.Mutex
+	responses := []struct {
+		status  int
+		content string
+	}{
+		{status: http.StatusOK, content: jsonConfiguration},
+		{status: http.StatusServiceUnavailable},
+		{status: http.StatusOK, content: `{"backends":`},
+		{status: http.StatusOK, content: jsonConfiguration},
+		{status: http.StatusOK, content: `{"backends":{}}`},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		response := responses[0]
+		if len(responses) > 1 {
+			responses = responses[1:]
+		}
+
+		rw.WriteHeader(response.status)
+		fmt.Fprint(rw, response.content)
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		Endpoint:     server.URL,
+		PollInterval: flaeg.Duration(10 * time.Millisecond),
+		PollTimeout:  flaeg.Duration(time.Second),
+	}
+	p.Watch = true
+	require.NoError(t, p.Init(nil))
+
+	configurationChan := make(chan types.ConfigMessage)
+	pool := safe.NewPool(context.Background())
+	defer pool.Stop()
+
+	require.NoError(t, p.Provide(configurationChan, pool))
+
+	// The errors and the unchanged configuration are not sent, the last good configuration is kept.
+	for _, expected := range []map[string]*types.Backend{expectedBackends, {}} {
+		select {
+		case message := <-configurationChan:
+			assert.Equal(t, "http", message.ProviderName)
+			assert.Equal(t, expected, message.Configuration.Backends)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the configuration")
+		}
+	}
+}
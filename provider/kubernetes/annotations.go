@@ -2,10 +2,14 @@ package kubernetes
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/containous/traefik/provider/label"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 )
 
+const annotationKubernetesPrefix = "ingress.kubernetes.io/"
+
 const (
 	annotationKubernetesIngressClass                    = "kubernetes.io/ingress.class"
 	annotationKubernetesAuthRealm                       = "ingress.kubernetes.io/auth-realm"
@@ -44,6 +48,9 @@ const (
 	annotationKubernetesAppRoot                         = "ingress.kubernetes.io/app-root"
 	annotationKubernetesServiceWeights                  = "ingress.kubernetes.io/service-weights"
 	annotationKubernetesRequestModifier                 = "ingress.kubernetes.io/request-modifier"
+	annotationKubernetesServiceUpstream                 = "ingress.kubernetes.io/service-upstream"
+	annotationKubernetesDefaultBackendNamespace         = "ingress.kubernetes.io/default-backend-namespace"
+	annotationKubernetesForcedAnnotations               = "ingress.kubernetes.io/forced-annotations"
 
 	annotationKubernetesSSLForceHost            = "ingress.kubernetes.io/ssl-force-host"
 	annotationKubernetesSSLRedirect             = "ingress.kubernetes.io/ssl-redirect"
@@ -141,3 +148,51 @@ func getMapValue(annotations map[string]string, annotation string) map[string]st
 	annotationName := getAnnotationName(annotations, annotation)
 	return label.GetMapValue(annotations, annotationName)
 }
+
+// applyNamespaceDefaults returns the Ingress with the annotations of its namespace as default annotations.
+// The annotations of the Ingress take precedence, whatever their prefix,
+// unless the namespace forces them with the forced-annotations annotation. The cached Ingress is not modified.
+func applyNamespaceDefaults(k8sClient Client, i *extensionsv1beta1.Ingress) (*extensionsv1beta1.Ingress, error) {
+	namespace, exists, err := k8sClient.GetNamespace(i.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !exists || len(namespace.Annotations) == 0 {
+		return i, nil
+	}
+
+	forced := make(map[string]bool)
+	for _, name := range getSliceStringValue(namespace.Annotations, annotationKubernetesForcedAnnotations) {
+		forced[strings.TrimPrefix(name, label.Prefix)] = true
+	}
+
+	annotations := make(map[string]string, len(i.Annotations)+len(namespace.Annotations))
+	for key, value := range i.Annotations {
+		annotations[key] = value
+	}
+
+	for key, value := range namespace.Annotations {
+		name := strings.TrimPrefix(key, label.Prefix)
+		if !strings.HasPrefix(name, annotationKubernetesPrefix) || name == annotationKubernetesForcedAnnotations {
+			continue
+		}
+
+		if forced[name] {
+			delete(annotations, name)
+			delete(annotations, label.Prefix+name)
+			delete(annotations, compatibilityMapping[name])
+			annotations[key] = value
+			continue
+		}
+
+		_, exists := i.Annotations[name]
+		_, existsWithPrefix := i.Annotations[label.Prefix+name]
+		if !exists && !existsWithPrefix {
+			annotations[key] = value
+		}
+	}
+
+	ingress := *i
+	ingress.Annotations = annotations
+	return &ingress, nil
+}
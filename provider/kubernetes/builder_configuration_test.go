@@ -494,6 +494,37 @@ func certificate(cert string, key string) func(*tls.Configuration) {
 	}
 }
 
+func tlsDefault() func(*tls.Configuration) {
+	return func(c *tls.Configuration) {
+		c.Default = true
+	}
+}
+
+func clientCAsSection(opts ...func(*tls.ClientCAConfiguration)) func(*types.Configuration) {
+	return func(c *types.Configuration) {
+		for _, opt := range opts {
+			clientCA := &tls.ClientCAConfiguration{}
+			opt(clientCA)
+			c.ClientCAs = append(c.ClientCAs, clientCA)
+		}
+	}
+}
+
+func clientCASection(entryPoints []string, files ...string) func(*tls.ClientCAConfiguration) {
+	return func(c *tls.ClientCAConfiguration) {
+		c.EntryPoints = entryPoints
+		for _, file := range files {
+			c.Files = append(c.Files, tls.FileOrContent(file))
+		}
+	}
+}
+
+func tlsOptionsSection(options ...*tls.DomainsOptions) func(*types.Configuration) {
+	return func(c *types.Configuration) {
+		c.TLSOptions = append(c.TLSOptions, options...)
+	}
+}
+
 // Test
 
 func TestBuildConfiguration(t *testing.T) {
@@ -6,14 +6,24 @@ import (
 	"io/ioutil"
 	"time"
 
-	"github.com/containous/traefik/log"
+	gatewayv1 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1"
+	gatewayv1alpha2 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1alpha2"
+	"github.com/containous/traefik/provider/kubernetes/crd/traefik/v1alpha1"
+	discoveryv1 "github.com/containous/traefik/provider/kubernetes/discovery/v1"
+	discoveryv1beta1 "github.com/containous/traefik/provider/kubernetes/discovery/v1beta1"
+	networkingv1 "github.com/containous/traefik/provider/kubernetes/networking/v1"
+	networkingv1beta1 "github.com/containous/traefik/provider/kubernetes/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	kubeerror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 )
@@ -41,25 +51,64 @@ func (reh *resourceEventHandler) OnDelete(obj interface{}) {
 // The stores can then be accessed via the Get* functions.
 type Client interface {
 	WatchAll(namespaces Namespaces, stopCh <-chan struct{}) (<-chan interface{}, error)
-	GetIngresses() []*extensionsv1beta1.Ingress
+	GetIngresses() []*Ingress
+	GetIngressClasses() []*networkingv1.IngressClass
 	GetService(namespace, name string) (*corev1.Service, bool, error)
 	GetSecret(namespace, name string) (*corev1.Secret, bool, error)
 	GetEndpoints(namespace, name string) (*corev1.Endpoints, bool, error)
 	UpdateIngressStatus(namespace, name, ip, hostname string) error
+	GetIngressRoutes() []*v1alpha1.IngressRoute
+	GetMiddleware(namespace, name string) (*v1alpha1.Middleware, bool, error)
+	GetTLSOption(namespace, name string) (*v1alpha1.TLSOption, bool, error)
+	GetGatewayClasses() []*gatewayv1.GatewayClass
+	GetGateways() []*gatewayv1.Gateway
+	GetHTTPRoutes() []*gatewayv1.HTTPRoute
+	GetTLSRoutes() []*gatewayv1alpha2.TLSRoute
+	GetNamespace(name string) (*corev1.Namespace, bool, error)
+	UpdateGatewayClassStatus(class *gatewayv1.GatewayClass, status gatewayv1.GatewayClassStatus) error
+	UpdateGatewayStatus(gateway *gatewayv1.Gateway, status gatewayv1.GatewayStatus) error
+	UpdateHTTPRouteStatus(route *gatewayv1.HTTPRoute, status gatewayv1.HTTPRouteStatus) error
+	UpdateTLSRouteStatus(route *gatewayv1alpha2.TLSRoute, status gatewayv1alpha2.TLSRouteStatus) error
 }
 
 type clientImpl struct {
-	clientset            *kubernetes.Clientset
-	factories            map[string]informers.SharedInformerFactory
-	ingressLabelSelector labels.Selector
-	isNamespaceAll       bool
-	watchedNamespaces    Namespaces
+	clientset               *kubernetes.Clientset
+	crdClient               rest.Interface
+	discoveryV1beta1Client  rest.Interface
+	discoveryV1Client       rest.Interface
+	networkingV1beta1Client rest.Interface
+	networkingV1Client      rest.Interface
+	gatewayV1Client         rest.Interface
+	gatewayV1alpha2Client   rest.Interface
+	factories               map[string]informers.SharedInformerFactory
+	namespaceFactory        informers.SharedInformerFactory
+	crdInformers            map[string]*crdInformers
+	gatewayInformers        map[string]*gatewayInformers
+	gatewayClassInformer    cache.SharedIndexInformer
+	ingressInformers        map[string]cache.SharedIndexInformer
+	ingressClassInformer    cache.SharedIndexInformer
+	endpointSliceInformers  map[string]cache.SharedIndexInformer
+	ingressAPI              string
+	ingressClassAPI         string
+	endpointSliceAPI        string
+	ingressLabelSelector    labels.Selector
+	watchCRD                bool
+	watchGateway            bool
+	watchEndpointSlices     bool
+	watchNamespaces         bool
+	isNamespaceAll          bool
+	watchedNamespaces       Namespaces
 }
 
 func newClientImpl(clientset *kubernetes.Clientset) *clientImpl {
 	return &clientImpl{
-		clientset: clientset,
-		factories: make(map[string]informers.SharedInformerFactory),
+		clientset:              clientset,
+		factories:              make(map[string]informers.SharedInformerFactory),
+		crdInformers:           make(map[string]*crdInformers),
+		gatewayInformers:       make(map[string]*gatewayInformers),
+		ingressInformers:       make(map[string]cache.SharedIndexInformer),
+		endpointSliceInformers: make(map[string]cache.SharedIndexInformer),
+		ingressAPI:             ingressAPIExtensions,
 	}
 }
 
@@ -109,7 +158,55 @@ func createClientFromConfig(c *rest.Config) (*clientImpl, error) {
 		return nil, err
 	}
 
-	return newClientImpl(clientset), nil
+	client := newClientImpl(clientset)
+
+	client.crdClient, err = newRESTClient(c, v1alpha1.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	client.networkingV1beta1Client, err = newRESTClient(c, networkingv1beta1.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	client.networkingV1Client, err = newRESTClient(c, networkingv1.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	client.discoveryV1beta1Client, err = newRESTClient(c, discoveryv1beta1.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	client.discoveryV1Client, err = newRESTClient(c, discoveryv1.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	client.gatewayV1Client, err = newRESTClient(c, gatewayv1.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	client.gatewayV1alpha2Client, err = newRESTClient(c, gatewayv1alpha2.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// newRESTClient returns a REST client for the resources of the given group version which are not served by the clientset
+func newRESTClient(c *rest.Config, groupVersion schema.GroupVersion) (*rest.RESTClient, error) {
+	config := *c
+	config.GroupVersion = &groupVersion
+	config.APIPath = "/apis"
+	config.ContentType = runtime.ContentTypeJSON
+	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: scheme.Codecs}
+
+	return rest.RESTClientFor(&config)
 }
 
 // WatchAll starts namespace-specific controllers for all relevant kinds.
@@ -122,18 +219,40 @@ func (c *clientImpl) WatchAll(namespaces Namespaces, stopCh <-chan struct{}) (<-
 	}
 
 	c.watchedNamespaces = namespaces
+	c.detectIngressAPIs()
+	if c.watchEndpointSlices {
+		c.detectEndpointSliceAPI()
+	}
 
 	eventHandler := c.newResourceEventHandler(eventCh)
 	for _, ns := range namespaces {
 		factory := informers.NewFilteredSharedInformerFactory(c.clientset, resyncPeriod, ns, nil)
-		factory.Extensions().V1beta1().Ingresses().Informer().AddEventHandler(eventHandler)
+		if c.ingressAPI == ingressAPIExtensions {
+			factory.Extensions().V1beta1().Ingresses().Informer().AddEventHandler(eventHandler)
+		} else {
+			informer := c.newIngressInformer(ns)
+			informer.AddEventHandler(eventHandler)
+			c.ingressInformers[ns] = informer
+		}
 		factory.Core().V1().Services().Informer().AddEventHandler(eventHandler)
-		factory.Core().V1().Endpoints().Informer().AddEventHandler(eventHandler)
+		if c.endpointSliceAPI == "" {
+			factory.Core().V1().Endpoints().Informer().AddEventHandler(eventHandler)
+		} else {
+			informer := c.newEndpointSliceInformer(ns)
+			informer.AddEventHandler(eventHandler)
+			c.endpointSliceInformers[ns] = informer
+		}
 		c.factories[ns] = factory
 	}
 
 	for _, ns := range namespaces {
 		c.factories[ns].Start(stopCh)
+		if informer, ok := c.ingressInformers[ns]; ok {
+			go informer.Run(stopCh)
+		}
+		if informer, ok := c.endpointSliceInformers[ns]; ok {
+			go informer.Run(stopCh)
+		}
 	}
 
 	for _, ns := range namespaces {
@@ -142,6 +261,12 @@ func (c *clientImpl) WatchAll(namespaces Namespaces, stopCh <-chan struct{}) (<-
 				return nil, fmt.Errorf("timed out waiting for controller caches to sync %s in namespace %q", t.String(), ns)
 			}
 		}
+		if informer, ok := c.ingressInformers[ns]; ok && !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+			return nil, fmt.Errorf("timed out waiting for controller caches to sync %s ingresses in namespace %q", c.ingressAPI, ns)
+		}
+		if informer, ok := c.endpointSliceInformers[ns]; ok && !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+			return nil, fmt.Errorf("timed out waiting for controller caches to sync %s endpoint slices in namespace %q", c.endpointSliceAPI, ns)
+		}
 	}
 
 	// Do not wait for the Secrets store to get synced since we cannot rely on
@@ -153,30 +278,84 @@ func (c *clientImpl) WatchAll(namespaces Namespaces, stopCh <-chan struct{}) (<-
 		c.factories[ns].Start(stopCh)
 	}
 
+	// The IngressClasses are cluster-scoped, and not waited for either as older RBAC
+	// configurations do not grant access to them. Ingresses referencing a class are
+	// ignored until the class is known.
+	if c.ingressClassAPI != "" {
+		c.ingressClassInformer = c.newIngressClassInformer()
+		c.ingressClassInformer.AddEventHandler(eventHandler)
+		go c.ingressClassInformer.Run(stopCh)
+	}
+
+	// The namespaces are watched for their annotations, used as default annotations of their Ingresses,
+	// and for the listeners of the Gateways selecting the namespaces of their routes.
+	// They are only waited for when their annotations are used, as older RBAC configurations do not grant access to them.
+	if c.watchNamespaces || c.watchGateway {
+		c.namespaceFactory = informers.NewSharedInformerFactory(c.clientset, resyncPeriod)
+		c.namespaceFactory.Core().V1().Namespaces().Informer().AddEventHandler(eventHandler)
+		c.namespaceFactory.Start(stopCh)
+
+		if c.watchNamespaces && !cache.WaitForCacheSync(stopCh, c.namespaceFactory.Core().V1().Namespaces().Informer().HasSynced) {
+			return nil, fmt.Errorf("timed out waiting for controller caches to sync namespaces")
+		}
+	}
+
+	if c.watchCRD {
+		if err := c.watchCRDs(namespaces, eventHandler, stopCh); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.watchGateway {
+		if err := c.watchGateways(namespaces, eventHandler, stopCh); err != nil {
+			return nil, err
+		}
+	}
+
 	return eventCh, nil
 }
 
-// GetIngresses returns all Ingresses for observed namespaces in the cluster.
-func (c *clientImpl) GetIngresses() []*extensionsv1beta1.Ingress {
-	var result []*extensionsv1beta1.Ingress
+// GetIngresses returns all Ingresses for observed namespaces in the cluster,
+// whatever the API they are read from.
+func (c *clientImpl) GetIngresses() []*Ingress {
+	if c.ingressAPI != ingressAPIExtensions {
+		return c.getNetworkingIngresses()
+	}
+
+	var result []*Ingress
 	for ns, factory := range c.factories {
 		ings, err := factory.Extensions().V1beta1().Ingresses().Lister().List(c.ingressLabelSelector)
 		if err != nil {
-			log.Errorf("Failed to list ingresses in namespace %s: %s", ns, err)
+			logger.Errorf("Failed to list ingresses in namespace %s: %s", ns, err)
 		}
 		for _, ing := range ings {
-			result = append(result, ing)
+			result = append(result, newIngressFromExtensions(ing))
 		}
 	}
 	return result
 }
 
+// GetNamespace returns the named namespace.
+func (c *clientImpl) GetNamespace(name string) (*corev1.Namespace, bool, error) {
+	if c.namespaceFactory == nil {
+		return nil, false, fmt.Errorf("failed to get namespace %s: namespaces are not watched", name)
+	}
+
+	namespace, err := c.namespaceFactory.Core().V1().Namespaces().Lister().Get(name)
+	exist, err := translateNotFoundError(err)
+	return namespace, exist, err
+}
+
 // UpdateIngressStatus updates an Ingress with a provided status.
 func (c *clientImpl) UpdateIngressStatus(namespace, name, ip, hostname string) error {
 	if !c.isWatchedNamespace(namespace) {
 		return fmt.Errorf("failed to get ingress %s/%s: namespace is not within watched namespaces", namespace, name)
 	}
 
+	if c.ingressAPI != ingressAPIExtensions {
+		return c.updateNetworkingIngressStatus(namespace, name, ip, hostname)
+	}
+
 	ing, err := c.factories[c.lookupNamespace(namespace)].Extensions().V1beta1().Ingresses().Lister().Ingresses(namespace).Get(name)
 	if err != nil {
 		return fmt.Errorf("failed to get ingress %s/%s: %v", namespace, name, err)
@@ -185,7 +364,7 @@ func (c *clientImpl) UpdateIngressStatus(namespace, name, ip, hostname string) e
 	if len(ing.Status.LoadBalancer.Ingress) > 0 {
 		if ing.Status.LoadBalancer.Ingress[0].Hostname == hostname && ing.Status.LoadBalancer.Ingress[0].IP == ip {
 			// If status is already set, skip update
-			log.Debugf("Skipping status update on ingress %s/%s", ing.Namespace, ing.Name)
+			logger.Debugf("Skipping status update on ingress %s/%s", ing.Namespace, ing.Name)
 			return nil
 		}
 	}
@@ -196,7 +375,7 @@ func (c *clientImpl) UpdateIngressStatus(namespace, name, ip, hostname string) e
 	if err != nil {
 		return fmt.Errorf("failed to update ingress status %s/%s: %v", namespace, name, err)
 	}
-	log.Infof("Updated status on ingress %s/%s", namespace, name)
+	logger.Infof("Updated status on ingress %s/%s", namespace, name)
 	return nil
 }
 
@@ -212,11 +391,16 @@ func (c *clientImpl) GetService(namespace, name string) (*corev1.Service, bool,
 }
 
 // GetEndpoints returns the named endpoints from the given namespace.
+// When the EndpointSlices are watched, the endpoints are built from the slices of the named service.
 func (c *clientImpl) GetEndpoints(namespace, name string) (*corev1.Endpoints, bool, error) {
 	if !c.isWatchedNamespace(namespace) {
 		return nil, false, fmt.Errorf("failed to get endpoints %s/%s: namespace is not within watched namespaces", namespace, name)
 	}
 
+	if c.endpointSliceAPI != "" {
+		return c.getEndpointsFromSlices(namespace, name)
+	}
+
 	endpoint, err := c.factories[c.lookupNamespace(namespace)].Core().V1().Endpoints().Lister().Endpoints(namespace).Get(name)
 	exist, err := translateNotFoundError(err)
 	return endpoint, exist, err
@@ -249,10 +433,20 @@ func (c *clientImpl) lookupNamespace(ns string) string {
 func (c *clientImpl) newResourceEventHandler(events chan<- interface{}) cache.ResourceEventHandler {
 	return &cache.FilteringResourceEventHandler{
 		FilterFunc: func(obj interface{}) bool {
-			// Ignore Ingresses that do not match our custom label selector.
-			if ing, ok := obj.(*extensionsv1beta1.Ingress); ok {
-				lbls := labels.Set(ing.GetLabels())
-				return c.ingressLabelSelector.Matches(lbls)
+			// Ignore Ingresses and routes that do not match our custom label selector.
+			switch o := obj.(type) {
+			case *extensionsv1beta1.Ingress:
+				return c.ingressLabelSelector.Matches(labels.Set(o.GetLabels()))
+			case *networkingv1beta1.Ingress:
+				return c.ingressLabelSelector.Matches(labels.Set(o.GetLabels()))
+			case *networkingv1.Ingress:
+				return c.ingressLabelSelector.Matches(labels.Set(o.GetLabels()))
+			case *v1alpha1.IngressRoute:
+				return c.ingressLabelSelector.Matches(labels.Set(o.GetLabels()))
+			case *gatewayv1.HTTPRoute:
+				return c.ingressLabelSelector.Matches(labels.Set(o.GetLabels()))
+			case *gatewayv1alpha2.TLSRoute:
+				return c.ingressLabelSelector.Matches(labels.Set(o.GetLabels()))
 			}
 			return true
 		},
@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/containous/traefik/provider/kubernetes/crd/traefik/v1alpha1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	resourceIngressRoutes = "ingressroutes"
+	resourceMiddlewares   = "middlewares"
+	resourceTLSOptions    = "tlsoptions"
+)
+
+func init() {
+	// The custom resources are decoded with the codecs of the Kubernetes resources.
+	if err := v1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+}
+
+// crdInformers holds the informers of the custom resources of a namespace
+type crdInformers struct {
+	ingressRoutes cache.SharedIndexInformer
+	middlewares   cache.SharedIndexInformer
+	tlsOptions    cache.SharedIndexInformer
+}
+
+func (c *clientImpl) newCRDInformer(namespace, resource string, objType runtime.Object) cache.SharedIndexInformer {
+	listWatch := cache.NewListWatchFromClient(c.crdClient, resource, namespace, fields.Everything())
+	return cache.NewSharedIndexInformer(listWatch, objType, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
+
+// watchCRDs starts the namespace-specific informers of the custom resources.
+// The custom resource definitions must be installed in the cluster, otherwise the caches never get synced.
+func (c *clientImpl) watchCRDs(namespaces Namespaces, eventHandler cache.ResourceEventHandler, stopCh <-chan struct{}) error {
+	for _, ns := range namespaces {
+		informers := &crdInformers{
+			ingressRoutes: c.newCRDInformer(ns, resourceIngressRoutes, &v1alpha1.IngressRoute{}),
+			middlewares:   c.newCRDInformer(ns, resourceMiddlewares, &v1alpha1.Middleware{}),
+			tlsOptions:    c.newCRDInformer(ns, resourceTLSOptions, &v1alpha1.TLSOption{}),
+		}
+
+		for _, informer := range []cache.SharedIndexInformer{informers.ingressRoutes, informers.middlewares, informers.tlsOptions} {
+			informer.AddEventHandler(eventHandler)
+			go informer.Run(stopCh)
+		}
+
+		c.crdInformers[ns] = informers
+	}
+
+	for _, ns := range namespaces {
+		informers := c.crdInformers[ns]
+		if !cache.WaitForCacheSync(stopCh, informers.ingressRoutes.HasSynced, informers.middlewares.HasSynced, informers.tlsOptions.HasSynced) {
+			return fmt.Errorf("timed out waiting for controller caches to sync custom resources in namespace %q", ns)
+		}
+	}
+
+	return nil
+}
+
+// GetIngressRoutes returns all IngressRoutes for observed namespaces in the cluster.
+func (c *clientImpl) GetIngressRoutes() []*v1alpha1.IngressRoute {
+	var result []*v1alpha1.IngressRoute
+	for _, informers := range c.crdInformers {
+		for _, obj := range informers.ingressRoutes.GetStore().List() {
+			ingressRoute, ok := obj.(*v1alpha1.IngressRoute)
+			if !ok {
+				logger.Errorf("Unexpected object %T in the IngressRoutes store", obj)
+				continue
+			}
+			if c.ingressLabelSelector != nil && !c.ingressLabelSelector.Matches(labels.Set(ingressRoute.GetLabels())) {
+				continue
+			}
+			result = append(result, ingressRoute)
+		}
+	}
+	return result
+}
+
+// GetMiddleware returns the named Middleware from the given namespace.
+func (c *clientImpl) GetMiddleware(namespace, name string) (*v1alpha1.Middleware, bool, error) {
+	obj, exists, err := c.getCustomResource(namespace, name, resourceMiddlewares)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+
+	middleware, ok := obj.(*v1alpha1.Middleware)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected object %T for middleware %s/%s", obj, namespace, name)
+	}
+	return middleware, true, nil
+}
+
+// GetTLSOption returns the named TLSOption from the given namespace.
+func (c *clientImpl) GetTLSOption(namespace, name string) (*v1alpha1.TLSOption, bool, error) {
+	obj, exists, err := c.getCustomResource(namespace, name, resourceTLSOptions)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+
+	tlsOption, ok := obj.(*v1alpha1.TLSOption)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected object %T for TLS option %s/%s", obj, namespace, name)
+	}
+	return tlsOption, true, nil
+}
+
+func (c *clientImpl) getCustomResource(namespace, name, resource string) (interface{}, bool, error) {
+	if !c.isWatchedNamespace(namespace) {
+		return nil, false, fmt.Errorf("failed to get %s %s/%s: namespace is not within watched namespaces", resource, namespace, name)
+	}
+
+	informers, ok := c.crdInformers[c.lookupNamespace(namespace)]
+	if !ok {
+		return nil, false, fmt.Errorf("failed to get %s %s/%s: custom resources are not watched", resource, namespace, name)
+	}
+
+	var store cache.Store
+	switch resource {
+	case resourceMiddlewares:
+		store = informers.middlewares.GetStore()
+	case resourceTLSOptions:
+		store = informers.tlsOptions.GetStore()
+	default:
+		store = informers.ingressRoutes.GetStore()
+	}
+
+	return store.GetByKey(namespace + "/" + name)
+}
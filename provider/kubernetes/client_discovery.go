@@ -0,0 +1,123 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	discoveryv1 "github.com/containous/traefik/provider/kubernetes/discovery/v1"
+	discoveryv1beta1 "github.com/containous/traefik/provider/kubernetes/discovery/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	resourceEndpointSlices = "endpointslices"
+
+	endpointSliceAPIDiscoveryV1beta1 = "discovery.k8s.io/v1beta1"
+	endpointSliceAPIDiscoveryV1      = "discovery.k8s.io/v1"
+
+	// endpointSliceServiceIndex indexes the EndpointSlices by the namespace/name of their Service
+	endpointSliceServiceIndex = "service"
+)
+
+func init() {
+	// The discovery resources missing from the vendored client are decoded with the codecs of the Kubernetes resources.
+	if err := discoveryv1beta1.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+	if err := discoveryv1.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+}
+
+// detectEndpointSliceAPI selects the most recent API serving the EndpointSlices.
+// The EndpointSlices are not served before Kubernetes 1.17, in which case the API is left empty.
+func (c *clientImpl) detectEndpointSliceAPI() {
+	c.endpointSliceAPI = ""
+
+	for _, groupVersion := range []string{endpointSliceAPIDiscoveryV1, endpointSliceAPIDiscoveryV1beta1} {
+		resources, err := c.clientset.Discovery().ServerResourcesForGroupVersion(groupVersion)
+		if err != nil {
+			logger.Debugf("Unable to discover the %s resources: %v", groupVersion, err)
+			continue
+		}
+
+		for _, resource := range resources.APIResources {
+			if resource.Name == resourceEndpointSlices {
+				c.endpointSliceAPI = groupVersion
+				logger.Infof("Reading the EndpointSlices from the %s API", c.endpointSliceAPI)
+				return
+			}
+		}
+	}
+
+	logger.Warn("The EndpointSlices are not served by the cluster, reading the Endpoints instead")
+}
+
+func (c *clientImpl) discoveryClient(api string) rest.Interface {
+	if api == endpointSliceAPIDiscoveryV1 {
+		return c.discoveryV1Client
+	}
+	return c.discoveryV1beta1Client
+}
+
+// newEndpointSliceInformer returns an informer of the EndpointSlices of a namespace, indexed by Service
+func (c *clientImpl) newEndpointSliceInformer(namespace string) cache.SharedIndexInformer {
+	var objType runtime.Object = &discoveryv1beta1.EndpointSlice{}
+	if c.endpointSliceAPI == endpointSliceAPIDiscoveryV1 {
+		objType = &discoveryv1.EndpointSlice{}
+	}
+
+	listWatch := cache.NewListWatchFromClient(c.discoveryClient(c.endpointSliceAPI), resourceEndpointSlices, namespace, fields.Everything())
+	return cache.NewSharedIndexInformer(listWatch, objType, resyncPeriod, cache.Indexers{
+		cache.NamespaceIndex:      cache.MetaNamespaceIndexFunc,
+		endpointSliceServiceIndex: endpointSliceServiceIndexFunc,
+	})
+}
+
+func endpointSliceServiceIndexFunc(obj interface{}) ([]string, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName, ok := accessor.GetLabels()[discoveryv1.LabelServiceName]
+	if !ok {
+		return nil, nil
+	}
+	return []string{accessor.GetNamespace() + "/" + serviceName}, nil
+}
+
+// getEndpointsFromSlices returns the EndpointSlices of the named Service, merged into Endpoints
+func (c *clientImpl) getEndpointsFromSlices(namespace, name string) (*corev1.Endpoints, bool, error) {
+	informer, ok := c.endpointSliceInformers[c.lookupNamespace(namespace)]
+	if !ok {
+		return nil, false, fmt.Errorf("failed to get endpoint slices %s/%s: endpoint slices are not watched", namespace, name)
+	}
+
+	objs, err := informer.GetIndexer().ByIndex(endpointSliceServiceIndex, namespace+"/"+name)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get endpoint slices %s/%s: %v", namespace, name, err)
+	}
+	if len(objs) == 0 {
+		return nil, false, nil
+	}
+
+	var slices []*discoveryv1.EndpointSlice
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *discoveryv1.EndpointSlice:
+			slices = append(slices, o)
+		case *discoveryv1beta1.EndpointSlice:
+			slices = append(slices, newEndpointSliceFromDiscoveryV1beta1(o))
+		default:
+			logger.Errorf("Unexpected object %T in the EndpointSlices store", obj)
+		}
+	}
+
+	return newEndpointsFromSlices(namespace, name, slices), true, nil
+}
@@ -0,0 +1,222 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	gatewayv1 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1"
+	gatewayv1alpha2 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	resourceGatewayClasses = "gatewayclasses"
+	resourceGateways       = "gateways"
+	resourceHTTPRoutes     = "httproutes"
+	resourceTLSRoutes      = "tlsroutes"
+)
+
+func init() {
+	// The Gateway API resources are decoded with the codecs of the Kubernetes resources.
+	if err := gatewayv1.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+	if err := gatewayv1alpha2.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+}
+
+// gatewayInformers holds the informers of the Gateway API resources of a namespace
+type gatewayInformers struct {
+	gateways   cache.SharedIndexInformer
+	httpRoutes cache.SharedIndexInformer
+	tlsRoutes  cache.SharedIndexInformer
+}
+
+func newGatewayInformer(client rest.Interface, namespace, resource string, objType runtime.Object) cache.SharedIndexInformer {
+	listWatch := cache.NewListWatchFromClient(client, resource, namespace, fields.Everything())
+	return cache.NewSharedIndexInformer(listWatch, objType, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
+
+// watchGateways starts the informers of the Gateway API resources.
+// The TLSRoutes are only watched when their experimental definition is installed in the cluster.
+// The other definitions must be installed in the cluster, otherwise the caches never get synced.
+func (c *clientImpl) watchGateways(namespaces Namespaces, eventHandler cache.ResourceEventHandler, stopCh <-chan struct{}) error {
+	watchTLSRoutes := c.isServed(gatewayv1alpha2.SchemeGroupVersion.String(), resourceTLSRoutes)
+	if !watchTLSRoutes {
+		logger.Infof("The %s TLSRoutes are not served by the cluster, they are ignored", gatewayv1alpha2.SchemeGroupVersion)
+	}
+
+	c.gatewayClassInformer = newGatewayInformer(c.gatewayV1Client, metav1.NamespaceAll, resourceGatewayClasses, &gatewayv1.GatewayClass{})
+	c.gatewayClassInformer.AddEventHandler(eventHandler)
+	go c.gatewayClassInformer.Run(stopCh)
+
+	synced := []cache.InformerSynced{c.gatewayClassInformer.HasSynced}
+	for _, ns := range namespaces {
+		nsInformers := &gatewayInformers{
+			gateways:   newGatewayInformer(c.gatewayV1Client, ns, resourceGateways, &gatewayv1.Gateway{}),
+			httpRoutes: newGatewayInformer(c.gatewayV1Client, ns, resourceHTTPRoutes, &gatewayv1.HTTPRoute{}),
+		}
+		if watchTLSRoutes {
+			nsInformers.tlsRoutes = newGatewayInformer(c.gatewayV1alpha2Client, ns, resourceTLSRoutes, &gatewayv1alpha2.TLSRoute{})
+		}
+
+		for _, informer := range []cache.SharedIndexInformer{nsInformers.gateways, nsInformers.httpRoutes, nsInformers.tlsRoutes} {
+			if informer == nil {
+				continue
+			}
+			informer.AddEventHandler(eventHandler)
+			go informer.Run(stopCh)
+			synced = append(synced, informer.HasSynced)
+		}
+
+		c.gatewayInformers[ns] = nsInformers
+	}
+
+	if !cache.WaitForCacheSync(stopCh, synced...) {
+		return fmt.Errorf("timed out waiting for controller caches to sync the Gateway API resources")
+	}
+
+	return nil
+}
+
+// isServed tells whether the cluster serves a resource in the given group version
+func (c *clientImpl) isServed(groupVersion, resource string) bool {
+	resources, err := c.clientset.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		logger.Debugf("Unable to discover the %s resources: %v", groupVersion, err)
+		return false
+	}
+
+	for _, r := range resources.APIResources {
+		if r.Name == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGatewayClasses returns the GatewayClasses of the cluster.
+func (c *clientImpl) GetGatewayClasses() []*gatewayv1.GatewayClass {
+	if c.gatewayClassInformer == nil {
+		return nil
+	}
+
+	var result []*gatewayv1.GatewayClass
+	for _, obj := range c.gatewayClassInformer.GetStore().List() {
+		class, ok := obj.(*gatewayv1.GatewayClass)
+		if !ok {
+			logger.Errorf("Unexpected object %T in the GatewayClasses store", obj)
+			continue
+		}
+		result = append(result, class)
+	}
+	return result
+}
+
+// GetGateways returns all Gateways for observed namespaces in the cluster.
+func (c *clientImpl) GetGateways() []*gatewayv1.Gateway {
+	var result []*gatewayv1.Gateway
+	for _, informers := range c.gatewayInformers {
+		for _, obj := range informers.gateways.GetStore().List() {
+			gateway, ok := obj.(*gatewayv1.Gateway)
+			if !ok {
+				logger.Errorf("Unexpected object %T in the Gateways store", obj)
+				continue
+			}
+			result = append(result, gateway)
+		}
+	}
+	return result
+}
+
+// GetHTTPRoutes returns all HTTPRoutes for observed namespaces in the cluster.
+func (c *clientImpl) GetHTTPRoutes() []*gatewayv1.HTTPRoute {
+	var result []*gatewayv1.HTTPRoute
+	for _, informers := range c.gatewayInformers {
+		for _, obj := range informers.httpRoutes.GetStore().List() {
+			route, ok := obj.(*gatewayv1.HTTPRoute)
+			if !ok {
+				logger.Errorf("Unexpected object %T in the HTTPRoutes store", obj)
+				continue
+			}
+			if c.ingressLabelSelector != nil && !c.ingressLabelSelector.Matches(labels.Set(route.GetLabels())) {
+				continue
+			}
+			result = append(result, route)
+		}
+	}
+	return result
+}
+
+// GetTLSRoutes returns all TLSRoutes for observed namespaces in the cluster.
+func (c *clientImpl) GetTLSRoutes() []*gatewayv1alpha2.TLSRoute {
+	var result []*gatewayv1alpha2.TLSRoute
+	for _, informers := range c.gatewayInformers {
+		if informers.tlsRoutes == nil {
+			continue
+		}
+		for _, obj := range informers.tlsRoutes.GetStore().List() {
+			route, ok := obj.(*gatewayv1alpha2.TLSRoute)
+			if !ok {
+				logger.Errorf("Unexpected object %T in the TLSRoutes store", obj)
+				continue
+			}
+			if c.ingressLabelSelector != nil && !c.ingressLabelSelector.Matches(labels.Set(route.GetLabels())) {
+				continue
+			}
+			result = append(result, route)
+		}
+	}
+	return result
+}
+
+// UpdateGatewayClassStatus updates the status of a GatewayClass.
+func (c *clientImpl) UpdateGatewayClassStatus(class *gatewayv1.GatewayClass, status gatewayv1.GatewayClassStatus) error {
+	classCopy := class.DeepCopy()
+	classCopy.Status = status
+	return c.updateGatewayStatus(c.gatewayV1Client, "", resourceGatewayClasses, class.Name, classCopy)
+}
+
+// UpdateGatewayStatus updates the status of a Gateway.
+func (c *clientImpl) UpdateGatewayStatus(gateway *gatewayv1.Gateway, status gatewayv1.GatewayStatus) error {
+	gatewayCopy := gateway.DeepCopy()
+	gatewayCopy.Status = status
+	return c.updateGatewayStatus(c.gatewayV1Client, gateway.Namespace, resourceGateways, gateway.Name, gatewayCopy)
+}
+
+// UpdateHTTPRouteStatus updates the status of an HTTPRoute.
+func (c *clientImpl) UpdateHTTPRouteStatus(route *gatewayv1.HTTPRoute, status gatewayv1.HTTPRouteStatus) error {
+	routeCopy := route.DeepCopy()
+	routeCopy.Status = status
+	return c.updateGatewayStatus(c.gatewayV1Client, route.Namespace, resourceHTTPRoutes, route.Name, routeCopy)
+}
+
+// UpdateTLSRouteStatus updates the status of a TLSRoute.
+func (c *clientImpl) UpdateTLSRouteStatus(route *gatewayv1alpha2.TLSRoute, status gatewayv1alpha2.TLSRouteStatus) error {
+	routeCopy := route.DeepCopy()
+	routeCopy.Status = status
+	return c.updateGatewayStatus(c.gatewayV1alpha2Client, route.Namespace, resourceTLSRoutes, route.Name, routeCopy)
+}
+
+func (c *clientImpl) updateGatewayStatus(client rest.Interface, namespace, resource, name string, obj runtime.Object) error {
+	err := client.Put().
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		SubResource("status").
+		Body(obj).
+		Do().
+		Error()
+	if err != nil {
+		return fmt.Errorf("failed to update the status of %s %s/%s: %v", resource, namespace, name, err)
+	}
+
+	logger.Debugf("Updated the status of %s %s/%s", resource, namespace, name)
+	return nil
+}
@@ -4,18 +4,36 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	gatewayv1 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1"
+	gatewayv1alpha2 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1alpha2"
+	"github.com/containous/traefik/provider/kubernetes/crd/traefik/v1alpha1"
+	networkingv1 "github.com/containous/traefik/provider/kubernetes/networking/v1"
+	networkingv1beta1 "github.com/containous/traefik/provider/kubernetes/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
-	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	v1beta12 "k8s.io/api/extensions/v1beta1"
 )
 
 var _ Client = (*clientMock)(nil)
 
 type clientMock struct {
-	ingresses []*extensionsv1beta1.Ingress
-	services  []*corev1.Service
-	secrets   []*corev1.Secret
-	endpoints []*corev1.Endpoints
+	ingresses      []*Ingress
+	ingressClasses []*networkingv1.IngressClass
+	services       []*corev1.Service
+	secrets        []*corev1.Secret
+	endpoints      []*corev1.Endpoints
+
+	ingressRoutes []*v1alpha1.IngressRoute
+	middlewares   []*v1alpha1.Middleware
+	tlsOptions    []*v1alpha1.TLSOption
+
+	namespaces     []*corev1.Namespace
+	gatewayClasses []*gatewayv1.GatewayClass
+	gateways       []*gatewayv1.Gateway
+	httpRoutes     []*gatewayv1.HTTPRoute
+	tlsRoutes      []*gatewayv1alpha2.TLSRoute
+
+	// statuses records the statuses written to the Gateway API resources, indexed by kind, namespace and name
+	statuses map[string]interface{}
 
 	apiServiceError       error
 	apiSecretError        error
@@ -26,7 +44,7 @@ type clientMock struct {
 }
 
 func newClientMock(paths ...string) clientMock {
-	var c clientMock
+	c := clientMock{statuses: make(map[string]interface{})}
 
 	for _, path := range paths {
 		yamlContent, err := ioutil.ReadFile(path)
@@ -44,7 +62,31 @@ func newClientMock(paths ...string) clientMock {
 			case *corev1.Endpoints:
 				c.endpoints = append(c.endpoints, o)
 			case *v1beta12.Ingress:
-				c.ingresses = append(c.ingresses, o)
+				c.ingresses = append(c.ingresses, newIngressFromExtensions(o))
+			case *networkingv1beta1.Ingress:
+				c.ingresses = append(c.ingresses, newIngressFromNetworkingV1beta1(o))
+			case *networkingv1.Ingress:
+				c.ingresses = append(c.ingresses, newIngressFromNetworkingV1(o))
+			case *networkingv1beta1.IngressClass:
+				c.ingressClasses = append(c.ingressClasses, newIngressClassFromNetworkingV1beta1(o))
+			case *networkingv1.IngressClass:
+				c.ingressClasses = append(c.ingressClasses, o)
+			case *v1alpha1.IngressRoute:
+				c.ingressRoutes = append(c.ingressRoutes, o)
+			case *v1alpha1.Middleware:
+				c.middlewares = append(c.middlewares, o)
+			case *v1alpha1.TLSOption:
+				c.tlsOptions = append(c.tlsOptions, o)
+			case *corev1.Namespace:
+				c.namespaces = append(c.namespaces, o)
+			case *gatewayv1.GatewayClass:
+				c.gatewayClasses = append(c.gatewayClasses, o)
+			case *gatewayv1.Gateway:
+				c.gateways = append(c.gateways, o)
+			case *gatewayv1.HTTPRoute:
+				c.httpRoutes = append(c.httpRoutes, o)
+			case *gatewayv1alpha2.TLSRoute:
+				c.tlsRoutes = append(c.tlsRoutes, o)
 			default:
 				panic(fmt.Sprintf("Unknown runtime object %+v %T", o, o))
 			}
@@ -54,10 +96,14 @@ func newClientMock(paths ...string) clientMock {
 	return c
 }
 
-func (c clientMock) GetIngresses() []*extensionsv1beta1.Ingress {
+func (c clientMock) GetIngresses() []*Ingress {
 	return c.ingresses
 }
 
+func (c clientMock) GetIngressClasses() []*networkingv1.IngressClass {
+	return c.ingressClasses
+}
+
 func (c clientMock) GetService(namespace, name string) (*corev1.Service, bool, error) {
 	if c.apiServiceError != nil {
 		return nil, false, c.apiServiceError
@@ -105,3 +151,76 @@ func (c clientMock) WatchAll(namespaces Namespaces, stopCh <-chan struct{}) (<-c
 func (c clientMock) UpdateIngressStatus(namespace, name, ip, hostname string) error {
 	return c.apiIngressStatusError
 }
+
+func (c clientMock) GetIngressRoutes() []*v1alpha1.IngressRoute {
+	return c.ingressRoutes
+}
+
+func (c clientMock) GetMiddleware(namespace, name string) (*v1alpha1.Middleware, bool, error) {
+	for _, middleware := range c.middlewares {
+		if middleware.Namespace == namespace && middleware.Name == name {
+			return middleware, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (c clientMock) GetTLSOption(namespace, name string) (*v1alpha1.TLSOption, bool, error) {
+	for _, tlsOption := range c.tlsOptions {
+		if tlsOption.Namespace == namespace && tlsOption.Name == name {
+			return tlsOption, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (c clientMock) GetGatewayClasses() []*gatewayv1.GatewayClass {
+	return c.gatewayClasses
+}
+
+func (c clientMock) GetGateways() []*gatewayv1.Gateway {
+	return c.gateways
+}
+
+func (c clientMock) GetHTTPRoutes() []*gatewayv1.HTTPRoute {
+	return c.httpRoutes
+}
+
+func (c clientMock) GetTLSRoutes() []*gatewayv1alpha2.TLSRoute {
+	return c.tlsRoutes
+}
+
+func (c clientMock) GetNamespace(name string) (*corev1.Namespace, bool, error) {
+	for _, namespace := range c.namespaces {
+		if namespace.Name == name {
+			return namespace, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (c clientMock) UpdateGatewayClassStatus(class *gatewayv1.GatewayClass, status gatewayv1.GatewayClassStatus) error {
+	c.recordStatus("GatewayClass", "", class.Name, status)
+	return nil
+}
+
+func (c clientMock) UpdateGatewayStatus(gateway *gatewayv1.Gateway, status gatewayv1.GatewayStatus) error {
+	c.recordStatus("Gateway", gateway.Namespace, gateway.Name, status)
+	return nil
+}
+
+func (c clientMock) UpdateHTTPRouteStatus(route *gatewayv1.HTTPRoute, status gatewayv1.HTTPRouteStatus) error {
+	c.recordStatus("HTTPRoute", route.Namespace, route.Name, status)
+	return nil
+}
+
+func (c clientMock) UpdateTLSRouteStatus(route *gatewayv1alpha2.TLSRoute, status gatewayv1alpha2.TLSRouteStatus) error {
+	c.recordStatus("TLSRoute", route.Namespace, route.Name, status)
+	return nil
+}
+
+func (c clientMock) recordStatus(kind, namespace, name string, status interface{}) {
+	if c.statuses != nil {
+		c.statuses[kind+"/"+namespace+"/"+name] = status
+	}
+}
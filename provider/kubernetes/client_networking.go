@@ -0,0 +1,201 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	networkingv1 "github.com/containous/traefik/provider/kubernetes/networking/v1"
+	networkingv1beta1 "github.com/containous/traefik/provider/kubernetes/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	resourceIngresses      = "ingresses"
+	resourceIngressClasses = "ingressclasses"
+
+	ingressAPIExtensions        = "extensions/v1beta1"
+	ingressAPINetworkingV1beta1 = "networking.k8s.io/v1beta1"
+	ingressAPINetworkingV1      = "networking.k8s.io/v1"
+)
+
+func init() {
+	// The networking resources missing from the vendored client are decoded with the codecs of the Kubernetes resources.
+	if err := networkingv1beta1.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+	if err := networkingv1.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+}
+
+// detectIngressAPIs selects the most recent APIs serving the Ingresses and the IngressClasses.
+// The IngressClasses are not served before Kubernetes 1.18, in which case the API is left empty.
+func (c *clientImpl) detectIngressAPIs() {
+	c.ingressAPI = ingressAPIExtensions
+	c.ingressClassAPI = ""
+
+	for _, groupVersion := range []string{ingressAPINetworkingV1, ingressAPINetworkingV1beta1} {
+		resources, err := c.clientset.Discovery().ServerResourcesForGroupVersion(groupVersion)
+		if err != nil {
+			logger.Debugf("Unable to discover the %s resources: %v", groupVersion, err)
+			continue
+		}
+
+		var hasIngresses, hasIngressClasses bool
+		for _, resource := range resources.APIResources {
+			switch resource.Name {
+			case resourceIngresses:
+				hasIngresses = true
+			case resourceIngressClasses:
+				hasIngressClasses = true
+			}
+		}
+
+		if hasIngresses && c.ingressAPI == ingressAPIExtensions {
+			c.ingressAPI = groupVersion
+		}
+		if hasIngressClasses && c.ingressClassAPI == "" {
+			c.ingressClassAPI = groupVersion
+		}
+	}
+
+	logger.Infof("Reading the Ingresses from the %s API", c.ingressAPI)
+	if c.ingressClassAPI != "" {
+		logger.Infof("Reading the IngressClasses from the %s API", c.ingressClassAPI)
+	}
+}
+
+func (c *clientImpl) networkingClient(api string) rest.Interface {
+	if api == ingressAPINetworkingV1 {
+		return c.networkingV1Client
+	}
+	return c.networkingV1beta1Client
+}
+
+// newIngressInformer returns an informer of the networking Ingresses of a namespace
+func (c *clientImpl) newIngressInformer(namespace string) cache.SharedIndexInformer {
+	var objType runtime.Object = &networkingv1beta1.Ingress{}
+	if c.ingressAPI == ingressAPINetworkingV1 {
+		objType = &networkingv1.Ingress{}
+	}
+
+	listWatch := cache.NewListWatchFromClient(c.networkingClient(c.ingressAPI), resourceIngresses, namespace, fields.Everything())
+	return cache.NewSharedIndexInformer(listWatch, objType, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
+
+// newIngressClassInformer returns an informer of the cluster-scoped IngressClasses
+func (c *clientImpl) newIngressClassInformer() cache.SharedIndexInformer {
+	var objType runtime.Object = &networkingv1beta1.IngressClass{}
+	if c.ingressClassAPI == ingressAPINetworkingV1 {
+		objType = &networkingv1.IngressClass{}
+	}
+
+	listWatch := cache.NewListWatchFromClient(c.networkingClient(c.ingressClassAPI), resourceIngressClasses, metav1.NamespaceAll, fields.Everything())
+	return cache.NewSharedIndexInformer(listWatch, objType, resyncPeriod, cache.Indexers{})
+}
+
+// getNetworkingIngresses returns the networking Ingresses of the observed namespaces, converted to Ingresses
+func (c *clientImpl) getNetworkingIngresses() []*Ingress {
+	var result []*Ingress
+	for _, informer := range c.ingressInformers {
+		for _, obj := range informer.GetStore().List() {
+			var ingress *Ingress
+			switch o := obj.(type) {
+			case *networkingv1.Ingress:
+				ingress = newIngressFromNetworkingV1(o)
+			case *networkingv1beta1.Ingress:
+				ingress = newIngressFromNetworkingV1beta1(o)
+			default:
+				logger.Errorf("Unexpected object %T in the Ingresses store", obj)
+				continue
+			}
+
+			if c.ingressLabelSelector != nil && !c.ingressLabelSelector.Matches(labels.Set(ingress.GetLabels())) {
+				continue
+			}
+			result = append(result, ingress)
+		}
+	}
+	return result
+}
+
+// GetIngressClasses returns the IngressClasses of the cluster, converted to networking.k8s.io/v1.
+func (c *clientImpl) GetIngressClasses() []*networkingv1.IngressClass {
+	if c.ingressClassInformer == nil {
+		return nil
+	}
+
+	var result []*networkingv1.IngressClass
+	for _, obj := range c.ingressClassInformer.GetStore().List() {
+		switch o := obj.(type) {
+		case *networkingv1.IngressClass:
+			result = append(result, o)
+		case *networkingv1beta1.IngressClass:
+			result = append(result, newIngressClassFromNetworkingV1beta1(o))
+		default:
+			logger.Errorf("Unexpected object %T in the IngressClasses store", obj)
+		}
+	}
+	return result
+}
+
+// updateNetworkingIngressStatus updates the status of a networking Ingress
+func (c *clientImpl) updateNetworkingIngressStatus(namespace, name, ip, hostname string) error {
+	informer, ok := c.ingressInformers[c.lookupNamespace(namespace)]
+	if !ok {
+		return fmt.Errorf("failed to get ingress %s/%s: ingresses are not watched", namespace, name)
+	}
+
+	obj, exists, err := informer.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil {
+		return fmt.Errorf("failed to get ingress %s/%s: %v", namespace, name, err)
+	}
+	if !exists {
+		return fmt.Errorf("failed to get ingress %s/%s: not found", namespace, name)
+	}
+
+	status := corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: ip, Hostname: hostname}}}
+
+	var ingCopy runtime.Object
+	var current corev1.LoadBalancerStatus
+	switch o := obj.(type) {
+	case *networkingv1.Ingress:
+		current = o.Status.LoadBalancer
+		ing := o.DeepCopy()
+		ing.Status.LoadBalancer = status
+		ingCopy = ing
+	case *networkingv1beta1.Ingress:
+		current = o.Status.LoadBalancer
+		ing := o.DeepCopy()
+		ing.Status.LoadBalancer = status
+		ingCopy = ing
+	default:
+		return fmt.Errorf("unexpected object %T for ingress %s/%s", obj, namespace, name)
+	}
+
+	if len(current.Ingress) > 0 && current.Ingress[0].Hostname == hostname && current.Ingress[0].IP == ip {
+		// If status is already set, skip update
+		logger.Debugf("Skipping status update on ingress %s/%s", namespace, name)
+		return nil
+	}
+
+	err = c.networkingClient(c.ingressAPI).Put().
+		Namespace(namespace).
+		Resource(resourceIngresses).
+		Name(name).
+		SubResource("status").
+		Body(ingCopy).
+		Do().
+		Error()
+	if err != nil {
+		return fmt.Errorf("failed to update ingress status %s/%s: %v", namespace, name, err)
+	}
+	logger.Infof("Updated status on ingress %s/%s", namespace, name)
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package v1 holds the gateway.networking.k8s.io/v1 resources of the Kubernetes Gateway API:
+// the GatewayClasses, the Gateways and the HTTPRoutes.
+// Only the fields read or written by the provider are declared.
+package v1
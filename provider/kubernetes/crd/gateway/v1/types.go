@@ -0,0 +1,269 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayClass describes a class of Gateways, handled by the controller named in its spec
+type GatewayClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GatewayClassSpec   `json:"spec"`
+	Status GatewayClassStatus `json:"status,omitempty"`
+}
+
+// GatewayClassSpec holds the controller of a GatewayClass
+type GatewayClassSpec struct {
+	ControllerName string `json:"controllerName"`
+}
+
+// GatewayClassStatus is the status of a GatewayClass
+type GatewayClassStatus struct {
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// GatewayClassList is a list of GatewayClasses
+type GatewayClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GatewayClass `json:"items"`
+}
+
+// Gateway describes the listeners through which the traffic enters the cluster
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GatewaySpec   `json:"spec"`
+	Status GatewayStatus `json:"status,omitempty"`
+}
+
+// GatewaySpec holds the class and the listeners of a Gateway
+type GatewaySpec struct {
+	GatewayClassName string     `json:"gatewayClassName"`
+	Listeners        []Listener `json:"listeners"`
+}
+
+// Listener is a logical endpoint of a Gateway, accepting the connections on a port and a protocol
+type Listener struct {
+	Name          string            `json:"name"`
+	Hostname      string            `json:"hostname,omitempty"`
+	Port          int32             `json:"port"`
+	Protocol      string            `json:"protocol"`
+	TLS           *GatewayTLSConfig `json:"tls,omitempty"`
+	AllowedRoutes *AllowedRoutes    `json:"allowedRoutes,omitempty"`
+}
+
+// GatewayTLSConfig describes the TLS configuration of a listener
+type GatewayTLSConfig struct {
+	Mode            string                  `json:"mode,omitempty"`
+	CertificateRefs []SecretObjectReference `json:"certificateRefs,omitempty"`
+}
+
+// SecretObjectReference references a Secret, in the namespace of the referent when the namespace is empty
+type SecretObjectReference struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AllowedRoutes restricts the routes which can attach to a listener
+type AllowedRoutes struct {
+	Namespaces *RouteNamespaces `json:"namespaces,omitempty"`
+	Kinds      []RouteGroupKind `json:"kinds,omitempty"`
+}
+
+// RouteNamespaces selects the namespaces of the routes which can attach to a listener
+type RouteNamespaces struct {
+	From     string                `json:"from,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// RouteGroupKind is the group and the kind of a route
+type RouteGroupKind struct {
+	Group string `json:"group,omitempty"`
+	Kind  string `json:"kind"`
+}
+
+// GatewayStatus is the status of a Gateway
+type GatewayStatus struct {
+	Addresses  []GatewayStatusAddress `json:"addresses,omitempty"`
+	Conditions []Condition            `json:"conditions,omitempty"`
+	Listeners  []ListenerStatus       `json:"listeners,omitempty"`
+}
+
+// GatewayStatusAddress is an address bound to a Gateway
+type GatewayStatusAddress struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+// ListenerStatus is the status of a listener
+type ListenerStatus struct {
+	Name           string           `json:"name"`
+	SupportedKinds []RouteGroupKind `json:"supportedKinds"`
+	AttachedRoutes int32            `json:"attachedRoutes"`
+	Conditions     []Condition      `json:"conditions"`
+}
+
+// GatewayList is a list of Gateways
+type GatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Gateway `json:"items"`
+}
+
+// HTTPRoute describes how the HTTP requests received by the listeners of Gateways are routed to Services
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HTTPRouteSpec   `json:"spec"`
+	Status HTTPRouteStatus `json:"status,omitempty"`
+}
+
+// HTTPRouteSpec holds the parents, the hostnames and the rules of an HTTPRoute
+type HTTPRouteSpec struct {
+	CommonRouteSpec `json:",inline"`
+
+	Hostnames []string        `json:"hostnames,omitempty"`
+	Rules     []HTTPRouteRule `json:"rules,omitempty"`
+}
+
+// CommonRouteSpec holds the fields common to all routes
+type CommonRouteSpec struct {
+	ParentRefs []ParentReference `json:"parentRefs,omitempty"`
+}
+
+// ParentReference references the Gateway, or the listener of a Gateway, a route attaches to
+type ParentReference struct {
+	Group       string `json:"group,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+	Port        int32  `json:"port,omitempty"`
+}
+
+// HTTPRouteRule routes the requests matching any of its matches to its backends
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch  `json:"matches,omitempty"`
+	Filters     []HTTPRouteFilter `json:"filters,omitempty"`
+	BackendRefs []HTTPBackendRef  `json:"backendRefs,omitempty"`
+}
+
+// HTTPRouteMatch holds the predicates a request must match
+type HTTPRouteMatch struct {
+	Path        *HTTPPathMatch        `json:"path,omitempty"`
+	Headers     []HTTPHeaderMatch     `json:"headers,omitempty"`
+	QueryParams []HTTPQueryParamMatch `json:"queryParams,omitempty"`
+	Method      string                `json:"method,omitempty"`
+}
+
+// HTTPPathMatch describes how the path of a request is matched
+type HTTPPathMatch struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPHeaderMatch describes how a header of a request is matched
+type HTTPHeaderMatch struct {
+	Type  string `json:"type,omitempty"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPQueryParamMatch describes how a query parameter of a request is matched
+type HTTPQueryParamMatch struct {
+	Type  string `json:"type,omitempty"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPRouteFilter modifies the requests matching a rule
+type HTTPRouteFilter struct {
+	Type                  string                     `json:"type"`
+	RequestHeaderModifier *HTTPHeaderFilter          `json:"requestHeaderModifier,omitempty"`
+	RequestRedirect       *HTTPRequestRedirectFilter `json:"requestRedirect,omitempty"`
+}
+
+// HTTPHeaderFilter sets, adds and removes request headers
+type HTTPHeaderFilter struct {
+	Set    []HTTPHeader `json:"set,omitempty"`
+	Add    []HTTPHeader `json:"add,omitempty"`
+	Remove []string     `json:"remove,omitempty"`
+}
+
+// HTTPHeader is the name and the value of a header
+type HTTPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPRequestRedirectFilter redirects the requests, replacing the scheme, the hostname or the port of their URL
+type HTTPRequestRedirectFilter struct {
+	Scheme     string `json:"scheme,omitempty"`
+	Hostname   string `json:"hostname,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+}
+
+// HTTPBackendRef references a backend of an HTTPRoute rule
+type HTTPBackendRef struct {
+	BackendRef `json:",inline"`
+}
+
+// BackendRef references a backend and its weight
+type BackendRef struct {
+	BackendObjectReference `json:",inline"`
+
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// BackendObjectReference references a backend, a Service by default
+type BackendObjectReference struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Port      int32  `json:"port,omitempty"`
+}
+
+// HTTPRouteStatus is the status of an HTTPRoute
+type HTTPRouteStatus struct {
+	RouteStatus `json:",inline"`
+}
+
+// RouteStatus holds the status of a route for each of its parents
+type RouteStatus struct {
+	Parents []RouteParentStatus `json:"parents"`
+}
+
+// RouteParentStatus is the status of a route for one of its parents, written by the controller of the parent
+type RouteParentStatus struct {
+	ParentRef      ParentReference `json:"parentRef"`
+	ControllerName string          `json:"controllerName"`
+	Conditions     []Condition     `json:"conditions,omitempty"`
+}
+
+// HTTPRouteList is a list of HTTPRoutes
+type HTTPRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HTTPRoute `json:"items"`
+}
+
+// Condition is an observation of the state of a resource
+type Condition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	ObservedGeneration int64       `json:"observedGeneration,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	Reason             string      `json:"reason"`
+	Message            string      `json:"message"`
+}
@@ -0,0 +1,3 @@
+// Package v1alpha2 holds the gateway.networking.k8s.io/v1alpha2 TLSRoutes of the Kubernetes Gateway API.
+// They share the references and the route status of the v1 resources.
+package v1alpha2
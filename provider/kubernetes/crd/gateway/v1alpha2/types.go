@@ -0,0 +1,42 @@
+package v1alpha2
+
+import (
+	gatewayv1 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TLSRoute describes how the TLS connections received by the listeners of Gateways are routed to Services,
+// according to their SNI
+type TLSRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TLSRouteSpec   `json:"spec"`
+	Status TLSRouteStatus `json:"status,omitempty"`
+}
+
+// TLSRouteSpec holds the parents, the hostnames and the rules of a TLSRoute
+type TLSRouteSpec struct {
+	gatewayv1.CommonRouteSpec `json:",inline"`
+
+	Hostnames []string       `json:"hostnames,omitempty"`
+	Rules     []TLSRouteRule `json:"rules,omitempty"`
+}
+
+// TLSRouteRule forwards the connections to its backends
+type TLSRouteRule struct {
+	BackendRefs []gatewayv1.BackendRef `json:"backendRefs,omitempty"`
+}
+
+// TLSRouteStatus is the status of a TLSRoute
+type TLSRouteStatus struct {
+	gatewayv1.RouteStatus `json:",inline"`
+}
+
+// TLSRouteList is a list of TLSRoutes
+type TLSRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []TLSRoute `json:"items"`
+}
@@ -0,0 +1,237 @@
+package v1alpha1
+
+import (
+	"github.com/mitchellh/copystructure"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies the receiver into out
+func (in *IngressRoute) DeepCopyInto(out *IngressRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy copies the receiver, creating a new IngressRoute
+func (in *IngressRoute) DeepCopy() *IngressRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *IngressRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *IngressRouteSpec) DeepCopyInto(out *IngressRouteSpec) {
+	*out = *in
+	if in.Routes != nil {
+		out.Routes = make([]Route, len(in.Routes))
+		for i := range in.Routes {
+			in.Routes[i].DeepCopyInto(&out.Routes[i])
+		}
+	}
+	if in.EntryPoints != nil {
+		out.EntryPoints = make([]string, len(in.EntryPoints))
+		copy(out.EntryPoints, in.EntryPoints)
+	}
+	if in.TLS != nil {
+		out.TLS = new(TLS)
+		*out.TLS = *in.TLS
+		if in.TLS.Options != nil {
+			out.TLS.Options = new(TLSOptionRef)
+			*out.TLS.Options = *in.TLS.Options
+		}
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+	if in.Services != nil {
+		out.Services = make([]Service, len(in.Services))
+		copy(out.Services, in.Services)
+	}
+	if in.Middlewares != nil {
+		out.Middlewares = make([]MiddlewareRef, len(in.Middlewares))
+		copy(out.Middlewares, in.Middlewares)
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *IngressRouteList) DeepCopyInto(out *IngressRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]IngressRoute, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new IngressRouteList
+func (in *IngressRouteList) DeepCopy() *IngressRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *IngressRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *Middleware) DeepCopyInto(out *Middleware) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy copies the receiver, creating a new Middleware
+func (in *Middleware) DeepCopy() *Middleware {
+	if in == nil {
+		return nil
+	}
+	out := new(Middleware)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *Middleware) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out.
+// The settings reuse the types of the frontends, which have no generated deep copy functions,
+// so they are copied by reflection.
+func (in *MiddlewareSpec) DeepCopyInto(out *MiddlewareSpec) {
+	*out = copystructure.Must(copystructure.Copy(*in)).(MiddlewareSpec)
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *MiddlewareList) DeepCopyInto(out *MiddlewareList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]Middleware, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new MiddlewareList
+func (in *MiddlewareList) DeepCopy() *MiddlewareList {
+	if in == nil {
+		return nil
+	}
+	out := new(MiddlewareList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *MiddlewareList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *TLSOption) DeepCopyInto(out *TLSOption) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy copies the receiver, creating a new TLSOption
+func (in *TLSOption) DeepCopy() *TLSOption {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *TLSOption) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *TLSOptionSpec) DeepCopyInto(out *TLSOptionSpec) {
+	*out = *in
+	if in.CipherSuites != nil {
+		out.CipherSuites = make([]string, len(in.CipherSuites))
+		copy(out.CipherSuites, in.CipherSuites)
+	}
+	if in.ClientCA != nil {
+		out.ClientCA = new(ClientAuth)
+		*out.ClientCA = *in.ClientCA
+		if in.ClientCA.SecretNames != nil {
+			out.ClientCA.SecretNames = make([]string, len(in.ClientCA.SecretNames))
+			copy(out.ClientCA.SecretNames, in.ClientCA.SecretNames)
+		}
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *TLSOptionList) DeepCopyInto(out *TLSOptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]TLSOption, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new TLSOptionList
+func (in *TLSOptionList) DeepCopy() *TLSOptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSOptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *TLSOptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
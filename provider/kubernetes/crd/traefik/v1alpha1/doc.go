@@ -0,0 +1,3 @@
+// Package v1alpha1 holds the types of the traefik.containo.us/v1alpha1 custom resources:
+// the IngressRoutes, the Middlewares and the TLSOptions.
+package v1alpha1
@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressRoute routes the requests matching a rule to a set of weighted services
+type IngressRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec IngressRouteSpec `json:"spec"`
+}
+
+// IngressRouteSpec is the specification of an IngressRoute
+type IngressRouteSpec struct {
+	Routes      []Route  `json:"routes"`
+	EntryPoints []string `json:"entryPoints,omitempty"`
+	TLS         *TLS     `json:"tls,omitempty"`
+}
+
+// Route holds a frontend rule, using the syntax of the frontend rules (e.g. "Host:foo.com;PathPrefix:/bar"),
+// and the services and the middlewares of the requests matching it
+type Route struct {
+	Match       string          `json:"match"`
+	Priority    int             `json:"priority,omitempty"`
+	Services    []Service       `json:"services,omitempty"`
+	Middlewares []MiddlewareRef `json:"middlewares,omitempty"`
+}
+
+// Service references a Kubernetes service, and its share of the requests of a route
+type Service struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+	// Weight is the relative weight of the service among the services of the route, 1 by default
+	Weight int `json:"weight,omitempty"`
+	// Scheme is http, https or h2c, https is used by default for the port 443
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// MiddlewareRef references a Middleware of the namespace of the IngressRoute
+type MiddlewareRef struct {
+	Name string `json:"name"`
+}
+
+// TLS holds the certificate and the TLS options of the domains of an IngressRoute
+type TLS struct {
+	// SecretName references a secret of the namespace of the IngressRoute holding tls.crt and tls.key
+	SecretName string        `json:"secretName,omitempty"`
+	Options    *TLSOptionRef `json:"options,omitempty"`
+}
+
+// TLSOptionRef references a TLSOption of the namespace of the IngressRoute
+type TLSOptionRef struct {
+	Name string `json:"name"`
+}
+
+// IngressRouteList is a list of IngressRoutes
+type IngressRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []IngressRoute `json:"items"`
+}
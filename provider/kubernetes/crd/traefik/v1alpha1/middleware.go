@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	"github.com/containous/traefik/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Middleware holds the settings applied to the frontends of the routes referencing it
+type Middleware struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec MiddlewareSpec `json:"spec"`
+}
+
+// MiddlewareSpec is the specification of a Middleware.
+// A route can reference several middlewares, as long as they do not configure the same setting.
+type MiddlewareSpec struct {
+	Headers           *types.Headers          `json:"headers,omitempty"`
+	Redirect          *types.Redirect         `json:"redirect,omitempty"`
+	WhiteList         *types.WhiteList        `json:"whiteList,omitempty"`
+	RateLimit         *types.RateLimit        `json:"rateLimit,omitempty"`
+	PassTLSClientCert *types.TLSClientHeaders `json:"passTLSClientCert,omitempty"`
+	BasicAuth         *BasicAuth              `json:"basicAuth,omitempty"`
+	ForwardAuth       *ForwardAuth            `json:"forwardAuth,omitempty"`
+}
+
+// BasicAuth holds the basic authentication settings
+type BasicAuth struct {
+	// Secret references a secret of the namespace of the Middleware holding the users, in the htpasswd format
+	Secret       string `json:"secret"`
+	RemoveHeader bool   `json:"removeHeader,omitempty"`
+	HeaderField  string `json:"headerField,omitempty"`
+}
+
+// ForwardAuth holds the forward authentication settings
+type ForwardAuth struct {
+	Address             string   `json:"address"`
+	TrustForwardHeader  bool     `json:"trustForwardHeader,omitempty"`
+	AuthResponseHeaders []string `json:"authResponseHeaders,omitempty"`
+	// TLSSecret references a secret of the namespace of the Middleware holding the client certificate in tls.crt and tls.key
+	TLSSecret          string `json:"tlsSecret,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+// MiddlewareList is a list of Middlewares
+type MiddlewareList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Middleware `json:"items"`
+}
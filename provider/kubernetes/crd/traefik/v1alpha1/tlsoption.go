@@ -0,0 +1,38 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TLSOption holds the TLS settings of the domains of the IngressRoutes referencing it.
+// They override the TLS settings of the entrypoints.
+type TLSOption struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec TLSOptionSpec `json:"spec"`
+}
+
+// TLSOptionSpec is the specification of a TLSOption
+type TLSOptionSpec struct {
+	MinVersion   string      `json:"minVersion,omitempty"`
+	MaxVersion   string      `json:"maxVersion,omitempty"`
+	CipherSuites []string    `json:"cipherSuites,omitempty"`
+	ClientCA     *ClientAuth `json:"clientCA,omitempty"`
+}
+
+// ClientAuth holds the CAs used to verify the client certificates
+type ClientAuth struct {
+	// SecretNames references secrets of the namespace of the TLSOption holding a CA bundle in ca.crt
+	SecretNames []string `json:"secretNames"`
+	// Optional verifies the client certificates only when provided
+	Optional bool `json:"optional,omitempty"`
+}
+
+// TLSOptionList is a list of TLSOptions
+type TLSOptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TLSOption `json:"items"`
+}
@@ -0,0 +1,130 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies the receiver into out
+func (in *EndpointSlice) DeepCopyInto(out *EndpointSlice) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Endpoints != nil {
+		out.Endpoints = make([]Endpoint, len(in.Endpoints))
+		for i := range in.Endpoints {
+			in.Endpoints[i].DeepCopyInto(&out.Endpoints[i])
+		}
+	}
+	if in.Ports != nil {
+		out.Ports = make([]EndpointPort, len(in.Ports))
+		for i := range in.Ports {
+			in.Ports[i].DeepCopyInto(&out.Ports[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new EndpointSlice
+func (in *EndpointSlice) DeepCopy() *EndpointSlice {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSlice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *EndpointSlice) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+	*out = *in
+	if in.Addresses != nil {
+		out.Addresses = make([]string, len(in.Addresses))
+		copy(out.Addresses, in.Addresses)
+	}
+	in.Conditions.DeepCopyInto(&out.Conditions)
+	if in.Hostname != nil {
+		out.Hostname = new(string)
+		*out.Hostname = *in.Hostname
+	}
+	if in.TargetRef != nil {
+		out.TargetRef = new(corev1.ObjectReference)
+		*out.TargetRef = *in.TargetRef
+	}
+	if in.NodeName != nil {
+		out.NodeName = new(string)
+		*out.NodeName = *in.NodeName
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *EndpointConditions) DeepCopyInto(out *EndpointConditions) {
+	*out = *in
+	if in.Ready != nil {
+		out.Ready = new(bool)
+		*out.Ready = *in.Ready
+	}
+	if in.Serving != nil {
+		out.Serving = new(bool)
+		*out.Serving = *in.Serving
+	}
+	if in.Terminating != nil {
+		out.Terminating = new(bool)
+		*out.Terminating = *in.Terminating
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *EndpointPort) DeepCopyInto(out *EndpointPort) {
+	*out = *in
+	if in.Name != nil {
+		out.Name = new(string)
+		*out.Name = *in.Name
+	}
+	if in.Protocol != nil {
+		out.Protocol = new(corev1.Protocol)
+		*out.Protocol = *in.Protocol
+	}
+	if in.Port != nil {
+		out.Port = new(int32)
+		*out.Port = *in.Port
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *EndpointSliceList) DeepCopyInto(out *EndpointSliceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]EndpointSlice, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new EndpointSliceList
+func (in *EndpointSliceList) DeepCopy() *EndpointSliceList {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSliceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *EndpointSliceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package v1 holds the discovery.k8s.io/v1 EndpointSlice types,
+// served by the Kubernetes API from 1.21 on.
+// The vendored k8s.io/api does not provide them yet, only the fields read by the provider are declared.
+package v1
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LabelServiceName is the label holding the name of the Service of an EndpointSlice
+const LabelServiceName = "kubernetes.io/service-name"
+
+// AddressType represents the type of the addresses of an EndpointSlice
+type AddressType string
+
+const (
+	// AddressTypeIPv4 represents an IPv4 address
+	AddressTypeIPv4 = AddressType("IPv4")
+	// AddressTypeIPv6 represents an IPv6 address
+	AddressTypeIPv6 = AddressType("IPv6")
+	// AddressTypeFQDN represents a fully qualified domain name
+	AddressTypeFQDN = AddressType("FQDN")
+)
+
+// EndpointSlice represents a subset of the endpoints implementing a Service
+type EndpointSlice struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	AddressType AddressType    `json:"addressType"`
+	Endpoints   []Endpoint     `json:"endpoints"`
+	Ports       []EndpointPort `json:"ports"`
+}
+
+// Endpoint represents a single logical backend implementing a Service
+type Endpoint struct {
+	Addresses  []string                `json:"addresses"`
+	Conditions EndpointConditions      `json:"conditions,omitempty"`
+	Hostname   *string                 `json:"hostname,omitempty"`
+	TargetRef  *corev1.ObjectReference `json:"targetRef,omitempty"`
+	NodeName   *string                 `json:"nodeName,omitempty"`
+}
+
+// EndpointConditions represents the current condition of an endpoint
+type EndpointConditions struct {
+	Ready       *bool `json:"ready,omitempty"`
+	Serving     *bool `json:"serving,omitempty"`
+	Terminating *bool `json:"terminating,omitempty"`
+}
+
+// EndpointPort represents a port used by an EndpointSlice
+type EndpointPort struct {
+	Name     *string          `json:"name,omitempty"`
+	Protocol *corev1.Protocol `json:"protocol,omitempty"`
+	Port     *int32           `json:"port,omitempty"`
+}
+
+// EndpointSliceList is a collection of EndpointSlices
+type EndpointSliceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EndpointSlice `json:"items"`
+}
@@ -0,0 +1,4 @@
+// Package v1beta1 holds the discovery.k8s.io/v1beta1 EndpointSlice types,
+// served by the Kubernetes API from 1.17 on.
+// The vendored k8s.io/api does not provide them yet, only the fields read by the provider are declared.
+package v1beta1
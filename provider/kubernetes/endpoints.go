@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	discoveryv1 "github.com/containous/traefik/provider/kubernetes/discovery/v1"
+	discoveryv1beta1 "github.com/containous/traefik/provider/kubernetes/discovery/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// labelTopologyHostname is the topology key of the v1beta1 EndpointSlices holding the name of the node of an endpoint
+const labelTopologyHostname = "kubernetes.io/hostname"
+
+// newEndpointsFromSlices merges the EndpointSlices of a Service into Endpoints, one subset per slice.
+// The ready endpoints are the addresses of the subsets. When no endpoint of the Service is ready, e.g. during a rollout,
+// the terminating endpoints which are still serving are used instead, so that they drain gracefully.
+func newEndpointsFromSlices(namespace, name string, slices []*discoveryv1.EndpointSlice) *corev1.Endpoints {
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+
+	fallbackToTerminating := true
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if isEndpointReady(endpoint) {
+				fallbackToTerminating = false
+			}
+		}
+	}
+
+	for _, slice := range slices {
+		if slice.AddressType == discoveryv1.AddressTypeFQDN {
+			continue
+		}
+
+		var subset corev1.EndpointSubset
+		for _, port := range slice.Ports {
+			if port.Port == nil {
+				continue
+			}
+
+			endpointPort := corev1.EndpointPort{Name: stringValue(port.Name), Port: *port.Port}
+			if port.Protocol != nil {
+				endpointPort.Protocol = *port.Protocol
+			}
+			subset.Ports = append(subset.Ports, endpointPort)
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if len(endpoint.Addresses) == 0 {
+				continue
+			}
+
+			address := corev1.EndpointAddress{
+				IP:        endpoint.Addresses[0],
+				Hostname:  stringValue(endpoint.Hostname),
+				NodeName:  endpoint.NodeName,
+				TargetRef: endpoint.TargetRef,
+			}
+
+			if isEndpointReady(endpoint) || fallbackToTerminating && isEndpointServingAndTerminating(endpoint) {
+				subset.Addresses = append(subset.Addresses, address)
+			} else {
+				subset.NotReadyAddresses = append(subset.NotReadyAddresses, address)
+			}
+		}
+
+		endpoints.Subsets = append(endpoints.Subsets, subset)
+	}
+
+	return endpoints
+}
+
+// isEndpointReady tells whether an endpoint is ready, an unknown readiness being ready.
+func isEndpointReady(endpoint discoveryv1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
+// isEndpointServingAndTerminating tells whether an endpoint is terminating but can still serve requests.
+func isEndpointServingAndTerminating(endpoint discoveryv1.Endpoint) bool {
+	if endpoint.Conditions.Terminating == nil || !*endpoint.Conditions.Terminating {
+		return false
+	}
+	return endpoint.Conditions.Serving != nil && *endpoint.Conditions.Serving
+}
+
+func newEndpointSliceFromDiscoveryV1beta1(slice *discoveryv1beta1.EndpointSlice) *discoveryv1.EndpointSlice {
+	converted := &discoveryv1.EndpointSlice{
+		AddressType: discoveryv1.AddressType(slice.AddressType),
+	}
+	slice.ObjectMeta.DeepCopyInto(&converted.ObjectMeta)
+
+	for _, endpoint := range slice.Endpoints {
+		var nodeName *string
+		if hostname, ok := endpoint.Topology[labelTopologyHostname]; ok {
+			nodeName = &hostname
+		}
+
+		converted.Endpoints = append(converted.Endpoints, discoveryv1.Endpoint{
+			Addresses: endpoint.Addresses,
+			Conditions: discoveryv1.EndpointConditions{
+				Ready:       endpoint.Conditions.Ready,
+				Serving:     endpoint.Conditions.Serving,
+				Terminating: endpoint.Conditions.Terminating,
+			},
+			Hostname:  endpoint.Hostname,
+			TargetRef: endpoint.TargetRef,
+			NodeName:  nodeName,
+		})
+	}
+
+	for _, port := range slice.Ports {
+		converted.Ports = append(converted.Ports, discoveryv1.EndpointPort{
+			Name:     port.Name,
+			Protocol: port.Protocol,
+			Port:     port.Port,
+		})
+	}
+
+	return converted
+}
@@ -0,0 +1,194 @@
+package kubernetes
+
+import (
+	"testing"
+
+	discoveryv1 "github.com/containous/traefik/provider/kubernetes/discovery/v1"
+	discoveryv1beta1 "github.com/containous/traefik/provider/kubernetes/discovery/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewEndpointsFromSlices(t *testing.T) {
+	ready, notReady := true, false
+	port, portName := int32(8080), "http"
+
+	testCases := []struct {
+		desc     string
+		slices   []*discoveryv1.EndpointSlice
+		expected []corev1.EndpointSubset
+	}{
+		{
+			desc: "ready and not ready endpoints",
+			slices: []*discoveryv1.EndpointSlice{
+				{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Ports:       []discoveryv1.EndpointPort{{Name: &portName, Port: &port}},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses:  []string{"10.10.0.1"},
+							Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+							TargetRef:  &corev1.ObjectReference{Name: "pod1"},
+						},
+						{
+							Addresses: []string{"10.10.0.2"},
+						},
+						{
+							Addresses:  []string{"10.10.0.3"},
+							Conditions: discoveryv1.EndpointConditions{Ready: &notReady},
+						},
+					},
+				},
+			},
+			expected: []corev1.EndpointSubset{
+				{
+					Addresses: []corev1.EndpointAddress{
+						{IP: "10.10.0.1", TargetRef: &corev1.ObjectReference{Name: "pod1"}},
+						{IP: "10.10.0.2"},
+					},
+					NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.10.0.3"}},
+					Ports:             []corev1.EndpointPort{{Name: "http", Port: 8080}},
+				},
+			},
+		},
+		{
+			desc: "terminating endpoints are not used while an endpoint is ready",
+			slices: []*discoveryv1.EndpointSlice{
+				{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Ports:       []discoveryv1.EndpointPort{{Port: &port}},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses:  []string{"10.10.0.1"},
+							Conditions: discoveryv1.EndpointConditions{Ready: &notReady, Serving: &ready, Terminating: &ready},
+						},
+					},
+				},
+				{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Ports:       []discoveryv1.EndpointPort{{Port: &port}},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses:  []string{"10.10.0.2"},
+							Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+						},
+					},
+				},
+			},
+			expected: []corev1.EndpointSubset{
+				{
+					NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.10.0.1"}},
+					Ports:             []corev1.EndpointPort{{Port: 8080}},
+				},
+				{
+					Addresses: []corev1.EndpointAddress{{IP: "10.10.0.2"}},
+					Ports:     []corev1.EndpointPort{{Port: 8080}},
+				},
+			},
+		},
+		{
+			desc: "serving terminating endpoints are used when no endpoint is ready",
+			slices: []*discoveryv1.EndpointSlice{
+				{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Ports:       []discoveryv1.EndpointPort{{Port: &port}},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses:  []string{"10.10.0.1"},
+							Conditions: discoveryv1.EndpointConditions{Ready: &notReady, Serving: &ready, Terminating: &ready},
+						},
+						{
+							Addresses:  []string{"10.10.0.2"},
+							Conditions: discoveryv1.EndpointConditions{Ready: &notReady, Serving: &notReady, Terminating: &ready},
+						},
+						{
+							Addresses:  []string{"10.10.0.3"},
+							Conditions: discoveryv1.EndpointConditions{Ready: &notReady},
+						},
+					},
+				},
+			},
+			expected: []corev1.EndpointSubset{
+				{
+					Addresses: []corev1.EndpointAddress{{IP: "10.10.0.1"}},
+					NotReadyAddresses: []corev1.EndpointAddress{
+						{IP: "10.10.0.2"},
+						{IP: "10.10.0.3"},
+					},
+					Ports: []corev1.EndpointPort{{Port: 8080}},
+				},
+			},
+		},
+		{
+			desc: "FQDN slices are ignored",
+			slices: []*discoveryv1.EndpointSlice{
+				{
+					AddressType: discoveryv1.AddressTypeFQDN,
+					Ports:       []discoveryv1.EndpointPort{{Port: &port}},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses: []string{"example.com"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			endpoints := newEndpointsFromSlices("testing", "service1", test.slices)
+
+			assert.Equal(t, "testing", endpoints.Namespace)
+			assert.Equal(t, "service1", endpoints.Name)
+			assert.Equal(t, test.expected, endpoints.Subsets)
+		})
+	}
+}
+
+func TestNewEndpointSliceFromDiscoveryV1beta1(t *testing.T) {
+	ready := true
+	port, portName := int32(8080), "http"
+	protocol := corev1.ProtocolTCP
+
+	slice := &discoveryv1beta1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service1-abcde",
+			Namespace: "testing",
+			Labels:    map[string]string{discoveryv1beta1.LabelServiceName: "service1"},
+		},
+		AddressType: discoveryv1beta1.AddressTypeIPv4,
+		Ports:       []discoveryv1beta1.EndpointPort{{Name: &portName, Protocol: &protocol, Port: &port}},
+		Endpoints: []discoveryv1beta1.Endpoint{
+			{
+				Addresses:  []string{"10.10.0.1"},
+				Conditions: discoveryv1beta1.EndpointConditions{Ready: &ready},
+				Topology:   map[string]string{labelTopologyHostname: "node1"},
+			},
+		},
+	}
+
+	nodeName := "node1"
+	expected := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service1-abcde",
+			Namespace: "testing",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "service1"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports:       []discoveryv1.EndpointPort{{Name: &portName, Protocol: &protocol, Port: &port}},
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses:  []string{"10.10.0.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+				NodeName:   &nodeName,
+			},
+		},
+	}
+
+	assert.Equal(t, expected, newEndpointSliceFromDiscoveryV1beta1(slice))
+}
@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	networkingv1 "github.com/containous/traefik/provider/kubernetes/networking/v1"
+	networkingv1beta1 "github.com/containous/traefik/provider/kubernetes/networking/v1beta1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Ingress is an Ingress read from the extensions/v1beta1, networking.k8s.io/v1beta1 or networking.k8s.io/v1 API,
+// converted to extensions/v1beta1. It holds the fields which do not exist in extensions/v1beta1.
+type Ingress struct {
+	*extensionsv1beta1.Ingress
+	// ClassName is the spec.ingressClassName of the Ingress
+	ClassName string
+	// PathTypes holds the pathType of the paths, indexed by rule then by path
+	PathTypes [][]string
+}
+
+// pathType returns the pathType of a path of a rule, empty when the Ingress API has no path types
+func (i *Ingress) pathType(rule, path int) string {
+	if rule >= len(i.PathTypes) || path >= len(i.PathTypes[rule]) {
+		return ""
+	}
+	return i.PathTypes[rule][path]
+}
+
+func newIngressFromExtensions(ing *extensionsv1beta1.Ingress) *Ingress {
+	return &Ingress{Ingress: ing}
+}
+
+func newIngressFromNetworkingV1beta1(ing *networkingv1beta1.Ingress) *Ingress {
+	converted := &extensionsv1beta1.Ingress{
+		Status: extensionsv1beta1.IngressStatus{LoadBalancer: *ing.Status.LoadBalancer.DeepCopy()},
+	}
+	ing.ObjectMeta.DeepCopyInto(&converted.ObjectMeta)
+
+	if ing.Spec.Backend != nil {
+		converted.Spec.Backend = &extensionsv1beta1.IngressBackend{
+			ServiceName: ing.Spec.Backend.ServiceName,
+			ServicePort: ing.Spec.Backend.ServicePort,
+		}
+	}
+
+	for _, t := range ing.Spec.TLS {
+		converted.Spec.TLS = append(converted.Spec.TLS, extensionsv1beta1.IngressTLS{
+			Hosts:      append([]string(nil), t.Hosts...),
+			SecretName: t.SecretName,
+		})
+	}
+
+	pathTypes := make([][]string, len(ing.Spec.Rules))
+	for i, r := range ing.Spec.Rules {
+		rule := extensionsv1beta1.IngressRule{Host: r.Host}
+		if r.HTTP != nil {
+			rule.HTTP = &extensionsv1beta1.HTTPIngressRuleValue{}
+			for _, pa := range r.HTTP.Paths {
+				rule.HTTP.Paths = append(rule.HTTP.Paths, extensionsv1beta1.HTTPIngressPath{
+					Path: pa.Path,
+					Backend: extensionsv1beta1.IngressBackend{
+						ServiceName: pa.Backend.ServiceName,
+						ServicePort: pa.Backend.ServicePort,
+					},
+				})
+
+				var pathType string
+				if pa.PathType != nil {
+					pathType = string(*pa.PathType)
+				}
+				pathTypes[i] = append(pathTypes[i], pathType)
+			}
+		}
+		converted.Spec.Rules = append(converted.Spec.Rules, rule)
+	}
+
+	return &Ingress{
+		Ingress:   converted,
+		ClassName: stringValue(ing.Spec.IngressClassName),
+		PathTypes: pathTypes,
+	}
+}
+
+func newIngressFromNetworkingV1(ing *networkingv1.Ingress) *Ingress {
+	converted := &extensionsv1beta1.Ingress{
+		Status: extensionsv1beta1.IngressStatus{LoadBalancer: *ing.Status.LoadBalancer.DeepCopy()},
+	}
+	ing.ObjectMeta.DeepCopyInto(&converted.ObjectMeta)
+
+	if ing.Spec.DefaultBackend != nil {
+		backend := convertNetworkingV1Backend(*ing.Spec.DefaultBackend)
+		converted.Spec.Backend = &backend
+	}
+
+	for _, t := range ing.Spec.TLS {
+		converted.Spec.TLS = append(converted.Spec.TLS, extensionsv1beta1.IngressTLS{
+			Hosts:      append([]string(nil), t.Hosts...),
+			SecretName: t.SecretName,
+		})
+	}
+
+	pathTypes := make([][]string, len(ing.Spec.Rules))
+	for i, r := range ing.Spec.Rules {
+		rule := extensionsv1beta1.IngressRule{Host: r.Host}
+		if r.HTTP != nil {
+			rule.HTTP = &extensionsv1beta1.HTTPIngressRuleValue{}
+			for _, pa := range r.HTTP.Paths {
+				rule.HTTP.Paths = append(rule.HTTP.Paths, extensionsv1beta1.HTTPIngressPath{
+					Path:    pa.Path,
+					Backend: convertNetworkingV1Backend(pa.Backend),
+				})
+
+				var pathType string
+				if pa.PathType != nil {
+					pathType = string(*pa.PathType)
+				}
+				pathTypes[i] = append(pathTypes[i], pathType)
+			}
+		}
+		converted.Spec.Rules = append(converted.Spec.Rules, rule)
+	}
+
+	return &Ingress{
+		Ingress:   converted,
+		ClassName: stringValue(ing.Spec.IngressClassName),
+		PathTypes: pathTypes,
+	}
+}
+
+// convertNetworkingV1Backend converts a service backend, the resource backends are not supported
+// and result in a backend without service
+func convertNetworkingV1Backend(backend networkingv1.IngressBackend) extensionsv1beta1.IngressBackend {
+	if backend.Service == nil {
+		return extensionsv1beta1.IngressBackend{}
+	}
+
+	port := intstr.FromInt(int(backend.Service.Port.Number))
+	if len(backend.Service.Port.Name) > 0 {
+		port = intstr.FromString(backend.Service.Port.Name)
+	}
+
+	return extensionsv1beta1.IngressBackend{
+		ServiceName: backend.Service.Name,
+		ServicePort: port,
+	}
+}
+
+func newIngressClassFromNetworkingV1beta1(class *networkingv1beta1.IngressClass) *networkingv1.IngressClass {
+	converted := &networkingv1.IngressClass{
+		Spec: networkingv1.IngressClassSpec{Controller: class.Spec.Controller},
+	}
+	class.ObjectMeta.DeepCopyInto(&converted.ObjectMeta)
+	return converted
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
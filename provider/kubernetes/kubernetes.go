@@ -20,6 +20,7 @@ import (
 	"github.com/containous/traefik/job"
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
+	networkingv1 "github.com/containous/traefik/provider/kubernetes/networking/v1"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/tls"
@@ -29,8 +30,11 @@ import (
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
 )
 
+var logger = log.WithModule("provider/kubernetes")
+
 var _ provider.Provider = (*Provider)(nil)
 
 const (
@@ -43,6 +47,8 @@ const (
 	ruleTypeReplacePathRegex   = "ReplacePathRegex"
 	traefikDefaultRealm        = "traefik"
 	traefikDefaultIngressClass = "traefik"
+	traefikIngressController   = "traefik.io/ingress-controller"
+	annotationDefaultClass     = "ingressclass.kubernetes.io/is-default-class"
 	defaultBackendName         = "global-default-backend"
 	defaultFrontendName        = "global-default-frontend"
 	defaultFrontendRule        = "PathPrefix:/"
@@ -57,6 +63,13 @@ type IngressEndpoint struct {
 	PublishedService string `description:"Published Kubernetes Service to copy status from"`
 }
 
+// EntryPointsTLS references the secrets holding the default certificate and the client CA bundle of entrypoints
+type EntryPointsTLS struct {
+	EntryPoints        []string `description:"Entrypoints using the secrets (default entrypoints if empty)"`
+	DefaultCertificate string   `description:"Secret (namespace/name) holding the default certificate in tls.crt and tls.key"`
+	ClientCA           string   `description:"Secret (namespace/name) holding the CA bundle used to verify client certificates in ca.crt"`
+}
+
 // Provider holds configurations of the provider.
 type Provider struct {
 	provider.BaseProvider  `mapstructure:",squash" export:"true"`
@@ -67,10 +80,17 @@ type Provider struct {
 	EnablePassTLSCert      bool             `description:"Kubernetes enable Pass TLS Client Certs" export:"true"` // Deprecated
 	Namespaces             Namespaces       `description:"Kubernetes namespaces" export:"true"`
 	LabelSelector          string           `description:"Kubernetes Ingress label selector to use" export:"true"`
-	IngressClass           string           `description:"Value of kubernetes.io/ingress.class annotation to watch for" export:"true"`
+	IngressClass           string           `description:"Value of kubernetes.io/ingress.class annotation or name of the IngressClass to watch for" export:"true"`
 	IngressEndpoint        *IngressEndpoint `description:"Kubernetes Ingress Endpoint"`
+	EntryPointsTLS         *EntryPointsTLS  `description:"Kubernetes secrets holding the default certificate and the client CA bundle of entrypoints" export:"true"`
 	ThrottleDuration       flaeg.Duration   `description:"Ingress refresh throttle duration"`
+	EnableCRD              bool             `description:"Watch the IngressRoute, Middleware and TLSOption custom resources" export:"true"`
+	EnableGateway          bool             `description:"Watch the Gateway API resources: GatewayClasses, Gateways, HTTPRoutes and TLSRoutes" export:"true"`
+	SharedNamespaces       Namespaces       `description:"Namespaces whose secrets and services can be referenced as namespace/name by the Ingresses of other namespaces" export:"true"`
+	NamespaceDefaults      bool             `description:"Use the annotations of the namespaces as default annotations of their Ingresses" export:"true"`
+	EnableEndpointSlices   bool             `description:"Read the endpoints of the Services from the EndpointSlices" export:"true"`
 	lastConfiguration      safe.Safe
+	entryPointPorts        map[int32]string
 }
 
 func (p *Provider) newK8sClient(ingressLabelSelector string) (Client, error) {
@@ -78,27 +98,42 @@ func (p *Provider) newK8sClient(ingressLabelSelector string) (Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid ingress label selector: %q", ingressLabelSelector)
 	}
-	log.Infof("ingress label selector is: %q", ingLabelSel)
+	logger.Infof("ingress label selector is: %q", ingLabelSel)
+
+	cl, err := p.createClient()
+	if err == nil {
+		cl.ingressLabelSelector = ingLabelSel
+		cl.watchCRD = p.EnableCRD
+		cl.watchGateway = p.EnableGateway
+		cl.watchNamespaces = p.NamespaceDefaults
+		cl.watchEndpointSlices = p.EnableEndpointSlices
+	}
+
+	return cl, err
+}
+
+// NewClientset creates a Kubernetes clientset with the connection settings of the provider
+func (p *Provider) NewClientset() (kubernetes.Interface, error) {
+	cl, err := p.createClient()
+	if err != nil {
+		return nil, err
+	}
+	return cl.clientset, nil
+}
 
+func (p *Provider) createClient() (*clientImpl, error) {
 	withEndpoint := ""
 	if p.Endpoint != "" {
 		withEndpoint = fmt.Sprintf(" with endpoint %v", p.Endpoint)
 	}
 
-	var cl *clientImpl
 	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" && os.Getenv("KUBERNETES_SERVICE_PORT") != "" {
-		log.Infof("Creating in-cluster Provider client%s", withEndpoint)
-		cl, err = newInClusterClient(p.Endpoint)
-	} else {
-		log.Infof("Creating cluster-external Provider client%s", withEndpoint)
-		cl, err = newExternalClusterClient(p.Endpoint, p.Token, p.CertAuthFilePath)
+		logger.Infof("Creating in-cluster Provider client%s", withEndpoint)
+		return newInClusterClient(p.Endpoint)
 	}
 
-	if err == nil {
-		cl.ingressLabelSelector = ingLabelSel
-	}
-
-	return cl, err
+	logger.Infof("Creating cluster-external Provider client%s", withEndpoint)
+	return newExternalClusterClient(p.Endpoint, p.Token, p.CertAuthFilePath)
 }
 
 // Init the provider
@@ -118,7 +153,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 		return err
 	}
 
-	log.Debugf("Using Ingress label selector: %q", p.LabelSelector)
+	logger.Debugf("Using Ingress label selector: %q", p.LabelSelector)
 	k8sClient, err := p.newK8sClient(p.LabelSelector)
 	if err != nil {
 		return err
@@ -130,7 +165,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 			defer close(stopWatch)
 			eventsChan, err := k8sClient.WatchAll(p.Namespaces, stopWatch)
 			if err != nil {
-				log.Errorf("Error watching kubernetes events: %v", err)
+				logger.Errorf("Error watching kubernetes events: %v", err)
 				timer := time.NewTimer(1 * time.Second)
 				select {
 				case <-timer.C:
@@ -156,14 +191,14 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 					// dropped events. This is fine, because we don't treat different
 					// event types differently. But if we do in the future, we'll need to
 					// track more information about the dropped events.
-					log.Debugf("Received Kubernetes event kind %T", event)
+					logger.Debugf("Received Kubernetes event kind %T", event)
 					templateObjects, err := p.loadIngresses(k8sClient)
 					if err != nil {
 						return err
 					}
 
 					if reflect.DeepEqual(p.lastConfiguration.Get(), templateObjects) {
-						log.Debugf("Skipping Kubernetes event kind %T", event)
+						logger.Debugf("Skipping Kubernetes event kind %T", event)
 					} else {
 						p.lastConfiguration.Set(templateObjects)
 						configurationChan <- types.ConfigMessage{
@@ -181,11 +216,11 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 		}
 
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Provider connection error: %s; retrying in %s", err, time)
+			logger.Errorf("Provider connection error: %s; retrying in %s", err, time)
 		}
 		err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 		if err != nil {
-			log.Errorf("Cannot connect to Provider: %s", err)
+			logger.Errorf("Cannot connect to Provider: %s", err)
 		}
 	})
 
@@ -194,6 +229,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 
 func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error) {
 	ingresses := k8sClient.GetIngresses()
+	ingressClasses := k8sClient.GetIngressClasses()
 
 	templateObjects := &types.Configuration{
 		Backends:  map[string]*types.Backend{},
@@ -202,26 +238,37 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 
 	tlsConfigs := map[string]*tls.Configuration{}
 
-	for _, i := range ingresses {
+	for _, ingress := range ingresses {
+		i := ingress.Ingress
+
+		if p.NamespaceDefaults {
+			var err error
+			i, err = applyNamespaceDefaults(k8sClient, i)
+			if err != nil {
+				logger.Errorf("Cannot apply the default annotations of the namespace to ingress %s/%s: %v", ingress.Namespace, ingress.Name, err)
+				continue
+			}
+		}
+
 		ingressClass, err := getStringSafeValue(i.Annotations, annotationKubernetesIngressClass, "")
 		if err != nil {
-			log.Errorf("Misconfigured ingress class for ingress %s/%s: %v", i.Namespace, i.Name, err)
+			logger.Errorf("Misconfigured ingress class for ingress %s/%s: %v", i.Namespace, i.Name, err)
 			continue
 		}
 
-		if !p.shouldProcessIngress(ingressClass) {
+		if !p.shouldProcessIngress(ingressClass, ingress.ClassName, ingressClasses) {
 			continue
 		}
 
-		if err = getTLS(i, k8sClient, tlsConfigs); err != nil {
-			log.Errorf("Error configuring TLS for ingress %s/%s: %v", i.Namespace, i.Name, err)
+		if err = p.getTLS(i, k8sClient, tlsConfigs); err != nil {
+			logger.Errorf("Error configuring TLS for ingress %s/%s: %v", i.Namespace, i.Name, err)
 			continue
 		}
 
 		if i.Spec.Backend != nil {
 			err := p.addGlobalBackend(k8sClient, i, templateObjects)
 			if err != nil {
-				log.Errorf("Error creating global backend for ingress %s/%s: %v", i.Namespace, i.Name, err)
+				logger.Errorf("Error creating global backend for ingress %s/%s: %v", i.Namespace, i.Name, err)
 				continue
 			}
 		}
@@ -231,31 +278,31 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 		if _, ok := i.Annotations[annotationPercentageWeights]; ok {
 			fractionalAllocator, err := newFractionalWeightAllocator(i, k8sClient)
 			if err != nil {
-				log.Errorf("failed to create fractional weight allocator for ingress %s/%s: %v", i.Namespace, i.Name, err)
+				logger.Errorf("failed to create fractional weight allocator for ingress %s/%s: %v", i.Namespace, i.Name, err)
 				continue
 			}
-			log.Debugf("Created custom weight allocator for %s/%s: %s", i.Namespace, i.Name, fractionalAllocator)
+			logger.Debugf("Created custom weight allocator for %s/%s: %s", i.Namespace, i.Name, fractionalAllocator)
 			weightAllocator = fractionalAllocator
 		}
 
-		for _, r := range i.Spec.Rules {
+		for ruleIndex, r := range i.Spec.Rules {
 			if r.HTTP == nil {
-				log.Warn("Error in ingress: HTTP is nil")
+				logger.Warn("Error in ingress: HTTP is nil")
 				continue
 			}
 
-			for _, pa := range r.HTTP.Paths {
+			for pathIndex, pa := range r.HTTP.Paths {
 				priority := getIntValue(i.Annotations, annotationKubernetesPriority, 0)
 
 				err := templateSafeString(r.Host)
 				if err != nil {
-					log.Errorf("failed to validate host %q for ingress %s/%s: %v", r.Host, i.Namespace, i.Name, err)
+					logger.Errorf("failed to validate host %q for ingress %s/%s: %v", r.Host, i.Namespace, i.Name, err)
 					continue
 				}
 
 				err = templateSafeString(pa.Path)
 				if err != nil {
-					log.Errorf("failed to validate path %q for ingress %s/%s: %v", pa.Path, i.Namespace, i.Name, err)
+					logger.Errorf("failed to validate path %q for ingress %s/%s: %v", pa.Path, i.Namespace, i.Name, err)
 					continue
 				}
 
@@ -285,7 +332,7 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 
 				annotationAuthRealm := getAnnotationName(i.Annotations, annotationKubernetesAuthRealm)
 				if realm := i.Annotations[annotationAuthRealm]; realm != "" && realm != traefikDefaultRealm {
-					log.Errorf("Value for annotation %q on ingress %s/%s invalid: no realm customization supported", annotationAuthRealm, i.Namespace, i.Name)
+					logger.Errorf("Value for annotation %q on ingress %s/%s invalid: no realm customization supported", annotationAuthRealm, i.Namespace, i.Name)
 					delete(templateObjects.Backends, baseName)
 					continue
 				}
@@ -294,9 +341,15 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 				if fe, exists := templateObjects.Frontends[baseName]; exists {
 					frontend = fe
 				} else {
-					auth, err := getAuthConfig(i, k8sClient)
+					auth, err := p.getAuthConfig(i, k8sClient)
+					if err != nil {
+						logger.Errorf("Failed to retrieve auth configuration for ingress %s/%s: %s", i.Namespace, i.Name, err)
+						continue
+					}
+
+					errorPages, err := p.getErrorPages(k8sClient, i, templateObjects)
 					if err != nil {
-						log.Errorf("Failed to retrieve auth configuration for ingress %s/%s: %s", i.Namespace, i.Name, err)
+						logger.Errorf("Failed to retrieve error pages configuration for ingress %s/%s: %s", i.Namespace, i.Name, err)
 						continue
 					}
 
@@ -314,7 +367,7 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 						Redirect:          getFrontendRedirect(i, baseName, pa.Path),
 						EntryPoints:       entryPoints,
 						Headers:           getHeader(i),
-						Errors:            getErrorPages(i),
+						Errors:            errorPages,
 						RateLimit:         getRateLimit(i),
 						Auth:              auth,
 					}
@@ -322,18 +375,18 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 
 				service, exists, err := k8sClient.GetService(i.Namespace, pa.Backend.ServiceName)
 				if err != nil {
-					log.Errorf("Error while retrieving service information from k8s API %s/%s: %v", i.Namespace, pa.Backend.ServiceName, err)
+					logger.Errorf("Error while retrieving service information from k8s API %s/%s: %v", i.Namespace, pa.Backend.ServiceName, err)
 					return nil, err
 				}
 
 				if !exists {
-					log.Errorf("Service not found for %s/%s", i.Namespace, pa.Backend.ServiceName)
+					logger.Errorf("Service not found for %s/%s", i.Namespace, pa.Backend.ServiceName)
 					continue
 				}
 
-				rule, err := getRuleForPath(pa, i)
+				rule, err := getRuleForPath(pa, i, ingress.pathType(ruleIndex, pathIndex))
 				if err != nil {
-					log.Errorf("Failed to get rule for ingress %s/%s: %s", i.Namespace, i.Name, err)
+					logger.Errorf("Failed to get rule for ingress %s/%s: %s", i.Namespace, i.Name, err)
 					continue
 				}
 
@@ -378,7 +431,7 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 						case allowedProtocolH2C:
 						case label.DefaultProtocol:
 						default:
-							log.Errorf("Invalid protocol %s/%s specified for Ingress %s - skipping", annotationKubernetesProtocol, i.Namespace, i.Name)
+							logger.Errorf("Invalid protocol %s/%s specified for Ingress %s - skipping", annotationKubernetesProtocol, i.Namespace, i.Name)
 							continue
 						}
 
@@ -393,20 +446,26 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 								URL:    url,
 								Weight: externalNameServiceWeight,
 							}
+						} else if useClusterIP(service) {
+							url := getClusterIPURL(protocol, service, port)
+							templateObjects.Backends[baseName].Servers[url] = types.Server{
+								URL:    url,
+								Weight: weightAllocator.getWeight(r.Host, pa.Path, pa.Backend.ServiceName),
+							}
 						} else {
 							endpoints, exists, err := k8sClient.GetEndpoints(service.Namespace, service.Name)
 							if err != nil {
-								log.Errorf("Error retrieving endpoints %s/%s: %v", service.Namespace, service.Name, err)
+								logger.Errorf("Error retrieving endpoints %s/%s: %v", service.Namespace, service.Name, err)
 								return nil, err
 							}
 
 							if !exists {
-								log.Warnf("Endpoints not found for %s/%s", service.Namespace, service.Name)
+								logger.Warnf("Endpoints not found for %s/%s", service.Namespace, service.Name)
 								break
 							}
 
 							if len(endpoints.Subsets) == 0 {
-								log.Warnf("Endpoints not available for %s/%s", service.Namespace, service.Name)
+								logger.Warnf("Endpoints not available for %s/%s", service.Namespace, service.Name)
 								break
 							}
 
@@ -438,15 +497,130 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 
 		err = p.updateIngressStatus(i, k8sClient)
 		if err != nil {
-			log.Errorf("Cannot update Ingress %s/%s due to error: %v", i.Namespace, i.Name, err)
+			logger.Errorf("Cannot update Ingress %s/%s due to error: %v", i.Namespace, i.Name, err)
 		}
 	}
 
+	if p.EnableCRD {
+		p.loadIngressRoutes(k8sClient, templateObjects, tlsConfigs)
+	}
+
+	if p.EnableGateway {
+		p.loadGateways(k8sClient, templateObjects, tlsConfigs)
+	}
+
 	templateObjects.TLS = getTLSConfig(tlsConfigs)
 
+	if err := p.loadEntryPointsTLS(k8sClient, templateObjects); err != nil {
+		logger.Errorf("Error configuring entrypoints TLS: %v", err)
+	}
+
 	return templateObjects, nil
 }
 
+// loadEntryPointsTLS adds the default certificate and the client CA bundle referenced by the provider configuration
+func (p *Provider) loadEntryPointsTLS(k8sClient Client, templateObjects *types.Configuration) error {
+	if p.EntryPointsTLS == nil {
+		return nil
+	}
+
+	var entryPoints []string
+	entryPoints = append(entryPoints, p.EntryPointsTLS.EntryPoints...)
+	sort.Strings(entryPoints)
+
+	if len(p.EntryPointsTLS.DefaultCertificate) > 0 {
+		secret, namespace, name, err := getSecretByReference(k8sClient, p.EntryPointsTLS.DefaultCertificate)
+		if err != nil {
+			return err
+		}
+
+		cert, key, err := getCertificateBlocks(secret, namespace, name)
+		if err != nil {
+			return err
+		}
+
+		templateObjects.TLS = append(templateObjects.TLS, &tls.Configuration{
+			EntryPoints: entryPoints,
+			Certificate: &tls.Certificate{
+				CertFile: tls.FileOrContent(cert),
+				KeyFile:  tls.FileOrContent(key),
+			},
+			Default: true,
+		})
+	}
+
+	if len(p.EntryPointsTLS.ClientCA) > 0 {
+		secret, namespace, name, err := getSecretByReference(k8sClient, p.EntryPointsTLS.ClientCA)
+		if err != nil {
+			return err
+		}
+
+		ca, exists := secret.Data["ca.crt"]
+		if !exists || len(ca) == 0 {
+			return fmt.Errorf("secret %s/%s is missing or contains an empty ca.crt data entry", namespace, name)
+		}
+
+		templateObjects.ClientCAs = append(templateObjects.ClientCAs, &tls.ClientCAConfiguration{
+			EntryPoints: entryPoints,
+			Files:       tls.FilesOrContents{tls.FileOrContent(ca)},
+		})
+	}
+
+	return nil
+}
+
+// getSecretByReference fetches a secret referenced as namespace/name
+func getSecretByReference(k8sClient Client, reference string) (*corev1.Secret, string, string, error) {
+	parts := strings.Split(reference, "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return nil, "", "", fmt.Errorf("invalid secret reference %q, expected namespace/name", reference)
+	}
+	namespace, name := parts[0], parts[1]
+
+	secret, exists, err := k8sClient.GetSecret(namespace, name)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch secret %s/%s: %v", namespace, name, err)
+	}
+	if !exists {
+		return nil, "", "", fmt.Errorf("secret %s/%s does not exist", namespace, name)
+	}
+
+	return secret, namespace, name, nil
+}
+
+// resolveReference returns the namespace and the name of a secret or a service referenced by an Ingress annotation as name or namespace/name.
+// An Ingress can only reference the resources of its namespace and of the shared namespaces.
+func (p *Provider) resolveReference(namespace, reference string) (string, string, error) {
+	parts := strings.Split(reference, "/")
+	switch {
+	case len(parts) == 1:
+		return namespace, reference, nil
+	case len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0:
+		return "", "", fmt.Errorf("invalid reference %q, expected name or namespace/name", reference)
+	case parts[0] != namespace && !p.isSharedNamespace(parts[0]):
+		return "", "", fmt.Errorf("reference %q is not allowed: the %s namespace is not shared", reference, parts[0])
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// checkNamespace checks that an Ingress of the namespace can reference the resources of the target namespace.
+func (p *Provider) checkNamespace(namespace, target string) error {
+	if target != namespace && !p.isSharedNamespace(target) {
+		return fmt.Errorf("namespace %s is not allowed: the namespace is not shared", target)
+	}
+	return nil
+}
+
+func (p *Provider) isSharedNamespace(namespace string) bool {
+	for _, shared := range p.SharedNamespaces {
+		if shared == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Provider) updateIngressStatus(i *extensionsv1beta1.Ingress, k8sClient Client) error {
 	// Only process if an IngressEndpoint has been configured
 	if p.IngressEndpoint == nil {
@@ -474,7 +648,7 @@ func (p *Provider) updateIngressStatus(i *extensionsv1beta1.Ingress, k8sClient C
 
 	if exists && service.Status.LoadBalancer.Ingress == nil {
 		// service exists, but has no Load Balancer status
-		log.Debugf("Skipping updating Ingress %s/%s due to service %s having no status set", i.Namespace, i.Name, p.IngressEndpoint.PublishedService)
+		logger.Debugf("Skipping updating Ingress %s/%s due to service %s having no status set", i.Namespace, i.Name, p.IngressEndpoint.PublishedService)
 		return nil
 	}
 
@@ -489,7 +663,7 @@ func (p *Provider) loadConfig(templateObjects types.Configuration) *types.Config
 	var FuncMap = template.FuncMap{}
 	configuration, err := p.GetConfiguration("templates/kubernetes.tmpl", FuncMap, templateObjects)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 	return configuration
 }
@@ -512,23 +686,67 @@ func (p *Provider) addGlobalBackend(cl Client, i *extensionsv1beta1.Ingress, tem
 		},
 	}
 
-	service, exists, err := cl.GetService(i.Namespace, i.Spec.Backend.ServiceName)
+	serviceNamespace := getStringValue(i.Annotations, annotationKubernetesDefaultBackendNamespace, i.Namespace)
+	if err := p.checkNamespace(i.Namespace, serviceNamespace); err != nil {
+		return err
+	}
+
+	err := loadServiceBackend(cl, templateObjects.Backends[defaultBackendName], serviceNamespace, i.Spec.Backend.ServiceName, i.Spec.Backend.ServicePort)
+	if err != nil {
+		return err
+	}
+
+	errorPages, err := p.getErrorPages(cl, i, templateObjects)
+	if err != nil {
+		return err
+	}
+
+	passHostHeader := getBoolValue(i.Annotations, annotationKubernetesPreserveHost, !p.DisablePassHostHeaders)
+	passTLSCert := getBoolValue(i.Annotations, annotationKubernetesPassTLSCert, p.EnablePassTLSCert) // Deprecated
+	priority := getIntValue(i.Annotations, annotationKubernetesPriority, 0)
+	entryPoints := getSliceStringValue(i.Annotations, annotationKubernetesFrontendEntryPoints)
+
+	templateObjects.Frontends[defaultFrontendName] = &types.Frontend{
+		Backend:           defaultBackendName,
+		PassHostHeader:    passHostHeader,
+		PassTLSCert:       passTLSCert,
+		PassTLSClientCert: getPassTLSClientCert(i),
+		Routes:            make(map[string]types.Route),
+		Priority:          priority,
+		WhiteList:         getWhiteList(i),
+		Redirect:          getFrontendRedirect(i, defaultFrontendName, "/"),
+		EntryPoints:       entryPoints,
+		Headers:           getHeader(i),
+		Errors:            errorPages,
+		RateLimit:         getRateLimit(i),
+	}
+
+	templateObjects.Frontends[defaultFrontendName].Routes["/"] = types.Route{
+		Rule: defaultFrontendRule,
+	}
+
+	return nil
+}
+
+// loadServiceBackend fills the backend with the settings and the servers of the service port.
+func loadServiceBackend(cl Client, backend *types.Backend, namespace, serviceName string, servicePort intstr.IntOrString) error {
+	service, exists, err := cl.GetService(namespace, serviceName)
 	if err != nil {
-		return fmt.Errorf("error while retrieving service information from k8s API %s/%s: %v", i.Namespace, i.Spec.Backend.ServiceName, err)
+		return fmt.Errorf("error while retrieving service information from k8s API %s/%s: %v", namespace, serviceName, err)
 	}
 	if !exists {
-		return fmt.Errorf("service not found for %s/%s", i.Namespace, i.Spec.Backend.ServiceName)
+		return fmt.Errorf("service not found for %s/%s", namespace, serviceName)
 	}
 
-	templateObjects.Backends[defaultBackendName].CircuitBreaker = getCircuitBreaker(service)
-	templateObjects.Backends[defaultBackendName].LoadBalancer = getLoadBalancer(service)
-	templateObjects.Backends[defaultBackendName].MaxConn = getMaxConn(service)
-	templateObjects.Backends[defaultBackendName].Buffering = getBuffering(service)
-	templateObjects.Backends[defaultBackendName].ResponseForwarding = getResponseForwarding(service)
+	backend.CircuitBreaker = getCircuitBreaker(service)
+	backend.LoadBalancer = getLoadBalancer(service)
+	backend.MaxConn = getMaxConn(service)
+	backend.Buffering = getBuffering(service)
+	backend.ResponseForwarding = getResponseForwarding(service)
 
 	for _, port := range service.Spec.Ports {
 
-		if !equalPorts(port, i.Spec.Backend.ServicePort) {
+		if !equalPorts(port, servicePort) {
 			continue
 		}
 
@@ -545,7 +763,20 @@ func (p *Provider) addGlobalBackend(cl Client, i *extensionsv1beta1.Ingress, tem
 				url = fmt.Sprintf("%s:%d", url, port.Port)
 			}
 
-			templateObjects.Backends[defaultBackendName].Servers[url] = types.Server{
+			backend.Servers[url] = types.Server{
+				URL:    url,
+				Weight: label.DefaultWeight,
+			}
+
+		} else if useClusterIP(service) {
+
+			protocol := "http"
+			if port.Port == 443 || strings.HasPrefix(port.Name, "https") {
+				protocol = "https"
+			}
+
+			url := getClusterIPURL(protocol, service, port)
+			backend.Servers[url] = types.Server{
 				URL:    url,
 				Weight: label.DefaultWeight,
 			}
@@ -573,7 +804,7 @@ func (p *Provider) addGlobalBackend(cl Client, i *extensionsv1beta1.Ingress, tem
 
 				protocol := "http"
 				for _, address := range subset.Addresses {
-					if endpointPort == 443 || strings.HasPrefix(i.Spec.Backend.ServicePort.String(), "https") {
+					if endpointPort == 443 || strings.HasPrefix(servicePort.String(), "https") {
 						protocol = "https"
 					}
 
@@ -583,7 +814,7 @@ func (p *Provider) addGlobalBackend(cl Client, i *extensionsv1beta1.Ingress, tem
 						name = address.TargetRef.Name
 					}
 
-					templateObjects.Backends[defaultBackendName].Servers[name] = types.Server{
+					backend.Servers[name] = types.Server{
 						URL:    url,
 						Weight: label.DefaultWeight,
 					}
@@ -592,30 +823,6 @@ func (p *Provider) addGlobalBackend(cl Client, i *extensionsv1beta1.Ingress, tem
 		}
 	}
 
-	passHostHeader := getBoolValue(i.Annotations, annotationKubernetesPreserveHost, !p.DisablePassHostHeaders)
-	passTLSCert := getBoolValue(i.Annotations, annotationKubernetesPassTLSCert, p.EnablePassTLSCert) // Deprecated
-	priority := getIntValue(i.Annotations, annotationKubernetesPriority, 0)
-	entryPoints := getSliceStringValue(i.Annotations, annotationKubernetesFrontendEntryPoints)
-
-	templateObjects.Frontends[defaultFrontendName] = &types.Frontend{
-		Backend:           defaultBackendName,
-		PassHostHeader:    passHostHeader,
-		PassTLSCert:       passTLSCert,
-		PassTLSClientCert: getPassTLSClientCert(i),
-		Routes:            make(map[string]types.Route),
-		Priority:          priority,
-		WhiteList:         getWhiteList(i),
-		Redirect:          getFrontendRedirect(i, defaultFrontendName, "/"),
-		EntryPoints:       entryPoints,
-		Headers:           getHeader(i),
-		Errors:            getErrorPages(i),
-		RateLimit:         getRateLimit(i),
-	}
-
-	templateObjects.Frontends[defaultFrontendName].Routes["/"] = types.Route{
-		Rule: defaultFrontendRule,
-	}
-
 	return nil
 }
 
@@ -643,7 +850,7 @@ func throttleEvents(throttleDuration time.Duration, stop chan bool, eventsChan <
 					// do a refresh as soon as our throttle allows us to. It's fine
 					// to drop the event and keep whatever's in the buffer -- we
 					// don't do different things for different events
-					log.Debugf("Dropping event kind %T due to throttling", nextEvent)
+					logger.Debugf("Dropping event kind %T due to throttling", nextEvent)
 				}
 			}
 		}
@@ -652,22 +859,53 @@ func throttleEvents(throttleDuration time.Duration, stop chan bool, eventsChan <
 	return eventsChanBuffered
 }
 
-func getRuleForPath(pa extensionsv1beta1.HTTPIngressPath, i *extensionsv1beta1.Ingress) (string, error) {
+// getRuleForPath returns the rule matching a path. The Exact and Prefix path types match with a Path rule,
+// while the ImplementationSpecific path type, or no path type at all, uses the rule type annotation.
+func getRuleForPath(pa extensionsv1beta1.HTTPIngressPath, i *extensionsv1beta1.Ingress, pathType string) (string, error) {
 	if len(pa.Path) == 0 {
 		return "", nil
 	}
 
 	ruleType := getStringValue(i.Annotations, annotationKubernetesRuleType, ruleTypePathPrefix)
 
+	switch networkingv1.PathType(pathType) {
+	case networkingv1.PathTypeExact, networkingv1.PathTypePrefix:
+		if _, ok := i.Annotations[getAnnotationName(i.Annotations, annotationKubernetesRuleType)]; ok {
+			logger.Warnf("Ignoring annotation %q on ingress %s/%s for path %s of type %s, use the %s path type instead",
+				annotationKubernetesRuleType, i.Namespace, i.Name, pa.Path, pathType, networkingv1.PathTypeImplementationSpecific)
+		}
+
+		ruleType = ruleTypePath
+		if networkingv1.PathType(pathType) == networkingv1.PathTypePrefix {
+			ruleType = ruleTypePathPrefix
+		}
+	}
+
+	rule := ruleType + ":" + pa.Path
+	switch networkingv1.PathType(pathType) {
+	case networkingv1.PathTypeExact:
+		var err error
+		rule, err = getRuleForExactPath(pa.Path)
+		if err != nil {
+			return "", err
+		}
+	case networkingv1.PathTypePrefix:
+		var err error
+		rule, err = getRuleForPathPrefix(pa.Path)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	switch ruleType {
 	case ruleTypePath, ruleTypePathPrefix, ruleTypePathStrip, ruleTypePathPrefixStrip:
 	case ruleTypeReplacePath:
-		log.Warnf("Using %s as %s will be deprecated in the future. Please use the %s annotation instead", ruleType, annotationKubernetesRuleType, annotationKubernetesRequestModifier)
+		logger.Warnf("Using %s as %s will be deprecated in the future. Please use the %s annotation instead", ruleType, annotationKubernetesRuleType, annotationKubernetesRequestModifier)
 	default:
 		return "", fmt.Errorf("cannot use non-matcher rule: %q", ruleType)
 	}
 
-	rules := []string{ruleType + ":" + pa.Path}
+	rules := []string{rule}
 
 	if rewriteTarget := getStringValue(i.Annotations, annotationKubernetesRewriteTarget, ""); rewriteTarget != "" {
 		if ruleType == ruleTypeReplacePath {
@@ -689,6 +927,37 @@ func getRuleForPath(pa extensionsv1beta1.HTTPIngressPath, i *extensionsv1beta1.I
 	return strings.Join(rules, ";"), nil
 }
 
+// getRuleForExactPath returns the rule matching exactly a path, as the Exact path type does.
+func getRuleForExactPath(path string) (string, error) {
+	if err := checkLiteralPath(path); err != nil {
+		return "", err
+	}
+	return ruleTypePath + ":" + path, nil
+}
+
+// getRuleForPathPrefix returns the rule matching a path prefix element-wise, as the Prefix path type does:
+// the /foo prefix matches /foo and the paths below /foo/, but not /foobar.
+func getRuleForPathPrefix(path string) (string, error) {
+	if err := checkLiteralPath(path); err != nil {
+		return "", err
+	}
+
+	path = strings.TrimRight(path, "/")
+	if path == "" {
+		return ruleTypePathPrefix + ":/", nil
+	}
+	return ruleTypePath + ":" + path + "{pathPrefixSuffix:(?:/.*)?}", nil
+}
+
+// checkLiteralPath checks that a path is matched literally by a Path rule:
+// the braces declare regex patterns, and the commas and semicolons separate the paths and the rules.
+func checkLiteralPath(path string) error {
+	if strings.ContainsAny(path, "{},;") {
+		return fmt.Errorf("invalid path %q: the characters %q are not allowed", path, "{},;")
+	}
+	return nil
+}
+
 func parseRequestModifier(requestModifier, ruleType string) (string, error) {
 	trimmedRequestModifier := strings.TrimRight(requestModifier, " :")
 	if trimmedRequestModifier == "" {
@@ -726,30 +995,35 @@ func getRuleForHost(host string) string {
 	return "Host:" + host
 }
 
-func getTLS(ingress *extensionsv1beta1.Ingress, k8sClient Client, tlsConfigs map[string]*tls.Configuration) error {
+func (p *Provider) getTLS(ingress *extensionsv1beta1.Ingress, k8sClient Client, tlsConfigs map[string]*tls.Configuration) error {
 	for _, t := range ingress.Spec.TLS {
 		if t.SecretName == "" {
-			log.Debugf("Skipping TLS sub-section for ingress %s/%s: No secret name provided", ingress.Namespace, ingress.Name)
+			logger.Debugf("Skipping TLS sub-section for ingress %s/%s: No secret name provided", ingress.Namespace, ingress.Name)
 			continue
 		}
 
 		newEntryPoints := getSliceStringValue(ingress.Annotations, annotationKubernetesFrontendEntryPoints)
 
-		configKey := ingress.Namespace + "/" + t.SecretName
+		namespace, secretName, err := p.resolveReference(ingress.Namespace, t.SecretName)
+		if err != nil {
+			return err
+		}
+
+		configKey := namespace + "/" + secretName
 		if tlsConfig, tlsExists := tlsConfigs[configKey]; tlsExists {
 			for _, entryPoint := range newEntryPoints {
 				tlsConfig.EntryPoints = mergeEntryPoint(tlsConfig.EntryPoints, entryPoint)
 			}
 		} else {
-			secret, exists, err := k8sClient.GetSecret(ingress.Namespace, t.SecretName)
+			secret, exists, err := k8sClient.GetSecret(namespace, secretName)
 			if err != nil {
-				return fmt.Errorf("failed to fetch secret %s/%s: %v", ingress.Namespace, t.SecretName, err)
+				return fmt.Errorf("failed to fetch secret %s/%s: %v", namespace, secretName, err)
 			}
 			if !exists {
-				return fmt.Errorf("secret %s/%s does not exist", ingress.Namespace, t.SecretName)
+				return fmt.Errorf("secret %s/%s does not exist", namespace, secretName)
 			}
 
-			cert, key, err := getCertificateBlocks(secret, ingress.Namespace, t.SecretName)
+			cert, key, err := getCertificateBlocks(secret, namespace, secretName)
 			if err != nil {
 				return err
 			}
@@ -851,6 +1125,24 @@ func endpointPortNumber(servicePort corev1.ServicePort, endpointPorts []corev1.E
 	return 0
 }
 
+// useClusterIP tells whether the requests are forwarded to the cluster IP of a Service rather than to its endpoints,
+// leaving the load balancing to kube-proxy or to a service mesh.
+func useClusterIP(service *corev1.Service) bool {
+	if !getBoolValue(service.Annotations, annotationKubernetesServiceUpstream, false) {
+		return false
+	}
+
+	if service.Spec.ClusterIP == "" || service.Spec.ClusterIP == corev1.ClusterIPNone {
+		logger.Warnf("Service %s/%s does not have a cluster IP, forwarding the requests to its endpoints", service.Namespace, service.Name)
+		return false
+	}
+	return true
+}
+
+func getClusterIPURL(protocol string, service *corev1.Service, port corev1.ServicePort) string {
+	return protocol + "://" + net.JoinHostPort(service.Spec.ClusterIP, strconv.FormatInt(int64(port.Port), 10))
+}
+
 func equalPorts(servicePort corev1.ServicePort, ingressPort intstr.IntOrString) bool {
 	if int(servicePort.Port) == ingressPort.IntValue() {
 		return true
@@ -861,14 +1153,35 @@ func equalPorts(servicePort corev1.ServicePort, ingressPort intstr.IntOrString)
 	return false
 }
 
-func (p *Provider) shouldProcessIngress(annotationIngressClass string) bool {
+// shouldProcessIngress tells whether an Ingress is handled by Traefik.
+// The ingress class annotation takes precedence over the spec.ingressClassName field, which must reference
+// an IngressClass of the Traefik controller. An Ingress without class belongs to the default IngressClass, if any.
+func (p *Provider) shouldProcessIngress(annotationIngressClass, ingressClassName string, ingressClasses []*networkingv1.IngressClass) bool {
+	if len(annotationIngressClass) == 0 {
+		var class *networkingv1.IngressClass
+		for _, c := range ingressClasses {
+			if (len(ingressClassName) > 0 && c.Name == ingressClassName) ||
+				(len(ingressClassName) == 0 && c.Annotations[annotationDefaultClass] == "true") {
+				class = c
+				break
+			}
+		}
+
+		if class != nil {
+			return class.Spec.Controller == traefikIngressController && (len(p.IngressClass) == 0 || class.Name == p.IngressClass)
+		}
+		if len(ingressClassName) > 0 {
+			return false
+		}
+	}
+
 	if len(p.IngressClass) == 0 {
 		return len(annotationIngressClass) == 0 || annotationIngressClass == traefikDefaultIngressClass
 	}
 	return annotationIngressClass == p.IngressClass
 }
 
-func getAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Auth, error) {
+func (p *Provider) getAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Auth, error) {
 	authType := getStringValue(i.Annotations, annotationKubernetesAuthType, "")
 	if len(authType) == 0 {
 		return nil, nil
@@ -880,21 +1193,21 @@ func getAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Auth,
 
 	switch strings.ToLower(authType) {
 	case "basic":
-		basic, err := getBasicAuthConfig(i, k8sClient)
+		basic, err := p.getBasicAuthConfig(i, k8sClient)
 		if err != nil {
 			return nil, err
 		}
 
 		auth.Basic = basic
 	case "digest":
-		digest, err := getDigestAuthConfig(i, k8sClient)
+		digest, err := p.getDigestAuthConfig(i, k8sClient)
 		if err != nil {
 			return nil, err
 		}
 
 		auth.Digest = digest
 	case "forward":
-		forward, err := getForwardAuthConfig(i, k8sClient)
+		forward, err := p.getForwardAuthConfig(i, k8sClient)
 		if err != nil {
 			return nil, err
 		}
@@ -907,8 +1220,8 @@ func getAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Auth,
 	return auth, nil
 }
 
-func getBasicAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Basic, error) {
-	credentials, err := getAuthCredentials(i, k8sClient)
+func (p *Provider) getBasicAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Basic, error) {
+	credentials, err := p.getAuthCredentials(i, k8sClient)
 	if err != nil {
 		return nil, err
 	}
@@ -919,8 +1232,8 @@ func getBasicAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.
 	}, nil
 }
 
-func getDigestAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Digest, error) {
-	credentials, err := getAuthCredentials(i, k8sClient)
+func (p *Provider) getDigestAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Digest, error) {
+	credentials, err := p.getAuthCredentials(i, k8sClient)
 	if err != nil {
 		return nil, err
 	}
@@ -930,13 +1243,18 @@ func getDigestAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types
 	}, nil
 }
 
-func getAuthCredentials(i *extensionsv1beta1.Ingress, k8sClient Client) ([]string, error) {
+func (p *Provider) getAuthCredentials(i *extensionsv1beta1.Ingress, k8sClient Client) ([]string, error) {
 	authSecret := getStringValue(i.Annotations, annotationKubernetesAuthSecret, "")
 	if authSecret == "" {
 		return nil, fmt.Errorf("auth-secret annotation %s must be set", annotationKubernetesAuthSecret)
 	}
 
-	auth, err := loadAuthCredentials(i.Namespace, authSecret, k8sClient)
+	namespace, secretName, err := p.resolveReference(i.Namespace, authSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := loadAuthCredentials(namespace, secretName, k8sClient)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load auth credentials: %s", err)
 	}
@@ -980,7 +1298,7 @@ func loadAuthCredentials(namespace, secretName string, k8sClient Client) ([]stri
 	return credentials, nil
 }
 
-func getForwardAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Forward, error) {
+func (p *Provider) getForwardAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*types.Forward, error) {
 	authURL := getStringValue(i.Annotations, annotationKubernetesAuthForwardURL, "")
 	if len(authURL) == 0 {
 		return nil, fmt.Errorf("forward authentication requires a url")
@@ -992,8 +1310,16 @@ func getForwardAuthConfig(i *extensionsv1beta1.Ingress, k8sClient Client) (*type
 		AuthResponseHeaders: getSliceStringValue(i.Annotations, annotationKubernetesAuthForwardResponseHeaders),
 	}
 
-	authSecretName := getStringValue(i.Annotations, annotationKubernetesAuthForwardTLSSecret, "")
-	authSecretCert, authSecretKey, err := loadAuthTLSSecret(i.Namespace, authSecretName, k8sClient)
+	namespace, authSecretName := i.Namespace, getStringValue(i.Annotations, annotationKubernetesAuthForwardTLSSecret, "")
+	if len(authSecretName) > 0 {
+		var err error
+		namespace, authSecretName, err = p.resolveReference(i.Namespace, authSecretName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	authSecretCert, authSecretKey, err := loadAuthTLSSecret(namespace, authSecretName, k8sClient)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load auth secret: %s", err)
 	}
@@ -1062,13 +1388,13 @@ func getFrontendRedirect(i *extensionsv1beta1.Ingress, baseName, path string) *t
 
 	redirectRegex, err := getStringSafeValue(i.Annotations, annotationKubernetesRedirectRegex, "")
 	if err != nil {
-		log.Debugf("Skipping Redirect on Ingress %s/%s due to invalid regex: %s", i.Namespace, i.Name, redirectRegex)
+		logger.Debugf("Skipping Redirect on Ingress %s/%s due to invalid regex: %s", i.Namespace, i.Name, redirectRegex)
 		return nil
 	}
 
 	redirectReplacement, err := getStringSafeValue(i.Annotations, annotationKubernetesRedirectReplacement, "")
 	if err != nil {
-		log.Debugf("Skipping Redirect on Ingress %s/%s due to invalid replacement: %q", i.Namespace, i.Name, redirectRegex)
+		logger.Debugf("Skipping Redirect on Ingress %s/%s due to invalid replacement: %q", i.Namespace, i.Name, redirectRegex)
 		return nil
 	}
 
@@ -1115,7 +1441,7 @@ func getBuffering(service *corev1.Service) *types.Buffering {
 		buffering = &types.Buffering{}
 		err := yaml.Unmarshal([]byte(bufferingRaw), buffering)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 			return nil
 		}
 	}
@@ -1133,7 +1459,7 @@ func getLoadBalancer(service *corev1.Service) *types.LoadBalancer {
 	}
 
 	if sticky := service.Annotations[label.TraefikBackendLoadBalancerSticky]; len(sticky) > 0 {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, annotationKubernetesAffinity)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, annotationKubernetesAffinity)
 		loadBalancer.Sticky = strings.EqualFold(strings.TrimSpace(sticky), "true")
 	}
 
@@ -1209,20 +1535,71 @@ func getCircuitBreaker(service *corev1.Service) *types.CircuitBreaker {
 	return nil
 }
 
-func getErrorPages(i *extensionsv1beta1.Ingress) map[string]*types.ErrorPage {
-	var errorPages map[string]*types.ErrorPage
+// ingressErrorPage is an error page of the error-pages annotation.
+// The backend of the page is either a backend of the configuration or a service referenced as name or namespace/name.
+type ingressErrorPage struct {
+	Status      []string `yaml:"status"`
+	Backend     string   `yaml:"backend"`
+	Query       string   `yaml:"query"`
+	Service     string   `yaml:"service"`
+	ServicePort string   `yaml:"servicePort"`
+}
 
+func (p *Provider) getErrorPages(cl Client, i *extensionsv1beta1.Ingress, templateObjects *types.Configuration) (map[string]*types.ErrorPage, error) {
 	pagesRaw := getStringValue(i.Annotations, annotationKubernetesErrorPages, "")
-	if len(pagesRaw) > 0 {
-		errorPages = make(map[string]*types.ErrorPage)
-		err := yaml.Unmarshal([]byte(pagesRaw), errorPages)
-		if err != nil {
-			log.Error(err)
-			return nil
+	if len(pagesRaw) == 0 {
+		return nil, nil
+	}
+
+	pages := make(map[string]*ingressErrorPage)
+	err := yaml.Unmarshal([]byte(pagesRaw), pages)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil
+	}
+
+	errorPages := make(map[string]*types.ErrorPage, len(pages))
+	for pageName, page := range pages {
+		backendName := page.Backend
+
+		if len(page.Service) > 0 {
+			if len(page.Backend) > 0 {
+				return nil, fmt.Errorf("error page %s: backend and service cannot be both set", pageName)
+			}
+
+			namespace, serviceName, err := p.resolveReference(i.Namespace, page.Service)
+			if err != nil {
+				return nil, fmt.Errorf("error page %s: %v", pageName, err)
+			}
+
+			servicePort := intstr.Parse(page.ServicePort)
+			backendName = fmt.Sprintf("error-page-%s-%s-%s", namespace, serviceName, servicePort.String())
+
+			if _, exists := templateObjects.Backends[backendName]; !exists {
+				backend := &types.Backend{
+					Servers: make(map[string]types.Server),
+					LoadBalancer: &types.LoadBalancer{
+						Method: "wrr",
+					},
+				}
+
+				err = loadServiceBackend(cl, backend, namespace, serviceName, servicePort)
+				if err != nil {
+					return nil, fmt.Errorf("error page %s: %v", pageName, err)
+				}
+
+				templateObjects.Backends[backendName] = backend
+			}
+		}
+
+		errorPages[pageName] = &types.ErrorPage{
+			Status:  page.Status,
+			Backend: backendName,
+			Query:   page.Query,
 		}
 	}
 
-	return errorPages
+	return errorPages, nil
 }
 
 func getRateLimit(i *extensionsv1beta1.Ingress) *types.RateLimit {
@@ -1233,7 +1610,7 @@ func getRateLimit(i *extensionsv1beta1.Ingress) *types.RateLimit {
 		rateLimit = &types.RateLimit{}
 		err := yaml.Unmarshal([]byte(rateRaw), rateLimit)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 			return nil
 		}
 	}
@@ -1249,7 +1626,7 @@ func getPassTLSClientCert(i *extensionsv1beta1.Ingress) *types.TLSClientHeaders
 		passTLSClientCert = &types.TLSClientHeaders{}
 		err := yaml.Unmarshal([]byte(passRaw), passTLSClientCert)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 			return nil
 		}
 	}
@@ -0,0 +1,487 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containous/traefik/provider/kubernetes/crd/traefik/v1alpha1"
+	"github.com/containous/traefik/provider/label"
+	"github.com/containous/traefik/rules"
+	"github.com/containous/traefik/tls"
+	"github.com/containous/traefik/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// serviceWeightScale scales the weights of the services of a route before sharing them among their servers
+const serviceWeightScale = 1000
+
+// loadIngressRoutes adds the frontends and the backends of the IngressRoutes,
+// the certificates of their domains, and the TLS options of their domains
+func (p *Provider) loadIngressRoutes(k8sClient Client, templateObjects *types.Configuration, tlsConfigs map[string]*tls.Configuration) {
+	ingressRoutes := k8sClient.GetIngressRoutes()
+	sort.Slice(ingressRoutes, func(i, j int) bool {
+		if ingressRoutes[i].Namespace == ingressRoutes[j].Namespace {
+			return ingressRoutes[i].Name < ingressRoutes[j].Name
+		}
+		return ingressRoutes[i].Namespace < ingressRoutes[j].Namespace
+	})
+
+	// the TLSOption configuring each domain, a domain can only be configured by one TLSOption
+	domainsTLSOption := make(map[string]string)
+
+	for _, ingressRoute := range ingressRoutes {
+		ingressClass, err := getStringSafeValue(ingressRoute.Annotations, annotationKubernetesIngressClass, "")
+		if err != nil {
+			logger.Errorf("Misconfigured ingress class for ingress route %s/%s: %v", ingressRoute.Namespace, ingressRoute.Name, err)
+			continue
+		}
+
+		if !p.shouldProcessIngress(ingressClass, "", nil) {
+			continue
+		}
+
+		if err := getIngressRouteTLS(ingressRoute, k8sClient, tlsConfigs); err != nil {
+			logger.Errorf("Error configuring TLS for ingress route %s/%s: %v", ingressRoute.Namespace, ingressRoute.Name, err)
+			continue
+		}
+
+		var domains []string
+		for _, route := range ingressRoute.Spec.Routes {
+			name := makeIngressRouteName(ingressRoute, route)
+
+			if _, exists := templateObjects.Frontends[name]; exists {
+				logger.Errorf("Duplicate route %q in ingress route %s/%s", route.Match, ingressRoute.Namespace, ingressRoute.Name)
+				continue
+			}
+
+			routeDomains, err := checkRouteMatch(route.Match)
+			if err != nil {
+				logger.Errorf("Invalid match %q in ingress route %s/%s: %v", route.Match, ingressRoute.Namespace, ingressRoute.Name, err)
+				continue
+			}
+
+			frontend, err := p.buildIngressRouteFrontend(k8sClient, ingressRoute, route, name)
+			if err != nil {
+				logger.Errorf("Error configuring route %q of ingress route %s/%s: %v", route.Match, ingressRoute.Namespace, ingressRoute.Name, err)
+				continue
+			}
+
+			backend, err := buildIngressRouteBackend(k8sClient, ingressRoute.Namespace, route)
+			if err != nil {
+				logger.Errorf("Error configuring the services of route %q of ingress route %s/%s: %v", route.Match, ingressRoute.Namespace, ingressRoute.Name, err)
+				continue
+			}
+
+			templateObjects.Frontends[name] = frontend
+			templateObjects.Backends[name] = backend
+			domains = append(domains, routeDomains...)
+		}
+
+		if ingressRoute.Spec.TLS == nil || ingressRoute.Spec.TLS.Options == nil {
+			continue
+		}
+
+		domainsOptions, err := getIngressRouteTLSOptions(ingressRoute, k8sClient, domains, domainsTLSOption)
+		if err != nil {
+			logger.Errorf("Error configuring TLS options for ingress route %s/%s: %v", ingressRoute.Namespace, ingressRoute.Name, err)
+			continue
+		}
+
+		if domainsOptions != nil {
+			templateObjects.TLSOptions = append(templateObjects.TLSOptions, domainsOptions)
+		}
+	}
+}
+
+// makeIngressRouteName returns the name of the frontend and of the backend of an IngressRoute route,
+// which does not depend on the position of the route
+func makeIngressRouteName(ingressRoute *v1alpha1.IngressRoute, route v1alpha1.Route) string {
+	hash := sha256.Sum256([]byte(route.Match))
+	return fmt.Sprintf("%s-%s-%.10x", ingressRoute.Namespace, ingressRoute.Name, hash)
+}
+
+// checkRouteMatch validates a route match and returns its domains
+func checkRouteMatch(match string) ([]string, error) {
+	if err := templateSafeString(match); err != nil {
+		return nil, err
+	}
+
+	rls := &rules.Rules{}
+	return rls.ParseDomains(match)
+}
+
+func (p *Provider) buildIngressRouteFrontend(k8sClient Client, ingressRoute *v1alpha1.IngressRoute, route v1alpha1.Route, name string) (*types.Frontend, error) {
+	frontend := &types.Frontend{
+		Backend:        name,
+		EntryPoints:    ingressRoute.Spec.EntryPoints,
+		PassHostHeader: !p.DisablePassHostHeaders,
+		Priority:       route.Priority,
+		Routes: map[string]types.Route{
+			"match": {Rule: route.Match},
+		},
+	}
+
+	for _, ref := range route.Middlewares {
+		middleware, exists, err := k8sClient.GetMiddleware(ingressRoute.Namespace, ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch middleware %s/%s: %v", ingressRoute.Namespace, ref.Name, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("middleware %s/%s does not exist", ingressRoute.Namespace, ref.Name)
+		}
+
+		if err := applyMiddleware(k8sClient, frontend, middleware); err != nil {
+			return nil, fmt.Errorf("invalid middleware %s/%s: %v", ingressRoute.Namespace, ref.Name, err)
+		}
+	}
+
+	return frontend, nil
+}
+
+// applyMiddleware applies the settings of a middleware to a frontend.
+// The settings already applied by another middleware of the route cannot be overridden.
+func applyMiddleware(k8sClient Client, frontend *types.Frontend, middleware *v1alpha1.Middleware) error {
+	spec := middleware.Spec
+
+	if spec.Headers != nil {
+		if frontend.Headers != nil {
+			return errors.New("headers already configured by another middleware")
+		}
+		frontend.Headers = spec.Headers
+	}
+
+	if spec.Redirect != nil {
+		if frontend.Redirect != nil {
+			return errors.New("redirect already configured by another middleware")
+		}
+		if len(spec.Redirect.EntryPoint) == 0 && (len(spec.Redirect.Regex) == 0 || len(spec.Redirect.Replacement) == 0) {
+			return errors.New("redirect requires an entry point, or a regex and a replacement")
+		}
+		if err := templateSafeString(spec.Redirect.Regex); err != nil {
+			return fmt.Errorf("invalid redirect regex %q: %v", spec.Redirect.Regex, err)
+		}
+		if err := templateSafeString(spec.Redirect.Replacement); err != nil {
+			return fmt.Errorf("invalid redirect replacement %q: %v", spec.Redirect.Replacement, err)
+		}
+		frontend.Redirect = spec.Redirect
+	}
+
+	if spec.WhiteList != nil {
+		if frontend.WhiteList != nil {
+			return errors.New("white list already configured by another middleware")
+		}
+		frontend.WhiteList = spec.WhiteList
+	}
+
+	if spec.RateLimit != nil {
+		if frontend.RateLimit != nil {
+			return errors.New("rate limit already configured by another middleware")
+		}
+		if len(spec.RateLimit.ExtractorFunc) == 0 || len(spec.RateLimit.RateSet) == 0 {
+			return errors.New("rate limit requires an extractor function and at least one rate")
+		}
+		frontend.RateLimit = spec.RateLimit
+	}
+
+	if spec.PassTLSClientCert != nil {
+		if frontend.PassTLSClientCert != nil {
+			return errors.New("TLS client certificate forwarding already configured by another middleware")
+		}
+		frontend.PassTLSClientCert = spec.PassTLSClientCert
+	}
+
+	if spec.BasicAuth != nil || spec.ForwardAuth != nil {
+		if frontend.Auth != nil {
+			return errors.New("authentication already configured by another middleware")
+		}
+
+		auth, err := getMiddlewareAuth(k8sClient, middleware)
+		if err != nil {
+			return err
+		}
+		frontend.Auth = auth
+	}
+
+	return nil
+}
+
+func getMiddlewareAuth(k8sClient Client, middleware *v1alpha1.Middleware) (*types.Auth, error) {
+	spec := middleware.Spec
+	if spec.BasicAuth != nil && spec.ForwardAuth != nil {
+		return nil, errors.New("basic and forward authentications cannot be configured together")
+	}
+
+	if spec.BasicAuth != nil {
+		if len(spec.BasicAuth.Secret) == 0 {
+			return nil, errors.New("basic authentication requires a secret")
+		}
+
+		users, err := loadAuthCredentials(middleware.Namespace, spec.BasicAuth.Secret, k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auth credentials: %v", err)
+		}
+
+		return &types.Auth{
+			HeaderField: spec.BasicAuth.HeaderField,
+			Basic: &types.Basic{
+				Users:        users,
+				RemoveHeader: spec.BasicAuth.RemoveHeader,
+			},
+		}, nil
+	}
+
+	if len(spec.ForwardAuth.Address) == 0 {
+		return nil, errors.New("forward authentication requires an address")
+	}
+
+	forwardAuth := &types.Forward{
+		Address:             spec.ForwardAuth.Address,
+		TrustForwardHeader:  spec.ForwardAuth.TrustForwardHeader,
+		AuthResponseHeaders: spec.ForwardAuth.AuthResponseHeaders,
+	}
+
+	cert, key, err := loadAuthTLSSecret(middleware.Namespace, spec.ForwardAuth.TLSSecret, k8sClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth secret: %v", err)
+	}
+
+	if len(cert) > 0 || spec.ForwardAuth.InsecureSkipVerify {
+		forwardAuth.TLS = &types.ClientTLS{
+			Cert:               cert,
+			Key:                key,
+			InsecureSkipVerify: spec.ForwardAuth.InsecureSkipVerify,
+		}
+	}
+
+	return &types.Auth{Forward: forwardAuth}, nil
+}
+
+// buildIngressRouteBackend builds the backend of a route, sharing the requests among the services
+// according to their weights
+func buildIngressRouteBackend(k8sClient Client, namespace string, route v1alpha1.Route) (*types.Backend, error) {
+	if len(route.Services) == 0 {
+		return nil, errors.New("no service defined")
+	}
+
+	backend := &types.Backend{
+		Servers: make(map[string]types.Server),
+		LoadBalancer: &types.LoadBalancer{
+			Method: "wrr",
+		},
+	}
+
+	for _, svc := range route.Services {
+		if svc.Weight < 0 {
+			return nil, fmt.Errorf("invalid weight %d for service %s", svc.Weight, svc.Name)
+		}
+
+		weight := svc.Weight
+		if weight == 0 {
+			weight = label.DefaultWeight
+		}
+
+		urls, err := loadServiceURLs(k8sClient, namespace, svc)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, url := range urls {
+			serverWeight := label.DefaultWeight
+			// with several services, the weight of a service is shared among its servers
+			if len(route.Services) > 1 {
+				serverWeight = weight * serviceWeightScale / len(urls)
+			}
+
+			backend.Servers[url] = types.Server{
+				URL:    url,
+				Weight: serverWeight,
+			}
+		}
+	}
+
+	return backend, nil
+}
+
+// loadServiceURLs returns the URLs of the servers of a service
+func loadServiceURLs(k8sClient Client, namespace string, svc v1alpha1.Service) ([]string, error) {
+	service, exists, err := k8sClient.GetService(namespace, svc.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service %s/%s: %v", namespace, svc.Name, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("service %s/%s does not exist", namespace, svc.Name)
+	}
+
+	var servicePort *corev1.ServicePort
+	for i, port := range service.Spec.Ports {
+		if port.Port == svc.Port {
+			servicePort = &service.Spec.Ports[i]
+			break
+		}
+	}
+	if servicePort == nil && service.Spec.Type != corev1.ServiceTypeExternalName {
+		return nil, fmt.Errorf("port %d not found in service %s/%s", svc.Port, namespace, svc.Name)
+	}
+
+	protocol := svc.Scheme
+	if len(protocol) == 0 {
+		protocol = label.DefaultProtocol
+		if svc.Port == 443 || servicePort != nil && strings.HasPrefix(servicePort.Name, "https") {
+			protocol = allowedProtocolHTTPS
+		}
+	}
+
+	switch protocol {
+	case label.DefaultProtocol, allowedProtocolHTTPS, allowedProtocolH2C:
+	default:
+		return nil, fmt.Errorf("invalid scheme %q for service %s/%s", protocol, namespace, svc.Name)
+	}
+
+	// ExternalName services do not have any endpoints
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		url := protocol + "://" + service.Spec.ExternalName
+		if svc.Port != 443 && svc.Port != 80 {
+			url = fmt.Sprintf("%s:%d", url, svc.Port)
+		}
+		return []string{url}, nil
+	}
+
+	if useClusterIP(service) {
+		return []string{getClusterIPURL(protocol, service, *servicePort)}, nil
+	}
+
+	endpoints, exists, err := k8sClient.GetEndpoints(namespace, svc.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch endpoints %s/%s: %v", namespace, svc.Name, err)
+	}
+
+	if !exists || len(endpoints.Subsets) == 0 {
+		logger.Warnf("Endpoints not available for %s/%s", namespace, svc.Name)
+		return nil, nil
+	}
+
+	var urls []string
+	for _, subset := range endpoints.Subsets {
+		endpointPort := endpointPortNumber(*servicePort, subset.Ports)
+		if endpointPort == 0 {
+			// endpoint port does not match service.
+			continue
+		}
+
+		for _, address := range subset.Addresses {
+			urls = append(urls, protocol+"://"+net.JoinHostPort(address.IP, strconv.FormatInt(int64(endpointPort), 10)))
+		}
+	}
+
+	return urls, nil
+}
+
+// getIngressRouteTLS adds the certificate of an IngressRoute to tlsConfigs
+func getIngressRouteTLS(ingressRoute *v1alpha1.IngressRoute, k8sClient Client, tlsConfigs map[string]*tls.Configuration) error {
+	if ingressRoute.Spec.TLS == nil || len(ingressRoute.Spec.TLS.SecretName) == 0 {
+		return nil
+	}
+
+	configKey := ingressRoute.Namespace + "/" + ingressRoute.Spec.TLS.SecretName
+	if tlsConfig, exists := tlsConfigs[configKey]; exists {
+		for _, entryPoint := range ingressRoute.Spec.EntryPoints {
+			tlsConfig.EntryPoints = mergeEntryPoint(tlsConfig.EntryPoints, entryPoint)
+		}
+		return nil
+	}
+
+	secret, exists, err := k8sClient.GetSecret(ingressRoute.Namespace, ingressRoute.Spec.TLS.SecretName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch secret %s: %v", configKey, err)
+	}
+	if !exists {
+		return fmt.Errorf("secret %s does not exist", configKey)
+	}
+
+	cert, key, err := getCertificateBlocks(secret, ingressRoute.Namespace, ingressRoute.Spec.TLS.SecretName)
+	if err != nil {
+		return err
+	}
+
+	var entryPoints []string
+	entryPoints = append(entryPoints, ingressRoute.Spec.EntryPoints...)
+	sort.Strings(entryPoints)
+
+	tlsConfigs[configKey] = &tls.Configuration{
+		EntryPoints: entryPoints,
+		Certificate: &tls.Certificate{
+			CertFile: tls.FileOrContent(cert),
+			KeyFile:  tls.FileOrContent(key),
+		},
+	}
+	return nil
+}
+
+// getIngressRouteTLSOptions returns the TLS options of the domains of an IngressRoute.
+// The domains already configured by another TLSOption are skipped.
+func getIngressRouteTLSOptions(ingressRoute *v1alpha1.IngressRoute, k8sClient Client, domains []string, domainsTLSOption map[string]string) (*tls.DomainsOptions, error) {
+	name := ingressRoute.Spec.TLS.Options.Name
+	optionKey := ingressRoute.Namespace + "/" + name
+
+	if len(domains) == 0 {
+		return nil, errors.New("TLS options require the routes to match Host rules")
+	}
+
+	tlsOption, exists, err := k8sClient.GetTLSOption(ingressRoute.Namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch TLS option %s: %v", optionKey, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("TLS option %s does not exist", optionKey)
+	}
+
+	domainsOptions := &tls.DomainsOptions{
+		EntryPoints:  ingressRoute.Spec.EntryPoints,
+		MinVersion:   tlsOption.Spec.MinVersion,
+		MaxVersion:   tlsOption.Spec.MaxVersion,
+		CipherSuites: tlsOption.Spec.CipherSuites,
+	}
+
+	if tlsOption.Spec.ClientCA != nil {
+		for _, secretName := range tlsOption.Spec.ClientCA.SecretNames {
+			secret, exists, err := k8sClient.GetSecret(ingressRoute.Namespace, secretName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch secret %s/%s: %v", ingressRoute.Namespace, secretName, err)
+			}
+
+			if !exists {
+				return nil, fmt.Errorf("secret %s/%s does not exist", ingressRoute.Namespace, secretName)
+			}
+
+			ca, ok := secret.Data["ca.crt"]
+			if !ok || len(ca) == 0 {
+				return nil, fmt.Errorf("secret %s/%s is missing or contains an empty ca.crt data entry", ingressRoute.Namespace, secretName)
+			}
+
+			domainsOptions.ClientCA.Files = append(domainsOptions.ClientCA.Files, tls.FileOrContent(ca))
+		}
+		domainsOptions.ClientCA.Optional = tlsOption.Spec.ClientCA.Optional
+	}
+
+	for _, domain := range domains {
+		if otherOption, exists := domainsTLSOption[domain]; exists {
+			if otherOption != optionKey {
+				logger.Errorf("Domain %s of ingress route %s/%s is already configured by the TLS option %s, skipping", domain, ingressRoute.Namespace, ingressRoute.Name, otherOption)
+			}
+			continue
+		}
+
+		domainsTLSOption[domain] = optionKey
+		domainsOptions.Domains = append(domainsOptions.Domains, domain)
+	}
+
+	if len(domainsOptions.Domains) == 0 {
+		return nil, nil
+	}
+	return domainsOptions, nil
+}
@@ -0,0 +1,976 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	gatewayv1 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1"
+	gatewayv1alpha2 "github.com/containous/traefik/provider/kubernetes/crd/gateway/v1alpha2"
+	"github.com/containous/traefik/provider/kubernetes/crd/traefik/v1alpha1"
+	"github.com/containous/traefik/provider/label"
+	"github.com/containous/traefik/tls"
+	"github.com/containous/traefik/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	gatewayControllerName = "traefik.io/gateway-controller"
+	gatewayGroup          = "gateway.networking.k8s.io"
+
+	kindGateway   = "Gateway"
+	kindHTTPRoute = "HTTPRoute"
+	kindTLSRoute  = "TLSRoute"
+	kindService   = "Service"
+	kindSecret    = "Secret"
+
+	conditionTrue  = "True"
+	conditionFalse = "False"
+
+	conditionAccepted         = "Accepted"
+	conditionProgrammed       = "Programmed"
+	conditionResolvedRefs     = "ResolvedRefs"
+	conditionPartiallyInvalid = "PartiallyInvalid"
+
+	reasonAccepted                   = "Accepted"
+	reasonProgrammed                 = "Programmed"
+	reasonResolvedRefs               = "ResolvedRefs"
+	reasonInvalid                    = "Invalid"
+	reasonPortUnavailable            = "PortUnavailable"
+	reasonUnsupportedProtocol        = "UnsupportedProtocol"
+	reasonUnsupportedValue           = "UnsupportedValue"
+	reasonInvalidCertificateRef      = "InvalidCertificateRef"
+	reasonInvalidRouteKinds          = "InvalidRouteKinds"
+	reasonRefNotPermitted            = "RefNotPermitted"
+	reasonInvalidKind                = "InvalidKind"
+	reasonBackendNotFound            = "BackendNotFound"
+	reasonNoMatchingParent           = "NoMatchingParent"
+	reasonNotAllowedByListeners      = "NotAllowedByListeners"
+	reasonNoMatchingListenerHostname = "NoMatchingListenerHostname"
+
+	protocolHTTP  = "HTTP"
+	protocolHTTPS = "HTTPS"
+	protocolTLS   = "TLS"
+
+	tlsModeTerminate = "Terminate"
+
+	namespacesFromSame     = "Same"
+	namespacesFromAll      = "All"
+	namespacesFromSelector = "Selector"
+)
+
+// SetEntryPoints sets the addresses of the Traefik entrypoints, indexed by name.
+// The listeners of the Gateways are bound to the entrypoints listening on their port.
+func (p *Provider) SetEntryPoints(addresses map[string]string) {
+	p.entryPointPorts = make(map[int32]string)
+	for name, address := range addresses {
+		_, port, err := net.SplitHostPort(address)
+		if err != nil {
+			logger.Errorf("Invalid address %q for entrypoint %s: %v", address, name, err)
+			continue
+		}
+
+		portNumber, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			logger.Errorf("Invalid port %q for entrypoint %s: %v", port, name, err)
+			continue
+		}
+
+		if existing, ok := p.entryPointPorts[int32(portNumber)]; ok && existing < name {
+			// several entrypoints listening on the same port on different interfaces, keep a stable choice
+			continue
+		}
+		p.entryPointPorts[int32(portNumber)] = name
+	}
+}
+
+// gatewayListener holds a listener of a Gateway and the routes attached to it
+type gatewayListener struct {
+	gateway    *gatewayv1.Gateway
+	listener   gatewayv1.Listener
+	entryPoint string
+	kinds      []gatewayv1.RouteGroupKind
+	valid      bool
+	attached   int32
+	conditions []gatewayv1.Condition
+}
+
+// routeEntryPoints holds the hostnames a route matches on each entrypoint, no hostname meaning any hostname
+type routeEntryPoints map[string]*routeHostnames
+
+type routeHostnames struct {
+	any       bool
+	hostnames []string
+}
+
+func (r routeEntryPoints) add(entryPoint string, hostnames []string) {
+	hosts, ok := r[entryPoint]
+	if !ok {
+		hosts = &routeHostnames{}
+		r[entryPoint] = hosts
+	}
+
+	if len(hostnames) == 0 {
+		hosts.any = true
+		return
+	}
+	for _, hostname := range hostnames {
+		hosts.hostnames = mergeEntryPoint(hosts.hostnames, hostname)
+	}
+}
+
+// loadGateways adds the frontends and the backends of the routes attached to the Gateways of the Traefik GatewayClasses,
+// the certificates of their listeners, and writes the status of these resources
+func (p *Provider) loadGateways(k8sClient Client, templateObjects *types.Configuration, tlsConfigs map[string]*tls.Configuration) {
+	classes := make(map[string]bool)
+	for _, class := range k8sClient.GetGatewayClasses() {
+		if class.Spec.ControllerName != gatewayControllerName {
+			continue
+		}
+		classes[class.Name] = true
+
+		status := gatewayv1.GatewayClassStatus{
+			Conditions: updateConditions(class.Status.Conditions, class.Generation,
+				newCondition(conditionAccepted, true, reasonAccepted, "Handled by Traefik")),
+		}
+		if !reflect.DeepEqual(class.Status, status) {
+			if err := k8sClient.UpdateGatewayClassStatus(class, status); err != nil {
+				logger.Errorf("Cannot update GatewayClass %s due to error: %v", class.Name, err)
+			}
+		}
+	}
+
+	var gateways []*gatewayv1.Gateway
+	for _, gateway := range k8sClient.GetGateways() {
+		if classes[gateway.Spec.GatewayClassName] {
+			gateways = append(gateways, gateway)
+		}
+	}
+	sort.Slice(gateways, func(i, j int) bool {
+		if gateways[i].Namespace == gateways[j].Namespace {
+			return gateways[i].Name < gateways[j].Name
+		}
+		return gateways[i].Namespace < gateways[j].Namespace
+	})
+
+	listeners := make(map[string][]*gatewayListener)
+	for _, gateway := range gateways {
+		listeners[gateway.Namespace+"/"+gateway.Name] = p.loadGatewayListeners(k8sClient, gateway, tlsConfigs)
+	}
+
+	p.loadHTTPRoutes(k8sClient, listeners, templateObjects)
+	p.loadTLSRoutes(k8sClient, listeners)
+
+	addresses := p.getGatewayAddresses(k8sClient)
+	for _, gateway := range gateways {
+		status := gatewayv1.GatewayStatus{Addresses: addresses}
+
+		programmed := newCondition(conditionProgrammed, false, reasonInvalid, "No valid listener")
+		for _, gl := range listeners[gateway.Namespace+"/"+gateway.Name] {
+			if gl.valid {
+				programmed = newCondition(conditionProgrammed, true, reasonProgrammed, "Handled by Traefik")
+			}
+
+			var existing []gatewayv1.Condition
+			for _, listenerStatus := range gateway.Status.Listeners {
+				if listenerStatus.Name == gl.listener.Name {
+					existing = listenerStatus.Conditions
+				}
+			}
+
+			status.Listeners = append(status.Listeners, gatewayv1.ListenerStatus{
+				Name:           gl.listener.Name,
+				SupportedKinds: gl.kinds,
+				AttachedRoutes: gl.attached,
+				Conditions:     updateConditions(existing, gateway.Generation, gl.conditions...),
+			})
+		}
+
+		status.Conditions = updateConditions(gateway.Status.Conditions, gateway.Generation,
+			newCondition(conditionAccepted, true, reasonAccepted, "Handled by Traefik"), programmed)
+
+		if !reflect.DeepEqual(gateway.Status, status) {
+			if err := k8sClient.UpdateGatewayStatus(gateway, status); err != nil {
+				logger.Errorf("Cannot update Gateway %s/%s due to error: %v", gateway.Namespace, gateway.Name, err)
+			}
+		}
+	}
+}
+
+// loadGatewayListeners binds the listeners of a Gateway to the entrypoints, and loads their certificates
+func (p *Provider) loadGatewayListeners(k8sClient Client, gateway *gatewayv1.Gateway, tlsConfigs map[string]*tls.Configuration) []*gatewayListener {
+	var listeners []*gatewayListener
+	for _, listener := range gateway.Spec.Listeners {
+		gl := &gatewayListener{gateway: gateway, listener: listener, kinds: []gatewayv1.RouteGroupKind{}}
+		listeners = append(listeners, gl)
+
+		accepted := newCondition(conditionAccepted, true, reasonAccepted, "Listener accepted")
+		resolvedRefs := newCondition(conditionResolvedRefs, true, reasonResolvedRefs, "References resolved")
+
+		entryPoint, ok := p.entryPointPorts[listener.Port]
+		gl.entryPoint = entryPoint
+
+		var supportedKind string
+		switch {
+		case !ok:
+			accepted = newCondition(conditionAccepted, false, reasonPortUnavailable, fmt.Sprintf("No entrypoint listens on port %d", listener.Port))
+		case listener.Protocol == protocolHTTP || listener.Protocol == protocolHTTPS:
+			supportedKind = kindHTTPRoute
+		case listener.Protocol == protocolTLS:
+			accepted = newCondition(conditionAccepted, false, reasonUnsupportedProtocol, "The TLS protocol is not supported, Traefik only proxies HTTP")
+		default:
+			accepted = newCondition(conditionAccepted, false, reasonUnsupportedProtocol, fmt.Sprintf("Unsupported protocol %s", listener.Protocol))
+		}
+
+		if len(supportedKind) > 0 {
+			kinds, err := getListenerKinds(listener, supportedKind)
+			gl.kinds = kinds
+			if err != nil {
+				resolvedRefs = newCondition(conditionResolvedRefs, false, reasonInvalidRouteKinds, err.Error())
+			}
+		}
+
+		if accepted.Status == conditionTrue && listener.Protocol != protocolHTTP {
+			reason, err := p.loadListenerCertificates(k8sClient, gl, tlsConfigs)
+			if err != nil {
+				if reason == reasonUnsupportedValue {
+					accepted = newCondition(conditionAccepted, false, reason, err.Error())
+				} else {
+					resolvedRefs = newCondition(conditionResolvedRefs, false, reason, err.Error())
+				}
+			}
+		}
+
+		gl.valid = accepted.Status == conditionTrue && resolvedRefs.Status == conditionTrue
+
+		programmed := newCondition(conditionProgrammed, true, reasonProgrammed, "Listener programmed")
+		if !gl.valid {
+			programmed = newCondition(conditionProgrammed, false, reasonInvalid, "Invalid listener")
+		}
+
+		gl.conditions = []gatewayv1.Condition{accepted, resolvedRefs, programmed}
+	}
+
+	return listeners
+}
+
+// getListenerKinds returns the route kinds allowed on a listener, among the kind supported by its protocol
+func getListenerKinds(listener gatewayv1.Listener, supportedKind string) ([]gatewayv1.RouteGroupKind, error) {
+	supported := gatewayv1.RouteGroupKind{Group: gatewayGroup, Kind: supportedKind}
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return []gatewayv1.RouteGroupKind{supported}, nil
+	}
+
+	kinds := []gatewayv1.RouteGroupKind{}
+	var invalid []string
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		group := kind.Group
+		if len(group) == 0 {
+			group = gatewayGroup
+		}
+
+		if group == gatewayGroup && kind.Kind == supportedKind {
+			kinds = append(kinds, supported)
+			continue
+		}
+		invalid = append(invalid, group+"/"+kind.Kind)
+	}
+
+	if len(invalid) > 0 {
+		return kinds, fmt.Errorf("unsupported route kinds: %s", strings.Join(invalid, ", "))
+	}
+	return kinds, nil
+}
+
+// loadListenerCertificates adds the certificates of a listener terminating TLS to the TLS configurations.
+// It returns the reason of the failure with the error.
+func (p *Provider) loadListenerCertificates(k8sClient Client, gl *gatewayListener, tlsConfigs map[string]*tls.Configuration) (string, error) {
+	listener := gl.listener
+	if listener.TLS == nil || len(listener.TLS.CertificateRefs) == 0 {
+		return reasonInvalidCertificateRef, fmt.Errorf("no certificate defined for the %s protocol", listener.Protocol)
+	}
+
+	if len(listener.TLS.Mode) > 0 && listener.TLS.Mode != tlsModeTerminate {
+		return reasonUnsupportedValue, fmt.Errorf("unsupported TLS mode %s", listener.TLS.Mode)
+	}
+
+	for _, ref := range listener.TLS.CertificateRefs {
+		if len(ref.Group) > 0 || (len(ref.Kind) > 0 && ref.Kind != kindSecret) {
+			return reasonInvalidCertificateRef, fmt.Errorf("unsupported certificate reference %s/%s", ref.Group, ref.Kind)
+		}
+
+		namespace := ref.Namespace
+		if len(namespace) == 0 {
+			namespace = gl.gateway.Namespace
+		}
+		if namespace != gl.gateway.Namespace {
+			return reasonRefNotPermitted, fmt.Errorf("certificate reference to the %s namespace is not permitted", namespace)
+		}
+
+		configKey := namespace + "/" + ref.Name
+		if tlsConfig, exists := tlsConfigs[configKey]; exists {
+			tlsConfig.EntryPoints = mergeEntryPoint(tlsConfig.EntryPoints, gl.entryPoint)
+			continue
+		}
+
+		secret, exists, err := k8sClient.GetSecret(namespace, ref.Name)
+		if err != nil {
+			return reasonInvalidCertificateRef, fmt.Errorf("failed to fetch secret %s/%s: %v", namespace, ref.Name, err)
+		}
+		if !exists {
+			return reasonInvalidCertificateRef, fmt.Errorf("secret %s/%s does not exist", namespace, ref.Name)
+		}
+
+		cert, key, err := getCertificateBlocks(secret, namespace, ref.Name)
+		if err != nil {
+			return reasonInvalidCertificateRef, err
+		}
+
+		tlsConfigs[configKey] = &tls.Configuration{
+			EntryPoints: []string{gl.entryPoint},
+			Certificate: &tls.Certificate{
+				CertFile: tls.FileOrContent(cert),
+				KeyFile:  tls.FileOrContent(key),
+			},
+		}
+	}
+
+	return "", nil
+}
+
+// attachRoute attaches a route to the listeners of the parent Gateway which accept it, and returns the entrypoints and the hostnames
+// of the route on these listeners. It returns false when the parent is not a Gateway of a Traefik GatewayClass.
+func (p *Provider) attachRoute(k8sClient Client, listeners map[string][]*gatewayListener, kind, namespace string, hostnames []string,
+	parentRef gatewayv1.ParentReference, entryPoints routeEntryPoints) (gatewayv1.Condition, bool) {
+	gatewayListeners, ok := getParentListeners(listeners, namespace, parentRef)
+	if !ok {
+		return gatewayv1.Condition{}, false
+	}
+
+	reason, message := reasonNoMatchingParent, "No listener matches the parent reference"
+	for _, gl := range gatewayListeners {
+		if (len(parentRef.SectionName) > 0 && parentRef.SectionName != gl.listener.Name) ||
+			(parentRef.Port != 0 && parentRef.Port != gl.listener.Port) || !gl.valid {
+			continue
+		}
+
+		if !gl.allowsKind(kind) || !p.allowsNamespace(k8sClient, gl, namespace) {
+			if reason == reasonNoMatchingParent {
+				reason, message = reasonNotAllowedByListeners, "The route is not allowed by the listeners"
+			}
+			continue
+		}
+
+		matchingHostnames, ok := hostnamesIntersection(gl.listener.Hostname, hostnames)
+		if !ok {
+			reason, message = reasonNoMatchingListenerHostname, "No hostname matches the hostnames of the listeners"
+			continue
+		}
+
+		gl.attached++
+		entryPoints.add(gl.entryPoint, matchingHostnames)
+		reason = ""
+	}
+
+	if len(reason) > 0 {
+		return newCondition(conditionAccepted, false, reason, message), true
+	}
+	return newCondition(conditionAccepted, true, reasonAccepted, "Route accepted"), true
+}
+
+// getParentListeners returns the listeners of the parent of a route in a namespace.
+// It returns false when the parent is not a Gateway of a Traefik GatewayClass.
+func getParentListeners(listeners map[string][]*gatewayListener, namespace string, parentRef gatewayv1.ParentReference) ([]*gatewayListener, bool) {
+	if (len(parentRef.Group) > 0 && parentRef.Group != gatewayGroup) || (len(parentRef.Kind) > 0 && parentRef.Kind != kindGateway) {
+		return nil, false
+	}
+
+	parentNamespace := parentRef.Namespace
+	if len(parentNamespace) == 0 {
+		parentNamespace = namespace
+	}
+
+	gatewayListeners, ok := listeners[parentNamespace+"/"+parentRef.Name]
+	return gatewayListeners, ok
+}
+
+func (gl *gatewayListener) allowsKind(kind string) bool {
+	for _, k := range gl.kinds {
+		if k.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Provider) allowsNamespace(k8sClient Client, gl *gatewayListener, namespace string) bool {
+	from := namespacesFromSame
+	var selector *metav1.LabelSelector
+	if gl.listener.AllowedRoutes != nil && gl.listener.AllowedRoutes.Namespaces != nil {
+		if len(gl.listener.AllowedRoutes.Namespaces.From) > 0 {
+			from = gl.listener.AllowedRoutes.Namespaces.From
+		}
+		selector = gl.listener.AllowedRoutes.Namespaces.Selector
+	}
+
+	switch from {
+	case namespacesFromAll:
+		return true
+	case namespacesFromSame:
+		return namespace == gl.gateway.Namespace
+	case namespacesFromSelector:
+		if selector == nil {
+			return false
+		}
+
+		routeSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			logger.Errorf("Invalid namespace selector on listener %s of Gateway %s/%s: %v", gl.listener.Name, gl.gateway.Namespace, gl.gateway.Name, err)
+			return false
+		}
+
+		ns, exists, err := k8sClient.GetNamespace(namespace)
+		if err != nil {
+			logger.Errorf("Cannot get namespace %s: %v", namespace, err)
+			return false
+		}
+		return exists && routeSelector.Matches(labels.Set(ns.Labels))
+	default:
+		return false
+	}
+}
+
+// hostnamesIntersection returns the hostnames of a route matching the hostname of a listener,
+// no hostname meaning any hostname. It returns false when no hostname matches.
+func hostnamesIntersection(listenerHostname string, routeHostnames []string) ([]string, bool) {
+	if len(listenerHostname) == 0 {
+		return routeHostnames, true
+	}
+	if len(routeHostnames) == 0 {
+		return []string{listenerHostname}, true
+	}
+
+	var hostnames []string
+	for _, hostname := range routeHostnames {
+		switch {
+		case hostname == listenerHostname:
+			hostnames = append(hostnames, hostname)
+		case matchesWildcardHostname(listenerHostname, hostname):
+			hostnames = append(hostnames, hostname)
+		case matchesWildcardHostname(hostname, listenerHostname):
+			hostnames = append(hostnames, listenerHostname)
+		}
+	}
+	return hostnames, len(hostnames) > 0
+}
+
+// matchesWildcardHostname tells whether a hostname matches a wildcard hostname, which matches any number of labels
+func matchesWildcardHostname(wildcard, hostname string) bool {
+	if !strings.HasPrefix(wildcard, "*.") || strings.HasPrefix(hostname, "*.") {
+		return false
+	}
+	return strings.HasSuffix(hostname, wildcard[1:]) && len(hostname) > len(wildcard)-1
+}
+
+// validateHostnames returns an error when a hostname is not a DNS subdomain, or a wildcard DNS subdomain,
+// so that the hostnames cannot alter the rules built from them
+func validateHostnames(hostnames []string) error {
+	for _, hostname := range hostnames {
+		errs := validation.IsDNS1123Subdomain(hostname)
+		if strings.HasPrefix(hostname, "*.") {
+			errs = validation.IsWildcardDNS1123Subdomain(hostname)
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("invalid hostname %q: %s", hostname, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// getRuleForHostnames returns the rule matching any of the hostnames of a route
+func getRuleForHostnames(hostnames []string) string {
+	matcher := "Host:"
+	var rules []string
+	for _, hostname := range hostnames {
+		if strings.HasPrefix(hostname, "*.") {
+			matcher = "HostRegexp:"
+			hostname = "{subdomain:[A-Za-z0-9-_.]+}" + hostname[1:]
+		}
+		rules = append(rules, hostname)
+	}
+	return matcher + strings.Join(rules, ",")
+}
+
+// loadHTTPRoutes adds the frontends and the backends of the HTTPRoutes attached to the listeners, and writes their status
+func (p *Provider) loadHTTPRoutes(k8sClient Client, listeners map[string][]*gatewayListener, templateObjects *types.Configuration) {
+	routes := k8sClient.GetHTTPRoutes()
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Namespace == routes[j].Namespace {
+			return routes[i].Name < routes[j].Name
+		}
+		return routes[i].Namespace < routes[j].Namespace
+	})
+
+	for _, route := range routes {
+		entryPoints := make(routeEntryPoints)
+
+		var parents []gatewayv1.RouteParentStatus
+		for _, parentRef := range route.Spec.ParentRefs {
+			accepted, ok := p.attachRoute(k8sClient, listeners, kindHTTPRoute, route.Namespace, route.Spec.Hostnames, parentRef, entryPoints)
+			if ok {
+				parents = append(parents, gatewayv1.RouteParentStatus{ParentRef: parentRef, ControllerName: gatewayControllerName, Conditions: []gatewayv1.Condition{accepted}})
+			}
+		}
+
+		if len(parents) == 0 {
+			continue
+		}
+
+		resolvedRefs := newCondition(conditionResolvedRefs, true, reasonResolvedRefs, "References resolved")
+		var invalidRules []string
+		for i, rule := range route.Spec.Rules {
+			name := fmt.Sprintf("httproute-%s-%s-%d", route.Namespace, route.Name, i)
+
+			frontends, err := buildHTTPRouteFrontends(rule, name, entryPoints)
+			if err != nil {
+				logger.Errorf("Invalid rule %d of HTTPRoute %s/%s: %v", i, route.Namespace, route.Name, err)
+				invalidRules = append(invalidRules, fmt.Sprintf("rule %d: %v", i, err))
+				continue
+			}
+
+			var refs []gatewayv1.BackendRef
+			for _, ref := range rule.BackendRefs {
+				refs = append(refs, ref.BackendRef)
+			}
+
+			backend, reason, err := buildGatewayBackend(k8sClient, route.Namespace, refs)
+			if err != nil {
+				logger.Errorf("Invalid backends in rule %d of HTTPRoute %s/%s: %v", i, route.Namespace, route.Name, err)
+				resolvedRefs = newCondition(conditionResolvedRefs, false, reason, err.Error())
+			}
+
+			if len(frontends) > 0 {
+				templateObjects.Backends[name] = backend
+			}
+			for frontendName, frontend := range frontends {
+				templateObjects.Frontends[frontendName] = frontend
+			}
+		}
+
+		conditions := []gatewayv1.Condition{resolvedRefs}
+		if len(invalidRules) > 0 {
+			conditions = append(conditions, newCondition(conditionPartiallyInvalid, true, reasonUnsupportedValue, strings.Join(invalidRules, "; ")))
+		}
+
+		status := gatewayv1.HTTPRouteStatus{
+			RouteStatus: buildRouteStatus(route.Status.RouteStatus, route.Generation, parents, conditions...),
+		}
+		if !reflect.DeepEqual(route.Status, status) {
+			if err := k8sClient.UpdateHTTPRouteStatus(route, status); err != nil {
+				logger.Errorf("Cannot update HTTPRoute %s/%s due to error: %v", route.Namespace, route.Name, err)
+			}
+		}
+	}
+}
+
+// buildHTTPRouteFrontends returns the frontends of a rule of an HTTPRoute, one for each match on each entrypoint
+func buildHTTPRouteFrontends(rule gatewayv1.HTTPRouteRule, name string, entryPoints routeEntryPoints) (map[string]*types.Frontend, error) {
+	matches := rule.Matches
+	if len(matches) == 0 {
+		matches = []gatewayv1.HTTPRouteMatch{{}}
+	}
+
+	var matchRoutes []map[string]types.Route
+	for _, match := range matches {
+		routes, err := getRoutesForMatch(match)
+		if err != nil {
+			return nil, err
+		}
+		matchRoutes = append(matchRoutes, routes)
+	}
+
+	var headers *types.Headers
+	var redirect *types.Redirect
+	for _, filter := range rule.Filters {
+		switch {
+		case filter.Type == "RequestHeaderModifier" && filter.RequestHeaderModifier != nil:
+			var err error
+			headers, err = getHeaderModifier(filter.RequestHeaderModifier)
+			if err != nil {
+				return nil, err
+			}
+		case filter.Type == "RequestRedirect" && filter.RequestRedirect != nil:
+			var err error
+			redirect, err = getRedirectFilter(filter.RequestRedirect)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported filter %s", filter.Type)
+		}
+	}
+
+	frontends := make(map[string]*types.Frontend)
+	for entryPoint, hosts := range entryPoints {
+		var hostRule string
+		if !hosts.any {
+			if err := validateHostnames(hosts.hostnames); err != nil {
+				return nil, err
+			}
+			hostRule = getRuleForHostnames(hosts.hostnames)
+		}
+
+		for i, routes := range matchRoutes {
+			frontend := &types.Frontend{
+				Backend:        name,
+				PassHostHeader: true,
+				EntryPoints:    []string{entryPoint},
+				Routes:         make(map[string]types.Route),
+				Headers:        headers,
+				Redirect:       redirect,
+			}
+
+			for routeName, route := range routes {
+				frontend.Routes[routeName] = route
+			}
+			if len(hostRule) > 0 {
+				frontend.Routes["host"] = types.Route{Rule: hostRule}
+			}
+
+			frontends[fmt.Sprintf("%s-%d-%s", name, i, entryPoint)] = frontend
+		}
+	}
+
+	return frontends, nil
+}
+
+// getRoutesForMatch returns the frontend routes of a match of an HTTPRoute
+func getRoutesForMatch(match gatewayv1.HTTPRouteMatch) (map[string]types.Route, error) {
+	routes := make(map[string]types.Route)
+
+	pathType, path := "PathPrefix", "/"
+	if match.Path != nil {
+		if len(match.Path.Type) > 0 {
+			pathType = match.Path.Type
+		}
+		if len(match.Path.Value) > 0 {
+			path = match.Path.Value
+		}
+	}
+
+	switch pathType {
+	case "Exact":
+		rule, err := getRuleForExactPath(path)
+		if err != nil {
+			return nil, err
+		}
+		routes["path"] = types.Route{Rule: rule}
+	case "PathPrefix":
+		rule, err := getRuleForPathPrefix(path)
+		if err != nil {
+			return nil, err
+		}
+		routes["path"] = types.Route{Rule: rule}
+	default:
+		return nil, fmt.Errorf("unsupported path match type %s", pathType)
+	}
+
+	for i, header := range match.Headers {
+		switch header.Type {
+		case "", "Exact":
+			routes[fmt.Sprintf("header-%d", i)] = types.Route{Rule: "Headers:" + header.Name + "," + header.Value}
+		case "RegularExpression":
+			routes[fmt.Sprintf("header-%d", i)] = types.Route{Rule: "HeadersRegexp:" + header.Name + "," + header.Value}
+		default:
+			return nil, fmt.Errorf("unsupported header match type %s", header.Type)
+		}
+	}
+
+	for i, query := range match.QueryParams {
+		if len(query.Type) > 0 && query.Type != "Exact" {
+			return nil, fmt.Errorf("unsupported query parameter match type %s", query.Type)
+		}
+		routes[fmt.Sprintf("query-%d", i)] = types.Route{Rule: "Query:" + query.Name + "=" + query.Value}
+	}
+
+	if len(match.Method) > 0 {
+		routes["method"] = types.Route{Rule: "Method:" + match.Method}
+	}
+
+	for _, route := range routes {
+		if err := templateSafeString(route.Rule); err != nil {
+			return nil, err
+		}
+	}
+
+	return routes, nil
+}
+
+// getHeaderModifier returns the headers of a RequestHeaderModifier filter, the added values are appended to the values of the requests
+func getHeaderModifier(filter *gatewayv1.HTTPHeaderFilter) (*types.Headers, error) {
+	headers := &types.Headers{
+		CustomRequestHeaders: make(map[string]string),
+		AppendRequestHeaders: make(map[string]string),
+	}
+
+	for _, header := range filter.Set {
+		headers.CustomRequestHeaders[header.Name] = header.Value
+	}
+	for _, header := range filter.Add {
+		headers.AppendRequestHeaders[header.Name] = header.Value
+	}
+	// an empty value removes the header
+	for _, name := range filter.Remove {
+		headers.CustomRequestHeaders[name] = ""
+	}
+
+	for _, values := range []map[string]string{headers.CustomRequestHeaders, headers.AppendRequestHeaders} {
+		for name, value := range values {
+			if errs := validation.IsHTTPHeaderName(name); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid header name %q: %s", name, strings.Join(errs, ", "))
+			}
+			if err := templateSafeString(value); err != nil {
+				return nil, fmt.Errorf("invalid value of header %s: %v", name, err)
+			}
+		}
+	}
+
+	return headers, nil
+}
+
+func getRedirectFilter(filter *gatewayv1.HTTPRequestRedirectFilter) (*types.Redirect, error) {
+	permanent := false
+	switch filter.StatusCode {
+	case 0, 302:
+	case 301:
+		permanent = true
+	default:
+		return nil, fmt.Errorf("unsupported redirect status code %d", filter.StatusCode)
+	}
+
+	scheme := "${1}"
+	if len(filter.Scheme) > 0 {
+		scheme = filter.Scheme
+	}
+
+	hostname := "${2}"
+	if len(filter.Hostname) > 0 {
+		hostname = filter.Hostname
+	}
+
+	// the port of the request is kept unless the scheme changes
+	port := "${3}"
+	if filter.Port != 0 {
+		port = fmt.Sprintf(":%d", filter.Port)
+	} else if len(filter.Scheme) > 0 {
+		port = ""
+	}
+
+	redirect := &types.Redirect{
+		Regex:       `^(https?)://([^/:]+)(:[0-9]+)?(.*)$`,
+		Replacement: scheme + "://" + hostname + port + "${4}",
+		Permanent:   permanent,
+	}
+
+	if err := templateSafeString(redirect.Replacement); err != nil {
+		return nil, err
+	}
+	return redirect, nil
+}
+
+// buildGatewayBackend returns the backend of a rule of a route. When a reference is invalid, the servers of the valid references
+// are kept, and the reason of the failure is returned with the error.
+func buildGatewayBackend(k8sClient Client, namespace string, refs []gatewayv1.BackendRef) (*types.Backend, string, error) {
+	backend := &types.Backend{
+		Servers: make(map[string]types.Server),
+		LoadBalancer: &types.LoadBalancer{
+			Method: "wrr",
+		},
+	}
+
+	var reason string
+	var refErr error
+	for _, ref := range refs {
+		weight := label.DefaultWeight
+		if ref.Weight != nil {
+			weight = int(*ref.Weight)
+		}
+		if weight == 0 {
+			continue
+		}
+
+		if len(ref.Group) > 0 || (len(ref.Kind) > 0 && ref.Kind != kindService) {
+			reason, refErr = reasonInvalidKind, fmt.Errorf("unsupported backend %s/%s %s", ref.Group, ref.Kind, ref.Name)
+			continue
+		}
+
+		refNamespace := ref.Namespace
+		if len(refNamespace) == 0 {
+			refNamespace = namespace
+		}
+		if refNamespace != namespace {
+			reason, refErr = reasonRefNotPermitted, fmt.Errorf("backend reference to the %s namespace is not permitted", refNamespace)
+			continue
+		}
+
+		urls, err := loadServiceURLs(k8sClient, namespace, v1alpha1.Service{Name: ref.Name, Port: ref.Port})
+		if err != nil {
+			reason, refErr = reasonBackendNotFound, err
+			continue
+		}
+
+		for _, url := range urls {
+			serverWeight := label.DefaultWeight
+			// with several services, the weight of a service is shared among its servers
+			if len(refs) > 1 {
+				serverWeight = weight * serviceWeightScale / len(urls)
+			}
+
+			backend.Servers[url] = types.Server{
+				URL:    url,
+				Weight: serverWeight,
+			}
+		}
+	}
+
+	return backend, reason, refErr
+}
+
+// loadTLSRoutes rejects the TLSRoutes attached to the Gateways of the Traefik GatewayClasses, and writes their status.
+// Traefik only proxies HTTP, so it cannot forward the TLS connections as the TLSRoutes require.
+func (p *Provider) loadTLSRoutes(k8sClient Client, listeners map[string][]*gatewayListener) {
+	for _, route := range k8sClient.GetTLSRoutes() {
+		var parents []gatewayv1.RouteParentStatus
+		for _, parentRef := range route.Spec.ParentRefs {
+			if _, ok := getParentListeners(listeners, route.Namespace, parentRef); !ok {
+				continue
+			}
+
+			logger.Errorf("TLSRoute %s/%s is not supported, Traefik only proxies HTTP", route.Namespace, route.Name)
+			parents = append(parents, gatewayv1.RouteParentStatus{
+				ParentRef:      parentRef,
+				ControllerName: gatewayControllerName,
+				Conditions: []gatewayv1.Condition{
+					newCondition(conditionAccepted, false, reasonUnsupportedValue, "TLSRoutes are not supported, Traefik only proxies HTTP"),
+				},
+			})
+		}
+
+		if len(parents) == 0 {
+			continue
+		}
+
+		status := gatewayv1alpha2.TLSRouteStatus{
+			RouteStatus: buildRouteStatus(route.Status.RouteStatus, route.Generation, parents),
+		}
+		if !reflect.DeepEqual(route.Status, status) {
+			if err := k8sClient.UpdateTLSRouteStatus(route, status); err != nil {
+				logger.Errorf("Cannot update TLSRoute %s/%s due to error: %v", route.Namespace, route.Name, err)
+			}
+		}
+	}
+}
+
+// buildRouteStatus returns the status of a route, replacing the status of the parents handled by Traefik with the given conditions
+// added to their own, and keeping the status written by other controllers
+func buildRouteStatus(current gatewayv1.RouteStatus, generation int64, parents []gatewayv1.RouteParentStatus,
+	conditions ...gatewayv1.Condition) gatewayv1.RouteStatus {
+	status := gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{}}
+	for _, parent := range current.Parents {
+		if parent.ControllerName != gatewayControllerName {
+			status.Parents = append(status.Parents, parent)
+		}
+	}
+
+	for _, parent := range parents {
+		var existing []gatewayv1.Condition
+		for _, currentParent := range current.Parents {
+			if currentParent.ControllerName == gatewayControllerName && currentParent.ParentRef == parent.ParentRef {
+				existing = currentParent.Conditions
+			}
+		}
+
+		parentConditions := append(parent.Conditions, conditions...)
+		parent.Conditions = updateConditions(existing, generation, parentConditions...)
+		status.Parents = append(status.Parents, parent)
+	}
+
+	return status
+}
+
+// getGatewayAddresses returns the addresses of the Gateways, configured like the Ingress endpoint
+func (p *Provider) getGatewayAddresses(k8sClient Client) []gatewayv1.GatewayStatusAddress {
+	if p.IngressEndpoint == nil {
+		return nil
+	}
+
+	var loadBalancers []struct{ ip, hostname string }
+	if len(p.IngressEndpoint.PublishedService) == 0 {
+		loadBalancers = append(loadBalancers, struct{ ip, hostname string }{p.IngressEndpoint.IP, p.IngressEndpoint.Hostname})
+	} else {
+		serviceInfo := strings.Split(p.IngressEndpoint.PublishedService, "/")
+		if len(serviceInfo) != 2 {
+			logger.Errorf("Invalid publishedService format (expected 'namespace/service' format): %s", p.IngressEndpoint.PublishedService)
+			return nil
+		}
+
+		service, exists, err := k8sClient.GetService(serviceInfo[0], serviceInfo[1])
+		if err != nil || !exists {
+			logger.Errorf("Cannot get service %s: exists=%t, error=%v", p.IngressEndpoint.PublishedService, exists, err)
+			return nil
+		}
+
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			loadBalancers = append(loadBalancers, struct{ ip, hostname string }{ingress.IP, ingress.Hostname})
+		}
+	}
+
+	var addresses []gatewayv1.GatewayStatusAddress
+	for _, lb := range loadBalancers {
+		if len(lb.ip) > 0 {
+			addresses = append(addresses, gatewayv1.GatewayStatusAddress{Type: "IPAddress", Value: lb.ip})
+		}
+		if len(lb.hostname) > 0 {
+			addresses = append(addresses, gatewayv1.GatewayStatusAddress{Type: "Hostname", Value: lb.hostname})
+		}
+	}
+	return addresses
+}
+
+func newCondition(conditionType string, status bool, reason, message string) gatewayv1.Condition {
+	conditionStatus := conditionFalse
+	if status {
+		conditionStatus = conditionTrue
+	}
+
+	return gatewayv1.Condition{
+		Type:    conditionType,
+		Status:  conditionStatus,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// updateConditions returns the given conditions for a generation of a resource.
+// The transition time of a condition is kept as long as its status does not change.
+func updateConditions(existing []gatewayv1.Condition, generation int64, conditions ...gatewayv1.Condition) []gatewayv1.Condition {
+	now := metav1.Now().Rfc3339Copy()
+
+	var result []gatewayv1.Condition
+	for _, condition := range conditions {
+		condition.ObservedGeneration = generation
+		condition.LastTransitionTime = now
+		for _, e := range existing {
+			if e.Type == condition.Type && e.Status == condition.Status {
+				condition.LastTransitionTime = e.LastTransitionTime
+			}
+		}
+		result = append(result, condition)
+	}
+	return result
+}
@@ -0,0 +1,4 @@
+// Package v1 holds the networking.k8s.io/v1 Ingress and IngressClass types,
+// served by the Kubernetes API from 1.19 on.
+// The vendored k8s.io/api does not provide them yet, only the fields read by the provider are declared.
+package v1
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Ingress is a collection of rules that allow inbound connections to reach the endpoints defined by a backend
+type Ingress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IngressSpec   `json:"spec,omitempty"`
+	Status IngressStatus `json:"status,omitempty"`
+}
+
+// IngressList is a collection of Ingresses
+type IngressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Ingress `json:"items"`
+}
+
+// IngressSpec describes the Ingress the user wishes to exist
+type IngressSpec struct {
+	IngressClassName *string         `json:"ingressClassName,omitempty"`
+	DefaultBackend   *IngressBackend `json:"defaultBackend,omitempty"`
+	TLS              []IngressTLS    `json:"tls,omitempty"`
+	Rules            []IngressRule   `json:"rules,omitempty"`
+}
+
+// IngressTLS describes the transport layer security associated with an Ingress
+type IngressTLS struct {
+	Hosts      []string `json:"hosts,omitempty"`
+	SecretName string   `json:"secretName,omitempty"`
+}
+
+// IngressStatus describes the current state of the Ingress
+type IngressStatus struct {
+	LoadBalancer corev1.LoadBalancerStatus `json:"loadBalancer,omitempty"`
+}
+
+// IngressRule represents the rules mapping the paths under a specified host to the related backend services
+type IngressRule struct {
+	Host             string `json:"host,omitempty"`
+	IngressRuleValue `json:",inline,omitempty"`
+}
+
+// IngressRuleValue represents a rule to apply against incoming requests
+type IngressRuleValue struct {
+	HTTP *HTTPIngressRuleValue `json:"http,omitempty"`
+}
+
+// HTTPIngressRuleValue is a list of http selectors pointing to backends
+type HTTPIngressRuleValue struct {
+	Paths []HTTPIngressPath `json:"paths"`
+}
+
+// PathType represents the type of path referred to by a HTTPIngressPath
+type PathType string
+
+const (
+	// PathTypeExact matches the URL path exactly
+	PathTypeExact = PathType("Exact")
+	// PathTypePrefix matches based on a URL path prefix split by '/'
+	PathTypePrefix = PathType("Prefix")
+	// PathTypeImplementationSpecific leaves the matching up to the IngressClass
+	PathTypeImplementationSpecific = PathType("ImplementationSpecific")
+)
+
+// HTTPIngressPath associates a path with a backend
+type HTTPIngressPath struct {
+	Path     string         `json:"path,omitempty"`
+	PathType *PathType      `json:"pathType,omitempty"`
+	Backend  IngressBackend `json:"backend"`
+}
+
+// IngressBackend describes all endpoints for a given service and port
+type IngressBackend struct {
+	Service *IngressServiceBackend `json:"service,omitempty"`
+}
+
+// IngressServiceBackend references a Kubernetes Service as a Backend
+type IngressServiceBackend struct {
+	Name string             `json:"name"`
+	Port ServiceBackendPort `json:"port,omitempty"`
+}
+
+// ServiceBackendPort is the service port being referenced, by name or by number
+type ServiceBackendPort struct {
+	Name   string `json:"name,omitempty"`
+	Number int32  `json:"number,omitempty"`
+}
+
+// IngressClass represents the class of the Ingress, referenced by the Ingress Spec
+type IngressClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IngressClassSpec `json:"spec,omitempty"`
+}
+
+// IngressClassSpec provides information about the class of an Ingress
+type IngressClassSpec struct {
+	Controller string `json:"controller,omitempty"`
+}
+
+// IngressClassList is a collection of IngressClasses
+type IngressClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []IngressClass `json:"items"`
+}
@@ -0,0 +1,4 @@
+// Package v1beta1 holds the networking.k8s.io/v1beta1 Ingress and IngressClass types,
+// served by the Kubernetes API from 1.14 (Ingress) and 1.18 (IngressClass) on.
+// The vendored k8s.io/api does not provide them yet, only the fields read by the provider are declared.
+package v1beta1
@@ -39,7 +39,7 @@ func MustEncodeYaml(object runtime.Object, groupName string, w io.Writer) {
 
 // MustDecodeYaml Decode a YAML to objects.
 func MustDecodeYaml(content []byte) []runtime.Object {
-	acceptedK8sTypes := regexp.MustCompile(`(Deployment|Endpoints|Service|Ingress|Secret)`)
+	acceptedK8sTypes := regexp.MustCompile(`(Deployment|Endpoints|Service|Ingress|IngressRoute|Middleware|TLSOption|Secret|Namespace|GatewayClass|Gateway|HTTPRoute|TLSRoute)`)
 
 	files := strings.Split(string(content), "---")
 	retVal := make([]runtime.Object, 0, len(files))
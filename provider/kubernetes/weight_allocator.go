@@ -173,9 +173,9 @@ func getServiceInstanceCounts(ingress *extensionsv1beta1.Ingress, client Client)
 			if !exists {
 				return nil, fmt.Errorf("service not found for %s/%s", ingress.Namespace, pa.Backend.ServiceName)
 			}
-			if svc.Spec.Type == corev1.ServiceTypeExternalName {
+			if svc.Spec.Type == corev1.ServiceTypeExternalName || useClusterIP(svc) {
 				// external-name service has only one instance b/c it will actually be interpreted as a DNS record
-				// instead of real server.
+				// instead of real server, and so has a service reached through its cluster IP.
 				serviceInstanceCounts[ingressService{
 					host:    rule.Host,
 					path:    pa.Path,
@@ -125,6 +125,11 @@ const (
 	pathTLSEntryPoints = "/entrypoints"
 	pathTLSCertFile    = "/certificate/certfile"
 	pathTLSKeyFile     = "/certificate/keyfile"
+	pathTLSDefault     = "/default"
+
+	pathClientCAs            = "/clientcas/"
+	pathClientCAEntryPoints  = "/entrypoints"
+	pathClientCACertificates = "/ca"
 
 	pathTags      = "/tags"
 	pathAlias     = "/alias"
@@ -16,6 +16,8 @@ import (
 	"github.com/containous/traefik/types"
 )
 
+var logger = log.WithModule("provider/kv")
+
 // Provider holds common configurations of key-value providers.
 type Provider struct {
 	provider.BaseProvider `mapstructure:",squash" export:"true"`
@@ -61,6 +63,14 @@ func (p *Provider) SetKVClient(kvClient store.Store) {
 	p.kvClient = kvClient
 }
 
+// BuildConfiguration builds the configuration from the pairs read through the given store,
+// which allows the providers keeping a local copy of the KV store to build the configuration from it.
+func (p *Provider) BuildConfiguration(kvClient store.Store) (*types.Configuration, error) {
+	provider := *p
+	provider.kvClient = kvClient
+	return provider.buildConfiguration()
+}
+
 func (p *Provider) watchKv(configurationChan chan<- types.ConfigMessage, prefix string, stop chan bool) error {
 	operation := func() error {
 		events, err := p.kvClient.WatchTree(p.Prefix, make(chan struct{}), nil)
@@ -91,7 +101,7 @@ func (p *Provider) watchKv(configurationChan chan<- types.ConfigMessage, prefix
 	}
 
 	notify := func(err error, time time.Duration) {
-		log.Errorf("KV connection error: %+v, retrying in %s", err, time)
+		logger.Errorf("KV connection error: %+v, retrying in %s", err, time)
 	}
 	err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 	if err != nil {
@@ -110,7 +120,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 			pool.Go(func(stop chan bool) {
 				err := p.watchKv(configurationChan, p.Prefix, stop)
 				if err != nil {
-					log.Errorf("Cannot watch KV store: %v", err)
+					logger.Errorf("Cannot watch KV store: %v", err)
 				}
 			})
 		}
@@ -126,7 +136,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 		return nil
 	}
 	notify := func(err error, time time.Duration) {
-		log.Errorf("KV connection error: %+v, retrying in %s", err, time)
+		logger.Errorf("KV connection error: %+v, retrying in %s", err, time)
 	}
 	err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 	if err != nil {
@@ -12,7 +12,6 @@ import (
 	"github.com/BurntSushi/ty/fun"
 	"github.com/abronan/valkeyrie/store"
 	"github.com/containous/flaeg"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
@@ -38,7 +37,8 @@ func (p *Provider) buildConfiguration() (*types.Configuration, error) {
 		"Last":        p.last,
 		"Has":         p.has,
 
-		"getTLSSection": p.getTLSSection,
+		"getTLSSection":       p.getTLSSection,
+		"getClientCAsSection": p.getClientCAsSection,
 
 		// Frontend functions
 		"getBackendName":       p.getFuncString(pathFrontendBackend, ""),
@@ -103,7 +103,7 @@ func (p *Provider) getPassHostHeader() func(rootPath string) bool {
 		if len(rawValue) > 0 {
 			value, err := strconv.ParseBool(rawValue)
 			if err != nil {
-				log.Errorf("Invalid value for %s %s: %s", rootPath, pathFrontendPassHostHeader, rawValue)
+				logger.Errorf("Invalid value for %s %s: %s", rootPath, pathFrontendPassHostHeader, rawValue)
 				return label.DefaultPassHostHeader
 			}
 			return value
@@ -117,14 +117,14 @@ func (p *Provider) getPassHostHeader() func(rootPath string) bool {
 func (p *Provider) getSticky(rootPath string) bool {
 	stickyValue := p.get("", rootPath, pathBackendLoadBalancerSticky)
 	if len(stickyValue) > 0 {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", pathBackendLoadBalancerSticky, pathBackendLoadBalancerStickiness)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", pathBackendLoadBalancerSticky, pathBackendLoadBalancerStickiness)
 	} else {
 		return false
 	}
 
 	sticky, err := strconv.ParseBool(stickyValue)
 	if err != nil {
-		log.Warnf("Invalid %s value: %s.", pathBackendLoadBalancerSticky, stickyValue)
+		logger.Warnf("Invalid %s value: %s.", pathBackendLoadBalancerSticky, stickyValue)
 	}
 
 	return sticky
@@ -215,7 +215,7 @@ func (p *Provider) getRateLimit(rootPath string) *types.RateLimit {
 		var period flaeg.Duration
 		err := period.Set(rawPeriod)
 		if err != nil {
-			log.Errorf("Invalid %q value: %q", pathLimits+pathFrontendRateLimitPeriod, rawPeriod)
+			logger.Errorf("Invalid %q value: %q", pathLimits+pathFrontendRateLimitPeriod, rawPeriod)
 			continue
 		}
 
@@ -373,13 +373,13 @@ func (p *Provider) getTLSSection(prefix string) []*tls.Configuration {
 		keyFile := p.get("", tlsConfPath, pathTLSKeyFile)
 
 		if len(certFile) == 0 && len(keyFile) == 0 {
-			log.Warnf("Invalid TLS configuration (no cert and no key): %s", tlsConfPath)
+			logger.Warnf("Invalid TLS configuration (no cert and no key): %s", tlsConfPath)
 			continue
 		}
 
 		entryPoints := p.getList(tlsConfPath, pathTLSEntryPoints)
 		if len(entryPoints) == 0 {
-			log.Warnf("Invalid TLS configuration (no entry points): %s", tlsConfPath)
+			logger.Warnf("Invalid TLS configuration (no entry points): %s", tlsConfPath)
 			continue
 		}
 
@@ -389,6 +389,7 @@ func (p *Provider) getTLSSection(prefix string) []*tls.Configuration {
 				CertFile: tls.FileOrContent(certFile),
 				KeyFile:  tls.FileOrContent(keyFile),
 			},
+			Default: p.getBool(false, tlsConfPath, pathTLSDefault),
 		}
 
 		tlsSection = append(tlsSection, tlsConf)
@@ -397,6 +398,31 @@ func (p *Provider) getTLSSection(prefix string) []*tls.Configuration {
 	return tlsSection
 }
 
+func (p *Provider) getClientCAsSection(prefix string) []*tls.ClientCAConfiguration {
+	var clientCAsSection []*tls.ClientCAConfiguration
+
+	for _, clientCAPath := range p.list(prefix, pathClientCAs) {
+		ca := p.get("", clientCAPath, pathClientCACertificates)
+		if len(ca) == 0 {
+			logger.Warnf("Invalid client CA configuration (no CA): %s", clientCAPath)
+			continue
+		}
+
+		entryPoints := p.getList(clientCAPath, pathClientCAEntryPoints)
+		if len(entryPoints) == 0 {
+			logger.Warnf("Invalid client CA configuration (no entry points): %s", clientCAPath)
+			continue
+		}
+
+		clientCAsSection = append(clientCAsSection, &tls.ClientCAConfiguration{
+			EntryPoints: entryPoints,
+			Files:       tls.FilesOrContents{tls.FileOrContent(ca)},
+		})
+	}
+
+	return clientCAsSection
+}
+
 // getTLSClientCert create TLS client header configuration from labels
 func (p *Provider) getTLSClientCert(rootPath string) *types.TLSClientHeaders {
 	if !p.hasPrefix(rootPath, pathFrontendPassTLSClientCert) {
@@ -481,7 +507,7 @@ func (p *Provider) getAuthBasic(rootPath string) *types.Basic {
 	// backward compatibility
 	if p.hasDeprecatedBasicAuth(rootPath) {
 		basicAuth.Users = p.getList(rootPath, pathFrontendBasicAuth)
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", pathFrontendBasicAuth, pathFrontendAuthBasic)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", pathFrontendBasicAuth, pathFrontendAuthBasic)
 	} else {
 		basicAuth.Users = p.getList(rootPath, pathFrontendAuthBasicUsers)
 	}
@@ -576,7 +602,7 @@ func (p *Provider) listServers(backend string) []string {
 func (p *Provider) serverFilter(serverName string) bool {
 	key := fmt.Sprint(serverName, pathBackendServerURL)
 	if _, err := p.kvClient.Get(key, nil); err != nil {
-		log.Errorf("Failed to retrieve value for key %s: %s", key, err)
+		logger.Errorf("Failed to retrieve value for key %s: %s", key, err)
 		checkError(err)
 
 		return false
@@ -600,7 +626,7 @@ func (p *Provider) checkConstraints(keys ...string) bool {
 	ok, failingConstraint := p.MatchConstraints(constraintTags)
 	if !ok {
 		if failingConstraint != nil {
-			log.Debugf("Constraint %v not matching with following tags: %v", failingConstraint.String(), value)
+			logger.Debugf("Constraint %v not matching with following tags: %v", failingConstraint.String(), value)
 		}
 		return false
 	}
@@ -640,10 +666,10 @@ func (p *Provider) get(defaultValue string, keyParts ...string) string {
 
 	keyPair, err := p.kvClient.Get(key, nil)
 	if err != nil || keyPair == nil {
-		log.Debugf("Cannot get key %s %s", key, err)
+		logger.Debugf("Cannot get key %s %s", key, err)
 		checkError(err)
 
-		log.Debugf("Setting %s to default: %s", key, defaultValue)
+		logger.Debugf("Setting %s to default: %s", key, defaultValue)
 		return defaultValue
 	}
 
@@ -659,7 +685,7 @@ func (p *Provider) getBool(defaultValue bool, keyParts ...string) bool {
 
 	value, err := strconv.ParseBool(rawValue)
 	if err != nil {
-		log.Errorf("Invalid value for %v: %s", keyParts, rawValue)
+		logger.Errorf("Invalid value for %v: %s", keyParts, rawValue)
 		return defaultValue
 	}
 	return value
@@ -678,7 +704,7 @@ func (p *Provider) hasPrefix(keyParts ...string) bool {
 
 	listKeys, err := p.kvClient.List(baseKey, nil)
 	if err != nil {
-		log.Debugf("Cannot list keys under %q: %v", baseKey, err)
+		logger.Debugf("Cannot list keys under %q: %v", baseKey, err)
 		checkError(err)
 
 		return false
@@ -696,7 +722,7 @@ func (p *Provider) getInt(defaultValue int, keyParts ...string) int {
 
 	value, err := strconv.Atoi(rawValue)
 	if err != nil {
-		log.Errorf("Invalid value for %v: %s", keyParts, rawValue)
+		logger.Errorf("Invalid value for %v: %s", keyParts, rawValue)
 		return defaultValue
 	}
 	return value
@@ -711,7 +737,7 @@ func (p *Provider) getInt64(defaultValue int64, keyParts ...string) int64 {
 
 	value, err := strconv.ParseInt(rawValue, 10, 64)
 	if err != nil {
-		log.Errorf("Invalid value for %v: %s", keyParts, rawValue)
+		logger.Errorf("Invalid value for %v: %s", keyParts, rawValue)
 		return defaultValue
 	}
 	return value
@@ -722,7 +748,7 @@ func (p *Provider) list(keyParts ...string) []string {
 
 	keysPairs, err := p.kvClient.List(rootKey, nil)
 	if err != nil {
-		log.Debugf("Cannot list keys under %q: %v", rootKey, err)
+		logger.Debugf("Cannot list keys under %q: %v", rootKey, err)
 		checkError(err)
 
 		return nil
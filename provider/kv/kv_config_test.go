@@ -2245,6 +2245,7 @@ func TestProviderGetTLSes(t *testing.T) {
 					withPair("certificate/keyfile", "keyfile1")),
 				entry("tls/bar",
 					withPair("entrypoints", "http,https"),
+					withPair("default", "true"),
 					withPair("certificate/certfile", "certfile2"),
 					withPair("certificate/keyfile", "keyfile2"))),
 			expected: []*tls.Configuration{
@@ -2254,6 +2255,7 @@ func TestProviderGetTLSes(t *testing.T) {
 						CertFile: "certfile2",
 						KeyFile:  "keyfile2",
 					},
+					Default: true,
 				},
 				{
 					EntryPoints: []string{"http", "https"},
@@ -2301,6 +2303,62 @@ func TestProviderGetTLSes(t *testing.T) {
 	}
 }
 
+func TestProviderGetClientCAs(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		kvPairs  []*store.KVPair
+		expected []*tls.ClientCAConfiguration
+	}{
+		{
+			desc: "when several client CAs defined",
+			kvPairs: filler("traefik",
+				entry("clientcas/foo",
+					withPair("entrypoints", "https"),
+					withPair("ca", "ca1")),
+				entry("clientcas/bar",
+					withPair("entrypoints", "https,admin"),
+					withPair("ca", "ca2"))),
+			expected: []*tls.ClientCAConfiguration{
+				{
+					EntryPoints: []string{"https", "admin"},
+					Files:       tls.FilesOrContents{"ca2"},
+				},
+				{
+					EntryPoints: []string{"https"},
+					Files:       tls.FilesOrContents{"ca1"},
+				},
+			},
+		},
+		{
+			desc: "should return nil when no CA",
+			kvPairs: filler("traefik",
+				entry("clientcas/foo",
+					withPair("entrypoints", "https"))),
+			expected: nil,
+		},
+		{
+			desc: "should return nil when no entry points",
+			kvPairs: filler("traefik",
+				entry("clientcas/foo",
+					withPair("ca", "ca1"))),
+			expected: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getClientCAsSection("traefik")
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
 func TestProviderGetAuth(t *testing.T) {
 	testCases := []struct {
 		desc     string
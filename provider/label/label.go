@@ -10,6 +10,8 @@ import (
 	"github.com/containous/traefik/log"
 )
 
+var logger = log.WithModule("provider/label")
+
 const (
 	mapEntrySeparator = "||"
 	mapValueSeparator = ":"
@@ -53,7 +55,7 @@ func GetBoolValue(labels map[string]string, labelName string, defaultValue bool)
 		if err == nil {
 			return v
 		}
-		log.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
+		logger.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
 	}
 	return defaultValue
 }
@@ -65,7 +67,7 @@ func GetIntValue(labels map[string]string, labelName string, defaultValue int) i
 		if err == nil {
 			return value
 		}
-		log.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
+		logger.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
 	}
 	return defaultValue
 }
@@ -77,7 +79,19 @@ func GetInt64Value(labels map[string]string, labelName string, defaultValue int6
 		if err == nil {
 			return value
 		}
-		log.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
+		logger.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
+	}
+	return defaultValue
+}
+
+// GetFloat64Value get float64 value associated to a label
+func GetFloat64Value(labels map[string]string, labelName string, defaultValue float64) float64 {
+	if rawValue, ok := labels[labelName]; ok {
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err == nil {
+			return value
+		}
+		logger.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
 	}
 	return defaultValue
 }
@@ -90,7 +104,7 @@ func GetSliceStringValue(labels map[string]string, labelName string) []string {
 		value = SplitAndTrimString(values, ",")
 
 		if len(value) == 0 {
-			log.Debugf("Could not load %q.", labelName)
+			logger.Debugf("Could not load %q.", labelName)
 		}
 	}
 	return value
@@ -103,14 +117,14 @@ func ParseMapValue(labelName, values string) map[string]string {
 	for _, parts := range strings.Split(values, mapEntrySeparator) {
 		pair := strings.SplitN(parts, mapValueSeparator, 2)
 		if len(pair) != 2 {
-			log.Warnf("Could not load %q: %q, skipping...", labelName, parts)
+			logger.Warnf("Could not load %q: %q, skipping...", labelName, parts)
 		} else {
 			mapValue[http.CanonicalHeaderKey(strings.TrimSpace(pair[0]))] = strings.TrimSpace(pair[1])
 		}
 	}
 
 	if len(mapValue) == 0 {
-		log.Errorf("Could not load %q, skipping...", labelName)
+		logger.Errorf("Could not load %q, skipping...", labelName)
 		return nil
 	}
 	return mapValue
@@ -121,7 +135,7 @@ func GetMapValue(labels map[string]string, labelName string) map[string]string {
 	if values, ok := labels[labelName]; ok {
 
 		if len(values) == 0 {
-			log.Errorf("Missing value for %q, skipping...", labelName)
+			logger.Errorf("Missing value for %q, skipping...", labelName)
 			return nil
 		}
 
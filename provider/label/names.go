@@ -36,6 +36,11 @@ const (
 	SuffixBackendBufferingMemResponseBodyBytes                  = SuffixBackendBuffering + ".memResponseBodyBytes"
 	SuffixBackendBufferingRetryExpression                       = SuffixBackendBuffering + ".retryExpression"
 	SuffixFrontend                                              = "frontend"
+	SuffixFrontendAccessLog                                     = SuffixFrontend + ".accessLog"
+	SuffixFrontendAccessLogDisabled                             = SuffixFrontendAccessLog + ".disabled"
+	SuffixFrontendAccessLogVerbose                              = SuffixFrontendAccessLog + ".verbose"
+	SuffixFrontendAccessLogSampleRate                           = SuffixFrontendAccessLog + ".sampleRate"
+	SuffixFrontendAccessLogFilePath                             = SuffixFrontendAccessLog + ".filePath"
 	SuffixFrontendAuth                                          = SuffixFrontend + ".auth"
 	SuffixFrontendAuthBasic                                     = SuffixFrontendAuth + ".basic"
 	SuffixFrontendAuthBasicRemoveHeader                         = SuffixFrontendAuthBasic + ".removeHeader"
@@ -148,6 +153,11 @@ const (
 	TraefikBackendBufferingMemResponseBodyBytes                 = Prefix + SuffixBackendBufferingMemResponseBodyBytes
 	TraefikBackendBufferingRetryExpression                      = Prefix + SuffixBackendBufferingRetryExpression
 	TraefikFrontend                                             = Prefix + SuffixFrontend
+	TraefikFrontendAccessLog                                    = Prefix + SuffixFrontendAccessLog
+	TraefikFrontendAccessLogDisabled                            = Prefix + SuffixFrontendAccessLogDisabled
+	TraefikFrontendAccessLogVerbose                             = Prefix + SuffixFrontendAccessLogVerbose
+	TraefikFrontendAccessLogSampleRate                          = Prefix + SuffixFrontendAccessLogSampleRate
+	TraefikFrontendAccessLogFilePath                            = Prefix + SuffixFrontendAccessLogFilePath
 	TraefikFrontendAuth                                         = Prefix + SuffixFrontendAuth
 	TraefikFrontendAuthBasic                                    = Prefix + SuffixFrontendAuthBasic
 	TraefikFrontendAuthBasicRemoveHeader                        = Prefix + SuffixFrontendAuthBasicRemoveHeader
@@ -7,14 +7,13 @@ import (
 	"strings"
 
 	"github.com/containous/flaeg"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/types"
 )
 
 // GetWhiteList Create white list from labels
 func GetWhiteList(labels map[string]string) *types.WhiteList {
 	if Has(labels, TraefikFrontendWhitelistSourceRange) {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", TraefikFrontendWhitelistSourceRange, TraefikFrontendWhiteListSourceRange)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", TraefikFrontendWhitelistSourceRange, TraefikFrontendWhiteListSourceRange)
 	}
 
 	ranges := GetSliceStringValue(labels, TraefikFrontendWhiteListSourceRange)
@@ -60,6 +59,28 @@ func GetRedirect(labels map[string]string) *types.Redirect {
 	return nil
 }
 
+// GetAccessLog Create the access log settings of a frontend from labels
+func GetAccessLog(labels map[string]string) *types.FrontendAccessLog {
+	if !HasPrefix(labels, TraefikFrontendAccessLog+".") {
+		return nil
+	}
+
+	filePath := GetStringValue(labels, TraefikFrontendAccessLogFilePath, "")
+	if len(filePath) > 0 {
+		if err := types.CheckFrontendAccessLogFilePath(filePath); err != nil {
+			logger.Errorf("Ignoring the label %s: %v", TraefikFrontendAccessLogFilePath, err)
+			filePath = ""
+		}
+	}
+
+	return &types.FrontendAccessLog{
+		Disabled:   GetBoolValue(labels, TraefikFrontendAccessLogDisabled, false),
+		Verbose:    GetBoolValue(labels, TraefikFrontendAccessLogVerbose, false),
+		SampleRate: GetFloat64Value(labels, TraefikFrontendAccessLogSampleRate, 0),
+		FilePath:   filePath,
+	}
+}
+
 // GetTLSClientCert create TLS client header configuration from labels
 func GetTLSClientCert(labels map[string]string) *types.TLSClientHeaders {
 	if !HasPrefix(labels, TraefikFrontendPassTLSClientCert) {
@@ -140,7 +161,7 @@ func getAuthBasic(labels map[string]string) *types.Basic {
 	// backward compatibility
 	if Has(labels, TraefikFrontendAuthBasic) {
 		basicAuth.Users = GetSliceStringValue(labels, TraefikFrontendAuthBasic)
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", TraefikFrontendAuthBasic, TraefikFrontendAuthBasicUsers)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", TraefikFrontendAuthBasic, TraefikFrontendAuthBasicUsers)
 	} else {
 		basicAuth.Users = GetSliceStringValue(labels, TraefikFrontendAuthBasicUsers)
 	}
@@ -193,7 +214,7 @@ func ParseErrorPages(labels map[string]string, labelPrefix string, labelRegex *r
 		if strings.HasPrefix(lblName, labelPrefix) {
 			submatch := labelRegex.FindStringSubmatch(lblName)
 			if len(submatch) != 3 {
-				log.Errorf("Invalid page error label: %s, sub-match: %v", lblName, submatch)
+				logger.Errorf("Invalid page error label: %s, sub-match: %v", lblName, submatch)
 				continue
 			}
 
@@ -217,7 +238,7 @@ func ParseErrorPages(labels map[string]string, labelPrefix string, labelRegex *r
 			case SuffixErrorPageBackend:
 				ep.Backend = value
 			default:
-				log.Errorf("Invalid page error label: %s", lblName)
+				logger.Errorf("Invalid page error label: %s", lblName)
 				continue
 			}
 		}
@@ -250,7 +271,7 @@ func ParseRateSets(labels map[string]string, labelPrefix string, labelRegex *reg
 		if strings.HasPrefix(lblName, labelPrefix) && len(rawValue) > 0 {
 			submatch := labelRegex.FindStringSubmatch(lblName)
 			if len(submatch) != 3 {
-				log.Errorf("Invalid rate limit label: %s, sub-match: %v", lblName, submatch)
+				logger.Errorf("Invalid rate limit label: %s, sub-match: %v", lblName, submatch)
 				continue
 			}
 
@@ -271,26 +292,26 @@ func ParseRateSets(labels map[string]string, labelPrefix string, labelRegex *reg
 				var d flaeg.Duration
 				err := d.Set(rawValue)
 				if err != nil {
-					log.Errorf("Unable to parse %q: %q. %v", lblName, rawValue, err)
+					logger.Errorf("Unable to parse %q: %q. %v", lblName, rawValue, err)
 					continue
 				}
 				ep.Period = d
 			case "average":
 				value, err := strconv.ParseInt(rawValue, 10, 64)
 				if err != nil {
-					log.Errorf("Unable to parse %q: %q. %v", lblName, rawValue, err)
+					logger.Errorf("Unable to parse %q: %q. %v", lblName, rawValue, err)
 					continue
 				}
 				ep.Average = value
 			case "burst":
 				value, err := strconv.ParseInt(rawValue, 10, 64)
 				if err != nil {
-					log.Errorf("Unable to parse %q: %q. %v", lblName, rawValue, err)
+					logger.Errorf("Unable to parse %q: %q. %v", lblName, rawValue, err)
 					continue
 				}
 				ep.Burst = value
 			default:
-				log.Errorf("Invalid rate limit label: %s", lblName)
+				logger.Errorf("Invalid rate limit label: %s", lblName)
 				continue
 			}
 		}
@@ -433,7 +454,7 @@ func GetLoadBalancer(labels map[string]string) *types.LoadBalancer {
 // Deprecated
 func getSticky(labels map[string]string) bool {
 	if Has(labels, TraefikBackendLoadBalancerSticky) {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", TraefikBackendLoadBalancerSticky, TraefikBackendLoadBalancerStickiness)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", TraefikBackendLoadBalancerSticky, TraefikBackendLoadBalancerStickiness)
 	}
 
 	return GetBoolValue(labels, TraefikBackendLoadBalancerSticky, false)
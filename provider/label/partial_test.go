@@ -524,6 +524,74 @@ func TestGetRedirect(t *testing.T) {
 	}
 }
 
+func TestGetAccessLog(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.FrontendAccessLog
+	}{
+		{
+			desc:     "should return nil when no access log labels",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when access log disabled label",
+			labels: map[string]string{
+				TraefikFrontendAccessLogDisabled: "true",
+			},
+			expected: &types.FrontendAccessLog{
+				Disabled: true,
+			},
+		},
+		{
+			desc: "should return a struct when access log labels",
+			labels: map[string]string{
+				TraefikFrontendAccessLogVerbose:    "true",
+				TraefikFrontendAccessLogSampleRate: "0.01",
+				TraefikFrontendAccessLogFilePath:   "health/access.log",
+			},
+			expected: &types.FrontendAccessLog{
+				Verbose:    true,
+				SampleRate: 0.01,
+				FilePath:   "health/access.log",
+			},
+		},
+		{
+			desc: "should ignore an absolute file path",
+			labels: map[string]string{
+				TraefikFrontendAccessLogFilePath: "/etc/cron.d/access.log",
+			},
+			expected: &types.FrontendAccessLog{},
+		},
+		{
+			desc: "should ignore a file path outside of the frontend files directory",
+			labels: map[string]string{
+				TraefikFrontendAccessLogFilePath: "health/../../etc/cron.d/access.log",
+			},
+			expected: &types.FrontendAccessLog{},
+		},
+		{
+			desc: "should ignore an invalid sample rate",
+			labels: map[string]string{
+				TraefikFrontendAccessLogSampleRate: "foo",
+			},
+			expected: &types.FrontendAccessLog{},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := GetAccessLog(test.labels)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestGetRateLimit(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -3,8 +3,6 @@ package label
 import (
 	"regexp"
 	"strings"
-
-	"github.com/containous/traefik/log"
 )
 
 var (
@@ -129,8 +127,8 @@ func ExtractTraefikLabels(originLabels map[string]string) SegmentProperties {
 		}
 	}
 
-	log.Debug("originLabels", originLabels)
-	log.Debug("allLabels", allLabels)
+	logger.Debug("originLabels", originLabels)
+	logger.Debug("allLabels", allLabels)
 
 	allLabels.mergeDefault()
 
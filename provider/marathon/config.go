@@ -9,7 +9,6 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -53,6 +52,7 @@ func (p *Provider) buildConfigurationV2(applications *marathon.Applications) *ty
 		"getBasicAuth":         label.GetFuncSliceString(label.TraefikFrontendAuthBasic), // Deprecated
 		"getAuth":              label.GetAuth,
 		"getRedirect":          label.GetRedirect,
+		"getAccessLog":         label.GetAccessLog,
 		"getErrorPages":        label.GetErrorPages,
 		"getRateLimit":         label.GetRateLimit,
 		"getHeaders":           label.GetHeaders,
@@ -102,7 +102,7 @@ func (p *Provider) buildConfigurationV2(applications *marathon.Applications) *ty
 
 	configuration, err := p.GetConfiguration("templates/marathon.tmpl", MarathonFuncMap, templateObjects)
 	if err != nil {
-		log.Errorf("Failed to render Marathon configuration template: %v", err)
+		logger.Errorf("Failed to render Marathon configuration template: %v", err)
 	}
 	return configuration
 }
@@ -110,7 +110,7 @@ func (p *Provider) buildConfigurationV2(applications *marathon.Applications) *ty
 func (p *Provider) applicationFilter(app marathon.Application) bool {
 	// Filter disabled application.
 	if !label.IsEnabled(stringValueMap(app.Labels), p.ExposedByDefault) {
-		log.Debugf("Filtering disabled Marathon application %s", app.ID)
+		logger.Debugf("Filtering disabled Marathon application %s", app.ID)
 		return false
 	}
 
@@ -128,7 +128,7 @@ func (p *Provider) applicationFilter(app marathon.Application) bool {
 	}
 	if ok, failingConstraint := p.MatchConstraints(constraintTags); !ok {
 		if failingConstraint != nil {
-			log.Debugf("Filtering Marathon application %s pruned by %q constraint", app.ID, failingConstraint.String())
+			logger.Debugf("Filtering Marathon application %s pruned by %q constraint", app.ID, failingConstraint.String())
 		}
 		return false
 	}
@@ -142,7 +142,7 @@ func (p *Provider) taskFilter(task marathon.Task, application marathon.Applicati
 	}
 
 	if ready := p.readyChecker.Do(task, application); !ready {
-		log.Infof("Filtering unready task %s from application %s", task.ID, application.ID)
+		logger.Infof("Filtering unready task %s from application %s", task.ID, application.ID)
 		return false
 	}
 
@@ -157,7 +157,7 @@ func logIllegalServices(task marathon.Task, app marathon.Application) {
 	for segmentName, labels := range segmentProperties {
 		// Check for illegal/missing ports.
 		if _, err := processPorts(app, task, labels); err != nil {
-			log.Warnf("%s has an illegal configuration: no proper port available", identifier(app, task, segmentName))
+			logger.Warnf("%s has an illegal configuration: no proper port available", identifier(app, task, segmentName))
 			continue
 		}
 
@@ -165,7 +165,7 @@ func logIllegalServices(task marathon.Task, app marathon.Application) {
 		hasPortLabel := label.Has(labels, label.TraefikPort)
 		hasPortIndexLabel := label.Has(labels, label.TraefikPortIndex)
 		if hasPortLabel && hasPortIndexLabel {
-			log.Warnf("%s has both port and port index specified; port will take precedence", identifier(app, task, segmentName))
+			logger.Warnf("%s has both port and port index specified; port will take precedence", identifier(app, task, segmentName))
 		}
 	}
 }
@@ -228,7 +228,7 @@ func (p *Provider) getFrontendRule(app appData) string {
 func getPort(task marathon.Task, app appData) string {
 	port, err := processPorts(app.Application, task, app.SegmentLabels)
 	if err != nil {
-		log.Errorf("Unable to process ports for %s: %s", identifier(app.Application, task, app.SegmentName), err)
+		logger.Errorf("Unable to process ports for %s: %s", identifier(app.Application, task, app.SegmentName), err)
 		return ""
 	}
 
@@ -305,7 +305,7 @@ func (p *Provider) getServers(app appData) map[string]types.Server {
 	for _, task := range app.Tasks {
 		name, server, err := p.getServer(app, *task)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 			continue
 		}
 
@@ -320,7 +320,7 @@ func (p *Provider) getServers(app appData) map[string]types.Server {
 		for _, task := range linkedApp.Tasks {
 			name, server, err := p.getServer(*linkedApp, *task)
 			if err != nil {
-				log.Error(err)
+				logger.Error(err)
 				continue
 			}
 
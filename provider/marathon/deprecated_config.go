@@ -9,7 +9,6 @@ import (
 	"text/template"
 
 	"github.com/BurntSushi/ty/fun"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -78,7 +77,7 @@ func (p *Provider) buildConfigurationV1(applications *marathon.Applications) *ty
 
 	configuration, err := p.GetConfiguration("templates/marathon-v1.tmpl", MarathonFuncMap, templateObjects)
 	if err != nil {
-		log.Errorf("Failed to render Marathon configuration template: %v", err)
+		logger.Errorf("Failed to render Marathon configuration template: %v", err)
 	}
 	return configuration
 }
@@ -91,7 +90,7 @@ func logIllegalServicesV1(task marathon.Task, app marathon.Application) {
 	for _, serviceName := range getServiceNamesV1(app) {
 		// Check for illegal/missing ports.
 		if _, err := processPortsV1(app, task, serviceName); err != nil {
-			log.Warnf("%s has an illegal configuration: no proper port available", identifierV1(app, task, serviceName))
+			logger.Warnf("%s has an illegal configuration: no proper port available", identifierV1(app, task, serviceName))
 			continue
 		}
 
@@ -100,7 +99,7 @@ func logIllegalServicesV1(task marathon.Task, app marathon.Application) {
 		hasPortLabel := label.Has(labels, getLabelNameV1(serviceName, label.SuffixPort))
 		hasPortIndexLabel := label.Has(labels, getLabelNameV1(serviceName, label.SuffixPortIndex))
 		if hasPortLabel && hasPortIndexLabel {
-			log.Warnf("%s has both port and port index specified; port will take precedence", identifierV1(app, task, serviceName))
+			logger.Warnf("%s has both port and port index specified; port will take precedence", identifierV1(app, task, serviceName))
 		}
 	}
 }
@@ -164,7 +163,7 @@ func (p *Provider) getBackendServerV1(task marathon.Task, application marathon.A
 
 	switch numTaskIPAddresses {
 	case 0:
-		log.Errorf("Missing IP address for Marathon application %s on task %s", application.ID, task.ID)
+		logger.Errorf("Missing IP address for Marathon application %s on task %s", application.ID, task.ID)
 		return ""
 	case 1:
 		return task.IPAddresses[0].IPAddress
@@ -172,12 +171,12 @@ func (p *Provider) getBackendServerV1(task marathon.Task, application marathon.A
 		ipAddressIdx := label.GetIntValue(stringValueMap(application.Labels), labelIPAddressIdx, math.MinInt32)
 
 		if ipAddressIdx == math.MinInt32 {
-			log.Errorf("Found %d task IP addresses but missing IP address index for Marathon application %s on task %s",
+			logger.Errorf("Found %d task IP addresses but missing IP address index for Marathon application %s on task %s",
 				numTaskIPAddresses, application.ID, task.ID)
 			return ""
 		}
 		if ipAddressIdx < 0 || ipAddressIdx > numTaskIPAddresses {
-			log.Errorf("Cannot use IP address index to select from %d task IP addresses for Marathon application %s on task %s",
+			logger.Errorf("Cannot use IP address index to select from %d task IP addresses for Marathon application %s on task %s",
 				numTaskIPAddresses, application.ID, task.ID)
 			return ""
 		}
@@ -235,7 +234,7 @@ func hasMaxConnLabelsV1(application marathon.Application) bool {
 // Deprecated
 func getStickyV1(application marathon.Application) bool {
 	if label.Has(stringValueMap(application.Labels), label.TraefikBackendLoadBalancerSticky) {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
 	}
 	return label.GetBoolValue(stringValueMap(application.Labels), label.TraefikBackendLoadBalancerSticky, false)
 }
@@ -244,7 +243,7 @@ func getStickyV1(application marathon.Application) bool {
 func getPortV1(task marathon.Task, application marathon.Application, serviceName string) string {
 	port, err := processPortsV1(application, task, serviceName)
 	if err != nil {
-		log.Errorf("Unable to process ports for %s: %s", identifierV1(application, task, serviceName), err)
+		logger.Errorf("Unable to process ports for %s: %s", identifierV1(application, task, serviceName), err)
 		return ""
 	}
 
@@ -17,6 +17,8 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var logger = log.WithModule("provider/marathon")
+
 const (
 	traceMaxScanTokenSize = 1024 * 1024
 	marathonEventIDs      = marathon.EventIDApplications |
@@ -93,7 +95,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 		}
 		var rc *readinessChecker
 		if p.RespectReadinessChecks {
-			log.Debug("Enabling Marathon readiness checker")
+			logger.Debug("Enabling Marathon readiness checker")
 			rc = defaultReadinessChecker(p.Trace)
 		}
 		p.readyChecker = rc
@@ -118,7 +120,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 		}
 		client, err := marathon.NewClient(config)
 		if err != nil {
-			log.Errorf("Failed to create a client for marathon, error: %s", err)
+			logger.Errorf("Failed to create a client for marathon, error: %s", err)
 			return err
 		}
 		p.marathonClient = client
@@ -126,7 +128,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 		if p.Watch {
 			update, err := client.AddEventsListener(marathonEventIDs)
 			if err != nil {
-				log.Errorf("Failed to register for events, %s", err)
+				logger.Errorf("Failed to register for events, %s", err)
 				return err
 			}
 			pool.Go(func(stop chan bool) {
@@ -136,7 +138,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 					case <-stop:
 						return
 					case event := <-update:
-						log.Debugf("Received provider event %s", event)
+						logger.Debugf("Received provider event %s", event)
 
 						configuration := p.getConfiguration()
 						if configuration != nil {
@@ -159,11 +161,11 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 	}
 
 	notify := func(err error, time time.Duration) {
-		log.Errorf("Provider connection error %+v, retrying in %s", err, time)
+		logger.Errorf("Provider connection error %+v, retrying in %s", err, time)
 	}
 	err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 	if err != nil {
-		log.Errorf("Cannot connect to Provider server %+v", err)
+		logger.Errorf("Cannot connect to Provider server %+v", err)
 	}
 	return nil
 }
@@ -171,7 +173,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 func (p *Provider) getConfiguration() *types.Configuration {
 	applications, err := p.getApplications()
 	if err != nil {
-		log.Errorf("Failed to retrieve Marathon applications: %v", err)
+		logger.Errorf("Failed to retrieve Marathon applications: %v", err)
 		return nil
 	}
 
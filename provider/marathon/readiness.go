@@ -3,7 +3,6 @@ package marathon
 import (
 	"time"
 
-	"github.com/containous/traefik/log"
 	"github.com/gambol99/go-marathon"
 )
 
@@ -98,7 +97,7 @@ func (rc *readinessChecker) Do(task marathon.Task, app marathon.Application) boo
 		// An unparseable start time should never occur; if it does, we assume the
 		// problem should be surfaced as quickly as possible, which is easiest if
 		// we shun the task from rotation.
-		log.Warnf("Failed to parse start-time %s of task %s from application %s: %s (assuming unready)", task.StartedAt, task.ID, app.ID, err)
+		logger.Warnf("Failed to parse start-time %s of task %s from application %s: %s (assuming unready)", task.StartedAt, task.ID, app.ID, err)
 		return false
 	}
 
@@ -117,6 +116,6 @@ func (rc *readinessChecker) Do(task marathon.Task, app marathon.Application) boo
 
 func (rc *readinessChecker) tracef(format string, args ...interface{}) {
 	if rc.traceLogging {
-		log.Debugf(readinessLogHeader+format, args...)
+		logger.Debugf(readinessLogHeader+format, args...)
 	}
 }
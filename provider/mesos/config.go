@@ -8,7 +8,6 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -52,6 +51,7 @@ func (p *Provider) buildConfigurationV2(tasks []state.Task) *types.Configuration
 		"getPassTLSClientCert": label.GetTLSClientCert,
 		"getFrontendRule":      p.getFrontendRule,
 		"getRedirect":          label.GetRedirect,
+		"getAccessLog":         label.GetAccessLog,
 		"getErrorPages":        label.GetErrorPages,
 		"getRateLimit":         label.GetRateLimit,
 		"getHeaders":           label.GetHeaders,
@@ -70,7 +70,7 @@ func (p *Provider) buildConfigurationV2(tasks []state.Task) *types.Configuration
 
 	configuration, err := p.GetConfiguration("templates/mesos.tmpl", mesosFuncMap, templateObjects)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 
 	return configuration
@@ -106,11 +106,11 @@ func taskFilter(task taskData, exposedByDefaultFlag bool) bool {
 	name := getName(task)
 
 	if len(task.DiscoveryInfo.Ports.DiscoveryPorts) == 0 {
-		log.Debugf("Filtering Mesos task without port %s", name)
+		logger.Debugf("Filtering Mesos task without port %s", name)
 		return false
 	}
 	if !isEnabled(task, exposedByDefaultFlag) {
-		log.Debugf("Filtering disabled Mesos task %s", name)
+		logger.Debugf("Filtering disabled Mesos task %s", name)
 		return false
 	}
 
@@ -119,20 +119,20 @@ func taskFilter(task taskData, exposedByDefaultFlag bool) bool {
 	portNameLabel := label.GetStringValue(task.TraefikLabels, label.TraefikPortName, "")
 	portValueLabel := label.GetStringValue(task.TraefikLabels, label.TraefikPort, "")
 	if portIndexLabel != "" && portValueLabel != "" {
-		log.Debugf("Filtering Mesos task %s specifying both %q' and %q labels", task.Name, label.TraefikPortIndex, label.TraefikPort)
+		logger.Debugf("Filtering Mesos task %s specifying both %q' and %q labels", task.Name, label.TraefikPortIndex, label.TraefikPort)
 		return false
 	}
 	if portIndexLabel != "" {
 		index, err := strconv.Atoi(portIndexLabel)
 		if err != nil || index < 0 || index > len(task.DiscoveryInfo.Ports.DiscoveryPorts)-1 {
-			log.Debugf("Filtering Mesos task %s with unexpected value for %q label", task.Name, label.TraefikPortIndex)
+			logger.Debugf("Filtering Mesos task %s with unexpected value for %q label", task.Name, label.TraefikPortIndex)
 			return false
 		}
 	}
 	if portValueLabel != "" {
 		port, err := strconv.Atoi(portValueLabel)
 		if err != nil {
-			log.Debugf("Filtering Mesos task %s with unexpected value for %q label", task.Name, label.TraefikPort)
+			logger.Debugf("Filtering Mesos task %s with unexpected value for %q label", task.Name, label.TraefikPort)
 			return false
 		}
 
@@ -145,7 +145,7 @@ func taskFilter(task taskData, exposedByDefaultFlag bool) bool {
 		}
 
 		if !foundPort {
-			log.Debugf("Filtering Mesos task %s without a matching port for %q label", task.Name, label.TraefikPort)
+			logger.Debugf("Filtering Mesos task %s without a matching port for %q label", task.Name, label.TraefikPort)
 			return false
 		}
 	}
@@ -159,14 +159,14 @@ func taskFilter(task taskData, exposedByDefaultFlag bool) bool {
 		}
 
 		if !foundPort {
-			log.Debugf("Filtering Mesos task %s without a matching port for %q label", task.Name, label.TraefikPortName)
+			logger.Debugf("Filtering Mesos task %s without a matching port for %q label", task.Name, label.TraefikPortName)
 			return false
 		}
 	}
 
 	// filter healthChecks
 	if task.Statuses != nil && len(task.Statuses) > 0 && task.Statuses[0].Healthy != nil && !*task.Statuses[0].Healthy {
-		log.Debugf("Filtering Mesos task %s with bad healthCheck", name)
+		logger.Debugf("Filtering Mesos task %s with bad healthCheck", name)
 		return false
 
 	}
@@ -260,7 +260,7 @@ func (p *Provider) getServerPort(task taskData) string {
 	if label.Has(task.TraefikLabels, label.TraefikPort) {
 		pv := label.GetIntValue(task.TraefikLabels, label.TraefikPort, 0)
 		if pv <= 0 {
-			log.Errorf("explicitly specified port %d must be larger than zero", pv)
+			logger.Errorf("explicitly specified port %d must be larger than zero", pv)
 			return ""
 		}
 		return strconv.Itoa(pv)
@@ -297,7 +297,7 @@ func getIntValue(labels map[string]string, labelName string, defaultValue int, m
 	if value <= maxValue {
 		return value
 	}
-	log.Warnf("The value %d for %s exceed the max authorized value %d, falling back to %d.", value, labelName, maxValue, defaultValue)
+	logger.Warnf("The value %d for %s exceed the max authorized value %d, falling back to %d.", value, labelName, maxValue, defaultValue)
 	return defaultValue
 }
 
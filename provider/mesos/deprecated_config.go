@@ -8,7 +8,6 @@ import (
 	"text/template"
 
 	"github.com/BurntSushi/ty/fun"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -76,7 +75,7 @@ func (p *Provider) buildConfigurationV1(tasks []state.Task) *types.Configuration
 
 	configuration, err := p.GetConfiguration("templates/mesos-v1.tmpl", mesosFuncMap, templateObjects)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 	return configuration
 }
@@ -84,12 +83,12 @@ func (p *Provider) buildConfigurationV1(tasks []state.Task) *types.Configuration
 // Deprecated
 func taskFilterV1(task state.Task, exposedByDefaultFlag bool) bool {
 	if len(task.DiscoveryInfo.Ports.DiscoveryPorts) == 0 {
-		log.Debugf("Filtering Mesos task without port %s", task.Name)
+		logger.Debugf("Filtering Mesos task without port %s", task.Name)
 		return false
 	}
 
 	if !isEnabledV1(task, exposedByDefaultFlag) {
-		log.Debugf("Filtering disabled Mesos task %s", task.DiscoveryInfo.Name)
+		logger.Debugf("Filtering disabled Mesos task %s", task.DiscoveryInfo.Name)
 		return false
 	}
 
@@ -97,20 +96,20 @@ func taskFilterV1(task state.Task, exposedByDefaultFlag bool) bool {
 	portIndexLabel := getStringValueV1(task, label.TraefikPortIndex, "")
 	portValueLabel := getStringValueV1(task, label.TraefikPort, "")
 	if portIndexLabel != "" && portValueLabel != "" {
-		log.Debugf("Filtering Mesos task %s specifying both %q' and %q labels", task.Name, label.TraefikPortIndex, label.TraefikPort)
+		logger.Debugf("Filtering Mesos task %s specifying both %q' and %q labels", task.Name, label.TraefikPortIndex, label.TraefikPort)
 		return false
 	}
 	if portIndexLabel != "" {
 		index, err := strconv.Atoi(portIndexLabel)
 		if err != nil || index < 0 || index > len(task.DiscoveryInfo.Ports.DiscoveryPorts)-1 {
-			log.Debugf("Filtering Mesos task %s with unexpected value for %q label", task.Name, label.TraefikPortIndex)
+			logger.Debugf("Filtering Mesos task %s with unexpected value for %q label", task.Name, label.TraefikPortIndex)
 			return false
 		}
 	}
 	if portValueLabel != "" {
 		port, err := strconv.Atoi(portValueLabel)
 		if err != nil {
-			log.Debugf("Filtering Mesos task %s with unexpected value for %q label", task.Name, label.TraefikPort)
+			logger.Debugf("Filtering Mesos task %s with unexpected value for %q label", task.Name, label.TraefikPort)
 			return false
 		}
 
@@ -123,14 +122,14 @@ func taskFilterV1(task state.Task, exposedByDefaultFlag bool) bool {
 		}
 
 		if !foundPort {
-			log.Debugf("Filtering Mesos task %s without a matching port for %q label", task.Name, label.TraefikPort)
+			logger.Debugf("Filtering Mesos task %s without a matching port for %q label", task.Name, label.TraefikPort)
 			return false
 		}
 	}
 
 	// filter healthChecks
 	if task.Statuses != nil && len(task.Statuses) > 0 && task.Statuses[0].Healthy != nil && !*task.Statuses[0].Healthy {
-		log.Debugf("Filtering Mesos task %s with bad healthCheck", task.DiscoveryInfo.Name)
+		logger.Debugf("Filtering Mesos task %s with bad healthCheck", task.DiscoveryInfo.Name)
 		return false
 
 	}
@@ -146,7 +145,7 @@ func getIDV1(task state.Task) string {
 func getBackendV1(task state.Task, apps []state.Task) string {
 	_, err := getApplicationV1(task, apps)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 		return ""
 	}
 	return getBackendNameV1(task)
@@ -170,7 +169,7 @@ func getFrontendNameV1(task state.Task) string {
 func (p *Provider) getPort(task state.Task, applications []state.Task) string {
 	_, err := getApplicationV1(task, applications)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 		return ""
 	}
 
@@ -218,7 +217,7 @@ func getFuncApplicationStringValueV1(labelName string, defaultValue string) func
 	return func(task state.Task, applications []state.Task) string {
 		_, err := getApplicationV1(task, applications)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 			return defaultValue
 		}
 
@@ -231,7 +230,7 @@ func getFuncApplicationIntValueV1(labelName string, defaultValue int) func(task
 	return func(task state.Task, applications []state.Task) int {
 		_, err := getApplicationV1(task, applications)
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 			return defaultValue
 		}
 
@@ -299,9 +298,9 @@ func getIntValueV1(task state.Task, labelName string, defaultValue int, maxValue
 				if value <= maxValue {
 					return value
 				}
-				log.Warnf("The value %q for %q exceed the max authorized value %q, falling back to %v.", lbl.Value, labelName, maxValue, defaultValue)
+				logger.Warnf("The value %q for %q exceed the max authorized value %q, falling back to %v.", lbl.Value, labelName, maxValue, defaultValue)
 			} else {
-				log.Warnf("Unable to parse %q: %q, falling back to %v. %v", labelName, lbl.Value, defaultValue, err)
+				logger.Warnf("Unable to parse %q: %q, falling back to %v. %v", labelName, lbl.Value, defaultValue, err)
 			}
 		}
 	}
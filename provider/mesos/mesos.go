@@ -22,6 +22,8 @@ import (
 	"github.com/mesosphere/mesos-dns/util"
 )
 
+var logger = log.WithModule("provider/mesos")
+
 var _ provider.Provider = (*Provider)(nil)
 
 // Provider holds configuration of the provider.
@@ -51,7 +53,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 		// initialize logging
 		logging.SetupLogs()
 
-		log.Debugf("%s", p.IPSources)
+		logger.Debugf("%s", p.IPSources)
 
 		var zk string
 		var masters []string
@@ -99,7 +101,7 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 				} else {
 					timeout.Stop()
 				}
-				log.Debugf("new masters detected: %v", masters)
+				logger.Debugf("new masters detected: %v", masters)
 				p.Masters = masters
 				tasks := p.getTasks()
 				configuration := p.buildConfiguration(tasks)
@@ -110,17 +112,17 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 					}
 				}
 			case err := <-errch:
-				log.Errorf("%s", err)
+				logger.Errorf("%s", err)
 			}
 		}
 	}
 
 	notify := func(err error, time time.Duration) {
-		log.Errorf("Mesos connection error %+v, retrying in %s", err, time)
+		logger.Errorf("Mesos connection error %+v, retrying in %s", err, time)
 	}
 	err := backoff.RetryNotify(safe.OperationWithRecover(operation), job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 	if err != nil {
-		log.Errorf("Cannot connect to Mesos server %+v", err)
+		logger.Errorf("Cannot connect to Mesos server %+v", err)
 	}
 	return nil
 }
@@ -128,11 +130,11 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 func detectMasters(zk string, masters []string) <-chan []string {
 	changed := make(chan []string, 1)
 	if zk != "" {
-		log.Debugf("Starting master detector for ZK %s", zk)
+		logger.Debugf("Starting master detector for ZK %s", zk)
 		if md, err := detector.New(zk); err != nil {
-			log.Errorf("Failed to create master detector: %v", err)
+			logger.Errorf("Failed to create master detector: %v", err)
 		} else if err := md.Detect(detect.NewMasters(masters, changed)); err != nil {
-			log.Errorf("Failed to initialize master detector: %v", err)
+			logger.Errorf("Failed to initialize master detector: %v", err)
 		}
 	} else {
 		changed <- masters
@@ -145,7 +147,7 @@ func (p *Provider) getTasks() []state.Task {
 
 	st, err := rg.FindMaster(p.Masters...)
 	if err != nil {
-		log.Errorf("Failed to create a client for Mesos, error: %v", err)
+		logger.Errorf("Failed to create a client for Mesos, error: %v", err)
 		return nil
 	}
 
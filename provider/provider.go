@@ -15,6 +15,8 @@ import (
 	"github.com/containous/traefik/types"
 )
 
+var logger = log.WithModule("provider")
+
 // Provider defines methods of a provider.
 type Provider interface {
 	// Provide allows the provider to provide configurations to traefik
@@ -93,8 +95,8 @@ func (p *BaseProvider) CreateConfiguration(tmplContent string, funcMap template.
 
 	var renderedTemplate = buffer.String()
 	if p.DebugLogGeneratedTemplate {
-		log.Debugf("Template content: %s", tmplContent)
-		log.Debugf("Rendering results: %s", renderedTemplate)
+		logger.Debugf("Template content: %s", tmplContent)
+		logger.Debugf("Rendering results: %s", renderedTemplate)
 	}
 	return p.DecodeConfiguration(renderedTemplate)
 }
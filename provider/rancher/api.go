@@ -7,7 +7,6 @@ import (
 
 	"github.com/cenk/backoff"
 	"github.com/containous/traefik/job"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
 	"github.com/mitchellh/mapstructure"
@@ -66,7 +65,7 @@ func (p *Provider) apiProvide(configurationChan chan<- types.ConfigMessage, pool
 			rancherClient, err := p.createClient()
 
 			if err != nil {
-				log.Errorf("Failed to create a client for rancher, error: %s", err)
+				logger.Errorf("Failed to create a client for rancher, error: %s", err)
 				return err
 			}
 
@@ -102,10 +101,10 @@ func (p *Provider) apiProvide(configurationChan chan<- types.ConfigMessage, pool
 							checkAPI, errAPI := rancherClient.ApiKey.List(withoutPagination)
 
 							if errAPI != nil {
-								log.Errorf("Cannot establish connection: %+v, Rancher API return: %+v; Skipping refresh Data from Rancher API.", errAPI, checkAPI)
+								logger.Errorf("Cannot establish connection: %+v, Rancher API return: %+v; Skipping refresh Data from Rancher API.", errAPI, checkAPI)
 								continue
 							}
-							log.Debugf("Refreshing new Data from Rancher API")
+							logger.Debugf("Refreshing new Data from Rancher API")
 							stacks, err = listRancherStacks(rancherClient)
 							if err != nil {
 								continue
@@ -140,11 +139,11 @@ func (p *Provider) apiProvide(configurationChan chan<- types.ConfigMessage, pool
 			return nil
 		}
 		notify := func(err error, time time.Duration) {
-			log.Errorf("Provider connection error %+v, retrying in %s", err, time)
+			logger.Errorf("Provider connection error %+v, retrying in %s", err, time)
 		}
 		err := backoff.RetryNotify(operation, job.NewBackOff(backoff.NewExponentialBackOff()), notify)
 		if err != nil {
-			log.Errorf("Cannot connect to Provider Endpoint %+v", err)
+			logger.Errorf("Cannot connect to Provider Endpoint %+v", err)
 		}
 	})
 
@@ -158,7 +157,7 @@ func listRancherStacks(client *rancher.RancherClient) ([]*rancher.Stack, error)
 	stacks, err := client.Stack.List(withoutPagination)
 
 	if err != nil {
-		log.Errorf("Cannot get Provider Stacks %+v", err)
+		logger.Errorf("Cannot get Provider Stacks %+v", err)
 	}
 
 	for k := range stacks.Data {
@@ -175,7 +174,7 @@ func listRancherServices(client *rancher.RancherClient) ([]*rancher.Service, err
 	services, err := client.Service.List(withoutPagination)
 
 	if err != nil {
-		log.Errorf("Cannot get Provider Services %+v", err)
+		logger.Errorf("Cannot get Provider Services %+v", err)
 	}
 
 	for k := range services.Data {
@@ -192,7 +191,7 @@ func listRancherContainer(client *rancher.RancherClient) ([]*rancher.Container,
 	container, err := client.Container.List(withoutPagination)
 
 	if err != nil {
-		log.Errorf("Cannot get Provider Services %+v", err)
+		logger.Errorf("Cannot get Provider Services %+v", err)
 		return containerList, err
 	}
 
@@ -237,7 +236,7 @@ func parseAPISourcedRancherData(stacks []*rancher.Stack, services []*rancher.Ser
 			}
 
 			if service.LaunchConfig == nil || service.LaunchConfig.Labels == nil {
-				log.Warnf("Rancher Service Labels are missing. Stack: %s, service: %s", stack.Name, service.Name)
+				logger.Warnf("Rancher Service Labels are missing. Stack: %s, service: %s", stack.Name, service.Name)
 			} else {
 				for key, value := range service.LaunchConfig.Labels {
 					rData.Labels[key] = value.(string)
@@ -253,7 +252,7 @@ func parseAPISourcedRancherData(stacks []*rancher.Stack, services []*rancher.Ser
 						err := mapstructure.Decode(service.PublicEndpoints, &endpoints)
 
 						if err != nil {
-							log.Errorf("Failed to decode PublicEndpoint: %v", err)
+							logger.Errorf("Failed to decode PublicEndpoint: %v", err)
 							continue
 						}
 
@@ -8,7 +8,6 @@ import (
 	"text/template"
 
 	"github.com/BurntSushi/ty/fun"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -41,6 +40,7 @@ func (p *Provider) buildConfigurationV2(services []rancherData) *types.Configura
 		"getErrorPages":        label.GetErrorPages,
 		"getRateLimit":         label.GetRateLimit,
 		"getRedirect":          label.GetRedirect,
+		"getAccessLog":         label.GetAccessLog,
 		"getHeaders":           label.GetHeaders,
 		"getWhiteList":         label.GetWhiteList,
 	}
@@ -76,7 +76,7 @@ func (p *Provider) buildConfigurationV2(services []rancherData) *types.Configura
 
 	configuration, err := p.GetConfiguration("templates/rancher.tmpl", RancherFuncMap, templateObjects)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 
 	return configuration
@@ -88,25 +88,25 @@ func (p *Provider) serviceFilter(service rancherData) bool {
 	for segmentName, labels := range segmentProperties {
 		_, err := checkSegmentPort(labels, segmentName)
 		if err != nil {
-			log.Debugf("Filtering service %s %s without traefik.port label", service.Name, segmentName)
+			logger.Debugf("Filtering service %s %s without traefik.port label", service.Name, segmentName)
 			return false
 		}
 
 		if len(p.getFrontendRule(service.Name, labels)) == 0 {
-			log.Debugf("Filtering container with empty frontend rule %s %s", service.Name, segmentName)
+			logger.Debugf("Filtering container with empty frontend rule %s %s", service.Name, segmentName)
 			return false
 		}
 	}
 
 	if !label.IsEnabled(service.Labels, p.ExposedByDefault) {
-		log.Debugf("Filtering disabled service %s", service.Name)
+		logger.Debugf("Filtering disabled service %s", service.Name)
 		return false
 	}
 
 	constraintTags := label.GetSliceStringValue(service.Labels, label.TraefikTags)
 	if ok, failingConstraint := p.MatchConstraints(constraintTags); !ok {
 		if failingConstraint != nil {
-			log.Debugf("Filtering service %s with constraint %s", service.Name, failingConstraint.String())
+			logger.Debugf("Filtering service %s with constraint %s", service.Name, failingConstraint.String())
 		}
 		return false
 	}
@@ -115,11 +115,11 @@ func (p *Provider) serviceFilter(service rancherData) bool {
 	if p.EnableServiceHealthFilter {
 
 		if service.Health != "" && service.Health != healthy && service.Health != updatingHealthy {
-			log.Debugf("Filtering service %s with healthState of %s", service.Name, service.Health)
+			logger.Debugf("Filtering service %s with healthState of %s", service.Name, service.Health)
 			return false
 		}
 		if service.State != "" && service.State != active && service.State != updatingActive && service.State != upgraded && service.State != upgrading {
-			log.Debugf("Filtering service %s with state of %s", service.Name, service.State)
+			logger.Debugf("Filtering service %s with state of %s", service.Name, service.State)
 			return false
 		}
 	}
@@ -175,7 +175,7 @@ func getServers(service rancherData) map[string]types.Server {
 
 	for index, ip := range service.Containers {
 		if len(ip) == 0 {
-			log.Warnf("Unable to find the IP address for a container in the service %q: this container is ignored.", service.Name)
+			logger.Warnf("Unable to find the IP address for a container in the service %q: this container is ignored.", service.Name)
 			continue
 		}
 
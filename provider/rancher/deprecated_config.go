@@ -5,7 +5,6 @@ import (
 	"text/template"
 
 	"github.com/BurntSushi/ty/fun"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
@@ -68,7 +67,7 @@ func (p *Provider) buildConfigurationV1(services []rancherData) *types.Configura
 
 	configuration, err := p.GetConfiguration("templates/rancher-v1.tmpl", RancherFuncMap, templateObjects)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 
 	return configuration
@@ -77,19 +76,19 @@ func (p *Provider) buildConfigurationV1(services []rancherData) *types.Configura
 // Deprecated
 func (p *Provider) serviceFilterV1(service rancherData) bool {
 	if service.Labels[label.TraefikPort] == "" {
-		log.Debugf("Filtering service %s without traefik.port label", service.Name)
+		logger.Debugf("Filtering service %s without traefik.port label", service.Name)
 		return false
 	}
 
 	if !label.IsEnabled(service.Labels, p.ExposedByDefault) {
-		log.Debugf("Filtering disabled service %s", service.Name)
+		logger.Debugf("Filtering disabled service %s", service.Name)
 		return false
 	}
 
 	constraintTags := label.GetSliceStringValue(service.Labels, label.TraefikTags)
 	if ok, failingConstraint := p.MatchConstraints(constraintTags); !ok {
 		if failingConstraint != nil {
-			log.Debugf("Filtering service %s with constraint %s", service.Name, failingConstraint.String())
+			logger.Debugf("Filtering service %s with constraint %s", service.Name, failingConstraint.String())
 		}
 		return false
 	}
@@ -98,11 +97,11 @@ func (p *Provider) serviceFilterV1(service rancherData) bool {
 	if p.EnableServiceHealthFilter {
 
 		if service.Health != "" && service.Health != healthy && service.Health != updatingHealthy {
-			log.Debugf("Filtering service %s with healthState of %s", service.Name, service.Health)
+			logger.Debugf("Filtering service %s with healthState of %s", service.Name, service.Health)
 			return false
 		}
 		if service.State != "" && service.State != active && service.State != updatingActive && service.State != upgraded && service.State != upgrading {
-			log.Debugf("Filtering service %s with state of %s", service.Name, service.State)
+			logger.Debugf("Filtering service %s with state of %s", service.Name, service.State)
 			return false
 		}
 	}
@@ -132,7 +131,7 @@ func getBackendNameV1(service rancherData) string {
 // Deprecated
 func getStickyV1(service rancherData) bool {
 	if label.Has(service.Labels, label.TraefikBackendLoadBalancerSticky) {
-		log.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
+		logger.Warnf("Deprecated configuration found: %s. Please use %s.", label.TraefikBackendLoadBalancerSticky, label.TraefikBackendLoadBalancerStickiness)
 	}
 	return label.GetBoolValue(service.Labels, label.TraefikBackendLoadBalancerSticky, false)
 }
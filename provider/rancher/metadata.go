@@ -7,7 +7,6 @@ import (
 
 	"github.com/cenk/backoff"
 	"github.com/containous/traefik/job"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
 	"github.com/sirupsen/logrus"
@@ -29,16 +28,16 @@ func (p *Provider) metadataProvide(configurationChan chan<- types.ConfigMessage,
 		operation := func() error {
 			client, err := rancher.NewClientAndWait(metadataServiceURL)
 			if err != nil {
-				log.Errorf("Failed to create Rancher metadata service client: %v", err)
+				logger.Errorf("Failed to create Rancher metadata service client: %v", err)
 				return err
 			}
 
 			updateConfiguration := func(version string) {
-				log.WithField("metadata_version", version).Debugln("Refreshing configuration from Rancher metadata service")
+				logger.WithField("metadata_version", version).Debugln("Refreshing configuration from Rancher metadata service")
 
 				stacks, err := client.GetStacks()
 				if err != nil {
-					log.Errorf("Failed to query Rancher metadata service: %v", err)
+					logger.Errorf("Failed to query Rancher metadata service: %v", err)
 					return
 				}
 
@@ -65,14 +64,14 @@ func (p *Provider) metadataProvide(configurationChan chan<- types.ConfigMessage,
 		}
 
 		notify := func(err error, time time.Duration) {
-			log.WithFields(logrus.Fields{
+			logger.WithFields(logrus.Fields{
 				"error":    err,
 				"retry_in": time,
 			}).Errorln("Rancher metadata service connection error")
 		}
 
 		if err := backoff.RetryNotify(operation, job.NewBackOff(backoff.NewExponentialBackOff()), notify); err != nil {
-			log.WithField("endpoint", metadataServiceURL).Errorln("Cannot connect to Rancher metadata service")
+			logger.WithField("endpoint", metadataServiceURL).Errorln("Cannot connect to Rancher metadata service")
 		}
 	})
 
@@ -92,7 +91,7 @@ func (p *Provider) intervalPoll(client rancher.Client, updateConfiguration func(
 		case <-ticker.C:
 			newVersion, err := client.GetVersion()
 			if err != nil {
-				log.WithField("error", err).Errorln("Failed to read Rancher metadata service version")
+				logger.WithField("error", err).Errorln("Failed to read Rancher metadata service version")
 			} else if version != newVersion {
 				version = newVersion
 				updateConfiguration(version)
@@ -9,6 +9,8 @@ import (
 	"github.com/containous/traefik/types"
 )
 
+var logger = log.WithModule("provider/rancher")
+
 const (
 	// Health
 	healthy         = "healthy"
@@ -67,12 +69,12 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 
 func containerFilter(name, healthState, state string) bool {
 	if healthState != "" && healthState != healthy && healthState != updatingHealthy {
-		log.Debugf("Filtering container %s with healthState of %s", name, healthState)
+		logger.Debugf("Filtering container %s with healthState of %s", name, healthState)
 		return false
 	}
 
 	if state != "" && state != running && state != updatingRunning && state != upgraded {
-		log.Debugf("Filtering container %s with state of %s", name, state)
+		logger.Debugf("Filtering container %s with state of %s", name, state)
 		return false
 	}
 
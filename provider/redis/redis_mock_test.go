@@ -0,0 +1,469 @@
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis is an in-memory stand-in of a Redis server, supporting the commands used by the store.
+// The keyspace notifications are always enabled.
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	data     map[string]*fakeEntry
+	versions map[string]uint64
+	version  uint64
+	conns    map[*fakeRedisConn]struct{}
+
+	// intercept is called with each command before it is run, a non-nil reply is sent instead of running the command.
+	// It is called with the server lock held, and can change the data with setLocked.
+	intercept func(args []string) interface{}
+}
+
+type fakeEntry struct {
+	value    string
+	expireAt time.Time
+}
+
+type fakeRedisConn struct {
+	server        *fakeRedis
+	netConn       net.Conn
+	writeLock     sync.Mutex
+	writer        *bufio.Writer
+	authenticated bool
+	watched       map[string]uint64
+	queue         [][]string
+	inMulti       bool
+	channels      map[string]bool
+	patterns      map[string]bool
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeRedis{
+		listener: listener,
+		password: password,
+		data:     make(map[string]*fakeEntry),
+		versions: make(map[string]uint64),
+		conns:    make(map[*fakeRedisConn]struct{}),
+	}
+
+	go server.serve()
+	return server
+}
+
+func (f *fakeRedis) addr() string {
+	return f.listener.Addr().String()
+}
+
+func (f *fakeRedis) close() {
+	f.listener.Close()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for c := range f.conns {
+		c.netConn.Close()
+	}
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		netConn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &fakeRedisConn{
+			server:        f,
+			netConn:       netConn,
+			writer:        bufio.NewWriter(netConn),
+			authenticated: len(f.password) == 0,
+			channels:      make(map[string]bool),
+			patterns:      make(map[string]bool),
+		}
+
+		f.mu.Lock()
+		f.conns[c] = struct{}{}
+		f.mu.Unlock()
+
+		go c.serve()
+	}
+}
+
+func (c *fakeRedisConn) serve() {
+	defer func() {
+		c.server.mu.Lock()
+		delete(c.server.conns, c)
+		c.server.mu.Unlock()
+		c.netConn.Close()
+	}()
+
+	reader := bufio.NewReader(c.netConn)
+	for {
+		args, err := readFakeCommand(reader)
+		if err != nil {
+			return
+		}
+
+		c.server.mu.Lock()
+		reply := c.handle(args)
+		c.server.mu.Unlock()
+
+		c.write(reply)
+	}
+}
+
+func readFakeCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command %q", line)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+// fakeStatus, fakeError and fakeNil are the replies which are not bulk strings, integers or arrays.
+type fakeStatus string
+type fakeError string
+type fakeNil struct{}
+
+func encodeFakeReply(builder *strings.Builder, reply interface{}) {
+	switch value := reply.(type) {
+	case fakeStatus:
+		builder.WriteString("+" + string(value) + "\r\n")
+	case fakeError:
+		builder.WriteString("-" + string(value) + "\r\n")
+	case fakeNil:
+		builder.WriteString("$-1\r\n")
+	case int:
+		builder.WriteString(":" + strconv.Itoa(value) + "\r\n")
+	case string:
+		builder.WriteString("$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n")
+	case []interface{}:
+		if value == nil {
+			builder.WriteString("*-1\r\n")
+			return
+		}
+		builder.WriteString("*" + strconv.Itoa(len(value)) + "\r\n")
+		for _, item := range value {
+			encodeFakeReply(builder, item)
+		}
+	}
+}
+
+func (c *fakeRedisConn) write(reply interface{}) {
+	var builder strings.Builder
+	encodeFakeReply(&builder, reply)
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	c.writer.WriteString(builder.String())
+	c.writer.Flush()
+}
+
+// handle runs a command, the server lock is held.
+func (c *fakeRedisConn) handle(args []string) interface{} {
+	command := strings.ToUpper(args[0])
+
+	if command == "AUTH" {
+		if args[len(args)-1] != c.server.password {
+			return fakeError("WRONGPASS invalid username-password pair")
+		}
+		c.authenticated = true
+		return fakeStatus("OK")
+	}
+
+	if !c.authenticated {
+		return fakeError("NOAUTH Authentication required.")
+	}
+
+	if c.server.intercept != nil {
+		if reply := c.server.intercept(args); reply != nil {
+			return reply
+		}
+	}
+
+	if c.inMulti && command != "EXEC" && command != "DISCARD" {
+		c.queue = append(c.queue, args)
+		return fakeStatus("QUEUED")
+	}
+
+	switch command {
+	case "MULTI":
+		c.inMulti = true
+		c.queue = nil
+		return fakeStatus("OK")
+	case "DISCARD":
+		c.inMulti = false
+		c.queue = nil
+		c.watched = nil
+		return fakeStatus("OK")
+	case "EXEC":
+		c.inMulti = false
+		queue := c.queue
+		c.queue = nil
+
+		for key, version := range c.watched {
+			c.server.expire(key)
+			if c.server.versions[key] != version {
+				c.watched = nil
+				return []interface{}(nil)
+			}
+		}
+		c.watched = nil
+
+		replies := []interface{}{}
+		for _, queued := range queue {
+			replies = append(replies, c.run(queued))
+		}
+		return replies
+	default:
+		return c.run(args)
+	}
+}
+
+func (c *fakeRedisConn) run(args []string) interface{} {
+	f := c.server
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return fakeStatus("PONG")
+	case "GET":
+		entry := f.get(args[1])
+		if entry == nil {
+			return fakeNil{}
+		}
+		return entry.value
+	case "SET":
+		key, value := args[1], args[2]
+		var nx bool
+		var ttl time.Duration
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "NX":
+				nx = true
+			case "PX":
+				i++
+				milliseconds, _ := strconv.Atoi(args[i])
+				ttl = time.Duration(milliseconds) * time.Millisecond
+			}
+		}
+
+		if nx && f.get(key) != nil {
+			return fakeNil{}
+		}
+
+		entry := &fakeEntry{value: value}
+		if ttl > 0 {
+			entry.expireAt = time.Now().Add(ttl)
+		}
+		f.data[key] = entry
+		f.modified(key, "set")
+		return fakeStatus("OK")
+	case "DEL":
+		count := 0
+		for _, key := range args[1:] {
+			if f.get(key) != nil {
+				delete(f.data, key)
+				f.modified(key, "del")
+				count++
+			}
+		}
+		return count
+	case "EXISTS":
+		if f.get(args[1]) != nil {
+			return 1
+		}
+		return 0
+	case "MGET":
+		var values []interface{}
+		for _, key := range args[1:] {
+			if entry := f.get(key); entry != nil {
+				values = append(values, entry.value)
+			} else {
+				values = append(values, fakeNil{})
+			}
+		}
+		return values
+	case "SCAN":
+		pattern := "*"
+		for i := 2; i < len(args)-1; i++ {
+			if strings.ToUpper(args[i]) == "MATCH" {
+				pattern = args[i+1]
+			}
+		}
+
+		keys := []interface{}{}
+		for key := range f.data {
+			if f.get(key) != nil && matchFakePattern(pattern, key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].(string) < keys[j].(string) })
+		return []interface{}{"0", keys}
+	case "PEXPIRE":
+		entry := f.get(args[1])
+		if entry == nil {
+			return 0
+		}
+		milliseconds, _ := strconv.Atoi(args[2])
+		entry.expireAt = time.Now().Add(time.Duration(milliseconds) * time.Millisecond)
+		f.modified(args[1], "expire")
+		return 1
+	case "WATCH":
+		if c.watched == nil {
+			c.watched = make(map[string]uint64)
+		}
+		for _, key := range args[1:] {
+			f.expire(key)
+			c.watched[key] = f.versions[key]
+		}
+		return fakeStatus("OK")
+	case "UNWATCH":
+		c.watched = nil
+		return fakeStatus("OK")
+	case "SUBSCRIBE":
+		c.channels[args[1]] = true
+		return []interface{}{"subscribe", args[1], len(c.channels) + len(c.patterns)}
+	case "PSUBSCRIBE":
+		c.patterns[args[1]] = true
+		return []interface{}{"psubscribe", args[1], len(c.channels) + len(c.patterns)}
+	default:
+		return fakeError("ERR unknown command '" + args[0] + "'")
+	}
+}
+
+// get returns the entry of a key, the expired keys are deleted.
+func (f *fakeRedis) get(key string) *fakeEntry {
+	f.expire(key)
+	return f.data[key]
+}
+
+func (f *fakeRedis) expire(key string) {
+	entry, ok := f.data[key]
+	if ok && !entry.expireAt.IsZero() && time.Now().After(entry.expireAt) {
+		delete(f.data, key)
+		f.modified(key, "expired")
+	}
+}
+
+// modified bumps the version of a key and sends its keyspace notification.
+func (f *fakeRedis) modified(key string, event string) {
+	f.version++
+	f.versions[key] = f.version
+
+	channel := keyspaceChannelPrefix + key
+	for c := range f.conns {
+		if c.channels[channel] {
+			go c.write([]interface{}{"message", channel, event})
+		}
+		for pattern := range c.patterns {
+			if matchFakePattern(pattern, channel) {
+				go c.write([]interface{}{"pmessage", pattern, channel, event})
+			}
+		}
+	}
+}
+
+// matchFakePattern matches the glob-style patterns of Redis, supporting '*', '?' and the escapes.
+func matchFakePattern(pattern string, value string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(value); i >= 0; i-- {
+				if matchFakePattern(pattern[1:], value[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(value) == 0 {
+				return false
+			}
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(value) == 0 || value[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		value = value[1:]
+	}
+	return len(value) == 0
+}
+
+func (f *fakeRedis) set(key string, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.setLocked(key, value)
+}
+
+// setLocked sets a key, the server lock is held.
+func (f *fakeRedis) setLocked(key string, value string) {
+	f.data[key] = &fakeEntry{value: value}
+	f.modified(key, "set")
+}
+
+func (f *fakeRedis) setIntercept(intercept func(args []string) interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.intercept = intercept
+}
+
+func (f *fakeRedis) value(key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry := f.get(key)
+	if entry == nil {
+		return "", errors.New("key not found")
+	}
+	return entry.value, nil
+}
@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderProvide(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+	defer server.close()
+
+	server.set("traefik/backends/backend1/servers/server1/url", "http://127.0.0.1:80")
+	server.set("traefik/backends/backend1/servers/server1/weight", "10")
+	server.set("traefik/frontends/frontend1/backend", "backend1")
+	server.set("traefik/frontends/frontend1/routes/route1/rule", "Host:test.localhost")
+
+	p := &Provider{}
+	p.Endpoint = server.addr()
+	p.Prefix = "traefik"
+	p.Password = "secret"
+	p.Watch = true
+	require.NoError(t, p.Init(nil))
+
+	configurationChan := make(chan types.ConfigMessage)
+	pool := safe.NewPool(context.Background())
+	defer pool.Stop()
+
+	go func() {
+		assert.NoError(t, p.Provide(configurationChan, pool))
+	}()
+
+	configuration := receiveConfiguration(t, configurationChan)
+	require.Contains(t, configuration.Backends, "backend1")
+	assert.Equal(t, map[string]types.Server{
+		"server1": {URL: "http://127.0.0.1:80", Weight: 10},
+	}, configuration.Backends["backend1"].Servers)
+	require.Contains(t, configuration.Frontends, "frontend1")
+	assert.Equal(t, "backend1", configuration.Frontends["frontend1"].Backend)
+
+	// The configuration is sent again when it changes in Redis.
+	server.set("traefik/backends/backend1/servers/server2/url", "http://127.0.0.1:81")
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case message := <-configurationChan:
+			if len(message.Configuration.Backends["backend1"].Servers) == 2 {
+				assert.Equal(t, "redis", message.ProviderName)
+				return
+			}
+		case <-deadline:
+			t.Fatal("timeout waiting for the updated configuration")
+		}
+	}
+}
+
+func receiveConfiguration(t *testing.T, configurationChan <-chan types.ConfigMessage) *types.Configuration {
+	t.Helper()
+
+	select {
+	case message := <-configurationChan:
+		assert.Equal(t, "redis", message.ProviderName)
+		return message.Configuration
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the configuration")
+		return nil
+	}
+}
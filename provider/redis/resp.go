@@ -0,0 +1,252 @@
+package redis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// errNil is the error returned when Redis answers with a nil bulk string or a nil array
+var errNil = errors.New("redis: nil reply")
+
+// redisError is an error reply of Redis
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// conn is a connection to a Redis server speaking the RESP protocol
+// https://redis.io/topics/protocol
+type conn struct {
+	netConn net.Conn
+	reader  *bufio.Reader
+	writer  *bufio.Writer
+	timeout time.Duration
+	broken  bool
+}
+
+// do sends a command to Redis and reads its reply.
+// The replies are string for the simple strings, []byte for the bulk strings, int64 for the integers and []interface{} for the arrays.
+func (c *conn) do(args ...string) (interface{}, error) {
+	if err := c.send(args...); err != nil {
+		return nil, err
+	}
+	return c.receive(c.timeout)
+}
+
+func (c *conn) send(args ...string) error {
+	if c.timeout > 0 {
+		if err := c.netConn.SetWriteDeadline(time.Now().Add(c.timeout)); err != nil {
+			c.broken = true
+			return err
+		}
+	}
+
+	c.writer.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		c.writer.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n")
+		c.writer.WriteString(arg)
+		c.writer.WriteString("\r\n")
+	}
+
+	if err := c.writer.Flush(); err != nil {
+		c.broken = true
+		return err
+	}
+	return nil
+}
+
+// receive reads a reply, waiting at most for the given timeout if it is not 0.
+func (c *conn) receive(timeout time.Duration) (interface{}, error) {
+	deadline := time.Time{}
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	if err := c.netConn.SetReadDeadline(deadline); err != nil {
+		c.broken = true
+		return nil, err
+	}
+
+	reply, err := c.readReply()
+	if err != nil {
+		if _, ok := err.(redisError); !ok && err != errNil {
+			c.broken = true
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *conn) readReply() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: invalid reply %q", line)
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, errNil
+		}
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, errNil
+		}
+
+		values := make([]interface{}, size)
+		for i := range values {
+			values[i], err = c.readReply()
+			if err != nil && err != errNil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("redis: invalid reply %q", line)
+	}
+}
+
+func (c *conn) close() {
+	c.netConn.Close()
+}
+
+// client holds a pool of connections to a Redis server
+type client struct {
+	addrs    []string
+	tls      *tls.Config
+	username string
+	password string
+	timeout  time.Duration
+
+	lock   sync.Mutex
+	idle   []*conn
+	closed bool
+}
+
+// dial opens a connection to the first reachable address and authenticates it.
+func (c *client) dial() (*conn, error) {
+	var lastErr error
+	for _, addr := range c.addrs {
+		dialer := &net.Dialer{Timeout: c.timeout}
+
+		var netConn net.Conn
+		var err error
+		if c.tls != nil {
+			netConn, err = tls.DialWithDialer(dialer, "tcp", addr, c.tls)
+		} else {
+			netConn, err = dialer.Dial("tcp", addr)
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		cn := &conn{
+			netConn: netConn,
+			reader:  bufio.NewReader(netConn),
+			writer:  bufio.NewWriter(netConn),
+			timeout: c.timeout,
+		}
+
+		if len(c.password) > 0 {
+			args := []string{"AUTH", c.password}
+			if len(c.username) > 0 {
+				args = []string{"AUTH", c.username, c.password}
+			}
+			if _, err := cn.do(args...); err != nil {
+				cn.close()
+				return nil, err
+			}
+		}
+
+		return cn, nil
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("redis: no address")
+	}
+	return nil, lastErr
+}
+
+// get returns an idle connection, or a new one.
+func (c *client) get() (*conn, error) {
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		return nil, errors.New("redis: client closed")
+	}
+	if n := len(c.idle); n > 0 {
+		cn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.lock.Unlock()
+		return cn, nil
+	}
+	c.lock.Unlock()
+
+	return c.dial()
+}
+
+// put gives back a connection to the pool, the broken connections are closed.
+func (c *client) put(cn *conn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cn.broken || c.closed {
+		cn.close()
+		return
+	}
+	c.idle = append(c.idle, cn)
+}
+
+// do sends a command on a pooled connection and reads its reply.
+func (c *client) do(args ...string) (interface{}, error) {
+	cn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer c.put(cn)
+
+	return cn.do(args...)
+}
+
+func (c *client) close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.closed = true
+	for _, cn := range c.idle {
+		cn.close()
+	}
+	c.idle = nil
+}
@@ -0,0 +1,598 @@
+package redis
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abronan/valkeyrie"
+	"github.com/abronan/valkeyrie/store"
+	"github.com/containous/traefik/log"
+)
+
+var logger = log.WithModule("provider/redis")
+
+const (
+	// keyspaceChannelPrefix is the prefix of the channels of the keyspace notifications of the database 0
+	// https://redis.io/topics/notifications
+	keyspaceChannelPrefix = "__keyspace@0__:"
+
+	defaultLockTTL = 20 * time.Second
+	scanCount      = "1000"
+)
+
+// lockRetryInterval is the interval between two attempts to acquire a lock held by another client
+var lockRetryInterval = time.Second
+
+var _ store.Store = (*Store)(nil)
+
+// Store is a valkeyrie store backed by Redis.
+// The values are stored as plain Redis strings under their keys, so that they can be written with any Redis client.
+// Redis has no modification index, the LastIndex of the pairs is always 0 and the atomic operations compare the values.
+// The changes are watched through the keyspace notifications, which must be enabled on the Redis server.
+type Store struct {
+	client *client
+}
+
+// Register registers Redis to valkeyrie
+func Register() {
+	valkeyrie.AddStore(store.REDIS, New)
+}
+
+// New creates a new Redis client given a list of endpoints, the first reachable one is used.
+func New(endpoints []string, options *store.Config) (store.Store, error) {
+	c := &client{addrs: endpoints}
+	if options != nil {
+		c.tls = options.TLS
+		c.username = options.Username
+		c.password = options.Password
+		c.timeout = options.ConnectionTimeout
+	}
+
+	s := &Store{client: c}
+	if _, err := c.do("PING"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// normalize removes the leading slashes of a key.
+func normalize(key string) string {
+	return strings.TrimLeft(store.Normalize(key), "/")
+}
+
+// Put a value at the specified key
+func (s *Store) Put(key string, value []byte, options *store.WriteOptions) error {
+	args := []string{"SET", normalize(key), string(value)}
+	if options != nil && options.TTL > 0 {
+		args = append(args, "PX", formatMilliseconds(options.TTL))
+	}
+
+	_, err := s.client.do(args...)
+	return err
+}
+
+// Get a value given its key
+func (s *Store) Get(key string, options *store.ReadOptions) (*store.KVPair, error) {
+	key = normalize(key)
+
+	reply, err := s.client.do("GET", key)
+	if err == errNil {
+		return nil, store.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &store.KVPair{Key: key, Value: toBytes(reply)}, nil
+}
+
+// Delete the value at the specified key
+func (s *Store) Delete(key string) error {
+	reply, err := s.client.do("DEL", normalize(key))
+	if err != nil {
+		return err
+	}
+	if count, _ := reply.(int64); count == 0 {
+		return store.ErrKeyNotFound
+	}
+	return nil
+}
+
+// Exists verifies if a key exists in the store
+func (s *Store) Exists(key string, options *store.ReadOptions) (bool, error) {
+	reply, err := s.client.do("EXISTS", normalize(key))
+	if err != nil {
+		return false, err
+	}
+	count, _ := reply.(int64)
+	return count > 0, nil
+}
+
+// List the content of a given prefix
+func (s *Store) List(directory string, options *store.ReadOptions) ([]*store.KVPair, error) {
+	directory = normalize(directory)
+
+	keys, err := s.scan(escapePattern(directory) + "*")
+	if err != nil {
+		return nil, err
+	}
+
+	var pairs []*store.KVPair
+	for start := 0; start < len(keys); start += 100 {
+		end := start + 100
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		reply, err := s.client.do(append([]string{"MGET"}, keys[start:end]...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		values, _ := reply.([]interface{})
+		for i, value := range values {
+			// The keys deleted since the scan are nil.
+			if value == nil || keys[start+i] == directory {
+				continue
+			}
+			pairs = append(pairs, &store.KVPair{Key: keys[start+i], Value: toBytes(value)})
+		}
+	}
+
+	if len(pairs) == 0 {
+		return nil, store.ErrKeyNotFound
+	}
+	return pairs, nil
+}
+
+// scan returns the keys matching a pattern.
+func (s *Store) scan(pattern string) ([]string, error) {
+	var keys []string
+	cursor := "0"
+
+	for {
+		reply, err := s.client.do("SCAN", cursor, "MATCH", pattern, "COUNT", scanCount)
+		if err != nil {
+			return nil, err
+		}
+
+		values, ok := reply.([]interface{})
+		if !ok || len(values) != 2 {
+			return nil, redisError("invalid SCAN reply")
+		}
+
+		cursor = string(toBytes(values[0]))
+		batch, _ := values[1].([]interface{})
+		for _, key := range batch {
+			keys = append(keys, string(toBytes(key)))
+		}
+
+		if cursor == "0" {
+			return dedupe(keys), nil
+		}
+	}
+}
+
+// DeleteTree deletes a range of keys under a given directory
+func (s *Store) DeleteTree(directory string) error {
+	keys, err := s.scan(escapePattern(normalize(directory)) + "*")
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(keys); start += 100 {
+		end := start + 100
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		if _, err := s.client.do(append([]string{"DEL"}, keys[start:end]...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Watch for changes on a key.
+// The current value is sent first if the key exists, then the value is sent on each change of the key.
+// A deleted key is sent with an empty value.
+func (s *Store) Watch(key string, stopCh <-chan struct{}, options *store.ReadOptions) (<-chan *store.KVPair, error) {
+	key = normalize(key)
+
+	events, unsubscribe, err := s.subscribe("SUBSCRIBE", keyspaceChannelPrefix+key)
+	if err != nil {
+		return nil, err
+	}
+
+	watchCh := make(chan *store.KVPair)
+	go func() {
+		defer close(watchCh)
+		defer unsubscribe()
+
+		for initial := true; ; initial = false {
+			pair, err := s.Get(key, options)
+			if err == store.ErrKeyNotFound && !initial {
+				pair = &store.KVPair{Key: key}
+			} else if err != nil && err != store.ErrKeyNotFound {
+				logger.Errorf("Unable to get the Redis key %s: %v", key, err)
+				return
+			}
+
+			if pair != nil {
+				select {
+				case watchCh <- pair:
+				case <-stopCh:
+					return
+				}
+			}
+
+			select {
+			case _, ok := <-events:
+				if !ok {
+					return
+				}
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+
+	return watchCh, nil
+}
+
+// WatchTree watches for changes on child nodes under a given directory.
+// The current pairs are sent first, then the pairs are sent again on each change under the directory.
+func (s *Store) WatchTree(directory string, stopCh <-chan struct{}, options *store.ReadOptions) (<-chan []*store.KVPair, error) {
+	directory = normalize(directory)
+
+	events, unsubscribe, err := s.subscribe("PSUBSCRIBE", keyspaceChannelPrefix+escapePattern(directory)+"*")
+	if err != nil {
+		return nil, err
+	}
+
+	watchCh := make(chan []*store.KVPair)
+	go func() {
+		defer close(watchCh)
+		defer unsubscribe()
+
+		for {
+			pairs, err := s.List(directory, options)
+			if err != nil && err != store.ErrKeyNotFound {
+				logger.Errorf("Unable to list the Redis keys under %s: %v", directory, err)
+				return
+			}
+
+			select {
+			case watchCh <- pairs:
+			case <-stopCh:
+				return
+			}
+
+			select {
+			case _, ok := <-events:
+				if !ok {
+					return
+				}
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+
+	return watchCh, nil
+}
+
+// subscribe subscribes to a channel on a dedicated connection, until the returned unsubscribe function is called.
+// The returned channel holds at most one pending event, the bursts of notifications are coalesced.
+// It is closed when the connection is lost.
+func (s *Store) subscribe(command string, channel string) (<-chan struct{}, func(), error) {
+	cn, err := s.client.dial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The subscription is confirmed before returning, so that no change is missed after the first read.
+	if _, err := cn.do(command, channel); err != nil {
+		cn.close()
+		return nil, nil, err
+	}
+
+	events := make(chan struct{}, 1)
+	var once sync.Once
+	unsubscribe := func() { once.Do(cn.close) }
+
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+
+		for {
+			if _, err := cn.receive(0); err != nil {
+				return
+			}
+
+			select {
+			case events <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return events, unsubscribe, nil
+}
+
+// NewLock creates a lock for a given key.
+// The lock is held by setting the key with a TTL, which is renewed until the lock is released.
+func (s *Store) NewLock(key string, options *store.LockOptions) (store.Locker, error) {
+	l := &lock{
+		store: s,
+		key:   normalize(key),
+		ttl:   defaultLockTTL,
+	}
+
+	if options != nil {
+		l.value = options.Value
+		l.renewCh = options.RenewLock
+		if options.TTL > 0 {
+			l.ttl = options.TTL
+		}
+	}
+
+	// The value identifies the holder of the lock, a random one is used when none is given.
+	if len(l.value) == 0 {
+		token := make([]byte, 16)
+		if _, err := rand.Read(token); err != nil {
+			return nil, err
+		}
+		l.value = []byte(hex.EncodeToString(token))
+	}
+
+	return l, nil
+}
+
+// AtomicPut puts a value at the specified key, if the current value is the one of the previous pair.
+// Pass previous = nil to create a new key.
+func (s *Store) AtomicPut(key string, value []byte, previous *store.KVPair, options *store.WriteOptions) (bool, *store.KVPair, error) {
+	key = normalize(key)
+
+	args := []string{"SET", key, string(value)}
+	if options != nil && options.TTL > 0 {
+		args = append(args, "PX", formatMilliseconds(options.TTL))
+	}
+
+	if previous == nil {
+		_, err := s.client.do(append(args, "NX")...)
+		if err == errNil {
+			return false, nil, store.ErrKeyExists
+		}
+		if err != nil {
+			return false, nil, err
+		}
+		return true, &store.KVPair{Key: key, Value: value}, nil
+	}
+
+	swapped, err := s.compareAndSwap(key, previous.Value, args)
+	if err != nil {
+		return false, nil, err
+	}
+	if !swapped {
+		return false, nil, store.ErrKeyModified
+	}
+	return true, &store.KVPair{Key: key, Value: value}, nil
+}
+
+// AtomicDelete deletes a value at the specified key, if the current value is the one of the previous pair.
+func (s *Store) AtomicDelete(key string, previous *store.KVPair) (bool, error) {
+	if previous == nil {
+		return false, store.ErrPreviousNotSpecified
+	}
+
+	key = normalize(key)
+	deleted, err := s.compareAndSwap(key, previous.Value, []string{"DEL", key})
+	if err != nil {
+		return false, err
+	}
+	if !deleted {
+		return false, store.ErrKeyModified
+	}
+	return true, nil
+}
+
+// compareAndSwap runs a command if the current value of a key is the expected one.
+// The optimistic transactions of Redis (WATCH/MULTI/EXEC) ensure the key did not change in between.
+func (s *Store) compareAndSwap(key string, expected []byte, command []string) (bool, error) {
+	cn, err := s.client.get()
+	if err != nil {
+		return false, err
+	}
+	defer s.client.put(cn)
+
+	// The key stays watched until EXEC, DISCARD or UNWATCH, and would abort the next transaction of the pooled connection:
+	// it is unwatched on every early return, or the connection is closed.
+	watched := true
+	defer func() {
+		if !watched {
+			return
+		}
+		if _, err := cn.do("UNWATCH"); err != nil {
+			cn.broken = true
+		}
+	}()
+
+	if _, err := cn.do("WATCH", key); err != nil {
+		return false, err
+	}
+
+	reply, err := cn.do("GET", key)
+	if err == errNil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(toBytes(reply), expected) {
+		return false, nil
+	}
+
+	if _, err := cn.do("MULTI"); err != nil {
+		return false, err
+	}
+	if _, err := cn.do(command...); err != nil {
+		// DISCARD unwatches the key, the connection is closed if the transaction cannot be discarded.
+		watched = false
+		if _, errDiscard := cn.do("DISCARD"); errDiscard != nil {
+			cn.broken = true
+		}
+		return false, err
+	}
+
+	// EXEC unwatches the key, whatever its result.
+	watched = false
+	_, err = cn.do("EXEC")
+	if err == errNil {
+		// The key changed since WATCH, the transaction was aborted.
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Close the store connection
+func (s *Store) Close() {
+	s.client.close()
+}
+
+// lock is a store.Locker on a Redis key
+type lock struct {
+	store   *Store
+	key     string
+	value   []byte
+	ttl     time.Duration
+	renewCh chan struct{}
+
+	mu     sync.Mutex
+	unlock chan struct{}
+}
+
+// Lock attempts to acquire the lock and blocks while doing so.
+// It returns a channel that is closed if the lock is lost, or nil if stopChan receives or is closed before the lock is acquired.
+func (l *lock) Lock(stopChan chan struct{}) (<-chan struct{}, error) {
+	for {
+		reply, err := l.store.client.do("SET", l.key, string(l.value), "NX", "PX", formatMilliseconds(l.ttl))
+		if err != nil && err != errNil {
+			return nil, err
+		}
+
+		if reply != nil {
+			break
+		}
+
+		select {
+		case <-stopChan:
+			return nil, nil
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	lostCh := make(chan struct{})
+	unlock := make(chan struct{})
+
+	l.mu.Lock()
+	l.unlock = unlock
+	l.mu.Unlock()
+
+	go l.renew(lostCh, unlock)
+
+	return lostCh, nil
+}
+
+// renew extends the TTL of the key while the lock is held.
+func (l *lock) renew(lostCh chan struct{}, unlock chan struct{}) {
+	defer close(lostCh)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			renewed, err := l.store.compareAndSwap(l.key, l.value, []string{"PEXPIRE", l.key, formatMilliseconds(l.ttl)})
+			if err != nil {
+				logger.Errorf("Unable to renew the Redis lock %s: %v", l.key, err)
+				return
+			}
+			if !renewed {
+				return
+			}
+		case <-l.renewCh:
+			return
+		case <-unlock:
+			return
+		}
+	}
+}
+
+// Unlock releases the lock, if it is still held.
+func (l *lock) Unlock() error {
+	l.mu.Lock()
+	if l.unlock != nil {
+		close(l.unlock)
+		l.unlock = nil
+	}
+	l.mu.Unlock()
+
+	_, err := l.store.compareAndSwap(l.key, l.value, []string{"DEL", l.key})
+	return err
+}
+
+func toBytes(reply interface{}) []byte {
+	switch value := reply.(type) {
+	case []byte:
+		return value
+	case string:
+		return []byte(value)
+	default:
+		return nil
+	}
+}
+
+func formatMilliseconds(duration time.Duration) string {
+	milliseconds := int64(duration / time.Millisecond)
+	if milliseconds < 1 {
+		milliseconds = 1
+	}
+	return strconv.FormatInt(milliseconds, 10)
+}
+
+// escapePattern escapes the special characters of the glob-style patterns of Redis.
+func escapePattern(value string) string {
+	var builder strings.Builder
+	for _, char := range value {
+		switch char {
+		case '*', '?', '[', ']', '\\':
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+// dedupe removes the duplicate keys, SCAN may return a key more than once.
+func dedupe(keys []string) []string {
+	seen := make(map[string]struct{}, len(keys))
+	var result []string
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,235 @@
+package redis
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abronan/valkeyrie/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, server *fakeRedis) *Store {
+	kv, err := New([]string{server.addr()}, &store.Config{ConnectionTimeout: time.Second})
+	require.NoError(t, err)
+	return kv.(*Store)
+}
+
+func TestStoreAuthentication(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+	defer server.close()
+
+	_, err := New([]string{server.addr()}, &store.Config{Password: "wrong"})
+	assert.Error(t, err)
+
+	_, err = New([]string{server.addr()}, nil)
+	assert.Error(t, err)
+
+	kv, err := New([]string{"127.0.0.1:1", server.addr()}, &store.Config{Username: "traefik", Password: "secret"})
+	require.NoError(t, err)
+	kv.Close()
+}
+
+func TestStoreAtomicAborted(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		atomic func(s *Store) error
+	}{
+		{
+			desc: "put",
+			atomic: func(s *Store) error {
+				_, _, err := s.AtomicPut("traefik/key", []byte("new"), &store.KVPair{Value: []byte("current")}, nil)
+				return err
+			},
+		},
+		{
+			desc: "delete",
+			atomic: func(s *Store) error {
+				_, err := s.AtomicDelete("traefik/key", &store.KVPair{Value: []byte("current")})
+				return err
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			server := newFakeRedis(t, "")
+			defer server.close()
+
+			s := newTestStore(t, server)
+			defer s.Close()
+
+			server.set("traefik/key", "current")
+
+			// The key is written with the same value between WATCH and EXEC: the values still match, but the transaction is aborted.
+			server.setIntercept(func(args []string) interface{} {
+				if strings.ToUpper(args[0]) == "EXEC" {
+					server.setLocked("traefik/key", "current")
+				}
+				return nil
+			})
+
+			assert.Equal(t, store.ErrKeyModified, test.atomic(s))
+
+			value, err := server.value("traefik/key")
+			require.NoError(t, err)
+			assert.Equal(t, "current", value)
+		})
+	}
+}
+
+func TestStoreAtomicUnwatchOnError(t *testing.T) {
+	server := newFakeRedis(t, "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	server.set("traefik/key1", "current")
+	server.set("traefik/key2", "current")
+
+	server.setIntercept(func(args []string) interface{} {
+		if strings.ToUpper(args[0]) == "GET" && args[1] == "traefik/key1" {
+			return fakeError("WRONGTYPE Operation against a key holding the wrong kind of value")
+		}
+		return nil
+	})
+
+	_, _, err := s.AtomicPut("traefik/key1", []byte("new"), &store.KVPair{Value: []byte("current")}, nil)
+	require.Error(t, err)
+
+	server.setIntercept(nil)
+
+	// The pooled connection is reused: a key left watched by the failed transaction would abort the next one.
+	server.set("traefik/key1", "changed")
+
+	ok, _, err := s.AtomicPut("traefik/key2", []byte("new"), &store.KVPair{Value: []byte("current")}, nil)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestStoreWatchCoalescesNotifications(t *testing.T) {
+	server := newFakeRedis(t, "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	server.set("traefik/key", "initial")
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	watchCh, err := s.Watch("traefik/key", stopCh, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "initial", string(receivePair(t, watchCh).Value))
+
+	// The watcher is blocked while the notifications of the burst are received.
+	for i := 0; i < 100; i++ {
+		server.set("traefik/key", "value"+strconv.Itoa(i))
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// The burst is coalesced: the value read on the first notification, then the value read on the pending one.
+	var values []string
+	for quiet := false; !quiet; {
+		select {
+		case pair := <-watchCh:
+			values = append(values, string(pair.Value))
+		case <-time.After(100 * time.Millisecond):
+			quiet = true
+		}
+	}
+
+	require.NotEmpty(t, values)
+	assert.True(t, len(values) <= 2, "%d values sent for a burst of notifications", len(values))
+	assert.Equal(t, "value99", values[len(values)-1])
+}
+
+func TestStoreWatchTreeCoalescesNotifications(t *testing.T) {
+	server := newFakeRedis(t, "")
+	defer server.close()
+
+	s := newTestStore(t, server)
+	defer s.Close()
+
+	server.set("traefik/backends/backend1/servers/server0/url", "http://127.0.0.1:80")
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	watchCh, err := s.WatchTree("traefik", stopCh, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, receivePairs(t, watchCh), 1)
+
+	// The watcher is blocked while the notifications of the burst are received.
+	for i := 1; i <= 100; i++ {
+		server.set("traefik/backends/backend1/servers/server"+strconv.Itoa(i)+"/url", "http://127.0.0.1:80")
+	}
+	server.set("other/key", "value")
+	time.Sleep(100 * time.Millisecond)
+
+	// The burst is coalesced: the listing done on the first notification, then the listing done on the pending one.
+	var listings [][]*store.KVPair
+	for quiet := false; !quiet; {
+		select {
+		case pairs := <-watchCh:
+			listings = append(listings, pairs)
+		case <-time.After(100 * time.Millisecond):
+			quiet = true
+		}
+	}
+
+	require.NotEmpty(t, listings)
+	assert.True(t, len(listings) <= 2, "%d listings sent for a burst of notifications", len(listings))
+	assert.Len(t, listings[len(listings)-1], 101)
+}
+
+func TestEscapePattern(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "traefik/backends", expected: "traefik/backends"},
+		{value: "traefik*/?[a]", expected: `traefik\*/\?\[a\]`},
+		{value: `traefik\`, expected: `traefik\\`},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, escapePattern(test.value), test.value)
+		assert.True(t, matchFakePattern(escapePattern(test.value), test.value), test.value)
+	}
+}
+
+func receivePair(t *testing.T, watchCh <-chan *store.KVPair) *store.KVPair {
+	t.Helper()
+
+	select {
+	case pair, ok := <-watchCh:
+		require.True(t, ok, "the watch channel is closed")
+		return pair
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the pair")
+		return nil
+	}
+}
+
+func receivePairs(t *testing.T, watchCh <-chan []*store.KVPair) []*store.KVPair {
+	t.Helper()
+
+	select {
+	case pairs, ok := <-watchCh:
+		require.True(t, ok, "the watch channel is closed")
+		return pairs
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the pairs")
+		return nil
+	}
+}
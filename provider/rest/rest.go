@@ -13,6 +13,8 @@ import (
 	"github.com/unrolled/render"
 )
 
+var logger = log.WithModule("provider/rest")
+
 // Provider is a provider.Provider implementation that provides a Rest API
 type Provider struct {
 	configurationChan chan<- types.ConfigMessage
@@ -40,7 +42,7 @@ func (p *Provider) AddRoutes(systemRouter *mux.Router) {
 				fmt.Fprint(response, "Only 'rest' provider can be updated through the REST API")
 				return
 			} else if vars["provider"] == "web" {
-				log.Warn("The provider web is deprecated. Please use /rest instead")
+				logger.Warn("The provider web is deprecated. Please use /rest instead")
 			}
 
 			configuration := new(types.Configuration)
@@ -51,10 +53,10 @@ func (p *Provider) AddRoutes(systemRouter *mux.Router) {
 				p.configurationChan <- types.ConfigMessage{ProviderName: "web", Configuration: configuration}
 				err := templatesRenderer.JSON(response, http.StatusOK, configuration)
 				if err != nil {
-					log.Error(err)
+					logger.Error(err)
 				}
 			} else {
-				log.Errorf("Error parsing configuration %+v", err)
+				logger.Errorf("Error parsing configuration %+v", err)
 				http.Error(response, fmt.Sprintf("%+v", err), http.StatusBadRequest)
 			}
 		})
@@ -15,6 +15,8 @@ import (
 	"github.com/containous/traefik/types"
 )
 
+var logger = log.WithModule("rules")
+
 // Rules holds rule parsing and configuration
 type Rules struct {
 	Route        *types.ServerRoute
@@ -39,7 +41,7 @@ func (r *Rules) host(hosts ...string) *mux.Route {
 				if strings.EqualFold(reqH, host) || strings.EqualFold(flatH, host) {
 					return true
 				}
-				log.Debugf("CNAMEFlattening: request %s which resolved to %s, is not matched to route %s", reqH, flatH, host)
+				logger.Debugf("CNAMEFlattening: request %s which resolved to %s, is not matched to route %s", reqH, flatH, host)
 			}
 			return false
 		}
@@ -10,6 +10,8 @@ import (
 	"github.com/containous/traefik/log"
 )
 
+var logger = log.WithModule("safe")
+
 type routine struct {
 	goroutine func(chan bool)
 	stop      chan bool
@@ -144,8 +146,8 @@ func GoWithRecover(goroutine func(), customRecover func(err interface{})) {
 }
 
 func defaultRecoverGoroutine(err interface{}) {
-	log.Errorf("Error in Go routine: %s", err)
-	log.Errorf("Stack: %s", debug.Stack())
+	logger.Errorf("Error in Go routine: %s", err)
+	logger.Errorf("Stack: %s", debug.Stack())
 }
 
 // OperationWithRecover wrap a backoff operation in a Recover
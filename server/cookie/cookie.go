@@ -8,6 +8,8 @@ import (
 	"github.com/containous/traefik/log"
 )
 
+var logger = log.WithModule("server/cookie")
+
 const cookieNameLength = 6
 
 // GetName of a cookie
@@ -27,7 +29,7 @@ func GenerateName(backendName string) string {
 	_, err := hash.Write(data)
 	if err != nil {
 		// Impossible case
-		log.Errorf("Fail to create cookie name: %v", err)
+		logger.Errorf("Fail to create cookie name: %v", err)
 	}
 
 	return fmt.Sprintf("_%x", hash.Sum(nil))[:cookieNameLength]
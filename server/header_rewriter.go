@@ -4,7 +4,6 @@ import (
 	"net/http"
 	"os"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/whitelist"
 	"github.com/vulcand/oxy/forward"
 	"github.com/vulcand/oxy/utils"
@@ -50,7 +49,7 @@ func (h *headerRewriter) Rewrite(req *http.Request) {
 
 	err := h.ips.IsAuthorized(req)
 	if err != nil {
-		log.Debug(err)
+		logger.Debug(err)
 		// Remove additional X-Forwarded Headers which are used by the forward authentication
 		utils.RemoveHeaders(req.Header, xForwardedURI, xForwardedMethod)
 		h.secureRewriter.Rewrite(req)
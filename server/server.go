@@ -33,11 +33,14 @@ import (
 	traefiktls "github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
 	"github.com/containous/traefik/whitelist"
+	"github.com/go-acme/lego/challenge/http01"
 	"github.com/go-acme/lego/challenge/tlsalpn01"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/negroni"
 )
 
+var logger = log.WithModule("server")
+
 var httpServerLogger = stdlog.New(log.WriterLevel(logrus.DebugLevel), "", 0)
 
 func newHijackConnectionTracker() *hijackConnectionTracker {
@@ -89,7 +92,7 @@ func (h *hijackConnectionTracker) Close() {
 	defer h.lock.Unlock()
 	for conn := range h.conns {
 		if err := conn.Close(); err != nil {
-			log.Errorf("Error while closing Hijacked conn: %v", err)
+			logger.Errorf("Error while closing Hijacked conn: %v", err)
 		}
 		delete(h.conns, conn)
 	}
@@ -111,6 +114,7 @@ type Server struct {
 	leadership                    *cluster.Leadership
 	defaultForwardingRoundTripper http.RoundTripper
 	metricsRegistry               metrics.Registry
+	serverSeriesLimiter           *metrics.ServerSeriesLimiter
 	provider                      provider.Provider
 	configurationListeners        []func(types.Configuration)
 	entryPoints                   map[string]EntryPoint
@@ -119,11 +123,12 @@ type Server struct {
 
 // EntryPoint entryPoint information (configuration + internalRouter)
 type EntryPoint struct {
-	InternalRouter   types.InternalRouter
-	Configuration    *configuration.EntryPoint
-	OnDemandListener func(string) (*tls.Certificate, error)
-	TLSALPNGetter    func(string) (*tls.Certificate, error)
-	CertificateStore *traefiktls.CertificateStore
+	InternalRouter    types.InternalRouter
+	Configuration     *configuration.EntryPoint
+	OnDemandListener  func(string) (*tls.Certificate, error)
+	TLSALPNGetter     func(string) (*tls.Certificate, error)
+	CertificateStore  *traefiktls.CertificateStore
+	ACMEHTTPChallenge bool
 }
 
 type serverEntryPoints map[string]*serverEntryPoint
@@ -136,6 +141,9 @@ type serverEntryPoint struct {
 	onDemandListener        func(string) (*tls.Certificate, error)
 	tlsALPNGetter           func(string) (*tls.Certificate, error)
 	hijackConnectionTracker *hijackConnectionTracker
+	tlsConfig               *traefiktls.DynamicConfig
+	clientCAs               traefiktls.FilesOrContents
+	domainsOptions          []*traefiktls.DomainsOptions
 }
 
 func (s serverEntryPoint) Shutdown(ctx context.Context) {
@@ -146,10 +154,10 @@ func (s serverEntryPoint) Shutdown(ctx context.Context) {
 			defer wg.Done()
 			if err := s.httpServer.Shutdown(ctx); err != nil {
 				if ctx.Err() == context.DeadlineExceeded {
-					log.Debugf("Wait server shutdown is over due to: %s", err)
+					logger.Debugf("Wait server shutdown is over due to: %s", err)
 					err = s.httpServer.Close()
 					if err != nil {
-						log.Error(err)
+						logger.Error(err)
 					}
 				}
 			}
@@ -162,7 +170,7 @@ func (s serverEntryPoint) Shutdown(ctx context.Context) {
 			defer wg.Done()
 			if err := s.hijackConnectionTracker.Shutdown(ctx); err != nil {
 				if ctx.Err() == context.DeadlineExceeded {
-					log.Debugf("Wait hijack connection is over due to: %s", err)
+					logger.Debugf("Wait hijack connection is over due to: %s", err)
 					s.hijackConnectionTracker.Close()
 				}
 			}
@@ -215,7 +223,7 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 
 	transport, err := createHTTPTransport(globalConfiguration)
 	if err != nil {
-		log.Errorf("failed to create HTTP transport: %v", err)
+		logger.Errorf("failed to create HTTP transport: %v", err)
 	}
 
 	server.defaultForwardingRoundTripper = transport
@@ -226,6 +234,9 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 	}
 
 	server.metricsRegistry = registerMetricClients(globalConfiguration.Metrics)
+	if server.metricsRegistry.IsEnabled() && globalConfiguration.Metrics.Servers != nil {
+		server.serverSeriesLimiter = metrics.NewServerSeriesLimiter(globalConfiguration.Metrics.Servers.MaxSeries)
+	}
 
 	if globalConfiguration.Cluster != nil {
 		// leadership creation if cluster mode
@@ -240,7 +251,7 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 		var err error
 		server.accessLoggerMiddleware, err = accesslog.NewLogHandler(globalConfiguration.AccessLog)
 		if err != nil {
-			log.Warnf("Unable to create log handler: %s", err)
+			logger.Warnf("Unable to create log handler: %s", err)
 		}
 	}
 	return server
@@ -250,6 +261,7 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 func (s *Server) Start() {
 	s.startHTTPServers()
 	s.startLeadership()
+	s.startCertificatesWatchdog()
 	s.routinesPool.Go(func(stop chan bool) {
 		s.listenProviders(stop)
 	})
@@ -267,13 +279,13 @@ func (s *Server) StartWithContext(ctx context.Context) {
 	go func() {
 		defer s.Close()
 		<-ctx.Done()
-		log.Info("I have to go...")
+		logger.Info("I have to go...")
 		reqAcceptGraceTimeOut := time.Duration(s.globalConfiguration.LifeCycle.RequestAcceptGraceTimeout)
 		if reqAcceptGraceTimeOut > 0 {
-			log.Infof("Waiting %s for incoming requests to cease", reqAcceptGraceTimeOut)
+			logger.Infof("Waiting %s for incoming requests to cease", reqAcceptGraceTimeOut)
 			time.Sleep(reqAcceptGraceTimeOut)
 		}
-		log.Info("Stopping server gracefully")
+		logger.Info("Stopping server gracefully")
 		s.Stop()
 	}()
 	s.Start()
@@ -286,7 +298,7 @@ func (s *Server) Wait() {
 
 // Stop stops the server
 func (s *Server) Stop() {
-	defer log.Info("Server stopped")
+	defer logger.Info("Server stopped")
 	var wg sync.WaitGroup
 	for sepn, sep := range s.serverEntryPoints {
 		wg.Add(1)
@@ -294,10 +306,10 @@ func (s *Server) Stop() {
 			defer wg.Done()
 			graceTimeOut := time.Duration(s.globalConfiguration.LifeCycle.GraceTimeOut)
 			ctx, cancel := context.WithTimeout(context.Background(), graceTimeOut)
-			log.Debugf("Waiting %s seconds before killing connections on entrypoint %s...", graceTimeOut, serverEntryPointName)
+			logger.Debugf("Waiting %s seconds before killing connections on entrypoint %s...", graceTimeOut, serverEntryPointName)
 			serverEntryPoint.Shutdown(ctx)
 			cancel()
-			log.Debugf("Entrypoint %s closed", serverEntryPointName)
+			logger.Debugf("Entrypoint %s closed", serverEntryPointName)
 		}(sepn, sep)
 	}
 	wg.Wait()
@@ -325,7 +337,7 @@ func (s *Server) Close() {
 	close(s.stopChan)
 	if s.accessLoggerMiddleware != nil {
 		if err := s.accessLoggerMiddleware.Close(); err != nil {
-			log.Errorf("Error closing access log file: %s", err)
+			logger.Errorf("Error closing access log file: %s", err)
 		}
 	}
 	cancel()
@@ -361,10 +373,11 @@ func (s *Server) listenProviders(stop chan bool) {
 			if !ok {
 				return
 			}
+			s.metricsRegistry.ProviderEventsCounter().With("provider", configMsg.ProviderName).Add(1)
 			if configMsg.Configuration != nil {
 				s.preLoadConfiguration(configMsg)
 			} else {
-				log.Debugf("Received nil configuration from provider %q, skipping.", configMsg.ProviderName)
+				logger.WithField(log.ProviderName, configMsg.ProviderName).Debugf("Received nil configuration from provider %q, skipping.", configMsg.ProviderName)
 			}
 		}
 	}
@@ -407,22 +420,22 @@ func (s *serverEntryPoint) getCertificate(clientHello *tls.ClientHelloInfo) (*tl
 		return nil, fmt.Errorf("strict SNI enabled - No certificate found for domain: %q, closing connection", domainToCheck)
 	}
 
-	log.Debugf("Serving default cert for request: %q", domainToCheck)
-	return s.certs.DefaultCertificate, nil
+	logger.Debugf("Serving default cert for request: %q", domainToCheck)
+	return s.certs.GetDefaultCertificate(), nil
 }
 
 func (s *Server) startProvider() {
 	// start providers
 	jsonConf, err := json.Marshal(s.provider)
 	if err != nil {
-		log.Debugf("Unable to marshal provider conf %T with error: %v", s.provider, err)
+		logger.Debugf("Unable to marshal provider conf %T with error: %v", s.provider, err)
 	}
-	log.Infof("Starting provider %T %s", s.provider, jsonConf)
+	logger.Infof("Starting provider %T %s", s.provider, jsonConf)
 	currentProvider := s.provider
 	safe.Go(func() {
 		err := currentProvider.Provide(s.configurationChan, s.routinesPool)
 		if err != nil {
-			log.Errorf("Error starting provider %T: %s", s.provider, err)
+			logger.Errorf("Error starting provider %T: %s", s.provider, err)
 		}
 	})
 }
@@ -444,29 +457,18 @@ func (s *Server) createTLSConfig(entryPointName string, tlsOption *traefiktls.TL
 	config.NextProtos = []string{"h2", "http/1.1", tlsalpn01.ACMETLS1Protocol}
 
 	if len(tlsOption.ClientCAFiles) > 0 {
-		log.Warnf("Deprecated configuration found during TLS configuration creation: %s. Please use %s (which allows to make the CA Files optional).", "tls.ClientCAFiles", "tls.ClientCA.files")
+		logger.Warnf("Deprecated configuration found during TLS configuration creation: %s. Please use %s (which allows to make the CA Files optional).", "tls.ClientCAFiles", "tls.ClientCA.files")
 		tlsOption.ClientCA.Files = tlsOption.ClientCAFiles
 		tlsOption.ClientCA.Optional = false
 	}
 
 	if len(tlsOption.ClientCA.Files) > 0 {
-		pool := x509.NewCertPool()
-		for _, caFile := range tlsOption.ClientCA.Files {
-			data, err := caFile.Read()
-			if err != nil {
-				return nil, err
-			}
-			ok := pool.AppendCertsFromPEM(data)
-			if !ok {
-				return nil, fmt.Errorf("invalid certificate(s) in %s", caFile)
-			}
+		pool, err := buildClientCAPool(tlsOption.ClientCA.Files)
+		if err != nil {
+			return nil, err
 		}
 		config.ClientCAs = pool
-		if tlsOption.ClientCA.Optional {
-			config.ClientAuth = tls.VerifyClientCertIfGiven
-		} else {
-			config.ClientAuth = tls.RequireAndVerifyClientCert
-		}
+		config.ClientAuth = getClientAuthType(tlsOption.ClientCA)
 	}
 
 	if s.globalConfiguration.ACME != nil && entryPointName == s.globalConfiguration.ACME.EntryPoint {
@@ -503,6 +505,22 @@ func (s *Server) createTLSConfig(entryPointName string, tlsOption *traefiktls.TL
 		config.MinVersion = minConst
 	}
 
+	if s.entryPoints[entryPointName].Configuration.TLS.PreferServerCipherSuites {
+		config.PreferServerCipherSuites = true
+	}
+
+	// Set the maximum TLS version if set in the config TOML
+	if len(s.entryPoints[entryPointName].Configuration.TLS.MaxVersion) > 0 {
+		maxConst, exists := traefiktls.MaxVersion[s.entryPoints[entryPointName].Configuration.TLS.MaxVersion]
+		if !exists {
+			return nil, fmt.Errorf("invalid TLS MaxVersion: %s", s.entryPoints[entryPointName].Configuration.TLS.MaxVersion)
+		}
+		if config.MinVersion != 0 && maxConst < config.MinVersion {
+			return nil, fmt.Errorf("TLS MaxVersion %s is lower than MinVersion %s", s.entryPoints[entryPointName].Configuration.TLS.MaxVersion, s.entryPoints[entryPointName].Configuration.TLS.MinVersion)
+		}
+		config.MaxVersion = maxConst
+	}
+
 	// Set the list of CipherSuites if set in the config TOML
 	if s.entryPoints[entryPointName].Configuration.TLS.CipherSuites != nil {
 		// if our list of CipherSuites is defined in the entrypoint config, we can re-initilize the suites list as empty
@@ -517,11 +535,96 @@ func (s *Server) createTLSConfig(entryPointName string, tlsOption *traefiktls.TL
 		}
 	}
 
+	// Set the list of elliptic curves if set in the config TOML
+	if s.entryPoints[entryPointName].Configuration.TLS.CurvePreferences != nil {
+		config.CurvePreferences = make([]tls.CurveID, 0)
+		for _, curve := range s.entryPoints[entryPointName].Configuration.TLS.CurvePreferences {
+			if curveID, exists := traefiktls.CurveIDs[curve]; exists {
+				config.CurvePreferences = append(config.CurvePreferences, curveID)
+			} else {
+				return nil, fmt.Errorf("invalid CurvePreferences: %s", curve)
+			}
+		}
+	}
+
+	dynamicConfig := traefiktls.NewDynamicConfig(config)
+	s.serverEntryPoints[entryPointName].tlsConfig = dynamicConfig
+
+	if err := s.configureSessionTickets(entryPointName, config, dynamicConfig, tlsOption.SessionTickets); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
+func buildClientCAPool(caFiles traefiktls.FilesOrContents) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	for _, caFile := range caFiles {
+		data, err := caFile.Read()
+		if err != nil {
+			return nil, err
+		}
+		ok := pool.AppendCertsFromPEM(data)
+		if !ok {
+			return nil, fmt.Errorf("invalid certificate(s) in %s", caFile)
+		}
+	}
+	return pool, nil
+}
+
+func getClientAuthType(clientCA traefiktls.ClientCA) tls.ClientAuthType {
+	if clientCA.Optional {
+		return tls.VerifyClientCertIfGiven
+	}
+	return tls.RequireAndVerifyClientCert
+}
+
+// updateClientCAs applies the client CAs provided dynamically, on top of the ones from the entrypoint configuration
+func (s *serverEntryPoint) updateClientCAs(clientCA traefiktls.ClientCA, clientCAs traefiktls.FilesOrContents) {
+	if s.tlsConfig == nil {
+		return
+	}
+
+	if len(clientCAs) == 0 {
+		s.tlsConfig.SetClientCAs(nil, tls.NoClientCert)
+		return
+	}
+
+	var caFiles traefiktls.FilesOrContents
+	caFiles = append(caFiles, clientCA.Files...)
+	caFiles = append(caFiles, clientCAs...)
+
+	pool, err := buildClientCAPool(caFiles)
+	if err != nil {
+		logger.Errorf("Unable to update client CAs: %v", err)
+		return
+	}
+	s.tlsConfig.SetClientCAs(pool, getClientAuthType(clientCA))
+}
+
+// updateDomainsOptions applies the TLS options provided dynamically for some domains
+func (s *serverEntryPoint) updateDomainsOptions(domainsOptions []*traefiktls.DomainsOptions) {
+	if s.tlsConfig == nil {
+		return
+	}
+
+	s.tlsConfig.SetDomainsOptions(domainsOptions)
+}
+
+// rejectMisdirectedRequests rejects the requests for a host with other TLS options than the server name of the TLS handshake,
+// with a 421 Misdirected Request for the client to open a new connection to this host.
+func (s *serverEntryPoint) rejectMisdirectedRequests(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if r.TLS != nil && s.tlsConfig != nil && !s.tlsConfig.SameDomainsOptions(middlewares.GetCanonizedHost(r.Context()), r.TLS.ServerName) {
+		logger.FromContext(r.Context()).Debugf("Rejecting the request for %s on a TLS connection established for %q", r.Host, r.TLS.ServerName)
+		http.Error(rw, http.StatusText(http.StatusMisdirectedRequest), http.StatusMisdirectedRequest)
+		return
+	}
+
+	next(rw, r)
+}
+
 func (s *Server) startServer(serverEntryPoint *serverEntryPoint) {
-	log.Infof("Starting server on %s", serverEntryPoint.httpServer.Addr)
+	logger.Infof("Starting server on %s", serverEntryPoint.httpServer.Addr)
 
 	var err error
 	if serverEntryPoint.httpServer.TLSConfig != nil {
@@ -531,19 +634,19 @@ func (s *Server) startServer(serverEntryPoint *serverEntryPoint) {
 	}
 
 	if err != http.ErrServerClosed {
-		log.Error("Error creating server: ", err)
+		logger.Error("Error creating server: ", err)
 	}
 }
 
 func (s *Server) setupServerEntryPoint(newServerEntryPointName string, newServerEntryPoint *serverEntryPoint) *serverEntryPoint {
 	serverMiddlewares, err := s.buildServerEntryPointMiddlewares(newServerEntryPointName, newServerEntryPoint)
 	if err != nil {
-		log.Fatal("Error preparing server: ", err)
+		logger.Fatal("Error preparing server: ", err)
 	}
 
 	newSrv, listener, err := s.prepareServer(newServerEntryPointName, s.entryPoints[newServerEntryPointName].Configuration, newServerEntryPoint.httpRouter, serverMiddlewares)
 	if err != nil {
-		log.Fatal("Error preparing server: ", err)
+		logger.Fatal("Error preparing server: ", err)
 	}
 
 	serverEntryPoint := s.serverEntryPoints[newServerEntryPointName]
@@ -551,7 +654,13 @@ func (s *Server) setupServerEntryPoint(newServerEntryPointName string, newServer
 	serverEntryPoint.listener = listener
 
 	serverEntryPoint.hijackConnectionTracker = newHijackConnectionTracker()
+	// the TLS handshake metrics hook of the server is kept
+	connState := serverEntryPoint.httpServer.ConnState
 	serverEntryPoint.httpServer.ConnState = func(conn net.Conn, state http.ConnState) {
+		if connState != nil {
+			connState(conn, state)
+		}
+
 		switch state {
 		case http.StateHijacked:
 			serverEntryPoint.hijackConnectionTracker.AddHijackedConnection(conn)
@@ -565,23 +674,36 @@ func (s *Server) setupServerEntryPoint(newServerEntryPointName string, newServer
 
 func (s *Server) prepareServer(entryPointName string, entryPoint *configuration.EntryPoint, router *middlewares.HandlerSwitcher, middlewares []negroni.Handler) (*h2c.Server, net.Listener, error) {
 	readTimeout, writeTimeout, idleTimeout := buildServerTimeouts(s.globalConfiguration)
-	log.Infof("Preparing server %s %+v with readTimeout=%s writeTimeout=%s idleTimeout=%s", entryPointName, entryPoint, readTimeout, writeTimeout, idleTimeout)
+	logger.Infof("Preparing server %s %+v with readTimeout=%s writeTimeout=%s idleTimeout=%s", entryPointName, entryPoint, readTimeout, writeTimeout, idleTimeout)
 
 	// middlewares
 	n := negroni.New()
 	for _, middleware := range middlewares {
 		n.Use(middleware)
 	}
-	n.UseHandler(router)
 
 	internalMuxRouter := s.buildInternalRouter(entryPointName)
-	internalMuxRouter.NotFoundHandler = n
+
+	var handler http.Handler = internalMuxRouter
+	if entryPoint.InternalRoutesPriority == configuration.InternalRoutesPriorityLow {
+		n.UseHandler(&frontendsFirstHandler{frontends: router, internal: internalMuxRouter})
+		internalMuxRouter.NotFoundHandler = router
+		handler = n
+	} else {
+		n.UseHandler(router)
+		internalMuxRouter.NotFoundHandler = n
+	}
 
 	tlsConfig, err := s.createTLSConfig(entryPointName, entryPoint.TLS, router)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating TLS config: %v", err)
 	}
 
+	var connState func(net.Conn, http.ConnState)
+	if tlsConfig != nil && s.metricsRegistry.IsEnabled() {
+		connState = newTLSHandshakeMetrics(entryPointName, s.metricsRegistry).connState
+	}
+
 	listener, err := net.Listen("tcp", entryPoint.Address)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error opening listener: %v", err)
@@ -599,12 +721,13 @@ func (s *Server) prepareServer(entryPointName string, entryPoint *configuration.
 	return &h2c.Server{
 			Server: &http.Server{
 				Addr:         entryPoint.Address,
-				Handler:      internalMuxRouter,
+				Handler:      handler,
 				TLSConfig:    tlsConfig,
 				ReadTimeout:  readTimeout,
 				WriteTimeout: writeTimeout,
 				IdleTimeout:  idleTimeout,
 				ErrorLog:     httpServerLogger,
+				ConnState:    connState,
 			},
 		},
 		listener,
@@ -617,7 +740,7 @@ func buildProxyProtocolListener(entryPoint *configuration.EntryPoint, listener n
 		return nil, fmt.Errorf("error creating whitelist: %s", err)
 	}
 
-	log.Infof("Enabling ProxyProtocol for trusted IPs %v", entryPoint.ProxyProtocol.TrustedIPs)
+	logger.Infof("Enabling ProxyProtocol for trusted IPs %v", entryPoint.ProxyProtocol.TrustedIPs)
 
 	return &proxyproto.Listener{
 		Listener: listener,
@@ -653,6 +776,26 @@ func (s *Server) buildInternalRouter(entryPointName string) *mux.Router {
 	return internalMuxRouter
 }
 
+// frontendsFirstHandler serves the internal routes only when no frontend matches the request.
+// The ACME HTTP-01 challenges are always answered by the internal routes.
+type frontendsFirstHandler struct {
+	frontends *middlewares.HandlerSwitcher
+	internal  *mux.Router
+}
+
+func (h *frontendsFirstHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !strings.HasPrefix(req.URL.Path, http01.ChallengePath("")) {
+		match := &mux.RouteMatch{}
+		// a not found match has no route
+		if h.frontends.GetHandler().Match(req, match) && match.Route != nil {
+			h.frontends.ServeHTTP(rw, req)
+			return
+		}
+	}
+
+	h.internal.ServeHTTP(rw, req)
+}
+
 func buildServerTimeouts(globalConfig configuration.GlobalConfiguration) (readTimeout, writeTimeout, idleTimeout time.Duration) {
 	readTimeout = time.Duration(0)
 	writeTimeout = time.Duration(0)
@@ -664,7 +807,7 @@ func buildServerTimeouts(globalConfig configuration.GlobalConfiguration) (readTi
 	// Prefer legacy idle timeout parameter for backwards compatibility reasons
 	if globalConfig.IdleTimeout > 0 {
 		idleTimeout = time.Duration(globalConfig.IdleTimeout)
-		log.Warn("top-level idle timeout configuration has been deprecated -- please use responding timeouts")
+		logger.Warn("top-level idle timeout configuration has been deprecated -- please use responding timeouts")
 	} else if globalConfig.RespondingTimeouts != nil {
 		idleTimeout = time.Duration(globalConfig.RespondingTimeouts.IdleTimeout)
 	} else {
@@ -684,20 +827,27 @@ func registerMetricClients(metricsConfig *types.Metrics) metrics.Registry {
 		prometheusRegister := metrics.RegisterPrometheus(metricsConfig.Prometheus)
 		if prometheusRegister != nil {
 			registries = append(registries, prometheusRegister)
-			log.Debug("Configured Prometheus metrics")
+			logger.Debug("Configured Prometheus metrics")
 		}
 	}
 	if metricsConfig.Datadog != nil {
 		registries = append(registries, metrics.RegisterDatadog(metricsConfig.Datadog))
-		log.Debugf("Configured DataDog metrics pushing to %s once every %s", metricsConfig.Datadog.Address, metricsConfig.Datadog.PushInterval)
+		logger.Debugf("Configured DataDog metrics pushing to %s once every %s", metricsConfig.Datadog.Address, metricsConfig.Datadog.PushInterval)
 	}
 	if metricsConfig.StatsD != nil {
 		registries = append(registries, metrics.RegisterStatsd(metricsConfig.StatsD))
-		log.Debugf("Configured StatsD metrics pushing to %s once every %s", metricsConfig.StatsD.Address, metricsConfig.StatsD.PushInterval)
+		logger.Debugf("Configured StatsD metrics pushing to %s once every %s", metricsConfig.StatsD.Address, metricsConfig.StatsD.PushInterval)
 	}
 	if metricsConfig.InfluxDB != nil {
 		registries = append(registries, metrics.RegisterInfluxDB(metricsConfig.InfluxDB))
-		log.Debugf("Configured InfluxDB metrics pushing to %s once every %s", metricsConfig.InfluxDB.Address, metricsConfig.InfluxDB.PushInterval)
+		logger.Debugf("Configured InfluxDB metrics pushing to %s once every %s", metricsConfig.InfluxDB.Address, metricsConfig.InfluxDB.PushInterval)
+	}
+	if metricsConfig.OTLP != nil {
+		otlpRegister := metrics.RegisterOTLP(metricsConfig.OTLP)
+		if otlpRegister != nil {
+			registries = append(registries, otlpRegister)
+			logger.Debugf("Configured OTLP metrics pushing to %s once every %s", metricsConfig.OTLP.Endpoint, metricsConfig.OTLP.PushInterval)
+		}
 	}
 
 	return metrics.NewMultiRegistry(registries)
@@ -707,6 +857,7 @@ func stopMetricsClients() {
 	metrics.StopDatadog()
 	metrics.StopStatsd()
 	metrics.StopInfluxDB()
+	metrics.StopOTLP()
 }
 
 func (s *Server) buildNameOrIPToCertificate(certs []tls.Certificate) map[string]*tls.Certificate {
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/containous/traefik/metrics"
+	traefiktls "github.com/containous/traefik/tls"
+	"github.com/containous/traefik/tls/watchdog"
+	"github.com/containous/traefik/types"
+)
+
+// acmeProviderName is the name of the configurations holding the certificates managed by the ACME provider
+const acmeProviderName = "ACME"
+
+// startCertificatesWatchdog checks the expiration of the certificates served by the TLS entrypoints
+func (s *Server) startCertificatesWatchdog() {
+	if s.globalConfiguration.CertificatesWatchdog == nil {
+		return
+	}
+
+	certificatesWatchdog := watchdog.New(s.globalConfiguration.CertificatesWatchdog, s.metricsRegistry)
+
+	// new certificates are checked as soon as they are loaded
+	s.AddListener(func(types.Configuration) {
+		certificatesWatchdog.Trigger()
+	})
+
+	s.routinesPool.GoCtx(func(ctx context.Context) {
+		certificatesWatchdog.Run(ctx, func() []*watchdog.Certificate {
+			certificates := s.getWatchedCertificates()
+			if s.metricsRegistry.IsEnabled() {
+				metrics.OnCertificatesUpdate(watchdog.SerialsByCommonName(certificates))
+			}
+			return certificates
+		})
+	})
+}
+
+// getWatchedCertificates returns the certificates of the TLS entrypoints, except the ones renewed by the ACME provider
+func (s *Server) getWatchedCertificates() []*watchdog.Certificate {
+	stores := make(map[string]*traefiktls.CertificateStore)
+	for entryPointName, serverEntryPoint := range s.serverEntryPoints {
+		if s.entryPoints[entryPointName].Configuration.TLS != nil {
+			stores[entryPointName] = serverEntryPoint.certs
+		}
+	}
+
+	currentConfigurations, _ := s.currentConfigurations.Get().(types.Configurations)
+	acmeCertificates := getCertificatesDER(currentConfigurations[acmeProviderName])
+
+	return watchdog.CertificatesFromStores(stores, func(cert *x509.Certificate) bool {
+		_, ok := acmeCertificates[string(cert.Raw)]
+		return ok
+	})
+}
+
+// getCertificatesDER returns the DER encoded leaf certificates of a configuration
+func getCertificatesDER(config *types.Configuration) map[string]struct{} {
+	certificates := make(map[string]struct{})
+	if config == nil {
+		return certificates
+	}
+
+	for _, conf := range config.TLS {
+		if conf == nil || conf.Certificate == nil {
+			continue
+		}
+
+		content, err := conf.Certificate.CertFile.Read()
+		if err != nil {
+			logger.Debugf("Unable to read certificate: %v", err)
+			continue
+		}
+
+		if block, _ := pem.Decode(content); block != nil {
+			certificates[string(block.Bytes)] = struct{}{}
+		}
+	}
+	return certificates
+}
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/containous/flaeg/parse"
@@ -19,6 +20,7 @@ import (
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/metrics"
 	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/middlewares/accesslog"
 	"github.com/containous/traefik/middlewares/pipelining"
 	"github.com/containous/traefik/rules"
 	traefiktls "github.com/containous/traefik/tls"
@@ -43,22 +45,28 @@ func (s *Server) loadConfiguration(configMsg types.ConfigMessage) {
 
 	s.metricsRegistry.ConfigReloadsCounter().Add(1)
 
+	start := time.Now()
 	newServerEntryPoints := s.loadConfig(newConfigurations, s.globalConfiguration)
+	s.metricsRegistry.ConfigBuildDurationHistogram().With("provider", configMsg.ProviderName).Observe(time.Since(start).Seconds())
 
 	s.metricsRegistry.LastConfigReloadSuccessGauge().Set(float64(time.Now().Unix()))
+	s.updateProviderMetrics(configMsg)
 
 	for newServerEntryPointName, newServerEntryPoint := range newServerEntryPoints {
 		s.serverEntryPoints[newServerEntryPointName].httpRouter.UpdateHandler(newServerEntryPoint.httpRouter.GetHandler())
 
 		if s.entryPoints[newServerEntryPointName].Configuration.TLS == nil {
 			if newServerEntryPoint.certs.ContainsCertificates() {
-				log.Debugf("Certificates not added to non-TLS entryPoint %s.", newServerEntryPointName)
+				logger.Debugf("Certificates not added to non-TLS entryPoint %s.", newServerEntryPointName)
 			}
 		} else {
 			s.serverEntryPoints[newServerEntryPointName].certs.DynamicCerts.Set(newServerEntryPoint.certs.DynamicCerts.Get())
+			s.serverEntryPoints[newServerEntryPointName].certs.DynamicDefaultCertificate.Set(newServerEntryPoint.certs.DynamicDefaultCertificate.Get())
 			s.serverEntryPoints[newServerEntryPointName].certs.ResetCache()
+			s.serverEntryPoints[newServerEntryPointName].updateClientCAs(s.entryPoints[newServerEntryPointName].Configuration.TLS.ClientCA, newServerEntryPoint.clientCAs)
+			s.serverEntryPoints[newServerEntryPointName].updateDomainsOptions(newServerEntryPoint.domainsOptions)
 		}
-		log.Infof("Server configuration reloaded on %s", s.serverEntryPoints[newServerEntryPointName].httpServer.Addr)
+		logger.Infof("Server configuration reloaded on %s", s.serverEntryPoints[newServerEntryPointName].httpServer.Addr)
 	}
 
 	s.currentConfigurations.Set(newConfigurations)
@@ -89,7 +97,8 @@ func (s *Server) loadConfig(configurations types.Configurations, globalConfigura
 				serverEntryPoints,
 				backendsHandlers, backendsHealthCheck)
 			if err != nil {
-				log.Errorf("%v. Skipping frontend %s...", err, frontendName)
+				logger.WithFields(logrus.Fields{log.ProviderName: providerName, log.FrontendName: frontendName}).
+					Errorf("%v. Skipping frontend %s...", err, frontendName)
 			}
 
 			if len(frontendPostConfigs) > 0 {
@@ -101,7 +110,7 @@ func (s *Server) loadConfig(configurations types.Configurations, globalConfigura
 	for _, postConfig := range postConfigs {
 		err := postConfig(backendsHandlers)
 		if err != nil {
-			log.Errorf("middleware post configuration error: %v", err)
+			logger.Errorf("middleware post configuration error: %v", err)
 		}
 	}
 
@@ -110,6 +119,9 @@ func (s *Server) loadConfig(configurations types.Configurations, globalConfigura
 	// Get new certificates list sorted per entrypoints
 	// Update certificates
 	entryPointsCertificates := s.loadHTTPSConfiguration(configurations, globalConfiguration.DefaultEntryPoints)
+	entryPointsDefaultCertificates := loadDefaultCertificates(configurations, globalConfiguration.DefaultEntryPoints)
+	entryPointsClientCAs := loadClientCAs(configurations, globalConfiguration.DefaultEntryPoints)
+	entryPointsDomainsOptions := loadDomainsOptions(configurations, globalConfiguration.DefaultEntryPoints)
 
 	// Sort routes and update certificates
 	for serverEntryPointName, serverEntryPoint := range serverEntryPoints {
@@ -117,6 +129,9 @@ func (s *Server) loadConfig(configurations types.Configurations, globalConfigura
 		if _, exists := entryPointsCertificates[serverEntryPointName]; exists {
 			serverEntryPoint.certs.DynamicCerts.Set(entryPointsCertificates[serverEntryPointName])
 		}
+		serverEntryPoint.certs.DynamicDefaultCertificate.Set(entryPointsDefaultCertificates[serverEntryPointName])
+		serverEntryPoint.clientCAs = entryPointsClientCAs[serverEntryPointName]
+		serverEntryPoint.domainsOptions = entryPointsDomainsOptions[serverEntryPointName]
 	}
 
 	return serverEntryPoints
@@ -148,12 +163,12 @@ func (s *Server) loadFrontendConfig(
 	var postConfigs []handlerPostConfig
 
 	for _, entryPointName := range frontend.EntryPoints {
-		log.Debugf("Wiring frontend %s to entryPoint %s", frontendName, entryPointName)
+		logger.Debugf("Wiring frontend %s to entryPoint %s", frontendName, entryPointName)
 
 		entryPoint := s.entryPoints[entryPointName].Configuration
 
 		if backendsHandlers[entryPointName+providerName+frontendHash] == nil {
-			log.Debugf("Creating backend %s", frontend.Backend)
+			logger.Debugf("Creating backend %s", frontend.Backend)
 
 			handlers, responseModifier, postConfig, err := s.buildMiddlewares(frontendName, frontend, config.Backends, entryPointName, entryPoint, providerName)
 			if err != nil {
@@ -193,7 +208,7 @@ func (s *Server) loadFrontendConfig(
 
 			backendsHandlers[entryPointName+providerName+frontendHash] = n
 		} else {
-			log.Debugf("Reusing backend %s [%s - %s - %s - %s]",
+			logger.Debugf("Reusing backend %s [%s - %s - %s - %s]",
 				frontend.Backend, entryPointName, providerName, frontendName, frontendHash)
 		}
 
@@ -203,12 +218,22 @@ func (s *Server) loadFrontendConfig(
 		}
 
 		handler := buildMatcherMiddlewares(serverRoute, backendsHandlers[entryPointName+providerName+frontendHash])
+		handler = middlewares.NewFrontendLogContext(handler, frontendName, frontend.Backend)
+		if s.accessLoggerMiddleware != nil {
+			handler = accesslog.NewSaveRule(handler, frontendRule(frontend))
+			if frontend.AccessLog != nil {
+				handler = accesslog.NewSaveFrontendAccessLog(handler, frontend.AccessLog)
+			}
+		}
+		if s.globalConfiguration.API != nil && s.globalConfiguration.API.StatsRecorder.InspectionEnabled() {
+			handler = s.globalConfiguration.API.StatsRecorder.SaveFrontend(handler, frontendName, frontend.Backend)
+		}
 		serverRoute.Route.Handler(handler)
 
 		err = serverRoute.Route.GetError()
 		if err != nil {
 			// FIXME error management
-			log.Errorf("Error building route: %s", err)
+			logger.Errorf("Error building route: %s", err)
 		}
 	}
 
@@ -219,7 +244,7 @@ func (s *Server) buildForwarder(entryPointName string, entryPoint *configuration
 	frontendName string, frontend *types.Frontend,
 	responseModifier modifyResponse, backend *types.Backend) (http.Handler, error) {
 
-	roundTripper, err := s.getRoundTripper(entryPointName, frontend.PassTLSCert, entryPoint.TLS)
+	roundTripper, err := s.getRoundTripper(entryPointName, frontend.PassTLSCert, entryPoint.TLS, backend.TLS)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create RoundTripper for frontend %s: %v", frontendName, err)
 	}
@@ -269,6 +294,15 @@ func (s *Server) buildForwarder(entryPointName string, entryPoint *configuration
 		})
 	}
 
+	if s.serverSeriesLimiter != nil {
+		sm := middlewares.NewServerMetricsMiddleware(s.metricsRegistry, frontend.Backend, s.serverSeriesLimiter)
+
+		next := fwd
+		fwd = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sm.ServeHTTP(w, r, next.ServeHTTP)
+		})
+	}
+
 	fwd = pipelining.NewPipelining(fwd)
 
 	return fwd, nil
@@ -288,7 +322,7 @@ func buildServerRoute(serverEntryPoint *serverEntryPoint, frontendName string, f
 		serverRoute.Route = newRoute
 
 		priority += len(route.Rule)
-		log.Debugf("Creating route %s %s", routeName, route.Rule)
+		logger.Debugf("Creating route %s %s", routeName, route.Rule)
 	}
 
 	if frontend.Priority > 0 {
@@ -300,23 +334,41 @@ func buildServerRoute(serverEntryPoint *serverEntryPoint, frontendName string, f
 	return serverRoute, nil
 }
 
+// frontendRule returns the rules of the routes of a frontend, which all have to match, sorted by route name
+func frontendRule(frontend *types.Frontend) string {
+	routeNames := make([]string, 0, len(frontend.Routes))
+	for routeName := range frontend.Routes {
+		routeNames = append(routeNames, routeName)
+	}
+	sort.Strings(routeNames)
+
+	rls := make([]string, 0, len(routeNames))
+	for _, routeName := range routeNames {
+		rls = append(rls, frontend.Routes[routeName].Rule)
+	}
+	return strings.Join(rls, ";")
+}
+
 func (s *Server) preLoadConfiguration(configMsg types.ConfigMessage) {
 	providersThrottleDuration := time.Duration(s.globalConfiguration.ProvidersThrottleDuration)
 	s.defaultConfigurationValues(configMsg.Configuration)
 	currentConfigurations := s.currentConfigurations.Get().(types.Configurations)
+	providerLogger := logger.WithField(log.ProviderName, configMsg.ProviderName)
 
-	if log.GetLevel() == logrus.DebugLevel {
+	if logger.GetLevel() >= logrus.DebugLevel {
 		jsonConf, _ := json.Marshal(configMsg.Configuration)
-		log.Debugf("Configuration received from provider %s: %s", configMsg.ProviderName, string(jsonConf))
+		providerLogger.Debugf("Configuration received from provider %s: %s", configMsg.ProviderName, string(jsonConf))
 	}
 
-	if configMsg.Configuration == nil || configMsg.Configuration.Backends == nil && configMsg.Configuration.Frontends == nil && configMsg.Configuration.TLS == nil {
-		log.Infof("Skipping empty Configuration for provider %s", configMsg.ProviderName)
+	if configMsg.Configuration == nil || configMsg.Configuration.Backends == nil && configMsg.Configuration.Frontends == nil && configMsg.Configuration.TLS == nil && configMsg.Configuration.ClientCAs == nil && configMsg.Configuration.TLSOptions == nil {
+		providerLogger.Infof("Skipping empty Configuration for provider %s", configMsg.ProviderName)
 		return
 	}
 
 	if reflect.DeepEqual(currentConfigurations[configMsg.ProviderName], configMsg.Configuration) {
-		log.Infof("Skipping same configuration for provider %s", configMsg.ProviderName)
+		providerLogger.Infof("Skipping same configuration for provider %s", configMsg.ProviderName)
+		// the provider is still in sync
+		s.metricsRegistry.ProviderLastSyncGauge().With("provider", configMsg.ProviderName).Set(float64(time.Now().Unix()))
 		return
 	}
 
@@ -351,7 +403,7 @@ func (s *Server) configureFrontends(frontends map[string]*types.Frontend) {
 
 		frontendEntryPoints, undefinedEntryPoints := s.filterEntryPoints(frontend.EntryPoints)
 		if len(undefinedEntryPoints) > 0 {
-			log.Errorf("Undefined entry point(s) '%s' for frontend %s", strings.Join(undefinedEntryPoints, ","), frontendName)
+			logger.Errorf("Undefined entry point(s) '%s' for frontend %s", strings.Join(undefinedEntryPoints, ","), frontendName)
 		}
 
 		frontend.EntryPoints = frontendEntryPoints
@@ -386,7 +438,7 @@ func configureBackends(backends map[string]*types.Backend) {
 	for backendName := range backends {
 		backend := backends[backendName]
 		if backend.LoadBalancer != nil && backend.LoadBalancer.Sticky {
-			log.Warnf("Deprecated configuration found: %s. Please use %s.", "backend.LoadBalancer.Sticky", "backend.LoadBalancer.Stickiness")
+			logger.Warnf("Deprecated configuration found: %s. Please use %s.", "backend.LoadBalancer.Sticky", "backend.LoadBalancer.Stickiness")
 		}
 
 		_, err := types.NewLoadBalancerMethod(backend.LoadBalancer)
@@ -397,7 +449,7 @@ func configureBackends(backends map[string]*types.Backend) {
 				}
 			}
 		} else {
-			log.Debugf("Backend %s: %v", backendName, err)
+			logger.Debugf("Backend %s: %v", backendName, err)
 
 			var stickiness *types.Stickiness
 			if backend.LoadBalancer != nil {
@@ -433,6 +485,19 @@ func (s *Server) listenConfigurations(stop chan bool) {
 	}
 }
 
+// updateProviderMetrics records the last sync and the size of the configuration of a provider.
+func (s *Server) updateProviderMetrics(configMsg types.ConfigMessage) {
+	var servers int
+	for _, backend := range configMsg.Configuration.Backends {
+		servers += len(backend.Servers)
+	}
+
+	s.metricsRegistry.ProviderLastSyncGauge().With("provider", configMsg.ProviderName).Set(float64(time.Now().Unix()))
+	s.metricsRegistry.ProviderFrontendsGauge().With("provider", configMsg.ProviderName).Set(float64(len(configMsg.Configuration.Frontends)))
+	s.metricsRegistry.ProviderBackendsGauge().With("provider", configMsg.ProviderName).Set(float64(len(configMsg.Configuration.Backends)))
+	s.metricsRegistry.ProviderServersGauge().With("provider", configMsg.ProviderName).Set(float64(servers))
+}
+
 // throttleProviderConfigReload throttles the configuration reload speed for a single provider.
 // It will immediately publish a new configuration and then only publish the next configuration after the throttle duration.
 // Note that in the case it receives N new configs in the timeframe of the throttle duration after publishing,
@@ -441,6 +506,8 @@ func (s *Server) throttleProviderConfigReload(throttle time.Duration, publish ch
 	ring := channels.NewRingChannel(1)
 	defer ring.Close()
 
+	var throttling int32
+
 	s.routinesPool.Go(func(stop chan bool) {
 		for {
 			select {
@@ -449,7 +516,9 @@ func (s *Server) throttleProviderConfigReload(throttle time.Duration, publish ch
 			case nextConfig := <-ring.Out():
 				if config, ok := nextConfig.(types.ConfigMessage); ok {
 					publish <- config
+					atomic.StoreInt32(&throttling, 1)
 					time.Sleep(throttle)
+					atomic.StoreInt32(&throttling, 0)
 				}
 			}
 		}
@@ -460,6 +529,13 @@ func (s *Server) throttleProviderConfigReload(throttle time.Duration, publish ch
 		case <-stop:
 			return
 		case nextConfig := <-in:
+			if atomic.LoadInt32(&throttling) == 1 {
+				s.metricsRegistry.ProviderThrottledConfigsCounter().With("provider", nextConfig.ProviderName).Add(1)
+			}
+			// the pending configuration is replaced by the new one
+			if ring.Len() > 0 {
+				s.metricsRegistry.ProviderDroppedConfigsCounter().With("provider", nextConfig.ProviderName).Add(1)
+			}
 			ring.In() <- nextConfig
 		}
 	}
@@ -480,7 +556,7 @@ func buildMatcherMiddlewares(serverRoute *types.ServerRoute, handler http.Handle
 		if len(sp) == 2 {
 			handler = middlewares.NewReplacePathRegexHandler(sp[0], sp[1], handler)
 		} else {
-			log.Warnf("Invalid syntax for ReplacePathRegex: %s. Separate the regular expression and the replacement by a space.", serverRoute.ReplacePathRegex)
+			logger.Warnf("Invalid syntax for ReplacePathRegex: %s. Separate the regular expression and the replacement by a space.", serverRoute.ReplacePathRegex)
 		}
 	}
 
@@ -510,9 +586,16 @@ func buildMatcherMiddlewares(serverRoute *types.ServerRoute, handler http.Handle
 }
 
 func (s *Server) postLoadConfiguration() {
+	if s.accessLoggerMiddleware != nil {
+		s.accessLoggerMiddleware.OnConfigurationUpdate(s.currentConfigurations.Get().(types.Configurations))
+	}
+
 	if s.metricsRegistry.IsEnabled() {
 		activeConfig := s.currentConfigurations.Get().(types.Configurations)
 		metrics.OnConfigurationUpdate(activeConfig)
+		if s.serverSeriesLimiter != nil {
+			s.serverSeriesLimiter.OnConfigurationUpdate(activeConfig)
+		}
 	}
 
 	if s.globalConfiguration.ACME == nil || s.leadership == nil || !s.leadership.IsLeader() {
@@ -539,9 +622,9 @@ func (s *Server) postLoadConfiguration() {
 						rls := rules.Rules{}
 						domains, err := rls.ParseDomains(route.Rule)
 						if err != nil {
-							log.Errorf("Error parsing domains: %v", err)
+							logger.Errorf("Error parsing domains: %v", err)
 						} else if len(domains) == 0 {
-							log.Debugf("No domain parsed in rule %q", route.Rule)
+							logger.Debugf("No domain parsed in rule %q", route.Rule)
 						} else {
 							s.globalConfiguration.ACME.LoadCertificateForDomains(domains)
 						}
@@ -564,6 +647,59 @@ func (s *Server) loadHTTPSConfiguration(configurations types.Configurations, def
 	return newEPCertificates
 }
 
+// loadDefaultCertificates returns the default certificates provided dynamically, sorted per entrypoints
+func loadDefaultCertificates(configurations types.Configurations, defaultEntryPoints configuration.DefaultEntryPoints) map[string]*tls.Certificate {
+	defaultCertificates := make(map[string]*tls.Certificate)
+	for _, config := range configurations {
+		for _, conf := range config.TLS {
+			if !conf.Default || conf.Certificate == nil {
+				continue
+			}
+
+			cert, err := buildDefaultCertificate(conf.Certificate)
+			if err != nil {
+				logger.Errorf("Unable to load default certificate: %v", err)
+				continue
+			}
+
+			entryPoints := conf.EntryPoints
+			if len(entryPoints) == 0 {
+				entryPoints = defaultEntryPoints
+			}
+			for _, ep := range entryPoints {
+				if _, exists := defaultCertificates[ep]; exists {
+					logger.Warnf("A default certificate is already provided for entrypoint %s, skipping", ep)
+					continue
+				}
+				defaultCertificates[ep] = cert
+			}
+		}
+	}
+	return defaultCertificates
+}
+
+// loadClientCAs returns the client CAs provided dynamically, sorted per entrypoints
+func loadClientCAs(configurations types.Configurations, defaultEntryPoints configuration.DefaultEntryPoints) map[string]traefiktls.FilesOrContents {
+	clientCAs := make(map[string]traefiktls.FilesOrContents)
+	for _, config := range configurations {
+		if len(config.ClientCAs) > 0 {
+			traefiktls.SortClientCAsPerEntryPoints(config.ClientCAs, clientCAs, defaultEntryPoints)
+		}
+	}
+	return clientCAs
+}
+
+// loadDomainsOptions returns the TLS options of domains provided dynamically, sorted per entrypoints
+func loadDomainsOptions(configurations types.Configurations, defaultEntryPoints configuration.DefaultEntryPoints) map[string][]*traefiktls.DomainsOptions {
+	domainsOptions := make(map[string][]*traefiktls.DomainsOptions)
+	for _, config := range configurations {
+		if len(config.TLSOptions) > 0 {
+			traefiktls.SortDomainsOptionsPerEntryPoints(config.TLSOptions, domainsOptions, defaultEntryPoints)
+		}
+	}
+	return domainsOptions
+}
+
 func (s *Server) buildServerEntryPoints() map[string]*serverEntryPoint {
 	serverEntryPoints := make(map[string]*serverEntryPoint)
 	for entryPointName, entryPoint := range s.entryPoints {
@@ -585,14 +721,14 @@ func (s *Server) buildServerEntryPoints() map[string]*serverEntryPoint {
 			if entryPoint.Configuration.TLS.DefaultCertificate != nil {
 				cert, err := buildDefaultCertificate(entryPoint.Configuration.TLS.DefaultCertificate)
 				if err != nil {
-					log.Error(err)
+					logger.Error(err)
 					continue
 				}
 				serverEntryPoints[entryPointName].certs.DefaultCertificate = cert
 			} else {
 				cert, err := generate.DefaultCertificate()
 				if err != nil {
-					log.Errorf("failed to generate default certificate: %v", err)
+					logger.Errorf("failed to generate default certificate: %v", err)
 					continue
 				}
 				serverEntryPoints[entryPointName].certs.DefaultCertificate = cert
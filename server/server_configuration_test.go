@@ -219,6 +219,48 @@ func TestServerLoadCertificateWithDefaultEntryPoint(t *testing.T) {
 	}
 }
 
+func TestServerLoadDynamicDefaultCertificateAndClientCAs(t *testing.T) {
+	globalConfig := configuration.GlobalConfiguration{
+		DefaultEntryPoints: []string{"https"},
+	}
+	entryPoints := map[string]EntryPoint{
+		"https": {Configuration: &configuration.EntryPoint{TLS: &tls.TLS{}}},
+		"admin": {Configuration: &configuration.EntryPoint{TLS: &tls.TLS{}}},
+	}
+
+	dynamicConfigs := types.Configurations{
+		"config": &types.Configuration{
+			TLS: []*tls.Configuration{
+				{
+					Certificate: &tls.Certificate{
+						CertFile: localhostCert,
+						KeyFile:  localhostKey,
+					},
+					Default: true,
+				},
+			},
+			ClientCAs: []*tls.ClientCAConfiguration{
+				{
+					EntryPoints: []string{"admin"},
+					Files:       tls.FilesOrContents{localhostCert},
+				},
+			},
+		},
+	}
+
+	srv := NewServer(globalConfig, nil, entryPoints)
+
+	mapEntryPoints := srv.loadConfig(dynamicConfigs, globalConfig)
+
+	defaultCert := mapEntryPoints["https"].certs.GetDefaultCertificate()
+	require.NotNil(t, defaultCert)
+	assert.NotEqual(t, mapEntryPoints["admin"].certs.GetDefaultCertificate(), defaultCert)
+	assert.Contains(t, mapEntryPoints["https"].certs.GetAllDomains(), "example.com")
+
+	assert.Empty(t, mapEntryPoints["https"].clientCAs)
+	assert.Equal(t, tls.FilesOrContents{localhostCert}, mapEntryPoints["admin"].clientCAs)
+}
+
 func TestReuseBackend(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(http.StatusOK)
@@ -413,6 +455,41 @@ func TestServerMultipleFrontendRules(t *testing.T) {
 	}
 }
 
+func TestFrontendRule(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		routes   map[string]types.Route
+		expected string
+	}{
+		{
+			desc:     "no routes",
+			expected: "",
+		},
+		{
+			desc:     "one route",
+			routes:   map[string]types.Route{"route-host": {Rule: "Host:foo.bar"}},
+			expected: "Host:foo.bar",
+		},
+		{
+			desc: "routes sorted by name",
+			routes: map[string]types.Route{
+				"route-path": {Rule: "PathPrefix:/api"},
+				"route-host": {Rule: "Host:foo.bar"},
+			},
+			expected: "Host:foo.bar;PathPrefix:/api",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, frontendRule(&types.Frontend{Routes: test.routes}))
+		})
+	}
+}
+
 func TestServerBuildHealthCheckOptions(t *testing.T) {
 	lb := &testLoadBalancer{}
 	globalInterval := 15 * time.Second
@@ -12,7 +12,6 @@ import (
 
 	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/healthcheck"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/middlewares/accesslog"
 	"github.com/containous/traefik/server/cookie"
@@ -44,7 +43,7 @@ func (s *Server) buildBalancerMiddlewares(frontendName string, frontend *types.F
 	// Health Check
 	var backendHealthCheck *healthcheck.BackendConfig
 	if hcOpts := buildHealthCheckOptions(balancer, frontend.Backend, backend.HealthCheck, s.globalConfiguration.HealthCheck); hcOpts != nil {
-		log.Debugf("Setting up backend health check %s", *hcOpts)
+		logger.Debugf("Setting up backend health check %s", *hcOpts)
 
 		hcOpts.Transport = s.defaultForwardingRoundTripper
 		backendHealthCheck = healthcheck.NewBackendConfig(*hcOpts, frontend.Backend)
@@ -68,7 +67,7 @@ func (s *Server) buildBalancerMiddlewares(frontendName string, frontend *types.F
 
 	// Max Connections
 	if backend.MaxConn != nil && backend.MaxConn.Amount != 0 {
-		log.Debugf("Creating load-balancer connection limit")
+		logger.Debugf("Creating load-balancer connection limit")
 
 		handler, err := buildMaxConn(lb, backend.MaxConn)
 		if err != nil {
@@ -96,7 +95,7 @@ func (s *Server) buildBalancerMiddlewares(frontendName string, frontend *types.F
 
 	// Circuit Breaker
 	if backend.CircuitBreaker != nil {
-		log.Debugf("Creating circuit breaker %s", backend.CircuitBreaker.Expression)
+		logger.Debugf("Creating circuit breaker %s", backend.CircuitBreaker.Expression)
 
 		expression := backend.CircuitBreaker.Expression
 		circuitBreaker, err := middlewares.NewCircuitBreaker(lb, expression, middlewares.NewCircuitBreakerOptions(expression))
@@ -139,10 +138,10 @@ func (s *Server) buildLoadBalancer(frontendName string, backendName string, back
 
 	switch lbMethod {
 	case types.Drr:
-		log.Debug("Creating load-balancer drr")
+		logger.Debug("Creating load-balancer drr")
 
 		if stickySession != nil {
-			log.Debugf("Sticky session with cookie %v", cookieName)
+			logger.Debugf("Sticky session with cookie %v", cookieName)
 
 			lb, err = roundrobin.NewRebalancer(rr, roundrobin.RebalancerStickySession(stickySession))
 			if err != nil {
@@ -155,10 +154,10 @@ func (s *Server) buildLoadBalancer(frontendName string, backendName string, back
 			}
 		}
 	case types.Wrr:
-		log.Debug("Creating load-balancer wrr")
+		logger.Debug("Creating load-balancer wrr")
 
 		if stickySession != nil {
-			log.Debugf("Sticky session with cookie %v", cookieName)
+			logger.Debugf("Sticky session with cookie %v", cookieName)
 
 			if s.accessLoggerMiddleware != nil {
 				lb, err = roundrobin.New(saveFrontend, roundrobin.EnableStickySession(stickySession))
@@ -192,7 +191,7 @@ func (s *Server) configureLBServers(lb healthcheck.BalancerHandler, backend *typ
 			return fmt.Errorf("error parsing server URL %s: %v", srv.URL, err)
 		}
 
-		log.Debugf("Creating server %s at %s with weight %d", name, u, srv.Weight)
+		logger.Debugf("Creating server %s at %s with weight %d", name, u, srv.Weight)
 
 		if err := lb.UpsertServer(u, roundrobin.Weight(srv.Weight)); err != nil {
 			return fmt.Errorf("error adding server %s to load balancer: %v", srv.URL, err)
@@ -204,8 +203,23 @@ func (s *Server) configureLBServers(lb healthcheck.BalancerHandler, backend *typ
 }
 
 // getRoundTripper will either use server.defaultForwardingRoundTripper or create a new one
-// given a custom TLS configuration is passed and the passTLSCert option is set to true.
-func (s *Server) getRoundTripper(entryPointName string, passTLSCert bool, tls *traefiktls.TLS) (http.RoundTripper, error) {
+// given the backend holds a TLS configuration, or a custom TLS configuration is passed and the passTLSCert option is set to true.
+func (s *Server) getRoundTripper(entryPointName string, passTLSCert bool, tls *traefiktls.TLS, backendTLS *types.BackendTLS) (http.RoundTripper, error) {
+	if backendTLS != nil {
+		tlsConfig, err := createBackendTLSConfig(backendTLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create backend TLSClientConfig: %v", err)
+		}
+
+		transport, err := createHTTPTransport(s.globalConfiguration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP transport: %v", err)
+		}
+
+		transport.TLSClientConfig = tlsConfig
+		return transport, nil
+	}
+
 	if passTLSCert {
 		tlsConfig, err := createClientTLSConfig(entryPointName, tls)
 		if err != nil {
@@ -287,7 +301,7 @@ func createRootCACertPool(rootCAs traefiktls.FilesOrContents) *x509.CertPool {
 	for _, cert := range rootCAs {
 		certContent, err := cert.Read()
 		if err != nil {
-			log.Error("Error while read RootCAs", err)
+			logger.Error("Error while read RootCAs", err)
 			continue
 		}
 		roots.AppendCertsFromPEM(certContent)
@@ -307,7 +321,7 @@ func createClientTLSConfig(entryPointName string, tlsOption *traefiktls.TLS) (*t
 	}
 
 	if len(tlsOption.ClientCAFiles) > 0 {
-		log.Warnf("Deprecated configuration found during client TLS configuration creation: %s. Please use %s (which allows to make the CA Files optional).", "tls.ClientCAFiles", "tls.ClientCA.files")
+		logger.Warnf("Deprecated configuration found during client TLS configuration creation: %s. Please use %s (which allows to make the CA Files optional).", "tls.ClientCAFiles", "tls.ClientCA.files")
 		tlsOption.ClientCA.Files = tlsOption.ClientCAFiles
 		tlsOption.ClientCA.Optional = false
 	}
@@ -332,6 +346,73 @@ func createClientTLSConfig(entryPointName string, tlsOption *traefiktls.TLS) (*t
 	return config, nil
 }
 
+// createBackendTLSConfig creates the TLS configuration used to connect to the servers of a backend
+func createBackendTLSConfig(backendTLS *types.BackendTLS) (*tls.Config, error) {
+	config := &tls.Config{}
+
+	if len(backendTLS.Cert) > 0 || len(backendTLS.Key) > 0 {
+		cert, err := tls.X509KeyPair([]byte(backendTLS.Cert), []byte(backendTLS.Key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(backendTLS.CA) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM([]byte(backendTLS.CA)) {
+			return nil, errors.New("invalid CA certificate(s)")
+		}
+	}
+
+	if len(backendTLS.TrustedURIs) > 0 {
+		// The host names of the servers are not part of their certificates, the chain is verified by verifyURISANs instead.
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = verifyURISANs(config.RootCAs, backendTLS.TrustedURIs)
+	}
+
+	return config, nil
+}
+
+// verifyURISANs returns a function verifying that the certificate chain of a server is signed by the roots,
+// and that the URI SANs of its certificate contain one of the trusted URIs.
+func verifyURISANs(roots *x509.CertPool, trustedURIs []string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("no certificate presented by the server")
+		}
+
+		var certs []*x509.Certificate
+		for _, rawCert := range rawCerts {
+			cert, err := x509.ParseCertificate(rawCert)
+			if err != nil {
+				return fmt.Errorf("invalid certificate presented by the server: %v", err)
+			}
+			certs = append(certs, cert)
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+		if err != nil {
+			return err
+		}
+
+		for _, uri := range certs[0].URIs {
+			for _, trustedURI := range trustedURIs {
+				if uri.String() == trustedURI {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("the certificate of the server does not match any of the trusted URIs %v", trustedURIs)
+	}
+}
+
 func (s *Server) buildRetryMiddleware(handler http.Handler, retry *configuration.Retry, countServers int, backendName string) http.Handler {
 	retryListeners := middlewares.RetryListeners{}
 	if s.metricsRegistry.IsEnabled() {
@@ -346,7 +427,7 @@ func (s *Server) buildRetryMiddleware(handler http.Handler, retry *configuration
 		retryAttempts = retry.Attempts
 	}
 
-	log.Debugf("Creating retries max attempts %d", retryAttempts)
+	logger.Debugf("Creating retries max attempts %d", retryAttempts)
 
 	return middlewares.NewRetry(retryAttempts, handler, retryListeners)
 }
@@ -357,7 +438,7 @@ func buildRateLimiter(handler http.Handler, rlConfig *types.RateLimit) (http.Han
 		return nil, err
 	}
 
-	log.Debugf("Creating load-balancer rate limiter")
+	logger.Debugf("Creating load-balancer rate limiter")
 
 	rateSet := ratelimit.NewRateSet()
 	for _, rate := range rlConfig.RateSet {
@@ -370,7 +451,7 @@ func buildRateLimiter(handler http.Handler, rlConfig *types.RateLimit) (http.Han
 }
 
 func buildBufferingMiddleware(handler http.Handler, config *types.Buffering) (http.Handler, error) {
-	log.Debugf("Setting up buffering: request limits: %d (mem), %d (max), response limits: %d (mem), %d (max) with retry: '%s'",
+	logger.Debugf("Setting up buffering: request limits: %d (mem), %d (max), response limits: %d (mem), %d (max) with retry: '%s'",
 		config.MemRequestBodyBytes, config.MaxRequestBodyBytes, config.MemResponseBodyBytes,
 		config.MaxResponseBodyBytes, config.RetryExpression)
 
@@ -390,7 +471,7 @@ func buildMaxConn(lb http.Handler, maxConns *types.MaxConn) (http.Handler, error
 		return nil, fmt.Errorf("error creating connection limit: %v", err)
 	}
 
-	log.Debugf("Creating load-balancer connection limit")
+	logger.Debugf("Creating load-balancer connection limit")
 
 	handler, err := connlimit.New(lb, extractFunc, maxConns.Amount)
 	if err != nil {
@@ -409,9 +490,9 @@ func buildHealthCheckOptions(lb healthcheck.BalancerHandler, backend string, hc
 	if hc.Interval != "" {
 		intervalOverride, err := time.ParseDuration(hc.Interval)
 		if err != nil {
-			log.Errorf("Illegal health check interval for backend '%s': %s", backend, err)
+			logger.Errorf("Illegal health check interval for backend '%s': %s", backend, err)
 		} else if intervalOverride <= 0 {
-			log.Errorf("Health check interval smaller than zero for backend '%s', backend", backend)
+			logger.Errorf("Health check interval smaller than zero for backend '%s', backend", backend)
 		} else {
 			interval = intervalOverride
 		}
@@ -1,10 +1,20 @@
 package server
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/containous/traefik/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfigureBackends(t *testing.T) {
@@ -79,3 +89,130 @@ func TestConfigureBackends(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateBackendTLSConfig(t *testing.T) {
+	caCert, caKey := generateTestCertificate(t, nil, nil, "")
+	otherCACert, otherCAKey := generateTestCertificate(t, nil, nil, "")
+	serverCert, _ := generateTestCertificate(t, caCert, caKey, "spiffe://11111111.consul/ns/default/dc/dc1/svc/web")
+	otherServerCert, _ := generateTestCertificate(t, otherCACert, otherCAKey, "spiffe://11111111.consul/ns/default/dc/dc1/svc/web")
+	clientCert, clientKey := generateTestCertificate(t, caCert, caKey, "spiffe://11111111.consul/ns/default/dc/dc1/svc/traefik")
+
+	backendTLS := &types.BackendTLS{
+		CA:          encodeTestCertificate(caCert),
+		Cert:        encodeTestCertificate(clientCert),
+		Key:         encodeTestKey(t, clientKey),
+		TrustedURIs: []string{"spiffe://11111111.consul/ns/default/dc/dc1/svc/web"},
+	}
+
+	config, err := createBackendTLSConfig(backendTLS)
+	require.NoError(t, err)
+
+	assert.Len(t, config.Certificates, 1)
+	assert.True(t, config.InsecureSkipVerify)
+	require.NotNil(t, config.VerifyPeerCertificate)
+
+	testCases := []struct {
+		desc        string
+		rawCerts    [][]byte
+		expectedErr bool
+	}{
+		{
+			desc:     "trusted URI signed by the CA",
+			rawCerts: [][]byte{serverCert.Raw},
+		},
+		{
+			desc:        "untrusted URI",
+			rawCerts:    [][]byte{clientCert.Raw},
+			expectedErr: true,
+		},
+		{
+			desc:        "signed by another CA",
+			rawCerts:    [][]byte{otherServerCert.Raw},
+			expectedErr: true,
+		},
+		{
+			desc:        "no certificate",
+			expectedErr: true,
+		},
+		{
+			desc:        "invalid certificate",
+			rawCerts:    [][]byte{[]byte("foo")},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := config.VerifyPeerCertificate(test.rawCerts, nil)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateBackendTLSConfigInvalid(t *testing.T) {
+	_, err := createBackendTLSConfig(&types.BackendTLS{CA: "foo"})
+	assert.Error(t, err)
+
+	_, err = createBackendTLSConfig(&types.BackendTLS{Cert: "foo", Key: "bar"})
+	assert.Error(t, err)
+}
+
+// generateTestCertificate generates a certificate signed by the parent, or a self-signed CA when parent is nil.
+func generateTestCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, uri string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if uri != "" {
+		parsed, errURI := url.Parse(uri)
+		require.NoError(t, errURI)
+		template.URIs = []*url.URL{parsed}
+	}
+
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func encodeTestCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+func encodeTestKey(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	raw, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: raw}))
+}
@@ -5,13 +5,13 @@ import (
 	"net/http"
 
 	"github.com/containous/traefik/configuration"
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/middlewares/accesslog"
 	mauth "github.com/containous/traefik/middlewares/auth"
 	"github.com/containous/traefik/middlewares/errorpages"
 	"github.com/containous/traefik/middlewares/redirect"
 	"github.com/containous/traefik/types"
+	"github.com/go-acme/lego/challenge/http01"
 	thoas_stats "github.com/thoas/stats"
 	"github.com/unrolled/secure"
 	"github.com/urfave/negroni"
@@ -45,8 +45,8 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 
 	// Metrics
 	if s.metricsRegistry.IsEnabled() {
-		handler := middlewares.NewBackendMetricsMiddleware(s.metricsRegistry, frontend.Backend)
-		middle = append(middle, handler)
+		middle = append(middle, middlewares.NewFrontendMetricsMiddleware(s.metricsRegistry, frontendName))
+		middle = append(middle, middlewares.NewBackendMetricsMiddleware(s.metricsRegistry, frontend.Backend))
 	}
 
 	// Whitelist
@@ -56,9 +56,9 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 	}
 	if ipWhitelistMiddleware != nil {
 		if frontend.WhiteList != nil {
-			log.Debugf("Configured IP Whitelists: %v", frontend.WhiteList.SourceRange)
+			logger.Debugf("Configured IP Whitelists: %v", frontend.WhiteList.SourceRange)
 		} else {
-			log.Debugf("Configured IP Whitelists: %v", frontend.WhitelistSourceRange)
+			logger.Debugf("Configured IP Whitelists: %v", frontend.WhitelistSourceRange)
 		}
 
 		handler := s.tracingMiddleware.NewNegroniHandlerWrapper(
@@ -78,13 +78,13 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 		handler := s.wrapNegroniHandlerWithAccessLog(rewrite, fmt.Sprintf("frontend redirect for %s", frontendName))
 		middle = append(middle, handler)
 
-		log.Debugf("Frontend %s redirect created", frontendName)
+		logger.Debugf("Frontend %s redirect created", frontendName)
 	}
 
 	// Header
 	headerMiddleware := middlewares.NewHeaderFromStruct(frontend.Headers)
 	if headerMiddleware != nil {
-		log.Debugf("Adding header middleware for frontend %s", frontendName)
+		logger.Debugf("Adding header middleware for frontend %s", frontendName)
 
 		handler := s.tracingMiddleware.NewNegroniHandlerWrapper("Header", headerMiddleware, false)
 		middle = append(middle, handler)
@@ -93,7 +93,7 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 	// Secure
 	secureMiddleware := middlewares.NewSecure(frontend.Headers)
 	if secureMiddleware != nil {
-		log.Debugf("Adding secure middleware for frontend %s", frontendName)
+		logger.Debugf("Adding secure middleware for frontend %s", frontendName)
 
 		handler := negroni.HandlerFunc(secureMiddleware.HandlerFuncWithNextForRequestOnly)
 		middle = append(middle, handler)
@@ -101,7 +101,7 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 
 	// Basic auth
 	if len(frontend.BasicAuth) > 0 {
-		log.Debugf("Adding basic authentication for frontend %s", frontendName)
+		logger.Debugf("Adding basic authentication for frontend %s", frontendName)
 
 		authMiddleware, err := s.buildBasicAuthMiddleware(frontend.BasicAuth)
 		if err != nil {
@@ -115,7 +115,7 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 	// TLSClientHeaders
 	tlsClientHeadersMiddleware := middlewares.NewTLSClientHeaders(frontend.PassTLSClientCert)
 	if tlsClientHeadersMiddleware != nil {
-		log.Debugf("Adding TLSClientHeaders middleware for frontend %s", frontendName)
+		logger.Debugf("Adding TLSClientHeaders middleware for frontend %s", frontendName)
 
 		handler := s.tracingMiddleware.NewNegroniHandlerWrapper("TLSClientHeaders", tlsClientHeadersMiddleware, false)
 		middle = append(middle, handler)
@@ -136,14 +136,14 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 }
 
 func (s *Server) buildServerEntryPointMiddlewares(serverEntryPointName string, serverEntryPoint *serverEntryPoint) ([]negroni.Handler, error) {
-	serverMiddlewares := []negroni.Handler{middlewares.NegroniRecoverHandler()}
+	serverMiddlewares := []negroni.Handler{middlewares.NewEntryPointLogContext(serverEntryPointName), middlewares.NegroniRecoverHandler()}
 
 	if s.tracingMiddleware.IsEnabled() {
 		serverMiddlewares = append(serverMiddlewares, s.tracingMiddleware.NewEntryPoint(serverEntryPointName))
 	}
 
 	if s.accessLoggerMiddleware != nil {
-		serverMiddlewares = append(serverMiddlewares, s.accessLoggerMiddleware)
+		serverMiddlewares = append(serverMiddlewares, s.accessLoggerMiddleware.EntryPointHandler(serverEntryPointName))
 	}
 
 	if s.metricsRegistry.IsEnabled() {
@@ -157,12 +157,23 @@ func (s *Server) buildServerEntryPointMiddlewares(serverEntryPointName string, s
 		serverMiddlewares = append(serverMiddlewares, s.globalConfiguration.API.Stats)
 		if s.globalConfiguration.API.Statistics != nil {
 			if s.globalConfiguration.API.StatsRecorder == nil {
-				s.globalConfiguration.API.StatsRecorder = middlewares.NewStatsRecorder(s.globalConfiguration.API.Statistics.RecentErrors)
+				var fields *types.AccessLogFields
+				if s.globalConfiguration.AccessLog != nil {
+					fields = s.globalConfiguration.AccessLog.Fields
+				}
+				s.globalConfiguration.API.StatsRecorder = middlewares.NewStatsRecorder(s.globalConfiguration.API.Statistics, fields)
 			}
 			serverMiddlewares = append(serverMiddlewares, s.globalConfiguration.API.StatsRecorder)
 		}
 	}
 
+	// RequestHost Cannonizer, before the redirection to match the Host rules of the redirect exemptions
+	serverMiddlewares = append(serverMiddlewares, &middlewares.RequestHost{})
+
+	if s.entryPoints[serverEntryPointName].Configuration.TLS != nil {
+		serverMiddlewares = append(serverMiddlewares, negroni.HandlerFunc(serverEntryPoint.rejectMisdirectedRequests))
+	}
+
 	if s.entryPoints[serverEntryPointName].Configuration.Redirect != nil {
 		redirectHandlers, err := s.buildEntryPointRedirect()
 		if err != nil {
@@ -193,9 +204,6 @@ func (s *Server) buildServerEntryPointMiddlewares(serverEntryPointName string, s
 		serverMiddlewares = append(serverMiddlewares, s.wrapNegroniHandlerWithAccessLog(ipWhitelistMiddleware, fmt.Sprintf("ipwhitelister for entrypoint %s", serverEntryPointName)))
 	}
 
-	// RequestHost Cannonizer
-	serverMiddlewares = append(serverMiddlewares, &middlewares.RequestHost{})
-
 	return serverMiddlewares, nil
 }
 
@@ -223,10 +231,10 @@ func buildErrorPagesMiddleware(frontendName string, frontend *types.Frontend, ba
 
 	for errorPageName, errorPage := range frontend.Errors {
 		if frontend.Backend == errorPage.Backend {
-			log.Errorf("Error when creating error page %q for frontend %q: error pages backend %q is the same as backend for the frontend (infinite call risk).",
+			logger.Errorf("Error when creating error page %q for frontend %q: error pages backend %q is the same as backend for the frontend (infinite call risk).",
 				errorPageName, frontendName, errorPage.Backend)
 		} else if backends[errorPage.Backend] == nil {
-			log.Errorf("Error when creating error page %q for frontend %q: the backend %q doesn't exist.",
+			logger.Errorf("Error when creating error page %q for frontend %q: the backend %q doesn't exist.",
 				errorPageName, frontendName, errorPage.Backend)
 		} else {
 			errorPagesHandler, err := errorpages.NewHandler(errorPage, entryPointName+providerName+errorPage.Backend)
@@ -271,7 +279,15 @@ func (s *Server) buildEntryPointRedirect() (map[string]negroni.Handler, error) {
 		entryPoint := ep.Configuration
 
 		if entryPoint.Redirect != nil && entryPointName != entryPoint.Redirect.EntryPoint {
-			handler, err := s.buildRedirectHandler(entryPointName, entryPoint.Redirect)
+			redirectConfig := entryPoint.Redirect
+			if s.isACMEHTTPChallengeEntryPoint(entryPointName) {
+				// the HTTP-01 challenge is answered on this entry point, whatever the internal routes priority
+				redirectConfig = &types.Redirect{}
+				*redirectConfig = *entryPoint.Redirect
+				redirectConfig.Exemptions = append([]string{http01.ChallengePath("")}, entryPoint.Redirect.Exemptions...)
+			}
+
+			handler, err := s.buildRedirectHandler(entryPointName, redirectConfig)
 			if err != nil {
 				return nil, fmt.Errorf("error loading configuration for entrypoint %s: %v", entryPointName, err)
 			}
@@ -291,8 +307,12 @@ func (s *Server) buildRedirectHandler(srcEntryPointName string, opt *types.Redir
 		if entryPoint == nil {
 			return nil, fmt.Errorf("unknown target entrypoint %q", srcEntryPointName)
 		}
-		log.Debugf("Creating entry point redirect %s -> %s", srcEntryPointName, opt.EntryPoint)
-		return redirect.NewEntryPointHandler(entryPoint, opt.Permanent)
+		logger.Debugf("Creating entry point redirect %s -> %s", srcEntryPointName, opt.EntryPoint)
+		redirection, err := redirect.NewEntryPointHandler(entryPoint, opt.Permanent)
+		if err != nil {
+			return nil, err
+		}
+		return redirect.NewExemptedHandler(redirection, opt.Exemptions)
 	}
 
 	// regex redirect
@@ -300,9 +320,20 @@ func (s *Server) buildRedirectHandler(srcEntryPointName string, opt *types.Redir
 	if err != nil {
 		return nil, err
 	}
-	log.Debugf("Creating regex redirect %s -> %s -> %s", srcEntryPointName, opt.Regex, opt.Replacement)
+	logger.Debugf("Creating regex redirect %s -> %s -> %s", srcEntryPointName, opt.Regex, opt.Replacement)
+
+	return redirect.NewExemptedHandler(redirection, opt.Exemptions)
+}
+
+// isACMEHTTPChallengeEntryPoint returns true if the HTTP-01 challenges are answered on the entry point,
+// by the ACME provider, or by the ACME of the cluster mode for which the global configuration is kept.
+func (s *Server) isACMEHTTPChallengeEntryPoint(entryPointName string) bool {
+	if s.entryPoints[entryPointName].ACMEHTTPChallenge {
+		return true
+	}
 
-	return redirection, nil
+	acme := s.globalConfiguration.ACME
+	return acme != nil && acme.HTTPChallenge != nil && acme.HTTPChallenge.EntryPoint == entryPointName
 }
 
 func buildIPWhiteLister(whiteList *types.WhiteList, wlRange []string) (*middlewares.IPWhiteLister, error) {
@@ -1,15 +1,20 @@
 package server
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/containous/mux"
+	"github.com/containous/traefik/acme"
 	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/metrics"
 	"github.com/containous/traefik/middlewares"
+	acmeprovider "github.com/containous/traefik/provider/acme"
 	th "github.com/containous/traefik/testhelpers"
 	"github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
@@ -287,3 +292,118 @@ func TestServerGenericFrontendAuthFail(t *testing.T) {
 
 	_ = srv.loadConfig(dynamicConfigs, globalConfig)
 }
+
+func TestBuildEntryPointRedirectACMEExemption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acme")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	newEntryPoints := func() map[string]EntryPoint {
+		return map[string]EntryPoint{
+			"http": {Configuration: &configuration.EntryPoint{
+				Address: ":80",
+				Redirect: &types.Redirect{
+					EntryPoint: "https",
+					Exemptions: []string{"/ping"},
+				},
+				InternalRoutesPriority: configuration.InternalRoutesPriorityLow,
+			}},
+			"https": {Configuration: &configuration.EntryPoint{Address: ":443", TLS: &tls.TLS{}}},
+		}
+	}
+
+	setups := []struct {
+		desc      string
+		newServer func(t *testing.T) *Server
+	}{
+		{
+			desc: "ACME provider",
+			newServer: func(t *testing.T) *Server {
+				globalConfig := configuration.GlobalConfiguration{
+					ACME: &acme.ACME{
+						Storage:       filepath.Join(dir, "acme.json"),
+						EntryPoint:    "https",
+						HTTPChallenge: &acmeprovider.HTTPChallenge{EntryPoint: "http"},
+					},
+				}
+
+				provider, err := globalConfig.InitACMEProvider()
+				require.NoError(t, err)
+				require.NotNil(t, provider)
+				// the ACME configuration is cleared once the provider is created
+				require.Nil(t, globalConfig.ACME)
+
+				entryPoints := newEntryPoints()
+				for entryPointName, entryPoint := range entryPoints {
+					entryPoint.ACMEHTTPChallenge = entryPointName == provider.HTTPChallenge.EntryPoint
+					entryPoints[entryPointName] = entryPoint
+				}
+
+				return &Server{globalConfiguration: globalConfig, entryPoints: entryPoints}
+			},
+		},
+		{
+			desc: "ACME in cluster mode",
+			newServer: func(t *testing.T) *Server {
+				return &Server{
+					globalConfiguration: configuration.GlobalConfiguration{
+						ACME: &acme.ACME{
+							HTTPChallenge: &acmeprovider.HTTPChallenge{EntryPoint: "http"},
+						},
+					},
+					entryPoints: newEntryPoints(),
+				}
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc           string
+		url            string
+		expectedStatus int
+	}{
+		{
+			desc:           "ACME challenge",
+			url:            "http://foo/.well-known/acme-challenge/token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "exemption",
+			url:            "http://foo/ping",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "redirected",
+			url:            "http://foo/api",
+			expectedStatus: http.StatusFound,
+		},
+	}
+
+	for _, setup := range setups {
+		setup := setup
+		t.Run(setup.desc, func(t *testing.T) {
+			srv := setup.newServer(t)
+
+			redirectHandlers, err := srv.buildEntryPointRedirect()
+			require.NoError(t, err)
+			require.Contains(t, redirectHandlers, "http")
+
+			for _, test := range testCases {
+				test := test
+				t.Run(test.desc, func(t *testing.T) {
+					recorder := httptest.NewRecorder()
+					req := th.MustNewRequest(http.MethodGet, test.url, nil)
+
+					redirectHandlers["http"].ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+						rw.WriteHeader(http.StatusOK)
+					})
+
+					assert.Equal(t, test.expectedStatus, recorder.Code)
+				})
+			}
+
+			// the configuration of the entry point is not modified
+			assert.Equal(t, []string{"/ping"}, srv.entryPoints["http"].Configuration.Redirect.Exemptions)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/containous/staert"
+	"github.com/containous/traefik/cluster"
+	traefiktls "github.com/containous/traefik/tls"
+)
+
+// configureSessionTickets sets up the session ticket keys of an entry point TLS config
+func (s *Server) configureSessionTickets(entryPointName string, config *tls.Config, dynamicConfig *traefiktls.DynamicConfig, sessionTickets *traefiktls.SessionTickets) error {
+	if sessionTickets == nil {
+		return nil
+	}
+
+	if sessionTickets.Disabled {
+		config.SessionTicketsDisabled = true
+		return nil
+	}
+
+	sessionTickets.SetDefaults()
+
+	switch {
+	case len(sessionTickets.KeyFiles) > 0:
+		return s.loadSessionTicketKeysFromFiles(entryPointName, dynamicConfig, sessionTickets)
+	case len(sessionTickets.Storage) > 0:
+		return s.loadSessionTicketKeysFromStore(entryPointName, dynamicConfig, sessionTickets)
+	default:
+		// keys are generated and rotated by crypto/tls
+		return nil
+	}
+}
+
+func (s *Server) loadSessionTicketKeysFromFiles(entryPointName string, dynamicConfig *traefiktls.DynamicConfig, sessionTickets *traefiktls.SessionTickets) error {
+	keys, err := traefiktls.ReadSessionTicketKeys(sessionTickets.KeyFiles)
+	if err != nil {
+		return fmt.Errorf("unable to read session ticket keys: %v", err)
+	}
+
+	if err := dynamicConfig.SetSessionTicketKeys(keys); err != nil {
+		return err
+	}
+
+	s.routinesPool.GoCtx(func(ctx context.Context) {
+		ticker := time.NewTicker(time.Duration(sessionTickets.RotationInterval))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				keys, err := traefiktls.ReadSessionTicketKeys(sessionTickets.KeyFiles)
+				if err != nil {
+					logger.Errorf("Unable to reload session ticket keys for entrypoint %s: %v", entryPointName, err)
+					continue
+				}
+				if err := dynamicConfig.SetSessionTicketKeys(keys); err != nil {
+					logger.Errorf("Unable to apply session ticket keys for entrypoint %s: %v", entryPointName, err)
+					continue
+				}
+				logger.Debugf("Session ticket keys reloaded for entrypoint %s", entryPointName)
+			}
+		}
+	})
+	return nil
+}
+
+func (s *Server) loadSessionTicketKeysFromStore(entryPointName string, dynamicConfig *traefiktls.DynamicConfig, sessionTickets *traefiktls.SessionTickets) error {
+	if s.leadership == nil {
+		return errors.New("session ticket keys storage requires the cluster mode")
+	}
+
+	listener := func(object cluster.Object) error {
+		keys := object.(*traefiktls.SessionTicketKeys)
+		if len(keys.Keys) == 0 {
+			return nil
+		}
+		logger.Debugf("Session ticket keys updated for entrypoint %s", entryPointName)
+		return dynamicConfig.SetSessionTicketKeys(keys)
+	}
+
+	datastore, err := cluster.NewDataStore(
+		s.leadership.Pool.Ctx(),
+		staert.KvSource{
+			Store:  s.leadership.Store,
+			Prefix: sessionTickets.Storage,
+		},
+		&traefiktls.SessionTicketKeys{},
+		listener)
+	if err != nil {
+		return err
+	}
+
+	if object, err := datastore.Load(); err != nil {
+		logger.Debugf("Unable to load session ticket keys for entrypoint %s: %v", entryPointName, err)
+	} else if err := listener(object); err != nil {
+		logger.Errorf("Unable to apply session ticket keys for entrypoint %s: %v", entryPointName, err)
+	}
+
+	interval := time.Duration(sessionTickets.RotationInterval)
+	rotate := func() {
+		if !s.leadership.IsLeader() {
+			return
+		}
+		if err := rotateSessionTicketKeys(datastore, interval, sessionTickets.MaxKeys); err != nil {
+			logger.Errorf("Unable to rotate session ticket keys for entrypoint %s: %v", entryPointName, err)
+		}
+	}
+
+	s.leadership.AddListener(func(elected bool) error {
+		if elected {
+			rotate()
+		}
+		return nil
+	})
+
+	s.leadership.Pool.AddGoCtx(func(ctx context.Context) {
+		// only runs on the leader, check more often than the rotation interval to catch up quickly after an election
+		ticker := time.NewTicker(interval / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				rotate()
+			}
+		}
+	})
+	return nil
+}
+
+// rotateSessionTicketKeys adds a new session ticket key in the store if the current keys are too old
+func rotateSessionTicketKeys(store cluster.Store, interval time.Duration, maxKeys int) error {
+	if keys, ok := store.Get().(*traefiktls.SessionTicketKeys); ok && !keys.NeedRotation(interval) {
+		return nil
+	}
+
+	transaction, object, err := store.Begin()
+	if err != nil {
+		return err
+	}
+
+	keys := object.(*traefiktls.SessionTicketKeys)
+	if keys.NeedRotation(interval) {
+		if err := keys.Rotate(maxKeys); err != nil {
+			return err
+		}
+		logger.Debug("Session ticket keys rotated")
+	}
+
+	return transaction.Commit(keys)
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/cluster"
+	traefiktls "github.com/containous/traefik/tls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	object  cluster.Object
+	commits int
+}
+
+func (m *memoryStore) Load() (cluster.Object, error) {
+	return m.object, nil
+}
+
+func (m *memoryStore) Get() cluster.Object {
+	return m.object
+}
+
+func (m *memoryStore) Begin() (cluster.Transaction, cluster.Object, error) {
+	return m, m.object, nil
+}
+
+func (m *memoryStore) Commit(object cluster.Object) error {
+	m.object = object
+	m.commits++
+	return nil
+}
+
+func TestRotateSessionTicketKeys(t *testing.T) {
+	store := &memoryStore{object: &traefiktls.SessionTicketKeys{}}
+
+	err := rotateSessionTicketKeys(store, time.Hour, 2)
+	require.NoError(t, err)
+
+	keys := store.Get().(*traefiktls.SessionTicketKeys)
+	require.Len(t, keys.Keys, 1)
+	assert.Equal(t, 1, store.commits)
+
+	// keys are recent enough, the store is left untouched
+	err = rotateSessionTicketKeys(store, time.Hour, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.commits)
+
+	keys.RotatedAt = time.Now().Add(-2 * time.Hour)
+	for i := 0; i < 2; i++ {
+		err = rotateSessionTicketKeys(store, 0, 2)
+		require.NoError(t, err)
+	}
+
+	keys = store.Get().(*traefiktls.SessionTicketKeys)
+	assert.Len(t, keys.Keys, 2)
+	assert.Equal(t, 3, store.commits)
+}
@@ -1,3 +1,4 @@
+//go:build !windows
 // +build !windows
 
 package server
@@ -21,16 +22,16 @@ func (s *Server) listenSignals(stop chan bool) {
 		case sig := <-s.signals:
 			switch sig {
 			case syscall.SIGUSR1:
-				log.Infof("Closing and re-opening log files for rotation: %+v", sig)
+				logger.Infof("Closing and re-opening log files for rotation: %+v", sig)
 
 				if s.accessLoggerMiddleware != nil {
 					if err := s.accessLoggerMiddleware.Rotate(); err != nil {
-						log.Errorf("Error rotating access log: %v", err)
+						logger.Errorf("Error rotating access log: %v", err)
 					}
 				}
 
 				if err := log.RotateFile(); err != nil {
-					log.Errorf("Error rotating traefik log: %v", err)
+					logger.Errorf("Error rotating traefik log: %v", err)
 				}
 			}
 		}
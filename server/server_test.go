@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,10 +13,12 @@ import (
 	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/middlewares"
 	th "github.com/containous/traefik/testhelpers"
+	traefiktls "github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/unrolled/secure"
+	"github.com/urfave/negroni"
 )
 
 func TestPrepareServerTimeouts(t *testing.T) {
@@ -93,6 +96,106 @@ func TestPrepareServerTimeouts(t *testing.T) {
 	}
 }
 
+type internalRouterFunc func(router *mux.Router)
+
+func (f internalRouterFunc) AddRoutes(router *mux.Router) {
+	f(router)
+}
+
+func TestPrepareServerInternalRoutesPriority(t *testing.T) {
+	writeBody := func(body string) http.HandlerFunc {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = rw.Write([]byte(body))
+		}
+	}
+
+	internalRouter := internalRouterFunc(func(router *mux.Router) {
+		router.Path("/ping").Handler(writeBody("internal"))
+		router.Path("/api").Handler(writeBody("internal"))
+		router.Path("/.well-known/acme-challenge/{token}").Handler(writeBody("internal"))
+	})
+
+	frontends := mux.NewRouter()
+	frontends.Path("/ping").Handler(writeBody("frontend"))
+	frontends.PathPrefix("/.well-known").Handler(writeBody("frontend"))
+	frontends.NotFoundHandler = http.NotFoundHandler()
+
+	middleware := negroni.HandlerFunc(func(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		rw.Header().Set("X-Middleware", "true")
+		next(rw, req)
+	})
+
+	testCases := []struct {
+		desc               string
+		priority           string
+		path               string
+		expectedBody       string
+		expectedMiddleware bool
+	}{
+		{
+			desc:         "high priority, internal route",
+			path:         "/ping",
+			expectedBody: "internal",
+		},
+		{
+			desc:               "high priority, frontend",
+			priority:           configuration.InternalRoutesPriorityHigh,
+			path:               "/.well-known/foo",
+			expectedBody:       "frontend",
+			expectedMiddleware: true,
+		},
+		{
+			desc:               "low priority, frontend matching an internal route",
+			priority:           configuration.InternalRoutesPriorityLow,
+			path:               "/ping",
+			expectedBody:       "frontend",
+			expectedMiddleware: true,
+		},
+		{
+			desc:               "low priority, internal route",
+			priority:           configuration.InternalRoutesPriorityLow,
+			path:               "/api",
+			expectedBody:       "internal",
+			expectedMiddleware: true,
+		},
+		{
+			desc:               "low priority, ACME challenge",
+			priority:           configuration.InternalRoutesPriorityLow,
+			path:               "/.well-known/acme-challenge/token",
+			expectedBody:       "internal",
+			expectedMiddleware: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			entryPoint := &configuration.EntryPoint{
+				Address:                "localhost:0",
+				ForwardedHeaders:       &configuration.ForwardedHeaders{Insecure: true},
+				InternalRoutesPriority: test.priority,
+			}
+			router := middlewares.NewHandlerSwitcher(frontends)
+
+			srv := NewServer(configuration.GlobalConfiguration{}, nil, map[string]EntryPoint{
+				"http": {InternalRouter: internalRouter, Configuration: entryPoint},
+			})
+			httpServer, listener, err := srv.prepareServer("http", entryPoint, router, []negroni.Handler{middleware})
+			require.NoError(t, err)
+			defer listener.Close()
+
+			recorder := httptest.NewRecorder()
+			httpServer.Handler.ServeHTTP(recorder, th.MustNewRequest(http.MethodGet, "http://foo"+test.path, nil))
+
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+			assert.Equal(t, test.expectedMiddleware, recorder.Header().Get("X-Middleware") == "true")
+		})
+	}
+}
+
 func TestListenProvidersSkipsEmptyConfigs(t *testing.T) {
 	server, stop, invokeStopChan := setupListenProvider(10 * time.Millisecond)
 	defer invokeStopChan()
@@ -461,3 +564,65 @@ func TestNewServerWithResponseModifiers(t *testing.T) {
 		})
 	}
 }
+
+func TestRejectMisdirectedRequests(t *testing.T) {
+	dynamicConfig := traefiktls.NewDynamicConfig(&tls.Config{})
+	dynamicConfig.SetDomainsOptions([]*traefiktls.DomainsOptions{
+		{Domains: []string{"strict.bar"}, MinVersion: "VersionTLS12"},
+	})
+	entryPoint := &serverEntryPoint{tlsConfig: dynamicConfig}
+
+	testCases := []struct {
+		desc           string
+		url            string
+		serverName     string
+		withoutTLS     bool
+		expectedStatus int
+	}{
+		{
+			desc:           "host of the server name",
+			url:            "https://strict.bar:8443",
+			serverName:     "strict.bar",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "hosts without options",
+			url:            "https://other.bar",
+			serverName:     "another.bar",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "host with other options than the server name",
+			url:            "https://strict.bar",
+			serverName:     "other.bar",
+			expectedStatus: http.StatusMisdirectedRequest,
+		},
+		{
+			desc:           "request without TLS",
+			url:            "http://strict.bar",
+			withoutTLS:     true,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			req.TLS = &tls.ConnectionState{ServerName: test.serverName}
+			if test.withoutTLS {
+				req.TLS = nil
+			}
+
+			n := negroni.New(&middlewares.RequestHost{}, negroni.HandlerFunc(entryPoint.rejectMisdirectedRequests))
+			n.UseHandlerFunc(func(rw http.ResponseWriter, r *http.Request) {})
+
+			recorder := httptest.NewRecorder()
+			n.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+		})
+	}
+}
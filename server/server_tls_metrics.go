@@ -0,0 +1,102 @@
+package server
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	traefiktls "github.com/containous/traefik/tls"
+	gokitmetrics "github.com/go-kit/kit/metrics"
+)
+
+type tlsMetrics interface {
+	TLSHandshakesCounter() gokitmetrics.Counter
+	TLSHandshakeDurationHistogram() gokitmetrics.Histogram
+	TLSHandshakeErrorsCounter() gokitmetrics.Counter
+}
+
+// tlsHandshakeMetrics records the TLS handshakes of an entry point.
+// The handshake of each connection is driven as soon as the connection is accepted, and its outcome recorded,
+// the http.Server gets the same result when it performs the handshake in turn.
+type tlsHandshakeMetrics struct {
+	entryPointName string
+	registry       tlsMetrics
+}
+
+func newTLSHandshakeMetrics(entryPointName string, registry tlsMetrics) *tlsHandshakeMetrics {
+	return &tlsHandshakeMetrics{
+		entryPointName: entryPointName,
+		registry:       registry,
+	}
+}
+
+// connState observes the handshake of the new TLS connections, it is an http.Server ConnState hook.
+func (m *tlsHandshakeMetrics) connState(conn net.Conn, state http.ConnState) {
+	if tlsConn, ok := conn.(*tls.Conn); ok && state == http.StateNew {
+		go m.observe(tlsConn)
+	}
+}
+
+// observe performs the handshake of a connection and records its outcome.
+func (m *tlsHandshakeMetrics) observe(conn *tls.Conn) {
+	start := time.Now()
+
+	if err := conn.Handshake(); err != nil {
+		m.registry.TLSHandshakeErrorsCounter().
+			With("entrypoint", m.entryPointName, "reason", tlsHandshakeErrorReason(err)).
+			Add(1)
+		return
+	}
+
+	state := conn.ConnectionState()
+	version := traefiktls.VersionName(state.Version)
+	m.registry.TLSHandshakesCounter().
+		With("entrypoint", m.entryPointName, "tls_version", version, "tls_cipher", traefiktls.CipherSuiteName(state.CipherSuite)).
+		Add(1)
+	m.registry.TLSHandshakeDurationHistogram().
+		With("entrypoint", m.entryPointName, "tls_version", version).
+		Observe(time.Since(start).Seconds())
+}
+
+// tlsHandshakeErrorReason classifies a TLS handshake error.
+func tlsHandshakeErrorReason(err error) string {
+	if recordErr, ok := err.(tls.RecordHeaderError); ok && tlsRecordHeaderLooksLikeHTTP(recordErr.RecordHeader) {
+		return "http_request"
+	}
+	if netErr, ok := err.(net.Error); (ok && netErr.Timeout()) || err == io.EOF || err == io.ErrUnexpectedEOF {
+		return "connection"
+	}
+
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "strict SNI enabled"):
+		return "sni_strict"
+	case strings.Contains(message, "client didn't provide a certificate"),
+		strings.Contains(message, "failed to verify client"),
+		strings.Contains(message, "failed to verify certificate"):
+		return "client_cert"
+	case strings.Contains(message, "unsupported versions"),
+		strings.Contains(message, "protocol version"):
+		return "protocol_version"
+	case strings.Contains(message, "no cipher suite supported"):
+		return "cipher_suite"
+	case strings.Contains(message, "remote error"):
+		return "remote_error"
+	case strings.Contains(message, "connection reset"), strings.Contains(message, "broken pipe"):
+		return "connection"
+	default:
+		return "other"
+	}
+}
+
+// tlsRecordHeaderLooksLikeHTTP reports whether a TLS record header looks like it might've been a misdirected plaintext HTTP request.
+func tlsRecordHeaderLooksLikeHTTP(hdr [5]byte) bool {
+	switch string(hdr[:]) {
+	case "GET /", "HEAD ", "POST ", "PUT /", "OPTIO":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"crypto/tls"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/tls/generate"
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSHandshakeMetricsObserve(t *testing.T) {
+	cert, err := generate.DefaultCertificate()
+	require.NoError(t, err)
+
+	collecting := newCollectingTLSMetrics()
+	metrics := newTLSHandshakeMetrics("https", collecting)
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	conn := tls.Server(serverConn, &tls.Config{Certificates: []tls.Certificate{*cert}})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		metrics.observe(conn)
+	}()
+
+	client := tls.Client(clientConn, &tls.Config{InsecureSkipVerify: true, MaxVersion: tls.VersionTLS12, CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}})
+	require.NoError(t, client.Handshake())
+	<-done
+
+	// the http.Server gets the outcome of the handshake already performed
+	require.NoError(t, conn.Handshake())
+
+	assert.Equal(t, float64(1), collecting.handshakes.CounterValue)
+	assert.Equal(t, []string{"entrypoint", "https", "tls_version", "1.2", "tls_cipher", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}, collecting.handshakes.LastLabelValues)
+	assert.Len(t, collecting.durations.Observations, 1)
+	assert.Equal(t, []string{"entrypoint", "https", "tls_version", "1.2"}, collecting.durations.LastLabelValues)
+	assert.Equal(t, float64(0), collecting.errors.CounterValue)
+}
+
+func TestTLSHandshakeMetricsErrors(t *testing.T) {
+	cert, err := generate.DefaultCertificate()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc           string
+		serverConfig   *tls.Config
+		client         func(conn net.Conn)
+		expectedReason string
+	}{
+		{
+			desc:         "missing client certificate",
+			serverConfig: &tls.Config{Certificates: []tls.Certificate{*cert}, ClientAuth: tls.RequireAnyClientCert, MaxVersion: tls.VersionTLS12},
+			client: func(conn net.Conn) {
+				_ = tls.Client(conn, &tls.Config{InsecureSkipVerify: true}).Handshake()
+			},
+			expectedReason: "client_cert",
+		},
+		{
+			desc:         "TLS 1.0 client",
+			serverConfig: &tls.Config{Certificates: []tls.Certificate{*cert}, MinVersion: tls.VersionTLS12},
+			client: func(conn net.Conn) {
+				_ = tls.Client(conn, &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS10, MaxVersion: tls.VersionTLS10}).Handshake()
+			},
+			expectedReason: "protocol_version",
+		},
+		{
+			desc:         "plain HTTP",
+			serverConfig: &tls.Config{Certificates: []tls.Certificate{*cert}},
+			client: func(conn net.Conn) {
+				_, _ = conn.Write([]byte("GET / HTTP/1.1\r\nHost: foo.bar\r\n\r\n"))
+			},
+			expectedReason: "http_request",
+		},
+		{
+			desc:         "connection closed",
+			serverConfig: &tls.Config{Certificates: []tls.Certificate{*cert}},
+			client: func(conn net.Conn) {
+				conn.Close()
+			},
+			expectedReason: "connection",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			collecting := newCollectingTLSMetrics()
+			metrics := newTLSHandshakeMetrics("https", collecting)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer listener.Close()
+
+			go func() {
+				clientConn, err := net.Dial("tcp", listener.Addr().String())
+				if err != nil {
+					return
+				}
+				defer clientConn.Close()
+
+				test.client(clientConn)
+				// waits for the server to close the connection
+				_, _ = io.Copy(ioutil.Discard, clientConn)
+			}()
+
+			serverConn, err := listener.Accept()
+			require.NoError(t, err)
+			defer serverConn.Close()
+
+			conn := tls.Server(serverConn, test.serverConfig)
+			metrics.observe(conn)
+
+			assert.Error(t, conn.Handshake())
+			conn.Close()
+			assert.Equal(t, float64(0), collecting.handshakes.CounterValue)
+			assert.Equal(t, float64(1), collecting.errors.CounterValue)
+			assert.Equal(t, []string{"entrypoint", "https", "reason", test.expectedReason}, collecting.errors.LastLabelValues)
+		})
+	}
+}
+
+func TestTLSHandshakeMetricsConnState(t *testing.T) {
+	collecting := newCollectingTLSMetrics()
+	metrics := newTLSHandshakeMetrics("https", collecting)
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	clientConn.Close()
+
+	// only the new TLS connections are observed
+	metrics.connState(serverConn, http.StateNew)
+	metrics.connState(tls.Server(serverConn, &tls.Config{}), http.StateClosed)
+
+	assert.Equal(t, float64(0), collecting.handshakes.CounterValue)
+	assert.Equal(t, float64(0), collecting.errors.CounterValue)
+}
+
+type collectingTLSMetrics struct {
+	handshakes *testhelpers.CollectingCounter
+	durations  *testhelpers.CollectingHistogram
+	errors     *testhelpers.CollectingCounter
+}
+
+func newCollectingTLSMetrics() *collectingTLSMetrics {
+	return &collectingTLSMetrics{
+		handshakes: &testhelpers.CollectingCounter{},
+		durations:  &testhelpers.CollectingHistogram{},
+		errors:     &testhelpers.CollectingCounter{},
+	}
+}
+
+func (m *collectingTLSMetrics) TLSHandshakesCounter() gokitmetrics.Counter {
+	return m.handshakes
+}
+
+func (m *collectingTLSMetrics) TLSHandshakeDurationHistogram() gokitmetrics.Histogram {
+	return m.durations
+}
+
+func (m *collectingTLSMetrics) TLSHandshakeErrorsCounter() gokitmetrics.Counter {
+	return m.errors
+}
@@ -9,7 +9,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/tls/generate"
 )
 
@@ -22,6 +21,22 @@ var (
 		"VersionTLS13": tls.VersionTLS13,
 	}
 
+	// MaxVersion Map of allowed TLS maximum versions
+	MaxVersion = MinVersion
+
+	// CurveIDs Map of TLS elliptic curves from crypto/tls
+	// Available curves defined at https://golang.org/pkg/crypto/tls/#CurveID
+	CurveIDs = map[string]tls.CurveID{
+		"secp256r1": tls.CurveP256,
+		"CurveP256": tls.CurveP256,
+		"secp384r1": tls.CurveP384,
+		"CurveP384": tls.CurveP384,
+		"secp521r1": tls.CurveP521,
+		"CurveP521": tls.CurveP521,
+		"x25519":    tls.X25519,
+		"X25519":    tls.X25519,
+	}
+
 	// CipherSuites Map of TLS CipherSuites from crypto/tls
 	// Available CipherSuites defined at https://golang.org/pkg/crypto/tls/#pkg-constants
 	CipherSuites = map[string]uint16{
@@ -54,6 +69,32 @@ var (
 	}
 )
 
+// VersionName returns the name of a TLS version as used in the metrics labels, e.g. "1.2"
+func VersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "1.0"
+	case tls.VersionTLS11:
+		return "1.1"
+	case tls.VersionTLS12:
+		return "1.2"
+	case tls.VersionTLS13:
+		return "1.3"
+	default:
+		return fmt.Sprintf("0x%04x", version)
+	}
+}
+
+// CipherSuiteName returns the name of a cipher suite as found in CipherSuites
+func CipherSuiteName(id uint16) string {
+	for name, cipherID := range CipherSuites {
+		if cipherID == id {
+			return name
+		}
+	}
+	return fmt.Sprintf("0x%04x", id)
+}
+
 // Certificate holds a SSL cert/key pair
 // Certs and Key could be either a file path, or the file content itself
 type Certificate struct {
@@ -109,7 +150,7 @@ func (c *Certificates) CreateTLSConfig(entryPointName string) (*tls.Config, erro
 		for _, certificate := range *c {
 			err := certificate.AppendCertificate(domainsCertificates, entryPointName)
 			if err != nil {
-				log.Errorf("Unable to add a certificate to the entryPoint %q : %v", entryPointName, err)
+				logger.Errorf("Unable to add a certificate to the entryPoint %q : %v", entryPointName, err)
 				continue
 			}
 
@@ -192,9 +233,9 @@ func (c *Certificate) AppendCertificate(certs map[string]map[string]*tls.Certifi
 		}
 	}
 	if certExists {
-		log.Warnf("Skipping addition of certificate for domain(s) %q, to EntryPoint %s, as it already exists for this Entrypoint.", certKey, ep)
+		logger.Warnf("Skipping addition of certificate for domain(s) %q, to EntryPoint %s, as it already exists for this Entrypoint.", certKey, ep)
 	} else {
-		log.Debugf("Adding certificate for domain(s) %s", certKey)
+		logger.Debugf("Adding certificate for domain(s) %s", certKey)
 		certs[ep][certKey] = &tlsCert
 	}
 
@@ -8,29 +8,40 @@ import (
 	"strings"
 	"time"
 
-	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/safe"
 	"github.com/patrickmn/go-cache"
 )
 
 // CertificateStore store for dynamic and static certificates
 type CertificateStore struct {
-	DynamicCerts       *safe.Safe
-	StaticCerts        *safe.Safe
-	DefaultCertificate *tls.Certificate
-	CertCache          *cache.Cache
-	SniStrict          bool
+	DynamicCerts              *safe.Safe
+	StaticCerts               *safe.Safe
+	DefaultCertificate        *tls.Certificate
+	DynamicDefaultCertificate *safe.Safe
+	CertCache                 *cache.Cache
+	SniStrict                 bool
 }
 
 // NewCertificateStore create a store for dynamic and static certificates
 func NewCertificateStore() *CertificateStore {
 	return &CertificateStore{
-		StaticCerts:  &safe.Safe{},
-		DynamicCerts: &safe.Safe{},
-		CertCache:    cache.New(1*time.Hour, 10*time.Minute),
+		StaticCerts:               &safe.Safe{},
+		DynamicCerts:              &safe.Safe{},
+		DynamicDefaultCertificate: &safe.Safe{},
+		CertCache:                 cache.New(1*time.Hour, 10*time.Minute),
 	}
 }
 
+// GetDefaultCertificate returns the default certificate provided dynamically if any, the static one otherwise
+func (c CertificateStore) GetDefaultCertificate() *tls.Certificate {
+	if c.DynamicDefaultCertificate != nil {
+		if cert, ok := c.DynamicDefaultCertificate.Get().(*tls.Certificate); ok && cert != nil {
+			return cert
+		}
+	}
+	return c.DefaultCertificate
+}
+
 // GetAllDomains return a slice with all the certificate domain
 func (c CertificateStore) GetAllDomains() []string {
 	var allCerts []string
@@ -50,8 +61,8 @@ func (c CertificateStore) GetAllDomains() []string {
 	}
 
 	// Get Default certificate
-	if c.DefaultCertificate != nil {
-		allCerts = append(allCerts, getCertificateDomains(c.DefaultCertificate)...)
+	if defaultCertificate := c.GetDefaultCertificate(); defaultCertificate != nil {
+		allCerts = append(allCerts, getCertificateDomains(defaultCertificate)...)
 	}
 	return allCerts
 }
@@ -63,7 +74,7 @@ func (c CertificateStore) GetBestCertificate(clientHello *tls.ClientHelloInfo) *
 		// If no ServerName is provided, Check for local IP address matches
 		host, _, err := net.SplitHostPort(clientHello.Conn.LocalAddr().String())
 		if err != nil {
-			log.Debugf("Could not split host/port: %v", err)
+			logger.Debugf("Could not split host/port: %v", err)
 		}
 		domainToCheck = strings.TrimSpace(host)
 	}
@@ -0,0 +1,127 @@
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+// DomainsOptions allows overriding, for a list of domains, the TLS versions, the cipher suites
+// and the client CAs of a list of entrypoints
+type DomainsOptions struct {
+	EntryPoints  []string
+	Domains      []string
+	MinVersion   string
+	MaxVersion   string
+	CipherSuites []string
+	ClientCA     ClientCA
+}
+
+// SortDomainsOptionsPerEntryPoints converts domains options into domains options sorted by EntryPoints
+func SortDomainsOptionsPerEntryPoints(configurations []*DomainsOptions, epOptions map[string][]*DomainsOptions, defaultEntryPoints []string) {
+	for _, conf := range configurations {
+		entryPoints := conf.EntryPoints
+		if len(entryPoints) == 0 {
+			logger.Debugf("No entryPoint is defined to add the TLS options of %s, they will be added to the default entryPoints: %s", strings.Join(conf.Domains, ", "), strings.Join(defaultEntryPoints, ", "))
+			entryPoints = defaultEntryPoints
+		}
+		for _, ep := range entryPoints {
+			epOptions[ep] = append(epOptions[ep], conf)
+		}
+	}
+}
+
+// domainSettings holds the parsed domains options
+type domainSettings struct {
+	minVersion   uint16
+	maxVersion   uint16
+	cipherSuites []uint16
+	clientCAs    *x509.CertPool
+	clientAuth   tls.ClientAuthType
+}
+
+func newDomainSettings(options *DomainsOptions) (*domainSettings, error) {
+	settings := &domainSettings{}
+
+	if len(options.MinVersion) > 0 {
+		minVersion, exists := MinVersion[options.MinVersion]
+		if !exists {
+			return nil, fmt.Errorf("invalid TLS MinVersion: %s", options.MinVersion)
+		}
+		settings.minVersion = minVersion
+	}
+
+	if len(options.MaxVersion) > 0 {
+		maxVersion, exists := MaxVersion[options.MaxVersion]
+		if !exists {
+			return nil, fmt.Errorf("invalid TLS MaxVersion: %s", options.MaxVersion)
+		}
+		if settings.minVersion != 0 && maxVersion < settings.minVersion {
+			return nil, fmt.Errorf("TLS MaxVersion %s is lower than MinVersion %s", options.MaxVersion, options.MinVersion)
+		}
+		settings.maxVersion = maxVersion
+	}
+
+	for _, cipher := range options.CipherSuites {
+		cipherSuite, exists := CipherSuites[cipher]
+		if !exists {
+			return nil, fmt.Errorf("invalid CipherSuite: %s", cipher)
+		}
+		settings.cipherSuites = append(settings.cipherSuites, cipherSuite)
+	}
+
+	if len(options.ClientCA.Files) > 0 {
+		pool := x509.NewCertPool()
+		for _, caFile := range options.ClientCA.Files {
+			data, err := caFile.Read()
+			if err != nil {
+				return nil, err
+			}
+			if !pool.AppendCertsFromPEM(data) {
+				return nil, fmt.Errorf("invalid certificate(s) in %s", caFile)
+			}
+		}
+		settings.clientCAs = pool
+		settings.clientAuth = tls.RequireAndVerifyClientCert
+		if options.ClientCA.Optional {
+			settings.clientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
+	return settings, nil
+}
+
+func (s *domainSettings) apply(config *tls.Config) {
+	if s.minVersion != 0 {
+		config.MinVersion = s.minVersion
+		config.PreferServerCipherSuites = true
+	}
+	if s.maxVersion != 0 {
+		config.MaxVersion = s.maxVersion
+	}
+	if s.cipherSuites != nil {
+		config.CipherSuites = s.cipherSuites
+	}
+	if s.clientCAs != nil {
+		config.ClientCAs = s.clientCAs
+		config.ClientAuth = s.clientAuth
+	}
+}
+
+// lookupDomain returns the domain of the options applying to the given server name, trying the exact name first,
+// then a wildcard on its parent domain
+func lookupDomain(domains map[string]*domainSettings, serverName string) (string, bool) {
+	domain := strings.ToLower(strings.TrimSuffix(serverName, "."))
+	if _, ok := domains[domain]; ok {
+		return domain, true
+	}
+
+	if i := strings.Index(domain, "."); i > 0 {
+		wildcard := "*" + domain[i:]
+		if _, ok := domains[wildcard]; ok {
+			return wildcard, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,141 @@
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+	"sync"
+)
+
+// DynamicConfig hot-swaps the parts of an entry point TLS config which can change at runtime:
+// the session ticket keys, the client CAs and the TLS options of some domains.
+// The http.Server clones its TLS config when serving, so changes are applied on a copy of the base config
+// returned by GetConfigForClient.
+type DynamicConfig struct {
+	lock          sync.RWMutex
+	base          *tls.Config
+	current       *tls.Config
+	ticketKeys    [][32]byte
+	clientCAs     *x509.CertPool
+	clientAuth    tls.ClientAuthType
+	domains       map[string]*domainSettings
+	domainConfigs map[string]*tls.Config
+}
+
+// NewDynamicConfig creates a DynamicConfig hooked on the given TLS config
+func NewDynamicConfig(config *tls.Config) *DynamicConfig {
+	dynamicConfig := &DynamicConfig{base: config}
+	config.GetConfigForClient = dynamicConfig.getConfigForClient
+	return dynamicConfig
+}
+
+// SetSessionTicketKeys applies new session ticket keys
+func (d *DynamicConfig) SetSessionTicketKeys(keys *SessionTicketKeys) error {
+	tlsKeys, err := keys.toTLSKeys()
+	if err != nil {
+		return err
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.ticketKeys = tlsKeys
+	d.update()
+	return nil
+}
+
+// SetClientCAs applies new client CAs, a nil pool restores the client CAs of the base config
+func (d *DynamicConfig) SetClientCAs(pool *x509.CertPool, clientAuth tls.ClientAuthType) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.clientCAs = pool
+	d.clientAuth = clientAuth
+	d.update()
+}
+
+// SetDomainsOptions applies new TLS options to some domains, replacing the previous ones.
+// An invalid option is skipped and its domains keep their previous options,
+// a domain configured twice keeps the first options.
+func (d *DynamicConfig) SetDomainsOptions(options []*DomainsOptions) {
+	// the client CAs files are read before taking the lock, to not delay the handshakes
+	settings := make([]*domainSettings, len(options))
+	for i, option := range options {
+		var err error
+		settings[i], err = newDomainSettings(option)
+		if err != nil {
+			logger.Errorf("Invalid TLS options for %s, their previous options are kept: %v", strings.Join(option.Domains, ", "), err)
+		}
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	domains := make(map[string]*domainSettings)
+	for i, option := range options {
+		for _, domain := range option.Domains {
+			domain = strings.ToLower(domain)
+			if _, exists := domains[domain]; exists {
+				logger.Errorf("TLS options defined more than once for domain %s, the first ones are used", domain)
+				continue
+			}
+
+			if settings[i] != nil {
+				domains[domain] = settings[i]
+			} else if previous, ok := d.domains[domain]; ok {
+				domains[domain] = previous
+			}
+		}
+	}
+
+	d.domains = domains
+	d.update()
+}
+
+// SameDomainsOptions reports whether a request host has the same TLS options as the server name of the TLS handshake.
+// A request for another host than the server name must not use the connection otherwise,
+// e.g. to not skip the client certificate required by the options of this host.
+func (d *DynamicConfig) SameDomainsOptions(host, serverName string) bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	hostDomain, _ := lookupDomain(d.domains, host)
+	serverDomain, _ := lookupDomain(d.domains, serverName)
+	return d.domains[hostDomain] == d.domains[serverDomain]
+}
+
+func (d *DynamicConfig) update() {
+	d.current = nil
+	if d.ticketKeys != nil || d.clientCAs != nil {
+		config := d.base.Clone()
+		config.GetConfigForClient = nil
+		if d.ticketKeys != nil {
+			config.SetSessionTicketKeys(d.ticketKeys)
+		}
+		if d.clientCAs != nil {
+			config.ClientCAs = d.clientCAs
+			config.ClientAuth = d.clientAuth
+		}
+		d.current = config
+	}
+
+	d.domainConfigs = make(map[string]*tls.Config, len(d.domains))
+	for domain, settings := range d.domains {
+		config := d.base.Clone()
+		if d.current != nil {
+			config = d.current.Clone()
+		}
+		config.GetConfigForClient = nil
+		settings.apply(config)
+		d.domainConfigs[domain] = config
+	}
+}
+
+func (d *DynamicConfig) getConfigForClient(clientHello *tls.ClientHelloInfo) (*tls.Config, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	if domain, ok := lookupDomain(d.domains, clientHello.ServerName); ok {
+		return d.domainConfigs[domain], nil
+	}
+	// a nil config means the base config is used
+	return d.current, nil
+}
@@ -0,0 +1,224 @@
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicConfigClientCAs(t *testing.T) {
+	base := &tls.Config{ClientAuth: tls.NoClientCert}
+	dynamicConfig := NewDynamicConfig(base)
+
+	config, err := base.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Nil(t, config, "the base config is used when nothing is dynamic")
+
+	pool := x509.NewCertPool()
+	dynamicConfig.SetClientCAs(pool, tls.RequireAndVerifyClientCert)
+
+	config, err = base.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.NotNil(t, config)
+	assert.Equal(t, pool, config.ClientCAs)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+	assert.Nil(t, config.GetConfigForClient)
+	assert.Equal(t, tls.NoClientCert, base.ClientAuth)
+
+	dynamicConfig.SetClientCAs(nil, tls.NoClientCert)
+
+	config, err = base.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Nil(t, config)
+}
+
+func TestDynamicConfigDomainsOptions(t *testing.T) {
+	base := &tls.Config{MinVersion: tls.VersionTLS10}
+	dynamicConfig := NewDynamicConfig(base)
+
+	dynamicConfig.SetDomainsOptions([]*DomainsOptions{
+		{
+			Domains:      []string{"Foo.bar", "*.wildcard.bar"},
+			MinVersion:   "VersionTLS12",
+			CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		},
+	})
+
+	testCases := []struct {
+		desc               string
+		serverName         string
+		expectedMinVersion uint16
+	}{
+		{
+			desc:               "exact domain",
+			serverName:         "foo.bar",
+			expectedMinVersion: tls.VersionTLS12,
+		},
+		{
+			desc:               "wildcard domain",
+			serverName:         "sub.wildcard.bar",
+			expectedMinVersion: tls.VersionTLS12,
+		},
+		{
+			desc:       "wildcard does not match the parent domain",
+			serverName: "wildcard.bar",
+		},
+		{
+			desc:       "other domain",
+			serverName: "other.bar",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := base.GetConfigForClient(&tls.ClientHelloInfo{ServerName: test.serverName})
+			require.NoError(t, err)
+
+			if test.expectedMinVersion == 0 {
+				assert.Nil(t, config, "the base config is used")
+				return
+			}
+
+			require.NotNil(t, config)
+			assert.Equal(t, test.expectedMinVersion, config.MinVersion)
+			assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}, config.CipherSuites)
+			assert.Nil(t, config.GetConfigForClient)
+		})
+	}
+
+	assert.Equal(t, uint16(tls.VersionTLS10), base.MinVersion)
+}
+
+func TestDynamicConfigDomainsOptionsInvalid(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		options  []*DomainsOptions
+		expected map[string]uint16
+	}{
+		{
+			desc:     "unknown min version",
+			options:  []*DomainsOptions{{Domains: []string{"foo.bar"}, MinVersion: "VersionTLS99"}},
+			expected: map[string]uint16{"foo.bar": tls.VersionTLS11},
+		},
+		{
+			desc:     "max version lower than min version",
+			options:  []*DomainsOptions{{Domains: []string{"foo.bar"}, MinVersion: "VersionTLS12", MaxVersion: "VersionTLS11"}},
+			expected: map[string]uint16{"foo.bar": tls.VersionTLS11},
+		},
+		{
+			desc:     "unknown cipher suite",
+			options:  []*DomainsOptions{{Domains: []string{"foo.bar"}, CipherSuites: []string{"TLS_FOO"}}},
+			expected: map[string]uint16{"foo.bar": tls.VersionTLS11},
+		},
+		{
+			desc: "invalid option next to a valid one",
+			options: []*DomainsOptions{
+				{Domains: []string{"foo.bar", "new.bar"}, MinVersion: "VersionTLS99"},
+				{Domains: []string{"other.bar"}, MinVersion: "VersionTLS12"},
+			},
+			expected: map[string]uint16{"foo.bar": tls.VersionTLS11, "new.bar": 0, "other.bar": tls.VersionTLS12},
+		},
+		{
+			desc: "domain defined twice",
+			options: []*DomainsOptions{
+				{Domains: []string{"foo.bar"}, MinVersion: "VersionTLS12"},
+				{Domains: []string{"FOO.bar", "other.bar"}, MinVersion: "VersionTLS11"},
+			},
+			expected: map[string]uint16{"foo.bar": tls.VersionTLS12, "other.bar": tls.VersionTLS11},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			base := &tls.Config{}
+			dynamicConfig := NewDynamicConfig(base)
+			dynamicConfig.SetDomainsOptions([]*DomainsOptions{{Domains: []string{"foo.bar"}, MinVersion: "VersionTLS11"}})
+
+			dynamicConfig.SetDomainsOptions(test.options)
+
+			for serverName, expectedMinVersion := range test.expected {
+				config, err := base.GetConfigForClient(&tls.ClientHelloInfo{ServerName: serverName})
+				require.NoError(t, err)
+
+				if expectedMinVersion == 0 {
+					assert.Nil(t, config, serverName)
+					continue
+				}
+				require.NotNil(t, config, serverName)
+				assert.Equal(t, expectedMinVersion, config.MinVersion, serverName)
+			}
+		})
+	}
+}
+
+func TestDynamicConfigSameDomainsOptions(t *testing.T) {
+	dynamicConfig := NewDynamicConfig(&tls.Config{})
+	dynamicConfig.SetDomainsOptions([]*DomainsOptions{
+		{Domains: []string{"ciphers.bar", "*.ciphers.bar"}, CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+		{Domains: []string{"strict.bar"}, MinVersion: "VersionTLS12"},
+	})
+
+	testCases := []struct {
+		desc       string
+		host       string
+		serverName string
+		expected   bool
+	}{
+		{
+			desc:       "same domain",
+			host:       "ciphers.bar",
+			serverName: "CIPHERS.bar.",
+			expected:   true,
+		},
+		{
+			desc:       "domains of the same options",
+			host:       "sub.ciphers.bar",
+			serverName: "ciphers.bar",
+			expected:   true,
+		},
+		{
+			desc:       "domains without options",
+			host:       "other.bar",
+			serverName: "another.bar",
+			expected:   true,
+		},
+		{
+			desc:       "host with options on a connection without",
+			host:       "ciphers.bar",
+			serverName: "other.bar",
+		},
+		{
+			desc:       "host with options on a connection without server name",
+			host:       "ciphers.bar",
+			serverName: "",
+		},
+		{
+			desc:       "host without options on a connection with",
+			host:       "other.bar",
+			serverName: "ciphers.bar",
+		},
+		{
+			desc:       "domains of different options",
+			host:       "strict.bar",
+			serverName: "ciphers.bar",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, dynamicConfig.SameDomainsOptions(test.host, test.serverName))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package tls
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containous/flaeg"
+)
+
+const (
+	// sessionTicketKeySize is the size of a session ticket key expected by crypto/tls
+	sessionTicketKeySize = 32
+
+	// DefaultSessionTicketKeysRotationInterval is the default interval between two rotations of the session ticket keys
+	DefaultSessionTicketKeysRotationInterval = flaeg.Duration(12 * time.Hour)
+
+	// DefaultSessionTicketMaxKeys is the default number of session ticket keys kept to decrypt tickets
+	DefaultSessionTicketMaxKeys = 3
+)
+
+// SessionTickets configures the TLS session ticket keys of an entry point
+// Keys are either read from KeyFiles, shared through the cluster KV store under the Storage key,
+// or generated by crypto/tls when none of them is set.
+type SessionTickets struct {
+	Disabled         bool            `description:"Disable TLS session resumption with session tickets" export:"true"`
+	KeyFiles         FilesOrContents `description:"Files holding the session ticket keys, one base64 encoded 32 bytes key per line. The first key encrypts new tickets."`
+	Storage          string          `description:"Key used to share the session ticket keys in the cluster KV store" export:"true"`
+	RotationInterval flaeg.Duration  `description:"Interval between two rotations (Storage) or reloads (KeyFiles) of the session ticket keys" export:"true"`
+	MaxKeys          int             `description:"Number of session ticket keys kept to decrypt tickets (Storage only)" export:"true"`
+}
+
+// SetDefaults sets the default values of the session tickets configuration
+func (s *SessionTickets) SetDefaults() {
+	if s.RotationInterval <= 0 {
+		s.RotationInterval = DefaultSessionTicketKeysRotationInterval
+	}
+	if s.MaxKeys <= 0 {
+		s.MaxKeys = DefaultSessionTicketMaxKeys
+	}
+}
+
+// SessionTicketKeys holds the keys used to encrypt and decrypt TLS session tickets
+// The first key is used to encrypt new tickets, all of them are used to decrypt tickets
+type SessionTicketKeys struct {
+	Keys      [][]byte
+	RotatedAt time.Time
+}
+
+// Rotate prepends a new random key and keeps at most maxKeys keys
+func (k *SessionTicketKeys) Rotate(maxKeys int) error {
+	key := make([]byte, sessionTicketKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("unable to generate session ticket key: %v", err)
+	}
+
+	k.Keys = append([][]byte{key}, k.Keys...)
+	if maxKeys > 0 && len(k.Keys) > maxKeys {
+		k.Keys = k.Keys[:maxKeys]
+	}
+	k.RotatedAt = time.Now().UTC()
+	return nil
+}
+
+// NeedRotation returns true if the keys are older than the given interval
+func (k *SessionTicketKeys) NeedRotation(interval time.Duration) bool {
+	return len(k.Keys) == 0 || time.Since(k.RotatedAt) >= interval
+}
+
+func (k *SessionTicketKeys) toTLSKeys() ([][32]byte, error) {
+	if len(k.Keys) == 0 {
+		return nil, errors.New("no session ticket key")
+	}
+
+	keys := make([][32]byte, len(k.Keys))
+	for i, key := range k.Keys {
+		if len(key) != sessionTicketKeySize {
+			return nil, fmt.Errorf("invalid session ticket key size: expected %d bytes, got %d", sessionTicketKeySize, len(key))
+		}
+		copy(keys[i][:], key)
+	}
+	return keys, nil
+}
+
+// ReadSessionTicketKeys reads the session ticket keys from files or contents
+func ReadSessionTicketKeys(files FilesOrContents) (*SessionTicketKeys, error) {
+	keys := &SessionTicketKeys{}
+	for _, file := range files {
+		content, err := file.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if len(line) == 0 || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			key, err := base64.StdEncoding.DecodeString(line)
+			if err != nil {
+				return nil, fmt.Errorf("invalid session ticket key in %s: %v", file, err)
+			}
+			if len(key) != sessionTicketKeySize {
+				return nil, fmt.Errorf("invalid session ticket key in %s: expected %d bytes, got %d", file, sessionTicketKeySize, len(key))
+			}
+			keys.Keys = append(keys.Keys, key)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(keys.Keys) == 0 {
+		return nil, errors.New("no session ticket key found")
+	}
+	keys.RotatedAt = time.Now().UTC()
+	return keys, nil
+}
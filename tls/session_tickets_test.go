@@ -0,0 +1,147 @@
+package tls
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/tls/generate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSessionTicketKeys(t *testing.T) {
+	key1 := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))
+	key2 := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", 32)))
+
+	dir, err := ioutil.TempDir("", "traefik-tickets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	keysFile := filepath.Join(dir, "keys")
+	err = ioutil.WriteFile(keysFile, []byte("# current key first\n"+key1+"\n\n"+key2+"\n"), 0600)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc         string
+		files        FilesOrContents
+		expectedKeys [][]byte
+		expectedErr  bool
+	}{
+		{
+			desc:         "from file",
+			files:        FilesOrContents{FileOrContent(keysFile)},
+			expectedKeys: [][]byte{[]byte(strings.Repeat("a", 32)), []byte(strings.Repeat("b", 32))},
+		},
+		{
+			desc:         "from content",
+			files:        FilesOrContents{FileOrContent(key2)},
+			expectedKeys: [][]byte{[]byte(strings.Repeat("b", 32))},
+		},
+		{
+			desc:        "invalid key size",
+			files:       FilesOrContents{FileOrContent(base64.StdEncoding.EncodeToString([]byte("short")))},
+			expectedErr: true,
+		},
+		{
+			desc:        "invalid base64",
+			files:       FilesOrContents{"not-base64!"},
+			expectedErr: true,
+		},
+		{
+			desc:        "no key",
+			files:       FilesOrContents{"# nothing"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			keys, err := ReadSessionTicketKeys(test.files)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedKeys, keys.Keys)
+		})
+	}
+}
+
+func TestSessionTicketKeysRotate(t *testing.T) {
+	keys := &SessionTicketKeys{}
+	assert.True(t, keys.NeedRotation(time.Hour))
+
+	for i := 0; i < 5; i++ {
+		previous := keys.Keys
+		require.NoError(t, keys.Rotate(3))
+
+		require.NotEmpty(t, keys.Keys)
+		assert.Len(t, keys.Keys[0], sessionTicketKeySize)
+		if len(previous) > 0 {
+			assert.Equal(t, previous[0], keys.Keys[1])
+		}
+	}
+
+	assert.Len(t, keys.Keys, 3)
+	assert.False(t, keys.NeedRotation(time.Hour))
+	assert.True(t, keys.NeedRotation(0))
+}
+
+func TestDynamicConfigSessionTicketsResumption(t *testing.T) {
+	keys := &SessionTicketKeys{}
+	require.NoError(t, keys.Rotate(DefaultSessionTicketMaxKeys))
+
+	newServerConfig := func() *tls.Config {
+		cert, err := generate.DefaultCertificate()
+		require.NoError(t, err)
+
+		config := &tls.Config{
+			Certificates: []tls.Certificate{*cert},
+			MaxVersion:   tls.VersionTLS12,
+		}
+		dynamicConfig := NewDynamicConfig(config)
+		require.NoError(t, dynamicConfig.SetSessionTicketKeys(keys))
+		return config
+	}
+
+	clientConfig := &tls.Config{
+		InsecureSkipVerify: true,
+		ClientSessionCache: tls.NewLRUClientSessionCache(1),
+		ServerName:         "traefik.test",
+	}
+
+	// the second server shares the keys of the first one, as another replica would
+	assert.False(t, handshake(t, newServerConfig(), clientConfig))
+	assert.True(t, handshake(t, newServerConfig(), clientConfig))
+
+	// a new encryption key still decrypts tickets issued with the previous one
+	require.NoError(t, keys.Rotate(DefaultSessionTicketMaxKeys))
+	assert.True(t, handshake(t, newServerConfig(), clientConfig))
+}
+
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) bool {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- tls.Server(serverConn, serverConfig).Handshake()
+	}()
+
+	client := tls.Client(clientConn, clientConfig)
+	require.NoError(t, client.Handshake())
+	require.NoError(t, <-errCh)
+
+	return client.ConnectionState().DidResume
+}
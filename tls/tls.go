@@ -9,6 +9,8 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var logger = log.WithModule("tls")
+
 const (
 	certificateHeader = "-----BEGIN CERTIFICATE-----\n"
 )
@@ -22,13 +24,17 @@ type ClientCA struct {
 
 // TLS configures TLS for an entry point
 type TLS struct {
-	MinVersion         string `export:"true"`
-	CipherSuites       []string
-	Certificates       Certificates
-	ClientCAFiles      FilesOrContents // Deprecated
-	ClientCA           ClientCA
-	DefaultCertificate *Certificate
-	SniStrict          bool `export:"true"`
+	MinVersion               string `export:"true"`
+	MaxVersion               string `export:"true"`
+	CipherSuites             []string
+	CurvePreferences         []string `export:"true"`
+	PreferServerCipherSuites bool     `export:"true"`
+	Certificates             Certificates
+	ClientCAFiles            FilesOrContents // Deprecated
+	ClientCA                 ClientCA
+	DefaultCertificate       *Certificate
+	SniStrict                bool            `export:"true"`
+	SessionTickets           *SessionTickets `export:"true"`
 }
 
 // FilesOrContents hold the CA we want to have in root
@@ -38,6 +44,14 @@ type FilesOrContents []FileOrContent
 type Configuration struct {
 	EntryPoints []string
 	Certificate *Certificate
+	// Default serves the certificate when no other certificate matches the requested domain
+	Default bool
+}
+
+// ClientCAConfiguration allows mapping CA certificates, used to verify client certificates, to a list of entrypoints
+type ClientCAConfiguration struct {
+	EntryPoints []string
+	Files       FilesOrContents
 }
 
 // String is the method to format the flag's value, part of the flag.Value interface.
@@ -86,8 +100,8 @@ func SortTLSPerEntryPoints(configurations []*Configuration, epConfiguration map[
 	}
 	for _, conf := range configurations {
 		if conf.EntryPoints == nil || len(conf.EntryPoints) == 0 {
-			if log.GetLevel() >= logrus.DebugLevel {
-				log.Debugf("No entryPoint is defined to add the certificate %s, it will be added to the default entryPoints: %s",
+			if logger.GetLevel() >= logrus.DebugLevel {
+				logger.Debugf("No entryPoint is defined to add the certificate %s, it will be added to the default entryPoints: %s",
 					conf.Certificate.getTruncatedCertificateName(),
 					strings.Join(defaultEntryPoints, ", "))
 			}
@@ -95,8 +109,22 @@ func SortTLSPerEntryPoints(configurations []*Configuration, epConfiguration map[
 		}
 		for _, ep := range conf.EntryPoints {
 			if err := conf.Certificate.AppendCertificate(epConfiguration, ep); err != nil {
-				log.Errorf("Unable to append certificate %s to entrypoint %s: %v", conf.Certificate.getTruncatedCertificateName(), ep, err)
+				logger.Errorf("Unable to append certificate %s to entrypoint %s: %v", conf.Certificate.getTruncatedCertificateName(), ep, err)
 			}
 		}
 	}
 }
+
+// SortClientCAsPerEntryPoints converts client CA configurations into CA certificates sorted by EntryPoints
+func SortClientCAsPerEntryPoints(configurations []*ClientCAConfiguration, epClientCAs map[string]FilesOrContents, defaultEntryPoints []string) {
+	for _, conf := range configurations {
+		entryPoints := conf.EntryPoints
+		if len(entryPoints) == 0 {
+			logger.Debugf("No entryPoint is defined to add the client CA, it will be added to the default entryPoints: %s", strings.Join(defaultEntryPoints, ", "))
+			entryPoints = defaultEntryPoints
+		}
+		for _, ep := range entryPoints {
+			epClientCAs[ep] = append(epClientCAs[ep], conf.Files...)
+		}
+	}
+}
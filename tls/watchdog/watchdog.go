@@ -0,0 +1,372 @@
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containous/flaeg"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/safe"
+	traefiktls "github.com/containous/traefik/tls"
+	"github.com/go-kit/kit/metrics"
+)
+
+var logger = log.WithModule("tls/watchdog")
+
+const (
+	// DefaultCheckInterval is the default interval between two checks of the certificates
+	DefaultCheckInterval = flaeg.Duration(time.Hour)
+
+	// DefaultWebhookTimeout is the default timeout of the webhook requests
+	DefaultWebhookTimeout = flaeg.Duration(10 * time.Second)
+)
+
+// DefaultThresholds are the default numbers of days before expiration at which an alert is raised
+var DefaultThresholds = Days{30, 14, 7, 1}
+
+// metricsRegistry is a local interface in the watchdog package, exposing only the required metrics
+// necessary for the watchdog package. This makes it easier for the tests.
+type metricsRegistry interface {
+	CertificateDaysToExpiryGauge() metrics.Gauge
+}
+
+// Config holds the certificates expiration watchdog configuration
+type Config struct {
+	CheckInterval flaeg.Duration `description:"Interval between two checks of the certificates expiration" export:"true"`
+	Thresholds    Days           `description:"Numbers of days before expiration at which an alert is raised" export:"true"`
+	Webhook       *Webhook       `description:"Send the alerts to a webhook" export:"true"`
+}
+
+// SetDefaults sets the default values of the watchdog configuration
+func (c *Config) SetDefaults() {
+	if c.CheckInterval <= 0 {
+		c.CheckInterval = DefaultCheckInterval
+	}
+	if len(c.Thresholds) == 0 {
+		c.Thresholds = append(Days{}, DefaultThresholds...)
+	}
+	if c.Webhook != nil && c.Webhook.Timeout <= 0 {
+		c.Webhook.Timeout = DefaultWebhookTimeout
+	}
+}
+
+// Webhook holds the configuration of the webhook receiving the alerts
+type Webhook struct {
+	URL     string         `description:"URL receiving the alerts as JSON POST requests"`
+	Timeout flaeg.Duration `description:"Timeout of the webhook requests" export:"true"`
+}
+
+// Days holds numbers of days
+type Days []int
+
+// Set adds strings elem into the the parser
+// it splits str on "," and ";" and apply Atoi to string
+func (d *Days) Set(str string) error {
+	fargs := func(c rune) bool {
+		return c == ',' || c == ';'
+	}
+	// get function
+	slice := strings.FieldsFunc(str, fargs)
+	for _, day := range slice {
+		value, err := strconv.Atoi(strings.TrimSpace(day))
+		if err != nil {
+			return err
+		}
+		*d = append(*d, value)
+	}
+	return nil
+}
+
+// Get []int
+func (d *Days) Get() interface{} { return *d }
+
+// String return slice in a string
+func (d *Days) String() string { return fmt.Sprintf("%v", *d) }
+
+// SetValue sets []int into the parser
+func (d *Days) SetValue(val interface{}) {
+	*d = val.(Days)
+}
+
+// Certificate is a certificate served by one or several entrypoints
+type Certificate struct {
+	EntryPoints []string
+	Leaf        *x509.Certificate
+	fingerprint string
+}
+
+// Alert is raised when a certificate reaches an expiration threshold, and sent to the webhook
+type Alert struct {
+	CommonName   string    `json:"commonName"`
+	Domains      []string  `json:"domains,omitempty"`
+	SerialNumber string    `json:"serialNumber"`
+	Issuer       string    `json:"issuer"`
+	EntryPoints  []string  `json:"entryPoints"`
+	NotAfter     time.Time `json:"notAfter"`
+	DaysToExpiry int       `json:"daysToExpiry"`
+	Threshold    int       `json:"threshold"`
+	Expired      bool      `json:"expired"`
+}
+
+// Watchdog checks periodically the expiration of the certificates served by the entrypoints
+type Watchdog struct {
+	config   *Config
+	registry metricsRegistry
+	client   *http.Client
+	trigger  chan struct{}
+
+	lock sync.Mutex
+	// lowest threshold already notified per certificate fingerprint
+	notified map[string]int
+}
+
+// New creates a certificates expiration watchdog
+func New(config *Config, registry metricsRegistry) *Watchdog {
+	config.SetDefaults()
+
+	thresholds := append(Days{}, config.Thresholds...)
+	sort.Sort(sort.Reverse(sort.IntSlice(thresholds)))
+	config.Thresholds = thresholds
+
+	w := &Watchdog{
+		config:   config,
+		registry: registry,
+		trigger:  make(chan struct{}, 1),
+		notified: make(map[string]int),
+	}
+
+	if config.Webhook != nil {
+		w.client = &http.Client{Timeout: time.Duration(config.Webhook.Timeout)}
+	}
+	return w
+}
+
+// Run checks the certificates at every check interval, or when a check is triggered, until the context is done
+func (w *Watchdog) Run(ctx context.Context, getCertificates func() []*Certificate) {
+	ticker := time.NewTicker(time.Duration(w.config.CheckInterval))
+	defer ticker.Stop()
+
+	for {
+		w.Check(getCertificates(), time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.trigger:
+		}
+	}
+}
+
+// Trigger asks for a new check of the certificates, without waiting for the next check interval
+func (w *Watchdog) Trigger() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Check exports the expiration of the certificates and returns the alerts raised for the thresholds newly reached
+func (w *Watchdog) Check(certificates []*Certificate, now time.Time) []Alert {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var alerts []Alert
+	seen := make(map[string]struct{})
+	for _, cert := range certificates {
+		seen[cert.fingerprint] = struct{}{}
+
+		remaining := cert.Leaf.NotAfter.Sub(now)
+		w.registry.CertificateDaysToExpiryGauge().
+			With("cn", cert.Leaf.Subject.CommonName, "serial", cert.Leaf.SerialNumber.String()).
+			Set(remaining.Hours() / 24)
+
+		alert, ok := w.newAlert(cert, remaining)
+		if !ok {
+			continue
+		}
+
+		if alert.Expired {
+			logger.Errorf("Certificate %s (serial %s) served by entrypoints %s expired on %s", alert.CommonName, alert.SerialNumber, strings.Join(alert.EntryPoints, ","), alert.NotAfter)
+		} else {
+			logger.Warnf("Certificate %s (serial %s) served by entrypoints %s expires in %d days, on %s", alert.CommonName, alert.SerialNumber, strings.Join(alert.EntryPoints, ","), alert.DaysToExpiry, alert.NotAfter)
+		}
+
+		if previous, exists := w.notified[cert.fingerprint]; exists && previous <= alert.Threshold {
+			continue
+		}
+		w.notified[cert.fingerprint] = alert.Threshold
+		alerts = append(alerts, alert)
+	}
+
+	for fingerprint := range w.notified {
+		if _, ok := seen[fingerprint]; !ok {
+			delete(w.notified, fingerprint)
+		}
+	}
+
+	if w.client != nil && len(alerts) > 0 {
+		if err := w.sendAlerts(alerts); err != nil {
+			logger.Errorf("Unable to send certificates expiration alerts to the webhook: %v", err)
+		}
+	}
+
+	return alerts
+}
+
+// newAlert returns an alert if the certificate reached one of the thresholds, the threshold of an expired certificate is 0
+func (w *Watchdog) newAlert(cert *Certificate, remaining time.Duration) (Alert, bool) {
+	days := int(remaining.Hours() / 24)
+	expired := remaining <= 0
+
+	threshold := -1
+	if expired {
+		threshold = 0
+	} else {
+		// thresholds are sorted in descending order, keep the lowest one reached
+		for _, t := range w.config.Thresholds {
+			if days < t {
+				threshold = t
+			}
+		}
+	}
+
+	if threshold < 0 {
+		return Alert{}, false
+	}
+
+	return Alert{
+		CommonName:   cert.Leaf.Subject.CommonName,
+		Domains:      cert.Leaf.DNSNames,
+		SerialNumber: cert.Leaf.SerialNumber.String(),
+		Issuer:       cert.Leaf.Issuer.CommonName,
+		EntryPoints:  cert.EntryPoints,
+		NotAfter:     cert.Leaf.NotAfter,
+		DaysToExpiry: days,
+		Threshold:    threshold,
+		Expired:      expired,
+	}, true
+}
+
+func (w *Watchdog) sendAlerts(alerts []Alert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := w.client.Post(w.config.Webhook.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SerialsByCommonName returns the serial numbers of the certificates by common name, as labelled in the metrics.
+func SerialsByCommonName(certificates []*Certificate) map[string]map[string]bool {
+	serials := make(map[string]map[string]bool)
+	for _, cert := range certificates {
+		cn := cert.Leaf.Subject.CommonName
+		if serials[cn] == nil {
+			serials[cn] = make(map[string]bool)
+		}
+		serials[cn][cert.Leaf.SerialNumber.String()] = true
+	}
+	return serials
+}
+
+// CertificatesFromStores returns the certificates of the entrypoints certificate stores,
+// a certificate shared by several entrypoints is returned once.
+func CertificatesFromStores(stores map[string]*traefiktls.CertificateStore, exclude func(*x509.Certificate) bool) []*Certificate {
+	certificates := make(map[string]*Certificate)
+
+	add := func(entryPoint string, cert *tls.Certificate) {
+		if cert == nil || len(cert.Certificate) == 0 {
+			return
+		}
+
+		leaf := cert.Leaf
+		if leaf == nil {
+			var err error
+			leaf, err = x509.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				logger.Debugf("Unable to parse certificate of entrypoint %s: %v", entryPoint, err)
+				return
+			}
+		}
+
+		if exclude != nil && exclude(leaf) {
+			return
+		}
+
+		sum := sha256.Sum256(leaf.Raw)
+		fingerprint := hex.EncodeToString(sum[:])
+		if c, ok := certificates[fingerprint]; ok {
+			for _, ep := range c.EntryPoints {
+				if ep == entryPoint {
+					return
+				}
+			}
+			c.EntryPoints = append(c.EntryPoints, entryPoint)
+			return
+		}
+
+		certificates[fingerprint] = &Certificate{
+			EntryPoints: []string{entryPoint},
+			Leaf:        leaf,
+			fingerprint: fingerprint,
+		}
+	}
+
+	entryPoints := make([]string, 0, len(stores))
+	for entryPoint := range stores {
+		entryPoints = append(entryPoints, entryPoint)
+	}
+	sort.Strings(entryPoints)
+
+	for _, entryPoint := range entryPoints {
+		store := stores[entryPoint]
+		if store == nil {
+			continue
+		}
+
+		for _, certs := range []*safe.Safe{store.StaticCerts, store.DynamicCerts} {
+			if certs == nil {
+				continue
+			}
+			certMap, ok := certs.Get().(map[string]*tls.Certificate)
+			if !ok {
+				continue
+			}
+			for _, cert := range certMap {
+				add(entryPoint, cert)
+			}
+		}
+		add(entryPoint, store.GetDefaultCertificate())
+	}
+
+	result := make([]*Certificate, 0, len(certificates))
+	for _, cert := range certificates {
+		result = append(result, cert)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Leaf.NotAfter.Before(result[j].Leaf.NotAfter)
+	})
+	return result
+}
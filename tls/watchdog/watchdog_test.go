@@ -0,0 +1,239 @@
+package watchdog
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg"
+	"github.com/containous/traefik/testhelpers"
+	traefiktls "github.com/containous/traefik/tls"
+	"github.com/containous/traefik/tls/generate"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type collectingMetrics struct {
+	gauge *testhelpers.CollectingGauge
+}
+
+func (m *collectingMetrics) CertificateDaysToExpiryGauge() metrics.Gauge {
+	return m.gauge
+}
+
+func newCertificate(t *testing.T, domain string, expiration time.Time) *tls.Certificate {
+	t.Helper()
+
+	certPEM, keyPEM, err := generate.KeyPair(domain, expiration)
+	require.NoError(t, err)
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	return &cert
+}
+
+func TestCertificatesFromStores(t *testing.T) {
+	now := time.Now()
+	shared := newCertificate(t, "shared.localhost", now.Add(24*time.Hour))
+	dynamic := newCertificate(t, "dynamic.localhost", now.Add(48*time.Hour))
+	acme := newCertificate(t, "acme.localhost", now.Add(time.Hour))
+	defaultCert := newCertificate(t, "default.localhost", now.Add(72*time.Hour))
+
+	httpsStore := traefiktls.NewCertificateStore()
+	httpsStore.StaticCerts.Set(map[string]*tls.Certificate{"shared.localhost": shared})
+	httpsStore.DynamicCerts.Set(map[string]*tls.Certificate{"dynamic.localhost": dynamic, "acme.localhost": acme})
+	httpsStore.DefaultCertificate = defaultCert
+
+	adminStore := traefiktls.NewCertificateStore()
+	adminStore.DynamicCerts.Set(map[string]*tls.Certificate{"shared.localhost": shared})
+
+	stores := map[string]*traefiktls.CertificateStore{
+		"https": httpsStore,
+		"admin": adminStore,
+		"http":  nil,
+	}
+
+	excludeACME := func(cert *x509.Certificate) bool {
+		return cert.DNSNames[0] == "acme.localhost"
+	}
+
+	certificates := CertificatesFromStores(stores, excludeACME)
+	require.Len(t, certificates, 3)
+
+	assert.Equal(t, "shared.localhost", certificates[0].Leaf.DNSNames[0])
+	assert.Equal(t, []string{"admin", "https"}, certificates[0].EntryPoints)
+	assert.Equal(t, "dynamic.localhost", certificates[1].Leaf.DNSNames[0])
+	assert.Equal(t, []string{"https"}, certificates[1].EntryPoints)
+	assert.Equal(t, "default.localhost", certificates[2].Leaf.DNSNames[0])
+	assert.Equal(t, []string{"https"}, certificates[2].EntryPoints)
+}
+
+func TestWatchdogCheck(t *testing.T) {
+	var lock sync.Mutex
+	var received [][]Alert
+	webhook := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var alerts []Alert
+		if err := json.NewDecoder(req.Body).Decode(&alerts); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		lock.Lock()
+		received = append(received, alerts)
+		lock.Unlock()
+	}))
+	defer webhook.Close()
+
+	now := time.Now()
+	expiration := now.Add(20*24*time.Hour + time.Hour)
+	stores := map[string]*traefiktls.CertificateStore{
+		"https": traefiktls.NewCertificateStore(),
+	}
+	stores["https"].StaticCerts.Set(map[string]*tls.Certificate{
+		"manual.localhost": newCertificate(t, "manual.localhost", expiration),
+		"valid.localhost":  newCertificate(t, "valid.localhost", now.Add(365*24*time.Hour)),
+	})
+	certificates := CertificatesFromStores(stores, nil)
+
+	registry := &collectingMetrics{gauge: &testhelpers.CollectingGauge{}}
+	w := New(&Config{
+		Thresholds: Days{7, 30, 14},
+		Webhook:    &Webhook{URL: webhook.URL},
+	}, registry)
+
+	testCases := []struct {
+		desc              string
+		now               time.Time
+		expectedThreshold int
+		expectedExpired   bool
+		expectedAlert     bool
+	}{
+		{
+			desc:              "first threshold reached",
+			now:               now,
+			expectedThreshold: 30,
+			expectedAlert:     true,
+		},
+		{
+			desc: "same threshold is notified once",
+			now:  now.Add(time.Hour),
+		},
+		{
+			desc:              "lower threshold reached",
+			now:               now.Add(10 * 24 * time.Hour),
+			expectedThreshold: 14,
+			expectedAlert:     true,
+		},
+		{
+			desc:              "expired",
+			now:               expiration.Add(time.Minute),
+			expectedThreshold: 0,
+			expectedExpired:   true,
+			expectedAlert:     true,
+		},
+		{
+			desc: "expired is notified once",
+			now:  expiration.Add(time.Hour),
+		},
+	}
+
+	// the checks depend on the previous ones
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			alerts := w.Check(certificates, test.now)
+			if !test.expectedAlert {
+				assert.Empty(t, alerts)
+				return
+			}
+
+			require.Len(t, alerts, 1)
+			assert.Equal(t, []string{"manual.localhost"}, alerts[0].Domains)
+			assert.Equal(t, []string{"https"}, alerts[0].EntryPoints)
+			assert.Equal(t, test.expectedThreshold, alerts[0].Threshold)
+			assert.Equal(t, test.expectedExpired, alerts[0].Expired)
+		})
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, received, 3)
+	assert.Equal(t, 30, received[0][0].Threshold)
+	assert.Equal(t, 20, received[0][0].DaysToExpiry)
+	assert.Equal(t, 14, received[1][0].Threshold)
+	assert.True(t, received[2][0].Expired)
+}
+
+func TestWatchdogCheckMetrics(t *testing.T) {
+	now := time.Now()
+	cert := newCertificate(t, "metrics.localhost", now.Add(36*time.Hour))
+
+	stores := map[string]*traefiktls.CertificateStore{
+		"https": traefiktls.NewCertificateStore(),
+	}
+	stores["https"].DynamicCerts.Set(map[string]*tls.Certificate{"metrics.localhost": cert})
+	certificates := CertificatesFromStores(stores, nil)
+	require.Len(t, certificates, 1)
+
+	registry := &collectingMetrics{gauge: &testhelpers.CollectingGauge{}}
+	w := New(&Config{CheckInterval: flaeg.Duration(time.Minute)}, registry)
+
+	w.Check(certificates, now)
+
+	assert.InDelta(t, 1.5, registry.gauge.GaugeValue, 0.01)
+	assert.Equal(t, []string{"cn", generate.DefaultDomain, "serial", certificates[0].Leaf.SerialNumber.String()}, registry.gauge.LastLabelValues)
+
+	// the series of the certificates no longer served are removed from their labels
+	expected := map[string]map[string]bool{
+		generate.DefaultDomain: {certificates[0].Leaf.SerialNumber.String(): true},
+	}
+	assert.Equal(t, expected, SerialsByCommonName(certificates))
+}
+
+func TestDaysSet(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected Days
+		hasError bool
+	}{
+		{
+			desc:     "comma separated",
+			value:    "30,14,7",
+			expected: Days{30, 14, 7},
+		},
+		{
+			desc:     "semicolon separated",
+			value:    "30;1",
+			expected: Days{30, 1},
+		},
+		{
+			desc:     "invalid",
+			value:    "30,foo",
+			hasError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			days := Days{}
+			err := days.Set(test.value)
+			if test.hasError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, days)
+		})
+	}
+}
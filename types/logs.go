@@ -18,17 +18,58 @@ const (
 
 // TraefikLog holds the configuration settings for the traefik logger.
 type TraefikLog struct {
-	FilePath string `json:"file,omitempty" description:"Traefik log file path. Stdout is used when omitted or empty"`
-	Format   string `json:"format,omitempty" description:"Traefik log format: json | common"`
+	FilePath     string    `json:"file,omitempty" description:"Traefik log file path. Stdout is used when omitted or empty"`
+	Format       string    `json:"format,omitempty" description:"Traefik log format: json | common"`
+	ModuleLevels KeyValues `json:"moduleLevels,omitempty" description:"Log levels of the modules (module=level), e.g. provider/kubernetes=debug"`
 }
 
 // AccessLog holds the configuration settings for the access logger (middlewares/accesslog).
 type AccessLog struct {
 	FilePath      string            `json:"file,omitempty" description:"Access log file path. Stdout is used when omitted or empty" export:"true"`
-	Format        string            `json:"format,omitempty" description:"Access log format: json | common" export:"true"`
+	Format        string            `json:"format,omitempty" description:"Access log format: json | common | logfmt | template" export:"true"`
+	Template      string            `json:"template,omitempty" description:"Access log template used by the template format (Go text/template syntax)" export:"true"`
 	Filters       *AccessLogFilters `json:"filters,omitempty" description:"Access log filters, used to keep only specific access logs" export:"true"`
 	Fields        *AccessLogFields  `json:"fields,omitempty" description:"AccessLogFields" export:"true"`
 	BufferingSize int64             `json:"bufferingSize,omitempty" description:"Number of access log lines to process in a buffered way. Default 0." export:"true"`
+	Sinks         *AccessLogSinks   `json:"sinks,omitempty" description:"Additional outputs of the access logs" export:"true"`
+
+	FrontendFilesDirectory string `json:"frontendFilesDirectory,omitempty" description:"Directory of the access log files of the frontends, the frontends cannot have their own file without it" export:"true"`
+}
+
+// AccessLogSinks holds the configuration of the additional outputs of the access logs
+type AccessLogSinks struct {
+	Syslog *AccessLogSyslog `json:"syslog,omitempty" description:"Send the access logs to a syslog server (RFC5424)" export:"true"`
+	HTTP   *AccessLogHTTP   `json:"http,omitempty" description:"Send the access logs in batches of NDJSON to an HTTP endpoint" export:"true"`
+	TCP    *AccessLogTCP    `json:"tcp,omitempty" description:"Send the access logs as lines to a TCP endpoint" export:"true"`
+}
+
+// AccessLogSyslog holds the configuration of the syslog access log sink
+type AccessLogSyslog struct {
+	Address   string     `json:"address,omitempty" description:"Syslog server address (host:port)"`
+	Protocol  string     `json:"protocol,omitempty" description:"Syslog transport: udp | tcp | tls" export:"true"`
+	TLS       *ClientTLS `json:"tls,omitempty" description:"TLS configuration used with the tls transport" export:"true"`
+	Facility  string     `json:"facility,omitempty" description:"Syslog facility (kern, user, daemon, local0 ... local7)" export:"true"`
+	AppName   string     `json:"appName,omitempty" description:"Syslog application name" export:"true"`
+	QueueSize int        `json:"queueSize,omitempty" description:"Number of access log lines waiting to be sent before the requests are slowed down" export:"true"`
+}
+
+// AccessLogHTTP holds the configuration of the HTTP access log sink
+type AccessLogHTTP struct {
+	URL           string         `json:"url,omitempty" description:"URL receiving the access logs"`
+	Headers       KeyValues      `json:"headers,omitempty" description:"Headers sent with the requests (key=value)"`
+	TLS           *ClientTLS     `json:"tls,omitempty" description:"TLS configuration of the requests" export:"true"`
+	BatchSize     int            `json:"batchSize,omitempty" description:"Maximum number of access log lines sent in a request" export:"true"`
+	FlushInterval parse.Duration `json:"flushInterval,omitempty" description:"Maximum time an access log line waits before being sent" export:"true"`
+	MaxRetries    int            `json:"maxRetries,omitempty" description:"Number of retries of a failed request before the batch is dropped" export:"true"`
+	Timeout       parse.Duration `json:"timeout,omitempty" description:"Timeout of the requests" export:"true"`
+	QueueSize     int            `json:"queueSize,omitempty" description:"Number of access log lines waiting to be sent before the requests are slowed down" export:"true"`
+}
+
+// AccessLogTCP holds the configuration of the TCP access log sink
+type AccessLogTCP struct {
+	Address   string     `json:"address,omitempty" description:"TCP endpoint address (host:port)"`
+	TLS       *ClientTLS `json:"tls,omitempty" description:"Enable TLS support" export:"true"`
+	QueueSize int        `json:"queueSize,omitempty" description:"Number of access log lines waiting to be sent before the requests are slowed down" export:"true"`
 }
 
 // AccessLogFilters holds filters configuration
@@ -36,6 +77,7 @@ type AccessLogFilters struct {
 	StatusCodes   StatusCodes    `json:"statusCodes,omitempty" description:"Keep access logs with status codes in the specified range" export:"true"`
 	RetryAttempts bool           `json:"retryAttempts,omitempty" description:"Keep access logs when at least one retry happened" export:"true"`
 	MinDuration   parse.Duration `json:"duration,omitempty" description:"Keep access logs when request took longer than the specified duration" export:"true"`
+	SampleRate    float64        `json:"sampleRate,omitempty" description:"Keep a random fraction, between 0 and 1, of the access logs" export:"true"`
 }
 
 // FieldHeaders holds configuration for access log headers
@@ -150,22 +192,27 @@ func (f *FieldHeaderNames) SetValue(val interface{}) {
 
 // AccessLogFields holds configuration for access log fields
 type AccessLogFields struct {
-	DefaultMode string        `json:"defaultMode,omitempty" description:"Default mode for fields: keep | drop" export:"true"`
+	DefaultMode string        `json:"defaultMode,omitempty" description:"Default mode for fields: keep | drop | redact" export:"true"`
 	Names       FieldNames    `json:"names,omitempty" description:"Override mode for fields" export:"true"`
 	Headers     *FieldHeaders `json:"headers,omitempty" description:"Headers to keep, drop or redact" export:"true"`
 }
 
 // Keep check if the field need to be kept or dropped
 func (f *AccessLogFields) Keep(field string) bool {
-	defaultKeep := true
+	return f.KeepField(field) != AccessLogDrop
+}
+
+// KeepField checks if the field needs to be kept, dropped or redacted and returns the status
+func (f *AccessLogFields) KeepField(field string) string {
+	defaultValue := AccessLogKeep
 	if f != nil {
-		defaultKeep = checkFieldValue(f.DefaultMode, defaultKeep)
+		defaultValue = checkFieldHeaderValue(f.DefaultMode, defaultValue)
 
 		if v, ok := f.Names[field]; ok {
-			return checkFieldValue(v, defaultKeep)
+			return checkFieldHeaderValue(v, defaultValue)
 		}
 	}
-	return defaultKeep
+	return defaultValue
 }
 
 // KeepHeader checks if the headers need to be kept, dropped or redacted and returns the status
@@ -181,17 +228,6 @@ func (f *AccessLogFields) KeepHeader(header string) string {
 	return defaultValue
 }
 
-func checkFieldValue(value string, defaultKeep bool) bool {
-	switch value {
-	case AccessLogKeep:
-		return true
-	case AccessLogDrop:
-		return false
-	default:
-		return defaultKeep
-	}
-}
-
 func checkFieldHeaderValue(value string, defaultValue string) string {
 	if value == AccessLogKeep || value == AccessLogDrop || value == AccessLogRedact {
 		return value
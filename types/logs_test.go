@@ -417,3 +417,58 @@ func TestFieldsHeadersNamesSetValue(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessLogFieldsKeepField(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		fields   *AccessLogFields
+		field    string
+		expected string
+		keep     bool
+	}{
+		{
+			desc:     "nil fields",
+			field:    "ClientHost",
+			expected: AccessLogKeep,
+			keep:     true,
+		},
+		{
+			desc:     "default mode",
+			fields:   &AccessLogFields{DefaultMode: AccessLogDrop},
+			field:    "ClientHost",
+			expected: AccessLogDrop,
+			keep:     false,
+		},
+		{
+			desc:     "redacted by default",
+			fields:   &AccessLogFields{DefaultMode: AccessLogRedact},
+			field:    "ClientHost",
+			expected: AccessLogRedact,
+			keep:     true,
+		},
+		{
+			desc:     "redacted by name",
+			fields:   &AccessLogFields{DefaultMode: AccessLogDrop, Names: FieldNames{"ClientHost": AccessLogRedact}},
+			field:    "ClientHost",
+			expected: AccessLogRedact,
+			keep:     true,
+		},
+		{
+			desc:     "unknown mode",
+			fields:   &AccessLogFields{DefaultMode: AccessLogDrop, Names: FieldNames{"ClientHost": "foo"}},
+			field:    "ClientHost",
+			expected: AccessLogDrop,
+			keep:     false,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.fields.KeepField(test.field))
+			assert.Equal(t, test.keep, test.fields.Keep(test.field))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -20,6 +21,8 @@ import (
 	"github.com/ryanuber/go-glob"
 )
 
+var logger = log.WithModule("types")
+
 // Backend holds backend configuration.
 type Backend struct {
 	Servers            map[string]Server   `json:"servers,omitempty"`
@@ -29,6 +32,17 @@ type Backend struct {
 	HealthCheck        *HealthCheck        `json:"healthCheck,omitempty"`
 	Buffering          *Buffering          `json:"buffering,omitempty"`
 	ResponseForwarding *ResponseForwarding `json:"forwardingResponse,omitempty"`
+	TLS                *BackendTLS         `json:"tls,omitempty"`
+}
+
+// BackendTLS holds the TLS configuration used to connect to the servers of a backend.
+// CA, Cert and Key hold PEM contents.
+// When TrustedURIs is set, the servers are identified by the URI SANs of their certificates rather than by their host names.
+type BackendTLS struct {
+	CA          string   `json:"ca,omitempty"`
+	Cert        string   `json:"cert,omitempty"`
+	Key         string   `json:"-"`
+	TrustedURIs []string `json:"trustedURIs,omitempty"`
 }
 
 // ResponseForwarding holds configuration for the forward of the response
@@ -128,6 +142,7 @@ type RateLimit struct {
 // Headers holds the custom header configuration
 type Headers struct {
 	CustomRequestHeaders  map[string]string `json:"customRequestHeaders,omitempty"`
+	AppendRequestHeaders  map[string]string `json:"appendRequestHeaders,omitempty"`
 	CustomResponseHeaders map[string]string `json:"customResponseHeaders,omitempty"`
 
 	AllowedHosts            []string          `json:"allowedHosts,omitempty"`
@@ -155,7 +170,8 @@ type Headers struct {
 // HasCustomHeadersDefined checks to see if any of the custom header elements have been set
 func (h *Headers) HasCustomHeadersDefined() bool {
 	return h != nil && (len(h.CustomResponseHeaders) != 0 ||
-		len(h.CustomRequestHeaders) != 0)
+		len(h.CustomRequestHeaders) != 0 ||
+		len(h.AppendRequestHeaders) != 0)
 }
 
 // HasSecureHeadersDefined checks to see if any of the secure header elements have been set
@@ -199,6 +215,33 @@ type Frontend struct {
 	RateLimit            *RateLimit            `json:"ratelimit,omitempty"`
 	Redirect             *Redirect             `json:"redirect,omitempty"`
 	Auth                 *Auth                 `json:"auth,omitempty"`
+	AccessLog            *FrontendAccessLog    `json:"accessLog,omitempty" hash:"ignore"`
+}
+
+// FrontendAccessLog holds the access log settings of a frontend
+type FrontendAccessLog struct {
+	Disabled   bool    `json:"disabled,omitempty"`
+	Verbose    bool    `json:"verbose,omitempty"`
+	SampleRate float64 `json:"sampleRate,omitempty"`
+	FilePath   string  `json:"filePath,omitempty"`
+}
+
+// CheckFrontendAccessLogFilePath checks that the access log file of a frontend is a name relative to the frontend files directory.
+func CheckFrontendAccessLogFilePath(filePath string) error {
+	if filepath.IsAbs(filePath) || strings.HasPrefix(filePath, "/") || strings.HasPrefix(filePath, `\`) {
+		return fmt.Errorf("the access log file %q of a frontend must be relative to the frontend files directory", filePath)
+	}
+
+	for _, element := range strings.FieldsFunc(filePath, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if element == ".." {
+			return fmt.Errorf("the access log file %q of a frontend must not contain '..'", filePath)
+		}
+	}
+
+	if filepath.Clean(filePath) == "." {
+		return fmt.Errorf("the access log file %q of a frontend is not a file name", filePath)
+	}
+	return nil
 }
 
 // Hash returns the hash value of a Frontend struct.
@@ -218,6 +261,8 @@ type Redirect struct {
 	Regex       string `json:"regex,omitempty"`
 	Replacement string `json:"replacement,omitempty"`
 	Permanent   bool   `json:"permanent,omitempty"`
+	// Exemptions are path prefixes or frontend rules of the requests which are not redirected
+	Exemptions []string `json:"exemptions,omitempty"`
 }
 
 // LoadBalancerMethod holds the method of load balancing to use.
@@ -259,9 +304,11 @@ type Configurations map[string]*Configuration
 
 // Configuration of a provider.
 type Configuration struct {
-	Backends  map[string]*Backend         `json:"backends,omitempty"`
-	Frontends map[string]*Frontend        `json:"frontends,omitempty"`
-	TLS       []*traefiktls.Configuration `json:"-"`
+	Backends   map[string]*Backend                 `json:"backends,omitempty"`
+	Frontends  map[string]*Frontend                `json:"frontends,omitempty"`
+	TLS        []*traefiktls.Configuration         `json:"-"`
+	ClientCAs  []*traefiktls.ClientCAConfiguration `json:"-"`
+	TLSOptions []*traefiktls.DomainsOptions        `json:"-"`
 }
 
 // ConfigMessage hold configuration information exchanged between parts of traefik.
@@ -435,15 +482,23 @@ func CanonicalDomain(domain string) string {
 
 // Statistics provides options for monitoring request and response stats
 type Statistics struct {
-	RecentErrors int `description:"Number of recent errors logged" export:"true"`
+	RecentErrors   int `description:"Number of recent errors logged" export:"true"`
+	RecentRequests int `description:"Number of recent requests kept per frontend, enables the requests inspection API" export:"true"`
 }
 
 // Metrics provides options to expose and send Traefik metrics to different third party monitoring systems
 type Metrics struct {
-	Prometheus *Prometheus `description:"Prometheus metrics exporter type" export:"true"`
-	Datadog    *Datadog    `description:"DataDog metrics exporter type" export:"true"`
-	StatsD     *Statsd     `description:"StatsD metrics exporter type" export:"true"`
-	InfluxDB   *InfluxDB   `description:"InfluxDB metrics exporter type"`
+	Prometheus *Prometheus    `description:"Prometheus metrics exporter type" export:"true"`
+	Datadog    *Datadog       `description:"DataDog metrics exporter type" export:"true"`
+	StatsD     *Statsd        `description:"StatsD metrics exporter type" export:"true"`
+	InfluxDB   *InfluxDB      `description:"InfluxDB metrics exporter type"`
+	OTLP       *OTLPMetrics   `description:"OpenTelemetry (OTLP) metrics exporter type" export:"true"`
+	Servers    *ServerMetrics `description:"Enable per server metrics" export:"true"`
+}
+
+// ServerMetrics contains the configuration of the per server metrics
+type ServerMetrics struct {
+	MaxSeries int `description:"Maximum number of backend servers for which metrics are recorded" export:"true"`
 }
 
 // Prometheus can contain specific configuration used by the Prometheus Metrics exporter
@@ -473,6 +528,64 @@ type InfluxDB struct {
 	RetentionPolicy string `description:"InfluxDB retention policy used when protocol is http" export:"true"`
 }
 
+// OTLP contains the configuration of the export to an OpenTelemetry collector
+type OTLP struct {
+	Protocol           string         `description:"OTLP protocol (grpc or http)" export:"true"`
+	Endpoint           string         `description:"OTLP endpoint: host:port for grpc, base URL for http"`
+	Insecure           bool           `description:"Disable TLS for the grpc protocol" export:"true"`
+	TLS                *ClientTLS     `description:"Enable TLS support" export:"true"`
+	Headers            KeyValues      `description:"Headers sent with the export requests (key=value)"`
+	ResourceAttributes KeyValues      `description:"Resource attributes of the exported data (key=value)" export:"true"`
+	Timeout            flaeg.Duration `description:"Timeout of the export requests" export:"true"`
+}
+
+// OTLPMetrics contains the OTLP exporter and metrics pushing interval configuration
+type OTLPMetrics struct {
+	OTLP         `mapstructure:",squash" export:"true"`
+	PushInterval string  `description:"OTLP push interval" export:"true"`
+	Buckets      Buckets `description:"Buckets for latency metrics" export:"true"`
+}
+
+// KeyValues holds a map of key/value pairs
+type KeyValues map[string]string
+
+// String is the method to format the flag's value, part of the flag.Value interface.
+// The String method's output will be used in diagnostics.
+func (k *KeyValues) String() string {
+	return fmt.Sprintf("%+v", *k)
+}
+
+// Get return the KeyValues map
+func (k *KeyValues) Get() interface{} {
+	return *k
+}
+
+// Set is the method to set the flag value, part of the flag.Value interface.
+// Set's argument is a string to be parsed to set the flag.
+// It's a space-separated list of key=value, so we split it.
+func (k *KeyValues) Set(value string) error {
+	value = strings.Trim(value, "\"")
+
+	if *k == nil {
+		*k = make(KeyValues)
+	}
+
+	for _, field := range strings.Fields(value) {
+		n := strings.SplitN(field, "=", 2)
+		if len(n) != 2 || len(n[0]) == 0 {
+			return fmt.Errorf("invalid key/value pair %q, expected key=value", field)
+		}
+		(*k)[n[0]] = n[1]
+	}
+
+	return nil
+}
+
+// SetValue sets the KeyValues map with val
+func (k *KeyValues) SetValue(val interface{}) {
+	*k = val.(KeyValues)
+}
+
 // Buckets holds Prometheus Buckets
 type Buckets []float64
 
@@ -519,7 +632,7 @@ type ClientTLS struct {
 func (clientTLS *ClientTLS) CreateTLSConfig() (*tls.Config, error) {
 	var err error
 	if clientTLS == nil {
-		log.Warnf("clientTLS is nil")
+		logger.Warnf("clientTLS is nil")
 		return nil, nil
 	}
 	caPool := x509.NewCertPool()
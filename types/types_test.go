@@ -136,3 +136,50 @@ func TestHTTPCodeRanges_Contains(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyValuesSet(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		value       string
+		expected    KeyValues
+		expectedErr bool
+	}{
+		{
+			desc:     "one pair",
+			value:    "deployment.environment=production",
+			expected: KeyValues{"deployment.environment": "production"},
+		},
+		{
+			desc:     "pairs separated by spaces",
+			value:    `"Authorization=Bearer=token x-tenant=traefik"`,
+			expected: KeyValues{"Authorization": "Bearer=token", "x-tenant": "traefik"},
+		},
+		{
+			desc:        "missing value separator",
+			value:       "production",
+			expectedErr: true,
+		},
+		{
+			desc:        "empty key",
+			value:       "=production",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var keyValues KeyValues
+			err := keyValues.Set(test.value)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, keyValues)
+		})
+	}
+}
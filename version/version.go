@@ -12,6 +12,8 @@ import (
 	"github.com/unrolled/render"
 )
 
+var logger = log.WithModule("version")
+
 var (
 	// Version holds the current version of traefik.
 	Version = "dev"
@@ -53,31 +55,31 @@ func CheckNewVersion() {
 	client := github.NewClient(nil)
 	updateURL, err := url.Parse("https://update.traefik.io")
 	if err != nil {
-		log.Warnf("Error checking new version: %s", err)
+		logger.Warnf("Error checking new version: %s", err)
 		return
 	}
 	client.BaseURL = updateURL
 	releases, resp, err := client.Repositories.ListReleases(context.Background(), "containous", "traefik", nil)
 	if err != nil {
-		log.Warnf("Error checking new version: %s", err)
+		logger.Warnf("Error checking new version: %s", err)
 		return
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		log.Warnf("Error checking new version: status=%s", resp.Status)
+		logger.Warnf("Error checking new version: status=%s", resp.Status)
 		return
 	}
 
 	currentVersion, err := goversion.NewVersion(Version)
 	if err != nil {
-		log.Warnf("Error checking new version: %s", err)
+		logger.Warnf("Error checking new version: %s", err)
 		return
 	}
 
 	for _, release := range releases {
 		releaseVersion, err := goversion.NewVersion(*release.TagName)
 		if err != nil {
-			log.Warnf("Error checking new version: %s", err)
+			logger.Warnf("Error checking new version: %s", err)
 			return
 		}
 
@@ -86,7 +88,7 @@ func CheckNewVersion() {
 		}
 
 		if releaseVersion.GreaterThan(currentVersion) {
-			log.Warnf("A new release has been found: %s. Please consider updating.", releaseVersion.String())
+			logger.Warnf("A new release has been found: %s. Please consider updating.", releaseVersion.String())
 			return
 		}
 	}